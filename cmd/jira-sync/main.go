@@ -25,6 +25,6 @@ func main() {
 	// Initialize and execute CLI
 	if err := cli.Execute(buildInfo); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
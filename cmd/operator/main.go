@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"os"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -32,6 +33,12 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var apiServerHost string
+	var maxConcurrentSyncs int
+	var watchNamespaces string
+	var maintenanceMode bool
+	var maintenanceConfigMap string
+	var healthCheckInterval time.Duration
+	var healthCheckEndpoints string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -40,6 +47,23 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&apiServerHost, "api-server-host", "http://jira-sync-api:8080",
 		"The address of the v0.4.0 API server for job triggering.")
+	flag.IntVar(&maxConcurrentSyncs, "max-concurrent-syncs", 0,
+		"Maximum number of JIRASync operations running at once across the cluster. "+
+			"Additional syncs stay Pending with a Throttled condition. 0 means unlimited.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma-separated namespaces to watch, so the operator only needs namespaced RBAC. "+
+			"Empty watches all namespaces.")
+	flag.BoolVar(&maintenanceMode, "maintenance-mode", false,
+		"Start in API server maintenance mode: syncs are held with a MaintenanceMode condition "+
+			"instead of calling the API server.")
+	flag.StringVar(&maintenanceConfigMap, "maintenance-configmap", "",
+		"ConfigMap (namespace/name) whose presence switches maintenance mode on until it is deleted "+
+			"or its 'enabled' key is set to false. Empty disables the ConfigMap switch.")
+	flag.DurationVar(&healthCheckInterval, "health-check-interval", operatorcontrollers.DefaultHealthCheckInterval,
+		"How often the API server health check runs, and how often syncs held by an unreachable API server re-check.")
+	flag.StringVar(&healthCheckEndpoints, "health-check-endpoints", "",
+		"Comma-separated additional API server URLs to health check in parallel with --api-server-host. "+
+			"Each is reported in the jirasync_api_health_status metric; only --api-server-host holds syncs.")
 
 	opts := zap.Options{
 		Development: true,
@@ -49,8 +73,15 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	namespaces, err := operatorcontrollers.ParseWatchNamespaces(watchNamespaces)
+	if err != nil {
+		setupLog.Error(err, "invalid watch-namespaces")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  operatorcontrollers.WatchNamespacesCacheOptions(namespaces),
 		Metrics: server.Options{
 			BindAddress: metricsAddr,
 		},
@@ -77,6 +108,29 @@ func main() {
 
 	// Setup JIRASync controller
 	jiraSyncReconciler := operatorcontrollers.NewJIRASyncReconciler(mgr, apiServerHost)
+	if maxConcurrentSyncs < 0 {
+		setupLog.Error(nil, "max-concurrent-syncs must be non-negative", "value", maxConcurrentSyncs)
+		os.Exit(1)
+	}
+	jiraSyncReconciler.Throttle = operatorcontrollers.NewSyncThrottle(maxConcurrentSyncs)
+	// Read the maintenance ConfigMap directly, so namespace-scoped caches need not include it
+	jiraSyncReconciler.Maintenance, err = operatorcontrollers.NewMaintenanceMode(mgr.GetAPIReader(), maintenanceMode, maintenanceConfigMap)
+	if err != nil {
+		setupLog.Error(err, "invalid maintenance-configmap")
+		os.Exit(1)
+	}
+	if healthCheckInterval < operatorcontrollers.MinHealthCheckInterval {
+		setupLog.Error(nil, "health-check-interval is too short", "value", healthCheckInterval,
+			"minimum", operatorcontrollers.MinHealthCheckInterval)
+		os.Exit(1)
+	}
+	jiraSyncReconciler.HealthCheckInterval = healthCheckInterval
+	jiraSyncReconciler.HealthCheckEndpoints, err = operatorcontrollers.NewHealthCheckEndpoints(
+		healthCheckEndpoints, 30*time.Second, ctrl.Log.WithName("health-check"))
+	if err != nil {
+		setupLog.Error(err, "invalid health-check-endpoints")
+		os.Exit(1)
+	}
 	if err = jiraSyncReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "JIRASync")
 		os.Exit(1)
@@ -102,6 +156,12 @@ func main() {
 		"probeAddr", probeAddr,
 		"leaderElection", enableLeaderElection,
 		"apiServerHost", apiServerHost,
+		"maxConcurrentSyncs", maxConcurrentSyncs,
+		"watchNamespaces", namespaces,
+		"maintenanceMode", maintenanceMode,
+		"maintenanceConfigMap", maintenanceConfigMap,
+		"healthCheckInterval", healthCheckInterval,
+		"healthCheckEndpoints", healthCheckEndpoints,
 	)
 
 	if err := mgr.Start(ctx); err != nil {
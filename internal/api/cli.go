@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/chambrid/jira-cdc-git/pkg/client"
 	"github.com/chambrid/jira-cdc-git/pkg/jobs"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/rest"
@@ -19,6 +20,7 @@ var buildInfo BuildInfo
 // Execute starts the API server CLI
 func Execute(info BuildInfo) error {
 	buildInfo = info
+	client.Version = info.Version
 	return rootCmd.Execute()
 }
 
@@ -76,7 +78,10 @@ Examples:
   api-server serve --enable-jobs --namespace=jira-sync
   
   # Development mode with verbose logging
-  api-server serve --log-level=debug --enable-cors`,
+  api-server serve --log-level=debug --enable-cors
+
+  # Batch JIRA webhooks into one sync per minute (or per 100 issues)
+  api-server serve --webhook-repo=/workspace/repo --webhook-flush-interval=1m --webhook-max-batch=100`,
 	RunE: runServe,
 }
 
@@ -168,6 +173,25 @@ func loadServerConfig(cmd *cobra.Command) (*Config, error) {
 		config.RateLimitPerMinute = rateLimit
 	}
 
+	if cmd.Flags().Changed("webhook-repo") {
+		config.WebhookRepository, _ = cmd.Flags().GetString("webhook-repo")
+	}
+
+	if cmd.Flags().Changed("webhook-flush-interval") {
+		config.WebhookFlushInterval, _ = cmd.Flags().GetDuration("webhook-flush-interval")
+	}
+
+	if cmd.Flags().Changed("webhook-max-batch") {
+		config.WebhookMaxBatch, _ = cmd.Flags().GetInt("webhook-max-batch")
+	}
+
+	if config.WebhookFlushInterval <= 0 {
+		return nil, fmt.Errorf("--webhook-flush-interval must be positive, got %v", config.WebhookFlushInterval)
+	}
+	if config.WebhookMaxBatch < 1 {
+		return nil, fmt.Errorf("--webhook-max-batch must be at least 1, got %d", config.WebhookMaxBatch)
+	}
+
 	// Override with environment variables
 	if port := os.Getenv("API_PORT"); port != "" {
 		if p, err := parseIntParam(port, "API_PORT", config.Port); err == nil {
@@ -465,6 +489,9 @@ func init() {
 	serveCmd.Flags().Bool("enable-auth", false, "Enable authentication (disabled in v0.4.0)")
 	serveCmd.Flags().Bool("enable-cors", true, "Enable CORS")
 	serveCmd.Flags().Int("rate-limit", 100, "Rate limit per minute")
+	serveCmd.Flags().String("webhook-repo", "", "Repository that JIRA webhooks (POST /api/v1/webhooks/jira) sync into; enables webhook batching")
+	serveCmd.Flags().Duration("webhook-flush-interval", DefaultWebhookFlushInterval, "How long webhook updates are collected before they are synced as one batch")
+	serveCmd.Flags().Int("webhook-max-batch", DefaultWebhookMaxBatch, "Distinct issues that trigger a batch sync before the flush interval ends")
 
 	// Job scheduling flags
 	serveCmd.Flags().Bool("enable-jobs", false, "Enable Kubernetes job scheduling")
@@ -13,34 +13,46 @@ import (
 
 // SingleSyncRequest represents a single issue sync request
 type SingleSyncRequest struct {
-	IssueKey   string                        `json:"issue_key" validate:"required"`
-	Repository string                        `json:"repository" validate:"required"`
-	Options    *SyncOptions                  `json:"options,omitempty"`
-	Resources  *jobs.JobResourceRequirements `json:"resources,omitempty"`
-	SafeMode   bool                          `json:"safe_mode,omitempty"`
-	Async      bool                          `json:"async,omitempty"`
+	IssueKey           string                        `json:"issue_key" validate:"required"`
+	Repository         string                        `json:"repository" validate:"required"`
+	Options            *SyncOptions                  `json:"options,omitempty"`
+	Resources          *jobs.JobResourceRequirements `json:"resources,omitempty"`
+	SafeMode           bool                          `json:"safe_mode,omitempty"`
+	Async              bool                          `json:"async,omitempty"`
+	JobLabels          map[string]string             `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string             `json:"job_annotations,omitempty"`
+	GitCredentials     *jobs.GitCredentials          `json:"git_credentials,omitempty"`
+	CheckpointInterval int                           `json:"checkpoint_interval,omitempty"`
 }
 
 // BatchSyncRequest represents a batch issue sync request
 type BatchSyncRequest struct {
-	IssueKeys   []string                      `json:"issue_keys" validate:"required,min=1"`
-	Repository  string                        `json:"repository" validate:"required"`
-	Options     *SyncOptions                  `json:"options,omitempty"`
-	Resources   *jobs.JobResourceRequirements `json:"resources,omitempty"`
-	Parallelism int                           `json:"parallelism,omitempty"`
-	SafeMode    bool                          `json:"safe_mode,omitempty"`
-	Async       bool                          `json:"async,omitempty"`
+	IssueKeys          []string                      `json:"issue_keys" validate:"required,min=1"`
+	Repository         string                        `json:"repository" validate:"required"`
+	Options            *SyncOptions                  `json:"options,omitempty"`
+	Resources          *jobs.JobResourceRequirements `json:"resources,omitempty"`
+	Parallelism        int                           `json:"parallelism,omitempty"`
+	SafeMode           bool                          `json:"safe_mode,omitempty"`
+	Async              bool                          `json:"async,omitempty"`
+	JobLabels          map[string]string             `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string             `json:"job_annotations,omitempty"`
+	GitCredentials     *jobs.GitCredentials          `json:"git_credentials,omitempty"`
+	CheckpointInterval int                           `json:"checkpoint_interval,omitempty"`
 }
 
 // JQLSyncRequest represents a JQL query-based sync request
 type JQLSyncRequest struct {
-	JQL         string                        `json:"jql" validate:"required"`
-	Repository  string                        `json:"repository" validate:"required"`
-	Options     *SyncOptions                  `json:"options,omitempty"`
-	Resources   *jobs.JobResourceRequirements `json:"resources,omitempty"`
-	Parallelism int                           `json:"parallelism,omitempty"`
-	SafeMode    bool                          `json:"safe_mode,omitempty"`
-	Async       bool                          `json:"async,omitempty"`
+	JQL                string                        `json:"jql" validate:"required"`
+	Repository         string                        `json:"repository" validate:"required"`
+	Options            *SyncOptions                  `json:"options,omitempty"`
+	Resources          *jobs.JobResourceRequirements `json:"resources,omitempty"`
+	Parallelism        int                           `json:"parallelism,omitempty"`
+	SafeMode           bool                          `json:"safe_mode,omitempty"`
+	Async              bool                          `json:"async,omitempty"`
+	JobLabels          map[string]string             `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string             `json:"job_annotations,omitempty"`
+	GitCredentials     *jobs.GitCredentials          `json:"git_credentials,omitempty"`
+	CheckpointInterval int                           `json:"checkpoint_interval,omitempty"`
 }
 
 // SyncOptions represents sync operation options
@@ -177,6 +189,10 @@ func (s *Server) validateSingleSyncRequest(req *SingleSyncRequest) error {
 		return fmt.Errorf("invalid issue key format: %s", req.IssueKey)
 	}
 
+	if req.CheckpointInterval < 0 {
+		return fmt.Errorf("checkpoint_interval must not be negative")
+	}
+
 	return s.validateSyncOptions(req.Options)
 }
 
@@ -201,6 +217,10 @@ func (s *Server) validateBatchSyncRequest(req *BatchSyncRequest) error {
 		return fmt.Errorf("parallelism must be between 0 and 10")
 	}
 
+	if req.CheckpointInterval < 0 {
+		return fmt.Errorf("checkpoint_interval must not be negative")
+	}
+
 	return s.validateSyncOptions(req.Options)
 }
 
@@ -223,6 +243,10 @@ func (s *Server) validateJQLSyncRequest(req *JQLSyncRequest) error {
 		return fmt.Errorf("parallelism must be between 0 and 10")
 	}
 
+	if req.CheckpointInterval < 0 {
+		return fmt.Errorf("checkpoint_interval must not be negative")
+	}
+
 	return s.validateSyncOptions(req.Options)
 }
 
@@ -266,9 +290,14 @@ func isValidIssueKey(issueKey string) bool {
 func (s *Server) createAsyncSingleSync(ctx context.Context, req *SingleSyncRequest) (*SyncResponse, error) {
 	// Create job request
 	jobRequest := &jobs.SingleIssueSyncRequest{
-		IssueKey:   req.IssueKey,
-		Repository: req.Repository,
-		SafeMode:   req.SafeMode,
+		IssueKey:       req.IssueKey,
+		Repository:     req.Repository,
+		SafeMode:       req.SafeMode,
+		JobLabels:      req.JobLabels,
+		JobAnnotations: req.JobAnnotations,
+		GitCredentials: req.GitCredentials,
+
+		CheckpointInterval: req.CheckpointInterval,
 	}
 
 	// Apply options
@@ -304,9 +333,14 @@ func (s *Server) createAsyncSingleSync(ctx context.Context, req *SingleSyncReque
 func (s *Server) createAsyncBatchSync(ctx context.Context, req *BatchSyncRequest) (*SyncResponse, error) {
 	// Create job request
 	jobRequest := &jobs.BatchSyncRequest{
-		IssueKeys:  req.IssueKeys,
-		Repository: req.Repository,
-		SafeMode:   req.SafeMode,
+		IssueKeys:      req.IssueKeys,
+		Repository:     req.Repository,
+		SafeMode:       req.SafeMode,
+		JobLabels:      req.JobLabels,
+		JobAnnotations: req.JobAnnotations,
+		GitCredentials: req.GitCredentials,
+
+		CheckpointInterval: req.CheckpointInterval,
 	}
 
 	// Convert parallelism from int to *int32
@@ -351,9 +385,14 @@ func (s *Server) createAsyncBatchSync(ctx context.Context, req *BatchSyncRequest
 func (s *Server) createAsyncJQLSync(ctx context.Context, req *JQLSyncRequest) (*SyncResponse, error) {
 	// Create job request
 	jobRequest := &jobs.JQLSyncRequest{
-		JQL:        req.JQL,
-		Repository: req.Repository,
-		SafeMode:   req.SafeMode,
+		JQL:            req.JQL,
+		Repository:     req.Repository,
+		SafeMode:       req.SafeMode,
+		JobLabels:      req.JobLabels,
+		JobAnnotations: req.JobAnnotations,
+		GitCredentials: req.GitCredentials,
+
+		CheckpointInterval: req.CheckpointInterval,
 	}
 
 	// Convert parallelism from int to *int32
@@ -315,6 +315,26 @@ func (s *Server) getAPIEndpointDocs() []EndpointDoc {
 				"400": {Description: "Invalid request", Schema: "ErrorResponse"},
 			},
 		},
+		{
+			Method:      "POST",
+			Path:        "/api/v1/webhooks/jira",
+			Summary:     "JIRA issue webhook",
+			Description: "Queue the issue of a JIRA issue_created or issue_updated webhook; queued issues are synced into the webhook repository as one batch per flush interval",
+			RequestBody: &RequestBodyDoc{
+				Required:    true,
+				ContentType: "application/json",
+				Schema:      "JIRAWebhookEvent",
+				Example: map[string]interface{}{
+					"webhookEvent": "jira:issue_updated",
+					"issue":        map[string]interface{}{"key": "PROJ-123"},
+				},
+			},
+			Responses: map[string]ResponseDoc{
+				"202": {Description: "Issue queued, already queued, or event ignored", Schema: "WebhookResponse"},
+				"400": {Description: "Invalid payload", Schema: "ErrorResponse"},
+				"503": {Description: "Webhook batching is not configured", Schema: "ErrorResponse"},
+			},
+		},
 		{
 			Method:      "GET",
 			Path:        "/api/v1/jobs",
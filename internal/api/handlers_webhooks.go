@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/chambrid/jira-cdc-git/pkg/jobs"
+)
+
+// queuedWebhookEvents are the JIRA webhook events that queue their issue for the next batch
+// Deletions are left to the sync's own deletion handling, which needs the full query.
+var queuedWebhookEvents = map[string]bool{
+	"jira:issue_created": true,
+	"jira:issue_updated": true,
+}
+
+// JIRAWebhookEvent is the part of a JIRA issue webhook payload the queue reads
+type JIRAWebhookEvent struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        *struct {
+		Key string `json:"key"`
+	} `json:"issue"`
+}
+
+// WebhookResponse reports what happened to a webhook
+type WebhookResponse struct {
+	Status   string             `json:"status"` // queued, duplicate, or ignored
+	IssueKey string             `json:"issue_key,omitempty"`
+	Queue    WebhookQueueStatus `json:"queue"`
+}
+
+// handleJIRAWebhook queues the issue of a JIRA issue webhook for the next batch sync
+func (s *Server) handleJIRAWebhook(w http.ResponseWriter, r *http.Request) {
+	if s.webhooks == nil {
+		s.writeError(w, http.StatusServiceUnavailable, "WEBHOOKS_DISABLED", "Webhook batching is not configured", "start the server with --webhook-repo to accept JIRA webhooks")
+		return
+	}
+
+	var event JIRAWebhookEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		s.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid JSON webhook payload", err.Error())
+		return
+	}
+
+	if !queuedWebhookEvents[event.WebhookEvent] {
+		s.writeJSON(w, http.StatusAccepted, &WebhookResponse{Status: "ignored", Queue: s.webhooks.Status()})
+		return
+	}
+	if event.Issue == nil || !isValidIssueKey(event.Issue.Key) {
+		s.writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Webhook payload has no valid issue key", "")
+		return
+	}
+
+	queued, duplicate := s.webhooks.Add(event.Issue.Key)
+	if !queued {
+		s.writeError(w, http.StatusServiceUnavailable, "SHUTTING_DOWN", "Server is shutting down", "")
+		return
+	}
+	status := "queued"
+	if duplicate {
+		status = "duplicate"
+	}
+	s.writeJSON(w, http.StatusAccepted, &WebhookResponse{Status: status, IssueKey: event.Issue.Key, Queue: s.webhooks.Status()})
+}
+
+// submitWebhookBatch syncs a batch of webhook issue keys as one batch sync job
+func (s *Server) submitWebhookBatch(ctx context.Context, issueKeys []string) {
+	result, err := s.jobManager.SubmitBatchSync(ctx, &jobs.BatchSyncRequest{
+		IssueKeys:  issueKeys,
+		Repository: s.config.WebhookRepository,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to submit batch sync for %d webhook update(s): %v", len(issueKeys), err)
+		return
+	}
+	log.Printf("🪝 Submitted batch sync job %s for %d webhook update(s)", result.JobID, len(issueKeys))
+}
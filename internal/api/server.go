@@ -75,6 +75,13 @@ type Config struct {
 	LogLevel             string        `json:"log_level"`
 	EnableCORS           bool          `json:"enable_cors"`
 	AllowedOrigins       []string      `json:"allowed_origins"`
+
+	// Webhook batching: issue webhooks are collected and synced into WebhookRepository as one
+	// batch per WebhookFlushInterval, or once WebhookMaxBatch issues are pending (disabled when
+	// WebhookRepository is empty)
+	WebhookRepository    string        `json:"webhook_repository,omitempty"`
+	WebhookFlushInterval time.Duration `json:"webhook_flush_interval"`
+	WebhookMaxBatch      int           `json:"webhook_max_batch"`
 }
 
 // DefaultConfig returns default API server configuration
@@ -91,6 +98,8 @@ func DefaultConfig() *Config {
 		LogLevel:             "INFO",
 		EnableCORS:           true,
 		AllowedOrigins:       []string{"*"}, // Will be restricted in production
+		WebhookFlushInterval: DefaultWebhookFlushInterval,
+		WebhookMaxBatch:      DefaultWebhookMaxBatch,
 	}
 }
 
@@ -100,15 +109,20 @@ type Server struct {
 	buildInfo  BuildInfo
 	jobManager jobs.JobManager
 	httpServer *http.Server
+	webhooks   *webhookQueue // nil when webhook batching is disabled
 }
 
 // NewServer creates a new API server instance
 func NewServer(config *Config, buildInfo BuildInfo, jobManager jobs.JobManager) *Server {
-	return &Server{
+	s := &Server{
 		config:     config,
 		buildInfo:  buildInfo,
 		jobManager: jobManager,
 	}
+	if config.WebhookRepository != "" {
+		s.webhooks = newWebhookQueue(config.WebhookFlushInterval, config.WebhookMaxBatch, s.submitWebhookBatch)
+	}
+	return s
 }
 
 // Start starts the API server
@@ -136,7 +150,14 @@ func (s *Server) Start() error {
 // Stop gracefully stops the API server
 func (s *Server) Stop(ctx context.Context) error {
 	log.Println("🛑 Stopping API server...")
-	return s.httpServer.Shutdown(ctx)
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return err
+	}
+	if s.webhooks != nil {
+		// Sync the webhook updates still waiting for their batch
+		return s.webhooks.Stop(ctx)
+	}
+	return nil
 }
 
 // RegisterTestRoutes registers API routes for testing
@@ -156,6 +177,9 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/v1/sync/batch", s.handleBatchSync)
 	mux.HandleFunc("POST /api/v1/sync/jql", s.handleJQLSync)
 
+	// Webhook endpoints
+	mux.HandleFunc("POST /api/v1/webhooks/jira", s.handleJIRAWebhook)
+
 	// Job management endpoints
 	mux.HandleFunc("GET /api/v1/jobs", s.handleListJobs)
 	mux.HandleFunc("GET /api/v1/jobs/{id}", s.handleGetJob)
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Default webhook batching: near-real-time without one commit per JIRA edit
+const (
+	DefaultWebhookFlushInterval = 30 * time.Second
+	DefaultWebhookMaxBatch      = 50
+)
+
+// webhookQueue collects the issue keys of incoming webhooks and flushes them as one batch
+// The first key queued after a flush starts the interval; when it ends, or as soon as maxBatch
+// distinct keys are pending, every pending key is handed to flush in arrival order. A key that
+// is already pending is not queued again, so repeated edits of an issue within the interval cost
+// a single sync. Flushes run in the background; Stop flushes what is left and waits for them.
+type webhookQueue struct {
+	interval time.Duration
+	maxBatch int
+	flush    func(ctx context.Context, issueKeys []string)
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	timer   *time.Timer
+	stopped bool
+	flushes sync.WaitGroup
+}
+
+// WebhookQueueStatus describes the pending batch of a webhook queue
+type WebhookQueueStatus struct {
+	Pending       int           `json:"pending"`
+	MaxBatch      int           `json:"max_batch"`
+	FlushInterval time.Duration `json:"flush_interval"`
+}
+
+// newWebhookQueue creates a queue that hands batches of issue keys to flush
+func newWebhookQueue(interval time.Duration, maxBatch int, flush func(ctx context.Context, issueKeys []string)) *webhookQueue {
+	if interval <= 0 {
+		interval = DefaultWebhookFlushInterval
+	}
+	if maxBatch < 1 {
+		maxBatch = DefaultWebhookMaxBatch
+	}
+	return &webhookQueue{
+		interval: interval,
+		maxBatch: maxBatch,
+		flush:    flush,
+		queued:   make(map[string]bool),
+	}
+}
+
+// Add queues an issue key, reporting whether it was already pending
+// Returns false for both when the queue is stopped.
+func (q *webhookQueue) Add(issueKey string) (queued, duplicate bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.stopped {
+		return false, false
+	}
+	if q.queued[issueKey] {
+		return true, true
+	}
+
+	q.queued[issueKey] = true
+	q.pending = append(q.pending, issueKey)
+	if len(q.pending) >= q.maxBatch {
+		q.flushLocked()
+	} else if q.timer == nil {
+		q.timer = time.AfterFunc(q.interval, q.Flush)
+	}
+	return true, false
+}
+
+// Flush hands every pending key to flush now
+func (q *webhookQueue) Flush() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.flushLocked()
+}
+
+// flushLocked starts a background flush of the pending keys; callers hold mu
+func (q *webhookQueue) flushLocked() {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	if len(q.pending) == 0 {
+		return
+	}
+
+	batch := q.pending
+	q.pending = nil
+	q.queued = make(map[string]bool)
+
+	q.flushes.Add(1)
+	go func() {
+		defer q.flushes.Done()
+		q.flush(context.Background(), batch)
+	}()
+}
+
+// Status returns the size of the pending batch and the queue's limits
+func (q *webhookQueue) Status() WebhookQueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return WebhookQueueStatus{Pending: len(q.pending), MaxBatch: q.maxBatch, FlushInterval: q.interval}
+}
+
+// Stop flushes the pending keys, refuses new ones, and waits for running flushes until ctx ends
+func (q *webhookQueue) Stop(ctx context.Context) error {
+	q.mu.Lock()
+	q.stopped = true
+	q.flushLocked()
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.flushes.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
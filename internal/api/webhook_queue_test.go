@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/jobs"
+)
+
+// recordingJobManager records the batch sync requests it receives
+type recordingJobManager struct {
+	MockJobManager
+
+	mu      sync.Mutex
+	batches []*jobs.BatchSyncRequest
+}
+
+func (m *recordingJobManager) SubmitBatchSync(ctx context.Context, req *jobs.BatchSyncRequest) (*jobs.JobResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches = append(m.batches, req)
+	return &jobs.JobResult{JobID: "test-job-batch", Status: jobs.JobStatusPending}, nil
+}
+
+func (m *recordingJobManager) submitted() []*jobs.BatchSyncRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*jobs.BatchSyncRequest(nil), m.batches...)
+}
+
+// collectBatches returns a flush function that records batches, and a way to read them
+func collectBatches() (func(context.Context, []string), func() [][]string) {
+	var mu sync.Mutex
+	var batches [][]string
+	flush := func(ctx context.Context, issueKeys []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, issueKeys)
+	}
+	return flush, func() [][]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][]string(nil), batches...)
+	}
+}
+
+func TestWebhookQueue_FlushesAfterInterval(t *testing.T) {
+	flush, batches := collectBatches()
+	queue := newWebhookQueue(20*time.Millisecond, 10, flush)
+
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-1", "PROJ-3", "PROJ-2"} {
+		queue.Add(key)
+	}
+	if _, duplicate := queue.Add("PROJ-3"); !duplicate {
+		t.Error("Expected a pending key to be reported as a duplicate")
+	}
+	if status := queue.Status(); status.Pending != 3 {
+		t.Errorf("Expected 3 pending keys, got %d", status.Pending)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(batches()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	got := batches()
+	if len(got) != 1 || strings.Join(got[0], ",") != "PROJ-1,PROJ-2,PROJ-3" {
+		t.Fatalf("Expected one deduplicated batch in arrival order, got %v", got)
+	}
+
+	// A key can be queued again once its batch was flushed
+	if _, duplicate := queue.Add("PROJ-1"); duplicate {
+		t.Error("Expected a flushed key to be queued again")
+	}
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if got := batches(); len(got) != 2 || strings.Join(got[1], ",") != "PROJ-1" {
+		t.Errorf("Expected Stop to flush the pending key, got %v", got)
+	}
+}
+
+func TestWebhookQueue_FlushesAtMaxBatch(t *testing.T) {
+	flush, batches := collectBatches()
+	queue := newWebhookQueue(time.Hour, 2, flush)
+
+	queue.Add("PROJ-1")
+	queue.Add("PROJ-1")
+	queue.Add("PROJ-2")
+	queue.Add("PROJ-3")
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	got := batches()
+	if len(got) != 2 {
+		t.Fatalf("Expected a full batch and a final batch, got %v", got)
+	}
+	var sizes []int
+	for _, batch := range got {
+		sizes = append(sizes, len(batch))
+	}
+	if !(sizes[0] == 2 && sizes[1] == 1) && !(sizes[0] == 1 && sizes[1] == 2) {
+		t.Errorf("Expected batches of 2 and 1 keys, got %v", got)
+	}
+
+	if queued, _ := queue.Add("PROJ-4"); queued {
+		t.Error("Expected a stopped queue to refuse keys")
+	}
+}
+
+func TestAPIServer_JIRAWebhook(t *testing.T) {
+	postWebhook := func(mux *http.ServeMux, body string) (*httptest.ResponseRecorder, WebhookResponse) {
+		req := httptest.NewRequest("POST", "/api/v1/webhooks/jira", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		var response struct {
+			Data WebhookResponse `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response.Data
+	}
+
+	t.Run("disabled without a repository", func(t *testing.T) {
+		mux := http.NewServeMux()
+		createTestServer(t).RegisterTestRoutes(mux)
+		if w, _ := postWebhook(mux, `{"webhookEvent":"jira:issue_updated","issue":{"key":"PROJ-1"}}`); w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status 503, got %d", w.Code)
+		}
+	})
+
+	t.Run("queues and batches updates", func(t *testing.T) {
+		config := DefaultConfig()
+		config.WebhookRepository = "/workspace/repo"
+		config.WebhookFlushInterval = time.Hour
+		manager := &recordingJobManager{}
+		server := NewServer(config, BuildInfo{Version: "test"}, manager)
+		mux := http.NewServeMux()
+		server.RegisterTestRoutes(mux)
+
+		w, response := postWebhook(mux, `{"webhookEvent":"jira:issue_updated","issue":{"key":"PROJ-1"}}`)
+		if w.Code != http.StatusAccepted || response.Status != "queued" || response.Queue.Pending != 1 {
+			t.Errorf("Expected the issue to be queued, got %d %+v", w.Code, response)
+		}
+		if _, response := postWebhook(mux, `{"webhookEvent":"jira:issue_updated","issue":{"key":"PROJ-1"}}`); response.Status != "duplicate" {
+			t.Errorf("Expected a duplicate update, got %+v", response)
+		}
+		if _, response := postWebhook(mux, `{"webhookEvent":"jira:issue_created","issue":{"key":"PROJ-2"}}`); response.Status != "queued" || response.Queue.Pending != 2 {
+			t.Errorf("Expected a created issue to be queued, got %+v", response)
+		}
+		if _, response := postWebhook(mux, `{"webhookEvent":"comment_created","issue":{"key":"PROJ-3"}}`); response.Status != "ignored" {
+			t.Errorf("Expected other events to be ignored, got %+v", response)
+		}
+		if w, _ := postWebhook(mux, `{"webhookEvent":"jira:issue_updated","issue":{"key":"not a key"}}`); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for an invalid key, got %d", w.Code)
+		}
+		if len(manager.submitted()) != 0 {
+			t.Fatal("Expected no sync before the batch is flushed")
+		}
+
+		if err := server.webhooks.Stop(context.Background()); err != nil {
+			t.Fatalf("Stop() error = %v", err)
+		}
+		batches := manager.submitted()
+		if len(batches) != 1 || strings.Join(batches[0].IssueKeys, ",") != "PROJ-1,PROJ-2" || batches[0].Repository != "/workspace/repo" {
+			t.Errorf("Expected one batch sync of PROJ-1 and PROJ-2, got %+v", batches)
+		}
+	})
+}
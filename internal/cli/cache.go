@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// responseCacheFlags holds the --cache-* flags given on the command line, which override the
+// JIRA_CACHE_* environment settings
+type responseCacheFlags struct {
+	dir     *string
+	mode    *client.CacheMode
+	ttl     *time.Duration
+	refresh bool
+}
+
+// newResponseCacheFlags reads the response cache flags that were set explicitly
+func newResponseCacheFlags(cmd *cobra.Command) (*responseCacheFlags, error) {
+	flags := &responseCacheFlags{}
+	if cmd.Flags().Changed("cache-dir") {
+		dir, _ := cmd.Flags().GetString("cache-dir")
+		flags.dir = &dir
+	}
+	if cmd.Flags().Changed("cache-mode") {
+		modeArg, _ := cmd.Flags().GetString("cache-mode")
+		mode, err := client.ParseCacheMode(modeArg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --cache-mode value: %w", err)
+		}
+		flags.mode = &mode
+	}
+	if cmd.Flags().Changed("cache-ttl") {
+		ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+		if ttl < 0 {
+			return nil, fmt.Errorf("--cache-ttl must be non-negative")
+		}
+		flags.ttl = &ttl
+	}
+	flags.refresh, _ = cmd.Flags().GetBool("refresh-cache")
+	return flags, nil
+}
+
+// apply overrides the cache settings of cfg and reports the cache in use
+// A cache directory without a mode reads and writes the cache.
+func (f *responseCacheFlags) apply(cfg *config.Config) error {
+	if f != nil {
+		if f.dir != nil {
+			cfg.CacheDir = *f.dir
+			if f.mode == nil && (cfg.CacheMode == "" || cfg.CacheMode == string(client.CacheOff)) {
+				cfg.CacheMode = string(client.CacheReadWrite)
+			}
+		}
+		if f.mode != nil {
+			cfg.CacheMode = string(*f.mode)
+		}
+		if f.ttl != nil {
+			cfg.CacheTTL = *f.ttl
+		}
+		cfg.RefreshCache = cfg.RefreshCache || f.refresh
+	}
+
+	mode, err := client.ParseCacheMode(cfg.CacheMode)
+	if err != nil {
+		return err
+	}
+	if mode == client.CacheOff {
+		if cfg.RefreshCache {
+			return fmt.Errorf("--refresh-cache requires a response cache (set --cache-dir or --cache-mode)")
+		}
+		return nil
+	}
+	if cfg.CacheDir == "" {
+		return fmt.Errorf("cache mode %s requires --cache-dir (env: JIRA_CACHE_DIR)", mode)
+	}
+	if mode == client.CacheRead && cfg.RefreshCache {
+		return fmt.Errorf("--refresh-cache cannot be combined with --cache-mode=read, which never contacts JIRA")
+	}
+
+	switch {
+	case mode == client.CacheRead:
+		fmt.Printf("💾 Reading JIRA responses from cache %s (offline)\n", cfg.CacheDir)
+	case cfg.RefreshCache:
+		fmt.Printf("💾 Refreshing JIRA response cache %s\n", cfg.CacheDir)
+	case cfg.CacheTTL > 0:
+		fmt.Printf("💾 Using JIRA response cache %s (%s, entries expire after %v)\n", cfg.CacheDir, mode, cfg.CacheTTL)
+	default:
+		fmt.Printf("💾 Using JIRA response cache %s (%s)\n", cfg.CacheDir, mode)
+	}
+	return nil
+}
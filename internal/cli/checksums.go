@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/checksum"
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+// loadSigningKey loads the manifest signing key configured in JIRA_SYNC_SIGNING_KEY
+// Returns nil when signing was not requested.
+func loadSigningKey(cfg *config.Config, sign bool) (ed25519.PrivateKey, error) {
+	if !sign {
+		return nil, nil
+	}
+	if cfg.SigningKey == "" {
+		return nil, fmt.Errorf("signing requires JIRA_SYNC_SIGNING_KEY (path to a PEM Ed25519 private key)")
+	}
+	key, err := checksum.LoadPrivateKey(cfg.SigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JIRA_SYNC_SIGNING_KEY: %w", err)
+	}
+	return key, nil
+}
+
+// updateChecksumManifests regenerates the checksum manifest of every project in the repository,
+// signing it when a key is given, and commits the manifests that changed. Like indexes,
+// manifests are rebuilt from the issue files present, so they follow removed issues too.
+func updateChecksumManifests(gitRepo git.Repository, repoPath string, signingKey ed25519.PrivateKey) error {
+	projects, err := schema.ListProjects(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to list projects for checksums: %w", err)
+	}
+
+	var changedPaths, changedProjects []string
+	for _, projectKey := range projects {
+		paths, err := checksum.WriteProjectManifest(repoPath, projectKey, signingKey)
+		if err != nil {
+			return fmt.Errorf("failed to write checksum manifest for project %s: %w", projectKey, err)
+		}
+		if len(paths) > 0 {
+			changedPaths = append(changedPaths, paths...)
+			changedProjects = append(changedProjects, projectKey)
+		}
+	}
+
+	if len(changedPaths) == 0 {
+		fmt.Println("🔏 Checksum manifests already up to date")
+		return nil
+	}
+
+	message := fmt.Sprintf("chore(checksums): update checksum manifest for %s", strings.Join(changedProjects, ", "))
+	if err := gitRepo.CommitFiles(repoPath, message, changedPaths...); err != nil {
+		return fmt.Errorf("failed to commit checksum manifests: %w", err)
+	}
+	if signingKey != nil {
+		fmt.Printf("🔏 Updated signed checksum manifest for %d project(s): %s\n", len(changedProjects), strings.Join(changedProjects, ", "))
+	} else {
+		fmt.Printf("🔏 Updated checksum manifest for %d project(s): %s\n", len(changedProjects), strings.Join(changedProjects, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+	"github.com/spf13/cobra"
+)
+
+// compareRunsCmd represents the compare-runs command
+var compareRunsCmd = &cobra.Command{
+	Use:   "compare-runs",
+	Short: "Report how the mirrored issues changed between two sync runs",
+	Long: `Compare the issues mirrored by two recorded sync runs and report which appeared, which
+disappeared, which changed status, and which were otherwise updated.
+
+Runs are identified by the IDs shown by 'history'. Each incremental sync records a snapshot of
+the repository's issues in .jira-sync-runs/, kept for as long as the run stays in the history.
+Without --to the latest recorded run is used, and without --from the run before --to.`,
+	Example: `  # What changed in the latest run
+  jira-sync compare-runs --repo=./my-repo
+
+  # Compare two specific runs as JSON
+  jira-sync compare-runs --repo=./my-repo --from=sync-20240301T090003Z-1a2b3c --to=sync-20240302T090004Z-4d5e6f --json`,
+	RunE: runCompareRuns,
+}
+
+// runComparison is the delta between two sync runs as shown by compare-runs
+type runComparison struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	FromIssues int    `json:"from_issue_count"`
+	ToIssues   int    `json:"to_issue_count"`
+	*state.RunDelta
+}
+
+func runCompareRuns(cmd *cobra.Command, args []string) error {
+	repo, _ := cmd.Flags().GetString("repo")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	if repo == "" {
+		return fmt.Errorf("--repo flag is required")
+	}
+
+	comparison, err := compareRuns(repo, from, to)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(comparison)
+	}
+
+	displayRunComparison(repo, comparison)
+	return nil
+}
+
+// compareRuns loads the snapshots of two runs and diffs them
+// Missing run IDs default to the latest run with a snapshot and the one recorded before it.
+func compareRuns(repo, from, to string) (*runComparison, error) {
+	if from == "" || to == "" {
+		recorded, err := recordedSnapshotRuns(repo)
+		if err != nil {
+			return nil, err
+		}
+		if to == "" {
+			if len(recorded) == 0 {
+				return nil, fmt.Errorf("no run snapshots recorded for %s; snapshots are recorded by incremental syncs", repo)
+			}
+			to = recorded[len(recorded)-1]
+		}
+		if from == "" {
+			from = previousRun(recorded, to)
+			if from == "" {
+				return nil, fmt.Errorf("no run recorded before %s to compare against; pass --from", to)
+			}
+		}
+	}
+	if from == to {
+		return nil, fmt.Errorf("--from and --to must name different runs")
+	}
+
+	fromSnapshot, err := state.LoadRunSnapshot(repo, from)
+	if err != nil {
+		return nil, err
+	}
+	toSnapshot, err := state.LoadRunSnapshot(repo, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runComparison{
+		From:       from,
+		To:         to,
+		FromIssues: fromSnapshot.IssueCount,
+		ToIssues:   toSnapshot.IssueCount,
+		RunDelta:   state.CompareRunSnapshots(fromSnapshot, toSnapshot),
+	}, nil
+}
+
+// recordedSnapshotRuns lists the IDs of the runs in a repository's history that have a
+// snapshot, oldest first
+func recordedSnapshotRuns(repo string) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(repo, state.StateFileName)); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	stateManager := state.NewFileStateManager(state.FormatYAML)
+	syncState, err := stateManager.LoadState(repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync history: %w", err)
+	}
+
+	var recorded []string
+	for _, operation := range syncState.History {
+		if _, err := os.Stat(filepath.Join(repo, state.RunSnapshotDir, operation.ID+".yaml")); err == nil {
+			recorded = append(recorded, operation.ID)
+		}
+	}
+	return recorded, nil
+}
+
+// previousRun returns the run recorded before the given one, or "" if there is none
+func previousRun(recorded []string, operationID string) string {
+	for i, id := range recorded {
+		if id == operationID && i > 0 {
+			return recorded[i-1]
+		}
+	}
+	return ""
+}
+
+// displayRunComparison prints the delta between two runs
+func displayRunComparison(repo string, comparison *runComparison) {
+	fmt.Printf("🔍 Comparing runs for %s\n", repo)
+	fmt.Printf("   From: %s (%d issues)\n", comparison.From, comparison.FromIssues)
+	fmt.Printf("   To:   %s (%d issues)\n\n", comparison.To, comparison.ToIssues)
+
+	delta := comparison.RunDelta
+	if len(delta.Appeared)+len(delta.Disappeared)+len(delta.StatusChanged)+len(delta.Updated) == 0 {
+		fmt.Println("✅ No issues changed between the two runs")
+		return
+	}
+
+	if len(delta.Appeared) > 0 {
+		fmt.Printf("🆕 Appeared (%d):\n", len(delta.Appeared))
+		for _, issue := range delta.Appeared {
+			fmt.Printf("  • %s [%s] %s\n", issue.Key, issue.Status, issue.Summary)
+		}
+	}
+	if len(delta.Disappeared) > 0 {
+		fmt.Printf("🗑️  Disappeared (%d):\n", len(delta.Disappeared))
+		for _, issue := range delta.Disappeared {
+			fmt.Printf("  • %s [%s] %s\n", issue.Key, issue.Status, issue.Summary)
+		}
+	}
+	if len(delta.StatusChanged) > 0 {
+		fmt.Printf("🔀 Status changed (%d):\n", len(delta.StatusChanged))
+		for _, change := range delta.StatusChanged {
+			fmt.Printf("  • %s: %s → %s (%s)\n", change.Key, change.From, change.To, change.Summary)
+		}
+	}
+	if len(delta.Updated) > 0 {
+		fmt.Printf("✏️  Updated (%d): %s\n", len(delta.Updated), previewIssueKeys(delta.Updated, 10))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(compareRunsCmd)
+
+	compareRunsCmd.Flags().StringP("repo", "r", "", "Synced Git repository path (required)")
+	compareRunsCmd.Flags().String("from", "", "ID of the earlier run (default: the run before --to)")
+	compareRunsCmd.Flags().String("to", "", "ID of the later run (default: the latest recorded run)")
+	compareRunsCmd.Flags().Bool("json", false, "Print the comparison as JSON")
+}
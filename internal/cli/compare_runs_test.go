@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+)
+
+func TestCompareRuns_DefaultsToLatestTwoRuns(t *testing.T) {
+	repo := t.TempDir()
+
+	if _, err := compareRuns(repo, "", ""); err == nil || !strings.Contains(err.Error(), "no run snapshots") {
+		t.Fatalf("Expected an error without recorded runs, got %v", err)
+	}
+
+	manager := state.NewFileStateManager(state.FormatYAML)
+	syncState, err := manager.InitializeState(repo, state.RepositoryInfo{Path: repo})
+	if err != nil {
+		t.Fatalf("InitializeState() error = %v", err)
+	}
+	statuses := []string{"To Do", "In Progress", "Done"}
+	var ids []string
+	for i, status := range statuses {
+		operation := manager.StartSyncOperation(syncState, state.SyncTypeIncremental, state.SyncConfig{})
+		operation.ID = "sync-" + string(rune('a'+i))
+		ids = append(ids, operation.ID)
+		_ = manager.CompleteSyncOperation(syncState, operation, state.OperationResults{})
+		snapshot := &state.RunSnapshot{OperationID: operation.ID, Issues: map[string]state.SnapshotIssue{
+			"PROJ-1": {Summary: "Login fails", Status: status},
+		}}
+		if err := state.SaveRunSnapshot(repo, snapshot); err != nil {
+			t.Fatalf("SaveRunSnapshot() error = %v", err)
+		}
+	}
+	if err := manager.SaveState(repo, syncState); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+
+	comparison, err := compareRuns(repo, "", "")
+	if err != nil {
+		t.Fatalf("compareRuns() error = %v", err)
+	}
+	if comparison.From != ids[1] || comparison.To != ids[2] {
+		t.Errorf("Expected the latest two runs, got %s..%s", comparison.From, comparison.To)
+	}
+	if len(comparison.StatusChanged) != 1 || comparison.StatusChanged[0].From != "In Progress" || comparison.StatusChanged[0].To != "Done" {
+		t.Errorf("Unexpected status changes: %+v", comparison.StatusChanged)
+	}
+
+	comparison, err = compareRuns(repo, ids[0], ids[2])
+	if err != nil || comparison.StatusChanged[0].From != "To Do" {
+		t.Errorf("Expected an explicit --from to be used, got %+v, %v", comparison, err)
+	}
+	if _, err := compareRuns(repo, "", ids[0]); err == nil {
+		t.Error("Expected an error when no run precedes --to")
+	}
+	if _, err := compareRuns(repo, "sync-missing", ids[2]); err == nil || !strings.Contains(err.Error(), "no snapshot recorded") {
+		t.Errorf("Expected an unknown run to be reported, got %v", err)
+	}
+}
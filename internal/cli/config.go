@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and validate the resolved configuration",
+	Long: `Inspect the configuration that sync commands will actually use.
+
+Configuration is read from environment variables, with values in .env overriding
+the environment. Command-line flags such as --rate-limit and --concurrency override
+these per run. Credentials are always masked.`,
+}
+
+// configShowCmd represents the config show command
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the resolved configuration and where each value came from",
+	Example: `  # Show the configuration a sync would use
+  jira-sync config show`,
+	RunE: runConfigShow,
+}
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check required values are present and the JIRA URL is reachable",
+	Example: `  # Validate configuration and connectivity
+  jira-sync config validate
+
+  # Validate values only, without contacting JIRA
+  jira-sync config validate --skip-connectivity`,
+	RunE: runConfigValidate,
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	inspection, err := config.Inspect()
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	displayConfigSources(inspection)
+
+	fmt.Printf("\n⚙️  Settings:\n")
+	for _, setting := range inspection.Settings {
+		value := setting.Value
+		if value == "" {
+			value = "(empty)"
+		}
+		fmt.Printf("  • %-25s %-40s [%s]\n", setting.Key, value, setting.Source)
+	}
+
+	fmt.Printf("\n🔧 Command-line overrides (per run):\n")
+	fmt.Printf("  • sync --rate-limit overrides RATE_LIMIT_DELAY\n")
+	fmt.Printf("  • sync --concurrency sets batch workers (default 1, max 10)\n")
+	fmt.Printf("  • sync --resolve-users enables JIRA_RESOLVE_USERS\n")
+	fmt.Printf("  • sync --include-worklogs enables JIRA_INCLUDE_WORKLOGS\n")
+	fmt.Printf("  • sync --profile applies saved profile options\n")
+
+	return nil
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	skipConnectivity, _ := cmd.Flags().GetBool("skip-connectivity")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	inspection, err := config.Inspect()
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	displayConfigSources(inspection)
+
+	if err := config.NewLoader().Validate(inspection.Config); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return fmt.Errorf("configuration is invalid")
+	}
+	fmt.Println("✅ Required values present and well-formed")
+
+	if skipConnectivity {
+		return nil
+	}
+
+	fmt.Printf("🔗 Checking JIRA at %s...\n", inspection.Config.JIRABaseURL)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Reach JIRA the way a sync does, trusting JIRA_CA_CERT and honoring JIRA_INSECURE_SKIP_VERIFY
+	transport, err := client.NewHTTPTransport(inspection.Config)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return fmt.Errorf("configuration is invalid")
+	}
+	status, err := client.CheckReachable(ctx, &http.Client{
+		Timeout:   timeout,
+		Transport: client.NewIdentifyingTransport(transport, inspection.Config.UserAgent, inspection.Config.RequestIDs),
+	}, inspection.Config.JIRABaseURL)
+	if err != nil {
+		return fmt.Errorf("JIRA URL check failed: %w", err)
+	}
+	fmt.Printf("✅ JIRA server reachable (HTTP %d)\n", status)
+
+	return nil
+}
+
+// displayConfigSources lists the .env files that contributed configuration
+func displayConfigSources(inspection *config.Inspection) {
+	if len(inspection.EnvFiles) == 0 {
+		fmt.Println("📄 No .env file found; using environment variables and defaults")
+		return
+	}
+	for _, envFile := range inspection.EnvFiles {
+		fmt.Printf("📄 Loaded %s (overrides environment variables)\n", envFile)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+
+	configValidateCmd.Flags().Bool("skip-connectivity", false, "Only validate values, without contacting JIRA")
+	configValidateCmd.Flags().Duration("timeout", 10*time.Second, "Timeout for the JIRA reachability check")
+}
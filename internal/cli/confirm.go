@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	gosync "sync"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+// removalSampleSize bounds the affected paths listed before a removal is applied
+const removalSampleSize = 10
+
+// removalGuard decides whether a prune or deletion may modify the repository
+// Removals are applied when --confirm or --yes was given; otherwise the affected paths are
+// shown and the user is asked on the terminal. Without a terminal to ask, removals are refused.
+type removalGuard struct {
+	confirmed   bool
+	interactive bool
+	in          *bufio.Reader // shared by every prompt, so input buffered for one is not lost to the next
+	out         io.Writer
+
+	mu gosync.Mutex // serializes prompts of repositories synced concurrently
+}
+
+// newRemovalGuard reads --confirm and --yes
+func newRemovalGuard(cmd *cobra.Command) *removalGuard {
+	confirm, _ := cmd.Flags().GetBool("confirm")
+	yes, _ := cmd.Flags().GetBool("yes")
+	return &removalGuard{
+		confirmed:   confirm || yes,
+		interactive: isTerminal(os.Stdin),
+		in:          bufio.NewReader(os.Stdin),
+		out:         os.Stdout,
+	}
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// approve shows the paths a removal affects and reports whether it may be applied
+// Returns an error when confirmation is required but nobody can be asked.
+func (g *removalGuard) approve(plan *sync.RemovalPlan, repoPath, action string) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	fmt.Fprintf(g.out, "⚠️  %s will affect %d path(s) in %s:\n", action, plan.Count(), repoPath)
+	printPathSample(g.out, plan.Paths(repoPath))
+	if g.confirmed {
+		return true, nil
+	}
+	if !g.interactive {
+		return false, fmt.Errorf("refusing to apply %s without confirmation: review the paths above (or rerun with --dry-run) and pass --confirm or --yes", strings.ToLower(action))
+	}
+
+	fmt.Fprintf(g.out, "Apply? [y/N]: ")
+	response, _ := g.in.ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		fmt.Fprintf(g.out, "⏭️  %s cancelled, no files were changed\n", action)
+		return false, nil
+	}
+	return true, nil
+}
+
+// printPathSample lists the first removalSampleSize paths and how many more there are
+func printPathSample(out io.Writer, paths []string) {
+	for i, path := range paths {
+		if i == removalSampleSize {
+			fmt.Fprintf(out, "  … and %d more (use --dry-run to list them all)\n", len(paths)-removalSampleSize)
+			return
+		}
+		fmt.Fprintf(out, "  - %s\n", path)
+	}
+}
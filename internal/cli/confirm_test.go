@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+)
+
+func removalPlan(files int) *sync.RemovalPlan {
+	plan := &sync.RemovalPlan{}
+	for i := 1; i <= files; i++ {
+		plan.Files = append(plan.Files, sync.PrunedIssue{
+			IssueKey: fmt.Sprintf("PROJ-%d", i),
+			FilePath: fmt.Sprintf("/repo/projects/PROJ/issues/PROJ-%d.yaml", i),
+		})
+	}
+	plan.Links = []string{"/repo/projects/PROJ/relationships/epic/PROJ-1"}
+	return plan
+}
+
+func TestRemovalGuard_Approve(t *testing.T) {
+	tests := []struct {
+		name        string
+		confirmed   bool
+		interactive bool
+		input       string
+		approved    bool
+		wantErr     bool
+	}{
+		{name: "confirmed", confirmed: true, approved: true},
+		{name: "no terminal", wantErr: true},
+		{name: "answered yes", interactive: true, input: "yes\n", approved: true},
+		{name: "answered no", interactive: true, input: "n\n"},
+		{name: "no answer", interactive: true, input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			guard := &removalGuard{confirmed: tt.confirmed, interactive: tt.interactive, in: bufio.NewReader(strings.NewReader(tt.input)), out: &out}
+
+			approved, err := guard.approve(removalPlan(2), "/repo", "Prune")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("approve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if approved != tt.approved {
+				t.Errorf("approve() = %t, want %t", approved, tt.approved)
+			}
+			if !strings.Contains(out.String(), "Prune will affect 3 path(s)") || !strings.Contains(out.String(), "  - projects/PROJ/relationships/epic/PROJ-1") {
+				t.Errorf("Expected the affected paths to be listed, got:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestRemovalGuard_ApproveSeveralPrompts(t *testing.T) {
+	var out bytes.Buffer
+	guard := &removalGuard{interactive: true, in: bufio.NewReader(strings.NewReader("y\nn\nyes\n")), out: &out}
+
+	for i, want := range []bool{true, false, true} {
+		approved, err := guard.approve(removalPlan(1), "/repo", "Prune")
+		if err != nil {
+			t.Fatalf("approve() error = %v", err)
+		}
+		if approved != want {
+			t.Errorf("Prompt %d: approve() = %t, want %t", i+1, approved, want)
+		}
+	}
+}
+
+func TestRemovalGuard_ApproveSample(t *testing.T) {
+	var out bytes.Buffer
+	guard := &removalGuard{confirmed: true, out: &out}
+	if _, err := guard.approve(removalPlan(30), "/repo", "Prune"); err != nil {
+		t.Fatalf("approve() error = %v", err)
+	}
+	if lines := strings.Count(out.String(), "\n  - "); lines != removalSampleSize {
+		t.Errorf("Expected %d sample paths, got %d:\n%s", removalSampleSize, lines, out.String())
+	}
+	if !strings.Contains(out.String(), "… and 21 more") {
+		t.Errorf("Expected the remaining count, got:\n%s", out.String())
+	}
+}
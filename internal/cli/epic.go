@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/chambrid/jira-cdc-git/pkg/epic"
+	"github.com/spf13/cobra"
+)
+
+// epicCmd groups the EPIC commands
+var epicCmd = &cobra.Command{
+	Use:   "epic",
+	Short: "Inspect EPICs before syncing them",
+}
+
+// epicAnalyzeCmd represents the epic analyze command
+var epicAnalyzeCmd = &cobra.Command{
+	Use:   "analyze EPIC-KEY",
+	Short: "Show the structure of an EPIC: its issues by type and status, hierarchy, and gaps",
+	Long: `Discover every issue of an EPIC and summarize its structure, to check what an
+--epic-key sync or profile would cover.
+
+Analyses are cached on disk (~/.jira-sync/epic-cache) and reused for --cache-ttl, so
+repeated runs against a large EPIC answer without querying JIRA again. --refresh-epic
+re-analyzes the EPIC and replaces the cached result; --cache-ttl=0 disables the cache.`,
+	Example: `  # Analyze an EPIC, reusing an analysis from the last hour
+  jira-sync epic analyze PROJ-100
+
+  # Re-analyze after changing the EPIC in JIRA
+  jira-sync epic analyze PROJ-100 --refresh-epic
+
+  # Analyze a slice of the EPIC, as JSON
+  jira-sync epic analyze PROJ-100 --within="component = Payments" --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEpicAnalyze,
+}
+
+func runEpicAnalyze(cmd *cobra.Command, args []string) error {
+	epicKey := args[0]
+	within, _ := cmd.Flags().GetString("within")
+	refresh, _ := cmd.Flags().GetBool("refresh-epic")
+	cacheTTL, _ := cmd.Flags().GetDuration("cache-ttl")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	if err := validateIssueKey(epicKey); err != nil {
+		return fmt.Errorf("invalid EPIC key: %w", err)
+	}
+	if cacheTTL < 0 {
+		return fmt.Errorf("--cache-ttl cannot be negative, got %s", cacheTTL)
+	}
+	if refresh && cacheTTL == 0 {
+		return fmt.Errorf("--refresh-epic has no effect with --cache-ttl=0")
+	}
+
+	options := epic.DefaultDiscoveryOptions()
+	options.Within = within
+
+	// The cache is consulted before connecting, so a cached analysis needs no JIRA access
+	var cache *epic.AnalysisCache
+	if cacheTTL > 0 {
+		cache = epic.NewAnalysisCache(epic.DefaultAnalysisCacheDir(), cacheTTL)
+		if !refresh {
+			if result, cachedAt, ok := cache.Load(epicKey, options); ok {
+				result.CachedAt = &cachedAt
+				return displayEpicAnalysis(result, asJSON)
+			}
+		}
+	}
+
+	cfg, err := config.NewDotEnvLoader().Load()
+	if err != nil {
+		return configError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+	jiraClient, err := client.NewClient(cfg)
+	if err != nil {
+		return configError(fmt.Errorf("failed to create JIRA client: %w", err))
+	}
+	if err := jiraClient.Authenticate(); err != nil {
+		return configError(fmt.Errorf("failed to authenticate with JIRA: %w", err))
+	}
+
+	analyzer := epic.NewJIRAEpicAnalyzer(jiraClient, options)
+	if cache != nil {
+		analyzer = epic.NewCachingEpicAnalyzer(analyzer, cache, options, refresh)
+	}
+	if !asJSON {
+		fmt.Printf("🔍 Analyzing EPIC %s...\n", epicKey)
+	}
+	result, err := analyzer.AnalyzeEpic(epicKey)
+	if err != nil {
+		return fmt.Errorf("EPIC analysis failed: %w", err)
+	}
+	return displayEpicAnalysis(result, asJSON)
+}
+
+// displayEpicAnalysis prints an EPIC analysis as a summary or as JSON
+func displayEpicAnalysis(result *epic.AnalysisResult, asJSON bool) error {
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	fmt.Printf("🎯 %s: %s (%s)\n", result.EpicKey, result.EpicSummary, result.EpicStatus)
+	if result.CachedAt != nil {
+		fmt.Printf("💾 Cached analysis from %s ago (--refresh-epic to re-analyze)\n",
+			time.Since(*result.CachedAt).Round(time.Second))
+	}
+	fmt.Printf("📊 %d issue(s)\n", result.TotalIssues)
+	printEpicGroups("By type", result.IssuesByType)
+	printEpicGroups("By status", result.IssuesByStatus)
+	if result.Hierarchy != nil {
+		fmt.Printf("🌳 Hierarchy: %d level(s), %d stories, %d tasks, %d bugs\n", result.Hierarchy.Levels,
+			len(result.Hierarchy.Stories), len(result.Hierarchy.Tasks), len(result.Hierarchy.Bugs))
+	}
+	if report := result.Completeness; report != nil {
+		fmt.Printf("✅ Completeness: %.1f%% (%d of %d issues found)\n",
+			report.CompletenessPercent, report.TotalFoundIssues, report.TotalExpectedIssues)
+		for _, recommendation := range report.Recommendations {
+			fmt.Printf("   💡 %s\n", recommendation)
+		}
+	}
+	return nil
+}
+
+// printEpicGroups prints issue counts per group, largest first
+func printEpicGroups(title string, groups map[string][]string) {
+	if len(groups) == 0 {
+		return
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(groups[names[i]]) != len(groups[names[j]]) {
+			return len(groups[names[i]]) > len(groups[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	fmt.Printf("   %s:\n", title)
+	for _, name := range names {
+		fmt.Printf("     • %s: %d\n", name, len(groups[name]))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(epicCmd)
+	epicCmd.AddCommand(epicAnalyzeCmd)
+
+	epicAnalyzeCmd.Flags().String("within", "", "JQL filter limiting the analysis to a slice of the EPIC (e.g. 'component = Payments')")
+	epicAnalyzeCmd.Flags().Bool("refresh-epic", false, "Re-analyze the EPIC instead of reusing a cached analysis, and replace the cache")
+	epicAnalyzeCmd.Flags().Duration("cache-ttl", epic.DefaultAnalysisCacheTTL, "How long a cached analysis is reused (0 disables the cache)")
+	epicAnalyzeCmd.Flags().Bool("json", false, "Print the analysis as JSON")
+}
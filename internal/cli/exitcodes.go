@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+)
+
+// Exit codes of jira-sync, so automation can tell failure modes apart
+const (
+	ExitSuccess        = 0 // every issue synced
+	ExitUsageError     = 1 // invalid flags or arguments, or an unclassified error
+	ExitPartialFailure = 2 // some issues failed to sync
+	ExitTotalFailure   = 3 // no issue synced, or the sync could not run
+	ExitConfigError    = 4 // configuration or authentication error
+	ExitInterrupted    = 5 // interrupted by SIGINT or SIGTERM
+)
+
+// ExitError is an error that selects the process exit code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitUsageError
+}
+
+// configError marks a configuration or authentication failure
+func configError(err error) error {
+	return &ExitError{Code: ExitConfigError, Err: err}
+}
+
+// syncFailure marks a sync that could not complete, or was interrupted if ctx was cancelled
+func syncFailure(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return &ExitError{Code: ExitInterrupted, Err: fmt.Errorf("sync interrupted: %w", err)}
+	}
+	return &ExitError{Code: ExitTotalFailure, Err: err}
+}
+
+// setupInterrupted stops a run interrupted before its sync started, ahead of the next setup step
+func setupInterrupted(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return &ExitError{Code: ExitInterrupted, Err: errors.New("sync interrupted before it started")}
+	}
+	return nil
+}
+
+// syncResultError returns the exit error for a finished sync: nil when no issue failed,
+// a partial failure when some issues synced, and a total failure when none did
+func syncResultError(ctx context.Context, result *sync.BatchResult) error {
+	if ctx.Err() != nil {
+		return &ExitError{Code: ExitInterrupted, Err: fmt.Errorf("sync interrupted after %d of %d issues",
+			result.ProcessedIssues, result.TotalIssues)}
+	}
+	if result.FailedSync == 0 {
+		return nil
+	}
+	if result.SuccessfulSync == 0 {
+		return &ExitError{Code: ExitTotalFailure, Err: fmt.Errorf("all %d issues failed to sync", result.FailedSync)}
+	}
+	return &ExitError{Code: ExitPartialFailure, Err: fmt.Errorf("%d of %d issues failed to sync",
+		result.FailedSync, result.ProcessedIssues)}
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, ExitSuccess},
+		{"unclassified", errors.New("--repo flag is required"), ExitUsageError},
+		{"config", configError(errors.New("JIRA_BASE_URL is required")), ExitConfigError},
+		{"wrapped", fmt.Errorf("profile sync failed: %w", configError(errors.New("401"))), ExitConfigError},
+		{"total", syncFailure(context.Background(), errors.New("search failed")), ExitTotalFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncResultError(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		result *sync.BatchResult
+		want   int
+	}{
+		{"all synced", &sync.BatchResult{ProcessedIssues: 3, SuccessfulSync: 3}, ExitSuccess},
+		{"partial", &sync.BatchResult{ProcessedIssues: 3, SuccessfulSync: 2, FailedSync: 1}, ExitPartialFailure},
+		{"total", &sync.BatchResult{ProcessedIssues: 3, FailedSync: 3}, ExitTotalFailure},
+		{"nothing to sync", &sync.BatchResult{}, ExitSuccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(syncResultError(ctx, tt.result)); got != tt.want {
+				t.Errorf("exit code = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	result := &sync.BatchResult{TotalIssues: 5, ProcessedIssues: 2, SuccessfulSync: 2}
+	if got := ExitCode(syncResultError(cancelled, result)); got != ExitInterrupted {
+		t.Errorf("exit code after interrupt = %d, want %d", got, ExitInterrupted)
+	}
+	if got := ExitCode(syncFailure(cancelled, context.Canceled)); got != ExitInterrupted {
+		t.Errorf("exit code for interrupted failure = %d, want %d", got, ExitInterrupted)
+	}
+	if err := setupInterrupted(ctx); err != nil {
+		t.Errorf("setupInterrupted() without a signal = %v, want nil", err)
+	}
+	if got := ExitCode(setupInterrupted(cancelled)); got != ExitInterrupted {
+		t.Errorf("exit code for interrupted setup = %d, want %d", got, ExitInterrupted)
+	}
+}
+
+func TestDestinationsError(t *testing.T) {
+	ctx := context.Background()
+	synced := &sync.BatchResult{ProcessedIssues: 2, SuccessfulSync: 2}
+	partial := &sync.BatchResult{ProcessedIssues: 2, SuccessfulSync: 1, FailedSync: 1}
+	failed := errors.New("failed to initialize Git repository")
+	tests := []struct {
+		name     string
+		outcomes []destinationOutcome
+		want     int
+	}{
+		{"all synced", []destinationOutcome{{Label: "a", Result: synced}, {Label: "b", Result: synced}}, ExitSuccess},
+		{"issue failures", []destinationOutcome{{Label: "a", Result: synced}, {Label: "b", Result: partial}}, ExitPartialFailure},
+		{"one destination failed", []destinationOutcome{{Label: "a", Result: synced}, {Label: "b", Err: failed}}, ExitPartialFailure},
+		{"every destination failed", []destinationOutcome{{Label: "a", Err: failed}, {Label: "b", Err: failed}}, ExitTotalFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(destinationsError(ctx, tt.outcomes, len(tt.outcomes))); got != tt.want {
+				t.Errorf("exit code = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_RuntimeErrorsPrintNoUsage(t *testing.T) {
+	failing := &cobra.Command{
+		Use: "failing-test",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return &ExitError{Code: ExitPartialFailure, Err: errors.New("1 of 2 issues failed to sync")}
+		},
+	}
+	failing.Flags().Bool("some-flag", false, "")
+	rootCmd.AddCommand(failing)
+	defer rootCmd.RemoveCommand(failing)
+
+	var output bytes.Buffer
+	rootCmd.SetOut(&output)
+	rootCmd.SetErr(&output)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetErr(nil)
+
+	rootCmd.SetArgs([]string{"failing-test"})
+	err := rootCmd.Execute()
+	if ExitCode(err) != ExitPartialFailure {
+		t.Errorf("Expected a partial failure, got %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("Expected main to print the error alone, got output:\n%s", output.String())
+	}
+
+	// Invalid flags still show the usage
+	failing.SilenceUsage = false
+	output.Reset()
+	rootCmd.SetArgs([]string{"failing-test", "--unknown-flag"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("Expected an unknown flag error")
+	}
+	if !strings.Contains(output.String(), "Usage:") {
+		t.Errorf("Expected usage for an invalid flag, got:\n%s", output.String())
+	}
+	rootCmd.SetArgs(nil)
+}
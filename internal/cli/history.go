@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+	"github.com/spf13/cobra"
+)
+
+// DefaultHistoryLimit is how many sync runs history lists by default
+const DefaultHistoryLimit = 20
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the sync runs recorded for a repository",
+	Long: `Show the sync runs recorded in a repository's state file (.jira-sync-state.yaml), newest first.
+
+Each run lists when it started, its type, outcome, issue counts, and duration. Runs are
+recorded by incremental syncs; the state file keeps the most recent 50.`,
+	Example: `  # Show the last 20 runs
+  jira-sync history --repo=./my-repo
+
+  # Show every recorded run as JSON
+  jira-sync history --repo=./my-repo --limit=0 --json`,
+	RunE: runHistory,
+}
+
+// historyEntry is one sync run as shown by the history command
+type historyEntry struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Status     string    `json:"status"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Duration   string    `json:"duration"`
+	Query      string    `json:"query,omitempty"`
+	Total      int       `json:"total_issues"`
+	Successful int       `json:"successful"`
+	Failed     int       `json:"failed"`
+	Skipped    int       `json:"skipped"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	repo, _ := cmd.Flags().GetString("repo")
+	limit, _ := cmd.Flags().GetInt("limit")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	if repo == "" {
+		return fmt.Errorf("--repo flag is required")
+	}
+	if limit < 0 {
+		return fmt.Errorf("--limit must be 0 (all runs) or positive, got %d", limit)
+	}
+
+	entries, err := loadSyncHistory(repo, limit)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	displayHistory(repo, entries)
+	return nil
+}
+
+// loadSyncHistory reads up to limit recorded sync runs of a repository, newest first
+// A repository without a state file has no history, which is not an error.
+func loadSyncHistory(repo string, limit int) ([]historyEntry, error) {
+	if _, err := os.Stat(filepath.Join(repo, state.StateFileName)); os.IsNotExist(err) {
+		return []historyEntry{}, nil
+	}
+
+	stateManager := state.NewFileStateManager(state.FormatYAML)
+	syncState, err := stateManager.LoadState(repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync history: %w", err)
+	}
+
+	operations := stateManager.GetHistoryReport(syncState, limit)
+	entries := make([]historyEntry, 0, len(operations))
+	for i := len(operations) - 1; i >= 0; i-- {
+		entries = append(entries, newHistoryEntry(operations[i]))
+	}
+	return entries, nil
+}
+
+// newHistoryEntry summarizes a recorded sync operation
+func newHistoryEntry(operation state.SyncOperation) historyEntry {
+	query := operation.Query
+	if query == "" && len(operation.IssueKeys) > 0 {
+		query = previewIssueKeys(operation.IssueKeys, 5)
+	}
+	return historyEntry{
+		ID:         operation.ID,
+		Type:       string(operation.Type),
+		Status:     string(operation.Status),
+		StartTime:  operation.StartTime,
+		EndTime:    operation.EndTime,
+		Duration:   operation.Duration.Round(time.Millisecond).String(),
+		Query:      query,
+		Total:      operation.Results.TotalIssues,
+		Successful: operation.Results.SuccessfulSync,
+		Failed:     operation.Results.FailedSync,
+		Skipped:    operation.Results.SkippedIssues,
+		Error:      operation.Error,
+	}
+}
+
+// previewIssueKeys joins the first limit issue keys, counting the rest
+func previewIssueKeys(issueKeys []string, limit int) string {
+	if len(issueKeys) <= limit {
+		return strings.Join(issueKeys, ",")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(issueKeys[:limit], ","), len(issueKeys)-limit)
+}
+
+// displayHistory prints recorded sync runs as a table
+func displayHistory(repo string, entries []historyEntry) {
+	if len(entries) == 0 {
+		fmt.Printf("📭 No sync history recorded for %s\n", repo)
+		fmt.Println("💡 History is recorded by incremental syncs (sync --incremental)")
+		return
+	}
+
+	fmt.Printf("🕒 Sync history for %s (%d run(s), newest first):\n\n", repo, len(entries))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "STARTED\tTYPE\tSTATUS\tISSUES\tOK\tFAILED\tSKIPPED\tDURATION\n")
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\n",
+			entry.StartTime.Local().Format("2006-01-02 15:04:05"), entry.Type, entry.Status,
+			entry.Total, entry.Successful, entry.Failed, entry.Skipped, entry.Duration)
+	}
+	_ = w.Flush()
+
+	for _, entry := range entries {
+		if entry.Error != "" {
+			fmt.Printf("\n❌ %s (%s): %s\n", entry.StartTime.Local().Format("2006-01-02 15:04:05"), entry.ID, entry.Error)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringP("repo", "r", "", "Synced Git repository path (required)")
+	historyCmd.Flags().Int("limit", DefaultHistoryLimit, "Number of most recent runs to show (0 shows all)")
+	historyCmd.Flags().Bool("json", false, "Print the runs as JSON")
+}
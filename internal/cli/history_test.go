@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+)
+
+func TestLoadSyncHistory(t *testing.T) {
+	repo := t.TempDir()
+
+	entries, err := loadSyncHistory(repo, DefaultHistoryLimit)
+	if err != nil {
+		t.Fatalf("loadSyncHistory() error = %v", err)
+	}
+	if entries == nil || len(entries) != 0 {
+		t.Fatalf("Expected an empty history for a repository without state, got %v", entries)
+	}
+
+	manager := state.NewFileStateManager(state.FormatYAML)
+	syncState, err := manager.InitializeState(repo, state.RepositoryInfo{Path: repo})
+	if err != nil {
+		t.Fatalf("InitializeState() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		operation := manager.StartSyncOperation(syncState, state.SyncTypeIncremental, state.SyncConfig{})
+		operation.StartTime = time.Date(2024, 1, 15+i, 10, 0, 0, 0, time.UTC)
+		operation.IssueKeys = []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-4", "PROJ-5", "PROJ-6"}
+		if i == 2 {
+			_ = manager.FailSyncOperation(syncState, operation, errors.New("rate limited"))
+			continue
+		}
+		_ = manager.CompleteSyncOperation(syncState, operation, state.OperationResults{TotalIssues: 6, SuccessfulSync: 6})
+	}
+	if err := manager.SaveState(repo, syncState); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+
+	entries, err = loadSyncHistory(repo, 2)
+	if err != nil {
+		t.Fatalf("loadSyncHistory() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries with --limit=2, got %d", len(entries))
+	}
+	if entries[0].Status != string(state.SyncStatusFailed) || entries[0].Error != "rate limited" {
+		t.Errorf("Expected the newest (failed) run first, got %+v", entries[0])
+	}
+	if entries[1].Status != string(state.SyncStatusCompleted) || entries[1].Successful != 6 {
+		t.Errorf("Unexpected second entry: %+v", entries[1])
+	}
+	if entries[1].Query != "PROJ-1,PROJ-2,PROJ-3,PROJ-4,PROJ-5 (+1 more)" {
+		t.Errorf("Query = %q, want a preview of the issue keys", entries[1].Query)
+	}
+
+	all, _ := loadSyncHistory(repo, 0)
+	if len(all) != 3 || !all[0].StartTime.After(all[2].StartTime) {
+		t.Errorf("Expected every run, newest first, got %+v", all)
+	}
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+// postSyncHooks runs the --post-sync-hook commands after a sync
+type postSyncHooks struct {
+	onSuccess   string
+	onFailure   string
+	mustSucceed bool
+	repository  string
+	dryRun      bool
+	skip        bool // sample previews are not syncs
+}
+
+// newPostSyncHooks reads the hook flags of the sync command
+func newPostSyncHooks(cmd *cobra.Command) *postSyncHooks {
+	hooks := &postSyncHooks{}
+	hooks.onSuccess, _ = cmd.Flags().GetString("post-sync-hook")
+	hooks.onFailure, _ = cmd.Flags().GetString("post-sync-hook-on-failure")
+	hooks.mustSucceed, _ = cmd.Flags().GetBool("hook-must-succeed")
+	hooks.repository, _ = cmd.Flags().GetString("repo")
+	hooks.dryRun, _ = cmd.Flags().GetBool("dry-run")
+	sample, _ := cmd.Flags().GetInt("sample")
+	hooks.skip = sample > 0
+	return hooks
+}
+
+// run runs the hook for the outcome of a sync and returns the error the command should report
+// A failing hook only fails the command with --hook-must-succeed; the sync's own error always
+// takes precedence, so its exit code is kept.
+func (h *postSyncHooks) run(result *sync.BatchResult, syncErr error) error {
+	command := h.onSuccess
+	if syncErr != nil {
+		command = h.onFailure
+	}
+	if command == "" || h.skip {
+		return syncErr
+	}
+
+	summary := sync.NewHookSummary(result, syncErr, time.Now())
+	summary.Repository = h.repository
+	summary.DryRun = h.dryRun
+
+	fmt.Printf("🪝 Running post-sync hook (%s): %s\n", summary.Event, command)
+	output, hookErr := sync.RunHook(context.Background(), command, summary, sync.DefaultHookTimeout)
+	if trimmed := strings.TrimRight(string(output), "\n"); trimmed != "" {
+		for _, line := range strings.Split(trimmed, "\n") {
+			fmt.Printf("  │ %s\n", line)
+		}
+	}
+
+	switch {
+	case hookErr == nil:
+		fmt.Println("✅ Post-sync hook finished")
+		return syncErr
+	case !h.mustSucceed:
+		fmt.Printf("⚠️  Post-sync hook failed: %v\n", hookErr)
+		return syncErr
+	case syncErr != nil:
+		return fmt.Errorf("%w (post-sync hook also failed: %v)", syncErr, hookErr)
+	default:
+		return fmt.Errorf("post-sync hook failed: %w", hookErr)
+	}
+}
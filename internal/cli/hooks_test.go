@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+)
+
+func TestPostSyncHooks_Run(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "event")
+	record := `echo "$JIRA_SYNC_EVENT" > ` + marker
+	syncErr := &ExitError{Code: ExitPartialFailure, Err: errors.New("1 of 2 issues failed to sync")}
+
+	tests := []struct {
+		name       string
+		hooks      postSyncHooks
+		syncErr    error
+		wantEvent  string
+		wantErr    string
+		wantCode   int
+		noHookRuns bool
+	}{
+		{name: "success hook", hooks: postSyncHooks{onSuccess: record}, wantEvent: "success"},
+		{name: "failure hook keeps the sync error", hooks: postSyncHooks{onFailure: record}, syncErr: syncErr, wantEvent: "failure", wantErr: "1 of 2", wantCode: ExitPartialFailure},
+		{name: "no hook for the outcome", hooks: postSyncHooks{onFailure: record}, noHookRuns: true},
+		{name: "sample previews skip hooks", hooks: postSyncHooks{onSuccess: record, skip: true}, noHookRuns: true},
+		{name: "failing hook is logged", hooks: postSyncHooks{onSuccess: "exit 1"}},
+		{name: "failing hook must succeed", hooks: postSyncHooks{onSuccess: "exit 1", mustSucceed: true}, wantErr: "post-sync hook failed", wantCode: ExitUsageError},
+		{name: "failing hook after a failed sync", hooks: postSyncHooks{onFailure: "exit 1", mustSucceed: true}, syncErr: syncErr, wantErr: "post-sync hook also failed", wantCode: ExitPartialFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Remove(marker)
+			err := tt.hooks.run(&sync.BatchResult{TotalIssues: 2}, tt.syncErr)
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr) || ExitCode(err) != tt.wantCode) {
+				t.Fatalf("run() error = %v (exit %d), want %q (exit %d)", err, ExitCode(err), tt.wantErr, tt.wantCode)
+			}
+
+			data, readErr := os.ReadFile(marker)
+			if tt.noHookRuns {
+				if readErr == nil {
+					t.Error("Expected no hook to run")
+				}
+				return
+			}
+			if tt.wantEvent != "" && strings.TrimSpace(string(data)) != tt.wantEvent {
+				t.Errorf("Expected the hook to see event %q, got %q (%v)", tt.wantEvent, data, readErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/spf13/cobra"
+)
+
+// linksCmd groups the relationship link commands
+var linksCmd = &cobra.Command{
+	Use:   "links",
+	Short: "Manage the relationship links of a synced repository",
+}
+
+// linksRebuildCmd represents the links rebuild command
+var linksRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Recreate all relationship links from the synced issue files",
+	Long: `Recreate every relationship link of a synced repository from the issue files on disk.
+
+The relationships recorded in each issue file (epic, parent, subtasks, and issue links) are
+read, every projects/{project}/relationships/ directory is removed, and the links are created
+again. Nothing is fetched from JIRA, so this repairs corrupted or missing links, and checkouts
+on filesystems that turned symbolic links into plain files, without a full resync.
+
+Issue files that cannot be parsed are reported and skipped. The rebuilt links are committed
+unless --no-commit is given; other local changes stay out of the commit.`,
+	Example: `  # Rebuild and commit the links of a repository
+  jira-sync links rebuild --repo=./my-repo
+
+  # Rebuild links that were synced with --link-naming=key-summary
+  jira-sync links rebuild --repo=./my-repo --link-naming=key-summary`,
+	RunE: runLinksRebuild,
+}
+
+func runLinksRebuild(cmd *cobra.Command, args []string) error {
+	repo, _ := cmd.Flags().GetString("repo")
+	linkNamingArg, _ := cmd.Flags().GetString("link-naming")
+	noCommit, _ := cmd.Flags().GetBool("no-commit")
+
+	if repo == "" {
+		return fmt.Errorf("--repo flag is required")
+	}
+	linkNaming, err := links.ParseLinkNaming(linkNamingArg)
+	if err != nil {
+		return fmt.Errorf("invalid --link-naming value: %w", err)
+	}
+	gitRepo := git.NewGitRepository("JIRA CDC Git Sync", "jira-sync@automated.local")
+	if !noCommit && !gitRepo.IsRepository(repo) {
+		return fmt.Errorf("%s is not a Git repository (use --no-commit to rebuild links without committing)", repo)
+	}
+
+	fmt.Printf("🔗 Rebuilding relationship links in %s...\n", repo)
+	result, err := links.RebuildLinks(links.NewSymbolicLinkManagerWithNaming(0, linkNaming), repo)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild links: %w", err)
+	}
+
+	for _, skipped := range result.Skipped {
+		fmt.Printf("⚠️  Skipped %s: %s\n", skipped.Path, skipped.Reason)
+	}
+	fmt.Printf("📊 Rebuild Results:\n")
+	fmt.Printf("  • Issue files read: %d\n", result.IssuesRead)
+	fmt.Printf("  • Links removed: %d\n", len(result.Removed))
+	fmt.Printf("  • Links created: %d\n", len(result.Created))
+	if len(result.Skipped) > 0 {
+		fmt.Printf("  • Issue files skipped: %d\n", len(result.Skipped))
+	}
+
+	if noCommit {
+		return nil
+	}
+	changed := append(append([]string{}, result.Created...), result.Removed...)
+	message := fmt.Sprintf("chore(links): rebuild %d relationship link(s) from issue files", len(result.Created))
+	if err := gitRepo.CommitFiles(repo, message, changed...); err != nil {
+		return fmt.Errorf("failed to commit rebuilt links: %w", err)
+	}
+	fmt.Printf("✅ Links rebuilt; changes committed\n")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(linksCmd)
+	linksCmd.AddCommand(linksRebuildCmd)
+
+	linksRebuildCmd.Flags().StringP("repo", "r", "", "Synced Git repository path (required)")
+	linksRebuildCmd.Flags().String("link-naming", string(links.LinkNamingKey), "How links are named: key, key-summary, or type-key (match the naming the repository was synced with)")
+	linksRebuildCmd.Flags().Bool("no-commit", false, "Rebuild the links without committing them")
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of the sync command
+const (
+	SyncFormatYAML     = "yaml"     // one YAML file per issue in a Git repository (the default)
+	SyncFormatMarkdown = "markdown" // one Markdown file per issue in a Git repository, for browsing
+	SyncFormatNDJSON   = "ndjson"   // every issue as one JSON line in --output-file
+)
+
+// ndjsonIncompatibleFlags are the sync flags that only apply to a Git repository of issue files
+var ndjsonIncompatibleFlags = []string{
+	"profile", "projects", "repo", "watch", "incremental", "force", "dry-run", "dry-run-report",
+	"show-diff", "sample", "prune", "archive-pruned", "only-changed-since-commit", "base-ref",
+	"on-dirty", "generate-index", "index-format", "index-sort", "checksums", "sign", "merge-update",
+	"commit-strategy", "exclude-fields", "transform", "track-fields", "status-transitions-only",
+	"junit-report", "progress-socket", "link-naming", "write-status-badge", "no-manifest", "handle-deletions", "ramp-up",
+	"normalize-timestamps", "field-order", "generate-relationship-index", "checkpoint-interval",
+	"filter-expr", "git-retries", "git-retry-backoff", "max-files", "no-file-limit",
+	"minimal", "minimal-relationships", "quiet-hours", "quiet-hours-timezone", "quiet-hours-mode",
+	"frontmatter-fields",
+}
+
+// markdownIncompatibleFlags are the sync flags that only apply to YAML issue files: how the
+// YAML is written, and the steps that read issue files back
+var markdownIncompatibleFlags = []string{
+	"exclude-fields", "minimal", "minimal-relationships", "transform", "merge-update", "adf-render",
+	"flatten-fields", "no-flatten", "normalize-timestamps", "field-order", "generate-index",
+	"index-format", "index-sort", "generate-relationship-index", "checksums", "sign", "prune",
+	"archive-pruned", "handle-deletions",
+}
+
+// parseSyncFormat validates --format and the flags that go with it, returning the format
+func parseSyncFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output-file")
+
+	var incompatibleFlags []string
+	switch format {
+	case "", SyncFormatYAML, SyncFormatMarkdown:
+		if outputFile != "" {
+			return "", fmt.Errorf("--output-file requires --format=%s", SyncFormatNDJSON)
+		}
+		if format != SyncFormatMarkdown {
+			return SyncFormatYAML, nil
+		}
+		incompatibleFlags = markdownIncompatibleFlags
+	case SyncFormatNDJSON:
+		if outputFile == "" {
+			return "", fmt.Errorf("--format=%s requires --output-file", SyncFormatNDJSON)
+		}
+		incompatibleFlags = ndjsonIncompatibleFlags
+	default:
+		return "", fmt.Errorf("invalid --format value %q: must be %s, %s, or %s", format, SyncFormatYAML, SyncFormatMarkdown, SyncFormatNDJSON)
+	}
+
+	var incompatible []string
+	for _, name := range incompatibleFlags {
+		if cmd.Flags().Changed(name) {
+			incompatible = append(incompatible, "--"+name)
+		}
+	}
+	if len(incompatible) > 0 {
+		return "", fmt.Errorf("cannot combine --format=%s with %s", format, strings.Join(incompatible, ", "))
+	}
+	return format, nil
+}
+
+// exportNDJSON writes the sync's issues to outputFile as NDJSON, one issue per line
+// A JQL sync writes the issues of each search page directly; an issue list fetches each issue
+// in turn. The file is truncated first; a named pipe streams to its reader.
+func exportNDJSON(ctx context.Context, jiraClient client.Client, outputFile, issuesArg, jqlQuery string, adfRender schema.ADFRenderMode, fieldSchemas map[string]client.FieldSchema, flatten schema.FlattenStrategy) error {
+	var issues []string
+	if issuesArg != "" {
+		rawIssues, err := parseIssueList(issuesArg)
+		if err != nil {
+			return fmt.Errorf("failed to parse issues: %w", err)
+		}
+		issues, err = validateIssueList(rawIssues)
+		if err != nil {
+			return fmt.Errorf("issue validation failed: %w", err)
+		}
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to open NDJSON output: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	exporter := sync.NewNDJSONExporter(jiraClient, file, adfRender, fieldSchemas)
+	exporter.SetFlattenStrategy(flatten)
+	var result *sync.NDJSONResult
+	if issuesArg != "" {
+		fmt.Printf("📤 Exporting %d JIRA issue(s) as NDJSON to %s\n", len(issues), outputFile)
+		result, err = exporter.ExportIssues(ctx, issues)
+	} else {
+		fmt.Printf("📤 Exporting issues matching JQL as NDJSON to %s\n", outputFile)
+		fmt.Printf("   Query: %s\n", jqlQuery)
+		result, err = exporter.ExportJQL(ctx, jqlQuery, client.DefaultSearchPageSize)
+	}
+	if err != nil {
+		return syncFailure(ctx, fmt.Errorf("NDJSON export failed after %d issue(s): %w", result.Exported, err))
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write NDJSON output: %w", err)
+	}
+
+	fmt.Printf("✅ Exported %d of %d issue(s) in %v (schema version %d)\n",
+		result.Exported, result.TotalIssues, result.Duration.Round(time.Millisecond), sync.NDJSONSchemaVersion)
+	if len(result.Errors) == 0 {
+		return nil
+	}
+	for _, exportErr := range result.Errors {
+		fmt.Printf("   ❌ %s: %s\n", exportErr.IssueKey, exportErr.Message)
+	}
+	failed := len(result.Errors)
+	if result.Exported == 0 {
+		return &ExitError{Code: ExitTotalFailure, Err: fmt.Errorf("all %d issues failed to export", failed)}
+	}
+	return &ExitError{Code: ExitPartialFailure, Err: fmt.Errorf("%d of %d issues failed to export", failed, result.TotalIssues)}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/chambrid/jira-cdc-git/pkg/jql"
+	"github.com/spf13/cobra"
+)
+
+// previewCmd represents the preview command
+var previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Count the issues a JQL query matches, optionally listing the first few",
+	Long: `Preview a JQL query before syncing it.
+
+By default only the number of matching issues is fetched, which costs a single small
+search. --sample=N also fetches the first N matches and lists their key, status, and
+summary, to confirm the query selects the issues you expect.`,
+	Example: `  # Count the matches
+  jira-sync preview --jql="project = PROJ AND status = 'To Do'"
+
+  # Also list the first 10 matches
+  jira-sync preview --jql="Epic Link = PROJ-123" --sample=10`,
+	RunE: runPreview,
+}
+
+func runPreview(cmd *cobra.Command, args []string) error {
+	query, _ := cmd.Flags().GetString("jql")
+	sample, _ := cmd.Flags().GetInt("sample")
+
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("--jql flag is required")
+	}
+	if sample < 0 || sample > jql.MaxPreviewSampleSize {
+		return fmt.Errorf("--sample must be between 0 and %d, got %d", jql.MaxPreviewSampleSize, sample)
+	}
+
+	query, err := expandJQLAliases(cmd, query)
+	if err != nil {
+		return err
+	}
+	query, err = resolveJQLTemplate(cmd, query)
+	if err != nil {
+		return err
+	}
+
+	warnQueryCost(query)
+
+	cfg, err := config.NewDotEnvLoader().Load()
+	if err != nil {
+		return configError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+	jiraClient, err := client.NewClient(cfg)
+	if err != nil {
+		return configError(fmt.Errorf("failed to create JIRA client: %w", err))
+	}
+	if err := jiraClient.Authenticate(); err != nil {
+		return configError(fmt.Errorf("failed to authenticate with JIRA: %w", err))
+	}
+
+	builder := jql.NewJIRAQueryBuilder(jiraClient, nil, nil)
+	preview, err := builder.PreviewQueryWithSamples(query, sample)
+	if err != nil {
+		return err
+	}
+
+	displayPreview(preview)
+	return nil
+}
+
+// displayPreview prints the match count of a query preview and its sampled issues
+func displayPreview(preview *jql.PreviewResult) {
+	fmt.Printf("🔍 %s\n", preview.Query)
+	fmt.Printf("📊 %d matching issue(s) (%dms)\n", preview.TotalCount, preview.ExecutionTimeMs)
+	if len(preview.SampleIssues) == 0 {
+		return
+	}
+
+	fmt.Printf("📋 First %d of %d:\n", len(preview.SampleIssues), preview.TotalCount)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, issue := range preview.SampleIssues {
+		_, _ = fmt.Fprintf(w, "   %s\t%s\t%s\n", issue.Key, issue.Status.Name, issue.Summary)
+	}
+	_ = w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+
+	previewCmd.Flags().String("jql", "", "JQL query to preview")
+	previewCmd.Flags().Int("sample", 0, fmt.Sprintf("Also fetch and list the first N matching issues (max %d; 0 only counts)", jql.MaxPreviewSampleSize))
+	previewCmd.Flags().Bool("transform-jql", true, "Expand @alias shortcuts in JQL")
+	previewCmd.Flags().StringArray("jql-var", nil, "JQL template variable as Name=value, repeatable (env: JQL_VAR_Name)")
+}
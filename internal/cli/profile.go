@@ -8,6 +8,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/chambrid/jira-cdc-git/pkg/config"
 	"github.com/chambrid/jira-cdc-git/pkg/profile"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -28,8 +29,10 @@ with a simple name for easy reuse. This is especially useful for:
 • Template-based profile creation
 
 Profile Storage:
-  Profiles are stored in .jira-sync-profiles/ directory in YAML format.
-  You can export/import profiles for sharing with team members.
+  Profiles are stored in the .jira-sync-profiles/ directory, in profiles.yaml by default.
+  A profiles.json or profiles.toml file there is used instead when no profiles.yaml exists.
+  You can export/import profiles for sharing with team members, converting between
+  YAML, JSON, and TOML by file extension.
 
 Common Workflow:
   1. Create profile from template or manually
@@ -184,7 +187,11 @@ var profileImportCmd = &cobra.Command{
 	Long: `Import profiles from a previously exported file.
 
 By default, import will not overwrite existing profiles. Use --overwrite to replace
-existing profiles with imported ones.`,
+existing profiles with imported ones.
+
+Every profile is validated first (sync mode, JQL syntax, repository path, options) and a
+per-profile report is printed. One invalid profile aborts the import unless --skip-invalid
+is given, which imports the valid profiles and skips the rest.`,
 	Example: `  # Import profiles
   jira-sync profile import --file=team-profiles.yaml
   
@@ -195,10 +202,43 @@ existing profiles with imported ones.`,
   jira-sync profile import --file=shared-profiles.yaml --prefix=team-
   
   # Import with additional tags
-  jira-sync profile import --file=external-profiles.yaml --tags=external,imported`,
+  jira-sync profile import --file=external-profiles.yaml --tags=external,imported
+
+  # Import the valid profiles of a file, skipping broken ones
+  jira-sync profile import --file=team-profiles.yaml --skip-invalid`,
 	RunE: runProfileImportCommand,
 }
 
+var profileLintCmd = &cobra.Command{
+	Use:   "lint [profile-name...]",
+	Short: "Check profiles for risky or inefficient settings",
+	Long: `Check profiles against best-practice rules and print advisory warnings.
+
+Unlike validation, lint findings never make a profile unusable. Rules flag unbounded JQL,
+high concurrency, missing rate limits, overly broad scopes, and profiles that always force.
+Without names, every profile is linted.
+
+Rules can be disabled with --disable or in the profile_lint section of ~/.jira-sync.yaml or
+./.jira-sync.yaml:
+
+  profile_lint:
+    disabled: [missing-rate-limit]
+    max_concurrency: 6
+    max_issue_keys: 100`,
+	Example: `  # Lint all profiles
+  jira-sync profile lint
+
+  # Lint one profile and fail on warnings (for CI)
+  jira-sync profile lint nightly --error-on-warn
+
+  # Skip a rule
+  jira-sync profile lint --disable=missing-rate-limit
+
+  # Show the available rules
+  jira-sync profile lint --list-rules`,
+	RunE: runProfileLintCommand,
+}
+
 // Profile command flags
 var profileFlags struct {
 	// List flags
@@ -215,6 +255,7 @@ var profileFlags struct {
 	JQL          string
 	Issues       []string
 	EpicKey      string
+	EpicWithin   string
 	Repository   string
 	Concurrency  int
 	RateLimit    string
@@ -240,11 +281,19 @@ var profileFlags struct {
 	Format     string
 
 	// Import flags
-	ImportFile string
-	Overwrite  bool
-	Prefix     string
-	ImportTags []string
-	Validate   bool
+	ImportFile    string
+	Overwrite     bool
+	Prefix        string
+	ImportTags    []string
+	Validate      bool
+	SkipInvalid   bool
+	ImportWorkers int
+
+	// Lint flags
+	LintDisable        []string
+	LintMaxConcurrency int
+	ErrorOnWarn        bool
+	ListRules          bool
 
 	// Template variables (dynamic)
 	Variables map[string]string
@@ -261,6 +310,7 @@ func init() {
 	profileCmd.AddCommand(profileTemplatesCmd)
 	profileCmd.AddCommand(profileExportCmd)
 	profileCmd.AddCommand(profileImportCmd)
+	profileCmd.AddCommand(profileLintCmd)
 
 	// List command flags
 	profileListCmd.Flags().BoolVar(&profileFlags.Stats, "stats", false, "Include usage statistics")
@@ -276,6 +326,7 @@ func init() {
 	profileCreateCmd.Flags().StringVar(&profileFlags.JQL, "jql", "", "JQL query for sync")
 	profileCreateCmd.Flags().StringSliceVar(&profileFlags.Issues, "issues", nil, "Comma-separated issue keys")
 	profileCreateCmd.Flags().StringVar(&profileFlags.EpicKey, "epic-key", "", "EPIC key for EPIC-based sync")
+	profileCreateCmd.Flags().StringVar(&profileFlags.EpicWithin, "within", "", "JQL filter limiting the EPIC sync to a slice (e.g. 'component = Payments')")
 	profileCreateCmd.Flags().StringVar(&profileFlags.Repository, "repository", "", "Target repository path (required)")
 	profileCreateCmd.Flags().IntVar(&profileFlags.Concurrency, "concurrency", 5, "Concurrency level (1-10)")
 	profileCreateCmd.Flags().StringVar(&profileFlags.RateLimit, "rate-limit", "500ms", "Rate limit between API calls")
@@ -296,6 +347,7 @@ func init() {
 	profileUpdateCmd.Flags().StringVar(&profileFlags.JQL, "jql", "", "JQL query for sync")
 	profileUpdateCmd.Flags().StringSliceVar(&profileFlags.Issues, "issues", nil, "Comma-separated issue keys")
 	profileUpdateCmd.Flags().StringVar(&profileFlags.EpicKey, "epic-key", "", "EPIC key for EPIC-based sync")
+	profileUpdateCmd.Flags().StringVar(&profileFlags.EpicWithin, "within", "", "JQL filter limiting the EPIC sync to a slice (empty clears it)")
 	profileUpdateCmd.Flags().StringVar(&profileFlags.Repository, "repository", "", "Target repository path")
 	profileUpdateCmd.Flags().IntVar(&profileFlags.Concurrency, "concurrency", 0, "Concurrency level (1-10)")
 	profileUpdateCmd.Flags().StringVar(&profileFlags.RateLimit, "rate-limit", "", "Rate limit between API calls")
@@ -316,7 +368,7 @@ func init() {
 	profileExportCmd.Flags().StringSliceVar(&profileFlags.Names, "names", nil, "Specific profile names to export")
 	profileExportCmd.Flags().StringSliceVar(&profileFlags.Tags, "tags", nil, "Export profiles with these tags")
 	profileExportCmd.Flags().BoolVar(&profileFlags.NoStats, "no-stats", false, "Exclude usage statistics")
-	profileExportCmd.Flags().StringVar(&profileFlags.Format, "format", "", "Export format: yaml, json (auto-detected from file extension)")
+	profileExportCmd.Flags().StringVar(&profileFlags.Format, "format", "", "Export format: yaml, json, toml (auto-detected from file extension)")
 
 	// Mark required flags for export
 	_ = profileExportCmd.MarkFlagRequired("file")
@@ -327,16 +379,24 @@ func init() {
 	profileImportCmd.Flags().StringVar(&profileFlags.Prefix, "prefix", "", "Add prefix to imported profile names")
 	profileImportCmd.Flags().StringSliceVar(&profileFlags.ImportTags, "tags", nil, "Add these tags to imported profiles")
 	profileImportCmd.Flags().BoolVar(&profileFlags.Validate, "validate", true, "Validate profiles before import")
+	profileImportCmd.Flags().BoolVar(&profileFlags.SkipInvalid, "skip-invalid", false, "Import valid profiles and skip invalid ones instead of aborting")
+	profileImportCmd.Flags().IntVar(&profileFlags.ImportWorkers, "workers", profile.DefaultTransferWorkers, fmt.Sprintf("Profiles decoded and validated concurrently (files with fewer than %d profiles are processed serially)", profile.ParallelTransferThreshold))
 
 	// Mark required flags for import
 	_ = profileImportCmd.MarkFlagRequired("file")
 
+	// Lint command flags
+	profileLintCmd.Flags().StringSliceVar(&profileFlags.LintDisable, "disable", nil, "Lint rules to skip (adds to profile_lint.disabled)")
+	profileLintCmd.Flags().IntVar(&profileFlags.LintMaxConcurrency, "max-concurrency", 0, fmt.Sprintf("Concurrency above which high-concurrency warns (default %d)", profile.DefaultLintMaxConcurrency))
+	profileLintCmd.Flags().BoolVar(&profileFlags.ErrorOnWarn, "error-on-warn", false, "Exit with an error when any warning is found")
+	profileLintCmd.Flags().BoolVar(&profileFlags.ListRules, "list-rules", false, "List the lint rules and exit")
+
 	// Initialize variables map
 	profileFlags.Variables = make(map[string]string)
 }
 
 func runProfileListCommand(cmd *cobra.Command, args []string) error {
-	manager := profile.NewFileProfileManager(".", "yaml")
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
 
 	options := &profile.ProfileListOptions{
 		Tags:         profileFlags.Tags,
@@ -401,7 +461,7 @@ func runProfileListCommand(cmd *cobra.Command, args []string) error {
 }
 
 func runProfileCreateCommand(cmd *cobra.Command, args []string) error {
-	manager := profile.NewFileProfileManager(".", "yaml")
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
 
 	// Check if profile already exists
 	if manager.ProfileExists(profileFlags.Name) {
@@ -469,6 +529,7 @@ func runProfileCreateCommand(cmd *cobra.Command, args []string) error {
 			JQL:         profileFlags.JQL,
 			IssueKeys:   profileFlags.Issues,
 			EpicKey:     profileFlags.EpicKey,
+			EpicWithin:  profileFlags.EpicWithin,
 			Repository:  profileFlags.Repository,
 			Options: profile.ProfileOptions{
 				Concurrency:  profileFlags.Concurrency,
@@ -516,7 +577,7 @@ func runProfileCreateCommand(cmd *cobra.Command, args []string) error {
 }
 
 func runProfileShowCommand(cmd *cobra.Command, args []string) error {
-	manager := profile.NewFileProfileManager(".", "yaml")
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
 	profileName := args[0]
 
 	p, err := manager.GetProfile(profileName)
@@ -539,6 +600,9 @@ func runProfileShowCommand(cmd *cobra.Command, args []string) error {
 	}
 	if p.EpicKey != "" {
 		fmt.Printf("  EPIC: %s\n", p.EpicKey)
+		if p.EpicWithin != "" {
+			fmt.Printf("  Within: %s\n", p.EpicWithin)
+		}
 	}
 
 	// Show options
@@ -577,7 +641,7 @@ func runProfileShowCommand(cmd *cobra.Command, args []string) error {
 }
 
 func runProfileUpdateCommand(cmd *cobra.Command, args []string) error {
-	manager := profile.NewFileProfileManager(".", "yaml")
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
 	profileName := args[0]
 
 	// Get existing profile
@@ -598,6 +662,7 @@ func runProfileUpdateCommand(cmd *cobra.Command, args []string) error {
 		p.JQL = profileFlags.JQL
 		p.IssueKeys = nil
 		p.EpicKey = ""
+		p.EpicWithin = ""
 		updated = true
 	}
 
@@ -605,6 +670,7 @@ func runProfileUpdateCommand(cmd *cobra.Command, args []string) error {
 		p.IssueKeys = profileFlags.Issues
 		p.JQL = ""
 		p.EpicKey = ""
+		p.EpicWithin = ""
 		updated = true
 	}
 
@@ -615,6 +681,11 @@ func runProfileUpdateCommand(cmd *cobra.Command, args []string) error {
 		updated = true
 	}
 
+	if cmd.Flags().Changed("within") {
+		p.EpicWithin = profileFlags.EpicWithin
+		updated = true
+	}
+
 	if cmd.Flags().Changed("repository") {
 		p.Repository = profileFlags.Repository
 		updated = true
@@ -670,7 +741,7 @@ func runProfileUpdateCommand(cmd *cobra.Command, args []string) error {
 }
 
 func runProfileDeleteCommand(cmd *cobra.Command, args []string) error {
-	manager := profile.NewFileProfileManager(".", "yaml")
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
 	profileName := args[0]
 
 	// Check if profile exists
@@ -767,13 +838,14 @@ func runProfileTemplatesCommand(cmd *cobra.Command, args []string) error {
 }
 
 func runProfileExportCommand(cmd *cobra.Command, args []string) error {
-	manager := profile.NewFileProfileManager(".", "yaml")
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
 
 	options := &profile.ProfileExportOptions{
 		Names:        profileFlags.Names,
 		Tags:         profileFlags.Tags,
 		IncludeStats: !profileFlags.NoStats,
 		Format:       profileFlags.Format,
+		Progress:     transferProgress("Exporting"),
 	}
 
 	if err := manager.ExportToFile(profileFlags.ExportFile, options); err != nil {
@@ -792,32 +864,42 @@ func runProfileExportCommand(cmd *cobra.Command, args []string) error {
 }
 
 func runProfileImportCommand(cmd *cobra.Command, args []string) error {
-	manager := profile.NewFileProfileManager(".", "yaml")
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
+
+	if profileFlags.SkipInvalid && !profileFlags.Validate {
+		return fmt.Errorf("--skip-invalid requires --validate")
+	}
+	if profileFlags.ImportWorkers < 1 {
+		return fmt.Errorf("--workers must be at least 1")
+	}
 
 	// Validate import file first if requested
+	var skipped []string
 	if profileFlags.Validate {
 		fmt.Printf("🔍 Validating import file...\n")
-		validation, err := profile.ValidateImportFile(profileFlags.ImportFile)
+		report, err := profile.ValidateImportFileReport(profileFlags.ImportFile, profileFlags.ImportWorkers)
 		if err != nil {
 			return fmt.Errorf("failed to validate import file: %w", err)
 		}
 
-		if !validation.Valid {
-			fmt.Printf("❌ Import file validation failed:\n")
-			for _, err := range validation.Errors {
-				fmt.Printf("  • %s\n", err)
-			}
+		displayImportValidation(report)
+		if len(report.Errors) > 0 {
 			return fmt.Errorf("import file is invalid")
 		}
 
-		if len(validation.Warnings) > 0 {
-			fmt.Printf("⚠️  Import file warnings:\n")
-			for _, warning := range validation.Warnings {
-				fmt.Printf("  • %s\n", warning)
+		skipped = report.InvalidNames()
+		if len(skipped) > 0 {
+			if !profileFlags.SkipInvalid {
+				return fmt.Errorf("%d of %d profiles are invalid (use --skip-invalid to import the valid ones)",
+					len(skipped), len(report.Profiles))
+			}
+			if report.ValidCount() == 0 {
+				return fmt.Errorf("no valid profiles to import")
 			}
+			fmt.Printf("⏭️  Skipping %d invalid profile(s): %s\n", len(skipped), strings.Join(skipped, ", "))
+		} else {
+			fmt.Printf("✅ Import file is valid\n")
 		}
-
-		fmt.Printf("✅ Import file is valid\n")
 	}
 
 	options := &profile.ProfileImportOptions{
@@ -826,6 +908,9 @@ func runProfileImportCommand(cmd *cobra.Command, args []string) error {
 		NamePrefix:  profileFlags.Prefix,
 		DefaultTags: profileFlags.ImportTags,
 		Validate:    profileFlags.Validate,
+		SkipInvalid: profileFlags.SkipInvalid,
+		Workers:     profileFlags.ImportWorkers,
+		Progress:    transferProgress("Importing"),
 	}
 
 	if err := manager.ImportFromFile(profileFlags.ImportFile, options); err != nil {
@@ -833,12 +918,129 @@ func runProfileImportCommand(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("✅ Profiles imported successfully from %s\n", profileFlags.ImportFile)
+	if len(skipped) > 0 {
+		fmt.Printf("⚠️  %d invalid profile(s) were not imported\n", len(skipped))
+	}
+
+	return nil
+}
+
+// displayImportValidation prints the per-profile validation report of an import file
+func displayImportValidation(report *profile.ImportValidationReport) {
+	for _, err := range report.Errors {
+		fmt.Printf("❌ %s\n", err)
+	}
+	for _, p := range report.Profiles {
+		switch {
+		case !p.Valid:
+			fmt.Printf("❌ %s\n", p.Name)
+		case len(p.Warnings) > 0:
+			fmt.Printf("⚠️  %s\n", p.Name)
+		default:
+			fmt.Printf("✅ %s\n", p.Name)
+		}
+		for _, err := range p.Errors {
+			fmt.Printf("  • error: %s\n", err)
+		}
+		for _, warning := range p.Warnings {
+			fmt.Printf("  • warning: %s\n", warning)
+		}
+	}
+	fmt.Printf("📊 %d of %d profile(s) valid\n", report.ValidCount(), len(report.Profiles))
+	if report.Workers > 1 {
+		fmt.Printf("⏱️  Validated in %v with %d workers\n", report.Duration.Round(time.Millisecond), report.Workers)
+	} else {
+		fmt.Printf("⏱️  Validated in %v\n", report.Duration.Round(time.Millisecond))
+	}
+}
 
+func runProfileLintCommand(cmd *cobra.Command, args []string) error {
+	if profileFlags.ListRules {
+		for _, rule := range profile.LintRules() {
+			fmt.Printf("  %-20s %s\n", rule.ID, rule.Description)
+		}
+		return nil
+	}
+
+	lintConfig, err := profile.LoadLintConfig(config.SettingsFilePaths()...)
+	if err != nil {
+		return fmt.Errorf("failed to load lint configuration: %w", err)
+	}
+	lintConfig.Disabled = append(lintConfig.Disabled, profileFlags.LintDisable...)
+	if cmd.Flags().Changed("max-concurrency") {
+		lintConfig.MaxConcurrency = profileFlags.LintMaxConcurrency
+	}
+
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
+	var profiles []profile.Profile
+	if len(args) == 0 {
+		profiles, err = manager.ListProfiles(&profile.ProfileListOptions{SortBy: "name", SortOrder: "asc"})
+		if err != nil {
+			return fmt.Errorf("failed to list profiles: %w", err)
+		}
+	} else {
+		for _, name := range args {
+			p, err := manager.GetProfile(name)
+			if err != nil {
+				return fmt.Errorf("failed to get profile: %w", err)
+			}
+			profiles = append(profiles, *p)
+		}
+	}
+
+	if len(profiles) == 0 {
+		fmt.Println("No profiles found")
+		return nil
+	}
+
+	fmt.Printf("🔍 Linting %d profile(s)...\n", len(profiles))
+	warningCount, flagged := 0, 0
+	for i := range profiles {
+		warnings, err := profile.LintProfile(&profiles[i], lintConfig)
+		if err != nil {
+			return err
+		}
+		if len(warnings) == 0 {
+			fmt.Printf("✅ %s\n", profiles[i].Name)
+			continue
+		}
+
+		flagged++
+		warningCount += len(warnings)
+		fmt.Printf("⚠️  %s\n", profiles[i].Name)
+		for _, warning := range warnings {
+			fmt.Printf("  • [%s] %s\n", warning.Rule, warning.Message)
+		}
+	}
+
+	if warningCount == 0 {
+		fmt.Println("✅ No lint warnings")
+		return nil
+	}
+
+	fmt.Printf("📋 %d warning(s) in %d of %d profile(s)\n", warningCount, flagged, len(profiles))
+	if profileFlags.ErrorOnWarn {
+		return fmt.Errorf("profile lint found %d warning(s)", warningCount)
+	}
 	return nil
 }
 
 // Helper functions
 
+// transferProgress reports export/import progress for large profile collections
+// Small collections finish instantly, so they print nothing extra.
+func transferProgress(action string) profile.TransferProgressFunc {
+	return func(done, total int) {
+		if total < profile.ParallelTransferThreshold || (done%25 != 0 && done != total) {
+			return
+		}
+		fmt.Printf("\r📦 %s profiles: %d/%d", action, done, total)
+		if done == total {
+			fmt.Println()
+		}
+	}
+}
+
 func getSyncType(p profile.Profile) string {
 	if p.EpicKey != "" {
 		return "epic"
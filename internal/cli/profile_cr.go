@@ -0,0 +1,406 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+	"github.com/chambrid/jira-cdc-git/pkg/jql"
+	"github.com/chambrid/jira-cdc-git/pkg/profile"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+var profileToCRCmd = &cobra.Command{
+	Use:   "to-cr <profile-name>",
+	Short: "Generate an operator manifest equivalent to a profile",
+	Long: `Print a JIRASync manifest that runs the same sync as a profile in the Kubernetes operator.
+
+JQL and issue-list profiles map to jql, incremental, single, or batch syncs; EPIC profiles
+become a jql sync of the EPIC's issues. The operator pushes to a Git remote, so the destination
+is --git-repo, or the origin remote of the profile's repository. With --schedule, the JIRASync
+is wrapped in a SyncSchedule that runs it on a cron schedule.
+
+The manifest is checked against the CRD schema before it is printed. Profile options without an
+operator equivalent (such as concurrency or field exclusions) are reported and left out.`,
+	Example: `  # Print a JIRASync for a profile
+  jira-sync profile to-cr my-epic --git-repo=git@github.com:team/issues.git
+
+  # Write a nightly recurring sync to a file
+  jira-sync profile to-cr nightly --schedule="0 2 * * *" --namespace=jira --output=nightly.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileToCRCommand,
+}
+
+var profileToCRFlags struct {
+	GitRepo    string
+	Branch     string
+	Namespace  string
+	Name       string
+	Schedule   string
+	MaxRetries int
+	Output     string
+}
+
+func init() {
+	profileCmd.AddCommand(profileToCRCmd)
+
+	profileToCRCmd.Flags().StringVar(&profileToCRFlags.GitRepo, "git-repo", "", "Git remote the operator pushes to, HTTPS or SSH (default: origin remote of the profile's repository)")
+	profileToCRCmd.Flags().StringVar(&profileToCRFlags.Branch, "branch", "", "Target Git branch (default: the operator's default, main)")
+	profileToCRCmd.Flags().StringVar(&profileToCRFlags.Namespace, "namespace", "", "Namespace of the generated resource")
+	profileToCRCmd.Flags().StringVar(&profileToCRFlags.Name, "name", "", "Resource name (default: the profile name)")
+	profileToCRCmd.Flags().StringVar(&profileToCRFlags.Schedule, "schedule", "", "Cron schedule; emits a SyncSchedule running the sync recurringly")
+	profileToCRCmd.Flags().IntVar(&profileToCRFlags.MaxRetries, "max-retries", 0, "Retry attempts for failed syncs (0-10, default: operator default)")
+	profileToCRCmd.Flags().StringVar(&profileToCRFlags.Output, "output", "", "Write the manifest to a file instead of stdout")
+}
+
+// Patterns of the JIRASync CRD schema (crds/v1alpha1/jirasync-crd.yaml)
+var (
+	crIssueKeyPattern   = regexp.MustCompile(`^[A-Z][A-Z0-9]*-[1-9][0-9]*$`)
+	crJQLPattern        = regexp.MustCompile(`^[^;\\<>"\x00-\x1f]*$`)
+	crRepositoryPattern = regexp.MustCompile(`^(https://[a-zA-Z0-9][a-zA-Z0-9.-]*[a-zA-Z0-9]/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+(\.git)?|git@[a-zA-Z0-9][a-zA-Z0-9.-]*[a-zA-Z0-9]:[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+(\.git)?)$`)
+	crBranchPattern     = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9/_.-]*[a-zA-Z0-9]$|^[a-zA-Z0-9]$`)
+	crSchedulePattern   = regexp.MustCompile(`^(@(annually|yearly|monthly|weekly|daily|hourly|reboot))|(@every (\d+(ns|us|µs|ms|s|m|h))+)|(((\d+,)+\d+|(\d+(/|-)\d+)|\d+|\*) +){4,5}((\d+,)+\d+|(\d+(/|-)\d+)|\d+|\*)$`)
+)
+
+// CR limits of the JIRASync CRD schema
+const (
+	crMaxIssueKeys = 100
+	crMaxJQLLength = 1000
+	crMaxRetries   = 10
+
+	crMaxCheckpointInterval = 10000
+)
+
+// crManifest is a generated custom resource
+type crManifest struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Metadata   crMetadata `json:"metadata"`
+	Spec       any        `json:"spec"`
+}
+
+type crMetadata struct {
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// syncScheduleSpec is the part of the SyncSchedule CRD emitted for --schedule
+type syncScheduleSpec struct {
+	Schedule     string           `json:"schedule"`
+	SyncTemplate syncTemplateSpec `json:"syncTemplate"`
+}
+
+type syncTemplateSpec struct {
+	Spec operatortypes.JIRASyncSpec `json:"spec"`
+}
+
+// profileCROptions are the operator settings that profiles do not carry
+type profileCROptions struct {
+	GitRepo    string
+	Branch     string
+	Namespace  string
+	Name       string
+	Schedule   string
+	MaxRetries int // 0 leaves the operator default
+}
+
+func runProfileToCRCommand(cmd *cobra.Command, args []string) error {
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
+	p, err := manager.GetProfile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	options := profileCROptions{
+		GitRepo:    profileToCRFlags.GitRepo,
+		Branch:     profileToCRFlags.Branch,
+		Namespace:  profileToCRFlags.Namespace,
+		Name:       profileToCRFlags.Name,
+		Schedule:   profileToCRFlags.Schedule,
+		MaxRetries: profileToCRFlags.MaxRetries,
+	}
+	if options.GitRepo == "" {
+		remote, err := originRemoteURL(p.Repository)
+		if err != nil {
+			return fmt.Errorf("no --git-repo given and %w", err)
+		}
+		options.GitRepo = remote
+	}
+
+	manifest, warnings, err := profileToManifest(p, options)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if profileToCRFlags.Output == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+	if err := os.WriteFile(profileToCRFlags.Output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	fmt.Printf("✅ Wrote %s %s to %s\n", manifest.Kind, manifest.Metadata.Name, profileToCRFlags.Output)
+	return nil
+}
+
+// profileToManifest builds the JIRASync (or SyncSchedule) equivalent to a profile
+// It returns warnings for profile options the operator cannot express, and an error when the
+// manifest would not pass the CRD schema.
+func profileToManifest(p *profile.Profile, options profileCROptions) (*crManifest, []string, error) {
+	spec, warnings, err := profileToJIRASyncSpec(p, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if problems := validateJIRASyncSpecShape(spec); len(problems) > 0 {
+		return nil, nil, fmt.Errorf("profile %s does not map to a valid JIRASync:\n  • %s", p.Name, strings.Join(problems, "\n  • "))
+	}
+
+	name := options.Name
+	if name == "" {
+		name = resourceName(p.Name)
+	}
+	if errs := k8svalidation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return nil, nil, fmt.Errorf("invalid resource name %q: %s", name, errs[0])
+	}
+
+	manifest := &crManifest{
+		APIVersion: operatortypes.GroupVersion.String(),
+		Kind:       "JIRASync",
+		Metadata: crMetadata{
+			Name:        name,
+			Namespace:   options.Namespace,
+			Annotations: map[string]string{"sync.jira.io/profile": p.Name},
+		},
+		Spec: spec,
+	}
+
+	if options.Schedule != "" {
+		if !crSchedulePattern.MatchString(options.Schedule) {
+			return nil, nil, fmt.Errorf("invalid --schedule %q: expected a cron expression such as \"0 2 * * *\" or @daily", options.Schedule)
+		}
+		manifest.Kind = "SyncSchedule"
+		manifest.Spec = syncScheduleSpec{
+			Schedule:     options.Schedule,
+			SyncTemplate: syncTemplateSpec{Spec: *spec},
+		}
+	}
+
+	return manifest, warnings, nil
+}
+
+// profileToJIRASyncSpec maps a profile's scope and options onto a JIRASync spec
+func profileToJIRASyncSpec(p *profile.Profile, options profileCROptions) (*operatortypes.JIRASyncSpec, []string, error) {
+	spec := &operatortypes.JIRASyncSpec{
+		Destination: operatortypes.GitDestination{
+			Repository: options.GitRepo,
+			Branch:     options.Branch,
+		},
+	}
+
+	switch {
+	case p.EpicKey != "":
+		// The operator has no EPIC sync type; the EPIC's issues are selected by JQL
+		scoped, err := jql.ScopeToEpic(p.EpicKey, p.EpicWithin)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid EPIC configuration: %w", err)
+		}
+		spec.SyncType = "jql"
+		spec.Target.JQLQuery = crJQL(scoped)
+	case p.JQL != "":
+		spec.SyncType = "jql"
+		spec.Target.JQLQuery = crJQL(p.JQL)
+	case len(p.IssueKeys) == 1:
+		spec.SyncType = "single"
+		spec.Target.IssueKeys = p.IssueKeys
+	case len(p.IssueKeys) > 1:
+		spec.SyncType = "batch"
+		spec.Target.IssueKeys = p.IssueKeys
+	default:
+		return nil, nil, fmt.Errorf("profile does not specify any sync mode (JQL, EPIC, or issue keys)")
+	}
+
+	// Incremental syncs of a query keep their state between operator runs
+	if p.Options.Incremental && spec.Target.JQLQuery != "" {
+		spec.SyncType = "incremental"
+	}
+
+	if options.MaxRetries != 0 {
+		spec.RetryPolicy = &operatortypes.RetryPolicy{MaxRetries: options.MaxRetries}
+	}
+	if p.Options.CheckpointInterval > 0 {
+		spec.Checkpoint = &operatortypes.CheckpointSpec{Interval: p.Options.CheckpointInterval}
+	}
+
+	return spec, unmappedProfileOptions(p), nil
+}
+
+// crJQL rewrites double-quoted JQL strings with single quotes, which the CRD allows
+// Queries that already use single quotes are left as they are.
+func crJQL(query string) string {
+	if strings.Contains(query, "'") {
+		return query
+	}
+	return strings.ReplaceAll(query, `"`, "'")
+}
+
+// unmappedProfileOptions lists the profile options a JIRASync cannot express
+func unmappedProfileOptions(p *profile.Profile) []string {
+	options := p.Options
+	var unmapped []string
+	add := func(set bool, name string) {
+		if set {
+			unmapped = append(unmapped, name)
+		}
+	}
+	add(options.Concurrency != 0, "concurrency")
+	add(options.RateLimit != "", "rate_limit")
+	add(options.Force, "force")
+	add(options.DryRun, "dry_run")
+	add(options.Incremental && p.JQL == "" && p.EpicKey == "", "incremental (issue lists always sync fully)")
+	add(options.OnDirty != "", "on_dirty")
+	add(len(options.ExcludeFields) > 0, "exclude_fields")
+	add(options.OrderBy != "", "order_by")
+	add(options.GenerateIndex, "generate_index")
+	add(options.GenerateRelationshipIndex, "generate_relationship_index")
+	add(options.Checksums, "checksums")
+	add(options.Sign, "sign")
+	add(options.WriteStatusBadge, "write_status_badge")
+	add(options.HandleDeletions, "handle_deletions")
+	add(options.Prune, "prune")
+	add(options.LinkConcurrency != 0, "link_concurrency")
+	add(options.LinkNaming != "", "link_naming")
+	add(options.BulkFetchSize != 0, "bulk_fetch_size")
+	add(options.Transform != "", "transform")
+	add(options.FilterExpr != "", "filter_expr")
+	add(options.MergeUpdate, "merge_update")
+	add(options.ADFRender != "", "adf_render")
+	add(options.PermissionCheck != "", "permission_check")
+	// Jobs with a checkpoint always use batch commits
+	add(options.CommitStrategy != "" && (options.CommitStrategy != "batch" || options.CheckpointInterval == 0), "commit_strategy")
+	add(options.RampUp != "", "ramp_up")
+	add(options.FlattenFields != "", "flatten_fields")
+	add(options.NoFlatten, "no_flatten")
+	add(options.NormalizeTimestamps != "", "normalize_timestamps")
+	add(options.FieldOrder != "", "field_order")
+	add(options.StatusTransitionsOnly, "status_transitions_only")
+	add(options.GitRetries != 0 || options.GitRetryBackoff != "", "git_retries")
+	add(options.MaxFiles != 0 || options.NoFileLimit, "max_files")
+	add(options.MaxAPICalls != 0 || options.MaxDuration != "", "max_api_calls")
+	add(options.Minimal, "minimal")
+	add(options.QuietHours != "", "quiet_hours")
+	add(options.Format != "" && options.Format != SyncFormatYAML, "format")
+	add(options.FrontmatterFields != "", "frontmatter_fields")
+
+	var warnings []string
+	if len(unmapped) > 0 {
+		warnings = append(warnings, fmt.Sprintf("profile options without an operator equivalent are not included: %s", strings.Join(unmapped, ", ")))
+	}
+	if len(p.Destinations) > 0 {
+		warnings = append(warnings, fmt.Sprintf("only the primary repository is included; generate a resource per destination for the other %d", len(p.Destinations)))
+	}
+	return warnings
+}
+
+// validateJIRASyncSpecShape checks a spec against the constraints of the JIRASync CRD schema,
+// so a generated manifest is not rejected by the API server
+func validateJIRASyncSpecShape(spec *operatortypes.JIRASyncSpec) []string {
+	var problems []string
+
+	switch spec.SyncType {
+	case "single", "batch":
+		if len(spec.Target.IssueKeys) == 0 {
+			problems = append(problems, fmt.Sprintf("target.issueKeys is required for %s syncs", spec.SyncType))
+		}
+	case "jql", "incremental":
+		if spec.Target.JQLQuery == "" && spec.Target.ProjectKey == "" {
+			problems = append(problems, fmt.Sprintf("target.jqlQuery is required for %s syncs", spec.SyncType))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("syncType %q must be one of single, batch, jql, incremental", spec.SyncType))
+	}
+
+	if len(spec.Target.IssueKeys) > crMaxIssueKeys {
+		problems = append(problems, fmt.Sprintf("target.issueKeys has %d keys, at most %d are allowed (use a JQL profile)", len(spec.Target.IssueKeys), crMaxIssueKeys))
+	}
+	for _, key := range spec.Target.IssueKeys {
+		if !crIssueKeyPattern.MatchString(key) {
+			problems = append(problems, fmt.Sprintf("target.issueKeys: %q is not an issue key", key))
+		}
+	}
+
+	if query := spec.Target.JQLQuery; query != "" {
+		if len(query) > crMaxJQLLength {
+			problems = append(problems, fmt.Sprintf("target.jqlQuery is %d characters, at most %d are allowed", len(query), crMaxJQLLength))
+		}
+		if !crJQLPattern.MatchString(query) {
+			problems = append(problems, "target.jqlQuery cannot contain ; \\ < > \" or control characters (rewrite comparisons such as < as ranges or functions)")
+		}
+	}
+
+	if !crRepositoryPattern.MatchString(spec.Destination.Repository) {
+		problems = append(problems, fmt.Sprintf("destination.repository %q must be an HTTPS (https://host/owner/repo) or SSH (git@host:owner/repo) URL", spec.Destination.Repository))
+	}
+	if branch := spec.Destination.Branch; branch != "" && (len(branch) > 100 || !crBranchPattern.MatchString(branch)) {
+		problems = append(problems, fmt.Sprintf("destination.branch %q is not a valid branch name", branch))
+	}
+
+	if policy := spec.RetryPolicy; policy != nil && (policy.MaxRetries < 0 || policy.MaxRetries > crMaxRetries) {
+		problems = append(problems, fmt.Sprintf("retryPolicy.maxRetries must be between 0 and %d", crMaxRetries))
+	}
+	if checkpoint := spec.Checkpoint; checkpoint != nil && (checkpoint.Interval < 1 || checkpoint.Interval > crMaxCheckpointInterval) {
+		problems = append(problems, fmt.Sprintf("checkpoint.interval must be between 1 and %d", crMaxCheckpointInterval))
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// resourceName turns a profile name into a Kubernetes resource name
+func resourceName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	result := strings.Trim(b.String(), "-.")
+	if len(result) > k8svalidation.DNS1123SubdomainMaxLength {
+		result = strings.Trim(result[:k8svalidation.DNS1123SubdomainMaxLength], "-.")
+	}
+	return result
+}
+
+// originRemoteURL returns the URL of the origin remote of a local repository
+func originRemoteURL(repoPath string) (string, error) {
+	repo, err := gogit.PlainOpen(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("profile repository %s is not a Git repository: %w", repoPath, err)
+	}
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return "", fmt.Errorf("profile repository %s has no origin remote: %w", repoPath, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("origin remote of %s has no URL", repoPath)
+	}
+	return urls[0], nil
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+	"github.com/chambrid/jira-cdc-git/pkg/profile"
+)
+
+func TestProfileToManifest(t *testing.T) {
+	options := profileCROptions{GitRepo: "git@github.com:team/issues.git"}
+
+	tests := []struct {
+		name     string
+		profile  profile.Profile
+		syncType string
+		jql      string
+	}{
+		{"epic", profile.Profile{Name: "Epic Work", EpicKey: "PROJ-1"}, "jql", "'Epic Link' = PROJ-1"},
+		{"jql", profile.Profile{Name: "open", JQL: `project = PROJ AND status = "In Progress"`}, "jql", "project = PROJ AND status = 'In Progress'"},
+		{"incremental", profile.Profile{Name: "inc", JQL: "project = PROJ", Options: profile.ProfileOptions{Incremental: true}}, "incremental", "project = PROJ"},
+		{"single", profile.Profile{Name: "one", IssueKeys: []string{"PROJ-1"}}, "single", ""},
+		{"batch", profile.Profile{Name: "two", IssueKeys: []string{"PROJ-1", "PROJ-2"}}, "batch", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, _, err := profileToManifest(&tt.profile, options)
+			if err != nil {
+				t.Fatalf("profileToManifest() error = %v", err)
+			}
+			if manifest.Kind != "JIRASync" {
+				t.Errorf("Kind = %s, want JIRASync", manifest.Kind)
+			}
+			spec := manifest.Spec.(*operatortypes.JIRASyncSpec)
+			if spec.SyncType != tt.syncType {
+				t.Errorf("SyncType = %s, want %s", spec.SyncType, tt.syncType)
+			}
+			if tt.jql != "" && !strings.HasPrefix(spec.Target.JQLQuery, tt.jql) {
+				t.Errorf("JQLQuery = %q, want prefix %q", spec.Target.JQLQuery, tt.jql)
+			}
+			if strings.Contains(manifest.Metadata.Name, " ") {
+				t.Errorf("Name %q is not a resource name", manifest.Metadata.Name)
+			}
+		})
+	}
+}
+
+func TestProfileToManifest_Schedule(t *testing.T) {
+	p := &profile.Profile{Name: "nightly", JQL: "project = PROJ", Options: profile.ProfileOptions{Concurrency: 8}}
+	options := profileCROptions{GitRepo: "https://github.com/team/issues.git", Schedule: "0 2 * * *", MaxRetries: 3}
+
+	manifest, warnings, err := profileToManifest(p, options)
+	if err != nil {
+		t.Fatalf("profileToManifest() error = %v", err)
+	}
+	if manifest.Kind != "SyncSchedule" {
+		t.Fatalf("Kind = %s, want SyncSchedule", manifest.Kind)
+	}
+	spec := manifest.Spec.(syncScheduleSpec)
+	if spec.Schedule != "0 2 * * *" || spec.SyncTemplate.Spec.RetryPolicy.MaxRetries != 3 {
+		t.Errorf("unexpected schedule spec: %+v", spec)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "concurrency") {
+		t.Errorf("expected a warning about concurrency, got %v", warnings)
+	}
+
+	options.Schedule = "every night"
+	if _, _, err := profileToManifest(p, options); err == nil {
+		t.Error("expected an invalid schedule to be rejected")
+	}
+}
+
+func TestProfileToManifest_Checkpoint(t *testing.T) {
+	p := &profile.Profile{Name: "big", JQL: "project = PROJ", Options: profile.ProfileOptions{CommitStrategy: "batch", CheckpointInterval: 50}}
+
+	manifest, warnings, err := profileToManifest(p, profileCROptions{GitRepo: "git@github.com:team/issues.git"})
+	if err != nil {
+		t.Fatalf("profileToManifest() error = %v", err)
+	}
+	spec := manifest.Spec.(*operatortypes.JIRASyncSpec)
+	if spec.Checkpoint == nil || spec.Checkpoint.Interval != 50 {
+		t.Errorf("Checkpoint = %+v, want interval 50", spec.Checkpoint)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected batch commits with a checkpoint to map fully, got %v", warnings)
+	}
+}
+
+func TestProfileToManifest_InvalidShape(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile profile.Profile
+		options profileCROptions
+		want    string
+	}{
+		{"local repository", profile.Profile{Name: "p", JQL: "project = PROJ"}, profileCROptions{GitRepo: "/srv/issues"}, "destination.repository"},
+		{"mixed quotes", profile.Profile{Name: "p", JQL: `summary ~ "it's"`}, profileCROptions{GitRepo: "git@github.com:a/b.git"}, "target.jqlQuery"},
+		{"comparison", profile.Profile{Name: "p", JQL: "created > -7d"}, profileCROptions{GitRepo: "git@github.com:a/b.git"}, "target.jqlQuery"},
+		{"retries", profile.Profile{Name: "p", JQL: "project = PROJ"}, profileCROptions{GitRepo: "git@github.com:a/b.git", MaxRetries: 11}, "retryPolicy.maxRetries"},
+		{"bad key", profile.Profile{Name: "p", IssueKeys: []string{"proj-1"}}, profileCROptions{GitRepo: "git@github.com:a/b.git"}, "target.issueKeys"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := profileToManifest(&tt.profile, tt.options)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("profileToManifest() error = %v, want mention of %s", err, tt.want)
+			}
+		})
+	}
+}
+
+// The shape checks mirror the CRD; fail loudly if the CRD's sync types change underneath them
+func TestValidateJIRASyncSpecShape_MatchesCRD(t *testing.T) {
+	crd, err := os.ReadFile("../../crds/v1alpha1/jirasync-crd.yaml")
+	if err != nil {
+		t.Skipf("CRD not available: %v", err)
+	}
+	if !strings.Contains(string(crd), `enum: ["single", "batch", "jql", "incremental"]`) {
+		t.Error("CRD syncType enum changed; update validateJIRASyncSpecShape")
+	}
+	if !strings.Contains(string(crd), "maxItems: 100") {
+		t.Error("CRD issueKeys limit changed; update crMaxIssueKeys")
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+)
+
+// destinationOutcome is the result of syncing a profile into one of its repositories
+type destinationOutcome struct {
+	Label  string
+	Result *sync.BatchResult
+	Err    error
+}
+
+// printDestinationResults summarizes a profile sync per destination repository
+func printDestinationResults(outcomes []destinationOutcome) {
+	fmt.Printf("🎯 Destination Results:\n")
+	for _, outcome := range outcomes {
+		switch {
+		case outcome.Err != nil:
+			fmt.Printf("  ❌ %s: %v\n", outcome.Label, outcome.Err)
+		case outcome.Result.FailedSync > 0:
+			fmt.Printf("  ⚠️  %s: %d synced, %d failed\n", outcome.Label, outcome.Result.SuccessfulSync, outcome.Result.FailedSync)
+		default:
+			fmt.Printf("  ✅ %s: %d synced\n", outcome.Label, outcome.Result.SuccessfulSync)
+		}
+	}
+}
+
+// firstDestinationError returns the first destination's error, labelled with the destination
+func firstDestinationError(outcomes []destinationOutcome) error {
+	return firstOutcomeError(outcomes, "destination")
+}
+
+// firstOutcomeError returns the first failed repository's error, labelled with the given noun
+func firstOutcomeError(outcomes []destinationOutcome, noun string) error {
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			return fmt.Errorf("%s %s: %w", noun, outcome.Label, outcome.Err)
+		}
+	}
+	return nil
+}
+
+// destinationsError returns the exit error for a profile synced to total destinations
+func destinationsError(ctx context.Context, outcomes []destinationOutcome, total int) error {
+	return outcomesError(ctx, outcomes, total, "destination")
+}
+
+// outcomesError returns the exit error for a run that synced total repositories
+// A repository that could not sync makes the run a partial failure, or a total failure when
+// none synced; otherwise issue failures across repositories decide as for one repository.
+func outcomesError(ctx context.Context, outcomes []destinationOutcome, total int, noun string) error {
+	combined := combineOutcomes(outcomes)
+	failed := 0
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			failed++
+		}
+	}
+
+	if ctx.Err() != nil {
+		return &ExitError{Code: ExitInterrupted, Err: fmt.Errorf("sync interrupted after %d of %d %ss",
+			len(outcomes), total, noun)}
+	}
+	if failed == total {
+		return &ExitError{Code: ExitTotalFailure, Err: fmt.Errorf("all %d %ss failed to sync: %w",
+			total, noun, firstOutcomeError(outcomes, noun))}
+	}
+	if failed > 0 {
+		return &ExitError{Code: ExitPartialFailure, Err: fmt.Errorf("%d of %d %ss failed to sync: %w",
+			failed, total, noun, firstOutcomeError(outcomes, noun))}
+	}
+	return syncResultError(ctx, combined)
+}
+
+// combineOutcomes adds up the issue counts of the repositories that synced
+func combineOutcomes(outcomes []destinationOutcome) *sync.BatchResult {
+	combined := &sync.BatchResult{}
+	for _, outcome := range outcomes {
+		if outcome.Err != nil || outcome.Result == nil {
+			continue
+		}
+		combined.TotalIssues += outcome.Result.TotalIssues
+		combined.ProcessedIssues += outcome.Result.ProcessedIssues
+		combined.SuccessfulSync += outcome.Result.SuccessfulSync
+		combined.FailedSync += outcome.Result.FailedSync
+	}
+	return combined
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// profiler collects the pprof CPU/heap profiles and execution trace requested for a command run
+type profiler struct {
+	cpuFile   *os.File
+	traceFile *os.File
+	memPath   string
+
+	once sync.Once
+}
+
+// startProfiling starts the profiles requested by --cpuprofile, --memprofile and --trace
+// The returned stop function flushes and closes every profile; it is safe to call more than once.
+// Callers defer it: an interrupt (Ctrl-C or SIGTERM) stops the sync gracefully, so the profiles
+// are flushed on the way out, after the sync's own cleanup. Without any of the flags nothing is
+// started and stop is a no-op, so disabled profiling costs nothing.
+func startProfiling(cmd *cobra.Command) (func(), error) {
+	cpuPath, _ := cmd.Flags().GetString("cpuprofile")
+	memPath, _ := cmd.Flags().GetString("memprofile")
+	tracePath, _ := cmd.Flags().GetString("trace")
+	if cpuPath == "" && memPath == "" && tracePath == "" {
+		return func() {}, nil
+	}
+
+	p := &profiler{memPath: memPath}
+
+	if cpuPath != "" {
+		file, err := os.Create(cpuPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		p.cpuFile = file
+	}
+
+	if tracePath != "" {
+		file, err := os.Create(tracePath)
+		if err != nil {
+			p.stop()
+			return nil, fmt.Errorf("failed to create trace file: %w", err)
+		}
+		if err := trace.Start(file); err != nil {
+			_ = file.Close()
+			p.stop()
+			return nil, fmt.Errorf("failed to start execution trace: %w", err)
+		}
+		p.traceFile = file
+	}
+
+	fmt.Println("📈 Profiling enabled")
+	return p.stop, nil
+}
+
+// stop finishes all profiles once, reporting (but not failing on) write errors
+func (p *profiler) stop() {
+	p.once.Do(func() {
+		if p.cpuFile != nil {
+			pprof.StopCPUProfile()
+			closeProfile(p.cpuFile, "CPU profile")
+		}
+
+		if p.traceFile != nil {
+			trace.Stop()
+			closeProfile(p.traceFile, "execution trace")
+		}
+
+		if p.memPath != "" {
+			file, err := os.Create(p.memPath)
+			if err != nil {
+				fmt.Printf("⚠️  Failed to create memory profile: %v\n", err)
+				return
+			}
+			runtime.GC() // up-to-date allocation statistics
+			if err := pprof.WriteHeapProfile(file); err != nil {
+				fmt.Printf("⚠️  Failed to write memory profile: %v\n", err)
+			}
+			closeProfile(file, "memory profile")
+		}
+	})
+}
+
+// closeProfile closes a profile file and reports where it was written
+func closeProfile(file *os.File, description string) {
+	if err := file.Close(); err != nil {
+		fmt.Printf("⚠️  Failed to close %s: %v\n", description, err)
+		return
+	}
+	fmt.Printf("📈 Wrote %s to %s\n", description, file.Name())
+}
@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	gosync "sync"
+	"syscall"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+)
+
+const (
+	// progressWriteTimeout drops a client that stops reading instead of stalling progress
+	progressWriteTimeout = time.Second
+	// progressClientBuffer is how many updates are queued for a client before new ones are dropped
+	progressClientBuffer = 64
+	// progressPipePollInterval is how often a named pipe is checked for a reader
+	progressPipePollInterval = 250 * time.Millisecond
+)
+
+// progressClient is a connected progress consumer: a socket connection or an open named pipe
+type progressClient interface {
+	io.WriteCloser
+	SetWriteDeadline(t time.Time) error
+}
+
+// progressSubscriber queues updates for one client, written by its own goroutine
+type progressSubscriber struct {
+	client  progressClient
+	updates chan []byte
+}
+
+// progressSocket publishes sync progress as newline-delimited JSON to local clients
+// It listens on a Unix domain socket, or writes to an existing named pipe once a reader opens
+// it. Publishing never blocks the sync: without clients updates are discarded, a client that
+// falls behind misses updates while its queue is full, and a client that disconnects or stops
+// reading is dropped.
+type progressSocket struct {
+	path     string
+	listener net.Listener  // nil for a named pipe
+	done     chan struct{} // closed by Close
+	wg       gosync.WaitGroup
+
+	mu      gosync.Mutex
+	clients map[*progressSubscriber]bool
+	closed  bool
+}
+
+// newProgressSocket starts publishing progress at path
+// An existing named pipe is written to; otherwise a Unix socket is created, replacing a stale
+// socket left by an earlier run. Any other existing file is refused.
+func newProgressSocket(path string) (*progressSocket, error) {
+	s := &progressSocket{
+		path:    path,
+		done:    make(chan struct{}),
+		clients: make(map[*progressSubscriber]bool),
+	}
+
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.Mode()&os.ModeNamedPipe != 0:
+		s.wg.Add(1)
+		go s.openPipe()
+		return s, nil
+	case err == nil && info.Mode()&os.ModeSocket != 0:
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale progress socket %s: %w", path, err)
+		}
+	case err == nil:
+		return nil, fmt.Errorf("progress socket path %s exists and is not a socket or named pipe", path)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to check progress socket path %s: %w", path, err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on progress socket %s: %w", path, err)
+	}
+	s.listener = listener
+	s.wg.Add(1)
+	go s.accept()
+	return s, nil
+}
+
+// accept adds socket clients until the socket is closed
+func (s *progressSocket) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return // listener closed
+		}
+		s.addClient(conn.(*net.UnixConn))
+	}
+}
+
+// openPipe waits for a reader on the named pipe, and again after a reader goes away
+// Opening a pipe without a reader fails with O_NONBLOCK, so it is retried instead of blocking.
+func (s *progressSocket) openPipe() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(progressPipePollInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.clientCount() == 0 {
+			if pipe, err := os.OpenFile(s.path, os.O_WRONLY|syscall.O_NONBLOCK, 0); err == nil {
+				s.addClient(pipe)
+			}
+		}
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *progressSocket) addClient(client progressClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		_ = client.Close()
+		return
+	}
+	sub := &progressSubscriber{client: client, updates: make(chan []byte, progressClientBuffer)}
+	s.clients[sub] = true
+	s.wg.Add(1)
+	go s.write(sub)
+}
+
+// write sends a client its queued updates until it fails or the socket is closed
+// On Close, updates already queued are still written so the client sees the final progress.
+func (s *progressSocket) write(sub *progressSubscriber) {
+	defer s.wg.Done()
+	defer func() { _ = sub.client.Close() }()
+	for {
+		select {
+		case line := <-sub.updates:
+			if !s.send(sub, line) {
+				return
+			}
+		case <-s.done:
+			for {
+				select {
+				case line := <-sub.updates:
+					if !s.send(sub, line) {
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// send writes one update, dropping the client if the write fails or times out
+func (s *progressSocket) send(sub *progressSubscriber, line []byte) bool {
+	_ = sub.client.SetWriteDeadline(time.Now().Add(progressWriteTimeout))
+	if _, err := sub.client.Write(line); err != nil {
+		s.mu.Lock()
+		delete(s.clients, sub)
+		s.mu.Unlock()
+		return false
+	}
+	return true
+}
+
+func (s *progressSocket) clientCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clients)
+}
+
+// Publish queues an update for every connected client
+// A client whose queue is full misses this update; the lock is held only to list the clients.
+func (s *progressSocket) Publish(update sync.ProgressUpdate) {
+	if s == nil {
+		return
+	}
+	line, err := json.Marshal(update)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	subs := make([]*progressSubscriber, 0, len(s.clients))
+	for sub := range s.clients {
+		subs = append(subs, sub)
+	}
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.updates <- line:
+		default:
+		}
+	}
+}
+
+// Close flushes queued updates, disconnects all clients and removes the socket
+func (s *progressSocket) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	s.clients = nil
+	s.mu.Unlock()
+
+	var err error
+	if s.listener != nil {
+		// Closing a Unix listener also removes its socket file
+		err = s.listener.Close()
+	}
+	s.wg.Wait()
+	return err
+}
+
+// streamProgress publishes an engine's progress updates until its channel is closed
+// It returns a function that closes the channel, once the sync is done, and waits for the
+// remaining updates to be published. Without a socket, it does nothing.
+func streamProgress(engine *sync.BatchSyncEngine, socket *progressSocket) func() {
+	if socket == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range engine.GetProgressChannel() {
+			socket.Publish(update)
+		}
+	}()
+	return func() {
+		engine.CloseProgressChannel()
+		<-done
+	}
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+)
+
+func TestProgressSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.sock")
+	socket, err := newProgressSocket(path)
+	if err != nil {
+		t.Fatalf("newProgressSocket() error = %v", err)
+	}
+
+	// Publishing without clients is a no-op
+	socket.Publish(sync.ProgressUpdate{CurrentIssue: "PROJ-1"})
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	waitForClients(t, socket, 1)
+
+	socket.Publish(sync.ProgressUpdate{CurrentIssue: "PROJ-2", ProcessedCount: 2, TotalCount: 4, Percentage: 50})
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("failed to read update: %v", err)
+	}
+	var update sync.ProgressUpdate
+	if err := json.Unmarshal(line, &update); err != nil {
+		t.Fatalf("update is not JSON: %v", err)
+	}
+	if update.CurrentIssue != "PROJ-2" || update.Percentage != 50 {
+		t.Errorf("unexpected update: %+v", update)
+	}
+
+	// A client that goes away is dropped on the next publish
+	_ = conn.Close()
+	for i := 0; i < 10 && socket.clientCount() > 0; i++ {
+		socket.Publish(sync.ProgressUpdate{CurrentIssue: "PROJ-3"})
+		time.Sleep(10 * time.Millisecond)
+	}
+	if socket.clientCount() != 0 {
+		t.Error("expected the disconnected client to be dropped")
+	}
+
+	if err := socket.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected the socket file to be removed")
+	}
+	socket.Publish(sync.ProgressUpdate{CurrentIssue: "PROJ-4"})
+}
+
+func TestNewProgressSocket_ExistingPath(t *testing.T) {
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "progress.json")
+	if err := os.WriteFile(file, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newProgressSocket(file); err == nil {
+		t.Error("expected a regular file to be refused")
+	}
+
+	// A socket left by an earlier run is replaced
+	stale := filepath.Join(dir, "stale.sock")
+	listener, err := net.Listen("unix", stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = listener.Close()
+
+	socket, err := newProgressSocket(stale)
+	if err != nil {
+		t.Fatalf("newProgressSocket() error = %v", err)
+	}
+	_ = socket.Close()
+}
+
+// stalledClient never finishes a write until it is released
+type stalledClient struct {
+	release chan struct{}
+}
+
+func (c *stalledClient) Write(p []byte) (int, error) {
+	<-c.release
+	return len(p), nil
+}
+
+func (c *stalledClient) Close() error                     { return nil }
+func (c *stalledClient) SetWriteDeadline(time.Time) error { return nil }
+
+func TestProgressSocket_SlowClientDoesNotBlockPublish(t *testing.T) {
+	socket, err := newProgressSocket(filepath.Join(t.TempDir(), "progress.sock"))
+	if err != nil {
+		t.Fatalf("newProgressSocket() error = %v", err)
+	}
+	stalled := &stalledClient{release: make(chan struct{})}
+	socket.addClient(stalled)
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 0; i < progressClientBuffer*4; i++ {
+			socket.Publish(sync.ProgressUpdate{ProcessedCount: i})
+		}
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a client that does not read")
+	}
+
+	close(stalled.release)
+	if err := socket.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func waitForClients(t *testing.T, socket *progressSocket, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for socket.clientCount() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d client(s)", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	gosync "sync"
+	"syscall"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/chambrid/jira-cdc-git/pkg/jql"
+	"github.com/chambrid/jira-cdc-git/pkg/profile"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+	"github.com/spf13/cobra"
+)
+
+// DefaultProjectConcurrency is how many projects --projects syncs at the same time
+const DefaultProjectConcurrency = 2
+
+// projectKeyRegex matches a JIRA project key (PROJ, MY_PROJ, ABC2)
+var projectKeyRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// parseProjectList parses comma-separated project keys, upper-casing and deduplicating them
+func parseProjectList(value string) ([]string, error) {
+	var projects []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(value, ",") {
+		key := strings.ToUpper(strings.TrimSpace(field))
+		if key == "" || seen[key] {
+			continue
+		}
+		if !projectKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("project key '%s' does not match JIRA format (e.g., PROJ)", key)
+		}
+		seen[key] = true
+		projects = append(projects, key)
+	}
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("--projects requires at least one project key")
+	}
+	return projects, nil
+}
+
+// projectSyncProfiles builds one profile per project: a full-project query synced into
+// {repoBase}/{project} with the options shared by every project
+func projectSyncProfiles(projects []string, repoBase, orderBy string, explicitOrder bool, options profile.ProfileOptions) ([]*profile.Profile, error) {
+	profiles := make([]*profile.Profile, 0, len(projects))
+	for _, project := range projects {
+		query, err := orderSyncJQL(fmt.Sprintf("project = %s", project), orderBy, explicitOrder)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, &profile.Profile{
+			Name:       project,
+			JQL:        query,
+			Repository: filepath.Join(repoBase, project),
+			Options:    options,
+		})
+	}
+	return profiles, nil
+}
+
+// projectSyncOptions reads the per-repository sync flags that --projects applies to every project
+func projectSyncOptions(cmd *cobra.Command) profile.ProfileOptions {
+	options := profile.ProfileOptions{}
+	options.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	options.Incremental, _ = cmd.Flags().GetBool("incremental")
+	options.Force, _ = cmd.Flags().GetBool("force")
+	options.DryRun, _ = cmd.Flags().GetBool("dry-run")
+	options.OnDirty, _ = cmd.Flags().GetString("on-dirty")
+	excludeFields, _ := cmd.Flags().GetStringArray("exclude-fields")
+	options.ExcludeFields = schema.ParseFieldPatterns(excludeFields...)
+	options.Minimal, _ = cmd.Flags().GetBool("minimal")
+	options.MinimalRelationships, _ = cmd.Flags().GetBool("minimal-relationships")
+	options.GenerateIndex, _ = cmd.Flags().GetBool("generate-index")
+	options.GenerateRelationshipIndex, _ = cmd.Flags().GetBool("generate-relationship-index")
+	options.IndexFormat, _ = cmd.Flags().GetString("index-format")
+	options.IndexSort, _ = cmd.Flags().GetString("index-sort")
+	options.Checksums, _ = cmd.Flags().GetBool("checksums")
+	options.Sign, _ = cmd.Flags().GetBool("sign")
+	options.WriteStatusBadge, _ = cmd.Flags().GetBool("write-status-badge")
+	options.NoManifest, _ = cmd.Flags().GetBool("no-manifest")
+	options.HandleDeletions, _ = cmd.Flags().GetBool("handle-deletions")
+	options.Prune, _ = cmd.Flags().GetBool("prune")
+	options.ArchivePruned, _ = cmd.Flags().GetString("archive-pruned")
+	options.LinkConcurrency, _ = cmd.Flags().GetInt("link-concurrency")
+	options.LinkNaming, _ = cmd.Flags().GetString("link-naming")
+	options.BulkFetchSize, _ = cmd.Flags().GetInt("bulk-fetch-size")
+	options.Transform, _ = cmd.Flags().GetString("transform")
+	options.FilterExpr, _ = cmd.Flags().GetString("filter-expr")
+	options.MergeUpdate, _ = cmd.Flags().GetBool("merge-update")
+	options.ADFRender, _ = cmd.Flags().GetString("adf-render")
+	options.CommitStrategy, _ = cmd.Flags().GetString("commit-strategy")
+	options.CheckpointInterval, _ = cmd.Flags().GetInt("checkpoint-interval")
+	options.GitRetries, _ = cmd.Flags().GetInt("git-retries")
+	if cmd.Flags().Changed("git-retry-backoff") {
+		gitRetryBackoff, _ := cmd.Flags().GetDuration("git-retry-backoff")
+		options.GitRetryBackoff = gitRetryBackoff.String()
+	}
+	if cmd.Flags().Changed("max-files") {
+		options.MaxFiles, _ = cmd.Flags().GetInt("max-files")
+	}
+	options.NoFileLimit, _ = cmd.Flags().GetBool("no-file-limit")
+	options.FlattenFields, _ = cmd.Flags().GetString("flatten-fields")
+	options.NoFlatten, _ = cmd.Flags().GetBool("no-flatten")
+	options.NormalizeTimestamps, _ = cmd.Flags().GetString("normalize-timestamps")
+	options.FieldOrder, _ = cmd.Flags().GetString("field-order")
+	if rampUp, _ := cmd.Flags().GetDuration("ramp-up"); rampUp > 0 {
+		options.RampUp = rampUp.String()
+	}
+	options.QuietHours, _ = cmd.Flags().GetString("quiet-hours")
+	options.QuietHoursTimezone, _ = cmd.Flags().GetString("quiet-hours-timezone")
+	options.QuietHoursMode, _ = cmd.Flags().GetString("quiet-hours-mode")
+	if format, _ := cmd.Flags().GetString("format"); format == SyncFormatMarkdown {
+		options.Format = format
+	}
+	options.FrontmatterFields, _ = cmd.Flags().GetString("frontmatter-fields")
+	return options
+}
+
+// runProjectsSync syncs every project in --projects into its own repository under --repo-base
+// Projects run concurrently, bounded by --project-concurrency, through one JIRA client so that
+// they share its rate limiter rather than each pacing requests to the same host on its own.
+func runProjectsSync(cmd *cobra.Command) error {
+	for _, flag := range []string{"profile", "issues", "jql", "epic-key", "repo"} {
+		if value, _ := cmd.Flags().GetString(flag); value != "" {
+			return fmt.Errorf("--projects cannot be combined with --%s (each project syncs into --repo-base/<PROJECT>)", flag)
+		}
+	}
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		return fmt.Errorf("--projects cannot be combined with --watch")
+	}
+	// Every project counts against the shared client, so one project's budget would see them all
+	for _, flag := range []string{"max-api-calls", "max-duration"} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--projects cannot be combined with --%s (projects share one JIRA client)", flag)
+		}
+	}
+
+	projectsArg, _ := cmd.Flags().GetString("projects")
+	projects, err := parseProjectList(projectsArg)
+	if err != nil {
+		return err
+	}
+	repoBase, _ := cmd.Flags().GetString("repo-base")
+	if repoBase == "" {
+		return fmt.Errorf("--projects requires --repo-base")
+	}
+	projectConcurrency, _ := cmd.Flags().GetInt("project-concurrency")
+	if projectConcurrency == 0 {
+		projectConcurrency = DefaultProjectConcurrency
+	}
+	if projectConcurrency < 1 {
+		return fmt.Errorf("--project-concurrency must be at least 1, got %d", projectConcurrency)
+	}
+
+	options := projectSyncOptions(cmd)
+	removals := newRemovalGuard(cmd)
+	responseCache, err := newResponseCacheFlags(cmd)
+	if err != nil {
+		return err
+	}
+	syncType := "Full"
+	if options.Incremental {
+		syncType = "Incremental"
+	}
+	orderBy, _ := cmd.Flags().GetString("order-by")
+	explicitOrder := cmd.Flags().Changed("order-by")
+	if orderBy == "" {
+		orderBy = jql.DefaultOrderBy
+	}
+	profiles, err := projectSyncProfiles(projects, repoBase, orderBy, explicitOrder, options)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(repoBase, 0755); err != nil {
+		return fmt.Errorf("failed to create --repo-base directory: %w", err)
+	}
+
+	// Load configuration and connect once; every project shares the client and its rate limiter
+	fmt.Println("📄 Loading configuration...")
+	cfg, err := config.NewDotEnvLoader().Load()
+	if err != nil {
+		return configError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+	if rateLimitArg, _ := cmd.Flags().GetString("rate-limit"); rateLimitArg != "" {
+		rateLimit, err := parseRateLimit(rateLimitArg)
+		if err != nil {
+			return fmt.Errorf("invalid rate limit: %w", err)
+		}
+		cfg.RateLimitDelay = rateLimit
+	}
+	if options.Minimal {
+		applyMinimalConfig(cfg, options.MinimalRelationships, options.FilterExpr != "")
+	}
+	if err := applyExternalRefConfig(cfg); err != nil {
+		return configError(err)
+	}
+	if err := responseCache.apply(cfg); err != nil {
+		return configError(err)
+	}
+	signingKey, err := loadSigningKey(cfg, options.Sign && !options.DryRun)
+	if err != nil {
+		return configError(err)
+	}
+
+	fmt.Println("🔗 Connecting to JIRA...")
+	jiraClient, err := client.NewClient(cfg)
+	if err != nil {
+		return configError(fmt.Errorf("failed to create JIRA client: %w", err))
+	}
+	retryPolicy, err := client.NewRetryPolicy(cfg)
+	if err != nil {
+		return configError(err)
+	}
+	if err := jiraClient.Authenticate(); err != nil {
+		return configError(fmt.Errorf("failed to authenticate with JIRA: %w", err))
+	}
+
+	operationID := state.NewOperationID()
+	fmt.Printf("🆔 Operation ID: %s\n", operationID)
+	fmt.Printf("🗂️  Syncing %d project(s) into %s, %d at a time: %s\n",
+		len(projects), repoBase, projectConcurrency, strings.Join(projects, ", "))
+
+	// Stop starting new projects (and issues) on SIGINT/SIGTERM
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	startTime := time.Now()
+	outcomes := make([]destinationOutcome, len(profiles))
+	started := make([]bool, len(profiles))
+	slots := make(chan struct{}, projectConcurrency)
+	var wg gosync.WaitGroup
+	for i, p := range profiles {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		started[i] = true
+		wg.Add(1)
+		go func(i int, p *profile.Profile) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fmt.Printf("🚀 [%s] Starting sync into %s\n", p.Name, p.Repository)
+			result, err := syncProfileRepository(ctx, jiraClient, cfg, retryPolicy, nil, signingKey, p, p.JQL, syncType, 0, nil, removals, operationID)
+			if err != nil {
+				fmt.Printf("❌ [%s] Sync failed: %v\n", p.Name, err)
+			}
+			outcomes[i] = destinationOutcome{Label: p.Name, Result: result, Err: err}
+		}(i, p)
+	}
+	wg.Wait()
+
+	var finished []destinationOutcome
+	for i, outcome := range outcomes {
+		if started[i] {
+			finished = append(finished, outcome)
+		}
+	}
+	printProjectResults(finished, time.Since(startTime))
+	return outcomesError(ctx, finished, len(profiles), "project")
+}
+
+// printProjectResults summarizes a --projects sync per project, followed by the overall roll-up
+func printProjectResults(outcomes []destinationOutcome, duration time.Duration) {
+	fmt.Printf("🗂️  Project Results:\n")
+	failedProjects := 0
+	for _, outcome := range outcomes {
+		switch {
+		case outcome.Err != nil:
+			failedProjects++
+			fmt.Printf("  ❌ %s: %v\n", outcome.Label, outcome.Err)
+		case outcome.Result.FailedSync > 0:
+			fmt.Printf("  ⚠️  %s: %d synced, %d failed in %v\n", outcome.Label, outcome.Result.SuccessfulSync,
+				outcome.Result.FailedSync, outcome.Result.Duration.Round(time.Millisecond))
+		default:
+			fmt.Printf("  ✅ %s: %d synced in %v\n", outcome.Label, outcome.Result.SuccessfulSync,
+				outcome.Result.Duration.Round(time.Millisecond))
+		}
+	}
+
+	combined := combineOutcomes(outcomes)
+	fmt.Printf("📊 Overall: %d of %d project(s) synced; %d issue(s) synced, %d failed in %v\n",
+		len(outcomes)-failedProjects, len(outcomes), combined.SuccessfulSync, combined.FailedSync,
+		duration.Round(time.Millisecond))
+}
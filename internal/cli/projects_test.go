@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/profile"
+	"github.com/spf13/cobra"
+)
+
+func TestParseProjectList(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"single", "PROJ", []string{"PROJ"}, false},
+		{"several", "A,B,C", []string{"A", "B", "C"}, false},
+		{"trims and upper-cases", " proj , my_proj ", []string{"PROJ", "MY_PROJ"}, false},
+		{"deduplicates", "A,a,B,A", []string{"A", "B"}, false},
+		{"skips empty entries", "A,,B,", []string{"A", "B"}, false},
+		{"empty", "", nil, true},
+		{"only commas", ",,", nil, true},
+		{"invalid key", "A,PROJ-1", nil, true},
+		{"leading digit", "2PROJ", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProjectList(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProjectList(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseProjectList(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectSyncProfiles(t *testing.T) {
+	options := profile.ProfileOptions{Concurrency: 3, Incremental: true}
+	profiles, err := projectSyncProfiles([]string{"A", "B"}, "mirror", "key", false, options)
+	if err != nil {
+		t.Fatalf("projectSyncProfiles() error = %v", err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("got %d profiles, want 2", len(profiles))
+	}
+
+	for i, project := range []string{"A", "B"} {
+		p := profiles[i]
+		if p.Name != project {
+			t.Errorf("profile %d Name = %s, want %s", i, p.Name, project)
+		}
+		if want := filepath.Join("mirror", project); p.Repository != want {
+			t.Errorf("profile %d Repository = %s, want %s", i, p.Repository, want)
+		}
+		if want := "project = " + project + " ORDER BY key ASC"; p.JQL != want {
+			t.Errorf("profile %d JQL = %q, want %q", i, p.JQL, want)
+		}
+		if !reflect.DeepEqual(p.Options, options) {
+			t.Errorf("profile %d Options = %+v, want %+v", i, p.Options, options)
+		}
+	}
+}
+
+func TestRunProjectsSync_RejectsBudget(t *testing.T) {
+	for _, flag := range []string{"max-api-calls", "max-duration"} {
+		t.Run(flag, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("projects", "", "")
+			cmd.Flags().String("repo-base", "", "")
+			cmd.Flags().Int("max-api-calls", 0, "")
+			cmd.Flags().String("max-duration", "", "")
+			value := map[string]string{"max-api-calls": "100", "max-duration": "10m"}[flag]
+			if err := cmd.ParseFlags([]string{"--projects=A,B", "--repo-base=" + t.TempDir(), "--" + flag + "=" + value}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := runProjectsSync(cmd)
+			if err == nil || !strings.Contains(err.Error(), "--"+flag) {
+				t.Errorf("Expected --%s to be refused with --projects, got %v", flag, err)
+			}
+		})
+	}
+}
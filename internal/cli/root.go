@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 
+	"github.com/chambrid/jira-cdc-git/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -42,12 +43,20 @@ Configuration:
 Getting Started:
   jira-sync sync --issues=PROJ-123 --repo=./my-repo`,
 	Version: buildInfo.Version,
+	// main prints the returned error and exits with its mapped code
+	SilenceErrors: true,
+	// Usage only helps with invalid flags, which are rejected before this runs; runtime
+	// failures print just the error
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cmd.SilenceUsage = true
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute(info BuildInfo) error {
 	buildInfo = info
+	client.Version = info.Version
 	rootCmd.Version = fmt.Sprintf("%s (commit: %s, built: %s)", info.Version, info.Commit, info.Date)
 	return rootCmd.Execute()
 }
@@ -2,19 +2,27 @@ package cli
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/chambrid/jira-cdc-git/internal/sync"
 	"github.com/chambrid/jira-cdc-git/pkg/client"
 	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/chambrid/jira-cdc-git/pkg/filter"
 	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/jql"
 	"github.com/chambrid/jira-cdc-git/pkg/links"
 	"github.com/chambrid/jira-cdc-git/pkg/profile"
+	"github.com/chambrid/jira-cdc-git/pkg/ratelimit"
 	"github.com/chambrid/jira-cdc-git/pkg/schema"
 	"github.com/chambrid/jira-cdc-git/pkg/state"
 	"github.com/spf13/cobra"
@@ -37,9 +45,13 @@ File Structure:
 Sync Modes:
   • Profile: --profile=my-profile (use saved profile configuration)
   • Single/Multiple Issues: --issues=PROJ-123 or --issues=PROJ-1,PROJ-2,PROJ-3
+  • Issue ranges: --issues=PROJ-1..PROJ-50 (up to 1000 keys per range, mixable with single keys)
   • JQL Query: --jql="project = PROJ AND status = 'To Do'"
   • Incremental: --incremental (sync only changed issues since last sync)
   • Force Full: --force (ignore state and sync all issues)
+  • JQL templates: --jql="project = {{.Project}}" --jql-var Project=PROJ (or env JQL_VAR_Project)
+  • JQL aliases: --jql="project = PROJ AND @mine AND @open" (define more in .jira-sync.yaml)
+  • Git-aware: --only-changed-since-commit (sync issues updated since the last commit, no state file)
 
 Performance:
   • Default: 5 workers, 500ms rate limit (recommended for most JIRA instances)
@@ -57,6 +69,15 @@ Performance:
   # Sync all issues in epic using JQL
   jira-sync sync --jql="Epic Link = PROJ-123" --repo=./my-repo
 
+  # Resolve a parameterized JQL template at sync time
+  jira-sync sync --jql="project = {{.Project}} AND updated >= {{.Since}}" --jql-var Project=PROJ --jql-var Since=-7d --repo=./my-repo
+
+  # Sync only issues updated in JIRA since the repository's last commit
+  jira-sync sync --jql="project = PROJ" --repo=./my-repo --only-changed-since-commit
+
+  # Measure the window from a specific commit or branch instead of HEAD
+  jira-sync sync --jql="project = PROJ" --repo=./my-repo --only-changed-since-commit --base-ref=origin/main
+
   # Use profile with option overrides
   jira-sync sync --profile=epic-sync --incremental --dry-run
 
@@ -72,6 +93,42 @@ Performance:
 }
 
 func runSync(cmd *cobra.Command, args []string) error {
+	// Profile the whole run when requested (no-op otherwise)
+	stopProfiling, err := startProfiling(cmd)
+	if err != nil {
+		return err
+	}
+	defer stopProfiling()
+
+	// Reject flags the --format does not support before dispatching to projects or watch
+	if _, err := parseSyncFormat(cmd); err != nil {
+		return err
+	}
+
+	if projects, _ := cmd.Flags().GetString("projects"); projects != "" {
+		return newPostSyncHooks(cmd).run(nil, runProjectsSync(cmd))
+	}
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		return runWatch(cmd, args)
+	}
+	_, err = syncOnce(cmd, args)
+	return err
+}
+
+// syncOnce runs one sync from the command's flags, then its post-sync hook, and returns its result
+// The result is nil when the sync did not finish or did not run the sync engines.
+func syncOnce(cmd *cobra.Command, args []string) (*sync.BatchResult, error) {
+	result, err := runSyncOnce(cmd, args)
+	return result, newPostSyncHooks(cmd).run(result, err)
+}
+
+// runSyncOnce runs one sync from the command's flags and returns its result
+func runSyncOnce(cmd *cobra.Command, args []string) (*sync.BatchResult, error) {
+	// Stop on SIGINT/SIGTERM from the start, so an interrupted setup still restores stashed
+	// changes and records profile usage; issues already synced stay committed
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
 	// Get flags
 	profileName, _ := cmd.Flags().GetString("profile")
 	issuesArg, _ := cmd.Flags().GetString("issues")
@@ -82,33 +139,364 @@ func runSync(cmd *cobra.Command, args []string) error {
 	incremental, _ := cmd.Flags().GetBool("incremental")
 	force, _ := cmd.Flags().GetBool("force")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	resolveUsers, _ := cmd.Flags().GetBool("resolve-users")
+	includeWorklogs, _ := cmd.Flags().GetBool("include-worklogs")
+	junitReport, _ := cmd.Flags().GetString("junit-report")
+	onlyChangedSinceCommit, _ := cmd.Flags().GetBool("only-changed-since-commit")
+	baseRef, _ := cmd.Flags().GetString("base-ref")
+	onDirtyArg, _ := cmd.Flags().GetString("on-dirty")
+	excludeFieldsArg, _ := cmd.Flags().GetStringArray("exclude-fields")
+	minimal, _ := cmd.Flags().GetBool("minimal")
+	minimalRelationships, _ := cmd.Flags().GetBool("minimal-relationships")
+	orderByArg, _ := cmd.Flags().GetString("order-by")
+	generateIndex, _ := cmd.Flags().GetBool("generate-index")
+	generateRelationshipIndex, _ := cmd.Flags().GetBool("generate-relationship-index")
+	indexFormatArg, _ := cmd.Flags().GetString("index-format")
+	indexSortArg, _ := cmd.Flags().GetString("index-sort")
+	checksums, _ := cmd.Flags().GetBool("checksums")
+	writeStatusBadge, _ := cmd.Flags().GetBool("write-status-badge")
+	noManifest, _ := cmd.Flags().GetBool("no-manifest")
+	handleDeletions, _ := cmd.Flags().GetBool("handle-deletions")
+	sign, _ := cmd.Flags().GetBool("sign")
+	dryRunReport, _ := cmd.Flags().GetString("dry-run-report")
+	showDiff, _ := cmd.Flags().GetBool("show-diff")
+	linkConcurrency, _ := cmd.Flags().GetInt("link-concurrency")
+	linkNamingArg, _ := cmd.Flags().GetString("link-naming")
+	bulkFetchSize, _ := cmd.Flags().GetInt("bulk-fetch-size")
+	transformPath, _ := cmd.Flags().GetString("transform")
+	filterExprArg, _ := cmd.Flags().GetString("filter-expr")
+	mergeUpdate, _ := cmd.Flags().GetBool("merge-update")
+	adfRenderArg, _ := cmd.Flags().GetString("adf-render")
+	flattenFieldsArg, _ := cmd.Flags().GetString("flatten-fields")
+	noFlatten, _ := cmd.Flags().GetBool("no-flatten")
+	normalizeTimestampsArg, _ := cmd.Flags().GetString("normalize-timestamps")
+	fieldOrderArg, _ := cmd.Flags().GetString("field-order")
+	frontmatterFieldsArg, _ := cmd.Flags().GetString("frontmatter-fields")
+	commitStrategyArg, _ := cmd.Flags().GetString("commit-strategy")
+	checkpointInterval, _ := cmd.Flags().GetInt("checkpoint-interval")
+	rampUp, _ := cmd.Flags().GetDuration("ramp-up")
+	quietHoursArg, _ := cmd.Flags().GetString("quiet-hours")
+	quietHoursTimezone, _ := cmd.Flags().GetString("quiet-hours-timezone")
+	quietHoursModeArg, _ := cmd.Flags().GetString("quiet-hours-mode")
+	gitRetries, _ := cmd.Flags().GetInt("git-retries")
+	gitRetryBackoff, _ := cmd.Flags().GetDuration("git-retry-backoff")
+	maxFiles, _ := cmd.Flags().GetInt("max-files")
+	noFileLimit, _ := cmd.Flags().GetBool("no-file-limit")
+	epicKey, _ := cmd.Flags().GetString("epic-key")
+	within, _ := cmd.Flags().GetString("within")
+	prune, _ := cmd.Flags().GetBool("prune")
+	archivePruned, _ := cmd.Flags().GetString("archive-pruned")
+	removals := newRemovalGuard(cmd)
+	responseCache, err := newResponseCacheFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+	sample, _ := cmd.Flags().GetInt("sample")
+	expandDepth, _ := cmd.Flags().GetInt("expand-depth")
+	expandMaxIssues, _ := cmd.Flags().GetInt("expand-max-issues")
+	includeSubtasks, _ := cmd.Flags().GetBool("include-subtasks")
+	maxAPICalls, _ := cmd.Flags().GetInt("max-api-calls")
+	maxDurationArg, _ := cmd.Flags().GetString("max-duration")
+	permissionCheckArg, _ := cmd.Flags().GetString("permission-check")
+	statusTransitionsOnly, _ := cmd.Flags().GetBool("status-transitions-only")
+	trackFieldsArg, _ := cmd.Flags().GetStringSlice("track-fields")
+	progressSocketPath, _ := cmd.Flags().GetString("progress-socket")
+	outputFile, _ := cmd.Flags().GetString("output-file")
+
+	// NDJSON export writes a single file instead of a repository
+	format, err := parseSyncFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+	ndjson := format == SyncFormatNDJSON
 
 	// Handle profile-based sync
 	if profileName != "" {
-		return runProfileSync(cmd, profileName)
+		if err := checkProfileFlags(cmd); err != nil {
+			return nil, err
+		}
+		return nil, runProfileSync(ctx, cmd, profileName)
 	}
 
 	// Validate that repo is provided when not using profile
-	if repo == "" {
-		return fmt.Errorf("--repo flag is required when not using --profile")
+	if repo == "" && !ndjson {
+		return nil, fmt.Errorf("--repo flag is required when not using --profile")
 	}
 
-	// Validate mutual exclusivity of --issues and --jql
+	// Validate mutual exclusivity of --issues, --jql, and --epic-key
 	if issuesArg != "" && jqlArg != "" {
-		return fmt.Errorf("cannot specify both --issues and --jql flags")
+		return nil, fmt.Errorf("cannot specify both --issues and --jql flags")
+	}
+	if epicKey != "" && (issuesArg != "" || jqlArg != "") {
+		return nil, fmt.Errorf("cannot combine --epic-key with --issues or --jql")
+	}
+	if issuesArg == "" && jqlArg == "" && epicKey == "" {
+		return nil, fmt.Errorf("must specify either --issues, --jql, or --epic-key flag")
+	}
+	if within != "" && epicKey == "" {
+		return nil, fmt.Errorf("--within requires --epic-key")
+	}
+
+	// Validate relationship expansion (0 max issues uses the default)
+	if expandDepth < 0 {
+		return nil, fmt.Errorf("--expand-depth cannot be negative, got %d", expandDepth)
+	}
+	if expandDepth > 0 && issuesArg == "" {
+		return nil, fmt.Errorf("--expand-depth requires --issues")
+	}
+	if expandMaxIssues < 0 {
+		return nil, fmt.Errorf("--expand-max-issues must be positive, got %d", expandMaxIssues)
+	}
+	if expandMaxIssues == 0 {
+		expandMaxIssues = sync.DefaultExpandMaxIssues
+	}
+	if includeSubtasks && issuesArg == "" {
+		return nil, fmt.Errorf("--include-subtasks requires --issues")
+	}
+
+	// Validate prune flags: pruning compares the repository against a query's full result set
+	if archivePruned != "" && !prune {
+		return nil, fmt.Errorf("--archive-pruned requires --prune")
+	}
+	if prune && jqlArg == "" && epicKey == "" {
+		return nil, fmt.Errorf("--prune requires --jql or --epic-key")
 	}
-	if issuesArg == "" && jqlArg == "" {
-		return fmt.Errorf("must specify either --issues or --jql flag")
+	if prune && onlyChangedSinceCommit {
+		return nil, fmt.Errorf("cannot combine --prune with --only-changed-since-commit")
+	}
+	if handleDeletions && onlyChangedSinceCommit {
+		// The narrowed query omits every unchanged issue, which would all be probed as departed
+		return nil, fmt.Errorf("cannot combine --handle-deletions with --only-changed-since-commit")
+	}
+	if archivePruned != "" {
+		if err := sync.ValidateArchiveDir(archivePruned); err != nil {
+			return nil, fmt.Errorf("invalid --archive-pruned value: %w", err)
+		}
 	}
 
 	// Validate incremental flags
 	if incremental && force {
-		return fmt.Errorf("cannot specify both --incremental and --force flags")
+		return nil, fmt.Errorf("cannot specify both --incremental and --force flags")
+	}
+	if statusTransitionsOnly && !incremental {
+		return nil, fmt.Errorf("--status-transitions-only requires --incremental")
+	}
+	trackedFields, err := state.ParseTrackedFields(trackFieldsArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --track-fields value: %w", err)
+	}
+
+	// A dry-run report is a plan: it always implies --dry-run
+	if showDiff && dryRunReport == "" && !dryRun {
+		return nil, fmt.Errorf("--show-diff requires --dry-run or --dry-run-report")
+	}
+	if dryRunReport != "" && !dryRun {
+		dryRun = true
+		fmt.Println("🧪 --dry-run-report implies --dry-run: no files will be written")
+	}
+
+	// Sampling previews a few rendered issues instead of planning the whole scope
+	if err := validateSampleSize(sample); err != nil {
+		return nil, err
+	}
+	if sample > 0 {
+		if !dryRun {
+			return nil, fmt.Errorf("--sample requires --dry-run")
+		}
+		if dryRunReport != "" || showDiff || prune {
+			return nil, fmt.Errorf("cannot combine --sample with --dry-run-report, --show-diff or --prune")
+		}
+	}
+
+	// Validate Git-aware incremental flags
+	if baseRef != "" && !onlyChangedSinceCommit {
+		return nil, fmt.Errorf("--base-ref requires --only-changed-since-commit")
+	}
+	if onlyChangedSinceCommit && force {
+		return nil, fmt.Errorf("cannot specify both --only-changed-since-commit and --force flags")
+	}
+
+	// Validate dirty working tree policy
+	onDirty, err := git.ParseDirtyPolicy(onDirtyArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --on-dirty value: %w", err)
+	}
+
+	// Validate the sync budget (0 is unlimited)
+	budget, err := parseSyncBudget(maxAPICalls, maxDurationArg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the pre-sync permission check
+	permissionCheck, err := sync.ParsePermissionCheckMode(permissionCheckArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --permission-check value: %w", err)
+	}
+
+	// Validate link concurrency (0 uses the default)
+	if linkConcurrency < 0 || linkConcurrency > 20 {
+		return nil, fmt.Errorf("--link-concurrency must be between 0 and 20 (0 uses the default of %d), got %d", links.DefaultLinkConcurrency, linkConcurrency)
+	}
+
+	// Validate relationship link naming
+	linkNaming, err := links.ParseLinkNaming(linkNamingArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --link-naming value: %w", err)
+	}
+
+	// Validate bulk fetch size (0 uses the default, 1 fetches issues one at a time)
+	if bulkFetchSize < 0 || bulkFetchSize > client.MaxBulkFetchSize {
+		return nil, fmt.Errorf("--bulk-fetch-size must be between 0 and %d (0 uses the default of %d), got %d", client.MaxBulkFetchSize, client.DefaultBulkFetchSize, bulkFetchSize)
+	}
+
+	// Validate excluded field patterns
+	if minimalRelationships && !minimal {
+		return nil, fmt.Errorf("--minimal-relationships requires --minimal")
+	}
+	fieldFilter, err := syncFieldFilter(minimal, minimalRelationships, schema.ParseFieldPatterns(excludeFieldsArg...))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --exclude-fields value: %w", err)
+	}
+
+	// Load the issue transform before connecting, so a bad path fails fast
+	transform, err := schema.NewIssueTransform(transformPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --transform value: %w", err)
+	}
+
+	// Validate the issue filter before connecting, so a typo does not filter out every issue
+	issueFilter, err := parseIssueFilter(filterExprArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --filter-expr value: %w", err)
+	}
+
+	// Validate rich-text description rendering
+	adfRender, err := schema.ParseADFRenderMode(adfRenderArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --adf-render value: %w", err)
+	}
+
+	// Validate custom field flattening
+	if noFlatten && flattenFieldsArg != "" {
+		return nil, fmt.Errorf("cannot combine --no-flatten with --flatten-fields")
+	}
+	flatten, err := schema.ParseFlattenStrategy(flattenFieldsArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --flatten-fields value: %w", err)
+	}
+
+	// Validate timestamp normalization
+	timestamps, err := schema.ParseTimestampMode(normalizeTimestampsArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --normalize-timestamps value: %w", err)
+	}
+
+	// Validate the order of issue file fields
+	fieldOrder, err := schema.ParseFieldOrder(fieldOrderArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --field-order value: %w", err)
+	}
+
+	// Validate the frontmatter of Markdown issue files
+	frontmatterFields, err := schema.ParseFrontmatterFields(frontmatterFieldsArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --frontmatter-fields value: %w", err)
+	}
+	if len(frontmatterFields) > 0 && format != SyncFormatMarkdown {
+		return nil, fmt.Errorf("--frontmatter-fields requires --format=%s", SyncFormatMarkdown)
+	}
+
+	// Validate how synced issue files are committed
+	commitStrategy, err := sync.ParseCommitStrategy(commitStrategyArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --commit-strategy value: %w", err)
+	}
+	if err := sync.ValidateCheckpointInterval(commitStrategy, checkpointInterval); err != nil {
+		return nil, fmt.Errorf("invalid --checkpoint-interval value: %w", err)
+	}
+	gitRetry, err := git.ParseRetryPolicy(gitRetries, gitRetryBackoff)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --git-retries or --git-retry-backoff value: %w", err)
+	}
+
+	if rampUp < 0 {
+		return nil, fmt.Errorf("--ramp-up cannot be negative, got %s", rampUp)
+	}
+
+	// Validate the business-hours throttle
+	quietHours, err := ratelimit.ParseQuietHours(quietHoursArg, quietHoursTimezone, quietHoursModeArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --quiet-hours value: %w", err)
+	}
+	if quietHours == nil && (quietHoursTimezone != "" || quietHoursModeArg != "") {
+		return nil, fmt.Errorf("--quiet-hours-timezone and --quiet-hours-mode require --quiet-hours")
+	}
+
+	// Validate the file count safety limit
+	if maxFiles < 0 {
+		return nil, fmt.Errorf("--max-files must be positive, got %d (use --no-file-limit to disable the limit)", maxFiles)
+	}
+	if maxFiles == 0 {
+		maxFiles = sync.DefaultMaxFiles
+	}
+	if noFileLimit {
+		maxFiles = 0
+	}
+
+	// Validate result ordering
+	if orderByArg == "" {
+		orderByArg = jql.DefaultOrderBy
+	}
+	if _, err := jql.ParseOrderBy(orderByArg); err != nil {
+		return nil, fmt.Errorf("invalid --order-by value: %w", err)
+	}
+
+	// Validate index format
+	indexFormat, err := schema.ParseIndexFormat(indexFormatArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --index-format value: %w", err)
+	}
+	indexSort, err := schema.ParseIndexSort(indexSortArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --index-sort value: %w", err)
 	}
 
 	// Validate repository path
-	if err := validateRepoPath(repo); err != nil {
-		return fmt.Errorf("invalid repository path: %w", err)
+	if !ndjson {
+		if err := validateRepoPath(repo); err != nil {
+			return nil, fmt.Errorf("invalid repository path: %w", err)
+		}
+	}
+
+	// Expand @alias shortcuts and resolve JQL template variables before anything talks to JIRA
+	if jqlArg != "" {
+		expanded, err := expandJQLAliases(cmd, jqlArg)
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := resolveJQLTemplate(cmd, expanded)
+		if err != nil {
+			return nil, err
+		}
+		jqlArg = resolved
+	}
+
+	// EPIC mode syncs the EPIC's issues as a JQL query, optionally narrowed to a slice
+	if epicKey != "" {
+		expandedWithin, err := expandJQLAliases(cmd, within)
+		if err != nil {
+			return nil, err
+		}
+		resolvedWithin, err := resolveJQLTemplate(cmd, expandedWithin)
+		if err != nil {
+			return nil, err
+		}
+		within = resolvedWithin
+		jqlArg, err = jql.ScopeToEpic(epicKey, within)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --epic-key/--within value: %w", err)
+		}
 	}
 
 	// Parse rate limit (default or user-provided)
@@ -116,7 +504,7 @@ func runSync(cmd *cobra.Command, args []string) error {
 	if rateLimitArg != "" {
 		parsed, err := parseRateLimit(rateLimitArg)
 		if err != nil {
-			return fmt.Errorf("invalid rate limit: %w", err)
+			return nil, fmt.Errorf("invalid rate limit: %w", err)
 		}
 		rateLimitDuration = parsed
 	}
@@ -126,7 +514,16 @@ func runSync(cmd *cobra.Command, args []string) error {
 	configLoader := config.NewDotEnvLoader()
 	cfg, err := configLoader.Load()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return nil, configError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+	if statusTransitionsOnly {
+		if err := requireChangelog(cfg, "--status-transitions-only"); err != nil {
+			return nil, configError(err)
+		}
+	}
+	signingKey, err := loadSigningKey(cfg, sign && !dryRun)
+	if err != nil {
+		return nil, configError(err)
 	}
 
 	// Apply rate limit (show message only if different from default)
@@ -138,35 +535,162 @@ func runSync(cmd *cobra.Command, args []string) error {
 		cfg.RateLimitDelay = rateLimitDuration
 	}
 
+	// Resolve account IDs to display names (adds one lookup per distinct user)
+	if resolveUsers {
+		cfg.ResolveUsers = true
+	}
+	if cfg.ResolveUsers {
+		fmt.Println("👤 Resolving user account IDs to display names")
+	}
+
+	// Worklogs beyond the first page embedded in each issue cost extra requests
+	if includeWorklogs {
+		cfg.IncludeWorklogs = true
+	}
+	if minimal {
+		applyMinimalConfig(cfg, minimalRelationships, filterExprArg != "")
+	}
+	if cfg.IncludeWorklogs {
+		fmt.Println("⏱️  Including worklogs and time tracking")
+	}
+
+	if err := applyExternalRefConfig(cfg); err != nil {
+		return nil, configError(err)
+	}
+	if err := responseCache.apply(cfg); err != nil {
+		return nil, configError(err)
+	}
+
 	// Step 2: Initialize JIRA client
 	fmt.Println("🔗 Connecting to JIRA...")
 	jiraClient, err := client.NewClient(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create JIRA client: %w", err)
+		return nil, configError(fmt.Errorf("failed to create JIRA client: %w", err))
+	}
+	// The budget counts every request from here on, including the lookups made during setup
+	budgetTracker := sync.NewBudgetTracker(jiraClient, budget)
+	defer budgetTracker.Stop()
+	retryPolicy, err := client.NewRetryPolicy(cfg)
+	if err != nil {
+		return nil, configError(err)
 	}
 
 	// Authenticate with JIRA
 	if err := jiraClient.Authenticate(); err != nil {
-		return fmt.Errorf("failed to authenticate with JIRA: %w", err)
+		return nil, configError(fmt.Errorf("failed to authenticate with JIRA: %w", err))
+	}
+	if err := setupInterrupted(ctx); err != nil {
+		return nil, err
+	}
+
+	if epicKey != "" {
+		reportEpicSlice(jiraClient, epicKey, within)
+	}
+
+	// Pull issues related to the requested ones into the sync set
+	if expandDepth > 0 {
+		issuesArg, err = expandIssueNeighborhood(jiraClient, issuesArg, expandDepth, expandMaxIssues)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if includeSubtasks {
+		issuesArg, err = includeIssueSubtasks(jiraClient, issuesArg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Surface access problems before fetching rather than as scattered 403s
+	if err := verifyProjectPermissions(jiraClient, permissionCheck, issuesArg, jqlArg); err != nil {
+		return nil, configError(err)
+	}
+
+	// NDJSON export bypasses the repository, issue files, and symbolic links entirely
+	if ndjson {
+		if jqlArg != "" {
+			jqlArg, err = orderSyncJQL(jqlArg, orderByArg, cmd.Flags().Changed("order-by"))
+			if err != nil {
+				return nil, err
+			}
+			warnQueryCost(jqlArg)
+		}
+		return nil, exportNDJSON(ctx, jiraClient, outputFile, issuesArg, jqlArg, adfRender, customFieldSchemas(jiraClient, noFlatten), flatten)
 	}
 
 	// Step 3: Initialize Git repository
+	// One operation ID per run ties the run's commits, state history, and output together
+	operationID := state.NewOperationID()
+	fmt.Printf("🆔 Operation ID: %s\n", operationID)
 	fmt.Printf("📁 Preparing Git repository at %s...\n", repo)
-	gitRepo := git.NewGitRepository("JIRA CDC Git Sync", "jira-sync@automated.local")
+	gitRepo := git.NewGitRepositoryWithTrailers("JIRA CDC Git Sync", "jira-sync@automated.local", syncCommitTrailers(operationID, "", jqlArg)...)
+	git.SetRetryPolicy(gitRepo, gitRetry)
 
 	// Initialize repository if needed
 	if err := gitRepo.Initialize(repo); err != nil {
-		return fmt.Errorf("failed to initialize Git repository: %w", err)
+		return nil, fmt.Errorf("failed to initialize Git repository: %w", err)
+	}
+
+	// Use the Git history as incremental state: only issues updated since the measured commit
+	// (measured before --on-dirty=commit can move HEAD)
+	if onlyChangedSinceCommit {
+		issuesArg, jqlArg, err = restrictToChangedSinceCommit(gitRepo, repo, baseRef, issuesArg, jqlArg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Request a stable result order so processing and generated files are deterministic
+	if jqlArg != "" {
+		jqlArg, err = orderSyncJQL(jqlArg, orderByArg, cmd.Flags().Changed("order-by"))
+		if err != nil {
+			return nil, err
+		}
+		warnQueryCost(jqlArg)
+	}
+
+	var fileWriter schema.FileWriter
+	if format == SyncFormatMarkdown {
+		fileWriter = newMarkdownIssueWriter(cfg, frontmatterFields)
+	} else {
+		codeRefs, err := loadCodeRefs()
+		if err != nil {
+			return nil, configError(err)
+		}
+		fileWriter = newIssueFileWriter(fieldFilter, transform, mergeUpdate, adfRender, customFieldSchemas(jiraClient, noFlatten), flatten, timestamps, codeRefs, fieldOrder)
+	}
+	if sample > 0 {
+		return nil, previewSampleIssues(jiraClient, fileWriter, repo, issuesArg, jqlArg, sample)
+	}
+
+	// Refuse runaway scopes before anything is written
+	if err := enforceFileLimit(jiraClient, issuesArg, jqlArg, maxFiles, dryRun); err != nil {
+		return nil, err
+	}
+	if err := setupInterrupted(ctx); err != nil {
+		return nil, err
 	}
 
-	// Validate working tree is clean
-	if err := gitRepo.ValidateWorkingTree(repo); err != nil {
-		return fmt.Errorf("git repository validation failed: %w", err)
+	// Validate working tree is clean, or handle local changes per --on-dirty
+	preparedTree, err := prepareWorkingTree(gitRepo, repo, onDirty, dryRun)
+	if err != nil {
+		return nil, err
 	}
+	defer restoreWorkingTree(preparedTree)
 
 	// Step 4: Initialize sync engine
-	fileWriter := schema.NewYAMLFileWriter()
-	linkManager := links.NewSymbolicLinkManager()
+	linkManager := newLinkManager(linkConcurrency, linkNaming, format)
+
+	// Publish live progress to local clients such as a GUI
+	var progress *progressSocket
+	if progressSocketPath != "" {
+		progress, err = newProgressSocket(progressSocketPath)
+		if err != nil {
+			return nil, err
+		}
+		defer progress.Close()
+		fmt.Printf("📡 Streaming progress to %s\n", progressSocketPath)
+	}
 
 	// Choose between incremental and regular batch engine
 	var result *sync.BatchResult
@@ -174,7 +698,18 @@ func runSync(cmd *cobra.Command, args []string) error {
 	if incremental || force || dryRun {
 		// Use incremental engine for state management
 		stateManager := state.NewFileStateManager(state.FormatYAML)
+		_ = stateManager.SetTrackedFields(trackedFields) // validated above
 		incrementalEngine := sync.NewIncrementalBatchSyncEngine(jiraClient, fileWriter, gitRepo, linkManager, stateManager, concurrency)
+		incrementalEngine.UseBudget(budgetTracker)
+		incrementalEngine.SetBulkFetchSize(bulkFetchSize)
+		incrementalEngine.SetRetryPolicy(retryPolicy)
+		incrementalEngine.SetCommitStrategy(commitStrategy)
+		incrementalEngine.SetCheckpointInterval(checkpointInterval)
+		incrementalEngine.SetRampUp(rampUp)
+		incrementalEngine.SetQuietHours(quietHours)
+		incrementalEngine.SetIssueFilter(issueFilter)
+		incrementalEngine.SetOperationID(operationID)
+		defer streamProgress(incrementalEngine.BatchSyncEngine, progress)()
 
 		// Configure incremental sync options
 		incrementalOptions := sync.IncrementalSyncOptions{
@@ -182,6 +717,12 @@ func runSync(cmd *cobra.Command, args []string) error {
 			DryRun:          dryRun,
 			IncludeNew:      true,
 			IncludeModified: true,
+			ShowDiff:        showDiff,
+
+			StatusTransitionsOnly: statusTransitionsOnly,
+		}
+		if statusTransitionsOnly {
+			fmt.Println("🔀 Only syncing issues whose status changed since the last sync")
 		}
 
 		// Step 5: Execute incremental sync
@@ -189,12 +730,12 @@ func runSync(cmd *cobra.Command, args []string) error {
 			// Issues list mode
 			rawIssues, parseErr := parseIssueList(issuesArg)
 			if parseErr != nil {
-				return fmt.Errorf("failed to parse issues: %w", parseErr)
+				return nil, fmt.Errorf("failed to parse issues: %w", parseErr)
 			}
 
 			issues, validateErr := validateIssueList(rawIssues)
 			if validateErr != nil {
-				return fmt.Errorf("issue validation failed: %w", validateErr)
+				return nil, fmt.Errorf("issue validation failed: %w", validateErr)
 			}
 
 			if len(issues) == 1 {
@@ -216,7 +757,7 @@ func runSync(cmd *cobra.Command, args []string) error {
 				fmt.Printf("📋 Issues: %s\n", strings.Join(issues, ", "))
 			}
 
-			result, err = incrementalEngine.SyncIssuesIncremental(context.Background(), issues, repo, incrementalOptions)
+			result, err = incrementalEngine.SyncIssuesIncremental(ctx, issues, repo, incrementalOptions)
 		} else {
 			// JQL mode
 			if incremental {
@@ -228,11 +769,11 @@ func runSync(cmd *cobra.Command, args []string) error {
 			}
 			fmt.Printf("📋 JQL: %s\n", jqlArg)
 
-			result, err = incrementalEngine.SyncJQLIncremental(context.Background(), jqlArg, repo, incrementalOptions)
+			result, err = incrementalEngine.SyncJQLIncremental(ctx, jqlArg, repo, incrementalOptions)
 		}
 
 		if err != nil {
-			return fmt.Errorf("incremental sync failed: %w", err)
+			return nil, syncFailure(ctx, fmt.Errorf("incremental sync failed: %w", err))
 		}
 
 		// Display additional incremental sync information
@@ -252,14 +793,21 @@ func runSync(cmd *cobra.Command, args []string) error {
 	} else {
 		// Use regular batch engine for backward compatibility
 		batchEngine := sync.NewBatchSyncEngine(jiraClient, fileWriter, gitRepo, linkManager, concurrency)
+		batchEngine.UseBudget(budgetTracker)
+		batchEngine.SetBulkFetchSize(bulkFetchSize)
+		batchEngine.SetRetryPolicy(retryPolicy)
+		batchEngine.SetCommitStrategy(commitStrategy)
+		batchEngine.SetCheckpointInterval(checkpointInterval)
+		batchEngine.SetRampUp(rampUp)
+		batchEngine.SetQuietHours(quietHours)
+		batchEngine.SetIssueFilter(issueFilter)
 
 		// Step 5: Start progress monitoring
-		ctx := context.Background()
 		progressDone := make(chan bool, 1)
 
 		go func() {
 			defer func() { progressDone <- true }()
-			monitorProgress(batchEngine.GetProgressChannel())
+			monitorProgress(batchEngine.GetProgressChannel(), progress)
 		}()
 
 		// Step 6: Execute sync based on mode
@@ -267,12 +815,12 @@ func runSync(cmd *cobra.Command, args []string) error {
 			// Issues list mode
 			rawIssues, parseErr := parseIssueList(issuesArg)
 			if parseErr != nil {
-				return fmt.Errorf("failed to parse issues: %w", parseErr)
+				return nil, fmt.Errorf("failed to parse issues: %w", parseErr)
 			}
 
 			issues, validateErr := validateIssueList(rawIssues)
 			if validateErr != nil {
-				return fmt.Errorf("issue validation failed: %w", validateErr)
+				return nil, fmt.Errorf("issue validation failed: %w", validateErr)
 			}
 
 			if len(issues) == 1 {
@@ -284,31 +832,18 @@ func runSync(cmd *cobra.Command, args []string) error {
 
 			result, err = batchEngine.SyncIssues(ctx, issues, repo)
 			if err != nil {
-				return fmt.Errorf("batch sync failed: %w", err)
+				return nil, syncFailure(ctx, fmt.Errorf("batch sync failed: %w", err))
 			}
 		} else {
 			// JQL mode
 			fmt.Printf("🚀 Syncing JIRA issues matching JQL query to repository %s\n", repo)
 			fmt.Printf("📋 JQL: %s\n", jqlArg)
 
-			// First, get the count of issues to be processed
-			fmt.Print("🔍 Searching for matching issues...")
-			jqlIssues, searchErr := jiraClient.SearchIssues(jqlArg)
-			if searchErr != nil {
-				return fmt.Errorf("failed to execute JQL search: %w", searchErr)
-			}
-
-			fmt.Printf("\r✅ Found %d issues to process                \n", len(jqlIssues))
-
-			// Extract issue keys from the search results
-			issueKeys := make([]string, len(jqlIssues))
-			for i, issue := range jqlIssues {
-				issueKeys[i] = issue.Key
-			}
-
-			result, err = batchEngine.SyncIssues(ctx, issueKeys, repo)
+			// Stream search results page by page so large result sets never sit in memory
+			fmt.Println("🔍 Streaming matching issues into the sync queue...")
+			result, err = batchEngine.SyncJQLStream(ctx, jqlArg, repo, client.DefaultSearchPageSize)
 			if err != nil {
-				return fmt.Errorf("JQL sync failed: %w", err)
+				return nil, syncFailure(ctx, fmt.Errorf("JQL sync failed: %w", err))
 			}
 		}
 
@@ -319,73 +854,1045 @@ func runSync(cmd *cobra.Command, args []string) error {
 		<-progressDone
 	}
 
+	if handleDeletions {
+		if err := handleDeletedIssues(ctx, jiraClient, gitRepo, repo, jqlArg, dryRun, removals, result); err != nil {
+			return nil, err
+		}
+	}
+
 	// Step 7: Display results
+	result.OperationID = operationID
 	displaySyncResults(result)
+	displayBudgetUsage(result.Budget)
 
-	return nil
-}
+	if prune {
+		if err := pruneDepartedIssues(ctx, jiraClient, gitRepo, repo, jqlArg, archivePruned, dryRun, removals, result); err != nil {
+			return nil, err
+		}
+	}
 
-// validateIssueKey validates JIRA issue key format (e.g., PROJ-123)
-func validateIssueKey(issueKey string) error {
-	if issueKey == "" {
-		return fmt.Errorf("issue key cannot be empty")
+	if generateIndex && !dryRun {
+		if err := updateProjectIndexes(gitRepo, repo, indexFormat, indexSort); err != nil {
+			return nil, err
+		}
 	}
 
-	// JIRA issue key format: PROJECT-NUMBER (e.g., PROJ-123, MY-PROJECT-456)
-	issueKeyRegex := regexp.MustCompile(`^[A-Z][A-Z0-9]*(-[A-Z0-9]+)*-\d+$`)
-	if !issueKeyRegex.MatchString(issueKey) {
-		return fmt.Errorf("issue key '%s' does not match JIRA format (e.g., PROJ-123)", issueKey)
+	if generateRelationshipIndex && !dryRun {
+		if err := updateRelationshipIndex(gitRepo, repo); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
-}
+	if (checksums || sign) && !dryRun {
+		if err := updateChecksumManifests(gitRepo, repo, signingKey); err != nil {
+			return nil, err
+		}
+	}
 
-// validateRepoPath validates repository path
-func validateRepoPath(repoPath string) error {
-	if repoPath == "" {
-		return fmt.Errorf("repository path cannot be empty")
+	if !noManifest && !dryRun {
+		manifest := sync.Manifest{
+			ToolVersion: client.Version,
+			Scope:       manifestScope(cmd),
+			Options:     manifestOptions(cmd),
+			JIRA:        sync.NewManifestJIRA(cfg),
+		}
+		if err := updateSyncManifest(gitRepo, repo, manifest); err != nil {
+			return nil, err
+		}
 	}
 
-	// Convert to absolute path
-	absPath, err := filepath.Abs(repoPath)
-	if err != nil {
-		return fmt.Errorf("invalid path '%s': %w", repoPath, err)
+	if writeStatusBadge && !dryRun {
+		if err := updateStatusBadge(gitRepo, repo, result); err != nil {
+			return nil, err
+		}
 	}
 
-	// Check if parent directory exists (we'll create the repo dir if needed)
-	parentDir := filepath.Dir(absPath)
-	if _, err := os.Stat(parentDir); os.IsNotExist(err) {
-		return fmt.Errorf("parent directory '%s' does not exist", parentDir)
+	if junitReport != "" {
+		if err := sync.WriteJUnitReport(junitReport, result); err != nil {
+			return nil, err
+		}
+		fmt.Printf("🧾 JUnit report written to %s\n", junitReport)
 	}
 
-	return nil
-}
+	if dryRun {
+		displayDryRunPlan(result, showDiff)
+	}
 
-// parseIssueList parses comma-separated issue keys and returns a deduplicated, validated list
-func parseIssueList(issuesArg string) ([]string, error) {
-	if issuesArg == "" {
-		return nil, fmt.Errorf("issues list cannot be empty")
+	if dryRunReport != "" {
+		report := sync.BuildDryRunReport(result, repo, jqlArg, sync.DryRunParameters{
+			Incremental:   incremental,
+			Force:         force,
+			Concurrency:   concurrency,
+			OrderBy:       orderByArg,
+			ExcludeFields: schema.ParseFieldPatterns(excludeFieldsArg...),
+			ShowDiff:      showDiff,
+		})
+		if err := sync.WriteDryRunReport(dryRunReport, report); err != nil {
+			return nil, err
+		}
+		fmt.Printf("📝 Dry-run report written to %s (%d add, %d update, %d unchanged, %d delete, %d errors)\n",
+			dryRunReport, report.Summary.Add, report.Summary.Update, report.Summary.Unchanged, report.Summary.Delete, report.Summary.Errors)
 	}
 
-	// Split by comma and clean up whitespace
-	rawIssues := strings.Split(issuesArg, ",")
-	var issues []string
+	return result, syncResultError(ctx, result)
+}
 
-	for _, issue := range rawIssues {
-		trimmed := strings.TrimSpace(issue)
-		if trimmed != "" {
-			issues = append(issues, trimmed)
-		}
+// expandIssueNeighborhood adds the issues within depth relationship hops of the requested
+// issues and reports how many were requested versus added
+func expandIssueNeighborhood(jiraClient client.Client, issuesArg string, depth, maxIssues int) (string, error) {
+	rawIssues, err := parseIssueList(issuesArg)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse issues: %w", err)
+	}
+	requested, err := validateIssueList(rawIssues)
+	if err != nil {
+		return "", fmt.Errorf("issue validation failed: %w", err)
 	}
 
-	if len(issues) == 0 {
-		return nil, fmt.Errorf("no valid issues found in list")
+	fmt.Printf("🕸️  Expanding relationships up to %d hop(s) from %d issue(s)...\n", depth, len(requested))
+	expansion, err := sync.ExpandRelationships(jiraClient, requested, depth, maxIssues)
+	if err != nil {
+		return "", fmt.Errorf("relationship expansion failed: %w", err)
 	}
 
-	return issues, nil
+	fmt.Printf("🕸️  Sync set: %d requested + %d added via relationships = %d issues\n",
+		expansion.Requested, expansion.Added, len(expansion.Keys))
+	if expansion.Truncated {
+		fmt.Printf("⚠️  Expansion stopped at the limit of %d issues (--expand-max-issues)\n", maxIssues)
+	}
+	if len(expansion.Skipped) > 0 {
+		fmt.Printf("⚠️  Could not follow relationships of %d related issue(s): %s\n",
+			len(expansion.Skipped), strings.Join(expansion.Skipped, ", "))
+	}
+	return strings.Join(expansion.Keys, ","), nil
 }
 
-// validateIssueList validates a list of issue keys and removes duplicates
+// includeIssueSubtasks adds the sub-tasks of the requested issues to the sync set, so their
+// parent/child links resolve, and reports how many sub-tasks were pulled in
+func includeIssueSubtasks(jiraClient client.Client, issuesArg string) (string, error) {
+	rawIssues, err := parseIssueList(issuesArg)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse issues: %w", err)
+	}
+	requested, err := validateIssueList(rawIssues)
+	if err != nil {
+		return "", fmt.Errorf("issue validation failed: %w", err)
+	}
+
+	fmt.Printf("🧩 Looking up sub-tasks of %d issue(s)...\n", len(requested))
+	inclusion := sync.IncludeSubtasks(jiraClient, requested)
+	fmt.Printf("🧩 Sync set: %d requested + %d sub-task(s) = %d issues\n",
+		inclusion.Requested, inclusion.Added, len(inclusion.Keys))
+	if len(inclusion.Skipped) > 0 {
+		fmt.Printf("⚠️  Could not look up sub-tasks of %d issue(s): %s\n",
+			len(inclusion.Skipped), strings.Join(inclusion.Skipped, ", "))
+	}
+	return strings.Join(inclusion.Keys, ","), nil
+}
+
+// displayDryRunPlan prints the planned change for every issue that would be written
+func displayDryRunPlan(result *sync.BatchResult, showDiff bool) {
+	if len(result.Plan) == 0 {
+		return
+	}
+
+	fmt.Println("📝 Planned changes:")
+	for _, change := range result.Plan {
+		switch change.Action {
+		case sync.PlanActionAdd:
+			fmt.Printf("  + %s (new file)\n", change.IssueKey)
+		case sync.PlanActionUpdate:
+			fmt.Printf("  ~ %s (update)\n", change.IssueKey)
+		case sync.PlanActionDelete:
+			fmt.Printf("  - %s (%s)\n", change.IssueKey, change.Message)
+		case sync.PlanActionError:
+			fmt.Printf("  ! %s: %s\n", change.IssueKey, change.Message)
+		default:
+			continue
+		}
+		if showDiff && change.Diff != "" {
+			for _, line := range strings.Split(strings.TrimSuffix(change.Diff, "\n"), "\n") {
+				fmt.Printf("      %s\n", line)
+			}
+		}
+	}
+}
+
+// validateSampleSize checks the --sample value (0 disables sampling)
+func validateSampleSize(sample int) error {
+	if sample < 0 || sample > sync.MaxSampleSize {
+		return fmt.Errorf("--sample must be between 1 and %d, got %d", sync.MaxSampleSize, sample)
+	}
+	return nil
+}
+
+// previewSampleIssues fetches the first issues of the sync scope by key and prints them exactly
+// as they would be written, so field selection and format can be checked without a full dry run
+func previewSampleIssues(jiraClient client.Client, fileWriter schema.FileWriter, repo, issuesArg, jqlQuery string, sample int) error {
+	var result *sync.SampleResult
+	var err error
+	if issuesArg != "" {
+		rawIssues, parseErr := parseIssueList(issuesArg)
+		if parseErr != nil {
+			return fmt.Errorf("failed to parse issues: %w", parseErr)
+		}
+		issues, validateErr := validateIssueList(rawIssues)
+		if validateErr != nil {
+			return fmt.Errorf("issue validation failed: %w", validateErr)
+		}
+		result, err = sync.SampleIssueKeys(jiraClient, fileWriter, repo, issues, sample)
+	} else {
+		fmt.Printf("📋 JQL: %s\n", jqlQuery)
+		result, err = sync.SampleJQL(jiraClient, fileWriter, repo, jqlQuery, sample)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sample issues: %w", err)
+	}
+
+	fmt.Printf("🔬 Sample of %d of %d issues (first by key) - nothing is written\n", len(result.Samples), result.Total)
+	failed := 0
+	for _, s := range result.Samples {
+		fmt.Printf("\n📄 %s\n", s.FilePath)
+		if s.Error != "" {
+			failed++
+			fmt.Printf("   ❌ %s\n", s.Error)
+			continue
+		}
+		fmt.Println("---")
+		fmt.Print(string(s.Content))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sample issues could not be rendered", failed, len(result.Samples))
+	}
+	return nil
+}
+
+// expandJQLAliases replaces @alias shortcuts with their JQL using the built-in aliases and the
+// jql_aliases of ~/.jira-sync.yaml and ./.jira-sync.yaml; --transform-jql=false disables expansion
+func expandJQLAliases(cmd *cobra.Command, query string) (string, error) {
+	if cmd.Flags().Lookup("transform-jql") != nil {
+		if enabled, _ := cmd.Flags().GetBool("transform-jql"); !enabled {
+			return query, nil
+		}
+	}
+	if !jql.HasAliases(query) {
+		return query, nil
+	}
+
+	aliases, err := jql.LoadAliases(jql.DefaultAliasPaths()...)
+	if err != nil {
+		return "", fmt.Errorf("failed to load JQL aliases: %w", err)
+	}
+	expanded, err := jql.ExpandAliases(query, aliases)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand JQL aliases (use --transform-jql=false to send the query as written): %w", err)
+	}
+
+	fmt.Printf("🔤 Expanded JQL aliases: %s\n", expanded)
+	return expanded, nil
+}
+
+// applyExternalRefConfig enables extraction of GitHub/GitLab references configured in the
+// external_refs section of ~/.jira-sync.yaml or ./.jira-sync.yaml
+func applyExternalRefConfig(cfg *config.Config) error {
+	refs, err := config.LoadExternalRefConfig(config.SettingsFilePaths()...)
+	if err != nil {
+		return fmt.Errorf("failed to load external_refs configuration: %w", err)
+	}
+	if refs == nil {
+		return nil
+	}
+
+	cfg.ExternalRefs = refs
+	if refs.RemoteLinks {
+		fmt.Printf("🔗 Recording external references (%d patterns, including remote links)\n", len(refs.Patterns))
+	} else {
+		fmt.Printf("🔗 Recording external references (%d patterns)\n", len(refs.Patterns))
+	}
+	return nil
+}
+
+// loadCodeRefs scans the repository configured in the code_refs section of .jira-sync.yaml
+// for commits referencing issue keys; returns nil when no repository is configured
+func loadCodeRefs() (map[string][]client.CodeRef, error) {
+	refs, err := config.LoadCodeRefConfig(config.SettingsFilePaths()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load code_refs configuration: %w", err)
+	}
+	if refs == nil {
+		return nil, nil
+	}
+
+	index, err := git.ScanCodeRefs(refs.Repo, refs.Ref, refs.MaxCommits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan code_refs repository %s: %w", refs.Repo, err)
+	}
+	fmt.Printf("🔎 Recording code references from %s (%d issues referenced)\n", refs.Repo, len(index))
+	return index, nil
+}
+
+// resolveJQLTemplate renders JQL containing template syntax using JQL_VAR_* environment
+// variables overridden by --jql-var flags; plain JQL is returned unchanged
+func resolveJQLTemplate(cmd *cobra.Command, query string) (string, error) {
+	if !jql.IsTemplate(query) {
+		return query, nil
+	}
+
+	variables := jql.TemplateVariablesFromEnv()
+	if cmd.Flags().Lookup("jql-var") != nil {
+		pairs, _ := cmd.Flags().GetStringArray("jql-var")
+		flagVariables, err := jql.ParseTemplateVariables(pairs)
+		if err != nil {
+			return "", err
+		}
+		for name, value := range flagVariables {
+			variables[name] = value
+		}
+	}
+
+	resolved, err := jql.NewTemplateEngine().Resolve(query, variables)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve JQL template (provide values with --jql-var Name=value or %sName): %w", jql.TemplateVariableEnvPrefix, err)
+	}
+
+	fmt.Printf("🧩 Resolved JQL template: %s\n", resolved)
+	return resolved, nil
+}
+
+// updateProjectIndexes regenerates the index of every project in the repository and commits
+// the indexes that changed. Indexes are rebuilt from the issue files present, so they also
+// follow issues removed from the repository.
+func updateProjectIndexes(gitRepo git.Repository, repoPath, format, sortBy string) error {
+	projects, err := schema.ListProjects(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to list projects for index: %w", err)
+	}
+
+	var changedPaths, changedProjects []string
+	for _, projectKey := range projects {
+		indexPath, changed, err := schema.WriteProjectIndex(repoPath, projectKey, format, sortBy)
+		if err != nil {
+			return fmt.Errorf("failed to generate index for project %s: %w", projectKey, err)
+		}
+		if changed {
+			changedPaths = append(changedPaths, indexPath)
+			changedProjects = append(changedProjects, projectKey)
+		}
+	}
+
+	if len(changedPaths) == 0 {
+		fmt.Println("📇 Project indexes already up to date")
+		return nil
+	}
+
+	message := fmt.Sprintf("docs(index): update issue index for %s", strings.Join(changedProjects, ", "))
+	if err := gitRepo.CommitFiles(repoPath, message, changedPaths...); err != nil {
+		return fmt.Errorf("failed to commit project indexes: %w", err)
+	}
+	fmt.Printf("📇 Updated index for %d project(s): %s\n", len(changedProjects), strings.Join(changedProjects, ", "))
+	return nil
+}
+
+// updateRelationshipIndex regenerates the repository's relationship index and commits it if it changed
+func updateRelationshipIndex(gitRepo git.Repository, repoPath string) error {
+	indexPath, changed, err := links.WriteRelationshipIndex(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to generate relationship index: %w", err)
+	}
+	if !changed {
+		fmt.Println("🕸️  Relationship index already up to date")
+		return nil
+	}
+	if err := gitRepo.CommitFiles(repoPath, "docs(index): update relationship index", indexPath); err != nil {
+		return fmt.Errorf("failed to commit relationship index: %w", err)
+	}
+	fmt.Printf("🕸️  Updated relationship index %s\n", links.RelationshipIndexFile)
+	return nil
+}
+
+// updateSyncManifest writes the configuration of a sync to the repository root and commits it
+// when it changed
+func updateSyncManifest(gitRepo git.Repository, repoPath string, manifest sync.Manifest) error {
+	path, changed, err := sync.WriteManifest(repoPath, manifest)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	if err := gitRepo.CommitFiles(repoPath, "chore(manifest): record sync configuration", path); err != nil {
+		return fmt.Errorf("failed to commit sync manifest: %w", err)
+	}
+	fmt.Printf("📜 Sync configuration recorded in %s\n", sync.ManifestFileName)
+	return nil
+}
+
+// manifestScope reads the issues a direct sync selects, as given on the command line
+func manifestScope(cmd *cobra.Command) sync.ManifestScope {
+	var scope sync.ManifestScope
+	scope.JQL, _ = cmd.Flags().GetString("jql")
+	scope.EpicKey, _ = cmd.Flags().GetString("epic-key")
+	scope.Within, _ = cmd.Flags().GetString("within")
+	if issuesArg, _ := cmd.Flags().GetString("issues"); issuesArg != "" {
+		scope.Issues, _ = parseIssueList(issuesArg)
+	}
+	return scope
+}
+
+// manifestOptions reads the sync flags of a direct sync as the profile options they amount to
+func manifestOptions(cmd *cobra.Command) profile.ProfileOptions {
+	options := projectSyncOptions(cmd)
+	options.RateLimit, _ = cmd.Flags().GetString("rate-limit")
+	options.OrderBy, _ = cmd.Flags().GetString("order-by")
+	options.PermissionCheck, _ = cmd.Flags().GetString("permission-check")
+	options.StatusTransitionsOnly, _ = cmd.Flags().GetBool("status-transitions-only")
+	return options
+}
+
+// profileManifestScope returns the scope a profile declares, or the query it was resolved to
+func profileManifestScope(p *profile.Profile, jql string) sync.ManifestScope {
+	scope := sync.ManifestScope{JQL: p.JQL, Issues: p.IssueKeys, EpicKey: p.EpicKey, Within: p.EpicWithin}
+	if scope.JQL == "" && len(scope.Issues) == 0 && scope.EpicKey == "" {
+		scope.JQL = jql
+	}
+	return scope
+}
+
+// updateStatusBadge writes the summary of a finished sync to the repository root and commits it
+func updateStatusBadge(gitRepo git.Repository, repoPath string, result *sync.BatchResult) error {
+	path, err := sync.WriteStatusBadge(repoPath, result, time.Now())
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("chore(status): record sync %s", result.OperationID)
+	if err := gitRepo.CommitFiles(repoPath, message, path); err != nil {
+		return fmt.Errorf("failed to commit sync status: %w", err)
+	}
+	fmt.Printf("🏷️  Sync status written to %s\n", sync.StatusBadgeFileName)
+	return nil
+}
+
+// liveIssueKeys returns the keys of every issue matching query, searching page by page
+// Only the keys are kept, so checking a large scope does not hold all of its issues in memory.
+// A search stopped by SIGINT/SIGTERM is reported as an interrupted sync.
+func liveIssueKeys(ctx context.Context, jiraClient client.Client, query string) ([]string, error) {
+	var keys []string
+	err := client.StreamIssueKeys(ctx, jiraClient, query, client.DefaultSearchPageSize, func(page client.SearchPage) error {
+		keys = append(keys, page.Keys...)
+		return nil
+	})
+	if err != nil && ctx.Err() != nil {
+		return nil, syncFailure(ctx, err)
+	}
+	return keys, err
+}
+
+// pruneDepartedIssues removes (or archives) the files of issues that no longer match the sync query
+// The query is searched again in full, so incremental syncs that skipped unchanged issues still
+// prune against the complete result set. Dry runs only add the removals to the plan.
+func pruneDepartedIssues(ctx context.Context, jiraClient client.Client, gitRepo git.Repository, repoPath, query, archiveDir string, dryRun bool, guard *removalGuard, result *sync.BatchResult) error {
+	fmt.Println("🧹 Checking for issues that left the sync scope...")
+	scope, err := liveIssueKeys(ctx, jiraClient, query)
+	if err != nil {
+		return fmt.Errorf("failed to resolve issues for pruning: %w", err)
+	}
+
+	departed, err := sync.FindDepartedIssues(repoPath, scope)
+	if err != nil {
+		return fmt.Errorf("failed to find issues to prune: %w", err)
+	}
+	if len(departed) == 0 {
+		fmt.Println("🧹 No issues to prune")
+		return nil
+	}
+
+	plan, err := sync.PlanRemoval(repoPath, departed, archiveDir)
+	if err != nil {
+		return fmt.Errorf("failed to plan prune: %w", err)
+	}
+	if dryRun {
+		sync.PlanPrune(result, repoPath, departed, archiveDir)
+		fmt.Printf("🧹 Would prune %d issue(s): %s\n", len(departed), strings.Join(departed, ", "))
+		displayRemovalPlan(plan, repoPath)
+		return nil
+	}
+	if approved, err := guard.approve(plan, repoPath, "Prune"); err != nil || !approved {
+		return err
+	}
+
+	pruneResult, err := sync.PruneIssues(gitRepo, repoPath, departed, archiveDir)
+	if err != nil {
+		return err
+	}
+	if archiveDir != "" {
+		fmt.Printf("📦 Archived %d issue(s) to %s: %s\n", len(pruneResult.Pruned), archiveDir, strings.Join(departed, ", "))
+	} else {
+		fmt.Printf("🧹 Pruned %d issue(s): %s\n", len(pruneResult.Pruned), strings.Join(departed, ", "))
+	}
+	if pruneResult.LinksRemoved > 0 {
+		fmt.Printf("🔗 Removed %d relationship link(s) to pruned issues\n", pruneResult.LinksRemoved)
+	}
+	return nil
+}
+
+// validateProfilePrune checks a profile's prune and archive_pruned options
+// Pruning compares the repository against a query's full result set, so issue lists cannot prune.
+func validateProfilePrune(p *profile.Profile) error {
+	if p.Options.ArchivePruned != "" && !p.Options.Prune {
+		return fmt.Errorf("archive_pruned option requires prune")
+	}
+	if !p.Options.Prune {
+		return nil
+	}
+	if p.JQL == "" && p.EpicKey == "" {
+		return fmt.Errorf("prune option requires a profile with jql or epic_key")
+	}
+	if p.Options.ArchivePruned != "" {
+		if err := sync.ValidateArchiveDir(p.Options.ArchivePruned); err != nil {
+			return fmt.Errorf("invalid archive_pruned option: %w", err)
+		}
+	}
+	return nil
+}
+
+// displayRemovalPlan lists every path a dry-run prune or deletion would remove or move
+func displayRemovalPlan(plan *sync.RemovalPlan, repoPath string) {
+	for _, path := range plan.Paths(repoPath) {
+		fmt.Printf("  - %s\n", path)
+	}
+}
+
+// handleDeletedIssues removes the files of mirrored issues that were deleted in JIRA
+// Issues whose fetch failed with a 404 are deleted; for JQL syncs, mirrored issues that left the
+// query's results are probed too, since a search leaves deleted issues out silently. Issues that
+// fail with a 403 still exist and keep their files. Dry runs only add the removals to the plan.
+func handleDeletedIssues(ctx context.Context, jiraClient client.Client, gitRepo git.Repository, repoPath, query string, dryRun bool, guard *removalGuard, result *sync.BatchResult) error {
+	check := sync.ClassifyFetchFailures(repoPath, result)
+	if query != "" {
+		fmt.Println("🗑️  Checking for issues deleted in JIRA...")
+		scope, err := liveIssueKeys(ctx, jiraClient, query)
+		if err != nil {
+			return fmt.Errorf("failed to resolve issues for deletion check: %w", err)
+		}
+		departed, err := sync.FindDepartedIssues(repoPath, scope)
+		if err != nil {
+			return fmt.Errorf("failed to find issues to check for deletion: %w", err)
+		}
+		check.Merge(sync.ProbeIssues(jiraClient, departed))
+	}
+	result.RecordDeletions(check)
+
+	if len(check.AccessDenied) > 0 {
+		fmt.Printf("🔒 %d issue(s) can no longer be read (HTTP 403), keeping their files: %s\n",
+			len(check.AccessDenied), strings.Join(check.AccessDenied, ", "))
+	}
+	if len(check.Deleted) == 0 {
+		return nil
+	}
+
+	plan, err := sync.PlanRemoval(repoPath, check.Deleted, "")
+	if err != nil {
+		return fmt.Errorf("failed to plan removal of deleted issues: %w", err)
+	}
+	if dryRun {
+		sync.PlanDeletions(result, repoPath, check.Deleted)
+		fmt.Printf("🗑️  Would remove %d issue(s) deleted in JIRA: %s\n", len(check.Deleted), strings.Join(check.Deleted, ", "))
+		displayRemovalPlan(plan, repoPath)
+		return nil
+	}
+	if approved, err := guard.approve(plan, repoPath, "Removing issues deleted in JIRA"); err != nil || !approved {
+		return err
+	}
+
+	removed, err := sync.RemoveDeletedIssues(gitRepo, repoPath, check.Deleted)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🗑️  Removed %d issue(s) deleted in JIRA: %s\n", len(removed.Pruned), strings.Join(check.Deleted, ", "))
+	if removed.LinksRemoved > 0 {
+		fmt.Printf("🔗 Removed %d relationship link(s) to deleted issues\n", removed.LinksRemoved)
+	}
+	return nil
+}
+
+// orderSyncJQL gives a sync query an explicit ORDER BY
+// JIRA's default result order is not stable between searches. A query's own ORDER BY is kept
+// unless an ordering was set explicitly; otherwise the given ordering replaces or adds one.
+func orderSyncJQL(query, orderBySpec string, explicit bool) (string, error) {
+	if !explicit && jql.HasOrderBy(query) {
+		return query, nil
+	}
+	orderBy, err := jql.ParseOrderBy(orderBySpec)
+	if err != nil {
+		return "", fmt.Errorf("invalid order by: %w", err)
+	}
+	return jql.ApplyOrderBy(query, orderBy), nil
+}
+
+// warnQueryCost prints the patterns in a sync query that are known to be slow on JIRA
+// The analysis is advisory: the query runs as given.
+func warnQueryCost(query string) {
+	for _, warning := range jql.AnalyzeCost(query) {
+		fmt.Printf("⚠️  Slow JQL: %s\n", warning.Message)
+	}
+}
+
+// reportEpicSlice prints how many of an EPIC's issues the sync includes
+// Counting is informational, so failures only produce a warning.
+func reportEpicSlice(jiraClient client.Client, epicKey, within string) {
+	slice, err := jql.CountEpicSlice(jiraClient, epicKey, within)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: could not count issues in EPIC %s: %v\n", epicKey, err)
+		return
+	}
+	if slice.Within == "" {
+		fmt.Printf("🎯 EPIC %s: syncing all %d issues\n", epicKey, slice.Total)
+		return
+	}
+	fmt.Printf("🎯 EPIC %s: %d of %d issues match %q (%d filtered out)\n",
+		epicKey, slice.Included, slice.Total, slice.Within, slice.FilteredOut())
+}
+
+// verifyProjectPermissions checks browse access to the sync's projects before anything is fetched
+// In warn mode inaccessible projects, and failed checks, are reported and the sync continues.
+func verifyProjectPermissions(jiraClient client.Client, mode sync.PermissionCheckMode, issuesArg, query string) error {
+	if mode == sync.PermissionCheckOff {
+		return nil
+	}
+
+	var issueKeys []string
+	if issuesArg != "" {
+		keys, err := parseIssueList(issuesArg)
+		if err != nil {
+			return fmt.Errorf("failed to parse issues: %w", err)
+		}
+		issueKeys = keys
+	}
+
+	fmt.Println("🔐 Checking project permissions...")
+	report, err := sync.CheckProjectPermissions(jiraClient, sync.SyncScopeProjects(issueKeys, query))
+	if err != nil {
+		if mode == sync.PermissionCheckFail {
+			return fmt.Errorf("permission check failed: %w", err)
+		}
+		fmt.Printf("⚠️  Permission check failed, continuing: %v\n", err)
+		return nil
+	}
+	if report.Unresolved {
+		fmt.Println("⚠️  Permission check skipped: the query does not name its projects or issue keys")
+		return nil
+	}
+	if len(report.Inaccessible) == 0 {
+		fmt.Printf("✅ Browse permission confirmed for %d project(s): %s\n", len(report.Projects), strings.Join(report.Projects, ", "))
+		return nil
+	}
+
+	names := make([]string, 0, len(report.Inaccessible))
+	fmt.Printf("🚫 No access to %d of %d project(s):\n", len(report.Inaccessible), len(report.Projects))
+	for _, access := range report.Inaccessible {
+		fmt.Printf("  • %s: %s\n", access.Project, access.Reason)
+		names = append(names, access.Project)
+	}
+	if mode == sync.PermissionCheckFail {
+		return fmt.Errorf("permission check failed: cannot browse %s", strings.Join(names, ", "))
+	}
+	fmt.Println("⚠️  Continuing: issues in these projects will fail to sync")
+	return nil
+}
+
+// syncCommitTrailers identifies a run in its commit messages: the operation ID and the profile or
+// query that selected the issues (issue lists are not recorded, they can be long)
+func syncCommitTrailers(operationID, profileName, query string) []git.CommitTrailer {
+	return []git.CommitTrailer{
+		{Key: git.TrailerOperationID, Value: operationID},
+		{Key: git.TrailerProfile, Value: profileName},
+		{Key: git.TrailerQuery, Value: query},
+	}
+}
+
+// profileGitRetryPolicy builds the Git retry policy of a profile's git_retries and git_retry_backoff
+func profileGitRetryPolicy(options profile.ProfileOptions) (git.RetryPolicy, error) {
+	var backoff time.Duration
+	if options.GitRetryBackoff != "" {
+		var err error
+		if backoff, err = time.ParseDuration(options.GitRetryBackoff); err != nil {
+			return git.RetryPolicy{}, fmt.Errorf("invalid git_retry_backoff option: %w", err)
+		}
+	}
+	policy, err := git.ParseRetryPolicy(options.GitRetries, backoff)
+	if err != nil {
+		return git.RetryPolicy{}, fmt.Errorf("invalid git_retries or git_retry_backoff option: %w", err)
+	}
+	return policy, nil
+}
+
+// profileSyncBudget builds the sync budget of a profile's max_api_calls and max_duration (0 is unlimited)
+func profileSyncBudget(options profile.ProfileOptions) (sync.SyncBudget, error) {
+	budget := sync.SyncBudget{MaxAPICalls: options.MaxAPICalls}
+	if options.MaxAPICalls < 0 {
+		return budget, fmt.Errorf("invalid max_api_calls option: cannot be negative, got %d", options.MaxAPICalls)
+	}
+	if options.MaxDuration != "" {
+		duration, err := time.ParseDuration(options.MaxDuration)
+		if err != nil {
+			return budget, fmt.Errorf("invalid max_duration option: %w", err)
+		}
+		if duration < 0 {
+			return budget, fmt.Errorf("invalid max_duration option: cannot be negative, got %s", options.MaxDuration)
+		}
+		budget.MaxDuration = duration
+	}
+	if budget.MaxAPICalls > 0 || budget.MaxDuration > 0 {
+		fmt.Printf("⏳ Sync budget: %s\n", formatBudgetLimits(budget.MaxAPICalls, budget.MaxDuration))
+	}
+	return budget, nil
+}
+
+// syncFieldFilter builds the field filter of a sync: the minimal preset, if enabled, and exclusions
+func syncFieldFilter(minimal, minimalRelationships bool, exclude []string) (*schema.FieldFilter, error) {
+	var include []string
+	if minimal {
+		include = schema.MinimalFieldPatterns(minimalRelationships)
+	}
+	return schema.NewFieldFilter(include, exclude)
+}
+
+// applyMinimalConfig stops fetching data that minimal mode never writes
+// Custom fields and worklogs are still fetched for a filter expression, which may test them.
+func applyMinimalConfig(cfg *config.Config, withRelationships, filtering bool) {
+	fields := schema.MinimalFields
+	if withRelationships {
+		fields = append(append([]string(nil), fields...), schema.MinimalRelationshipsField)
+	}
+	fmt.Printf("🪶 Minimal mode: writing only %s\n", strings.Join(fields, ", "))
+	if !filtering {
+		cfg.IncludeCustomFields = false
+		cfg.IncludeWorklogs = false
+	}
+}
+
+// enforceFileLimit counts the issue files a sync would write and fails if there are more than maxFiles
+// A dry run writes nothing, so it only warns; maxFiles 0 disables the limit.
+func enforceFileLimit(jiraClient client.Client, issuesArg, jqlQuery string, maxFiles int, dryRun bool) error {
+	if maxFiles == 0 {
+		return nil
+	}
+	var issueKeys []string
+	if jqlQuery == "" {
+		keys, err := parseIssueList(issuesArg)
+		if err != nil {
+			return fmt.Errorf("failed to parse issues: %w", err)
+		}
+		issueKeys = keys
+	}
+
+	count, err := sync.CountSyncScope(jiraClient, issueKeys, jqlQuery)
+	if err != nil {
+		return err
+	}
+	if err := sync.CheckFileLimit(count, maxFiles); err != nil {
+		if dryRun {
+			fmt.Printf("⚠️  %v\n", err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// profileMaxFiles returns the file limit of a profile's max_files and no_file_limit (0: no limit)
+func profileMaxFiles(options profile.ProfileOptions) int {
+	switch {
+	case options.NoFileLimit:
+		return 0
+	case options.MaxFiles > 0:
+		return options.MaxFiles
+	default:
+		return sync.DefaultMaxFiles
+	}
+}
+
+// parseIssueFilter compiles a --filter-expr or filter_expr predicate; empty disables filtering
+func parseIssueFilter(source string) (sync.IssueFilter, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, nil
+	}
+	expr, err := filter.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("🔎 Filtering fetched issues by: %s\n", expr)
+	return expr, nil
+}
+
+// newIssueFileWriter creates the YAML writer, reporting configured field exclusions, transforms,
+// merge updates, non-default rich-text rendering, typed custom fields, flattening overrides,
+// timestamp normalization, and field ordering
+func newIssueFileWriter(fieldFilter *schema.FieldFilter, transform schema.IssueTransform, mergeUpdate bool, adfRender schema.ADFRenderMode, fieldSchemas map[string]client.FieldSchema, flatten schema.FlattenStrategy, timestamps schema.TimestampMode, codeRefs map[string][]client.CodeRef, fieldOrder []string) schema.FileWriter {
+	if fieldFilter == nil && transform == nil && !mergeUpdate && (adfRender == "" || adfRender == schema.ADFRenderMarkdown) && fieldSchemas == nil && flatten == nil && timestamps != schema.TimestampUTC && codeRefs == nil && len(fieldOrder) == 0 {
+		return schema.NewYAMLFileWriter()
+	}
+	if fieldFilter != nil {
+		fmt.Println("✂️  Omitting excluded fields from YAML output")
+	}
+	if transform != nil {
+		fmt.Println("🔀 Transforming issues before writing (issues with invalid transform output are marked failed)")
+	}
+	if mergeUpdate {
+		fmt.Println("🧩 Merging changed fields into existing issue files")
+	}
+	if adfRender == schema.ADFRenderText || adfRender == schema.ADFRenderRaw {
+		fmt.Printf("📝 Writing rich-text (ADF) descriptions as %s\n", adfRender)
+	}
+	if fieldSchemas != nil {
+		fmt.Printf("🏷️  Writing custom fields by type (%d field definitions)\n", len(fieldSchemas))
+	}
+	if flatten != nil {
+		fmt.Printf("🏷️  Flattening custom fields with %d type override(s)\n", len(flatten))
+	}
+	if timestamps == schema.TimestampUTC {
+		fmt.Println("🕒 Normalizing timestamps to UTC")
+	}
+	if len(fieldOrder) > 0 {
+		fmt.Printf("🔢 Ordering issue fields: %s\n", strings.Join(fieldOrder, ", "))
+	}
+	return &schema.YAMLFileWriter{Fields: fieldFilter, Transform: transform, Merge: mergeUpdate, ADFRender: adfRender, FieldSchemas: fieldSchemas, Flatten: flatten, Timestamps: timestamps, CodeRefs: codeRefs, FieldOrder: fieldOrder}
+}
+
+// newMarkdownIssueWriter creates the Markdown writer, linking each issue back to JIRA under
+// JIRA_PUBLIC_URL (or JIRA_BASE_URL when it is unset) and reporting any frontmatter fields
+func newMarkdownIssueWriter(cfg *config.Config, frontmatterFields []string) schema.FileWriter {
+	fmt.Println("📝 Writing issues as Markdown")
+	if len(frontmatterFields) == 0 {
+		return schema.NewMarkdownFileWriter(cfg.IssueLinkBaseURL())
+	}
+	fmt.Printf("🏷️  Writing frontmatter: %s\n", strings.Join(frontmatterFields, ", "))
+	return schema.NewMarkdownFileWriterWithFrontmatter(cfg.IssueLinkBaseURL(), frontmatterFields)
+}
+
+// newLinkManager creates the symbolic link manager, pointing links at the issue files of format
+func newLinkManager(concurrency int, naming links.LinkNaming, format string) links.LinkManager {
+	return &links.SymbolicLinkManager{Concurrency: concurrency, Naming: naming, Extension: schema.FileExtension(format)}
+}
+
+// customFieldSchemas fetches the custom field metadata used to write custom fields by type
+// Returns nil when custom fields are disabled or noFlatten asks for raw values; when the
+// metadata cannot be read the values are written raw rather than failing the sync.
+func customFieldSchemas(jiraClient client.Client, noFlatten bool) map[string]client.FieldSchema {
+	fields, ok := jiraClient.(client.FieldSchemaClient)
+	if !ok || noFlatten {
+		return nil
+	}
+	schemas, err := fields.GetFieldSchemas()
+	if errors.Is(err, client.ErrCustomFieldsDisabled) {
+		return nil
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Could not read custom field metadata, writing raw custom field values: %v\n", err)
+		return nil
+	}
+	return schemas
+}
+
+// prepareWorkingTree validates the working tree and applies the --on-dirty policy to local changes
+// Dry runs never modify the repository, so any policy other than fail just proceeds.
+func prepareWorkingTree(gitRepo git.Repository, repoPath string, policy git.DirtyPolicy, dryRun bool) (*git.PreparedWorkingTree, error) {
+	if dryRun && policy != git.DirtyPolicyFail {
+		policy = git.DirtyPolicyIgnore
+	}
+
+	prepared, err := git.PrepareWorkingTree(gitRepo, repoPath, policy)
+	if err != nil {
+		if git.IsDirtyWorkingTreeError(err) {
+			return nil, fmt.Errorf("git repository validation failed: %w (use --on-dirty=stash|commit|ignore to sync anyway)", err)
+		}
+		return nil, fmt.Errorf("git repository validation failed: %w", err)
+	}
+
+	if changes := len(prepared.DirtyFiles); changes > 0 {
+		switch policy {
+		case git.DirtyPolicyStash:
+			fmt.Printf("📦 Stashed %d local change(s); they will be restored after the sync\n", changes)
+		case git.DirtyPolicyCommit:
+			fmt.Printf("💾 Committed %d local change(s) before syncing\n", changes)
+		case git.DirtyPolicyIgnore:
+			fmt.Printf("⚠️  Proceeding with %d uncommitted local change(s) in the repository\n", changes)
+		}
+	}
+
+	return prepared, nil
+}
+
+// restoreWorkingTree puts stashed local changes back once the sync has finished
+// Restore problems are reported but do not fail an otherwise successful sync.
+func restoreWorkingTree(prepared *git.PreparedWorkingTree) {
+	if prepared == nil || prepared.Stash == nil {
+		return
+	}
+	if err := prepared.Restore(); err != nil {
+		fmt.Printf("⚠️  Warning: failed to restore stashed changes: %v\n", err)
+		return
+	}
+	fmt.Println("📦 Restored stashed local changes")
+}
+
+// restrictToChangedSinceCommit narrows the sync to issues updated in JIRA since a commit's time
+// Issue lists are converted to a key query so both modes share the same JQL window. A repository
+// without commits is left unrestricted so the first run performs a full sync.
+func restrictToChangedSinceCommit(gitRepo git.Repository, repo, baseRef, issuesArg, jqlArg string) (string, string, error) {
+	commitTime, err := gitRepo.GetCommitTime(repo, baseRef)
+	if err != nil {
+		if git.IsNoCommitsError(err) {
+			fmt.Println("🆕 Repository has no commits yet - performing full sync")
+			return issuesArg, jqlArg, nil
+		}
+		return "", "", fmt.Errorf("failed to determine commit time: %w", err)
+	}
+
+	if issuesArg != "" {
+		rawIssues, parseErr := parseIssueList(issuesArg)
+		if parseErr != nil {
+			return "", "", fmt.Errorf("failed to parse issues: %w", parseErr)
+		}
+
+		issues, validateErr := validateIssueList(rawIssues)
+		if validateErr != nil {
+			return "", "", fmt.Errorf("issue validation failed: %w", validateErr)
+		}
+
+		jqlArg = jql.IssueKeysQuery(issues)
+	}
+
+	measuredFrom := "HEAD"
+	if baseRef != "" {
+		measuredFrom = baseRef
+	}
+	fmt.Printf("🕒 Only syncing issues updated since %s commit (%s)\n", measuredFrom, commitTime.Local().Format("2006-01-02 15:04:05"))
+
+	return "", jql.RestrictUpdatedSince(jqlArg, commitTime, time.Now()), nil
+}
+
+// validateIssueKey validates JIRA issue key format (e.g., PROJ-123)
+func validateIssueKey(issueKey string) error {
+	if issueKey == "" {
+		return fmt.Errorf("issue key cannot be empty")
+	}
+
+	// JIRA issue key format: PROJECT-NUMBER (e.g., PROJ-123, MY-PROJECT-456)
+	issueKeyRegex := regexp.MustCompile(`^[A-Z][A-Z0-9]*(-[A-Z0-9]+)*-\d+$`)
+	if !issueKeyRegex.MatchString(issueKey) {
+		return fmt.Errorf("issue key '%s' does not match JIRA format (e.g., PROJ-123)", issueKey)
+	}
+
+	return nil
+}
+
+// validateRepoPath validates repository path
+func validateRepoPath(repoPath string) error {
+	if repoPath == "" {
+		return fmt.Errorf("repository path cannot be empty")
+	}
+
+	// Convert to absolute path
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid path '%s': %w", repoPath, err)
+	}
+
+	// Check if parent directory exists (we'll create the repo dir if needed)
+	parentDir := filepath.Dir(absPath)
+	if _, err := os.Stat(parentDir); os.IsNotExist(err) {
+		return fmt.Errorf("parent directory '%s' does not exist", parentDir)
+	}
+
+	return nil
+}
+
+// maxIssueRangeSize caps how many keys a single --issues range may expand to
+const maxIssueRangeSize = 1000
+
+// parseIssueList parses comma-separated issue keys and returns a deduplicated, validated list
+// Entries may be ranges of one project's keys (PROJ-1..PROJ-50, or PROJ-1..50), which expand
+// in place to every key in between.
+func parseIssueList(issuesArg string) ([]string, error) {
+	if issuesArg == "" {
+		return nil, fmt.Errorf("issues list cannot be empty")
+	}
+
+	// Split by comma and clean up whitespace
+	rawIssues := strings.Split(issuesArg, ",")
+	var issues []string
+
+	for _, issue := range rawIssues {
+		trimmed := strings.TrimSpace(issue)
+		if trimmed == "" {
+			continue
+		}
+		if strings.Contains(trimmed, "..") {
+			expanded, err := expandIssueRange(trimmed)
+			if err != nil {
+				return nil, err
+			}
+			issues = append(issues, expanded...)
+			continue
+		}
+		issues = append(issues, trimmed)
+	}
+
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("no valid issues found in list")
+	}
+
+	return issues, nil
+}
+
+// expandIssueRange expands "PROJ-1..PROJ-50" (or "PROJ-1..50") into the keys it spans
+func expandIssueRange(rangeSpec string) ([]string, error) {
+	parts := strings.Split(rangeSpec, "..")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid issue range '%s' (use PROJ-1..PROJ-50)", rangeSpec)
+	}
+	startKey, endKey := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+
+	if err := validateIssueKey(startKey); err != nil {
+		return nil, fmt.Errorf("invalid issue range '%s': %w", rangeSpec, err)
+	}
+	separator := strings.LastIndex(startKey, "-")
+	project := startKey[:separator]
+	start, _ := strconv.Atoi(startKey[separator+1:])
+
+	endNumber := endKey
+	if strings.Contains(endKey, "-") {
+		if err := validateIssueKey(endKey); err != nil {
+			return nil, fmt.Errorf("invalid issue range '%s': %w", rangeSpec, err)
+		}
+		endSeparator := strings.LastIndex(endKey, "-")
+		if endKey[:endSeparator] != project {
+			return nil, fmt.Errorf("invalid issue range '%s': both ends must be in project %s", rangeSpec, project)
+		}
+		endNumber = endKey[endSeparator+1:]
+	}
+	end, err := strconv.Atoi(endNumber)
+	if err != nil || end < 0 {
+		return nil, fmt.Errorf("invalid issue range '%s': end must be an issue key or number", rangeSpec)
+	}
+
+	if end < start {
+		return nil, fmt.Errorf("invalid issue range '%s': end is before start", rangeSpec)
+	}
+	if size := end - start + 1; size > maxIssueRangeSize {
+		return nil, fmt.Errorf("issue range '%s' spans %d issues (maximum %d); use --jql for larger sets", rangeSpec, size, maxIssueRangeSize)
+	}
+
+	keys := make([]string, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		keys = append(keys, fmt.Sprintf("%s-%d", project, number))
+	}
+	return keys, nil
+}
+
+// validateIssueList validates a list of issue keys and removes duplicates
 func validateIssueList(issues []string) ([]string, error) {
 	if len(issues) == 0 {
 		return nil, fmt.Errorf("issue list cannot be empty")
@@ -423,14 +1930,39 @@ func validateIssueList(issues []string) ([]string, error) {
 	return validIssues, nil
 }
 
-// monitorProgress displays real-time progress updates
-func monitorProgress(progressChan <-chan sync.ProgressUpdate) {
+// monitorProgress displays real-time progress updates, and publishes each one to the
+// progress socket if there is one
+func monitorProgress(progressChan <-chan sync.ProgressUpdate, progress *progressSocket) {
 	lastPercentage := -1.0
 
-	for update := range progressChan {
+	for update := range progressChan {
+		progress.Publish(update)
+
+		if update.Step == "ramp_up" {
+			fmt.Printf("🚦 Ramping up: %d/%d workers active\n", update.ActiveWorkers, update.TotalWorkers)
+			continue
+		}
+		if update.Step == "quiet_hours_start" && update.QuietHoursEnd != nil {
+			until := update.QuietHoursEnd.Format("15:04 MST")
+			if update.QuietHoursMode == ratelimit.QuietHoursPause {
+				fmt.Printf("🌙 Quiet hours: sync paused until %s\n", until)
+			} else {
+				fmt.Printf("🌙 Quiet hours: syncing with one worker, one issue per %v, until %s\n", sync.QuietHoursIssueInterval, until)
+			}
+			continue
+		}
+		if update.Step == "quiet_hours_end" {
+			fmt.Println("☀️  Quiet hours over: syncing at full speed")
+			continue
+		}
+
 		// Only display percentage updates to avoid spam
 		if update.Percentage > 0 && int(update.Percentage) != int(lastPercentage) {
-			fmt.Printf("⏳ Progress: %.0f%% (%d processed)\n", update.Percentage, update.ProcessedCount)
+			if update.TotalCount > 0 {
+				fmt.Printf("⏳ Progress: %.0f%% (%d/%d processed)\n", update.Percentage, update.ProcessedCount, update.TotalCount)
+			} else {
+				fmt.Printf("⏳ Progress: %.0f%% (%d processed)\n", update.Percentage, update.ProcessedCount)
+			}
 			lastPercentage = update.Percentage
 		}
 	}
@@ -439,17 +1971,38 @@ func monitorProgress(progressChan <-chan sync.ProgressUpdate) {
 // displaySyncResults shows the final results of the sync operation
 func displaySyncResults(result *sync.BatchResult) {
 	fmt.Printf("\n🎯 Sync completed in %v\n", result.Duration)
+	if result.OperationID != "" {
+		fmt.Printf("🆔 Operation ID: %s\n", result.OperationID)
+	}
 	fmt.Printf("📊 Results:\n")
 	fmt.Printf("  • Total issues: %d\n", result.TotalIssues)
 	fmt.Printf("  • Processed: %d\n", result.ProcessedIssues)
 	fmt.Printf("  • Successful: %d\n", result.SuccessfulSync)
 	fmt.Printf("  • Failed: %d\n", result.FailedSync)
+	if len(result.Deleted) > 0 {
+		fmt.Printf("  • Deleted in JIRA: %d\n", len(result.Deleted))
+	}
+	if len(result.Filtered) > 0 {
+		fmt.Printf("  • Filtered out: %d\n", len(result.Filtered))
+	}
 
 	// Performance metrics
 	fmt.Printf("⚡ Performance:\n")
 	fmt.Printf("  • Speed: %.1f issues/second\n", result.Performance.IssuesPerSecond)
 	fmt.Printf("  • Workers: %d\n", result.Performance.WorkerCount)
 	fmt.Printf("  • Avg time per issue: %v\n", result.Performance.AvgProcessTime)
+	if result.Performance.LinkCreationTime > 0 {
+		fmt.Printf("  • Link creation: %v (summed across workers)\n", result.Performance.LinkCreationTime)
+	}
+
+	// Show the status changes that selected issues
+	if len(result.Transitions) > 0 {
+		fmt.Printf("\n🔀 Status transitions:\n")
+		for _, transition := range result.Transitions {
+			fmt.Printf("  • %s: %s → %s (%s)\n", transition.IssueKey, transition.From, transition.To,
+				transition.At.Format(time.RFC3339))
+		}
+	}
 
 	// Show errors if any
 	if len(result.Errors) > 0 {
@@ -473,6 +2026,80 @@ func displaySyncResults(result *sync.BatchResult) {
 	}
 }
 
+// requireChangelog fails when a feature that reads issue changelogs is used with them disabled
+func requireChangelog(cfg *config.Config, feature string) error {
+	if !cfg.IncludeChangelog {
+		return fmt.Errorf("%s reads issue changelogs, but they are disabled (set JIRA_INCLUDE_CHANGELOG=true)", feature)
+	}
+	return nil
+}
+
+// parseSyncBudget validates --max-api-calls and --max-duration
+func parseSyncBudget(maxAPICalls int, maxDurationArg string) (sync.SyncBudget, error) {
+	budget := sync.SyncBudget{MaxAPICalls: maxAPICalls}
+	if maxAPICalls < 0 {
+		return budget, fmt.Errorf("--max-api-calls cannot be negative, got %d", maxAPICalls)
+	}
+	if maxDurationArg != "" {
+		duration, err := time.ParseDuration(maxDurationArg)
+		if err != nil {
+			return budget, fmt.Errorf("invalid --max-duration value: %w", err)
+		}
+		if duration < 0 {
+			return budget, fmt.Errorf("--max-duration cannot be negative, got %s", maxDurationArg)
+		}
+		budget.MaxDuration = duration
+	}
+	if budget.MaxAPICalls > 0 || budget.MaxDuration > 0 {
+		fmt.Printf("⏳ Sync budget: %s\n", formatBudgetLimits(budget.MaxAPICalls, budget.MaxDuration))
+	}
+	return budget, nil
+}
+
+// formatBudgetLimits describes the limits of a sync budget
+func formatBudgetLimits(maxAPICalls int, maxDuration time.Duration) string {
+	var limits []string
+	if maxAPICalls > 0 {
+		limits = append(limits, fmt.Sprintf("%d API calls", maxAPICalls))
+	}
+	if maxDuration > 0 {
+		limits = append(limits, maxDuration.String())
+	}
+	return strings.Join(limits, ", ")
+}
+
+// displayBudgetUsage reports how much of the sync budget was consumed and what was left over
+func displayBudgetUsage(usage *sync.BudgetUsage) {
+	if usage == nil {
+		return
+	}
+
+	consumed := fmt.Sprintf("%d API calls", usage.APICalls)
+	if usage.MaxAPICalls > 0 {
+		consumed = fmt.Sprintf("%d/%d API calls (%.0f%%)", usage.APICalls, usage.MaxAPICalls,
+			float64(usage.APICalls)/float64(usage.MaxAPICalls)*100)
+	}
+	elapsed := usage.Elapsed.Round(time.Second).String()
+	if usage.MaxDuration > 0 {
+		elapsed = fmt.Sprintf("%s/%s", elapsed, usage.MaxDuration)
+	}
+
+	if !usage.Exhausted {
+		fmt.Printf("⏳ Budget used: %s, %s\n", consumed, elapsed)
+		return
+	}
+
+	reason := "API call limit reached"
+	if usage.Reason == sync.BudgetReasonDuration {
+		reason = "duration limit reached"
+	}
+	fmt.Printf("\n⏳ Budget exhausted (%s): stopped after %s, %s\n", reason, consumed, elapsed)
+	fmt.Printf("📋 %d issue(s) left for a later run (with --incremental, a re-run skips issues already synced)\n", usage.RemainingCount)
+	if len(usage.Remaining) > 0 && len(usage.Remaining) <= 10 {
+		fmt.Printf("  • %s\n", strings.Join(usage.Remaining, ", "))
+	}
+}
+
 // parseRateLimit parses and validates a rate limit duration string
 func parseRateLimit(rateLimitStr string) (time.Duration, error) {
 	if rateLimitStr == "" {
@@ -496,25 +2123,147 @@ func init() {
 
 	// Sync command flags
 	syncCmd.Flags().StringP("profile", "p", "", "Use saved profile for sync configuration")
-	syncCmd.Flags().StringP("issues", "i", "", "JIRA issue key(s) - single issue (PROJ-123) or comma-separated list (PROJ-1,PROJ-2)")
+	syncCmd.Flags().StringP("issues", "i", "", "JIRA issue key(s) - single issue (PROJ-123), comma-separated list (PROJ-1,PROJ-2), or ranges (PROJ-1..PROJ-50)")
 	syncCmd.Flags().StringP("jql", "j", "", "JQL query to find issues (e.g., 'project = PROJ AND status = \"To Do\"')")
+	syncCmd.Flags().String("epic-key", "", "Sync the issues linked to this EPIC (PROJ-100)")
+	syncCmd.Flags().String("within", "", "JQL filter limiting --epic-key to a slice of the EPIC (e.g. 'component = Payments'); overrides the profile's epic_within")
+	syncCmd.Flags().Int("expand-depth", 0, "Also sync issues up to N relationship hops from --issues (EPIC, parent, subtask and issue links)")
+	syncCmd.Flags().Int("expand-max-issues", sync.DefaultExpandMaxIssues, "Safety limit on the total sync set when using --expand-depth")
+	syncCmd.Flags().Bool("include-subtasks", false, "Also sync the sub-tasks of --issues so parent/child links resolve")
+	syncCmd.Flags().String("permission-check", string(sync.PermissionCheckOff), "Check browse permission on the sync's projects before fetching: off, warn, or fail")
+	syncCmd.Flags().Int("max-api-calls", 0, "Stop starting new issues once this many JIRA API calls are used; the rest are left for a later run (0: unlimited)")
+	syncCmd.Flags().String("max-duration", "", "Stop starting new issues after this long (e.g. 30m); the rest are left for a later run")
+	syncCmd.Flags().String("projects", "", "Sync these whole projects (comma-separated keys), each into its own repository under --repo-base, several at a time")
+	syncCmd.Flags().String("repo-base", "", "Parent directory for --projects repositories ({repo-base}/{PROJECT})")
+	syncCmd.Flags().Int("project-concurrency", DefaultProjectConcurrency, "Projects --projects syncs at the same time (each with --concurrency workers, all sharing one rate limiter)")
 	syncCmd.Flags().StringP("repo", "r", "", "Target Git repository path - will be created if it doesn't exist (required when not using profile)")
 	syncCmd.Flags().IntP("concurrency", "c", 0, "Parallel workers for batch processing (1-10, overrides profile setting)")
+	syncCmd.Flags().Int("link-concurrency", links.DefaultLinkConcurrency, "Parallel relationship link creation per issue (1-20, 0: default)")
+	syncCmd.Flags().String("link-naming", string(links.LinkNamingKey), "How relationship links are named: key (the synced issue's key), key-summary (both keys and the linked issue's summary), or type-key (relationship type and both keys)")
+	syncCmd.Flags().Int("bulk-fetch-size", client.DefaultBulkFetchSize, fmt.Sprintf("Issues fetched per JIRA search call when syncing an issue list (1-%d, 1: one request per issue, 0: default)", client.MaxBulkFetchSize))
 	syncCmd.Flags().String("rate-limit", "", "API call delay between requests (examples: 100ms, 1s, 2s, overrides profile setting)")
 
 	// Incremental sync flags
 	syncCmd.Flags().Bool("incremental", false, "Perform incremental sync (only sync changed issues since last sync)")
 	syncCmd.Flags().Bool("force", false, "Force full sync (ignore state and sync all issues)")
 	syncCmd.Flags().Bool("dry-run", false, "Show what would be synced without making changes")
+	syncCmd.Flags().StringSlice("track-fields", nil, "Record the last-synced value of these fields per issue in the sync state (comma-separated: "+strings.Join(state.TrackableFields, ", ")+")")
+	syncCmd.Flags().Bool("status-transitions-only", false, "With --incremental, only sync issues whose status changed since the last sync, skipping pure edits (reads each issue's changelog)")
+
+	// User resolution flags
+	syncCmd.Flags().Bool("resolve-users", false, "Resolve assignee/reporter account IDs to display names and emails (extra API calls, cached per run; env: JIRA_RESOLVE_USERS)")
+
+	// Time tracking flags
+	syncCmd.Flags().Bool("include-worklogs", false, "Write each issue's worklogs (author, started, time spent) and time tracking estimates (extra API calls for issues with many worklogs; env: JIRA_INCLUDE_WORKLOGS)")
+
+	// Response cache flags
+	syncCmd.Flags().String("cache-dir", "", "Directory of cached JIRA responses; enables --cache-mode=readwrite unless a mode is set (env: JIRA_CACHE_DIR)")
+	syncCmd.Flags().String("cache-mode", string(client.CacheOff), "How the response cache is used: off, read (offline, cache only), write (always fetch, store responses), or readwrite (env: JIRA_CACHE_MODE)")
+	syncCmd.Flags().Duration("cache-ttl", 0, "Age after which cached responses are fetched again (0: never expire; env: JIRA_CACHE_TTL)")
+	syncCmd.Flags().Bool("refresh-cache", false, "Fetch every response from JIRA for this run and store it, ignoring cached entries")
+
+	// JQL template flags
+	syncCmd.Flags().Bool("transform-jql", true, "Expand @alias shortcuts in JQL (built-ins such as @mine and @open, plus jql_aliases from ~/.jira-sync.yaml and ./.jira-sync.yaml)")
+	syncCmd.Flags().StringArray("jql-var", nil, "JQL template variable as Name=value, repeatable (resolves {{.Name}} in --jql or profile JQL; env: JQL_VAR_Name)")
+
+	// Git-aware incremental flags
+	syncCmd.Flags().Bool("only-changed-since-commit", false, "Only sync issues updated in JIRA since the repository's last commit (uses Git history instead of a state file)")
+	syncCmd.Flags().String("base-ref", "", "Commit, branch, or tag to measure --only-changed-since-commit from (default: HEAD)")
+
+	// Dirty working tree flags
+	syncCmd.Flags().String("on-dirty", "fail", "What to do when the repository has uncommitted changes: fail, stash (shelve and restore after sync), commit (commit them first), or ignore (risky: staged changes end up in sync commits)")
+
+	// Output flags
+	syncCmd.Flags().Bool("generate-index", false, "Write a sorted index of all issue files per project (projects/<KEY>/index.yaml) and commit it after the sync")
+	syncCmd.Flags().Bool("generate-relationship-index", false, "Write every relationship of the synced issues as from/type/to triples to relationships/index.yaml and commit it after the sync")
+	syncCmd.Flags().String("index-format", schema.IndexFormatYAML, "Index file format for --generate-index: yaml or markdown (index.md)")
+	syncCmd.Flags().String("index-sort", schema.IndexSortKey, "Issue order of --generate-index indexes: "+strings.Join(schema.IndexSorts, ", ")+" (ties are ordered by key)")
+	syncCmd.Flags().Bool("checksums", false, "Write a SHA-256 manifest of all issue files per project (projects/<KEY>/checksums.sha256) and commit it after the sync")
+	syncCmd.Flags().String("post-sync-hook", "", "Shell command run after a successful sync, with the result summary as JSON on stdin and in JIRA_SYNC_* environment variables")
+	syncCmd.Flags().String("post-sync-hook-on-failure", "", "Shell command run after a failed sync, with the same summary as --post-sync-hook")
+	syncCmd.Flags().Bool("hook-must-succeed", false, "Fail the command when a post-sync hook fails (default: log the failure and keep the sync's result)")
+	syncCmd.Flags().Bool("write-status-badge", false, "Write a summary of the sync (time, counts, success, operation ID) to "+sync.StatusBadgeFileName+" at the repository root and commit it")
+	syncCmd.Flags().Bool("no-manifest", false, "Do not record the sync configuration (scope, options, JIRA settings, tool version) in "+sync.ManifestFileName+" at the repository root")
+	syncCmd.Flags().Bool("sign", false, "Also sign each checksum manifest with the Ed25519 key in JIRA_SYNC_SIGNING_KEY (implies --checksums)")
+	syncCmd.Flags().String("order-by", jql.DefaultOrderBy, "JQL result ordering, comma-separated fields with optional ASC/DESC (e.g. \"priority DESC, key\"); key is always the final tie-breaker. Replaces an ORDER BY in the query when set explicitly")
+	syncCmd.Flags().Bool("minimal", false, "Write lightweight status files with only "+strings.Join(schema.MinimalFields, ", ")+" (--exclude-fields still applies)")
+	syncCmd.Flags().Bool("minimal-relationships", false, "With --minimal, also write each issue's relationships")
+	syncCmd.Flags().StringArray("exclude-fields", nil, "YAML fields to omit, comma-separated or repeatable: globs (description, customfield_*, assignee.email) or re:<regex> on the dotted path")
+	syncCmd.Flags().Bool("prune", false, "Remove files of issues that no longer match --jql/--epic-key (within projects the query still covers) and commit the removal")
+	syncCmd.Flags().Bool("handle-deletions", false, "Remove the files and links of mirrored issues deleted in JIRA (HTTP 404) and commit the removal; issues that can no longer be read (HTTP 403) are reported and kept")
+	syncCmd.Flags().String("archive-pruned", "", "With --prune, move pruned issue files to this repository directory ({path}/{project}/{key}.yaml) instead of deleting them")
+	syncCmd.Flags().Bool("confirm", false, "Apply --prune and --handle-deletions removals without asking (otherwise they are listed and confirmed interactively, and refused without a terminal)")
+	syncCmd.Flags().Bool("yes", false, "Same as --confirm: skip the removal confirmation, for automation. Files are removed without review")
+	syncCmd.Flags().Bool("merge-update", false, "Update existing issue files in place: only changed fields are rewritten, keeping key order, comments, and untouched content (malformed files are rewritten in full)")
+	syncCmd.Flags().String("adf-render", string(schema.ADFRenderMarkdown), "How to write JIRA Cloud rich-text (ADF) descriptions: markdown, text, or raw (unchanged JSON)")
+	syncCmd.Flags().String("flatten-fields", "", "Override how custom field objects are flattened, as type=property pairs (e.g. user=accountId,option=id,other=auto; property raw keeps the object)")
+	syncCmd.Flags().Bool("no-flatten", false, "Write custom field values raw, as the objects JIRA returns")
+	syncCmd.Flags().String("normalize-timestamps", string(schema.TimestampPreserve), "How to write timestamps: preserve (as JIRA returns them) or utc (2024-03-15T07:30:00.000Z, for stable diffs)")
+	syncCmd.Flags().String("field-order", "", "Comma-separated top-level fields to write first in new issue files, with * marking where unlisted fields go (e.g. key,summary,status,*,custom_fields; default: "+strings.Join(schema.DefaultFieldOrder, ",")+")")
+	syncCmd.Flags().String("frontmatter-fields", "", "With --format=markdown, start each issue file with YAML frontmatter of these comma-separated fields, for static-site generators ("+strings.Join(schema.FrontmatterFields, ",")+"; default for "+strings.Join(schema.DefaultFrontmatterFields, ",")+")")
+	syncCmd.Flags().Duration("ramp-up", 0, "Start workers gradually over this period (e.g. 10s) instead of all at once, to avoid an initial burst of API calls (0: no ramp-up)")
+	syncCmd.Flags().String("quiet-hours", "", "Daily window (e.g. 09:00-17:00) during which syncs slow to one worker or pause, to leave JIRA to interactive users")
+	syncCmd.Flags().String("quiet-hours-timezone", "", "IANA timezone of --quiet-hours, e.g. Europe/Berlin (default: local time)")
+	syncCmd.Flags().String("quiet-hours-mode", "", "During --quiet-hours: slow (one worker, one issue per second; default) or pause (no issues until they end)")
+	syncCmd.Flags().String("commit-strategy", string(sync.CommitPerIssue), "How synced issues are committed: per-issue, batch (one commit listing added/modified issues), or summary (one commit with a count)")
+	syncCmd.Flags().Int("checkpoint-interval", 0, "With --commit-strategy batch or summary, commit every N changed issues instead of once at the end (0: one commit)")
+	syncCmd.Flags().Int("git-retries", 0, "Retry Git init and commits that fail transiently (a lock held by another process, a dropped connection) up to N times (0: no retries)")
+	syncCmd.Flags().Duration("git-retry-backoff", git.DefaultRetryBackoff, "Wait before the first Git retry, doubled for each further retry")
+	syncCmd.Flags().Int("max-files", sync.DefaultMaxFiles, "Safety limit: abort before writing if the sync scope has more issues than this (counted with one search; a dry run only warns)")
+	syncCmd.Flags().Bool("no-file-limit", false, "Disable the --max-files safety limit")
+	syncCmd.Flags().String("format", SyncFormatYAML, "Output format: yaml (issue files in --repo), markdown (browsable Markdown issue files in --repo), or ndjson (every issue as one JSON line in --output-file, for data pipelines)")
+	syncCmd.Flags().String("output-file", "", "With --format=ndjson, the file (or named pipe) to write issues to; replaced if it exists")
+	syncCmd.Flags().String("transform", "", "Post-process each issue before writing: an executable (issue JSON on stdin, JSON on stdout) or a Go template (.tmpl/.tpl/.gotmpl) that renders JSON")
+	syncCmd.Flags().String("filter-expr", "", `Only write fetched issues matching this expression, for conditions JQL cannot express (e.g. 'customfield_10016 > 8 and assignee == null')`)
+
+	// Reporting flags
+	syncCmd.Flags().Int("sample", 0, fmt.Sprintf("With --dry-run, fetch and print the first N issues by key as they would be written, instead of planning the full sync (max %d)", sync.MaxSampleSize))
+	syncCmd.Flags().String("dry-run-report", "", "Write the planned changes to this path for review (JSON, or YAML for .yaml/.yml); implies --dry-run")
+	syncCmd.Flags().Bool("show-diff", false, "Include file diffs for planned updates in dry-run output and reports")
+	syncCmd.Flags().String("progress-socket", "", "Publish live progress as newline-delimited JSON on this Unix socket (or existing named pipe) for local clients")
+	syncCmd.Flags().String("junit-report", "", "Write per-issue sync results as JUnit XML to this path (one test case per issue, grouped by project)")
+
+	// Watch mode flags
+	syncCmd.Flags().Bool("watch", false, "Keep running incremental syncs on an interval until interrupted (requires --incremental)")
+	syncCmd.Flags().String("watch-interval", DefaultWatchInterval.String(), "Time between watch cycles, and the interval restored whenever a cycle finds changes")
+	syncCmd.Flags().Float64("watch-interval-backoff", 1, "Multiply the watch interval by this after each cycle without changes, up to --watch-max-interval (1: fixed interval)")
+	syncCmd.Flags().String("watch-max-interval", DefaultWatchMaxInterval.String(), "Longest time between watch cycles, for idle and failure backoff")
+
+	// Profiling flags
+	syncCmd.Flags().String("cpuprofile", "", "Write a pprof CPU profile of the sync to this path")
+	syncCmd.Flags().String("memprofile", "", "Write a pprof heap profile to this path when the sync finishes")
+	syncCmd.Flags().String("trace", "", "Write a Go execution trace of the sync to this path (view with go tool trace)")
 
 	// Note: --repo is required when not using --profile, but we validate this in the command function
 }
 
+// profileUnsupportedFlags are the sync flags that have no profile option, so a profile sync
+// cannot apply them
+var profileUnsupportedFlags = []string{
+	"junit-report", "include-worklogs", "track-fields", "resolve-users", "expand-depth",
+	"expand-max-issues", "include-subtasks", "only-changed-since-commit", "base-ref",
+	"dry-run-report", "show-diff",
+}
+
+// checkProfileFlags refuses flags that a profile sync would otherwise ignore
+func checkProfileFlags(cmd *cobra.Command) error {
+	var unsupported []string
+	for _, name := range profileUnsupportedFlags {
+		if cmd.Flags().Changed(name) {
+			unsupported = append(unsupported, "--"+name)
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("--profile does not support %s", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
 // runProfileSync executes sync using a saved profile
-func runProfileSync(cmd *cobra.Command, profileName string) error {
+func runProfileSync(ctx context.Context, cmd *cobra.Command, profileName string) error {
 	// Load profile
 	fmt.Printf("📋 Loading profile '%s'...\n", profileName)
-	manager := profile.NewFileProfileManager(".", "yaml")
+	manager := profile.NewFileProfileManager(".", profile.FormatAuto)
 
 	p, err := manager.GetProfile(profileName)
 	if err != nil {
@@ -538,6 +2287,27 @@ func runProfileSync(cmd *cobra.Command, profileName string) error {
 		fmt.Printf("🔧 Overriding concurrency: %d\n", concurrency)
 	}
 
+	// Override link concurrency if provided
+	if cmd.Flags().Changed("link-concurrency") {
+		linkConcurrency, _ := cmd.Flags().GetInt("link-concurrency")
+		overriddenProfile.Options.LinkConcurrency = linkConcurrency
+		fmt.Printf("🔧 Overriding link concurrency: %d\n", linkConcurrency)
+	}
+
+	// Override link naming if provided
+	if cmd.Flags().Changed("link-naming") {
+		linkNaming, _ := cmd.Flags().GetString("link-naming")
+		overriddenProfile.Options.LinkNaming = linkNaming
+		fmt.Printf("🔧 Overriding link naming: %s\n", linkNaming)
+	}
+
+	// Override bulk fetch size if provided
+	if cmd.Flags().Changed("bulk-fetch-size") {
+		bulkFetchSize, _ := cmd.Flags().GetInt("bulk-fetch-size")
+		overriddenProfile.Options.BulkFetchSize = bulkFetchSize
+		fmt.Printf("🔧 Overriding bulk fetch size: %d\n", bulkFetchSize)
+	}
+
 	// Override rate limit if provided
 	if cmd.Flags().Changed("rate-limit") {
 		rateLimit, _ := cmd.Flags().GetString("rate-limit")
@@ -566,6 +2336,283 @@ func runProfileSync(cmd *cobra.Command, profileName string) error {
 		fmt.Printf("🔧 Overriding dry-run: %t\n", dryRun)
 	}
 
+	// Override dirty working tree policy if provided
+	if cmd.Flags().Changed("on-dirty") {
+		onDirty, _ := cmd.Flags().GetString("on-dirty")
+		overriddenProfile.Options.OnDirty = onDirty
+		fmt.Printf("🔧 Overriding on-dirty: %s\n", onDirty)
+	}
+
+	// Override index generation if provided
+	if cmd.Flags().Changed("generate-index") {
+		generateIndex, _ := cmd.Flags().GetBool("generate-index")
+		overriddenProfile.Options.GenerateIndex = generateIndex
+		fmt.Printf("🔧 Overriding generate-index: %t\n", generateIndex)
+	}
+	if cmd.Flags().Changed("generate-relationship-index") {
+		generateRelationshipIndex, _ := cmd.Flags().GetBool("generate-relationship-index")
+		overriddenProfile.Options.GenerateRelationshipIndex = generateRelationshipIndex
+		fmt.Printf("🔧 Overriding generate-relationship-index: %t\n", generateRelationshipIndex)
+	}
+	if cmd.Flags().Changed("index-format") {
+		indexFormat, _ := cmd.Flags().GetString("index-format")
+		overriddenProfile.Options.IndexFormat = indexFormat
+		fmt.Printf("🔧 Overriding index-format: %s\n", indexFormat)
+	}
+	if cmd.Flags().Changed("index-sort") {
+		indexSort, _ := cmd.Flags().GetString("index-sort")
+		overriddenProfile.Options.IndexSort = indexSort
+		fmt.Printf("🔧 Overriding index-sort: %s\n", indexSort)
+	}
+
+	// Override checksum manifests and signing if provided
+	if cmd.Flags().Changed("handle-deletions") {
+		handleDeletions, _ := cmd.Flags().GetBool("handle-deletions")
+		overriddenProfile.Options.HandleDeletions = handleDeletions
+		fmt.Printf("🔧 Overriding handle-deletions: %t\n", handleDeletions)
+	}
+
+	// Override pruning if provided
+	if cmd.Flags().Changed("prune") {
+		prune, _ := cmd.Flags().GetBool("prune")
+		overriddenProfile.Options.Prune = prune
+		fmt.Printf("🔧 Overriding prune: %t\n", prune)
+	}
+	if cmd.Flags().Changed("archive-pruned") {
+		archivePruned, _ := cmd.Flags().GetString("archive-pruned")
+		overriddenProfile.Options.ArchivePruned = archivePruned
+		fmt.Printf("🔧 Overriding archive-pruned: %s\n", archivePruned)
+	}
+
+	if cmd.Flags().Changed("write-status-badge") {
+		writeStatusBadge, _ := cmd.Flags().GetBool("write-status-badge")
+		overriddenProfile.Options.WriteStatusBadge = writeStatusBadge
+		fmt.Printf("🔧 Overriding write-status-badge: %t\n", writeStatusBadge)
+	}
+
+	if cmd.Flags().Changed("no-manifest") {
+		noManifest, _ := cmd.Flags().GetBool("no-manifest")
+		overriddenProfile.Options.NoManifest = noManifest
+		fmt.Printf("🔧 Overriding no-manifest: %t\n", noManifest)
+	}
+
+	if cmd.Flags().Changed("checksums") {
+		checksums, _ := cmd.Flags().GetBool("checksums")
+		overriddenProfile.Options.Checksums = checksums
+		fmt.Printf("🔧 Overriding checksums: %t\n", checksums)
+	}
+	if cmd.Flags().Changed("sign") {
+		sign, _ := cmd.Flags().GetBool("sign")
+		overriddenProfile.Options.Sign = sign
+		fmt.Printf("🔧 Overriding sign: %t\n", sign)
+	}
+
+	// Override excluded fields if provided
+	if cmd.Flags().Changed("minimal") {
+		minimal, _ := cmd.Flags().GetBool("minimal")
+		overriddenProfile.Options.Minimal = minimal
+		fmt.Printf("🔧 Overriding minimal mode: %t\n", minimal)
+	}
+	if cmd.Flags().Changed("minimal-relationships") {
+		minimalRelationships, _ := cmd.Flags().GetBool("minimal-relationships")
+		overriddenProfile.Options.MinimalRelationships = minimalRelationships
+		fmt.Printf("🔧 Overriding minimal relationships: %t\n", minimalRelationships)
+	}
+	if cmd.Flags().Changed("exclude-fields") {
+		excludeFields, _ := cmd.Flags().GetStringArray("exclude-fields")
+		overriddenProfile.Options.ExcludeFields = schema.ParseFieldPatterns(excludeFields...)
+		fmt.Printf("🔧 Overriding exclude-fields: %s\n", strings.Join(overriddenProfile.Options.ExcludeFields, ", "))
+	}
+
+	// Override issue transform if provided
+	if cmd.Flags().Changed("transform") {
+		transformPath, _ := cmd.Flags().GetString("transform")
+		overriddenProfile.Options.Transform = transformPath
+		fmt.Printf("🔧 Overriding transform: %s\n", transformPath)
+	}
+
+	// Override the issue filter if provided
+	if cmd.Flags().Changed("filter-expr") {
+		filterExpr, _ := cmd.Flags().GetString("filter-expr")
+		overriddenProfile.Options.FilterExpr = filterExpr
+		fmt.Printf("🔧 Overriding filter expression: %s\n", filterExpr)
+	}
+
+	// Override merge updates if provided
+	if cmd.Flags().Changed("merge-update") {
+		mergeUpdate, _ := cmd.Flags().GetBool("merge-update")
+		overriddenProfile.Options.MergeUpdate = mergeUpdate
+		fmt.Printf("🔧 Overriding merge update: %t\n", mergeUpdate)
+	}
+
+	// Override rich-text description rendering if provided
+	if cmd.Flags().Changed("adf-render") {
+		adfRender, _ := cmd.Flags().GetString("adf-render")
+		overriddenProfile.Options.ADFRender = adfRender
+		fmt.Printf("🔧 Overriding ADF rendering: %s\n", adfRender)
+	}
+
+	// Override timestamp normalization if provided
+	if cmd.Flags().Changed("normalize-timestamps") {
+		normalizeTimestamps, _ := cmd.Flags().GetString("normalize-timestamps")
+		overriddenProfile.Options.NormalizeTimestamps = normalizeTimestamps
+		fmt.Printf("🔧 Overriding timestamp normalization: %s\n", normalizeTimestamps)
+	}
+
+	// Override issue field ordering if provided
+	if cmd.Flags().Changed("field-order") {
+		fieldOrder, _ := cmd.Flags().GetString("field-order")
+		overriddenProfile.Options.FieldOrder = fieldOrder
+		fmt.Printf("🔧 Overriding field order: %s\n", fieldOrder)
+	}
+
+	// Override the frontmatter of Markdown issue files if provided
+	if cmd.Flags().Changed("frontmatter-fields") {
+		frontmatterFields, _ := cmd.Flags().GetString("frontmatter-fields")
+		overriddenProfile.Options.FrontmatterFields = frontmatterFields
+		fmt.Printf("🔧 Overriding frontmatter fields: %s\n", frontmatterFields)
+	}
+
+	// Override custom field flattening if provided
+	if cmd.Flags().Changed("flatten-fields") {
+		flattenFields, _ := cmd.Flags().GetString("flatten-fields")
+		overriddenProfile.Options.FlattenFields = flattenFields
+		fmt.Printf("🔧 Overriding custom field flattening: %s\n", flattenFields)
+	}
+	if cmd.Flags().Changed("no-flatten") {
+		noFlatten, _ := cmd.Flags().GetBool("no-flatten")
+		overriddenProfile.Options.NoFlatten = noFlatten
+		fmt.Printf("🔧 Overriding no-flatten: %t\n", noFlatten)
+	}
+
+	// Override the sync budget if provided
+	if cmd.Flags().Changed("max-api-calls") {
+		maxAPICalls, _ := cmd.Flags().GetInt("max-api-calls")
+		overriddenProfile.Options.MaxAPICalls = maxAPICalls
+		fmt.Printf("🔧 Overriding max API calls: %d\n", maxAPICalls)
+	}
+	if cmd.Flags().Changed("max-duration") {
+		maxDuration, _ := cmd.Flags().GetString("max-duration")
+		overriddenProfile.Options.MaxDuration = maxDuration
+		fmt.Printf("🔧 Overriding max duration: %s\n", maxDuration)
+	}
+
+	// Override the worker ramp-up if provided
+	if cmd.Flags().Changed("ramp-up") {
+		rampUp, _ := cmd.Flags().GetDuration("ramp-up")
+		overriddenProfile.Options.RampUp = rampUp.String()
+		fmt.Printf("🔧 Overriding worker ramp-up: %s\n", rampUp)
+	}
+
+	// Override the business-hours throttle if provided
+	if cmd.Flags().Changed("quiet-hours") {
+		quietHours, _ := cmd.Flags().GetString("quiet-hours")
+		overriddenProfile.Options.QuietHours = quietHours
+		fmt.Printf("🔧 Overriding quiet hours: %s\n", quietHours)
+	}
+	if cmd.Flags().Changed("quiet-hours-timezone") {
+		timezone, _ := cmd.Flags().GetString("quiet-hours-timezone")
+		overriddenProfile.Options.QuietHoursTimezone = timezone
+		fmt.Printf("🔧 Overriding quiet hours timezone: %s\n", timezone)
+	}
+	if cmd.Flags().Changed("quiet-hours-mode") {
+		mode, _ := cmd.Flags().GetString("quiet-hours-mode")
+		overriddenProfile.Options.QuietHoursMode = mode
+		fmt.Printf("🔧 Overriding quiet hours mode: %s\n", mode)
+	}
+
+	// Override the commit strategy if provided
+	if cmd.Flags().Changed("commit-strategy") {
+		commitStrategy, _ := cmd.Flags().GetString("commit-strategy")
+		overriddenProfile.Options.CommitStrategy = commitStrategy
+		fmt.Printf("🔧 Overriding commit strategy: %s\n", commitStrategy)
+	}
+	if cmd.Flags().Changed("checkpoint-interval") {
+		checkpointInterval, _ := cmd.Flags().GetInt("checkpoint-interval")
+		overriddenProfile.Options.CheckpointInterval = checkpointInterval
+		fmt.Printf("🔧 Overriding checkpoint interval: %d\n", checkpointInterval)
+	}
+
+	// Override Git operation retries if provided
+	if cmd.Flags().Changed("git-retries") {
+		gitRetries, _ := cmd.Flags().GetInt("git-retries")
+		overriddenProfile.Options.GitRetries = gitRetries
+		fmt.Printf("🔧 Overriding Git retries: %d\n", gitRetries)
+	}
+	if cmd.Flags().Changed("git-retry-backoff") {
+		gitRetryBackoff, _ := cmd.Flags().GetDuration("git-retry-backoff")
+		overriddenProfile.Options.GitRetryBackoff = gitRetryBackoff.String()
+		fmt.Printf("🔧 Overriding Git retry backoff: %s\n", gitRetryBackoff)
+	}
+
+	// Override the file count safety limit if provided
+	if cmd.Flags().Changed("max-files") {
+		maxFiles, _ := cmd.Flags().GetInt("max-files")
+		overriddenProfile.Options.MaxFiles = maxFiles
+		fmt.Printf("🔧 Overriding file limit: %d\n", maxFiles)
+	}
+	if cmd.Flags().Changed("no-file-limit") {
+		noFileLimit, _ := cmd.Flags().GetBool("no-file-limit")
+		overriddenProfile.Options.NoFileLimit = noFileLimit
+		fmt.Printf("🔧 Overriding file limit: disabled=%t\n", noFileLimit)
+	}
+
+	// Override the pre-sync permission check if provided
+	if cmd.Flags().Changed("permission-check") {
+		permissionCheck, _ := cmd.Flags().GetString("permission-check")
+		overriddenProfile.Options.PermissionCheck = permissionCheck
+		fmt.Printf("🔧 Overriding permission check: %s\n", permissionCheck)
+	}
+
+	// Override status-transitions-only mode if provided
+	if cmd.Flags().Changed("status-transitions-only") {
+		statusTransitionsOnly, _ := cmd.Flags().GetBool("status-transitions-only")
+		overriddenProfile.Options.StatusTransitionsOnly = statusTransitionsOnly
+		fmt.Printf("🔧 Overriding status-transitions-only: %t\n", statusTransitionsOnly)
+	}
+
+	// Override EPIC slice filter if provided
+	if cmd.Flags().Changed("within") {
+		within, _ := cmd.Flags().GetString("within")
+		overriddenProfile.EpicWithin = within
+		fmt.Printf("🔧 Overriding within: %s\n", within)
+	}
+
+	// Override the issue file format if provided
+	if cmd.Flags().Changed("format") {
+		format, _ := cmd.Flags().GetString("format")
+		overriddenProfile.Options.Format = format
+		fmt.Printf("🔧 Overriding format: %s\n", format)
+	}
+
+	// Override result ordering if provided
+	if cmd.Flags().Changed("order-by") {
+		orderBy, _ := cmd.Flags().GetString("order-by")
+		overriddenProfile.Options.OrderBy = orderBy
+		fmt.Printf("🔧 Overriding order-by: %s\n", orderBy)
+	}
+
+	// Expand @alias shortcuts and resolve JQL template variables so one profile can serve many
+	// parameterized runs
+	if overriddenProfile.JQL != "" {
+		expanded, err := expandJQLAliases(cmd, overriddenProfile.JQL)
+		if err != nil {
+			return err
+		}
+		resolved, err := resolveJQLTemplate(cmd, expanded)
+		if err != nil {
+			return err
+		}
+		overriddenProfile.JQL = resolved
+	}
+	if overriddenProfile.EpicWithin != "" {
+		expanded, err := expandJQLAliases(cmd, overriddenProfile.EpicWithin)
+		if err != nil {
+			return err
+		}
+		overriddenProfile.EpicWithin = expanded
+	}
+
 	// Show profile info
 	fmt.Printf("📋 Profile: %s\n", overriddenProfile.Name)
 	fmt.Printf("📁 Repository: %s\n", overriddenProfile.Repository)
@@ -574,6 +2621,9 @@ func runProfileSync(cmd *cobra.Command, profileName string) error {
 	if overriddenProfile.EpicKey != "" {
 		syncType = "EPIC"
 		fmt.Printf("🎯 EPIC: %s\n", overriddenProfile.EpicKey)
+		if overriddenProfile.EpicWithin != "" {
+			fmt.Printf("🔎 Within: %s\n", overriddenProfile.EpicWithin)
+		}
 	} else if overriddenProfile.JQL != "" {
 		syncType = "JQL"
 		fmt.Printf("🔍 JQL: %s\n", overriddenProfile.JQL)
@@ -598,22 +2648,67 @@ func runProfileSync(cmd *cobra.Command, profileName string) error {
 		return fmt.Errorf("profile validation failed: %s", strings.Join(validation.Errors, "; "))
 	}
 
+	// Sampling previews a few rendered issues instead of running the profile's sync
+	sample, _ := cmd.Flags().GetInt("sample")
+	if err := validateSampleSize(sample); err != nil {
+		return err
+	}
+	if sample > 0 && !overriddenProfile.Options.DryRun {
+		return fmt.Errorf("--sample requires --dry-run (or dry_run in the profile)")
+	}
+
+	// Publish live progress to local clients such as a GUI
+	var progress *progressSocket
+	if progressSocketPath, _ := cmd.Flags().GetString("progress-socket"); progressSocketPath != "" {
+		progress, err = newProgressSocket(progressSocketPath)
+		if err != nil {
+			return err
+		}
+		defer progress.Close()
+		fmt.Printf("📡 Streaming progress to %s\n", progressSocketPath)
+	}
+
+	// Request a stable result order so processing and generated files are deterministic
+	orderBy := overriddenProfile.Options.OrderBy
+	explicitOrder := orderBy != ""
+	if !explicitOrder {
+		orderBy = jql.DefaultOrderBy
+	}
+
 	// Execute sync based on profile configuration
 	startTime := time.Now()
+	removals := newRemovalGuard(cmd)
+	responseCache, err := newResponseCacheFlags(cmd)
+	if err != nil {
+		return err
+	}
 	var syncErr error
 
 	if overriddenProfile.EpicKey != "" {
-		// EPIC-based sync - delegate to JQL with epic expansion
+		// EPIC-based sync - delegate to JQL with epic expansion, narrowed by epic_within
 		// For now, convert to JQL query (in future, could integrate with EPIC analyzer)
-		epicJQL := fmt.Sprintf("\"Epic Link\" = %s", overriddenProfile.EpicKey)
-		syncErr = executeProfileSync(&overriddenProfile, epicJQL, syncType)
+		scopedJQL, err := jql.ScopeToEpic(overriddenProfile.EpicKey, overriddenProfile.EpicWithin)
+		if err != nil {
+			return fmt.Errorf("invalid EPIC configuration: %w", err)
+		}
+		epicJQL, err := orderSyncJQL(scopedJQL, orderBy, explicitOrder)
+		if err != nil {
+			return err
+		}
+		warnQueryCost(epicJQL)
+		syncErr = executeProfileSync(ctx, &overriddenProfile, epicJQL, syncType, sample, progress, removals, responseCache)
 	} else if overriddenProfile.JQL != "" {
 		// JQL-based sync
-		syncErr = executeProfileSync(&overriddenProfile, overriddenProfile.JQL, syncType)
+		orderedJQL, err := orderSyncJQL(overriddenProfile.JQL, orderBy, explicitOrder)
+		if err != nil {
+			return err
+		}
+		warnQueryCost(orderedJQL)
+		syncErr = executeProfileSync(ctx, &overriddenProfile, orderedJQL, syncType, sample, progress, removals, responseCache)
 	} else if len(overriddenProfile.IssueKeys) > 0 {
 		// Issue list sync - convert to issues argument and execute
 		issuesArg := strings.Join(overriddenProfile.IssueKeys, ",")
-		syncErr = executeProfileSyncWithIssues(&overriddenProfile, issuesArg, syncType)
+		syncErr = executeProfileSyncWithIssues(ctx, &overriddenProfile, issuesArg, syncType, sample, progress, removals, responseCache)
 	} else {
 		return fmt.Errorf("profile does not specify any sync mode (JQL, EPIC, or issue keys)")
 	}
@@ -635,7 +2730,8 @@ func runProfileSync(cmd *cobra.Command, profileName string) error {
 }
 
 // executeProfileSync executes a JQL-based sync using profile configuration
-func executeProfileSync(p *profile.Profile, jql string, syncType string) error {
+// A positive sample previews that many rendered issues instead of running the sync.
+func executeProfileSync(ctx context.Context, p *profile.Profile, jql string, syncType string, sample int, progress *progressSocket, removals *removalGuard, responseCache *responseCacheFlags) error {
 	// This function replicates the sync logic but uses profile configuration
 	// For brevity, I'll implement a simplified version that delegates to the existing logic
 
@@ -643,7 +2739,16 @@ func executeProfileSync(p *profile.Profile, jql string, syncType string) error {
 	configLoader := config.NewDotEnvLoader()
 	cfg, err := configLoader.Load()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return configError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	if p.Options.StatusTransitionsOnly {
+		if !p.Options.Incremental || p.Options.Force {
+			return fmt.Errorf("status_transitions_only option requires incremental without force")
+		}
+		if err := requireChangelog(cfg, "status_transitions_only"); err != nil {
+			return configError(err)
+		}
 	}
 
 	// Apply rate limit from profile
@@ -652,33 +2757,213 @@ func executeProfileSync(p *profile.Profile, jql string, syncType string) error {
 			cfg.RateLimitDelay = rateLimitDuration
 		}
 	}
+	if p.Options.Minimal {
+		applyMinimalConfig(cfg, p.Options.MinimalRelationships, p.Options.FilterExpr != "")
+	}
+
+	if err := applyExternalRefConfig(cfg); err != nil {
+		return configError(err)
+	}
+	if err := responseCache.apply(cfg); err != nil {
+		return configError(err)
+	}
+	budget, err := profileSyncBudget(p.Options)
+	if err != nil {
+		return err
+	}
 
 	// Initialize JIRA client
 	fmt.Println("🔗 Connecting to JIRA...")
 	jiraClient, err := client.NewClient(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create JIRA client: %w", err)
+		return configError(fmt.Errorf("failed to create JIRA client: %w", err))
+	}
+	// The budget counts every request of the run, across all of its destinations
+	budgetTracker := sync.NewBudgetTracker(jiraClient, budget)
+	defer budgetTracker.Stop()
+	retryPolicy, err := client.NewRetryPolicy(cfg)
+	if err != nil {
+		return configError(err)
+	}
+	signingKey, err := loadSigningKey(cfg, p.Options.Sign && !p.Options.DryRun)
+	if err != nil {
+		return configError(err)
 	}
 
 	if err := jiraClient.Authenticate(); err != nil {
-		return fmt.Errorf("failed to authenticate with JIRA: %w", err)
+		return configError(fmt.Errorf("failed to authenticate with JIRA: %w", err))
+	}
+	if err := setupInterrupted(ctx); err != nil {
+		return err
+	}
+
+	if p.EpicKey != "" {
+		reportEpicSlice(jiraClient, p.EpicKey, p.EpicWithin)
+	}
+
+	permissionCheck, err := sync.ParsePermissionCheckMode(p.Options.PermissionCheck)
+	if err != nil {
+		return fmt.Errorf("invalid permission_check option: %w", err)
+	}
+	if err := verifyProjectPermissions(jiraClient, permissionCheck, "", jql); err != nil {
+		return configError(err)
+	}
+
+	operationID := state.NewOperationID()
+	fmt.Printf("🆔 Operation ID: %s\n", operationID)
+
+	destinations := p.DestinationProfiles()
+	if len(destinations) == 1 {
+		result, err := syncProfileRepository(ctx, jiraClient, cfg, retryPolicy, budgetTracker, signingKey, p, jql, syncType, sample, progress, removals, operationID)
+		if err != nil || result == nil {
+			return err
+		}
+		return syncResultError(ctx, result)
+	}
+
+	// Fetch each issue once and render it into every destination
+	sharedClient := sync.NewFetchOnceClient(jiraClient)
+	outcomes := make([]destinationOutcome, 0, len(destinations))
+	for i, destination := range destinations {
+		label := p.Repository
+		if i > 0 {
+			label = p.Destinations[i-1].Label()
+		}
+		fmt.Printf("🎯 Destination %d/%d: %s\n", i+1, len(destinations), label)
+		result, err := syncProfileRepository(ctx, sharedClient, cfg, retryPolicy, budgetTracker, signingKey, destination, jql, syncType, sample, progress, removals, operationID)
+		outcomes = append(outcomes, destinationOutcome{Label: label, Result: result, Err: err})
+		if err != nil {
+			fmt.Printf("❌ Destination %s failed: %v\n", label, err)
+		}
+		if ctx.Err() != nil {
+			break
+		}
 	}
+	if sample > 0 {
+		return firstDestinationError(outcomes)
+	}
+
+	printDestinationResults(outcomes)
+	return destinationsError(ctx, outcomes, len(destinations))
+}
 
+// syncProfileRepository syncs a profile's issues into its repository and reports the results
+// It returns a nil result when sampling, which previews issues instead of syncing. A nil budget
+// is unlimited.
+func syncProfileRepository(ctx context.Context, jiraClient client.Client, cfg *config.Config, retryPolicy *client.RetryPolicy, budget *sync.BudgetTracker, signingKey ed25519.PrivateKey, p *profile.Profile, jql string, syncType string, sample int, progress *progressSocket, removals *removalGuard, operationID string) (*sync.BatchResult, error) {
 	// Initialize Git repository
 	fmt.Printf("📁 Preparing Git repository at %s...\n", p.Repository)
-	gitRepo := git.NewGitRepository("JIRA CDC Git Sync", "jira-sync@automated.local")
+	gitRepo := git.NewGitRepositoryWithTrailers("JIRA CDC Git Sync", "jira-sync@automated.local", syncCommitTrailers(operationID, p.Name, jql)...)
+	gitRetry, err := profileGitRetryPolicy(p.Options)
+	if err != nil {
+		return nil, err
+	}
+	git.SetRetryPolicy(gitRepo, gitRetry)
 
 	if err := gitRepo.Initialize(p.Repository); err != nil {
-		return fmt.Errorf("failed to initialize Git repository: %w", err)
+		return nil, fmt.Errorf("failed to initialize Git repository: %w", err)
 	}
 
-	if err := gitRepo.ValidateWorkingTree(p.Repository); err != nil {
-		return fmt.Errorf("git repository validation failed: %w", err)
+	onDirty, err := git.ParseDirtyPolicy(p.Options.OnDirty)
+	if err != nil {
+		return nil, fmt.Errorf("invalid on_dirty option: %w", err)
+	}
+	if err := validateProfilePrune(p); err != nil {
+		return nil, err
+	}
+	if sample == 0 {
+		// Refuse runaway scopes before anything is written
+		if err := enforceFileLimit(jiraClient, "", jql, profileMaxFiles(p.Options), p.Options.DryRun); err != nil {
+			return nil, err
+		}
+	}
+	if err := setupInterrupted(ctx); err != nil {
+		return nil, err
+	}
+	preparedTree, err := prepareWorkingTree(gitRepo, p.Repository, onDirty, p.Options.DryRun)
+	if err != nil {
+		return nil, err
 	}
+	defer restoreWorkingTree(preparedTree)
 
 	// Initialize sync components
-	fileWriter := schema.NewYAMLFileWriter()
-	linkManager := links.NewSymbolicLinkManager()
+	fieldFilter, err := syncFieldFilter(p.Options.Minimal, p.Options.MinimalRelationships, p.Options.ExcludeFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude_fields option: %w", err)
+	}
+	transform, err := schema.NewIssueTransform(p.Options.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transform option: %w", err)
+	}
+	issueFilter, err := parseIssueFilter(p.Options.FilterExpr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter_expr option: %w", err)
+	}
+	adfRender, err := schema.ParseADFRenderMode(p.Options.ADFRender)
+	if err != nil {
+		return nil, fmt.Errorf("invalid adf_render option: %w", err)
+	}
+	commitStrategy, err := sync.ParseCommitStrategy(p.Options.CommitStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit_strategy option: %w", err)
+	}
+	if err := sync.ValidateCheckpointInterval(commitStrategy, p.Options.CheckpointInterval); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint_interval option: %w", err)
+	}
+	var rampUp time.Duration
+	if p.Options.RampUp != "" {
+		if rampUp, err = time.ParseDuration(p.Options.RampUp); err != nil {
+			return nil, fmt.Errorf("invalid ramp_up option: %w", err)
+		}
+	}
+	quietHours, err := ratelimit.ParseQuietHours(p.Options.QuietHours, p.Options.QuietHoursTimezone, p.Options.QuietHoursMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet_hours option: %w", err)
+	}
+	flatten, err := schema.ParseFlattenStrategy(p.Options.FlattenFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flatten_fields option: %w", err)
+	}
+	timestamps, err := schema.ParseTimestampMode(p.Options.NormalizeTimestamps)
+	if err != nil {
+		return nil, fmt.Errorf("invalid normalize_timestamps option: %w", err)
+	}
+	fieldOrder, err := schema.ParseFieldOrder(p.Options.FieldOrder)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field_order option: %w", err)
+	}
+	format, err := schema.ParseFileFormat(p.Options.Format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format option: %w", err)
+	}
+	frontmatterFields, err := schema.ParseFrontmatterFields(p.Options.FrontmatterFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid frontmatter_fields option: %w", err)
+	}
+	var fileWriter schema.FileWriter
+	if format == schema.FileFormatMarkdown {
+		if yamlOnly := p.Options.YAMLOnlyOptions(); len(yamlOnly) > 0 {
+			return nil, fmt.Errorf("format markdown cannot be combined with %s", strings.Join(yamlOnly, ", "))
+		}
+		fileWriter = newMarkdownIssueWriter(cfg, frontmatterFields)
+	} else {
+		if len(frontmatterFields) > 0 {
+			return nil, fmt.Errorf("frontmatter_fields requires format markdown")
+		}
+		codeRefs, err := loadCodeRefs()
+		if err != nil {
+			return nil, configError(err)
+		}
+		fileWriter = newIssueFileWriter(fieldFilter, transform, p.Options.MergeUpdate, adfRender, customFieldSchemas(jiraClient, p.Options.NoFlatten), flatten, timestamps, codeRefs, fieldOrder)
+	}
+	if sample > 0 {
+		return nil, previewSampleIssues(jiraClient, fileWriter, p.Repository, "", jql, sample)
+	}
+	linkNaming, err := links.ParseLinkNaming(p.Options.LinkNaming)
+	if err != nil {
+		return nil, fmt.Errorf("invalid link_naming option: %w", err)
+	}
+	linkManager := newLinkManager(p.Options.LinkConcurrency, linkNaming, format)
 
 	// Execute sync based on profile options
 	var result *sync.BatchResult
@@ -687,39 +2972,126 @@ func executeProfileSync(p *profile.Profile, jql string, syncType string) error {
 		// Use incremental engine
 		stateManager := state.NewFileStateManager(state.FormatYAML)
 		incrementalEngine := sync.NewIncrementalBatchSyncEngine(jiraClient, fileWriter, gitRepo, linkManager, stateManager, p.Options.Concurrency)
+		incrementalEngine.SetBulkFetchSize(p.Options.BulkFetchSize)
+		incrementalEngine.SetRetryPolicy(retryPolicy)
+		incrementalEngine.UseBudget(budget)
+		incrementalEngine.SetCommitStrategy(commitStrategy)
+		incrementalEngine.SetCheckpointInterval(p.Options.CheckpointInterval)
+		incrementalEngine.SetRampUp(rampUp)
+		incrementalEngine.SetQuietHours(quietHours)
+		incrementalEngine.SetIssueFilter(issueFilter)
+		incrementalEngine.SetOperationID(operationID)
+		defer streamProgress(incrementalEngine.BatchSyncEngine, progress)()
 
 		incrementalOptions := sync.IncrementalSyncOptions{
 			Force:           p.Options.Force,
 			DryRun:          p.Options.DryRun,
 			IncludeNew:      true,
 			IncludeModified: true,
+
+			StatusTransitionsOnly: p.Options.StatusTransitionsOnly,
 		}
 
 		fmt.Printf("🔄 %s sync using JQL: %s\n", syncType, jql)
-		result, err = incrementalEngine.SyncJQLIncremental(context.Background(), jql, p.Repository, incrementalOptions)
+		result, err = incrementalEngine.SyncJQLIncremental(ctx, jql, p.Repository, incrementalOptions)
 	} else {
 		// Use regular batch engine
 		batchEngine := sync.NewBatchSyncEngine(jiraClient, fileWriter, gitRepo, linkManager, p.Options.Concurrency)
+		batchEngine.SetBulkFetchSize(p.Options.BulkFetchSize)
+		batchEngine.SetRetryPolicy(retryPolicy)
+		batchEngine.UseBudget(budget)
+		batchEngine.SetCommitStrategy(commitStrategy)
+		batchEngine.SetCheckpointInterval(p.Options.CheckpointInterval)
+		batchEngine.SetRampUp(rampUp)
+		batchEngine.SetQuietHours(quietHours)
+		batchEngine.SetIssueFilter(issueFilter)
+		defer streamProgress(batchEngine, progress)()
 		fmt.Printf("📊 %s sync using JQL: %s\n", syncType, jql)
-		result, err = batchEngine.SyncJQL(context.Background(), jql, p.Repository)
+		result, err = batchEngine.SyncJQL(ctx, jql, p.Repository)
 	}
 
 	if err != nil {
-		return fmt.Errorf("sync failed: %w", err)
+		return nil, syncFailure(ctx, fmt.Errorf("sync failed: %w", err))
+	}
+
+	if p.Options.HandleDeletions {
+		if err := handleDeletedIssues(ctx, jiraClient, gitRepo, p.Repository, jql, p.Options.DryRun, removals, result); err != nil {
+			return nil, err
+		}
 	}
 
 	// Show results
+	result.OperationID = operationID
 	fmt.Printf("📊 Sync Results:\n")
+	fmt.Printf("  • Operation ID: %s\n", result.OperationID)
 	fmt.Printf("  • Total Issues: %d\n", result.TotalIssues)
 	fmt.Printf("  • Successful: %d\n", result.SuccessfulSync)
 	fmt.Printf("  • Failed: %d\n", result.FailedSync)
+	if len(result.Deleted) > 0 {
+		fmt.Printf("  • Deleted in JIRA: %d\n", len(result.Deleted))
+	}
+	if len(result.Filtered) > 0 {
+		fmt.Printf("  • Filtered out: %d\n", len(result.Filtered))
+	}
 	fmt.Printf("  • Duration: %v\n", result.Duration)
+	displayBudgetUsage(result.Budget)
 
-	return nil
+	if p.Options.Prune {
+		if err := pruneDepartedIssues(ctx, jiraClient, gitRepo, p.Repository, jql, p.Options.ArchivePruned, p.Options.DryRun, removals, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Options.GenerateIndex && !p.Options.DryRun {
+		indexFormat, err := schema.ParseIndexFormat(p.Options.IndexFormat)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index_format option: %w", err)
+		}
+		indexSort, err := schema.ParseIndexSort(p.Options.IndexSort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index_sort option: %w", err)
+		}
+		if err := updateProjectIndexes(gitRepo, p.Repository, indexFormat, indexSort); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Options.GenerateRelationshipIndex && !p.Options.DryRun {
+		if err := updateRelationshipIndex(gitRepo, p.Repository); err != nil {
+			return nil, err
+		}
+	}
+
+	if (p.Options.Checksums || p.Options.Sign) && !p.Options.DryRun {
+		if err := updateChecksumManifests(gitRepo, p.Repository, signingKey); err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.Options.NoManifest && !p.Options.DryRun {
+		manifest := sync.Manifest{
+			ToolVersion: client.Version,
+			Profile:     p.Name,
+			Scope:       profileManifestScope(p, jql),
+			Options:     p.Options,
+			JIRA:        sync.NewManifestJIRA(cfg),
+		}
+		if err := updateSyncManifest(gitRepo, p.Repository, manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Options.WriteStatusBadge && !p.Options.DryRun {
+		if err := updateStatusBadge(gitRepo, p.Repository, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
 
 // executeProfileSyncWithIssues executes an issue-list-based sync using profile configuration
-func executeProfileSyncWithIssues(p *profile.Profile, issuesArg string, syncType string) error {
+func executeProfileSyncWithIssues(ctx context.Context, p *profile.Profile, issuesArg string, syncType string, sample int, progress *progressSocket, removals *removalGuard, responseCache *responseCacheFlags) error {
 	// Similar to executeProfileSync but for issue lists
 	// This would parse the issues and call the appropriate sync method
 	// For now, converting to JQL as a simplified implementation
@@ -732,5 +3104,5 @@ func executeProfileSyncWithIssues(p *profile.Profile, issuesArg string, syncType
 	// Convert issue list to JQL
 	jql := fmt.Sprintf("key in (%s)", strings.Join(issues, ","))
 
-	return executeProfileSync(p, jql, syncType)
+	return executeProfileSync(ctx, p, jql, syncType, sample, progress, removals, responseCache)
 }
@@ -2,10 +2,21 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/profile"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
 	"github.com/spf13/cobra"
 )
 
@@ -54,6 +65,17 @@ func TestParseIssueList(t *testing.T) {
 		{"empty input", "", nil, true},
 		{"only commas", ",,", nil, true},
 		{"only spaces", "   ", nil, true},
+		{"range", "PROJ-1..PROJ-3", []string{"PROJ-1", "PROJ-2", "PROJ-3"}, false},
+		{"range with number end", "PROJ-9..11", []string{"PROJ-9", "PROJ-10", "PROJ-11"}, false},
+		{"range mixed with keys", "PROJ-100, PROJ-1..PROJ-2,OTHER-5", []string{"PROJ-100", "PROJ-1", "PROJ-2", "OTHER-5"}, false},
+		{"range with dashed project", "MY-PROJECT-1..MY-PROJECT-2", []string{"MY-PROJECT-1", "MY-PROJECT-2"}, false},
+		{"single key range", "PROJ-5..PROJ-5", []string{"PROJ-5"}, false},
+		{"range across projects", "PROJ-1..OTHER-5", nil, true},
+		{"reversed range", "PROJ-5..PROJ-1", nil, true},
+		{"oversized range", "PROJ-1..PROJ-1001", nil, true},
+		{"invalid range start", "proj-1..PROJ-5", nil, true},
+		{"incomplete range", "PROJ-1..", nil, true},
+		{"chained range", "PROJ-1..PROJ-3..PROJ-5", nil, true},
 	}
 
 	for _, tt := range tests {
@@ -223,6 +245,20 @@ func TestSyncCommand_Flags(t *testing.T) {
 	if rateLimitFlag.Shorthand != "" {
 		t.Errorf("Expected rate-limit flag to have no shorthand, got '%s'", rateLimitFlag.Shorthand)
 	}
+
+	if excludeFieldsFlag := cmd.Flags().Lookup("exclude-fields"); excludeFieldsFlag == nil {
+		t.Error("Expected --exclude-fields flag to exist")
+	}
+	if transformFlag := cmd.Flags().Lookup("transform"); transformFlag == nil || transformFlag.DefValue != "" {
+		t.Error("Expected opt-in --transform flag to exist")
+	}
+
+	if generateIndexFlag := cmd.Flags().Lookup("generate-index"); generateIndexFlag == nil || generateIndexFlag.DefValue != "false" {
+		t.Error("Expected opt-in --generate-index flag to exist")
+	}
+	if indexFormatFlag := cmd.Flags().Lookup("index-format"); indexFormatFlag == nil || indexFormatFlag.DefValue != "yaml" {
+		t.Error("Expected --index-format flag to default to yaml")
+	}
 }
 
 func TestSyncCommand_MissingFlags(t *testing.T) {
@@ -279,7 +315,7 @@ func TestSyncCommand_ValidationErrors(t *testing.T) {
 			issues:   "",
 			jql:      "",
 			repo:     "/tmp",
-			errorMsg: "must specify either --issues or --jql flag",
+			errorMsg: "must specify either --issues, --jql, or --epic-key flag",
 		},
 		{
 			name:     "both issues and jql flags provided",
@@ -336,6 +372,359 @@ func TestSyncCommand_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestSyncCommand_EpicFlagValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		errorMsg string
+	}{
+		{
+			name:     "epic key with jql",
+			flags:    map[string]string{"epic-key": "PROJ-1", "jql": "project = PROJ"},
+			errorMsg: "cannot combine --epic-key with --issues or --jql",
+		},
+		{
+			name:     "within without epic key",
+			flags:    map[string]string{"jql": "project = PROJ", "within": "component = Payments"},
+			errorMsg: "--within requires --epic-key",
+		},
+		{
+			name:     "within with ordering",
+			flags:    map[string]string{"epic-key": "PROJ-1", "within": "component = Payments ORDER BY key"},
+			errorMsg: "invalid --epic-key/--within value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:  "sync",
+				RunE: runSync,
+			}
+			cmd.Flags().StringP("issues", "i", "", "")
+			cmd.Flags().StringP("jql", "j", "", "")
+			cmd.Flags().StringP("repo", "r", "", "")
+			cmd.Flags().String("epic-key", "", "")
+			cmd.Flags().String("within", "", "")
+
+			_ = cmd.Flags().Set("repo", t.TempDir())
+			for name, value := range tt.flags {
+				_ = cmd.Flags().Set(name, value)
+			}
+
+			err := cmd.Execute()
+			if err == nil || !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestSyncCommand_SampleFlagValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		errorMsg string
+	}{
+		{
+			name:     "sample without dry run",
+			flags:    map[string]string{"sample": "5"},
+			errorMsg: "--sample requires --dry-run",
+		},
+		{
+			name:     "sample too large",
+			flags:    map[string]string{"sample": "500", "dry-run": "true"},
+			errorMsg: "--sample must be between 1 and 50",
+		},
+		{
+			name:     "sample with report",
+			flags:    map[string]string{"sample": "5", "dry-run-report": "plan.json"},
+			errorMsg: "cannot combine --sample",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:  "sync",
+				RunE: runSync,
+			}
+			cmd.Flags().StringP("jql", "j", "", "")
+			cmd.Flags().StringP("repo", "r", "", "")
+			cmd.Flags().Bool("dry-run", false, "")
+			cmd.Flags().String("dry-run-report", "", "")
+			cmd.Flags().Int("sample", 0, "")
+
+			_ = cmd.Flags().Set("repo", t.TempDir())
+			_ = cmd.Flags().Set("jql", "project = PROJ")
+			for name, value := range tt.flags {
+				_ = cmd.Flags().Set(name, value)
+			}
+
+			err := cmd.Execute()
+			if err == nil || !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestSyncCommand_ExpandDepthValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		errorMsg string
+	}{
+		{
+			name:     "expand with jql",
+			flags:    map[string]string{"jql": "project = PROJ", "expand-depth": "1"},
+			errorMsg: "--expand-depth requires --issues",
+		},
+		{
+			name:     "negative depth",
+			flags:    map[string]string{"issues": "PROJ-1", "expand-depth": "-1"},
+			errorMsg: "--expand-depth cannot be negative",
+		},
+		{
+			name:     "negative limit",
+			flags:    map[string]string{"issues": "PROJ-1", "expand-depth": "1", "expand-max-issues": "-5"},
+			errorMsg: "--expand-max-issues must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:  "sync",
+				RunE: runSync,
+			}
+			cmd.Flags().StringP("issues", "i", "", "")
+			cmd.Flags().StringP("jql", "j", "", "")
+			cmd.Flags().StringP("repo", "r", "", "")
+			cmd.Flags().Int("expand-depth", 0, "")
+			cmd.Flags().Int("expand-max-issues", 0, "")
+
+			_ = cmd.Flags().Set("repo", t.TempDir())
+			for name, value := range tt.flags {
+				_ = cmd.Flags().Set(name, value)
+			}
+
+			err := cmd.Execute()
+			if err == nil || !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestSyncCommand_IncludeSubtasksValidation(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:  "sync",
+		RunE: runSync,
+	}
+	cmd.Flags().StringP("issues", "i", "", "")
+	cmd.Flags().StringP("jql", "j", "", "")
+	cmd.Flags().StringP("repo", "r", "", "")
+	cmd.Flags().Bool("include-subtasks", false, "")
+
+	_ = cmd.Flags().Set("repo", t.TempDir())
+	_ = cmd.Flags().Set("jql", "project = PROJ")
+	_ = cmd.Flags().Set("include-subtasks", "true")
+
+	err := cmd.Execute()
+	if err == nil || !contains(err.Error(), "--include-subtasks requires --issues") {
+		t.Errorf("Expected --include-subtasks to require --issues, got: %v", err)
+	}
+}
+
+func TestSyncCommand_PruneFlagValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		errorMsg string
+	}{
+		{
+			name:     "archive without prune",
+			flags:    map[string]string{"jql": "project = PROJ", "archive-pruned": "archive"},
+			errorMsg: "--archive-pruned requires --prune",
+		},
+		{
+			name:     "prune with issue list",
+			flags:    map[string]string{"issues": "PROJ-1", "prune": "true"},
+			errorMsg: "--prune requires --jql or --epic-key",
+		},
+		{
+			name:     "prune with git-aware window",
+			flags:    map[string]string{"jql": "project = PROJ", "prune": "true", "only-changed-since-commit": "true"},
+			errorMsg: "cannot combine --prune with --only-changed-since-commit",
+		},
+		{
+			name:     "deletion handling with git-aware window",
+			flags:    map[string]string{"jql": "project = PROJ", "handle-deletions": "true", "only-changed-since-commit": "true"},
+			errorMsg: "cannot combine --handle-deletions with --only-changed-since-commit",
+		},
+		{
+			name:     "archive outside repository",
+			flags:    map[string]string{"jql": "project = PROJ", "prune": "true", "archive-pruned": "../archive"},
+			errorMsg: "invalid --archive-pruned value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:  "sync",
+				RunE: runSync,
+			}
+			cmd.Flags().StringP("issues", "i", "", "")
+			cmd.Flags().StringP("jql", "j", "", "")
+			cmd.Flags().StringP("repo", "r", "", "")
+			cmd.Flags().Bool("prune", false, "")
+			cmd.Flags().String("archive-pruned", "", "")
+			cmd.Flags().Bool("only-changed-since-commit", false, "")
+			cmd.Flags().Bool("handle-deletions", false, "")
+
+			_ = cmd.Flags().Set("repo", t.TempDir())
+			for name, value := range tt.flags {
+				_ = cmd.Flags().Set(name, value)
+			}
+
+			err := cmd.Execute()
+			if err == nil || !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestSyncCommand_RangeFlagValidation(t *testing.T) {
+	tests := []struct {
+		flag     string
+		value    string
+		errorMsg string
+	}{
+		{flag: "link-concurrency", value: "21", errorMsg: "--link-concurrency must be between 0 and 20"},
+		{flag: "bulk-fetch-size", value: "-1", errorMsg: "--bulk-fetch-size must be between 0 and 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:  "sync",
+				RunE: runSync,
+			}
+			cmd.Flags().StringP("jql", "j", "", "")
+			cmd.Flags().StringP("repo", "r", "", "")
+			cmd.Flags().Int(tt.flag, 0, "")
+
+			_ = cmd.Flags().Set("repo", t.TempDir())
+			_ = cmd.Flags().Set("jql", "project = PROJ")
+			_ = cmd.Flags().Set(tt.flag, tt.value)
+
+			err := cmd.Execute()
+			if err == nil || !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestSyncCommand_StatusTransitionsOnlyValidation(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:  "sync",
+		RunE: runSync,
+	}
+	cmd.Flags().StringP("jql", "j", "", "")
+	cmd.Flags().StringP("repo", "r", "", "")
+	cmd.Flags().Bool("incremental", false, "")
+	cmd.Flags().Bool("status-transitions-only", false, "")
+
+	_ = cmd.Flags().Set("repo", t.TempDir())
+	_ = cmd.Flags().Set("jql", "project = PROJ")
+	_ = cmd.Flags().Set("status-transitions-only", "true")
+
+	err := cmd.Execute()
+	if err == nil || !contains(err.Error(), "--status-transitions-only requires --incremental") {
+		t.Errorf("Expected --incremental requirement error, got: %v", err)
+	}
+}
+
+func TestSyncCommand_MinimalValidation(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:  "sync",
+		RunE: runSync,
+	}
+	cmd.Flags().StringP("jql", "j", "", "")
+	cmd.Flags().StringP("repo", "r", "", "")
+	cmd.Flags().Bool("minimal", false, "")
+	cmd.Flags().Bool("minimal-relationships", false, "")
+
+	_ = cmd.Flags().Set("repo", t.TempDir())
+	_ = cmd.Flags().Set("jql", "project = PROJ")
+	_ = cmd.Flags().Set("minimal-relationships", "true")
+
+	err := cmd.Execute()
+	if err == nil || !contains(err.Error(), "--minimal-relationships requires --minimal") {
+		t.Errorf("Expected --minimal requirement error, got: %v", err)
+	}
+}
+
+func TestSyncCommand_QuietHoursValidation(t *testing.T) {
+	tests := []struct {
+		flags map[string]string
+		want  string
+	}{
+		{map[string]string{"quiet-hours": "9am-5pm"}, "invalid --quiet-hours value"},
+		{map[string]string{"quiet-hours": "09:00-17:00", "quiet-hours-mode": "stop"}, "unsupported quiet hours mode"},
+		{map[string]string{"quiet-hours-timezone": "Europe/Berlin"}, "require --quiet-hours"},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{
+			Use:  "sync",
+			RunE: runSync,
+		}
+		cmd.Flags().StringP("jql", "j", "", "")
+		cmd.Flags().StringP("repo", "r", "", "")
+		cmd.Flags().String("quiet-hours", "", "")
+		cmd.Flags().String("quiet-hours-timezone", "", "")
+		cmd.Flags().String("quiet-hours-mode", "", "")
+
+		_ = cmd.Flags().Set("repo", t.TempDir())
+		_ = cmd.Flags().Set("jql", "project = PROJ")
+		for name, value := range tt.flags {
+			_ = cmd.Flags().Set(name, value)
+		}
+
+		err := cmd.Execute()
+		if err == nil || !contains(err.Error(), tt.want) {
+			t.Errorf("Flags %v: expected error containing %q, got: %v", tt.flags, tt.want, err)
+		}
+	}
+}
+
+func TestApplyMinimalConfig(t *testing.T) {
+	cfg := &config.Config{IncludeCustomFields: true, IncludeWorklogs: true}
+	applyMinimalConfig(cfg, false, true)
+	if !cfg.IncludeCustomFields || !cfg.IncludeWorklogs {
+		t.Error("Expected a filter expression to keep the data it may test")
+	}
+	applyMinimalConfig(cfg, true, false)
+	if cfg.IncludeCustomFields || cfg.IncludeWorklogs {
+		t.Error("Expected minimal mode to stop fetching custom fields and worklogs")
+	}
+}
+
+func TestRequireChangelog(t *testing.T) {
+	if err := requireChangelog(&config.Config{IncludeChangelog: true}, "--status-transitions-only"); err != nil {
+		t.Errorf("requireChangelog() error = %v", err)
+	}
+
+	err := requireChangelog(&config.Config{}, "--status-transitions-only")
+	if err == nil || !contains(err.Error(), "JIRA_INCLUDE_CHANGELOG") {
+		t.Errorf("Expected changelog disabled error, got: %v", err)
+	}
+}
+
 func TestSyncCommand_RateLimitFlag(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -529,3 +918,512 @@ func containsAt(s, substr string, start int) bool {
 	}
 	return containsAt(s, substr, start+1)
 }
+
+func TestRestrictToChangedSinceCommit(t *testing.T) {
+	mockGit := git.NewMockRepository()
+	mockGit.SetRepositoryAsInitialized("/repo", true)
+
+	// Empty repository falls back to a full sync
+	issuesArg, jqlArg, err := restrictToChangedSinceCommit(mockGit, "/repo", "", "PROJ-1,PROJ-2", "")
+	if err != nil {
+		t.Fatalf("Expected no error for empty repository, got %v", err)
+	}
+	if issuesArg != "PROJ-1,PROJ-2" || jqlArg != "" {
+		t.Errorf("Expected arguments unchanged for empty repository, got %q / %q", issuesArg, jqlArg)
+	}
+
+	// Half a minute short of 90 minutes ago, so the bound rounds up to -90m
+	commitTime := time.Now().Add(-90*time.Minute + 30*time.Second)
+	mockGit.CommitTimes["/repo"] = commitTime
+	mockGit.CommitTimes["/repo@release"] = commitTime.Add(-24 * time.Hour)
+
+	issuesArg, jqlArg, err = restrictToChangedSinceCommit(mockGit, "/repo", "", "PROJ-1,PROJ-2", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if issuesArg != "" {
+		t.Errorf("Expected issue list converted to JQL, got issues %q", issuesArg)
+	}
+	if jqlArg != `(key in (PROJ-1, PROJ-2)) AND updated >= -90m` {
+		t.Errorf("Unexpected JQL: %s", jqlArg)
+	}
+
+	_, jqlArg, err = restrictToChangedSinceCommit(mockGit, "/repo", "release", "", "project = PROJ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(jqlArg, `updated >= -1530m`) {
+		t.Errorf("Expected window measured from --base-ref, got %s", jqlArg)
+	}
+
+	if _, _, err = restrictToChangedSinceCommit(mockGit, "/repo", "missing", "", "project = PROJ"); err == nil {
+		t.Error("Expected error for unresolvable --base-ref")
+	}
+}
+
+func TestPrepareWorkingTree_DryRunNeverModifies(t *testing.T) {
+	mockGit := git.NewMockRepository()
+	mockGit.SetRepositoryAsInitialized("/repo", false)
+	mockGit.RepositoryStatuses["/repo"].ModifiedFiles = []string{"README.md"}
+
+	if _, err := prepareWorkingTree(mockGit, "/repo", git.DirtyPolicyFail, false); err == nil {
+		t.Error("Expected dirty repository to fail with the default policy")
+	}
+
+	prepared, err := prepareWorkingTree(mockGit, "/repo", git.DirtyPolicyCommit, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(mockGit.LocalCommits["/repo"]) != 0 || prepared.Stash != nil {
+		t.Error("Expected dry run to leave local changes untouched")
+	}
+
+	prepared, err = prepareWorkingTree(mockGit, "/repo", git.DirtyPolicyStash, false)
+	if err != nil || prepared.Stash == nil {
+		t.Fatalf("Expected changes to be stashed, got %+v, %v", prepared, err)
+	}
+	restoreWorkingTree(prepared)
+	if mockGit.RepositoryStatuses["/repo"].IsClean {
+		t.Error("Expected stashed changes to be restored")
+	}
+}
+
+func TestResolveJQLTemplate(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "sync"}
+		cmd.Flags().StringArray("jql-var", nil, "JQL template variable")
+		return cmd
+	}
+
+	// Plain JQL passes through untouched
+	resolved, err := resolveJQLTemplate(newCmd(), "project = PROJ")
+	if err != nil || resolved != "project = PROJ" {
+		t.Errorf("Expected plain JQL unchanged, got %q, %v", resolved, err)
+	}
+
+	// Flags override environment values
+	t.Setenv("JQL_VAR_Project", "ENVPROJ")
+	t.Setenv("JQL_VAR_Since", "-7d")
+	cmd := newCmd()
+	_ = cmd.Flags().Set("jql-var", "Project=FLAGPROJ")
+
+	resolved, err = resolveJQLTemplate(cmd, "project = {{.Project}} AND updated >= {{.Since}}")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolved != "project = FLAGPROJ AND updated >= -7d" {
+		t.Errorf("Unexpected resolved JQL: %s", resolved)
+	}
+
+	// Unresolved variables fail clearly
+	_, err = resolveJQLTemplate(newCmd(), "project = {{.Project}} AND fixVersion = {{.Release}}")
+	if err == nil || !strings.Contains(err.Error(), "Release") {
+		t.Errorf("Expected error naming the unresolved variable, got %v", err)
+	}
+}
+
+func TestExpandJQLAliases(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "sync"}
+		cmd.Flags().Bool("transform-jql", true, "Expand JQL aliases")
+		return cmd
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, ".jira-sync.yaml"), []byte("jql_aliases:\n  team: \"component = Backend\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write alias config: %v", err)
+	}
+
+	expanded, err := expandJQLAliases(newCmd(), "project = PROJ AND @mine AND @team")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expanded != "project = PROJ AND assignee = currentUser() AND component = Backend" {
+		t.Errorf("Unexpected expanded JQL: %s", expanded)
+	}
+
+	// Disabled expansion sends the query as written
+	cmd := newCmd()
+	_ = cmd.Flags().Set("transform-jql", "false")
+	expanded, err = expandJQLAliases(cmd, "@unknown")
+	if err != nil || expanded != "@unknown" {
+		t.Errorf("Expected query unchanged with --transform-jql=false, got %q, %v", expanded, err)
+	}
+
+	if _, err := expandJQLAliases(newCmd(), "@unknown"); err == nil || !strings.Contains(err.Error(), "@unknown") {
+		t.Errorf("Expected error naming the unknown alias, got %v", err)
+	}
+}
+
+func TestOrderSyncJQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		orderBy  string
+		explicit bool
+		expected string
+	}{
+		{"default ordering added", "project = PROJ", "key", false, "project = PROJ ORDER BY key ASC"},
+		{"query ordering kept by default", "project = PROJ ORDER BY updated DESC", "key", false, "project = PROJ ORDER BY updated DESC"},
+		{"explicit ordering replaces query ordering", "project = PROJ ORDER BY updated DESC", "priority DESC", true, "project = PROJ ORDER BY priority DESC, key ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := orderSyncJQL(tt.query, tt.orderBy, tt.explicit)
+			if err != nil {
+				t.Fatalf("orderSyncJQL() unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("orderSyncJQL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := orderSyncJQL("project = PROJ", "priority UP", true); err == nil {
+		t.Error("Expected error for invalid ordering")
+	}
+}
+
+func TestStartProfiling(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "sync"}
+		cmd.Flags().String("cpuprofile", "", "")
+		cmd.Flags().String("memprofile", "", "")
+		cmd.Flags().String("trace", "", "")
+		return cmd
+	}
+
+	// Disabled profiling creates nothing
+	stop, err := startProfiling(newCmd())
+	if err != nil {
+		t.Fatalf("startProfiling() error = %v", err)
+	}
+	stop()
+
+	dir := t.TempDir()
+	cmd := newCmd()
+	paths := map[string]string{
+		"cpuprofile": filepath.Join(dir, "cpu.pprof"),
+		"memprofile": filepath.Join(dir, "mem.pprof"),
+		"trace":      filepath.Join(dir, "sync.trace"),
+	}
+	for name, path := range paths {
+		_ = cmd.Flags().Set(name, path)
+	}
+
+	stop, err = startProfiling(cmd)
+	if err != nil {
+		t.Fatalf("startProfiling() error = %v", err)
+	}
+	stop()
+	stop() // safe to call twice
+
+	for name, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Errorf("Expected --%s output at %s: %v", name, path, err)
+			continue
+		}
+		if info.Size() == 0 {
+			t.Errorf("Expected --%s output to be non-empty", name)
+		}
+	}
+
+	// An unwritable path fails before anything starts
+	cmd = newCmd()
+	_ = cmd.Flags().Set("cpuprofile", filepath.Join(dir, "missing", "cpu.pprof"))
+	if _, err := startProfiling(cmd); err == nil {
+		t.Error("Expected error for unwritable CPU profile path")
+	}
+}
+
+func TestCheckProfileFlags(t *testing.T) {
+	if err := checkProfileFlags(&cobra.Command{}); err != nil {
+		t.Errorf("Expected no error without flags, got %v", err)
+	}
+
+	for _, name := range profileUnsupportedFlags {
+		t.Run(name, func(t *testing.T) {
+			if syncCmd.Flags().Lookup(name) == nil {
+				t.Fatalf("--%s is not a sync flag", name)
+			}
+			cmd := &cobra.Command{}
+			cmd.Flags().String(name, "", "")
+			if err := cmd.Flags().Set(name, "value"); err != nil {
+				t.Fatal(err)
+			}
+
+			err := checkProfileFlags(cmd)
+			if err == nil || !strings.Contains(err.Error(), "--"+name) {
+				t.Errorf("Expected --%s to be refused with --profile, got %v", name, err)
+			}
+		})
+	}
+}
+
+func TestParseSyncBudget(t *testing.T) {
+	budget, err := parseSyncBudget(500, "30m")
+	if err != nil {
+		t.Fatalf("parseSyncBudget() error = %v", err)
+	}
+	if budget.MaxAPICalls != 500 || budget.MaxDuration != 30*time.Minute {
+		t.Errorf("Unexpected budget %+v", budget)
+	}
+
+	if budget, err := parseSyncBudget(0, ""); err != nil || budget.MaxAPICalls != 0 || budget.MaxDuration != 0 {
+		t.Errorf("Expected an unlimited budget, got %+v (err %v)", budget, err)
+	}
+
+	for _, tc := range []struct {
+		calls    int
+		duration string
+	}{{-1, ""}, {0, "soon"}, {0, "-5m"}} {
+		if _, err := parseSyncBudget(tc.calls, tc.duration); err == nil {
+			t.Errorf("Expected error for calls=%d duration=%q", tc.calls, tc.duration)
+		}
+	}
+}
+
+func TestProfileSyncBudget(t *testing.T) {
+	budget, err := profileSyncBudget(profile.ProfileOptions{MaxAPICalls: 500, MaxDuration: "30m"})
+	if err != nil {
+		t.Fatalf("profileSyncBudget() error = %v", err)
+	}
+	if budget.MaxAPICalls != 500 || budget.MaxDuration != 30*time.Minute {
+		t.Errorf("Unexpected budget %+v", budget)
+	}
+
+	for _, options := range []profile.ProfileOptions{{MaxAPICalls: -1}, {MaxDuration: "soon"}, {MaxDuration: "-5m"}} {
+		if _, err := profileSyncBudget(options); err == nil {
+			t.Errorf("Expected error for max_api_calls=%d max_duration=%q", options.MaxAPICalls, options.MaxDuration)
+		}
+	}
+}
+
+func TestVerifyProjectPermissions(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.InaccessibleProjects = map[string]string{"SECRET": "missing Browse Projects permission"}
+
+	if err := verifyProjectPermissions(mockClient, sync.PermissionCheckOff, "SECRET-1", ""); err != nil {
+		t.Errorf("Expected no check when off, got %v", err)
+	}
+	if mockClient.CheckProjectAccessCallCount != 0 {
+		t.Errorf("Expected no permission lookups when off, got %d", mockClient.CheckProjectAccessCallCount)
+	}
+
+	err := verifyProjectPermissions(mockClient, sync.PermissionCheckFail, "PROJ-1,SECRET-2..SECRET-4", "")
+	if err == nil || !strings.Contains(err.Error(), "SECRET") {
+		t.Errorf("Expected fail mode to reject inaccessible project, got %v", err)
+	}
+	if err := verifyProjectPermissions(mockClient, sync.PermissionCheckWarn, "", "project in (PROJ, SECRET)"); err != nil {
+		t.Errorf("Expected warn mode to continue, got %v", err)
+	}
+	if err := verifyProjectPermissions(mockClient, sync.PermissionCheckFail, "", "assignee = currentUser()"); err != nil {
+		t.Errorf("Expected unresolved scope to be skipped, got %v", err)
+	}
+}
+
+func TestSyncCommand_FormatFlagValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		errorMsg string
+	}{
+		{
+			name:     "unknown format",
+			flags:    map[string]string{"format": "csv"},
+			errorMsg: "invalid --format value",
+		},
+		{
+			name:     "ndjson without output file",
+			flags:    map[string]string{"format": "ndjson"},
+			errorMsg: "--format=ndjson requires --output-file",
+		},
+		{
+			name:     "output file without ndjson",
+			flags:    map[string]string{"output-file": "issues.ndjson"},
+			errorMsg: "--output-file requires --format=ndjson",
+		},
+		{
+			name:     "ndjson with repository flags",
+			flags:    map[string]string{"format": "ndjson", "output-file": "issues.ndjson", "repo": "./repo", "incremental": "true"},
+			errorMsg: "cannot combine --format=ndjson with --repo, --incremental",
+		},
+		{
+			name:     "markdown with output file",
+			flags:    map[string]string{"format": "markdown", "output-file": "issues.ndjson"},
+			errorMsg: "--output-file requires --format=ndjson",
+		},
+		{
+			name:     "frontmatter without markdown",
+			flags:    map[string]string{"repo": "./repo", "frontmatter-fields": "default"},
+			errorMsg: "--frontmatter-fields requires --format=markdown",
+		},
+		{
+			name:     "unknown frontmatter field",
+			flags:    map[string]string{"format": "markdown", "repo": "./repo", "frontmatter-fields": "key,sprint"},
+			errorMsg: "invalid --frontmatter-fields value",
+		},
+		{
+			name:     "markdown with YAML output flags",
+			flags:    map[string]string{"format": "markdown", "repo": "./repo", "exclude-fields": "comments", "checksums": "true"},
+			errorMsg: "cannot combine --format=markdown with --exclude-fields, --checksums",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:  "sync",
+				RunE: runSync,
+			}
+			cmd.Flags().StringP("jql", "j", "", "")
+			cmd.Flags().StringP("repo", "r", "", "")
+			cmd.Flags().Bool("incremental", false, "")
+			cmd.Flags().StringArray("exclude-fields", nil, "")
+			cmd.Flags().Bool("checksums", false, "")
+			cmd.Flags().String("frontmatter-fields", "", "")
+			cmd.Flags().String("format", SyncFormatYAML, "")
+			cmd.Flags().String("output-file", "", "")
+
+			_ = cmd.Flags().Set("jql", "project = PROJ")
+			for name, value := range tt.flags {
+				_ = cmd.Flags().Set(name, value)
+			}
+
+			err := cmd.Execute()
+			if err == nil || !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestNewMarkdownIssueWriter_IssueLinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *config.Config
+		frontmatter []string
+		expected    []string
+	}{
+		{
+			name:     "base URL",
+			cfg:      &config.Config{JIRABaseURL: "https://jira-api.internal"},
+			expected: []string{"[View in JIRA](https://jira-api.internal/browse/PROJ-1)"},
+		},
+		{
+			name:     "public URL",
+			cfg:      &config.Config{JIRABaseURL: "https://jira-api.internal", JIRAPublicURL: "https://jira.company.com/jira"},
+			expected: []string{"[View in JIRA](https://jira.company.com/jira/browse/PROJ-1)"},
+		},
+		{
+			name:        "public URL in frontmatter",
+			cfg:         &config.Config{JIRABaseURL: "https://jira-api.internal", JIRAPublicURL: "https://jira.company.com/jira"},
+			frontmatter: []string{"key", "url"},
+			expected:    []string{"---\nkey: PROJ-1\nurl: https://jira.company.com/jira/browse/PROJ-1\n---\n", "[View in JIRA](https://jira.company.com/jira/browse/PROJ-1)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, ok := newMarkdownIssueWriter(tt.cfg, tt.frontmatter).(schema.IssueRenderer)
+			if !ok {
+				t.Fatal("Expected the Markdown writer to render issues")
+			}
+			content, err := renderer.RenderIssue(&client.Issue{Key: "PROJ-1", Summary: "Login fails"})
+			if err != nil {
+				t.Fatalf("RenderIssue() error = %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(string(content), expected) {
+					t.Errorf("Expected %q in:\n%s", expected, content)
+				}
+			}
+		})
+	}
+}
+
+func TestEnforceFileLimit(t *testing.T) {
+	mockClient := client.NewMockClient()
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-3"} {
+		mockClient.AddIssue(&client.Issue{Key: key})
+	}
+	mockClient.AddJQLResult("project = PROJ", []string{"PROJ-1", "PROJ-2", "PROJ-3"})
+
+	if err := enforceFileLimit(mockClient, "", "project = PROJ", 3, false); err != nil {
+		t.Errorf("Expected a scope at the limit to pass, got %v", err)
+	}
+	if err := enforceFileLimit(mockClient, "", "project = PROJ", 2, false); err == nil || !strings.Contains(err.Error(), "3 issue files") {
+		t.Errorf("Expected the query scope to exceed the limit, got %v", err)
+	}
+	if err := enforceFileLimit(mockClient, "", "project = PROJ", 2, true); err != nil {
+		t.Errorf("Expected a dry run to only warn, got %v", err)
+	}
+	if err := enforceFileLimit(mockClient, "", "project = PROJ", 0, false); err != nil {
+		t.Errorf("Expected no limit with --no-file-limit, got %v", err)
+	}
+	if err := enforceFileLimit(mockClient, "PROJ-1,PROJ-2..PROJ-4", "", 3, false); err == nil {
+		t.Error("Expected the issue list scope to exceed the limit")
+	}
+	// An issue list that cannot be parsed must not skip the limit check
+	if err := enforceFileLimit(mockClient, " , ", "", 3, false); err == nil || !strings.Contains(err.Error(), "failed to parse issues") {
+		t.Errorf("Expected an invalid issue list to fail, got %v", err)
+	}
+}
+
+func TestValidateProfilePrune(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile profile.Profile
+		wantErr string
+	}{
+		{"no prune", profile.Profile{IssueKeys: []string{"PROJ-1"}}, ""},
+		{"jql", profile.Profile{JQL: "project = PROJ", Options: profile.ProfileOptions{Prune: true, ArchivePruned: "archive"}}, ""},
+		{"epic", profile.Profile{EpicKey: "PROJ-1", Options: profile.ProfileOptions{Prune: true}}, ""},
+		{"issue list", profile.Profile{IssueKeys: []string{"PROJ-1"}, Options: profile.ProfileOptions{Prune: true}}, "requires a profile with jql or epic_key"},
+		{"archive without prune", profile.Profile{JQL: "project = PROJ", Options: profile.ProfileOptions{ArchivePruned: "archive"}}, "requires prune"},
+		{"archive outside repository", profile.Profile{JQL: "project = PROJ", Options: profile.ProfileOptions{Prune: true, ArchivePruned: "../archive"}}, "invalid archive_pruned option"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProfilePrune(&tt.profile)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateProfilePrune() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateProfilePrune() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLiveIssueKeys(t *testing.T) {
+	mockClient := client.NewMockClient()
+	var want []string
+	for i := 1; i <= client.DefaultSearchPageSize+5; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		mockClient.AddIssue(&client.Issue{Key: key})
+		want = append(want, key)
+	}
+	mockClient.AddJQLResult("project = PROJ", want)
+
+	keys, err := liveIssueKeys(context.Background(), mockClient, "project = PROJ")
+	if err != nil {
+		t.Fatalf("liveIssueKeys() error = %v", err)
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected every matching key across pages, got %d keys", len(keys))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := liveIssueKeys(ctx, mockClient, "project = PROJ"); ExitCode(err) != ExitInterrupted {
+		t.Errorf("Expected an interrupted search to exit with %d, got %v", ExitInterrupted, err)
+	}
+}
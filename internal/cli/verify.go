@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/chambrid/jira-cdc-git/internal/sync"
+	"github.com/chambrid/jira-cdc-git/pkg/checksum"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+	"github.com/chambrid/jira-cdc-git/pkg/verify"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check a synced repository for internal consistency",
+	Long: `Verify that a synced repository is internally consistent.
+
+Checks performed:
+  • Every relationship link resolves to an existing issue file
+  • Every issue file is valid YAML matching the current issue schema, as written with the
+    output options recorded in the sync manifest (.jira-sync-manifest.yaml), if present;
+    files written through a transform are only checked to be valid YAML
+  • The state file (if present) matches on-disk content hashes
+  • Checksum manifests (if present, see sync --checksums) match their issue files, and with
+    --public-key, each manifest carries a valid signature
+
+Repairs applied with --fix, which requires the sync manifest:
+  • Broken relationship links are pruned
+  • Issue files with schema drift are re-stamped in the current format
+  • State entries for missing files are removed, and checksums are refreshed for re-stamped files
+  • Checksum manifests listing re-stamped files are regenerated, and re-signed with
+    --signing-key (without it, their now stale signatures are removed)
+
+Files modified outside of sync and files that are not valid YAML are reported but never
+changed, and neither are checksum manifest mismatches. The command exits non-zero when any problem remains unrepaired, which makes it
+suitable as a scheduled integrity audit for important mirrors.`,
+	Example: `  # Audit a repository
+  jira-sync verify --repo=./my-repo
+
+  # Audit and repair what can be fixed safely
+  jira-sync verify --repo=./my-repo --fix
+
+  # Also check the manifest signatures of a signed mirror
+  jira-sync verify --repo=./my-repo --public-key=./sync-signing.pub
+
+  # Repair a signed mirror, re-signing the manifests of re-stamped files
+  jira-sync verify --repo=./my-repo --fix --signing-key=./sync-signing.pem`,
+	RunE: runVerify,
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	repo, _ := cmd.Flags().GetString("repo")
+	fix, _ := cmd.Flags().GetBool("fix")
+	publicKeyPath, _ := cmd.Flags().GetString("public-key")
+	signingKeyPath, _ := cmd.Flags().GetString("signing-key")
+
+	if repo == "" {
+		return fmt.Errorf("--repo flag is required")
+	}
+
+	options := verify.Options{Fix: fix}
+	if publicKeyPath != "" {
+		publicKey, err := checksum.LoadPublicKey(publicKeyPath)
+		if err != nil {
+			return fmt.Errorf("invalid --public-key: %w", err)
+		}
+		options.PublicKey = publicKey
+	}
+	if signingKeyPath != "" {
+		signingKey, err := checksum.LoadPrivateKey(signingKeyPath)
+		if err != nil {
+			return fmt.Errorf("invalid --signing-key: %w", err)
+		}
+		options.SigningKey = signingKey
+	}
+	if err := applyManifestOptions(repo, &options); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Verifying repository %s...\n", repo)
+
+	verifier := verify.NewRepositoryVerifier(links.NewSymbolicLinkManager(), state.NewFileStateManager(state.FormatYAML))
+	result, err := verifier.Verify(repo, options)
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	displayVerifyResults(result, fix)
+
+	unrepaired := result.Unrepaired()
+	if len(unrepaired) > 0 {
+		return fmt.Errorf("verification found %d unrepaired problem(s)", len(unrepaired))
+	}
+
+	return nil
+}
+
+// applyManifestOptions sets up options to check issue files with the output options recorded in
+// the repository's sync manifest, so files are checked (and re-stamped) the way the sync wrote
+// them. Without a manifest, files are checked against the default output but never re-stamped,
+// since the options they were written with are unknown.
+func applyManifestOptions(repo string, verifyOptions *verify.Options) error {
+	manifest, err := sync.ReadManifest(repo)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		return checkUnrecordedOutput(repo, verifyOptions.Fix)
+	}
+	options := manifest.Options
+	if format, err := schema.ParseFileFormat(options.Format); err != nil || format != schema.FileFormatYAML {
+		return fmt.Errorf("verify checks YAML issue files, but %s records format %q", sync.ManifestFileName, options.Format)
+	}
+	fmt.Printf("📄 Checking issue files against the output options in %s\n", sync.ManifestFileName)
+
+	invalid := func(option string, err error) error {
+		return fmt.Errorf("invalid %s in %s: %w", option, sync.ManifestFileName, err)
+	}
+	fieldFilter, err := syncFieldFilter(options.Minimal, options.MinimalRelationships, options.ExcludeFields)
+	if err != nil {
+		return invalid("exclude_fields", err)
+	}
+	adfRender, err := schema.ParseADFRenderMode(options.ADFRender)
+	if err != nil {
+		return invalid("adf_render", err)
+	}
+	var flatten schema.FlattenStrategy
+	if !options.NoFlatten {
+		if flatten, err = schema.ParseFlattenStrategy(options.FlattenFields); err != nil {
+			return invalid("flatten_fields", err)
+		}
+	}
+	timestamps, err := schema.ParseTimestampMode(options.NormalizeTimestamps)
+	if err != nil {
+		return invalid("normalize_timestamps", err)
+	}
+	fieldOrder, err := schema.ParseFieldOrder(options.FieldOrder)
+	if err != nil {
+		return invalid("field_order", err)
+	}
+	if options.Transform != "" {
+		fmt.Printf("🔀 Issue files were transformed by %s: checking only that they are valid YAML\n", options.Transform)
+		verifyOptions.Transformed = true
+	}
+
+	// Custom field values are already flattened in the files, so no field schemas are needed
+	verifyOptions.Writer = &schema.YAMLFileWriter{
+		Fields:     fieldFilter,
+		Merge:      options.MergeUpdate,
+		ADFRender:  adfRender,
+		Flatten:    flatten,
+		Timestamps: timestamps,
+		FieldOrder: fieldOrder,
+	}
+	return nil
+}
+
+// checkUnrecordedOutput refuses to verify a repository without a sync manifest when the default
+// output cannot be assumed: --fix could re-stamp files written with other options, and a
+// repository of Markdown issue files has no YAML files to check.
+func checkUnrecordedOutput(repo string, fix bool) error {
+	yamlFiles, err := filepath.Glob(filepath.Join(repo, "projects", "*", "issues", "*.yaml"))
+	if err != nil {
+		return err
+	}
+	markdownFiles, err := filepath.Glob(filepath.Join(repo, "projects", "*", "issues", "*.md"))
+	if err != nil {
+		return err
+	}
+	if len(yamlFiles) == 0 && len(markdownFiles) > 0 {
+		return fmt.Errorf("verify checks YAML issue files, but %s has only Markdown issue files", repo)
+	}
+	if fix {
+		return fmt.Errorf("--fix requires %s to know the output options issue files were written with; run a sync without --no-manifest first", sync.ManifestFileName)
+	}
+	return nil
+}
+
+// displayVerifyResults shows the problems found and repaired during verification
+func displayVerifyResults(result *verify.Result, fix bool) {
+	fmt.Printf("📊 Checked:\n")
+	fmt.Printf("  • Issue files: %d\n", result.IssueFilesChecked)
+	fmt.Printf("  • Relationship links: %d\n", result.LinksChecked)
+	if result.StatePresent {
+		fmt.Printf("  • State entries: %d\n", result.StateEntriesChecked)
+	} else {
+		fmt.Printf("  • State file: not present (skipped)\n")
+	}
+	if result.ManifestsChecked > 0 {
+		fmt.Printf("  • Checksum manifests: %d (%d signature(s))\n", result.ManifestsChecked, result.SignaturesChecked)
+	}
+
+	if len(result.Problems) == 0 {
+		fmt.Printf("\n✅ Repository is consistent\n")
+		return
+	}
+
+	if fixed := result.FixedCount(); fixed > 0 {
+		fmt.Printf("\n🔧 Repaired (%d):\n", fixed)
+		for _, problem := range result.Problems {
+			if problem.Fixed {
+				fmt.Printf("  • [%s] %s: %s\n", problem.Type, problem.Path, problem.Message)
+			}
+		}
+	}
+
+	if len(result.ManifestsRefreshed) > 0 {
+		fmt.Printf("\n🔏 Refreshed checksum manifests for re-stamped files (%d):\n", len(result.ManifestsRefreshed))
+		for _, path := range result.ManifestsRefreshed {
+			fmt.Printf("  • %s\n", path)
+		}
+	}
+
+	unrepaired := result.Unrepaired()
+	if len(unrepaired) == 0 {
+		fmt.Printf("\n✅ All problems repaired\n")
+		return
+	}
+
+	fmt.Printf("\n❌ Problems (%d):\n", len(unrepaired))
+	fixable := 0
+	for _, problem := range unrepaired {
+		fmt.Printf("  • [%s] %s: %s\n", problem.Type, problem.Path, problem.Message)
+		if problem.Fixable {
+			fixable++
+		}
+	}
+
+	if !fix && fixable > 0 {
+		fmt.Printf("\n💡 %d problem(s) can be repaired with --fix\n", fixable)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringP("repo", "r", "", "Synced Git repository path to verify (required)")
+	verifyCmd.Flags().Bool("fix", false, "Repair problems that can be fixed safely (prune broken links, re-stamp schema)")
+	verifyCmd.Flags().String("public-key", "", "PEM Ed25519 public key for checking checksum manifest signatures (see sync --sign)")
+	verifyCmd.Flags().String("signing-key", "", "PEM Ed25519 private key for re-signing checksum manifests regenerated by --fix")
+}
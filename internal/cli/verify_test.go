@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/verify"
+)
+
+func TestApplyManifestOptions_WithoutManifest(t *testing.T) {
+	writeIssueFile := func(t *testing.T, repo, name string) {
+		t.Helper()
+		dir := filepath.Join(repo, "projects", "PROJ", "issues")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("key: PROJ-1\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	yamlRepo := t.TempDir()
+	writeIssueFile(t, yamlRepo, "PROJ-1.yaml")
+	if err := applyManifestOptions(yamlRepo, &verify.Options{}); err != nil {
+		t.Errorf("Expected YAML files to be checked against the default output, got %v", err)
+	}
+	options := verify.Options{Fix: true}
+	if err := applyManifestOptions(yamlRepo, &options); err == nil || !strings.Contains(err.Error(), "--fix requires") {
+		t.Errorf("Expected --fix to be refused without a manifest, got %v", err)
+	}
+
+	markdownRepo := t.TempDir()
+	writeIssueFile(t, markdownRepo, "PROJ-1.md")
+	if err := applyManifestOptions(markdownRepo, &verify.Options{}); err == nil || !strings.Contains(err.Error(), "only Markdown issue files") {
+		t.Errorf("Expected a Markdown repository to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/ratelimit"
+	"github.com/spf13/cobra"
+)
+
+// Watch mode defaults
+const (
+	DefaultWatchInterval    = 5 * time.Minute
+	DefaultWatchMaxInterval = time.Hour
+	watchFailureMultiplier  = 2
+)
+
+// watchSchedule decides how long watch mode waits between sync cycles
+// Cycles that find no changes stretch the interval by the backoff multiplier, up to the
+// maximum, and a cycle with changes resets it to the base. Failed cycles back off on top of
+// the current interval without changing it, so a recovered watch resumes where it was.
+type watchSchedule struct {
+	base       time.Duration
+	max        time.Duration
+	multiplier float64
+
+	interval    time.Duration // interval after the last successful cycle
+	emptyCycles int
+	failures    int
+}
+
+// newWatchSchedule creates a schedule; a multiplier of 1 keeps the interval fixed
+func newWatchSchedule(base, max time.Duration, multiplier float64) (*watchSchedule, error) {
+	if base <= 0 {
+		return nil, fmt.Errorf("--watch-interval must be positive, got %v", base)
+	}
+	if max < base {
+		return nil, fmt.Errorf("--watch-max-interval (%v) cannot be shorter than --watch-interval (%v)", max, base)
+	}
+	if multiplier < 1 {
+		return nil, fmt.Errorf("--watch-interval-backoff must be at least 1, got %g", multiplier)
+	}
+	return &watchSchedule{base: base, max: max, multiplier: multiplier, interval: base}, nil
+}
+
+// next records a cycle's outcome and returns the wait before the next cycle
+func (s *watchSchedule) next(changed, failed bool) time.Duration {
+	if failed {
+		s.failures++
+		wait := s.interval
+		for i := 0; i < s.failures && wait < s.max; i++ {
+			wait *= watchFailureMultiplier
+		}
+		return s.capped(wait)
+	}
+
+	s.failures = 0
+	if changed {
+		s.emptyCycles = 0
+		s.interval = s.base
+		return s.interval
+	}
+	s.emptyCycles++
+	s.interval = s.capped(time.Duration(float64(s.interval) * s.multiplier))
+	return s.interval
+}
+
+func (s *watchSchedule) capped(wait time.Duration) time.Duration {
+	if wait > s.max {
+		return s.max
+	}
+	return wait
+}
+
+// runWatch repeats incremental syncs until interrupted, adapting the interval to activity
+func runWatch(cmd *cobra.Command, args []string) error {
+	if profileName, _ := cmd.Flags().GetString("profile"); profileName != "" {
+		return fmt.Errorf("--watch cannot be combined with --profile")
+	}
+	incremental, _ := cmd.Flags().GetBool("incremental")
+	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !incremental || force || dryRun {
+		return fmt.Errorf("--watch requires --incremental (without --force or --dry-run)")
+	}
+
+	intervalArg, _ := cmd.Flags().GetString("watch-interval")
+	maxIntervalArg, _ := cmd.Flags().GetString("watch-max-interval")
+	multiplier, _ := cmd.Flags().GetFloat64("watch-interval-backoff")
+	interval, err := parseWatchDuration("--watch-interval", intervalArg, DefaultWatchInterval)
+	if err != nil {
+		return err
+	}
+	maxInterval, err := parseWatchDuration("--watch-max-interval", maxIntervalArg, DefaultWatchMaxInterval)
+	if err != nil {
+		return err
+	}
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	schedule, err := newWatchSchedule(interval, maxInterval, multiplier)
+	if err != nil {
+		return err
+	}
+	quietHoursArg, _ := cmd.Flags().GetString("quiet-hours")
+	quietHoursTimezone, _ := cmd.Flags().GetString("quiet-hours-timezone")
+	quietHoursMode, _ := cmd.Flags().GetString("quiet-hours-mode")
+	quietHours, err := ratelimit.ParseQuietHours(quietHoursArg, quietHoursTimezone, quietHoursMode)
+	if err != nil {
+		return fmt.Errorf("invalid --quiet-hours value: %w", err)
+	}
+
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	if multiplier > 1 {
+		fmt.Printf("👀 Watching for changes every %v (backing off x%g to %v when idle)\n", interval, multiplier, maxInterval)
+	} else {
+		fmt.Printf("👀 Watching for changes every %v\n", interval)
+	}
+	succeeded := false
+	for cycle := 1; ; cycle++ {
+		// Paused quiet hours skip whole cycles, so not even the change search reaches JIRA
+		if quietHours != nil && quietHours.Mode == ratelimit.QuietHoursPause && quietHours.Active(time.Now()) {
+			end := quietHours.End(time.Now())
+			fmt.Printf("🌙 Quiet hours %s: watch paused until %s\n", quietHours, end.Format("15:04 MST"))
+			select {
+			case <-ctx.Done():
+				fmt.Println("🛑 Watch stopped")
+				return nil
+			case <-time.After(time.Until(end)):
+			}
+			fmt.Println("☀️  Quiet hours over: watch resumed")
+		}
+
+		fmt.Printf("🔁 Watch cycle %d at %s\n", cycle, time.Now().Format("2006-01-02 15:04:05"))
+		result, err := syncOnce(cmd, args)
+		if ctx.Err() != nil {
+			fmt.Println("🛑 Watch stopped")
+			return err
+		}
+
+		// Issues that failed are retried by the next cycle. Other errors end the watch on the
+		// first cycle, where they point at the flags or configuration; once a cycle has
+		// succeeded they are treated as transient, such as JIRA being briefly unreachable.
+		failed := false
+		switch code := ExitCode(err); {
+		case err == nil, code == ExitPartialFailure:
+		case code == ExitTotalFailure || succeeded:
+			failed = true
+		default:
+			return err
+		}
+		if !failed {
+			succeeded = true
+		}
+
+		previous := schedule.interval
+		changed := result != nil && result.ProcessedIssues > 0
+		wait := schedule.next(changed, failed)
+		reportWatchWait(schedule, previous, wait, changed, failed, err)
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("🛑 Watch stopped")
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// reportWatchWait logs the wait before the next cycle, and why the interval changed
+func reportWatchWait(schedule *watchSchedule, previous, wait time.Duration, changed, failed bool, err error) {
+	switch {
+	case failed:
+		fmt.Printf("⚠️  Sync cycle failed (%d in a row): %v; retrying in %v\n", schedule.failures, err, wait)
+	case changed && previous != schedule.interval:
+		fmt.Printf("⚡ Changes detected; polling interval reset to %v\n", wait)
+	case !changed && previous != schedule.interval:
+		fmt.Printf("💤 No changes for %d cycles; polling interval increased to %v\n", schedule.emptyCycles, wait)
+	default:
+		fmt.Printf("⏳ Next sync in %v\n", wait)
+	}
+}
+
+// parseWatchDuration parses a watch interval flag, using the default when empty
+func parseWatchDuration(flag, value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+	return duration, nil
+}
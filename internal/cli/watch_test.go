@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestWatchSchedule_IdleBackoff(t *testing.T) {
+	schedule, err := newWatchSchedule(5*time.Minute, 30*time.Minute, 2)
+	if err != nil {
+		t.Fatalf("newWatchSchedule() error = %v", err)
+	}
+
+	var waits []time.Duration
+	for i := 0; i < 4; i++ {
+		waits = append(waits, schedule.next(false, false))
+	}
+	want := []time.Duration{10 * time.Minute, 20 * time.Minute, 30 * time.Minute, 30 * time.Minute}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Fatalf("idle waits = %v, want %v", waits, want)
+		}
+	}
+	if schedule.emptyCycles != 4 {
+		t.Errorf("emptyCycles = %d, want 4", schedule.emptyCycles)
+	}
+
+	if wait := schedule.next(true, false); wait != 5*time.Minute || schedule.emptyCycles != 0 {
+		t.Errorf("Expected a change to reset the interval, got %v", wait)
+	}
+}
+
+func TestWatchSchedule_FailureBackoff(t *testing.T) {
+	schedule, _ := newWatchSchedule(time.Minute, time.Hour, 2)
+	schedule.next(false, false) // idle interval is now 2m
+
+	// Failures back off from the current interval without advancing it
+	if wait := schedule.next(false, true); wait != 4*time.Minute {
+		t.Errorf("first failure wait = %v, want 4m", wait)
+	}
+	if wait := schedule.next(false, true); wait != 8*time.Minute {
+		t.Errorf("second failure wait = %v, want 8m", wait)
+	}
+	for i := 0; i < 10; i++ {
+		schedule.next(false, true)
+	}
+	if wait := schedule.next(false, true); wait != time.Hour {
+		t.Errorf("Expected failure backoff to be capped at 1h, got %v", wait)
+	}
+
+	// Recovery resumes the idle backoff where it was
+	if wait := schedule.next(false, false); wait != 4*time.Minute || schedule.failures != 0 {
+		t.Errorf("Expected the idle backoff to continue at 4m after recovery, got %v", wait)
+	}
+
+	fixed, _ := newWatchSchedule(time.Minute, time.Hour, 1)
+	if wait := fixed.next(false, false); wait != time.Minute {
+		t.Errorf("Expected a fixed interval without backoff, got %v", wait)
+	}
+}
+
+func TestNewWatchSchedule_Validation(t *testing.T) {
+	if _, err := newWatchSchedule(0, time.Hour, 2); err == nil {
+		t.Error("Expected an error for a zero interval")
+	}
+	if _, err := newWatchSchedule(time.Hour, time.Minute, 2); err == nil {
+		t.Error("Expected an error for a max interval below the base")
+	}
+	if _, err := newWatchSchedule(time.Minute, time.Hour, 0.5); err == nil {
+		t.Error("Expected an error for a multiplier below 1")
+	}
+}
+
+func TestRunWatch_RequiresIncremental(t *testing.T) {
+	cmd := &cobra.Command{Use: "sync", RunE: runSync}
+	cmd.Flags().Bool("watch", true, "")
+	cmd.Flags().Bool("incremental", false, "")
+	cmd.Flags().String("repo", "./repo", "")
+	cmd.Flags().String("jql", "project = PROJ", "")
+
+	err := runSync(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--watch requires --incremental") {
+		t.Errorf("Expected an --incremental error, got %v", err)
+	}
+}
@@ -28,6 +28,9 @@ type APIClient interface {
 	// GetJobStatus retrieves the status of a sync job
 	GetJobStatus(ctx context.Context, jobID string) (*JobStatusResponse, error)
 
+	// CancelJob requests cancellation of a running sync job
+	CancelJob(ctx context.Context, jobID string) error
+
 	// HealthCheck performs a health check against the API server
 	HealthCheck(ctx context.Context) error
 
@@ -106,27 +109,56 @@ func NewAPIClientWithAuth(baseURL string, timeout time.Duration, authType, authT
 
 // SingleSyncRequest represents a single issue sync request
 type SingleSyncRequest struct {
-	IssueKey   string `json:"issue_key"`
-	Repository string `json:"repository"`
-	Branch     string `json:"branch,omitempty"`
-	DryRun     bool   `json:"dry_run,omitempty"`
+	IssueKey           string            `json:"issue_key"`
+	Repository         string            `json:"repository"`
+	Branch             string            `json:"branch,omitempty"`
+	DryRun             bool              `json:"dry_run,omitempty"`
+	JobLabels          map[string]string `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string `json:"job_annotations,omitempty"`
+	GitCredentials     *GitCredentials   `json:"git_credentials,omitempty"`
+	CheckpointInterval int               `json:"checkpoint_interval,omitempty"`
 }
 
 // BatchSyncRequest represents a batch sync request
 type BatchSyncRequest struct {
-	IssueKeys   []string `json:"issue_keys"`
-	Repository  string   `json:"repository"`
-	Branch      string   `json:"branch,omitempty"`
-	Parallelism int      `json:"parallelism,omitempty"`
-	DryRun      bool     `json:"dry_run,omitempty"`
+	IssueKeys          []string          `json:"issue_keys"`
+	Repository         string            `json:"repository"`
+	Branch             string            `json:"branch,omitempty"`
+	Parallelism        int               `json:"parallelism,omitempty"`
+	DryRun             bool              `json:"dry_run,omitempty"`
+	JobLabels          map[string]string `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string `json:"job_annotations,omitempty"`
+	GitCredentials     *GitCredentials   `json:"git_credentials,omitempty"`
+	CheckpointInterval int               `json:"checkpoint_interval,omitempty"`
 }
 
 // JQLSyncRequest represents a JQL-based sync request
 type JQLSyncRequest struct {
-	JQLQuery   string `json:"jql_query"`
-	Repository string `json:"repository"`
-	Branch     string `json:"branch,omitempty"`
-	DryRun     bool   `json:"dry_run,omitempty"`
+	JQLQuery           string            `json:"jql_query"`
+	Repository         string            `json:"repository"`
+	Branch             string            `json:"branch,omitempty"`
+	DryRun             bool              `json:"dry_run,omitempty"`
+	JobLabels          map[string]string `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string `json:"job_annotations,omitempty"`
+	GitCredentials     *GitCredentials   `json:"git_credentials,omitempty"`
+	CheckpointInterval int               `json:"checkpoint_interval,omitempty"`
+}
+
+// GitCredentials authenticate the sync job's pushes to the destination repository
+// They are resolved from the JIRASync's gitSecretRef and redacted whenever they are formatted.
+type GitCredentials struct {
+	Username string `json:"username"`
+	Token    string `json:"token"`
+}
+
+// String describes the credentials without revealing them
+func (c GitCredentials) String() string {
+	return "GitCredentials{<redacted>}"
+}
+
+// GoString keeps %#v from printing the credentials
+func (c GitCredentials) GoString() string {
+	return c.String()
 }
 
 // SyncJobResponse represents the response from a sync operation trigger
@@ -145,6 +177,21 @@ type JobStatusResponse struct {
 	StartTime *time.Time        `json:"start_time,omitempty"`
 	EndTime   *time.Time        `json:"end_time,omitempty"`
 	Results   map[string]string `json:"results,omitempty"`
+
+	// Run statistics reported by the API server once a job has finished
+	TotalIssues     int        `json:"total_issues,omitempty"`
+	ProcessedIssues int        `json:"processed_issues,omitempty"`
+	SuccessfulSync  int        `json:"successful_sync,omitempty"`
+	FailedSync      int        `json:"failed_sync,omitempty"`
+	Duration        string     `json:"duration,omitempty"`
+	Errors          []JobError `json:"errors,omitempty"`
+}
+
+// JobError describes one failure reported for a job
+type JobError struct {
+	IssueKey string `json:"issue_key,omitempty"`
+	Step     string `json:"step"`
+	Message  string `json:"message"`
 }
 
 // APIError represents an API error response
@@ -217,6 +264,27 @@ func (c *Client) GetJobStatus(ctx context.Context, jobID string) (*JobStatusResp
 	return apiResponse.Data, nil
 }
 
+// CancelJob implements APIClient.CancelJob
+func (c *Client) CancelJob(ctx context.Context, jobID string) error {
+	endpoint := fmt.Sprintf("/api/v1/jobs/%s/cancel", url.PathEscape(jobID))
+
+	resp, err := c.makeHTTPRequest(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.log.Error(err, "Failed to close response body")
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.handleAPIError(resp)
+	}
+
+	return nil
+}
+
 // HealthCheck implements APIClient.HealthCheck
 func (c *Client) HealthCheck(ctx context.Context) error {
 	endpoint := "/api/v1/health"
@@ -460,6 +528,11 @@ func (c *Client) handleAPIError(resp *http.Response) error {
 
 // ConvertJIRASyncToAPIRequest converts a JIRASync CRD to appropriate API request
 func ConvertJIRASyncToAPIRequest(jiraSync *operatortypes.JIRASync) (interface{}, string, error) {
+	checkpointInterval := 0
+	if jiraSync.Spec.Checkpoint != nil {
+		checkpointInterval = jiraSync.Spec.Checkpoint.Interval
+	}
+
 	switch jiraSync.Spec.SyncType {
 	case "single":
 		if len(jiraSync.Spec.Target.IssueKeys) == 0 {
@@ -470,6 +543,11 @@ func ConvertJIRASyncToAPIRequest(jiraSync *operatortypes.JIRASync) (interface{},
 			Repository: jiraSync.Spec.Destination.Repository,
 			Branch:     jiraSync.Spec.Destination.Branch,
 			DryRun:     false, // DryRun not supported in CRD yet
+
+			JobLabels:      jiraSync.Spec.JobLabels,
+			JobAnnotations: jiraSync.Spec.JobAnnotations,
+
+			CheckpointInterval: checkpointInterval,
 		}, "single", nil
 
 	case "batch":
@@ -482,6 +560,11 @@ func ConvertJIRASyncToAPIRequest(jiraSync *operatortypes.JIRASync) (interface{},
 			Branch:      jiraSync.Spec.Destination.Branch,
 			Parallelism: 1,     // Default parallelism, not configurable in CRD yet
 			DryRun:      false, // DryRun not supported in CRD yet
+
+			JobLabels:      jiraSync.Spec.JobLabels,
+			JobAnnotations: jiraSync.Spec.JobAnnotations,
+
+			CheckpointInterval: checkpointInterval,
 		}, "batch", nil
 
 	case "jql", "incremental":
@@ -493,6 +576,11 @@ func ConvertJIRASyncToAPIRequest(jiraSync *operatortypes.JIRASync) (interface{},
 			Repository: jiraSync.Spec.Destination.Repository,
 			Branch:     jiraSync.Spec.Destination.Branch,
 			DryRun:     false, // DryRun not supported in CRD yet
+
+			JobLabels:      jiraSync.Spec.JobLabels,
+			JobAnnotations: jiraSync.Spec.JobAnnotations,
+
+			CheckpointInterval: checkpointInterval,
 		}, "jql", nil
 
 	default:
@@ -281,6 +281,45 @@ func TestClient_GetJobStatus(t *testing.T) {
 	}
 }
 
+func TestClient_CancelJob(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		expectError bool
+	}{
+		{name: "cancellation accepted", statusCode: http.StatusOK},
+		{name: "cancellation rejected", statusCode: http.StatusInternalServerError, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v1/jobs/job-123/cancel" {
+					t.Errorf("Expected POST /api/v1/jobs/job-123/cancel, got %s %s", r.Method, r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				response := map[string]interface{}{"success": tt.statusCode == http.StatusOK}
+				if err := json.NewEncoder(w).Encode(response); err != nil {
+					t.Errorf("Failed to encode response: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			client := NewAPIClient(server.URL, 30*time.Second, logr.Discard())
+			err := client.CancelJob(context.Background(), "job-123")
+
+			if tt.expectError && err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestClient_HealthCheck(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -417,6 +456,57 @@ func TestConvertJIRASyncToAPIRequest(t *testing.T) {
 	}
 }
 
+func TestConvertJIRASyncToAPIRequest_JobMetadata(t *testing.T) {
+	jiraSync := &operatortypes.JIRASync{
+		Spec: operatortypes.JIRASyncSpec{
+			SyncType:       "jql",
+			Target:         operatortypes.SyncTarget{JQLQuery: "project = PROJ"},
+			Destination:    operatortypes.GitDestination{Repository: "/tmp/repo"},
+			JobLabels:      map[string]string{"cost-center": "eng-42"},
+			JobAnnotations: map[string]string{"example.com/owner": "platform"},
+		},
+	}
+
+	request, _, err := ConvertJIRASyncToAPIRequest(jiraSync)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	jqlRequest := request.(*JQLSyncRequest)
+	if jqlRequest.JobLabels["cost-center"] != "eng-42" {
+		t.Errorf("Expected job labels to propagate, got %v", jqlRequest.JobLabels)
+	}
+	if jqlRequest.JobAnnotations["example.com/owner"] != "platform" {
+		t.Errorf("Expected job annotations to propagate, got %v", jqlRequest.JobAnnotations)
+	}
+}
+
+func TestConvertJIRASyncToAPIRequest_Checkpoint(t *testing.T) {
+	jiraSync := &operatortypes.JIRASync{
+		Spec: operatortypes.JIRASyncSpec{
+			SyncType:    "batch",
+			Target:      operatortypes.SyncTarget{IssueKeys: []string{"PROJ-1", "PROJ-2"}},
+			Destination: operatortypes.GitDestination{Repository: "/tmp/repo"},
+			Checkpoint:  &operatortypes.CheckpointSpec{Interval: 250},
+		},
+	}
+
+	request, _, err := ConvertJIRASyncToAPIRequest(jiraSync)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if interval := request.(*BatchSyncRequest).CheckpointInterval; interval != 250 {
+		t.Errorf("Expected checkpoint interval 250, got %d", interval)
+	}
+
+	// Without a checkpoint the job commits once, as before
+	jiraSync.Spec.Checkpoint = nil
+	request, _, _ = ConvertJIRASyncToAPIRequest(jiraSync)
+	if interval := request.(*BatchSyncRequest).CheckpointInterval; interval != 0 {
+		t.Errorf("Expected no checkpoint interval, got %d", interval)
+	}
+}
+
 func TestAPIError_Error(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -11,6 +11,7 @@ type MockAPIClient struct {
 	TriggerBatchSyncFunc  func(ctx context.Context, request *BatchSyncRequest) (*SyncJobResponse, error)
 	TriggerJQLSyncFunc    func(ctx context.Context, request *JQLSyncRequest) (*SyncJobResponse, error)
 	GetJobStatusFunc      func(ctx context.Context, jobID string) (*JobStatusResponse, error)
+	CancelJobFunc         func(ctx context.Context, jobID string) error
 	HealthCheckFunc       func(ctx context.Context) error
 	DirectHealthCheckFunc func(ctx context.Context) error
 
@@ -19,6 +20,7 @@ type MockAPIClient struct {
 	TriggerBatchSyncCalls  []BatchSyncRequest
 	TriggerJQLSyncCalls    []JQLSyncRequest
 	GetJobStatusCalls      []string
+	CancelJobCalls         []string
 	HealthCheckCalls       int
 	DirectHealthCheckCalls int
 }
@@ -30,6 +32,7 @@ func NewMockAPIClient() *MockAPIClient {
 		TriggerBatchSyncCalls:  make([]BatchSyncRequest, 0),
 		TriggerJQLSyncCalls:    make([]JQLSyncRequest, 0),
 		GetJobStatusCalls:      make([]string, 0),
+		CancelJobCalls:         make([]string, 0),
 	}
 }
 
@@ -104,6 +107,18 @@ func (m *MockAPIClient) GetJobStatus(ctx context.Context, jobID string) (*JobSta
 	}, nil
 }
 
+// CancelJob implements APIClient.CancelJob
+func (m *MockAPIClient) CancelJob(ctx context.Context, jobID string) error {
+	m.CancelJobCalls = append(m.CancelJobCalls, jobID)
+
+	if m.CancelJobFunc != nil {
+		return m.CancelJobFunc(ctx, jobID)
+	}
+
+	// Default behavior - cancellation accepted
+	return nil
+}
+
 // HealthCheck implements APIClient.HealthCheck
 func (m *MockAPIClient) HealthCheck(ctx context.Context) error {
 	m.HealthCheckCalls++
@@ -134,6 +149,7 @@ func (m *MockAPIClient) Reset() {
 	m.TriggerBatchSyncCalls = make([]BatchSyncRequest, 0)
 	m.TriggerJQLSyncCalls = make([]JQLSyncRequest, 0)
 	m.GetJobStatusCalls = make([]string, 0)
+	m.CancelJobCalls = make([]string, 0)
 	m.HealthCheckCalls = 0
 	m.DirectHealthCheckCalls = 0
 }
@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+// API server reachability condition and reasons
+const (
+	ConditionTypeAPIServerReady = "APIServerReady"
+
+	ReasonWaitingForAPIServer  = "WaitingForAPIServer"
+	ReasonAPIServerUnreachable = "APIServerUnreachable"
+	ReasonAPIServerReachable   = "APIServerReachable"
+)
+
+const (
+	// DefaultHealthCheckInterval is how often the background health check probes the API servers
+	DefaultHealthCheckInterval = 30 * time.Second
+
+	// MinHealthCheckInterval keeps the health check from flooding the API servers
+	MinHealthCheckInterval = 5 * time.Second
+
+	// DefaultAPIUnreachableRequeueInterval is how often syncs waiting on an unreachable
+	// API server re-check; it matches the default health check period
+	DefaultAPIUnreachableRequeueInterval = DefaultHealthCheckInterval
+)
+
+// HealthCheckEndpoint is an additional API server probed by the background health check
+// Only the primary API server (APIHost) triggers syncs, so additional endpoints are
+// reported through the jirasync_api_health_status gauge without holding syncs.
+type HealthCheckEndpoint struct {
+	Host   string
+	Client apiclient.APIClient
+}
+
+// NewHealthCheckEndpoints creates health check clients for comma-separated API server URLs
+// Duplicates are dropped; every URL must be an absolute http or https URL.
+func NewHealthCheckEndpoints(value string, timeout time.Duration, log logr.Logger) ([]HealthCheckEndpoint, error) {
+	seen := make(map[string]bool)
+	var endpoints []HealthCheckEndpoint
+	for _, host := range strings.Split(value, ",") {
+		host = strings.TrimSpace(host)
+		if host == "" || seen[host] {
+			continue
+		}
+		parsed, err := url.Parse(host)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid health check endpoint %q: must be an http or https URL", host)
+		}
+		seen[host] = true
+		endpoints = append(endpoints, HealthCheckEndpoint{
+			Host:   host,
+			Client: apiclient.NewAPIClient(host, timeout, log.WithValues("endpoint", host)),
+		})
+	}
+	return endpoints, nil
+}
+
+// APIHealthTracker records the outcome of the periodic API server health check so that
+// reconciles can hold new syncs instead of failing them while the API server is down
+// The zero value reports the API server as reachable until a check says otherwise.
+type APIHealthTracker struct {
+	mu        sync.RWMutex
+	checked   bool
+	reachable bool
+	lastError string
+	since     time.Time
+	now       func() time.Time
+}
+
+// NewAPIHealthTracker creates a tracker with no health check recorded yet
+func NewAPIHealthTracker() *APIHealthTracker {
+	return &APIHealthTracker{now: time.Now}
+}
+
+// Record stores the result of a health check (nil error = reachable)
+func (t *APIHealthTracker) Record(err error) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	reachable := err == nil
+	if !t.checked || t.reachable != reachable {
+		t.since = t.clock()
+	}
+	t.checked = true
+	t.reachable = reachable
+	t.lastError = ""
+	if err != nil {
+		t.lastError = err.Error()
+	}
+}
+
+// Reachable reports whether the last health check succeeded (true before the first check)
+func (t *APIHealthTracker) Reachable() bool {
+	if t == nil {
+		return true
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return !t.checked || t.reachable
+}
+
+// Message describes the current unreachable state for conditions and events
+func (t *APIHealthTracker) Message() string {
+	if t == nil {
+		return ""
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if !t.checked || t.reachable {
+		return ""
+	}
+	return fmt.Sprintf("Waiting: API server unreachable since %s (%s)",
+		t.since.UTC().Format(time.RFC3339), t.lastError)
+}
+
+func (t *APIHealthTracker) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+// setAPIServerReadyCondition records API server reachability on the resource, keeping the
+// original transition time while the outage lasts so repeated reconciles do not churn status
+// Returns true when the condition changed and needs to be written.
+func setAPIServerReadyCondition(jiraSync *operatortypes.JIRASync, reachable bool, message string) bool {
+	status := metav1.ConditionTrue
+	reason := ReasonAPIServerReachable
+	if reachable {
+		message = "API server is reachable"
+	} else {
+		status = metav1.ConditionFalse
+		reason = ReasonAPIServerUnreachable
+	}
+
+	for i, existing := range jiraSync.Status.Conditions {
+		if existing.Type != ConditionTypeAPIServerReady {
+			continue
+		}
+		if existing.Status == status && existing.Reason == reason {
+			return false
+		}
+		transition := metav1.Now()
+		if existing.Status == status {
+			transition = existing.LastTransitionTime
+		}
+		jiraSync.Status.Conditions[i] = metav1.Condition{
+			Type:               ConditionTypeAPIServerReady,
+			Status:             status,
+			LastTransitionTime: transition,
+			Reason:             reason,
+			Message:            message,
+		}
+		return true
+	}
+
+	// Resources that never waited on the API server do not need a condition
+	if reachable {
+		return false
+	}
+
+	jiraSync.Status.Conditions = append(jiraSync.Status.Conditions, metav1.Condition{
+		Type:               ConditionTypeAPIServerReady,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
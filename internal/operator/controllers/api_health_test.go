@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+func findCondition(jiraSync *operatortypes.JIRASync, conditionType string) *metav1.Condition {
+	for i := range jiraSync.Status.Conditions {
+		if jiraSync.Status.Conditions[i].Type == conditionType {
+			return &jiraSync.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestAPIHealthTracker(t *testing.T) {
+	var nilTracker *APIHealthTracker
+	assert.True(t, nilTracker.Reachable())
+	assert.Empty(t, nilTracker.Message())
+
+	tracker := NewAPIHealthTracker()
+	assert.True(t, tracker.Reachable(), "reachable until the first check fails")
+
+	outage := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return outage }
+	tracker.Record(errors.New("connection refused"))
+	assert.False(t, tracker.Reachable())
+	assert.Contains(t, tracker.Message(), "Waiting: API server unreachable since 2024-01-15T10:00:00Z")
+	assert.Contains(t, tracker.Message(), "connection refused")
+
+	// Repeated failures keep the original outage start
+	tracker.now = func() time.Time { return outage.Add(time.Minute) }
+	tracker.Record(errors.New("timeout"))
+	assert.Contains(t, tracker.Message(), "since 2024-01-15T10:00:00Z (timeout)")
+
+	tracker.Record(nil)
+	assert.True(t, tracker.Reachable())
+	assert.Empty(t, tracker.Message())
+}
+
+func TestSetAPIServerReadyCondition(t *testing.T) {
+	jiraSync := createTestJIRASync("health", "default")
+
+	assert.False(t, setAPIServerReadyCondition(jiraSync, true, ""), "no condition needed while reachable")
+	assert.Empty(t, jiraSync.Status.Conditions)
+
+	assert.True(t, setAPIServerReadyCondition(jiraSync, false, "down"))
+	condition := findCondition(jiraSync, ConditionTypeAPIServerReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonAPIServerUnreachable, condition.Reason)
+
+	// Later reconciles during the same outage do not rewrite the condition
+	assert.False(t, setAPIServerReadyCondition(jiraSync, false, "still down"))
+	assert.Equal(t, "down", findCondition(jiraSync, ConditionTypeAPIServerReady).Message)
+
+	assert.True(t, setAPIServerReadyCondition(jiraSync, true, ""))
+	condition = findCondition(jiraSync, ConditionTypeAPIServerReady)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonAPIServerReachable, condition.Reason)
+	assert.Len(t, jiraSync.Status.Conditions, 1)
+}
+
+func TestJIRASyncReconciler_PerformHealthCheck_RecordsReachability(t *testing.T) {
+	reconciler, _ := setupTestReconciler()
+	reconciler.APIHealth = NewAPIHealthTracker()
+	mockAPIClient := reconciler.APIClient.(*apiclient.MockAPIClient)
+
+	mockAPIClient.DirectHealthCheckFunc = func(ctx context.Context) error {
+		return errors.New("dial tcp: connection refused")
+	}
+	reconciler.performHealthCheck(context.TODO())
+	assert.False(t, reconciler.APIHealth.Reachable())
+
+	mockAPIClient.DirectHealthCheckFunc = nil
+	reconciler.performHealthCheck(context.TODO())
+	assert.True(t, reconciler.APIHealth.Reachable())
+}
+
+func TestNewHealthCheckEndpoints(t *testing.T) {
+	endpoints, err := NewHealthCheckEndpoints(" http://api-b:8080, https://api-c, http://api-b:8080,", time.Second, logr.Discard())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, "http://api-b:8080", endpoints[0].Host)
+	assert.Equal(t, "https://api-c", endpoints[1].Host)
+	assert.NotNil(t, endpoints[0].Client)
+
+	endpoints, err = NewHealthCheckEndpoints("", time.Second, logr.Discard())
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+
+	for _, invalid := range []string{"api-b:8080", "ftp://api-b", "http://"} {
+		_, err := NewHealthCheckEndpoints(invalid, time.Second, logr.Discard())
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestJIRASyncReconciler_PerformHealthCheck_MultipleEndpoints(t *testing.T) {
+	reconciler, _ := setupTestReconciler()
+	reconciler.APIHealth = NewAPIHealthTracker()
+
+	healthy := &apiclient.MockAPIClient{}
+	down := &apiclient.MockAPIClient{
+		DirectHealthCheckFunc: func(ctx context.Context) error { return errors.New("connection refused") },
+	}
+	reconciler.HealthCheckEndpoints = []HealthCheckEndpoint{
+		{Host: "http://api-b:8080", Client: healthy},
+		{Host: "http://api-c:8080", Client: down},
+		{Host: reconciler.APIHost, Client: down}, // the primary is checked through APIClient only
+	}
+
+	reconciler.performHealthCheck(context.TODO())
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(reconciler.apiHealthStatus.WithLabelValues(reconciler.APIHost)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(reconciler.apiHealthStatus.WithLabelValues("http://api-b:8080")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(reconciler.apiHealthStatus.WithLabelValues("http://api-c:8080")))
+	assert.Equal(t, 1, healthy.DirectHealthCheckCalls)
+	assert.Equal(t, 1, down.DirectHealthCheckCalls)
+	assert.True(t, reconciler.APIHealth.Reachable(), "additional endpoints do not hold syncs")
+}
+
+func TestJIRASyncReconciler_HealthCheckInterval(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	assert.Equal(t, DefaultHealthCheckInterval, reconciler.healthCheckInterval())
+
+	reconciler.HealthCheckInterval = 10 * time.Second
+	reconciler.APIHealth = NewAPIHealthTracker()
+	reconciler.APIHealth.Record(errors.New("connection refused"))
+
+	pending := createPhasedJIRASync("held", PhasePending, "")
+	require.NoError(t, fakeClient.Create(context.TODO(), pending))
+	result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pending)})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, result.RequeueAfter, "held syncs re-check at the health check period")
+}
+
+func TestJIRASyncReconciler_HandlePending_APIServerUnreachable(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	reconciler.APIHealth = NewAPIHealthTracker()
+	reconciler.APIHealth.Record(errors.New("connection refused"))
+	recorder := reconciler.StatusManager.recorder.(*record.FakeRecorder)
+	mockAPIClient := reconciler.APIClient.(*apiclient.MockAPIClient)
+
+	pending := createPhasedJIRASync("pending", PhasePending, "")
+	require.NoError(t, fakeClient.Create(context.TODO(), pending))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pending)}
+
+	for i := 0; i < 3; i++ {
+		result, err := reconciler.Reconcile(context.TODO(), req)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultAPIUnreachableRequeueInterval, result.RequeueAfter)
+	}
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhasePending, updated.Status.Phase, "sync is held, not failed")
+	assert.Empty(t, mockAPIClient.TriggerSingleSyncCalls)
+
+	condition := findCondition(&updated, ConditionTypeAPIServerReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonAPIServerUnreachable, condition.Reason)
+	assert.Contains(t, condition.Message, "Waiting: API server unreachable")
+
+	warnings := 0
+	for _, event := range drainEvents(recorder) {
+		if strings.Contains(event, ReasonAPIServerUnreachable) {
+			warnings++
+		}
+	}
+	assert.Equal(t, 1, warnings, "event is only emitted when the condition changes")
+
+	// Once the health check passes the sync is triggered and the condition flips back
+	reconciler.APIHealth.Record(nil)
+	_, err := reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhaseRunning, updated.Status.Phase)
+	assert.Len(t, mockAPIClient.TriggerSingleSyncCalls, 1)
+	condition = findCondition(&updated, ConditionTypeAPIServerReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonAPIServerReachable, condition.Reason)
+}
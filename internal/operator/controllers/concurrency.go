@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+// Throttling condition and reasons
+const (
+	ConditionTypeThrottled = "Throttled"
+
+	ReasonConcurrencyLimitReached = "ConcurrencyLimitReached"
+	ReasonAdmitted                = "Admitted"
+)
+
+// Sync priorities (must match the CRD enum)
+const (
+	PriorityLow    = "low"
+	PriorityNormal = "normal"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+const (
+	// DefaultThrottleRequeueInterval is how often throttled resources re-check for a free slot
+	DefaultThrottleRequeueInterval = 15 * time.Second
+
+	// DefaultPriorityAgingInterval is how long a throttled resource waits before it is
+	// promoted one priority level, so low priority syncs cannot be starved indefinitely
+	DefaultPriorityAgingInterval = 5 * time.Minute
+
+	// admissionGracePeriod covers the window between admitting a sync and the informer
+	// cache observing its Running phase, preventing over-admission on stale reads
+	admissionGracePeriod = 30 * time.Second
+)
+
+// priorityRank maps a priority to its scheduling rank (higher runs first)
+var priorityRank = map[string]int{
+	PriorityLow:    0,
+	PriorityNormal: 1,
+	PriorityHigh:   2,
+	PriorityUrgent: 3,
+}
+
+// SyncThrottle enforces a cluster-wide limit on concurrently running JIRASync operations
+// Pending resources beyond the limit stay Pending with a Throttled condition and are
+// admitted in order of effective priority (spec priority plus aging), then wait time.
+type SyncThrottle struct {
+	// MaxConcurrentSyncs is the global limit (0 disables throttling)
+	MaxConcurrentSyncs int
+
+	// RequeueInterval controls how often throttled resources are re-evaluated
+	RequeueInterval time.Duration
+
+	// AgingInterval controls how quickly waiting resources gain priority
+	AgingInterval time.Duration
+
+	mu       sync.Mutex
+	admitted map[types.NamespacedName]time.Time
+	now      func() time.Time
+}
+
+// NewSyncThrottle creates a throttle with the given global limit
+func NewSyncThrottle(maxConcurrentSyncs int) *SyncThrottle {
+	return &SyncThrottle{
+		MaxConcurrentSyncs: maxConcurrentSyncs,
+		RequeueInterval:    DefaultThrottleRequeueInterval,
+		AgingInterval:      DefaultPriorityAgingInterval,
+		admitted:           make(map[types.NamespacedName]time.Time),
+		now:                time.Now,
+	}
+}
+
+// Enabled reports whether a concurrency limit is configured
+func (t *SyncThrottle) Enabled() bool {
+	return t != nil && t.MaxConcurrentSyncs > 0
+}
+
+// ThrottleDecision is the outcome of an admission check
+type ThrottleDecision struct {
+	Admitted bool
+	Running  int // syncs currently holding a slot
+	Position int // 1-based position in the wait queue when not admitted
+	Message  string
+}
+
+// Admit decides whether the pending resource may start now
+// All JIRASync resources are listed so the limit holds across namespaces and operator restarts.
+func (t *SyncThrottle) Admit(ctx context.Context, c client.Client, jiraSync *operatortypes.JIRASync) (*ThrottleDecision, error) {
+	if !t.Enabled() {
+		return &ThrottleDecision{Admitted: true}, nil
+	}
+
+	var list operatortypes.JIRASyncList
+	if err := c.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list JIRASync resources for concurrency check: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	self := types.NamespacedName{Namespace: jiraSync.Namespace, Name: jiraSync.Name}
+
+	running := make(map[types.NamespacedName]bool)
+	var waiting []operatortypes.JIRASync
+	for i := range list.Items {
+		item := &list.Items[i]
+		key := types.NamespacedName{Namespace: item.Namespace, Name: item.Name}
+		if isHoldingSlot(item) {
+			running[key] = true
+			delete(t.admitted, key)
+			continue
+		}
+		if item.Status.Phase == PhasePending && item.DeletionTimestamp.IsZero() && key != self {
+			if _, recentlyAdmitted := t.admitted[key]; !recentlyAdmitted {
+				waiting = append(waiting, *item)
+			}
+		}
+	}
+
+	// Admissions the cache has not caught up with yet still hold a slot
+	for key, admittedAt := range t.admitted {
+		if now.Sub(admittedAt) > admissionGracePeriod {
+			delete(t.admitted, key)
+			continue
+		}
+		if key != self {
+			running[key] = true
+		}
+	}
+
+	if running[self] {
+		return &ThrottleDecision{Admitted: true, Running: len(running)}, nil
+	}
+
+	available := t.MaxConcurrentSyncs - len(running)
+	waiting = append(waiting, *jiraSync)
+	t.sortQueue(waiting, now)
+
+	position := 0
+	for i := range waiting {
+		if waiting[i].Namespace == jiraSync.Namespace && waiting[i].Name == jiraSync.Name {
+			position = i + 1
+			break
+		}
+	}
+
+	if position <= available {
+		t.admitted[self] = now
+		return &ThrottleDecision{Admitted: true, Running: len(running) + 1}, nil
+	}
+
+	return &ThrottleDecision{
+		Admitted: false,
+		Running:  len(running),
+		Position: position,
+		Message: fmt.Sprintf("Global concurrency limit reached (%d/%d running), queue position %d",
+			len(running), t.MaxConcurrentSyncs, position),
+	}, nil
+}
+
+// Release frees a slot reserved by Admit when the sync could not be started
+func (t *SyncThrottle) Release(jiraSync *operatortypes.JIRASync) {
+	if !t.Enabled() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.admitted, types.NamespacedName{Namespace: jiraSync.Namespace, Name: jiraSync.Name})
+}
+
+// sortQueue orders waiting resources by effective priority, then by how long they have waited
+func (t *SyncThrottle) sortQueue(queue []operatortypes.JIRASync, now time.Time) {
+	sort.SliceStable(queue, func(i, j int) bool {
+		ri, rj := t.effectivePriority(&queue[i], now), t.effectivePriority(&queue[j], now)
+		if ri != rj {
+			return ri > rj
+		}
+		wi, wj := waitingSince(&queue[i], now), waitingSince(&queue[j], now)
+		if !wi.Equal(wj) {
+			return wi.Before(wj)
+		}
+		if queue[i].Namespace != queue[j].Namespace {
+			return queue[i].Namespace < queue[j].Namespace
+		}
+		return queue[i].Name < queue[j].Name
+	})
+}
+
+// effectivePriority returns the spec priority rank promoted by time spent waiting
+func (t *SyncThrottle) effectivePriority(jiraSync *operatortypes.JIRASync, now time.Time) int {
+	rank := PriorityRank(jiraSync.Spec.Priority)
+	if t.AgingInterval > 0 {
+		waited := now.Sub(waitingSince(jiraSync, now))
+		if waited > 0 {
+			rank += int(waited / t.AgingInterval)
+		}
+	}
+	return rank
+}
+
+// PriorityRank returns the scheduling rank for a priority (unknown values rank as normal)
+func PriorityRank(priority string) int {
+	if rank, exists := priorityRank[priority]; exists {
+		return rank
+	}
+	return priorityRank[PriorityNormal]
+}
+
+// isHoldingSlot reports whether a resource counts against the concurrency limit
+func isHoldingSlot(jiraSync *operatortypes.JIRASync) bool {
+	if jiraSync.Status.Phase == PhaseRunning {
+		return true
+	}
+	// Pending with a job reference means the job was triggered but the phase not yet advanced
+	return jiraSync.Status.Phase == PhasePending && jiraSync.Status.JobRef != nil && jiraSync.Status.JobRef.Name != ""
+}
+
+// waitingSince returns when a resource started waiting for a slot
+// Resources that have not been throttled yet are treated as having just arrived.
+func waitingSince(jiraSync *operatortypes.JIRASync, now time.Time) time.Time {
+	for _, condition := range jiraSync.Status.Conditions {
+		if condition.Type == ConditionTypeThrottled && condition.Status == metav1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return now
+}
+
+// setThrottledCondition records the throttle decision, keeping the original transition time
+// while a resource remains throttled so its wait time (and fairness aging) is preserved
+func setThrottledCondition(jiraSync *operatortypes.JIRASync, decision *ThrottleDecision) bool {
+	status := metav1.ConditionFalse
+	reason := ReasonAdmitted
+	message := "Concurrency slot acquired"
+	if !decision.Admitted {
+		status = metav1.ConditionTrue
+		reason = ReasonConcurrencyLimitReached
+		message = decision.Message
+	}
+
+	for i, existing := range jiraSync.Status.Conditions {
+		if existing.Type != ConditionTypeThrottled {
+			continue
+		}
+		if existing.Status == status && existing.Message == message {
+			return false
+		}
+		transition := metav1.Now()
+		if existing.Status == status {
+			transition = existing.LastTransitionTime
+		}
+		jiraSync.Status.Conditions[i] = metav1.Condition{
+			Type:               ConditionTypeThrottled,
+			Status:             status,
+			LastTransitionTime: transition,
+			Reason:             reason,
+			Message:            message,
+		}
+		return true
+	}
+
+	// Resources that were never throttled do not need a condition
+	if decision.Admitted {
+		return false
+	}
+
+	jiraSync.Status.Conditions = append(jiraSync.Status.Conditions, metav1.Condition{
+		Type:               ConditionTypeThrottled,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+func createPhasedJIRASync(name, phase, priority string) *operatortypes.JIRASync {
+	jiraSync := createTestJIRASync(name, "default")
+	jiraSync.Finalizers = []string{JIRASyncFinalizer}
+	jiraSync.Spec.Priority = priority
+	jiraSync.Status.Phase = phase
+	if phase == PhaseRunning {
+		jiraSync.Status.JobRef = &operatortypes.JobReference{Name: name + "-job", Namespace: "api"}
+	}
+	return jiraSync
+}
+
+func throttledSince(jiraSync *operatortypes.JIRASync, since time.Time) {
+	jiraSync.Status.Conditions = append(jiraSync.Status.Conditions, metav1.Condition{
+		Type:               ConditionTypeThrottled,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(since),
+		Reason:             ReasonConcurrencyLimitReached,
+	})
+}
+
+func TestSyncThrottle_Disabled(t *testing.T) {
+	var nilThrottle *SyncThrottle
+	assert.False(t, nilThrottle.Enabled())
+	assert.False(t, NewSyncThrottle(0).Enabled())
+
+	_, fakeClient := setupTestReconciler()
+	decision, err := NewSyncThrottle(0).Admit(context.TODO(), fakeClient, createPhasedJIRASync("a", PhasePending, ""))
+	require.NoError(t, err)
+	assert.True(t, decision.Admitted)
+}
+
+func TestSyncThrottle_LimitReached(t *testing.T) {
+	_, fakeClient := setupTestReconciler()
+	require.NoError(t, fakeClient.Create(context.TODO(), createPhasedJIRASync("running", PhaseRunning, "")))
+
+	pending := createPhasedJIRASync("pending", PhasePending, "")
+	require.NoError(t, fakeClient.Create(context.TODO(), pending))
+
+	throttle := NewSyncThrottle(1)
+	decision, err := throttle.Admit(context.TODO(), fakeClient, pending)
+	require.NoError(t, err)
+	assert.False(t, decision.Admitted)
+	assert.Equal(t, 1, decision.Running)
+	assert.Equal(t, 1, decision.Position)
+
+	// A running sync always keeps its slot
+	var running operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "running"}, &running))
+	decision, err = throttle.Admit(context.TODO(), fakeClient, &running)
+	require.NoError(t, err)
+	assert.True(t, decision.Admitted)
+}
+
+func TestSyncThrottle_PriorityOrdering(t *testing.T) {
+	_, fakeClient := setupTestReconciler()
+
+	low := createPhasedJIRASync("low", PhasePending, PriorityLow)
+	urgent := createPhasedJIRASync("urgent", PhasePending, PriorityUrgent)
+	require.NoError(t, fakeClient.Create(context.TODO(), low))
+	require.NoError(t, fakeClient.Create(context.TODO(), urgent))
+
+	throttle := NewSyncThrottle(1)
+
+	decision, err := throttle.Admit(context.TODO(), fakeClient, low)
+	require.NoError(t, err)
+	assert.False(t, decision.Admitted, "low priority must yield to waiting urgent sync")
+	assert.Equal(t, 2, decision.Position)
+
+	decision, err = throttle.Admit(context.TODO(), fakeClient, urgent)
+	require.NoError(t, err)
+	assert.True(t, decision.Admitted)
+
+	// The urgent admission holds the slot until the cache shows it running
+	decision, err = throttle.Admit(context.TODO(), fakeClient, low)
+	require.NoError(t, err)
+	assert.False(t, decision.Admitted)
+	assert.Equal(t, 1, decision.Running)
+
+	// Releasing a failed admission frees the slot immediately
+	throttle.Release(urgent)
+	require.NoError(t, fakeClient.Delete(context.TODO(), urgent))
+	decision, err = throttle.Admit(context.TODO(), fakeClient, low)
+	require.NoError(t, err)
+	assert.True(t, decision.Admitted)
+}
+
+func TestSyncThrottle_AgingPreventsStarvation(t *testing.T) {
+	_, fakeClient := setupTestReconciler()
+	now := time.Now()
+
+	// A low priority sync that has waited four aging intervals outranks a fresh urgent one
+	low := createPhasedJIRASync("low", PhasePending, PriorityLow)
+	throttledSince(low, now.Add(-20*time.Minute))
+	urgent := createPhasedJIRASync("urgent", PhasePending, PriorityUrgent)
+	require.NoError(t, fakeClient.Create(context.TODO(), low))
+	require.NoError(t, fakeClient.Create(context.TODO(), urgent))
+
+	throttle := NewSyncThrottle(1)
+	throttle.now = func() time.Time { return now }
+
+	decision, err := throttle.Admit(context.TODO(), fakeClient, urgent)
+	require.NoError(t, err)
+	assert.False(t, decision.Admitted)
+
+	decision, err = throttle.Admit(context.TODO(), fakeClient, low)
+	require.NoError(t, err)
+	assert.True(t, decision.Admitted)
+}
+
+func TestSyncThrottle_EqualPriorityFIFO(t *testing.T) {
+	_, fakeClient := setupTestReconciler()
+	now := time.Now()
+
+	older := createPhasedJIRASync("b-older", PhasePending, PriorityNormal)
+	throttledSince(older, now.Add(-2*time.Minute))
+	newer := createPhasedJIRASync("a-newer", PhasePending, PriorityNormal)
+	throttledSince(newer, now.Add(-1*time.Minute))
+	require.NoError(t, fakeClient.Create(context.TODO(), older))
+	require.NoError(t, fakeClient.Create(context.TODO(), newer))
+
+	throttle := NewSyncThrottle(1)
+	throttle.now = func() time.Time { return now }
+
+	decision, err := throttle.Admit(context.TODO(), fakeClient, newer)
+	require.NoError(t, err)
+	assert.False(t, decision.Admitted)
+
+	decision, err = throttle.Admit(context.TODO(), fakeClient, older)
+	require.NoError(t, err)
+	assert.True(t, decision.Admitted)
+}
+
+func TestPriorityRank(t *testing.T) {
+	assert.Less(t, PriorityRank(PriorityLow), PriorityRank(PriorityNormal))
+	assert.Less(t, PriorityRank(PriorityNormal), PriorityRank(PriorityHigh))
+	assert.Less(t, PriorityRank(PriorityHigh), PriorityRank(PriorityUrgent))
+	assert.Equal(t, PriorityRank(PriorityNormal), PriorityRank(""))
+	assert.Equal(t, PriorityRank(PriorityNormal), PriorityRank("bogus"))
+}
+
+func TestSetThrottledCondition_PreservesWaitStart(t *testing.T) {
+	jiraSync := createPhasedJIRASync("sync", PhasePending, "")
+	waitStart := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	throttledSince(jiraSync, waitStart)
+
+	changed := setThrottledCondition(jiraSync, &ThrottleDecision{Admitted: false, Message: "queue position 2"})
+	assert.True(t, changed)
+	require.Len(t, jiraSync.Status.Conditions, 1)
+	assert.Equal(t, waitStart, jiraSync.Status.Conditions[0].LastTransitionTime.Time)
+
+	changed = setThrottledCondition(jiraSync, &ThrottleDecision{Admitted: true})
+	assert.True(t, changed)
+	assert.Equal(t, metav1.ConditionFalse, jiraSync.Status.Conditions[0].Status)
+	assert.Equal(t, ReasonAdmitted, jiraSync.Status.Conditions[0].Reason)
+
+	// Never-throttled resources get no condition on admission
+	fresh := createPhasedJIRASync("fresh", PhasePending, "")
+	assert.False(t, setThrottledCondition(fresh, &ThrottleDecision{Admitted: true}))
+	assert.Empty(t, fresh.Status.Conditions)
+}
+
+func TestJIRASyncReconciler_HandlePending_Throttled(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	reconciler.Throttle = NewSyncThrottle(1)
+
+	require.NoError(t, fakeClient.Create(context.TODO(), createPhasedJIRASync("running", PhaseRunning, "")))
+	pending := createPhasedJIRASync("pending", PhasePending, "")
+	require.NoError(t, fakeClient.Create(context.TODO(), pending))
+
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pending)}
+	result, err := reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultThrottleRequeueInterval, result.RequeueAfter)
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhasePending, updated.Status.Phase)
+	assert.Nil(t, updated.Status.JobRef)
+
+	var throttled *metav1.Condition
+	for i := range updated.Status.Conditions {
+		if updated.Status.Conditions[i].Type == ConditionTypeThrottled {
+			throttled = &updated.Status.Conditions[i]
+		}
+	}
+	require.NotNil(t, throttled)
+	assert.Equal(t, metav1.ConditionTrue, throttled.Status)
+	assert.Equal(t, ReasonConcurrencyLimitReached, throttled.Reason)
+
+	mockAPIClient := reconciler.APIClient.(*apiclient.MockAPIClient)
+	assert.Empty(t, mockAPIClient.TriggerSingleSyncCalls)
+
+	// Once the running sync completes, the pending one is admitted
+	var running operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "running"}, &running))
+	running.Status.Phase = PhaseCompleted
+	require.NoError(t, fakeClient.Status().Update(context.TODO(), &running))
+
+	_, err = reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhaseRunning, updated.Status.Phase)
+	assert.Len(t, mockAPIClient.TriggerSingleSyncCalls, 1)
+}
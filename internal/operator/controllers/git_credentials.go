@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+// Data keys read from the secret referenced by credentials.gitSecretRef; gitSecretRef.key
+// overrides the token key
+const (
+	DefaultGitUsernameKey = "username"
+	DefaultGitTokenKey    = "token"
+)
+
+// Condition type and reasons for Git credentials
+const (
+	ConditionTypeGitCredentialsReady = "GitCredentialsReady"
+
+	ReasonGitCredentialsResolved = "GitCredentialsResolved"
+	ReasonGitSecretNotFound      = "GitSecretNotFound"
+	ReasonGitSecretInvalid       = "GitSecretInvalid"
+)
+
+// gitCredentialsError reports a gitSecretRef that cannot be resolved
+// Messages name the secret and its keys but never their values.
+type gitCredentialsError struct {
+	reason  string
+	message string
+}
+
+func (e *gitCredentialsError) Error() string {
+	return e.message
+}
+
+// validateCredentialRefs checks the credentials settings of a spec
+func validateCredentialRefs(refs *operatortypes.CredentialRefs) error {
+	if refs == nil {
+		return nil
+	}
+	if refs.JIRASecretRef != nil {
+		return fmt.Errorf("credentials.jiraSecretRef is not supported on JIRASync; JIRA credentials are configured on the API server")
+	}
+	if ref := refs.GitSecretRef; ref != nil {
+		if errs := validation.IsDNS1123Subdomain(ref.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid credentials.gitSecretRef.name %q: %s", ref.Name, errs[0])
+		}
+		if ref.Key != "" {
+			if errs := validation.IsConfigMapKey(ref.Key); len(errs) > 0 {
+				return fmt.Errorf("invalid credentials.gitSecretRef.key %q: %s", ref.Key, errs[0])
+			}
+		}
+	}
+	return nil
+}
+
+// gitSecretRef returns the Git credentials secret referenced by a sync, or nil
+func gitSecretRef(jiraSync *operatortypes.JIRASync) *operatortypes.SecretRef {
+	if jiraSync.Spec.Credentials == nil {
+		return nil
+	}
+	return jiraSync.Spec.Credentials.GitSecretRef
+}
+
+// resolveGitCredentials reads the Git credentials referenced by a sync from its namespace
+// Returns nil when the sync references no Git secret. The secret must hold a non-empty username
+// and token; a missing secret or key is reported as a *gitCredentialsError.
+func (r *JIRASyncReconciler) resolveGitCredentials(ctx context.Context, jiraSync *operatortypes.JIRASync) (*apiclient.GitCredentials, error) {
+	ref := gitSecretRef(jiraSync)
+	if ref == nil {
+		return nil, nil
+	}
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: jiraSync.Namespace}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &gitCredentialsError{
+				reason:  ReasonGitSecretNotFound,
+				message: fmt.Sprintf("Git credentials secret %q not found", ref.Name),
+			}
+		}
+		return nil, fmt.Errorf("failed to read Git credentials secret %q: %w", ref.Name, err)
+	}
+
+	tokenKey := ref.Key
+	if tokenKey == "" {
+		tokenKey = DefaultGitTokenKey
+	}
+	var missing []string
+	for _, key := range []string{DefaultGitUsernameKey, tokenKey} {
+		if len(secret.Data[key]) == 0 {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &gitCredentialsError{
+			reason:  ReasonGitSecretInvalid,
+			message: fmt.Sprintf("Git credentials secret %q is missing key(s): %s", ref.Name, strings.Join(missing, ", ")),
+		}
+	}
+
+	return &apiclient.GitCredentials{
+		Username: string(secret.Data[DefaultGitUsernameKey]),
+		Token:    string(secret.Data[tokenKey]),
+	}, nil
+}
+
+// setGitCredentialsCondition records whether the Git credentials of a sync could be resolved
+func (r *JIRASyncReconciler) setGitCredentialsCondition(jiraSync *operatortypes.JIRASync, err error) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeGitCredentialsReady,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGitCredentialsResolved,
+		Message:            fmt.Sprintf("Git credentials resolved from secret %q", gitSecretRef(jiraSync).Name),
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = gitCredentialsReason(err)
+		condition.Message = err.Error()
+	}
+	r.setCondition(&jiraSync.Status.Conditions, condition)
+}
+
+// gitCredentialsReason returns the condition and event reason of a Git credentials failure
+func gitCredentialsReason(err error) string {
+	var credErr *gitCredentialsError
+	if errors.As(err, &credErr) {
+		return credErr.reason
+	}
+	return ReasonGitSecretInvalid
+}
+
+// attachGitCredentials adds resolved Git credentials to an API sync request
+func attachGitCredentials(request interface{}, credentials *apiclient.GitCredentials) {
+	switch req := request.(type) {
+	case *apiclient.SingleSyncRequest:
+		req.GitCredentials = credentials
+	case *apiclient.BatchSyncRequest:
+		req.GitCredentials = credentials
+	case *apiclient.JQLSyncRequest:
+		req.GitCredentials = credentials
+	}
+}
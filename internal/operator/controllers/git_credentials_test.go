@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+func createGitSecret(t *testing.T, c client.Client, data map[string]string) {
+	t.Helper()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-push", Namespace: "default"},
+		Data:       map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	require.NoError(t, c.Create(context.TODO(), secret))
+}
+
+func pendingSyncWithGitSecret(key string) *operatortypes.JIRASync {
+	jiraSync := createPhasedJIRASync("push", PhasePending, "")
+	jiraSync.Spec.Credentials = &operatortypes.CredentialRefs{
+		GitSecretRef: &operatortypes.SecretRef{Name: "git-push", Key: key},
+	}
+	return jiraSync
+}
+
+func TestGitCredentials_PassedToSyncJob(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	createGitSecret(t, fakeClient, map[string]string{"username": "bot", "password": "s3cr3t-token"})
+	jiraSync := pendingSyncWithGitSecret("password")
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+
+	_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)})
+	require.NoError(t, err)
+
+	mockAPI := reconciler.APIClient.(*apiclient.MockAPIClient)
+	require.Len(t, mockAPI.TriggerSingleSyncCalls, 1)
+	credentials := mockAPI.TriggerSingleSyncCalls[0].GitCredentials
+	require.NotNil(t, credentials)
+	assert.Equal(t, "bot", credentials.Username)
+	assert.Equal(t, "s3cr3t-token", credentials.Token)
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(jiraSync), &updated))
+	assert.Equal(t, PhaseRunning, updated.Status.Phase)
+	condition := meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeGitCredentialsReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+}
+
+func TestGitCredentials_InvalidSecretFailsSync(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     map[string]string
+		wantReason string
+	}{
+		{name: "missing secret", wantReason: ReasonGitSecretNotFound},
+		{name: "missing token", secret: map[string]string{"username": "bot-user"}, wantReason: ReasonGitSecretInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler, fakeClient := setupTestReconciler()
+			if tt.secret != nil {
+				createGitSecret(t, fakeClient, tt.secret)
+			}
+			jiraSync := pendingSyncWithGitSecret("")
+			require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)})
+			require.NoError(t, err)
+
+			mockAPI := reconciler.APIClient.(*apiclient.MockAPIClient)
+			assert.Empty(t, mockAPI.TriggerSingleSyncCalls)
+
+			var updated operatortypes.JIRASync
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(jiraSync), &updated))
+			assert.Equal(t, PhaseFailed, updated.Status.Phase)
+			condition := meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeGitCredentialsReady)
+			require.NotNil(t, condition)
+			assert.Equal(t, metav1.ConditionFalse, condition.Status)
+			assert.Equal(t, tt.wantReason, condition.Reason)
+			assert.NotContains(t, condition.Message, "bot-user")
+		})
+	}
+}
+
+func TestValidateCredentialRefs(t *testing.T) {
+	assert.NoError(t, validateCredentialRefs(nil))
+	assert.NoError(t, validateCredentialRefs(&operatortypes.CredentialRefs{GitSecretRef: &operatortypes.SecretRef{Name: "git-push"}}))
+	assert.Error(t, validateCredentialRefs(&operatortypes.CredentialRefs{GitSecretRef: &operatortypes.SecretRef{Name: "Git_Push"}}))
+	assert.Error(t, validateCredentialRefs(&operatortypes.CredentialRefs{GitSecretRef: &operatortypes.SecretRef{Name: "git-push", Key: "bad key"}}))
+	assert.Error(t, validateCredentialRefs(&operatortypes.CredentialRefs{JIRASecretRef: &operatortypes.SecretRef{Name: "jira"}}))
+}
+
+func TestGitCredentials_Redacted(t *testing.T) {
+	request := &apiclient.JQLSyncRequest{
+		JQLQuery:       "project = PROJ",
+		GitCredentials: &apiclient.GitCredentials{Username: "bot", Token: "s3cr3t-token"},
+	}
+	for _, format := range []string{"%v", "%+v", "%#v"} {
+		assert.NotContains(t, fmt.Sprintf(format, request), "s3cr3t-token", format)
+		assert.NotContains(t, fmt.Sprintf(format, *request.GitCredentials), "s3cr3t-token", format)
+	}
+}
@@ -2,17 +2,22 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -31,6 +36,12 @@ type JIRASyncReconciler struct {
 	APIHost       string              // v0.4.0 API server host for job triggering
 	APIClient     apiclient.APIClient // API client for triggering sync operations
 	StatusManager *StatusManager      // Enhanced status management
+	Throttle      *SyncThrottle       // Global concurrency limit across JIRASync resources (nil = unlimited)
+	APIHealth     *APIHealthTracker   // Last API server health check result (nil = always reachable)
+	Maintenance   *MaintenanceMode    // Planned API server maintenance switch (nil = never in maintenance)
+
+	HealthCheckInterval  time.Duration         // Period of the background health check (0 = DefaultHealthCheckInterval)
+	HealthCheckEndpoints []HealthCheckEndpoint // Additional API servers probed alongside APIHost
 
 	// Metrics
 	reconcileCounter  prometheus.CounterVec
@@ -62,14 +73,25 @@ const (
 	LastErrorAnnotation  = "sync.jira.io/last-error"
 )
 
+// MaxCheckpointInterval is the largest spec.checkpoint.interval, matching the CRD schema
+const MaxCheckpointInterval = 10000
+
 // +kubebuilder:rbac:groups=sync.jira.io,resources=jirasyncs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=sync.jira.io,resources=jirasyncs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=sync.jira.io,resources=jirasyncs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 
-// NewJIRASyncReconciler creates a new JIRASyncReconciler with metrics
+// NewJIRASyncReconciler creates a new JIRASyncReconciler with metrics registered in
+// controller-runtime's metrics registry
 func NewJIRASyncReconciler(mgr ctrl.Manager, apiHost string) *JIRASyncReconciler {
+	return NewJIRASyncReconcilerWithRegisterer(mgr, apiHost, metrics.Registry)
+}
+
+// NewJIRASyncReconcilerWithRegisterer creates a new JIRASyncReconciler with metrics registered
+// in registerer (nil: not registered). Reconcilers sharing a registerer share its metrics.
+func NewJIRASyncReconcilerWithRegisterer(mgr ctrl.Manager, apiHost string, registerer prometheus.Registerer) *JIRASyncReconciler {
 	log := ctrl.Log.WithName("controllers").WithName("JIRASync")
 
 	// Create API client for v0.4.0 integration
@@ -88,94 +110,113 @@ func NewJIRASyncReconciler(mgr ctrl.Manager, apiHost string) *JIRASyncReconciler
 		APIHost:       apiHost,
 		APIClient:     apiClient,
 		StatusManager: statusManager,
+		APIHealth:     NewAPIHealthTracker(),
 	}
 
 	// Initialize metrics
-	reconciler.initMetrics()
+	reconciler.initMetrics(registerer)
 
 	return reconciler
 }
 
-// initMetrics initializes Prometheus metrics
-func (r *JIRASyncReconciler) initMetrics() {
-	r.reconcileCounter = *prometheus.NewCounterVec(
+// initMetrics initializes Prometheus metrics and registers them with registerer
+// Registration is idempotent: a metric the registerer already holds, such as one registered by
+// another reconciler, is reused instead of registered twice.
+func (r *JIRASyncReconciler) initMetrics(registerer prometheus.Registerer) {
+	r.reconcileCounter = *registerCollector(r, registerer, prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "jirasync_reconcile_total",
 			Help: "Total number of JIRASync reconciliations",
 		},
 		[]string{"namespace", "name", "result"},
-	)
+	))
 
-	r.reconcileDuration = *prometheus.NewHistogramVec(
+	r.reconcileDuration = *registerCollector(r, registerer, prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "jirasync_reconcile_duration_seconds",
 			Help:    "Duration of JIRASync reconciliations",
 			Buckets: prometheus.DefBuckets,
 		},
 		[]string{"namespace", "name"},
-	)
+	))
 
-	r.syncJobsTotal = *prometheus.NewGaugeVec(
+	r.syncJobsTotal = *registerCollector(r, registerer, prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "jirasync_jobs_total",
 			Help: "Total number of active sync jobs",
 		},
 		[]string{"namespace", "phase"},
-	)
+	))
 
-	r.apiHealthStatus = *prometheus.NewGaugeVec(
+	r.apiHealthStatus = *registerCollector(r, registerer, prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "jirasync_api_health_status",
 			Help: "Health status of the v0.4.0 API server (1=healthy, 0=unhealthy)",
 		},
 		[]string{"api_host"},
-	)
+	))
 
-	r.apiCallCounter = *prometheus.NewCounterVec(
+	r.apiCallCounter = *registerCollector(r, registerer, prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "jirasync_api_calls_total",
 			Help: "Total number of API calls made to v0.4.0 server",
 		},
 		[]string{"endpoint", "status"},
-	)
+	))
 
-	r.apiCallDuration = *prometheus.NewHistogramVec(
+	r.apiCallDuration = *registerCollector(r, registerer, prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "jirasync_api_call_duration_seconds",
 			Help:    "Duration of API calls to v0.4.0 server",
 			Buckets: prometheus.DefBuckets,
 		},
 		[]string{"endpoint"},
-	)
+	))
 
-	r.statusUpdateCounter = *prometheus.NewCounterVec(
+	r.statusUpdateCounter = *registerCollector(r, registerer, prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "jirasync_status_updates_total",
 			Help: "Total number of status updates",
 		},
 		[]string{"namespace", "name", "phase"},
-	)
+	))
 
-	r.conditionCounter = *prometheus.NewGaugeVec(
+	r.conditionCounter = *registerCollector(r, registerer, prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "jirasync_conditions",
 			Help: "Current conditions status (1=True, 0=False)",
 		},
 		[]string{"namespace", "name", "type"},
-	)
+	))
 
-	r.progressGauge = *prometheus.NewGaugeVec(
+	r.progressGauge = *registerCollector(r, registerer, prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "jirasync_progress_percentage",
 			Help: "Current progress percentage",
 		},
 		[]string{"namespace", "name", "stage"},
-	)
+	))
+}
 
-	// Register metrics with controller-runtime's metrics registry
-	metrics.Registry.MustRegister(&r.reconcileCounter, &r.reconcileDuration, &r.syncJobsTotal,
-		&r.apiHealthStatus, &r.apiCallCounter, &r.apiCallDuration,
-		&r.statusUpdateCounter, &r.conditionCounter, &r.progressGauge)
+// registerCollector registers a metric and returns it, or the equal metric registerer already
+// holds. Metrics that conflict with a different registered metric are logged and left
+// unregistered, so the reconciler still works without exporting them.
+func registerCollector[T prometheus.Collector](r *JIRASyncReconciler, registerer prometheus.Registerer, collector T) T {
+	if registerer == nil {
+		return collector
+	}
+	err := registerer.Register(collector)
+	if err == nil {
+		return collector
+	}
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+	r.Log.Error(err, "Failed to register metric")
+	return collector
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -236,6 +277,12 @@ func (r *JIRASyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	// Update metrics
 	r.syncJobsTotal.WithLabelValues(req.Namespace, jiraSync.Status.Phase).Inc()
 
+	// Hold syncs during planned API server maintenance instead of failing them
+	if held, result := r.holdForMaintenance(ctx, &jiraSync); held {
+		r.reconcileCounter.WithLabelValues(req.Namespace, req.Name, "maintenance").Inc()
+		return result, nil
+	}
+
 	// Reconcile based on current phase
 	var result ctrl.Result
 	var err error
@@ -292,10 +339,10 @@ func (r *JIRASyncReconciler) initializeSync(ctx context.Context, jiraSync *opera
 		// Set a custom condition for API server readiness
 		// Since there's no UpdateCondition method, we'll use a different approach or add it
 		condition := metav1.Condition{
-			Type:               "APIServerReady",
+			Type:               ConditionTypeAPIServerReady,
 			Status:             metav1.ConditionFalse,
 			LastTransitionTime: metav1.NewTime(time.Now()),
-			Reason:             "WaitingForAPIServer",
+			Reason:             ReasonWaitingForAPIServer,
 			Message:            "Waiting for API server to be ready",
 		}
 
@@ -307,7 +354,7 @@ func (r *JIRASyncReconciler) initializeSync(ctx context.Context, jiraSync *opera
 		// Find and update existing condition or add new one
 		found := false
 		for i, existingCondition := range jiraSync.Status.Conditions {
-			if existingCondition.Type == "APIServerReady" {
+			if existingCondition.Type == ConditionTypeAPIServerReady {
 				if existingCondition.Status != condition.Status {
 					jiraSync.Status.Conditions[i] = condition
 				}
@@ -356,7 +403,8 @@ func (r *JIRASyncReconciler) initializeSync(ctx context.Context, jiraSync *opera
 			log.Error(err, "Failed to update status")
 		}
 		r.updateStatusMetrics(jiraSync)
-		return ctrl.Result{}, nil
+		// Status writes do not trigger reconciles; requeue so the failure is handled
+		return ctrl.Result{Requeue: true}, nil
 	}
 
 	// Initialize sync state and statistics
@@ -402,7 +450,8 @@ func (r *JIRASyncReconciler) initializeSync(ctx context.Context, jiraSync *opera
 	}
 
 	r.updateStatusMetrics(jiraSync)
-	return ctrl.Result{}, nil
+	// Status writes do not trigger reconciles; requeue so the pending sync is triggered
+	return ctrl.Result{Requeue: true}, nil
 }
 
 // handlePending processes a pending sync by triggering API operations
@@ -416,12 +465,69 @@ func (r *JIRASyncReconciler) handlePending(ctx context.Context, jiraSync *operat
 		return r.updateStatus(ctx, jiraSync, PhaseRunning, "API sync operation already triggered")
 	}
 
+	// Hold the sync while the API server is unreachable instead of failing the trigger
+	if !r.APIHealth.Reachable() {
+		message := r.APIHealth.Message()
+		log.Info("API server unreachable, holding sync in Pending", "reason", message)
+		if setAPIServerReadyCondition(jiraSync, false, message) {
+			r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeWarning, ReasonAPIServerUnreachable, message)
+			if err := r.Status().Update(ctx, jiraSync); err != nil {
+				log.Error(err, "Failed to update APIServerReady condition")
+			}
+			r.updateStatusMetrics(jiraSync)
+		}
+		return ctrl.Result{RequeueAfter: r.healthCheckInterval()}, nil
+	}
+	if setAPIServerReadyCondition(jiraSync, true, "") {
+		r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeNormal, ReasonAPIServerReachable, "API server is reachable again")
+		if err := r.Status().Update(ctx, jiraSync); err != nil {
+			log.Error(err, "Failed to update APIServerReady condition")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+	}
+
+	// Resolve Git credentials for pushing before the sync takes a concurrency slot
+	gitCredentials, err := r.resolveGitCredentials(ctx, jiraSync)
+	if gitSecretRef(jiraSync) != nil {
+		r.setGitCredentialsCondition(jiraSync, err)
+	}
+	if err != nil {
+		log.Error(err, "Failed to resolve Git credentials")
+		r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeWarning, gitCredentialsReason(err), err.Error())
+		r.recordError(jiraSync, err)
+		return r.updateStatus(ctx, jiraSync, PhaseFailed, "Failed to resolve Git credentials: "+err.Error())
+	}
+
+	// Enforce the global concurrency limit before triggering new work
+	if r.Throttle.Enabled() {
+		decision, err := r.Throttle.Admit(ctx, r.Client, jiraSync)
+		if err != nil {
+			log.Error(err, "Failed to evaluate concurrency limit")
+			return ctrl.Result{RequeueAfter: r.Throttle.RequeueInterval}, nil
+		}
+
+		changed := setThrottledCondition(jiraSync, decision)
+		if !decision.Admitted {
+			log.Info("Sync throttled by global concurrency limit",
+				"running", decision.Running, "limit", r.Throttle.MaxConcurrentSyncs, "position", decision.Position)
+			if changed {
+				if err := r.Status().Update(ctx, jiraSync); err != nil {
+					log.Error(err, "Failed to update Throttled condition")
+				}
+				r.updateStatusMetrics(jiraSync)
+			}
+			return ctrl.Result{RequeueAfter: r.Throttle.RequeueInterval}, nil
+		}
+	}
+
 	// Convert JIRASync to API request
 	request, requestType, err := apiclient.ConvertJIRASyncToAPIRequest(jiraSync)
 	if err != nil {
+		r.Throttle.Release(jiraSync)
 		r.recordError(jiraSync, err)
 		return r.updateStatus(ctx, jiraSync, PhaseFailed, "Failed to convert sync spec: "+err.Error())
 	}
+	attachGitCredentials(request, gitCredentials)
 
 	log.Info("Triggering API sync operation", "type", requestType)
 
@@ -454,6 +560,7 @@ func (r *JIRASyncReconciler) handlePending(ctx context.Context, jiraSync *operat
 
 	if err != nil {
 		log.Error(err, "Failed to trigger API sync operation")
+		r.Throttle.Release(jiraSync)
 		r.recordError(jiraSync, err)
 		return r.updateStatus(ctx, jiraSync, PhaseFailed, "Failed to trigger sync: "+err.Error())
 	}
@@ -464,6 +571,13 @@ func (r *JIRASyncReconciler) handlePending(ctx context.Context, jiraSync *operat
 		Namespace: "api", // Special namespace indicating this is an API job
 	}
 
+	// spec.timeout is measured from the trigger of the current job, so retries get a full timeout
+	triggeredAt := metav1.Now()
+	if jiraSync.Status.SyncStats == nil {
+		jiraSync.Status.SyncStats = &operatortypes.SyncStats{}
+	}
+	jiraSync.Status.SyncStats.StartTime = &triggeredAt
+
 	log.Info("API sync operation triggered successfully", "jobID", response.JobID)
 	return r.updateStatus(ctx, jiraSync, PhaseRunning, fmt.Sprintf("API sync operation triggered: %s", response.JobID))
 }
@@ -478,6 +592,12 @@ func (r *JIRASyncReconciler) handleRunning(ctx context.Context, jiraSync *operat
 		return r.updateStatus(ctx, jiraSync, PhasePending, "No job reference found")
 	}
 
+	// Enforce spec.timeout before polling the job again
+	now := time.Now()
+	if deadline, ok := syncDeadline(jiraSync); ok && !now.Before(deadline) {
+		return r.failTimedOutSync(ctx, jiraSync, now)
+	}
+
 	// Check if this is an API job (namespace = "api") or legacy Kubernetes job
 	if jiraSync.Status.JobRef.Namespace == "api" {
 		// This is an API job, check status via API
@@ -518,6 +638,7 @@ func (r *JIRASyncReconciler) handleAPIJobStatus(ctx context.Context, jiraSync *o
 		// Clear any previous error
 		r.clearError(jiraSync)
 
+		r.storeResultArtifact(ctx, jiraSync, jobStatus)
 		return r.updateStatus(ctx, jiraSync, PhaseCompleted, "API sync completed successfully")
 
 	case "failed":
@@ -527,6 +648,7 @@ func (r *JIRASyncReconciler) handleAPIJobStatus(ctx context.Context, jiraSync *o
 			errorMsg += ": " + jobStatus.Message
 		}
 		r.recordError(jiraSync, fmt.Errorf("%s", errorMsg))
+		r.storeResultArtifact(ctx, jiraSync, jobStatus)
 		return r.updateStatus(ctx, jiraSync, PhaseFailed, errorMsg)
 
 	case "running", "pending":
@@ -542,7 +664,7 @@ func (r *JIRASyncReconciler) handleAPIJobStatus(ctx context.Context, jiraSync *o
 		}
 
 		log.Info(message)
-		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil // Check again in 15 seconds
+		return ctrl.Result{RequeueAfter: requeueBeforeDeadline(jiraSync, 15*time.Second, time.Now())}, nil // Check again in 15 seconds
 
 	default:
 		// Unknown status
@@ -596,7 +718,7 @@ func (r *JIRASyncReconciler) handleKubernetesJobStatus(ctx context.Context, jira
 	}
 
 	// Job still running, requeue for later check
-	return ctrl.Result{RequeueAfter: time.Minute * 2}, nil
+	return ctrl.Result{RequeueAfter: requeueBeforeDeadline(jiraSync, time.Minute*2, time.Now())}, nil
 }
 
 // handleCompleted processes a completed sync
@@ -614,34 +736,63 @@ func (r *JIRASyncReconciler) handleFailed(ctx context.Context, jiraSync *operato
 	log := r.Log.WithValues("jirasync", client.ObjectKeyFromObject(jiraSync))
 	log.Info("Handling failed JIRASync")
 
-	// Implement retry logic if configured
-	if jiraSync.Spec.RetryPolicy != nil && jiraSync.Spec.RetryPolicy.MaxRetries > 0 {
-		// Check if we should retry
-		retryCount := r.getRetryCount(jiraSync)
-		if retryCount < jiraSync.Spec.RetryPolicy.MaxRetries {
-			log.Info("Retrying failed sync", "retryCount", retryCount, "maxRetries", jiraSync.Spec.RetryPolicy.MaxRetries)
+	policy := jiraSync.Spec.RetryPolicy
+	if policy == nil {
+		return ctrl.Result{}, nil
+	}
 
-			// Increment retry count
-			r.incrementRetryCount(jiraSync)
+	// The retry budget covers one spec generation; editing the spec starts a new one
+	now := time.Now()
+	budgetReset := resetRetryBudgetOnSpecChange(jiraSync)
+	if budgetReset {
+		log.Info("Spec changed since the retry budget started, starting a new retry budget")
+	}
+	annotationsChanged := startRetryBudget(jiraSync, now) || budgetReset
 
-			// Calculate backoff delay
-			delay := time.Duration(jiraSync.Spec.RetryPolicy.InitialDelay) * time.Second
-			for i := 0; i < retryCount; i++ {
-				delay = time.Duration(float64(delay) * jiraSync.Spec.RetryPolicy.BackoffMultiplier)
-			}
+	budget := r.evaluateRetryBudget(jiraSync, now)
+	if !budget.Exhausted {
+		retryCount := budget.Attempts
+		log.Info("Retrying failed sync", "retryCount", retryCount, "maxRetries", policy.MaxRetries)
 
-			// Update the JIRASync with the new retry count
-			if err := r.Update(ctx, jiraSync); err != nil {
-				return ctrl.Result{}, err
-			}
+		// Increment retry count
+		r.incrementRetryCount(jiraSync)
+
+		// Calculate backoff delay
+		delay := time.Duration(policy.InitialDelay) * time.Second
+		for i := 0; i < retryCount; i++ {
+			delay = time.Duration(float64(delay) * policy.BackoffMultiplier)
+		}
+
+		// Update the JIRASync with the new retry count
+		if err := r.Update(ctx, jiraSync); err != nil {
+			return ctrl.Result{}, err
+		}
 
-			return r.updateStatusWithDelay(ctx, jiraSync, PhasePending,
-				fmt.Sprintf("Retrying sync (attempt %d/%d)", retryCount+1, jiraSync.Spec.RetryPolicy.MaxRetries),
-				delay)
+		if budgetReset {
+			setRetryExhaustedCondition(jiraSync, false, ReasonRetryBudgetReset, "Spec changed; retry budget reset")
 		}
+
+		return r.updateStatusWithDelay(ctx, jiraSync, PhasePending,
+			fmt.Sprintf("Retrying sync (attempt %d/%d)", retryCount+1, policy.MaxRetries),
+			delay)
+	}
+
+	// Budget exhausted: record a terminal condition once and stop retrying
+	if annotationsChanged {
+		if err := r.Update(ctx, jiraSync); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if setRetryExhaustedCondition(jiraSync, true, budget.Reason, budget.Message) {
+		log.Info("Retry budget exhausted", "reason", budget.Reason, "retries", budget.Attempts)
+		r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeWarning, ConditionTypeRetryExhausted, budget.Message)
+		if err := r.Status().Update(ctx, jiraSync); err != nil {
+			log.Error(err, "Failed to update RetryExhausted condition")
+			return ctrl.Result{}, err
+		}
+		r.updateStatusMetrics(jiraSync)
 	}
 
-	// No more retries, keep in failed state
 	return ctrl.Result{}, nil
 }
 
@@ -707,6 +858,10 @@ func (r *JIRASyncReconciler) buildSyncArgs(jiraSync *operatortypes.JIRASync) []s
 		args = append(args, "--branch", jiraSync.Spec.Destination.Branch)
 	}
 
+	if checkpoint := jiraSync.Spec.Checkpoint; checkpoint != nil {
+		args = append(args, "--commit-strategy", "batch", "--checkpoint-interval", strconv.Itoa(checkpoint.Interval))
+	}
+
 	return args
 }
 
@@ -737,6 +892,51 @@ func (r *JIRASyncReconciler) validateSyncSpec(spec *operatortypes.JIRASyncSpec)
 		return fmt.Errorf("invalid syncType: %s", spec.SyncType)
 	}
 
+	// Validate the retry budget
+	if policy := spec.RetryPolicy; policy != nil {
+		if policy.MaxRetries < 0 {
+			return fmt.Errorf("retryPolicy.maxRetries must not be negative")
+		}
+		if policy.MaxRetryDuration < 0 || policy.MaxRetryDuration > MaxRetryDurationLimit {
+			return fmt.Errorf("retryPolicy.maxRetryDuration must be between 0 and %d seconds", MaxRetryDurationLimit)
+		}
+	}
+
+	// Validate the sync timeout (0 disables it)
+	if spec.Timeout != 0 && (spec.Timeout < MinSyncTimeout || spec.Timeout > MaxSyncTimeout) {
+		return fmt.Errorf("timeout must be between %d and %d seconds, or 0 for no timeout", MinSyncTimeout, MaxSyncTimeout)
+	}
+
+	// Validate result artifact settings
+	if err := validateResultArtifact(spec.ResultArtifact); err != nil {
+		return err
+	}
+
+	// Validate credential references
+	if err := validateCredentialRefs(spec.Credentials); err != nil {
+		return err
+	}
+
+	// Validate commit checkpoints
+	if spec.Checkpoint != nil && (spec.Checkpoint.Interval < 1 || spec.Checkpoint.Interval > MaxCheckpointInterval) {
+		return fmt.Errorf("checkpoint.interval must be between 1 and %d", MaxCheckpointInterval)
+	}
+
+	// Validate custom job metadata so Job creation does not fail later
+	for key, value := range spec.JobLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid jobLabels key %q: %s", key, errs[0])
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid jobLabels value for %q: %s", key, errs[0])
+		}
+	}
+	for key := range spec.JobAnnotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid jobAnnotations key %q: %s", key, errs[0])
+		}
+	}
+
 	return nil
 }
 
@@ -767,7 +967,12 @@ func (r *JIRASyncReconciler) updateStatus(ctx context.Context, jiraSync *operato
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// Status-only updates are filtered from the watch, so phases that need another pass
+	// (trigger, poll, retry) requeue here; a completed sync has nothing left to do
+	if phase == PhaseCompleted {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{Requeue: true}, nil
 }
 
 func (r *JIRASyncReconciler) updateStatusWithDelay(ctx context.Context, jiraSync *operatortypes.JIRASync, phase, message string, delay time.Duration) (ctrl.Result, error) {
@@ -820,13 +1025,15 @@ func (r *JIRASyncReconciler) clearError(jiraSync *operatortypes.JIRASync) {
 	if jiraSync.Annotations != nil {
 		delete(jiraSync.Annotations, LastErrorAnnotation)
 		delete(jiraSync.Annotations, RetryCountAnnotation)
+		delete(jiraSync.Annotations, RetryStartAnnotation)
+		delete(jiraSync.Annotations, RetryGenerationAnnotation)
 	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *JIRASyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&operatortypes.JIRASync{}).
+		For(&operatortypes.JIRASync{}, builder.WithPredicates(ignoreStatusOnlyUpdates())).
 		Owns(&batchv1.Job{}).
 		Complete(r)
 }
@@ -837,24 +1044,59 @@ func (r *JIRASyncReconciler) recordAPICall(endpoint, status string, duration tim
 	r.apiCallDuration.WithLabelValues(endpoint).Observe(duration.Seconds())
 }
 
-// performHealthCheck checks the health of the API server and updates metrics
-// Uses DirectHealthCheck to bypass circuit breaker and reset it on success
+// performHealthCheck checks the health of the API servers and updates metrics
+// Uses DirectHealthCheck to bypass circuit breaker and reset it on success. Additional
+// endpoints are checked in parallel; only the primary API server's result holds syncs.
 func (r *JIRASyncReconciler) performHealthCheck(ctx context.Context) {
 	log := r.Log.WithName("health-check")
+	maintenance, _, _ := r.Maintenance.Active(ctx)
+	primaryHost := r.APIHost
+
+	var wg sync.WaitGroup
+	for _, endpoint := range r.HealthCheckEndpoints {
+		if endpoint.Host == primaryHost {
+			continue
+		}
+		wg.Add(1)
+		go func(endpoint HealthCheckEndpoint) {
+			defer wg.Done()
+			r.recordHealthCheck(log, endpoint.Host, endpoint.Client.DirectHealthCheck(ctx), maintenance)
+		}(endpoint)
+	}
 
 	err := r.APIClient.DirectHealthCheck(ctx)
-	if err != nil {
+	r.APIHealth.Record(err)
+	r.recordHealthCheck(log, primaryHost, err, maintenance)
+	wg.Wait()
+}
+
+// recordHealthCheck logs one endpoint's health check result and updates its gauge
+func (r *JIRASyncReconciler) recordHealthCheck(log logr.Logger, host string, err error, maintenance bool) {
+	log = log.WithValues("host", host)
+	if err != nil && maintenance {
+		// The outage is planned; keep the log quiet until maintenance ends
+		log.V(1).Info("API direct health check failed during maintenance", "error", err.Error())
+		r.apiHealthStatus.WithLabelValues(host).Set(0) // Unhealthy
+	} else if err != nil {
 		log.Error(err, "API direct health check failed")
-		r.apiHealthStatus.WithLabelValues(r.APIHost).Set(0) // Unhealthy
+		r.apiHealthStatus.WithLabelValues(host).Set(0) // Unhealthy
 	} else {
 		log.V(1).Info("API direct health check passed - circuit breaker reset if needed")
-		r.apiHealthStatus.WithLabelValues(r.APIHost).Set(1) // Healthy
+		r.apiHealthStatus.WithLabelValues(host).Set(1) // Healthy
+	}
+}
+
+// healthCheckInterval returns the configured health check period
+func (r *JIRASyncReconciler) healthCheckInterval() time.Duration {
+	if r.HealthCheckInterval > 0 {
+		return r.HealthCheckInterval
 	}
+	return DefaultHealthCheckInterval
 }
 
 // StartHealthCheckRoutine starts a background goroutine for periodic health checks
 func (r *JIRASyncReconciler) StartHealthCheckRoutine(ctx context.Context) {
-	ticker := time.NewTicker(30 * time.Second) // Health check every 30 seconds
+	ticker := time.NewTicker(r.healthCheckInterval())
 	go func() {
 		defer ticker.Stop()
 		for {
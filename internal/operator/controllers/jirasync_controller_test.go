@@ -230,7 +230,7 @@ func TestJIRASyncReconciler_HandlePending(t *testing.T) {
 
 	result, err := reconciler.Reconcile(context.TODO(), req)
 	assert.NoError(t, err)
-	assert.False(t, result.Requeue)
+	assert.True(t, result.Requeue, "status writes are filtered from the watch, so the running sync requeues itself")
 
 	// Verify API sync was triggered and status updated
 	var updated operatortypes.JIRASync
@@ -338,7 +338,7 @@ func TestJIRASyncReconciler_HandleRunning_JobFailed(t *testing.T) {
 
 	result, err := reconciler.Reconcile(context.TODO(), req)
 	assert.NoError(t, err)
-	assert.False(t, result.Requeue)
+	assert.True(t, result.Requeue, "failed syncs requeue so the retry policy is applied")
 
 	// Verify status was updated to failed
 	var updated operatortypes.JIRASync
@@ -538,6 +538,62 @@ func TestJIRASyncReconciler_ValidateSyncSpec(t *testing.T) {
 			wantErr: true,
 			errMsg:  "jqlQuery required for jql sync type",
 		},
+		{
+			name: "valid job metadata",
+			spec: operatortypes.JIRASyncSpec{
+				SyncType:    "jql",
+				Target:      operatortypes.SyncTarget{JQLQuery: "project = TEST"},
+				Destination: operatortypes.GitDestination{Repository: "https://github.com/test/repo.git"},
+				JobLabels:   map[string]string{"cost-center": "eng-42", "example.com/team": "platform"},
+				JobAnnotations: map[string]string{
+					"example.com/owner": "Platform Team <platform@example.com>",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid job label value",
+			spec: operatortypes.JIRASyncSpec{
+				SyncType:    "jql",
+				Target:      operatortypes.SyncTarget{JQLQuery: "project = TEST"},
+				Destination: operatortypes.GitDestination{Repository: "https://github.com/test/repo.git"},
+				JobLabels:   map[string]string{"team": "not a valid value"},
+			},
+			wantErr: true,
+			errMsg:  "invalid jobLabels value",
+		},
+		{
+			name: "invalid job annotation key",
+			spec: operatortypes.JIRASyncSpec{
+				SyncType:       "jql",
+				Target:         operatortypes.SyncTarget{JQLQuery: "project = TEST"},
+				Destination:    operatortypes.GitDestination{Repository: "https://github.com/test/repo.git"},
+				JobAnnotations: map[string]string{"bad key!": "x"},
+			},
+			wantErr: true,
+			errMsg:  "invalid jobAnnotations key",
+		},
+		{
+			name: "valid checkpoint",
+			spec: operatortypes.JIRASyncSpec{
+				SyncType:    "jql",
+				Target:      operatortypes.SyncTarget{JQLQuery: "project = TEST"},
+				Destination: operatortypes.GitDestination{Repository: "https://github.com/test/repo.git"},
+				Checkpoint:  &operatortypes.CheckpointSpec{Interval: 100},
+			},
+			wantErr: false,
+		},
+		{
+			name: "checkpoint interval out of range",
+			spec: operatortypes.JIRASyncSpec{
+				SyncType:    "jql",
+				Target:      operatortypes.SyncTarget{JQLQuery: "project = TEST"},
+				Destination: operatortypes.GitDestination{Repository: "https://github.com/test/repo.git"},
+				Checkpoint:  &operatortypes.CheckpointSpec{Interval: 0},
+			},
+			wantErr: true,
+			errMsg:  "checkpoint.interval must be between 1 and 10000",
+		},
 	}
 
 	for _, tt := range tests {
@@ -609,6 +665,22 @@ func TestJIRASyncReconciler_BuildSyncArgs(t *testing.T) {
 			},
 			expected: []string{"sync", "--incremental", "--project", "TEST", "--repo", "/path/to/repo"},
 		},
+		{
+			name: "checkpointed sync",
+			jiraSync: &operatortypes.JIRASync{
+				Spec: operatortypes.JIRASyncSpec{
+					SyncType: "jql",
+					Target: operatortypes.SyncTarget{
+						JQLQuery: "project = TEST",
+					},
+					Destination: operatortypes.GitDestination{
+						Repository: "/path/to/repo",
+					},
+					Checkpoint: &operatortypes.CheckpointSpec{Interval: 500},
+				},
+			},
+			expected: []string{"sync", "--jql", "project = TEST", "--repo", "/path/to/repo", "--commit-strategy", "batch", "--checkpoint-interval", "500"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -645,3 +717,48 @@ func TestJIRASyncReconciler_RetryLogic(t *testing.T) {
 	assert.NotContains(t, jiraSync.Annotations, LastErrorAnnotation)
 	assert.NotContains(t, jiraSync.Annotations, RetryCountAnnotation)
 }
+
+func TestJIRASyncReconciler_InitMetricsTwice(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first := &JIRASyncReconciler{Log: ctrl.Log.WithName("test")}
+	second := &JIRASyncReconciler{Log: ctrl.Log.WithName("test")}
+
+	require.NotPanics(t, func() {
+		first.initMetrics(registry)
+		second.initMetrics(registry)
+	})
+
+	// Both reconcilers record into the one registered metric
+	first.reconcileCounter.WithLabelValues("default", "sync", "success").Inc()
+	second.reconcileCounter.WithLabelValues("default", "sync", "success").Inc()
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	var total float64
+	for _, family := range families {
+		if family.GetName() == "jirasync_reconcile_total" {
+			for _, metric := range family.GetMetric() {
+				total += metric.GetCounter().GetValue()
+			}
+		}
+	}
+	assert.Equal(t, float64(2), total)
+}
+
+func TestJIRASyncReconciler_InitMetricsConflict(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	// A different metric under one of the reconciler's names
+	registry.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "jirasync_reconcile_total", Help: "other"}))
+
+	reconciler := &JIRASyncReconciler{Log: ctrl.Log.WithName("test")}
+	require.NotPanics(t, func() {
+		reconciler.initMetrics(registry)
+		reconciler.reconcileCounter.WithLabelValues("default", "sync", "success").Inc()
+	})
+
+	// Without a registerer the metrics work but are not exported
+	unregistered := &JIRASyncReconciler{Log: ctrl.Log.WithName("test")}
+	require.NotPanics(t, func() {
+		unregistered.initMetrics(nil)
+		unregistered.progressGauge.WithLabelValues("default", "sync", "fetch").Set(50)
+	})
+}
@@ -0,0 +1,253 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+// Maintenance mode condition and reasons
+const (
+	ConditionTypeMaintenanceMode = "MaintenanceMode"
+
+	ReasonMaintenanceInProgress = "MaintenanceInProgress"
+	ReasonMaintenanceEnded      = "MaintenanceEnded"
+)
+
+// Keys of the maintenance ConfigMap
+const (
+	// MaintenanceEnabledKey turns maintenance off while the ConfigMap stays in place ("false")
+	MaintenanceEnabledKey = "enabled"
+	// MaintenanceMessageKey is an optional note shown in the MaintenanceMode condition
+	MaintenanceMessageKey = "message"
+)
+
+const (
+	// DefaultMaintenanceRequeueInterval is how often held syncs re-check for the end of maintenance
+	DefaultMaintenanceRequeueInterval = 2 * time.Minute
+
+	// maintenanceCheckInterval bounds how often the maintenance ConfigMap is read, since every
+	// reconcile asks and the read bypasses the informer cache
+	maintenanceCheckInterval = 10 * time.Second
+)
+
+// MaintenanceMode decides whether the operator is in planned API server maintenance
+// Maintenance is on when forced by the --maintenance-mode flag, or while the maintenance
+// ConfigMap exists and its "enabled" key is not "false". Syncs are then held with a
+// MaintenanceMode condition instead of calling the API server, and resume when it clears.
+// A nil MaintenanceMode is never in maintenance.
+type MaintenanceMode struct {
+	// Forced keeps the operator in maintenance regardless of the ConfigMap
+	Forced bool
+
+	// ConfigMap is the well-known ConfigMap that switches maintenance on (empty name disables it)
+	ConfigMap types.NamespacedName
+
+	// RequeueInterval controls how often held syncs re-check
+	RequeueInterval time.Duration
+
+	reader client.Reader
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	active    bool
+	message   string
+	now       func() time.Time
+}
+
+// NewMaintenanceMode creates a maintenance switch reading the "namespace/name" ConfigMap through
+// reader (an empty configMap leaves only the forced flag)
+func NewMaintenanceMode(reader client.Reader, forced bool, configMap string) (*MaintenanceMode, error) {
+	m := &MaintenanceMode{
+		Forced:          forced,
+		RequeueInterval: DefaultMaintenanceRequeueInterval,
+		reader:          reader,
+		now:             time.Now,
+	}
+	if configMap == "" {
+		return m, nil
+	}
+
+	namespace, name, found := strings.Cut(configMap, "/")
+	if !found || namespace == "" || name == "" {
+		return nil, fmt.Errorf("maintenance ConfigMap %q must be namespace/name", configMap)
+	}
+	if problems := validation.IsDNS1123Label(namespace); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid maintenance ConfigMap namespace %q: %s", namespace, strings.Join(problems, "; "))
+	}
+	if problems := validation.IsDNS1123Subdomain(name); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid maintenance ConfigMap name %q: %s", name, strings.Join(problems, "; "))
+	}
+	m.ConfigMap = types.NamespacedName{Namespace: namespace, Name: name}
+	return m, nil
+}
+
+// Active reports whether maintenance is on, with a message for conditions and events
+// When the ConfigMap cannot be read the last known state is kept and the error returned.
+func (m *MaintenanceMode) Active(ctx context.Context) (bool, string, error) {
+	if m == nil {
+		return false, "", nil
+	}
+	if m.Forced {
+		return true, "Operator started with --maintenance-mode", nil
+	}
+	if m.ConfigMap.Name == "" || m.reader == nil {
+		return false, "", nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock()
+	if !m.checkedAt.IsZero() && now.Sub(m.checkedAt) < maintenanceCheckInterval {
+		return m.active, m.message, nil
+	}
+
+	var configMap corev1.ConfigMap
+	err := m.reader.Get(ctx, m.ConfigMap, &configMap)
+	switch {
+	case apierrors.IsNotFound(err):
+		m.active, m.message = false, ""
+	case err != nil:
+		return m.active, m.message, fmt.Errorf("failed to read maintenance ConfigMap %s: %w", m.ConfigMap, err)
+	default:
+		m.active, m.message = maintenanceFromConfigMap(&configMap)
+	}
+	m.checkedAt = now
+	return m.active, m.message, nil
+}
+
+// maintenanceFromConfigMap reads the maintenance state from an existing ConfigMap
+func maintenanceFromConfigMap(configMap *corev1.ConfigMap) (bool, string) {
+	if value, exists := configMap.Data[MaintenanceEnabledKey]; exists {
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil && !enabled {
+			return false, ""
+		}
+	}
+
+	message := fmt.Sprintf("API server maintenance in progress (ConfigMap %s/%s)", configMap.Namespace, configMap.Name)
+	if note := strings.TrimSpace(configMap.Data[MaintenanceMessageKey]); note != "" {
+		message += ": " + note
+	}
+	return true, message
+}
+
+func (m *MaintenanceMode) requeueInterval() time.Duration {
+	if m.RequeueInterval > 0 {
+		return m.RequeueInterval
+	}
+	return DefaultMaintenanceRequeueInterval
+}
+
+func (m *MaintenanceMode) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// heldDuringMaintenance reports whether a phase talks to the API server and so waits out maintenance
+// Completed resources only schedule their next run, which is held once it is Pending.
+func heldDuringMaintenance(phase string) bool {
+	switch phase {
+	case "", PhasePending, PhaseRunning, PhaseFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// holdForMaintenance keeps a resource where it is while maintenance is on, and clears its
+// MaintenanceMode condition once maintenance ends
+// Returns true with the requeue to use when the reconcile should stop here.
+func (r *JIRASyncReconciler) holdForMaintenance(ctx context.Context, jiraSync *operatortypes.JIRASync) (bool, ctrl.Result) {
+	log := r.Log.WithValues("jirasync", client.ObjectKeyFromObject(jiraSync))
+
+	active, message, err := r.Maintenance.Active(ctx)
+	if err != nil {
+		log.Error(err, "Failed to check maintenance mode, keeping the last known state")
+	}
+
+	if active && heldDuringMaintenance(jiraSync.Status.Phase) {
+		if setMaintenanceCondition(jiraSync, true, message) {
+			log.Info("Maintenance mode on, holding sync", "phase", jiraSync.Status.Phase, "reason", message)
+			r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeNormal, ReasonMaintenanceInProgress, message)
+			if err := r.Status().Update(ctx, jiraSync); err != nil {
+				log.Error(err, "Failed to update MaintenanceMode condition")
+			}
+			r.updateStatusMetrics(jiraSync)
+		}
+		return true, ctrl.Result{RequeueAfter: r.Maintenance.requeueInterval()}
+	}
+
+	if !active && setMaintenanceCondition(jiraSync, false, "") {
+		log.Info("Maintenance mode ended, resuming sync", "phase", jiraSync.Status.Phase)
+		r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeNormal, ReasonMaintenanceEnded, "Maintenance mode ended, resuming sync")
+		if err := r.Status().Update(ctx, jiraSync); err != nil {
+			log.Error(err, "Failed to update MaintenanceMode condition")
+			return true, ctrl.Result{RequeueAfter: 10 * time.Second}
+		}
+	}
+	return false, ctrl.Result{}
+}
+
+// setMaintenanceCondition records maintenance mode on the resource, keeping the original
+// transition time while maintenance lasts so repeated reconciles do not churn status
+// Returns true when the condition changed and needs to be written.
+func setMaintenanceCondition(jiraSync *operatortypes.JIRASync, active bool, message string) bool {
+	status := metav1.ConditionTrue
+	reason := ReasonMaintenanceInProgress
+	if !active {
+		status = metav1.ConditionFalse
+		reason = ReasonMaintenanceEnded
+		message = "Maintenance mode ended"
+	}
+
+	for i, existing := range jiraSync.Status.Conditions {
+		if existing.Type != ConditionTypeMaintenanceMode {
+			continue
+		}
+		if existing.Status == status && existing.Message == message {
+			return false
+		}
+		transition := metav1.Now()
+		if existing.Status == status {
+			transition = existing.LastTransitionTime
+		}
+		jiraSync.Status.Conditions[i] = metav1.Condition{
+			Type:               ConditionTypeMaintenanceMode,
+			Status:             status,
+			LastTransitionTime: transition,
+			Reason:             reason,
+			Message:            message,
+		}
+		return true
+	}
+
+	// Resources that were never held do not need a condition
+	if !active {
+		return false
+	}
+
+	jiraSync.Status.Conditions = append(jiraSync.Status.Conditions, metav1.Condition{
+		Type:               ConditionTypeMaintenanceMode,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
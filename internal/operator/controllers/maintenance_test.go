@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+func TestNewMaintenanceMode(t *testing.T) {
+	m, err := NewMaintenanceMode(nil, false, "jira-sync/jira-sync-maintenance")
+	require.NoError(t, err)
+	assert.Equal(t, "jira-sync", m.ConfigMap.Namespace)
+	assert.Equal(t, "jira-sync-maintenance", m.ConfigMap.Name)
+
+	m, err = NewMaintenanceMode(nil, false, "")
+	require.NoError(t, err)
+	active, _, err := m.Active(context.TODO())
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	for _, invalid := range []string{"jira-sync-maintenance", "/name", "ns/", "Bad_NS/name"} {
+		_, err := NewMaintenanceMode(nil, false, invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	var disabled *MaintenanceMode
+	active, _, err = disabled.Active(context.TODO())
+	require.NoError(t, err)
+	assert.False(t, active, "nil maintenance mode is never active")
+
+	forced, err := NewMaintenanceMode(nil, true, "")
+	require.NoError(t, err)
+	active, message, err := forced.Active(context.TODO())
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Contains(t, message, "--maintenance-mode")
+}
+
+func TestMaintenanceMode_ConfigMap(t *testing.T) {
+	_, fakeClient := setupTestReconciler()
+	m, err := NewMaintenanceMode(fakeClient, false, "ops/jira-sync-maintenance")
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	active, _, err := m.Active(context.TODO())
+	require.NoError(t, err)
+	assert.False(t, active, "no ConfigMap, no maintenance")
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "jira-sync-maintenance", Namespace: "ops"},
+		Data:       map[string]string{MaintenanceMessageKey: "database upgrade until 14:00"},
+	}
+	require.NoError(t, fakeClient.Create(context.TODO(), configMap))
+
+	active, _, err = m.Active(context.TODO())
+	require.NoError(t, err)
+	assert.False(t, active, "the result is cached between checks")
+
+	now = now.Add(maintenanceCheckInterval)
+	active, message, err := m.Active(context.TODO())
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Contains(t, message, "ops/jira-sync-maintenance")
+	assert.Contains(t, message, "database upgrade until 14:00")
+
+	configMap.Data[MaintenanceEnabledKey] = "false"
+	require.NoError(t, fakeClient.Update(context.TODO(), configMap))
+	now = now.Add(maintenanceCheckInterval)
+	active, _, err = m.Active(context.TODO())
+	require.NoError(t, err)
+	assert.False(t, active, "enabled=false switches maintenance off")
+}
+
+func TestSetMaintenanceCondition(t *testing.T) {
+	jiraSync := createTestJIRASync("maintenance", "default")
+
+	assert.False(t, setMaintenanceCondition(jiraSync, false, ""), "no condition needed outside maintenance")
+	assert.Empty(t, jiraSync.Status.Conditions)
+
+	assert.True(t, setMaintenanceCondition(jiraSync, true, "upgrade"))
+	assert.False(t, setMaintenanceCondition(jiraSync, true, "upgrade"))
+	condition := findCondition(jiraSync, ConditionTypeMaintenanceMode)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonMaintenanceInProgress, condition.Reason)
+
+	assert.True(t, setMaintenanceCondition(jiraSync, false, ""))
+	condition = findCondition(jiraSync, ConditionTypeMaintenanceMode)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonMaintenanceEnded, condition.Reason)
+	assert.Len(t, jiraSync.Status.Conditions, 1)
+}
+
+func TestJIRASyncReconciler_MaintenanceModeHoldsSyncs(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	reconciler.Maintenance = &MaintenanceMode{Forced: true}
+	recorder := reconciler.StatusManager.recorder.(*record.FakeRecorder)
+	mockAPIClient := reconciler.APIClient.(*apiclient.MockAPIClient)
+
+	pending := createPhasedJIRASync("pending", PhasePending, "")
+	require.NoError(t, fakeClient.Create(context.TODO(), pending))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pending)}
+
+	for i := 0; i < 3; i++ {
+		result, err := reconciler.Reconcile(context.TODO(), req)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultMaintenanceRequeueInterval, result.RequeueAfter)
+	}
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhasePending, updated.Status.Phase, "sync is held, not failed")
+	assert.Empty(t, mockAPIClient.TriggerSingleSyncCalls)
+	condition := findCondition(&updated, ConditionTypeMaintenanceMode)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	events := 0
+	for _, event := range drainEvents(recorder) {
+		if strings.Contains(event, ReasonMaintenanceInProgress) {
+			events++
+		}
+	}
+	assert.Equal(t, 1, events, "event is only emitted when the condition changes")
+
+	// Once maintenance clears the sync is triggered and the condition flips back
+	reconciler.Maintenance.Forced = false
+	_, err := reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhaseRunning, updated.Status.Phase)
+	assert.Len(t, mockAPIClient.TriggerSingleSyncCalls, 1)
+	condition = findCondition(&updated, ConditionTypeMaintenanceMode)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonMaintenanceEnded, condition.Reason)
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// ParseWatchNamespaces parses a comma-separated list of namespaces to watch
+// An empty list means all namespaces. Duplicates are dropped and the result is sorted.
+func ParseWatchNamespaces(value string) ([]string, error) {
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, namespace := range strings.Split(value, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" || seen[namespace] {
+			continue
+		}
+		if problems := validation.IsDNS1123Label(namespace); len(problems) > 0 {
+			return nil, fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(problems, "; "))
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// WatchNamespacesCacheOptions restricts the manager's cache, and so every watch and cached
+// read of the controllers, to the given namespaces; no namespaces keeps the cluster-wide cache
+func WatchNamespacesCacheOptions(namespaces []string) cache.Options {
+	if len(namespaces) == 0 {
+		return cache.Options{}
+	}
+	defaults := make(map[string]cache.Config, len(namespaces))
+	for _, namespace := range namespaces {
+		defaults[namespace] = cache.Config{}
+	}
+	return cache.Options{DefaultNamespaces: defaults}
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWatchNamespaces(t *testing.T) {
+	namespaces, err := ParseWatchNamespaces("")
+	require.NoError(t, err)
+	assert.Empty(t, namespaces, "empty means all namespaces")
+
+	namespaces, err = ParseWatchNamespaces(" team-b, team-a,,team-b ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "team-b"}, namespaces)
+
+	_, err = ParseWatchNamespaces("team-a,Team_B")
+	assert.ErrorContains(t, err, "Team_B")
+}
+
+func TestWatchNamespacesCacheOptions(t *testing.T) {
+	assert.Nil(t, WatchNamespacesCacheOptions(nil).DefaultNamespaces, "all namespaces by default")
+
+	options := WatchNamespacesCacheOptions([]string{"team-a", "team-b"})
+	assert.Len(t, options.DefaultNamespaces, 2)
+	assert.Contains(t, options.DefaultNamespaces, "team-a")
+	assert.Contains(t, options.DefaultNamespaces, "team-b")
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ignoreStatusOnlyUpdates filters out JIRASync updates that only changed status
+// The reconciler writes status on every phase change; without this filter each write triggers
+// another reconcile. Updates pass when the generation (spec), labels, annotations, finalizers or
+// deletion timestamp changed. Create, delete and generic events always pass. Phase transitions
+// that need another pass requeue explicitly instead of relying on their own status write.
+func ignoreStatusOnlyUpdates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return true
+			}
+			oldObj, newObj := e.ObjectOld, e.ObjectNew
+
+			if oldObj.GetGeneration() != newObj.GetGeneration() {
+				return true
+			}
+			if !oldObj.GetDeletionTimestamp().Equal(newObj.GetDeletionTimestamp()) {
+				return true
+			}
+			return !stringMapsEqual(oldObj.GetLabels(), newObj.GetLabels()) ||
+				!stringMapsEqual(oldObj.GetAnnotations(), newObj.GetAnnotations()) ||
+				!stringSlicesEqual(oldObj.GetFinalizers(), newObj.GetFinalizers())
+		},
+	}
+}
+
+// stringMapsEqual compares two maps, treating nil and empty as equal
+func stringMapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, exists := b[key]; !exists || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// stringSlicesEqual compares two slices element by element, treating nil and empty as equal
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+func TestIgnoreStatusOnlyUpdates(t *testing.T) {
+	pred := ignoreStatusOnlyUpdates()
+	base := createPhasedJIRASync("predicate", PhasePending, "")
+	base.Generation = 1
+
+	tests := []struct {
+		name   string
+		mutate func(*operatortypes.JIRASync)
+		expect bool
+	}{
+		{"status only", func(s *operatortypes.JIRASync) { s.Status.Phase = PhaseRunning }, false},
+		{"no change", func(s *operatortypes.JIRASync) {}, false},
+		{"spec change", func(s *operatortypes.JIRASync) {
+			s.Spec.Target.JQLQuery = "project = OTHER"
+			s.Generation = 2
+		}, true},
+		{"annotation change", func(s *operatortypes.JIRASync) {
+			s.Annotations = map[string]string{RetryCountAnnotation: "1"}
+		}, true},
+		{"label change", func(s *operatortypes.JIRASync) { s.Labels = map[string]string{"team": "a"} }, true},
+		{"finalizer removed", func(s *operatortypes.JIRASync) { s.Finalizers = nil }, true},
+		{"deletion requested", func(s *operatortypes.JIRASync) {
+			now := metav1.Now()
+			s.DeletionTimestamp = &now
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := base.DeepCopy()
+			tt.mutate(updated)
+			assert.Equal(t, tt.expect, pred.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: updated}))
+		})
+	}
+
+	assert.True(t, pred.Create(event.CreateEvent{Object: base}))
+	assert.True(t, pred.Delete(event.DeleteEvent{Object: base}))
+}
+
+// reconcileUntilIdle simulates the watch: after each reconcile, the resulting update triggers
+// another reconcile only when the predicate lets it through. Requeues are not followed.
+func reconcileUntilIdle(t *testing.T, reconciler *JIRASyncReconciler, c client.Client, req reconcile.Request, pred predicate.Predicate) {
+	for i := 0; i < 10; i++ {
+		var before operatortypes.JIRASync
+		require.NoError(t, c.Get(context.TODO(), req.NamespacedName, &before))
+
+		_, err := reconciler.Reconcile(context.TODO(), req)
+		require.NoError(t, err)
+
+		var after operatortypes.JIRASync
+		require.NoError(t, c.Get(context.TODO(), req.NamespacedName, &after))
+		if before.ResourceVersion == after.ResourceVersion || !pred.Update(event.UpdateEvent{ObjectOld: &before, ObjectNew: &after}) {
+			return
+		}
+	}
+}
+
+func TestIgnoreStatusOnlyUpdates_ReconcileCount(t *testing.T) {
+	run := func(pred predicate.Predicate) float64 {
+		reconciler, fakeClient := setupTestReconciler()
+		jiraSync := createPhasedJIRASync("churn", PhasePending, "")
+		require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)}
+
+		reconcileUntilIdle(t, reconciler, fakeClient, req, pred)
+		return testutil.ToFloat64(reconciler.reconcileCounter.WithLabelValues("default", "churn", "success"))
+	}
+
+	// Without filtering, the Pending -> Running status write triggers a second reconcile
+	assert.Greater(t, run(predicate.Funcs{}), 1.0)
+	// With filtering, only the reconcile that made the change is counted
+	assert.Equal(t, 1.0, run(ignoreStatusOnlyUpdates()))
+}
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+// Result artifact settings
+const (
+	DefaultResultArtifactHistory  = 10
+	MaxResultArtifactHistory      = 50
+	ResultArtifactConfigMapSuffix = "-sync-results"
+
+	// maxArtifactErrors bounds the per-issue errors kept in one summary, so ConfigMaps stay small
+	maxArtifactErrors = 100
+
+	// resultArtifactKeyPrefix marks summary keys; keys sort chronologically by their timestamp
+	resultArtifactKeyPrefix = "run-"
+)
+
+// Event reasons for result artifacts
+const (
+	ReasonResultArtifactStored = "ResultArtifactStored"
+	ReasonResultArtifactFailed = "ResultArtifactFailed"
+)
+
+// artifactKeyInvalidChars matches characters not allowed in ConfigMap data keys
+var artifactKeyInvalidChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// SyncResultSummary is the JSON artifact recorded for each finished sync run
+type SyncResultSummary struct {
+	JIRASync        string                       `json:"jiraSync"`
+	Namespace       string                       `json:"namespace"`
+	Generation      int64                        `json:"generation"`
+	JobID           string                       `json:"jobId"`
+	Outcome         string                       `json:"outcome"` // completed or failed
+	Message         string                       `json:"message,omitempty"`
+	SyncType        string                       `json:"syncType"`
+	Target          operatortypes.SyncTarget     `json:"target"`
+	Destination     operatortypes.GitDestination `json:"destination"`
+	ConfigHash      string                       `json:"configHash,omitempty"`
+	StartTime       *time.Time                   `json:"startTime,omitempty"`
+	EndTime         *time.Time                   `json:"endTime,omitempty"`
+	Duration        string                       `json:"duration,omitempty"`
+	TotalIssues     int                          `json:"totalIssues"`
+	ProcessedIssues int                          `json:"processedIssues"`
+	SuccessfulSync  int                          `json:"successfulSync"`
+	FailedSync      int                          `json:"failedSync"`
+	Errors          []apiclient.JobError         `json:"errors,omitempty"`
+	ErrorsOmitted   int                          `json:"errorsOmitted,omitempty"`
+	Results         map[string]string            `json:"results,omitempty"`
+	RecordedAt      time.Time                    `json:"recordedAt"`
+}
+
+// resultArtifactConfigMapName returns the ConfigMap that stores a sync's result summaries
+func resultArtifactConfigMapName(jiraSync *operatortypes.JIRASync) string {
+	if spec := jiraSync.Spec.ResultArtifact; spec != nil && spec.ConfigMapName != "" {
+		return spec.ConfigMapName
+	}
+	return jiraSync.Name + ResultArtifactConfigMapSuffix
+}
+
+// validateResultArtifact checks the resultArtifact settings of a spec
+func validateResultArtifact(spec *operatortypes.ResultArtifactSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if spec.History < 0 || spec.History > MaxResultArtifactHistory {
+		return fmt.Errorf("resultArtifact.history must be between 0 and %d", MaxResultArtifactHistory)
+	}
+	if spec.ConfigMapName != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.ConfigMapName); len(errs) > 0 {
+			return fmt.Errorf("invalid resultArtifact.configMapName %q: %s", spec.ConfigMapName, errs[0])
+		}
+	}
+	return nil
+}
+
+// buildSyncResultSummary describes a finished API job for the result artifact
+func buildSyncResultSummary(jiraSync *operatortypes.JIRASync, jobStatus *apiclient.JobStatusResponse, now time.Time) *SyncResultSummary {
+	summary := &SyncResultSummary{
+		JIRASync:        jiraSync.Name,
+		Namespace:       jiraSync.Namespace,
+		Generation:      jiraSync.Generation,
+		JobID:           jobStatus.JobID,
+		Outcome:         jobStatus.Status,
+		Message:         jobStatus.Message,
+		SyncType:        jiraSync.Spec.SyncType,
+		Target:          jiraSync.Spec.Target,
+		Destination:     jiraSync.Spec.Destination,
+		StartTime:       jobStatus.StartTime,
+		EndTime:         jobStatus.EndTime,
+		Duration:        jobStatus.Duration,
+		TotalIssues:     jobStatus.TotalIssues,
+		ProcessedIssues: jobStatus.ProcessedIssues,
+		SuccessfulSync:  jobStatus.SuccessfulSync,
+		FailedSync:      jobStatus.FailedSync,
+		Errors:          jobStatus.Errors,
+		Results:         jobStatus.Results,
+		RecordedAt:      now.UTC(),
+	}
+	if jiraSync.Status.SyncState != nil {
+		summary.ConfigHash = jiraSync.Status.SyncState.ConfigHash
+	}
+	if summary.Duration == "" && jobStatus.StartTime != nil && jobStatus.EndTime != nil {
+		summary.Duration = jobStatus.EndTime.Sub(*jobStatus.StartTime).String()
+	}
+	if len(summary.Errors) > maxArtifactErrors {
+		summary.ErrorsOmitted = len(summary.Errors) - maxArtifactErrors
+		summary.Errors = summary.Errors[:maxArtifactErrors]
+	}
+	return summary
+}
+
+// resultArtifactKey names the ConfigMap key of one run summary
+func resultArtifactKey(jobID string, recordedAt time.Time) string {
+	return fmt.Sprintf("%s%s-%s.json", resultArtifactKeyPrefix,
+		recordedAt.UTC().Format("20060102T150405Z"), artifactKeyInvalidChars.ReplaceAllString(jobID, "_"))
+}
+
+// storeResultArtifact records the summary of a finished API job when spec.resultArtifact is set
+// The summary is written to the configured ConfigMap (created and owned by the JIRASync if missing)
+// and referenced from status.resultArtifact. Storage problems are reported as a Warning event and
+// never change the outcome of the sync.
+func (r *JIRASyncReconciler) storeResultArtifact(ctx context.Context, jiraSync *operatortypes.JIRASync, jobStatus *apiclient.JobStatusResponse) {
+	spec := jiraSync.Spec.ResultArtifact
+	if spec == nil {
+		return
+	}
+	log := r.Log.WithValues("jirasync", client.ObjectKeyFromObject(jiraSync))
+
+	now := time.Now()
+	summary := buildSyncResultSummary(jiraSync, jobStatus, now)
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		r.reportResultArtifactFailure(jiraSync, fmt.Errorf("failed to encode result summary: %w", err))
+		return
+	}
+
+	name := resultArtifactConfigMapName(jiraSync)
+	key := resultArtifactKey(jobStatus.JobID, now)
+	history := spec.History
+	if history == 0 {
+		history = DefaultResultArtifactHistory
+	}
+
+	if err := r.writeResultConfigMap(ctx, jiraSync, name, key, string(data), history); err != nil {
+		log.Error(err, "Failed to store sync result artifact", "configMap", name)
+		r.reportResultArtifactFailure(jiraSync, err)
+		return
+	}
+
+	uploadedAt := metav1.NewTime(now)
+	jiraSync.Status.ResultArtifact = &operatortypes.ArtifactReference{
+		Kind:       "ConfigMap",
+		Name:       name,
+		Namespace:  jiraSync.Namespace,
+		Key:        key,
+		JobID:      jobStatus.JobID,
+		UploadedAt: &uploadedAt,
+	}
+	r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeNormal, ReasonResultArtifactStored,
+		fmt.Sprintf("Sync result stored in ConfigMap %s (key %s)", name, key))
+}
+
+// writeResultConfigMap adds a summary to the ConfigMap and removes summaries beyond the history limit
+func (r *JIRASyncReconciler) writeResultConfigMap(ctx context.Context, jiraSync *operatortypes.JIRASync, name, key, data string, history int) error {
+	var configMap corev1.ConfigMap
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: jiraSync.Namespace}, &configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: jiraSync.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "jira-sync-operator",
+					"sync.jira.io/jirasync":        jiraSync.Name,
+				},
+			},
+			Data: map[string]string{key: data},
+		}
+		pruneResultArtifacts(configMap.Data, history)
+		if err := controllerutil.SetOwnerReference(jiraSync, &configMap, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner of result ConfigMap: %w", err)
+		}
+		if err := r.Create(ctx, &configMap); err != nil {
+			return fmt.Errorf("failed to create result ConfigMap %s: %w", name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get result ConfigMap %s: %w", name, err)
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[key] = data
+	pruneResultArtifacts(configMap.Data, history)
+	if err := r.Update(ctx, &configMap); err != nil {
+		return fmt.Errorf("failed to update result ConfigMap %s: %w", name, err)
+	}
+	return nil
+}
+
+// pruneResultArtifacts keeps the newest history run summaries; other keys are left untouched
+func pruneResultArtifacts(data map[string]string, history int) {
+	var keys []string
+	for key := range data {
+		if strings.HasPrefix(key, resultArtifactKeyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) <= history {
+		return
+	}
+	sort.Strings(keys)
+	for _, key := range keys[:len(keys)-history] {
+		delete(data, key)
+	}
+}
+
+// reportResultArtifactFailure emits a Warning event for a summary that could not be stored
+func (r *JIRASyncReconciler) reportResultArtifactFailure(jiraSync *operatortypes.JIRASync, err error) {
+	r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeWarning, ReasonResultArtifactFailed,
+		fmt.Sprintf("Failed to store sync result: %v", err))
+}
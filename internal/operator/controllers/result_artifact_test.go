@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+func TestResultArtifact_StoredOnCompletion(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	recorder := reconciler.StatusManager.recorder.(*record.FakeRecorder)
+	mockAPI := reconciler.APIClient.(*apiclient.MockAPIClient)
+	mockAPI.GetJobStatusFunc = func(ctx context.Context, jobID string) (*apiclient.JobStatusResponse, error) {
+		return &apiclient.JobStatusResponse{
+			JobID:           jobID,
+			Status:          "completed",
+			Progress:        100,
+			TotalIssues:     3,
+			ProcessedIssues: 3,
+			SuccessfulSync:  2,
+			FailedSync:      1,
+			Errors:          []apiclient.JobError{{IssueKey: "TEST-3", Step: "fetch", Message: "not found"}},
+		}, nil
+	}
+
+	jiraSync := createPhasedJIRASync("artifact", PhaseRunning, "")
+	jiraSync.Spec.ResultArtifact = &operatortypes.ResultArtifactSpec{}
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+
+	_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)})
+	require.NoError(t, err)
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(jiraSync), &updated))
+	assert.Equal(t, PhaseCompleted, updated.Status.Phase)
+	require.NotNil(t, updated.Status.ResultArtifact)
+	ref := updated.Status.ResultArtifact
+	assert.Equal(t, "ConfigMap", ref.Kind)
+	assert.Equal(t, "artifact-sync-results", ref.Name)
+	assert.Equal(t, "artifact-job", ref.JobID)
+	assert.NotNil(t, ref.UploadedAt)
+
+	var configMap corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: "default"}, &configMap))
+	require.Len(t, configMap.OwnerReferences, 1)
+	assert.Equal(t, "artifact", configMap.OwnerReferences[0].Name)
+
+	var summary SyncResultSummary
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[ref.Key]), &summary))
+	assert.Equal(t, "completed", summary.Outcome)
+	assert.Equal(t, 3, summary.TotalIssues)
+	assert.Equal(t, 1, summary.FailedSync)
+	require.Len(t, summary.Errors, 1)
+	assert.Equal(t, "TEST-3", summary.Errors[0].IssueKey)
+
+	assert.Equal(t, 1, countEvents(recorder, ReasonResultArtifactStored))
+}
+
+func TestResultArtifact_DisabledByDefault(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+
+	jiraSync := createPhasedJIRASync("no-artifact", PhaseRunning, "")
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+
+	_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)})
+	require.NoError(t, err)
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(jiraSync), &updated))
+	assert.Equal(t, PhaseCompleted, updated.Status.Phase)
+	assert.Nil(t, updated.Status.ResultArtifact)
+
+	var configMaps corev1.ConfigMapList
+	require.NoError(t, fakeClient.List(context.TODO(), &configMaps))
+	assert.Empty(t, configMaps.Items)
+}
+
+func TestResultArtifact_HistoryPruning(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+
+	jiraSync := createPhasedJIRASync("history", PhaseRunning, "")
+	jiraSync.Spec.ResultArtifact = &operatortypes.ResultArtifactSpec{ConfigMapName: "shared-results", History: 2}
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+
+	// Existing ConfigMap with older runs and an unrelated key
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-results", Namespace: "default"},
+		Data: map[string]string{
+			resultArtifactKey("job-1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)): "{}",
+			resultArtifactKey("job-2", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)): "{}",
+			"README": "kept",
+		},
+	}
+	require.NoError(t, fakeClient.Create(context.TODO(), existing))
+
+	for i := 0; i < 2; i++ {
+		reconciler.storeResultArtifact(context.TODO(), jiraSync, &apiclient.JobStatusResponse{
+			JobID: fmt.Sprintf("job/%d", i+3), Status: "failed",
+		})
+	}
+
+	var configMap corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: "shared-results", Namespace: "default"}, &configMap))
+	assert.Len(t, configMap.Data, 3)
+	assert.Equal(t, "kept", configMap.Data["README"])
+	for key := range configMap.Data {
+		assert.NotContains(t, key, "job-1")
+		assert.NotContains(t, key, "job-2")
+	}
+	require.NotNil(t, jiraSync.Status.ResultArtifact)
+	assert.True(t, strings.HasSuffix(jiraSync.Status.ResultArtifact.Key, "-job_4.json"))
+}
+
+func TestValidateResultArtifact(t *testing.T) {
+	assert.NoError(t, validateResultArtifact(nil))
+	assert.NoError(t, validateResultArtifact(&operatortypes.ResultArtifactSpec{}))
+	assert.NoError(t, validateResultArtifact(&operatortypes.ResultArtifactSpec{ConfigMapName: "sync-results", History: 50}))
+	assert.Error(t, validateResultArtifact(&operatortypes.ResultArtifactSpec{History: -1}))
+	assert.Error(t, validateResultArtifact(&operatortypes.ResultArtifactSpec{History: MaxResultArtifactHistory + 1}))
+	assert.Error(t, validateResultArtifact(&operatortypes.ResultArtifactSpec{ConfigMapName: "Not_Valid"}))
+}
+
+func TestBuildSyncResultSummary_TruncatesErrors(t *testing.T) {
+	jiraSync := createTestJIRASync("summary", "default")
+	errs := make([]apiclient.JobError, maxArtifactErrors+5)
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+
+	summary := buildSyncResultSummary(jiraSync, &apiclient.JobStatusResponse{
+		JobID: "job", Status: "failed", Errors: errs, StartTime: &start, EndTime: &end,
+	}, end)
+
+	assert.Len(t, summary.Errors, maxArtifactErrors)
+	assert.Equal(t, 5, summary.ErrorsOmitted)
+	assert.Equal(t, "1m30s", summary.Duration)
+}
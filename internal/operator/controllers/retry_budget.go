@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+// Retry budget condition and reasons
+const (
+	ConditionTypeRetryExhausted = "RetryExhausted"
+
+	ReasonRetryLimitReached  = "RetryLimitReached"
+	ReasonRetryTimeExhausted = "RetryTimeBudgetExhausted"
+	ReasonRetryBudgetReset   = "RetryBudgetReset"
+)
+
+// Retry budget annotations
+const (
+	// RetryStartAnnotation records when the current retry budget started (first failure, RFC3339)
+	RetryStartAnnotation = "sync.jira.io/retry-start"
+
+	// RetryGenerationAnnotation records the spec generation the current retry budget belongs to
+	RetryGenerationAnnotation = "sync.jira.io/retry-generation"
+)
+
+// MaxRetryDurationLimit is the largest accepted retryPolicy.maxRetryDuration (7 days, in seconds)
+const MaxRetryDurationLimit = 7 * 24 * 60 * 60
+
+// retryBudget is the evaluated retry state of a failed resource
+type retryBudget struct {
+	Attempts  int
+	Exhausted bool
+	Reason    string
+	Message   string
+}
+
+// evaluateRetryBudget checks the attempt limit and the optional time budget
+// The time budget is measured from the first failure recorded in RetryStartAnnotation.
+func (r *JIRASyncReconciler) evaluateRetryBudget(jiraSync *operatortypes.JIRASync, now time.Time) retryBudget {
+	policy := jiraSync.Spec.RetryPolicy
+	budget := retryBudget{Attempts: r.getRetryCount(jiraSync)}
+
+	if budget.Attempts >= policy.MaxRetries {
+		budget.Exhausted = true
+		budget.Reason = ReasonRetryLimitReached
+		budget.Message = fmt.Sprintf("Retry budget exhausted after %d of %d retries; sync will not be retried", budget.Attempts, policy.MaxRetries)
+		return budget
+	}
+
+	if policy.MaxRetryDuration > 0 {
+		if start, ok := retryStart(jiraSync); ok {
+			limit := time.Duration(policy.MaxRetryDuration) * time.Second
+			if elapsed := now.Sub(start); elapsed >= limit {
+				budget.Exhausted = true
+				budget.Reason = ReasonRetryTimeExhausted
+				budget.Message = fmt.Sprintf("Retry time budget of %s exhausted after %d retries; sync will not be retried", limit, budget.Attempts)
+			}
+		}
+	}
+
+	return budget
+}
+
+// startRetryBudget records the start of a retry budget for the current generation if none is running
+// Returns true when annotations changed.
+func startRetryBudget(jiraSync *operatortypes.JIRASync, now time.Time) bool {
+	if _, ok := retryStart(jiraSync); ok {
+		return false
+	}
+	if jiraSync.Annotations == nil {
+		jiraSync.Annotations = make(map[string]string)
+	}
+	jiraSync.Annotations[RetryStartAnnotation] = now.UTC().Format(time.RFC3339)
+	jiraSync.Annotations[RetryGenerationAnnotation] = strconv.FormatInt(jiraSync.Generation, 10)
+	return true
+}
+
+// resetRetryBudgetOnSpecChange starts a fresh budget when the spec changed since the budget began
+// With stopOnExhaustion an exhausted resource stays terminal even when its spec is edited.
+// Returns true when the budget was reset.
+func resetRetryBudgetOnSpecChange(jiraSync *operatortypes.JIRASync) bool {
+	generation, exists := jiraSync.Annotations[RetryGenerationAnnotation]
+	if !exists || generation == strconv.FormatInt(jiraSync.Generation, 10) {
+		return false
+	}
+	if jiraSync.Spec.RetryPolicy.StopOnExhaustion && isRetryExhausted(jiraSync) {
+		return false
+	}
+
+	delete(jiraSync.Annotations, RetryCountAnnotation)
+	delete(jiraSync.Annotations, RetryStartAnnotation)
+	delete(jiraSync.Annotations, RetryGenerationAnnotation)
+	return true
+}
+
+// retryStart returns when the current retry budget started
+func retryStart(jiraSync *operatortypes.JIRASync) (time.Time, bool) {
+	value, exists := jiraSync.Annotations[RetryStartAnnotation]
+	if !exists {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// isRetryExhausted reports whether the resource carries a terminal RetryExhausted condition
+func isRetryExhausted(jiraSync *operatortypes.JIRASync) bool {
+	for _, condition := range jiraSync.Status.Conditions {
+		if condition.Type == ConditionTypeRetryExhausted && condition.Status == metav1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// setRetryExhaustedCondition records whether the retry budget is exhausted, keeping the
+// original transition time so repeated reconciles of a terminal resource do not churn status
+// Returns true when the condition changed and needs to be written.
+func setRetryExhaustedCondition(jiraSync *operatortypes.JIRASync, exhausted bool, reason, message string) bool {
+	status := metav1.ConditionFalse
+	if exhausted {
+		status = metav1.ConditionTrue
+	}
+
+	for i, existing := range jiraSync.Status.Conditions {
+		if existing.Type != ConditionTypeRetryExhausted {
+			continue
+		}
+		if existing.Status == status && existing.Reason == reason {
+			return false
+		}
+		transition := metav1.Now()
+		if existing.Status == status {
+			transition = existing.LastTransitionTime
+		}
+		jiraSync.Status.Conditions[i] = metav1.Condition{
+			Type:               ConditionTypeRetryExhausted,
+			Status:             status,
+			LastTransitionTime: transition,
+			Reason:             reason,
+			Message:            message,
+		}
+		return true
+	}
+
+	// Resources that never exhausted their budget do not need a condition
+	if !exhausted {
+		return false
+	}
+
+	jiraSync.Status.Conditions = append(jiraSync.Status.Conditions, metav1.Condition{
+		Type:               ConditionTypeRetryExhausted,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+func createFailedJIRASync(name string, policy *operatortypes.RetryPolicy, annotations map[string]string) *operatortypes.JIRASync {
+	jiraSync := createPhasedJIRASync(name, PhaseFailed, "")
+	jiraSync.Spec.RetryPolicy = policy
+	jiraSync.Annotations = annotations
+	return jiraSync
+}
+
+func countEvents(recorder *record.FakeRecorder, reason string) int {
+	count := 0
+	for _, event := range drainEvents(recorder) {
+		if strings.Contains(event, reason) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestJIRASyncReconciler_RetryBudget_LimitExhausted(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	recorder := reconciler.StatusManager.recorder.(*record.FakeRecorder)
+
+	jiraSync := createFailedJIRASync("exhausted",
+		&operatortypes.RetryPolicy{MaxRetries: 2, BackoffMultiplier: 2.0, InitialDelay: 5},
+		map[string]string{RetryCountAnnotation: "2"})
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)}
+
+	for i := 0; i < 3; i++ {
+		result, err := reconciler.Reconcile(context.TODO(), req)
+		require.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result, "exhausted syncs are not requeued")
+	}
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhaseFailed, updated.Status.Phase)
+
+	condition := findCondition(&updated, ConditionTypeRetryExhausted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonRetryLimitReached, condition.Reason)
+	assert.Contains(t, condition.Message, "after 2 of 2 retries")
+
+	assert.Equal(t, 1, countEvents(recorder, ConditionTypeRetryExhausted), "warning is emitted once")
+}
+
+func TestJIRASyncReconciler_RetryBudget_TimeExhausted(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+
+	started := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+	jiraSync := createFailedJIRASync("time-budget",
+		&operatortypes.RetryPolicy{MaxRetries: 5, BackoffMultiplier: 2.0, InitialDelay: 5, MaxRetryDuration: 3600},
+		map[string]string{RetryCountAnnotation: "1", RetryStartAnnotation: started, RetryGenerationAnnotation: "0"})
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)}
+
+	result, err := reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	condition := findCondition(&updated, ConditionTypeRetryExhausted)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonRetryTimeExhausted, condition.Reason)
+	assert.Equal(t, "1", updated.Annotations[RetryCountAnnotation])
+}
+
+func TestJIRASyncReconciler_RetryBudget_StartsOnFirstFailure(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+
+	jiraSync := createFailedJIRASync("first-failure",
+		&operatortypes.RetryPolicy{MaxRetries: 3, BackoffMultiplier: 2.0, InitialDelay: 5, MaxRetryDuration: 3600}, nil)
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)}
+
+	result, err := reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, result.RequeueAfter)
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhasePending, updated.Status.Phase)
+	assert.Equal(t, "1", updated.Annotations[RetryCountAnnotation])
+	assert.NotEmpty(t, updated.Annotations[RetryStartAnnotation])
+	assert.Nil(t, findCondition(&updated, ConditionTypeRetryExhausted))
+}
+
+func TestResetRetryBudgetOnSpecChange(t *testing.T) {
+	exhaustedSync := func(stop bool) *operatortypes.JIRASync {
+		jiraSync := createFailedJIRASync("reset",
+			&operatortypes.RetryPolicy{MaxRetries: 1, StopOnExhaustion: stop},
+			map[string]string{
+				RetryCountAnnotation:      "1",
+				RetryStartAnnotation:      time.Now().UTC().Format(time.RFC3339),
+				RetryGenerationAnnotation: "1",
+			})
+		jiraSync.Generation = 1
+		setRetryExhaustedCondition(jiraSync, true, ReasonRetryLimitReached, "exhausted")
+		return jiraSync
+	}
+
+	// Same generation keeps the budget
+	jiraSync := exhaustedSync(false)
+	assert.False(t, resetRetryBudgetOnSpecChange(jiraSync))
+
+	// An edited spec starts a new budget
+	jiraSync.Generation = 2
+	assert.True(t, resetRetryBudgetOnSpecChange(jiraSync))
+	assert.NotContains(t, jiraSync.Annotations, RetryCountAnnotation)
+	assert.NotContains(t, jiraSync.Annotations, RetryStartAnnotation)
+
+	// stopOnExhaustion keeps exhausted resources terminal
+	jiraSync = exhaustedSync(true)
+	jiraSync.Generation = 2
+	assert.False(t, resetRetryBudgetOnSpecChange(jiraSync))
+	assert.Equal(t, "1", jiraSync.Annotations[RetryCountAnnotation])
+}
+
+func TestValidateSyncSpec_RetryPolicy(t *testing.T) {
+	reconciler, _ := setupTestReconciler()
+
+	spec := createTestJIRASync("validate", "default").Spec
+	spec.RetryPolicy = &operatortypes.RetryPolicy{MaxRetries: 3, MaxRetryDuration: 3600}
+	assert.NoError(t, reconciler.validateSyncSpec(&spec))
+
+	spec.RetryPolicy.MaxRetryDuration = -1
+	assert.Error(t, reconciler.validateSyncSpec(&spec))
+
+	spec.RetryPolicy.MaxRetryDuration = MaxRetryDurationLimit + 1
+	assert.Error(t, reconciler.validateSyncSpec(&spec))
+
+	spec.RetryPolicy = &operatortypes.RetryPolicy{MaxRetries: -1}
+	assert.Error(t, reconciler.validateSyncSpec(&spec))
+}
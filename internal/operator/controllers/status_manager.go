@@ -3,7 +3,11 @@ package controllers
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -478,11 +482,60 @@ func (sm *StatusManager) calculateHealthStatus(jiraSync *operatortypes.JIRASync)
 	return HealthStatusUnknown
 }
 
+// configFingerprint is the canonical form of the spec fields that define what a sync produces
+// Field order is fixed by the struct, so the JSON encoding is stable across processes.
+type configFingerprint struct {
+	SyncType   string   `json:"syncType"`
+	IssueKeys  []string `json:"issueKeys,omitempty"`
+	JQLQuery   string   `json:"jqlQuery,omitempty"`
+	ProjectKey string   `json:"projectKey,omitempty"`
+	EpicKey    string   `json:"epicKey,omitempty"`
+	Repository string   `json:"repository"`
+	Branch     string   `json:"branch,omitempty"`
+	Path       string   `json:"path,omitempty"`
+}
+
 // GenerateConfigHash creates a hash of the sync specification for change detection
+// Only fields that define what is synced and where it is written participate: syncType,
+// target (issueKeys as a sorted set, jqlQuery with surrounding whitespace trimmed, projectKey,
+// epicKey) and destination (repository, branch, path). Operational settings (schedule,
+// retryPolicy, priority, timeout, jobLabels, jobAnnotations) and all status fields are
+// excluded, so retuning how a sync runs never looks like a configuration change.
 func (sm *StatusManager) GenerateConfigHash(spec *operatortypes.JIRASyncSpec) string {
-	hasher := sha256.New()
-	_, _ = fmt.Fprintf(hasher, "%+v", spec)
-	return fmt.Sprintf("%x", hasher.Sum(nil))[:16]
+	fingerprint := configFingerprint{
+		SyncType:   spec.SyncType,
+		IssueKeys:  normalizeIssueKeys(spec.Target.IssueKeys),
+		JQLQuery:   strings.TrimSpace(spec.Target.JQLQuery),
+		ProjectKey: spec.Target.ProjectKey,
+		EpicKey:    spec.Target.EpicKey,
+		Repository: spec.Destination.Repository,
+		Branch:     spec.Destination.Branch,
+		Path:       spec.Destination.Path,
+	}
+
+	// Encoding a struct of strings cannot fail
+	data, _ := json.Marshal(fingerprint)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// normalizeIssueKeys returns the issue keys as a sorted, de-duplicated set
+func normalizeIssueKeys(keys []string) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+	normalized := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, key)
+	}
+	sort.Strings(normalized)
+	return normalized
 }
 
 func (sm *StatusManager) emitPhaseChangeEvent(jiraSync *operatortypes.JIRASync, oldPhase, newPhase string) {
@@ -446,6 +446,71 @@ func TestStatusManager_GenerateConfigHash(t *testing.T) {
 	assert.Len(t, hash2, 16)
 }
 
+func TestStatusManager_GenerateConfigHash_StableFields(t *testing.T) {
+	statusManager := NewStatusManager(nil, record.NewFakeRecorder(10), logr.Discard())
+
+	baseSpec := func() *operatortypes.JIRASyncSpec {
+		return &operatortypes.JIRASyncSpec{
+			SyncType: "batch",
+			Target: operatortypes.SyncTarget{
+				IssueKeys: []string{"TEST-2", "TEST-1"},
+			},
+			Destination: operatortypes.GitDestination{
+				Repository: "/tmp/repo",
+				Branch:     "main",
+			},
+		}
+	}
+	baseHash := statusManager.GenerateConfigHash(baseSpec())
+
+	// Separately built but identical specs hash identically (pointer fields must not leak addresses)
+	withPolicy := func() *operatortypes.JIRASyncSpec {
+		spec := baseSpec()
+		spec.RetryPolicy = &operatortypes.RetryPolicy{MaxRetries: 3}
+		return spec
+	}
+	assert.Equal(t, statusManager.GenerateConfigHash(withPolicy()), statusManager.GenerateConfigHash(withPolicy()))
+
+	unchanged := map[string]func(*operatortypes.JIRASyncSpec){
+		"retry policy":        func(s *operatortypes.JIRASyncSpec) { s.RetryPolicy = &operatortypes.RetryPolicy{MaxRetries: 5} },
+		"schedule":            func(s *operatortypes.JIRASyncSpec) { s.Schedule = "0 * * * *" },
+		"priority":            func(s *operatortypes.JIRASyncSpec) { s.Priority = "urgent" },
+		"timeout":             func(s *operatortypes.JIRASyncSpec) { s.Timeout = 600 },
+		"job labels":          func(s *operatortypes.JIRASyncSpec) { s.JobLabels = map[string]string{"team": "a"} },
+		"job annotations":     func(s *operatortypes.JIRASyncSpec) { s.JobAnnotations = map[string]string{"owner": "b"} },
+		"issue key order":     func(s *operatortypes.JIRASyncSpec) { s.Target.IssueKeys = []string{"TEST-1", "TEST-2"} },
+		"duplicate issue key": func(s *operatortypes.JIRASyncSpec) { s.Target.IssueKeys = []string{"TEST-1", "TEST-2", "TEST-1"} },
+	}
+	for name, mutate := range unchanged {
+		spec := baseSpec()
+		mutate(spec)
+		assert.Equal(t, baseHash, statusManager.GenerateConfigHash(spec), "%s should not change the hash", name)
+	}
+
+	changed := map[string]func(*operatortypes.JIRASyncSpec){
+		"sync type":   func(s *operatortypes.JIRASyncSpec) { s.SyncType = "single" },
+		"issue keys":  func(s *operatortypes.JIRASyncSpec) { s.Target.IssueKeys = []string{"TEST-1"} },
+		"jql":         func(s *operatortypes.JIRASyncSpec) { s.Target.JQLQuery = "project = TEST" },
+		"project key": func(s *operatortypes.JIRASyncSpec) { s.Target.ProjectKey = "TEST" },
+		"epic key":    func(s *operatortypes.JIRASyncSpec) { s.Target.EpicKey = "TEST-100" },
+		"repository":  func(s *operatortypes.JIRASyncSpec) { s.Destination.Repository = "/tmp/other" },
+		"branch":      func(s *operatortypes.JIRASyncSpec) { s.Destination.Branch = "develop" },
+		"path":        func(s *operatortypes.JIRASyncSpec) { s.Destination.Path = "issues" },
+	}
+	for name, mutate := range changed {
+		spec := baseSpec()
+		mutate(spec)
+		assert.NotEqual(t, baseHash, statusManager.GenerateConfigHash(spec), "%s should change the hash", name)
+	}
+
+	// Surrounding whitespace in a query is not a meaningful change
+	jqlSpec := baseSpec()
+	jqlSpec.Target.JQLQuery = "project = TEST"
+	paddedSpec := baseSpec()
+	paddedSpec.Target.JQLQuery = "  project = TEST\n"
+	assert.Equal(t, statusManager.GenerateConfigHash(jqlSpec), statusManager.GenerateConfigHash(paddedSpec))
+}
+
 func TestStatusManager_ProgressCalculations(t *testing.T) {
 	recorder := record.NewFakeRecorder(10)
 	logger := logr.Discard()
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+// ReasonSyncTimeout marks a sync failed because it ran longer than spec.timeout
+const ReasonSyncTimeout = "Timeout"
+
+// Accepted spec.timeout range (in seconds), matching the CRD schema
+const (
+	MinSyncTimeout = 60
+	MaxSyncTimeout = 2 * 60 * 60
+)
+
+// syncDeadline returns when a running sync times out
+// Syncs without a timeout or without a recorded start time never time out.
+func syncDeadline(jiraSync *operatortypes.JIRASync) (time.Time, bool) {
+	if jiraSync.Spec.Timeout <= 0 || jiraSync.Status.SyncStats == nil || jiraSync.Status.SyncStats.StartTime == nil {
+		return time.Time{}, false
+	}
+	return jiraSync.Status.SyncStats.StartTime.Add(time.Duration(jiraSync.Spec.Timeout) * time.Second), true
+}
+
+// requeueBeforeDeadline shortens a requeue delay so the timeout is enforced close to the deadline
+func requeueBeforeDeadline(jiraSync *operatortypes.JIRASync, delay time.Duration, now time.Time) time.Duration {
+	deadline, ok := syncDeadline(jiraSync)
+	if !ok {
+		return delay
+	}
+	if remaining := deadline.Sub(now); remaining < delay {
+		return max(remaining, time.Second)
+	}
+	return delay
+}
+
+// failTimedOutSync requests cancellation of the sync's job and marks the resource Failed
+// Cancellation is best effort: the resource fails even if the job cannot be cancelled.
+func (r *JIRASyncReconciler) failTimedOutSync(ctx context.Context, jiraSync *operatortypes.JIRASync, now time.Time) (ctrl.Result, error) {
+	log := r.Log.WithValues("jirasync", client.ObjectKeyFromObject(jiraSync))
+	limit := time.Duration(jiraSync.Spec.Timeout) * time.Second
+	elapsed := now.Sub(jiraSync.Status.SyncStats.StartTime.Time)
+
+	message := fmt.Sprintf("Sync exceeded its timeout of %s (running for %s)", limit, elapsed.Truncate(time.Second))
+	if err := r.cancelSyncJob(ctx, jiraSync); err != nil {
+		log.Error(err, "Failed to cancel timed out sync job")
+		message += fmt.Sprintf("; job cancellation failed: %v", err)
+	} else if jiraSync.Status.JobRef != nil {
+		message += fmt.Sprintf("; job %s cancelled", jiraSync.Status.JobRef.Name)
+		// The cancelled job is not polled again; a retry triggers a new one
+		jiraSync.Status.JobRef = nil
+	}
+	log.Info("Sync timed out", "timeout", limit, "elapsed", elapsed)
+
+	jiraSync.Status.SyncStats.Duration = elapsed.String()
+	r.recordError(jiraSync, fmt.Errorf("%s", message))
+	r.StatusManager.recorder.Event(jiraSync, corev1.EventTypeWarning, ReasonSyncTimeout, message)
+
+	jiraSync.Status.Phase = PhaseFailed
+	jiraSync.Status.LastError = message
+	r.setCondition(&jiraSync.Status.Conditions, metav1.Condition{
+		Type:               ConditionTypeReady,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(now),
+		Reason:             ReasonSyncTimeout,
+		Message:            message,
+	})
+
+	if err := r.Status().Update(ctx, jiraSync); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// cancelSyncJob stops the job executing a sync: API jobs are cancelled through the API server,
+// legacy Kubernetes Jobs are deleted along with their pods
+func (r *JIRASyncReconciler) cancelSyncJob(ctx context.Context, jiraSync *operatortypes.JIRASync) error {
+	jobRef := jiraSync.Status.JobRef
+	if jobRef == nil || jobRef.Name == "" {
+		return nil
+	}
+
+	if jobRef.Namespace == "api" {
+		if err := r.APIClient.CancelJob(ctx, jobRef.Name); err != nil {
+			return fmt.Errorf("failed to cancel API job %s: %w", jobRef.Name, err)
+		}
+		return nil
+	}
+
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: jobRef.Name, Namespace: jobRef.Namespace}, &job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get job %s: %w", jobRef.Name, err)
+	}
+	if err := r.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete job %s: %w", jobRef.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/chambrid/jira-cdc-git/internal/operator/apiclient"
+	operatortypes "github.com/chambrid/jira-cdc-git/internal/operator/types"
+)
+
+func createRunningJIRASync(name string, timeout int, startedAgo time.Duration) *operatortypes.JIRASync {
+	jiraSync := createPhasedJIRASync(name, PhaseRunning, "")
+	jiraSync.Spec.Timeout = timeout
+	started := metav1.NewTime(time.Now().Add(-startedAgo))
+	jiraSync.Status.SyncStats = &operatortypes.SyncStats{StartTime: &started}
+	return jiraSync
+}
+
+func TestJIRASyncReconciler_Timeout_FailsAndCancelsJob(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	mockAPI := reconciler.APIClient.(*apiclient.MockAPIClient)
+	recorder := reconciler.StatusManager.recorder.(*record.FakeRecorder)
+
+	jiraSync := createRunningJIRASync("timed-out", 60, 2*time.Minute)
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)}
+
+	_, err := reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"timed-out-job"}, mockAPI.CancelJobCalls)
+	assert.Empty(t, mockAPI.GetJobStatusCalls, "timed out jobs are not polled")
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhaseFailed, updated.Status.Phase)
+	assert.Nil(t, updated.Status.JobRef)
+	assert.Contains(t, updated.Status.LastError, "timeout of 1m0s")
+
+	condition := findCondition(&updated, ConditionTypeReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonSyncTimeout, condition.Reason)
+	assert.Equal(t, 1, countEvents(recorder, ReasonSyncTimeout))
+}
+
+func TestJIRASyncReconciler_Timeout_CancellationFailureStillFails(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+	mockAPI := reconciler.APIClient.(*apiclient.MockAPIClient)
+	mockAPI.CancelJobFunc = func(ctx context.Context, jobID string) error {
+		return errors.New("api unavailable")
+	}
+
+	jiraSync := createRunningJIRASync("cancel-fails", 60, time.Hour)
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)}
+
+	_, err := reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+
+	var updated operatortypes.JIRASync
+	require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+	assert.Equal(t, PhaseFailed, updated.Status.Phase)
+	assert.Contains(t, updated.Status.LastError, "job cancellation failed")
+	assert.Equal(t, ReasonSyncTimeout, findCondition(&updated, ConditionTypeReady).Reason)
+}
+
+func TestJIRASyncReconciler_Timeout_NotExceeded(t *testing.T) {
+	tests := []struct {
+		name       string
+		timeout    int
+		startedAgo time.Duration
+	}{
+		{"no timeout", 0, 24 * time.Hour},
+		{"within timeout", 600, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler, fakeClient := setupTestReconciler()
+			mockAPI := reconciler.APIClient.(*apiclient.MockAPIClient)
+			mockAPI.SetJobStatusResponse("running-job", "running", 50, "")
+
+			jiraSync := createRunningJIRASync("running", tt.timeout, tt.startedAgo)
+			require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)}
+
+			result, err := reconciler.Reconcile(context.TODO(), req)
+			require.NoError(t, err)
+			assert.Equal(t, 15*time.Second, result.RequeueAfter)
+			assert.Empty(t, mockAPI.CancelJobCalls)
+
+			var updated operatortypes.JIRASync
+			require.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, &updated))
+			assert.Equal(t, PhaseRunning, updated.Status.Phase)
+		})
+	}
+}
+
+func TestJIRASyncReconciler_Timeout_DeletesLegacyJob(t *testing.T) {
+	reconciler, fakeClient := setupTestReconciler()
+
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "legacy-job", Namespace: "default"}}
+	require.NoError(t, fakeClient.Create(context.TODO(), job))
+
+	jiraSync := createRunningJIRASync("legacy", 60, 5*time.Minute)
+	jiraSync.Status.JobRef = &operatortypes.JobReference{Name: "legacy-job", Namespace: "default"}
+	require.NoError(t, fakeClient.Create(context.TODO(), jiraSync))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(jiraSync)}
+
+	_, err := reconciler.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+
+	var remaining batchv1.Job
+	err = fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(job), &remaining)
+	assert.True(t, apierrors.IsNotFound(err), "legacy job should be deleted, got %v", err)
+}
+
+func TestRequeueBeforeDeadline(t *testing.T) {
+	now := time.Now()
+	jiraSync := createRunningJIRASync("requeue", 60, 55*time.Second)
+	jiraSync.Status.SyncStats.StartTime = &metav1.Time{Time: now.Add(-55 * time.Second)}
+
+	assert.Equal(t, 5*time.Second, requeueBeforeDeadline(jiraSync, 15*time.Second, now))
+	assert.Equal(t, 2*time.Second, requeueBeforeDeadline(jiraSync, 2*time.Second, now))
+
+	jiraSync.Spec.Timeout = 0
+	assert.Equal(t, 15*time.Second, requeueBeforeDeadline(jiraSync, 15*time.Second, now))
+}
+
+func TestValidateSyncSpec_Timeout(t *testing.T) {
+	reconciler, _ := setupTestReconciler()
+
+	spec := createTestJIRASync("validate", "default").Spec
+	for _, timeout := range []int{0, MinSyncTimeout, MaxSyncTimeout} {
+		spec.Timeout = timeout
+		assert.NoError(t, reconciler.validateSyncSpec(&spec), "timeout %d", timeout)
+	}
+	for _, timeout := range []int{-1, MinSyncTimeout - 1, MaxSyncTimeout + 1} {
+		spec.Timeout = timeout
+		assert.Error(t, reconciler.validateSyncSpec(&spec), "timeout %d", timeout)
+	}
+}
@@ -25,6 +25,43 @@ type JIRASyncSpec struct {
 
 	// Retry configuration for failed sync operations
 	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Sync operation priority for scheduling (low, normal, high, urgent)
+	Priority string `json:"priority,omitempty"`
+
+	// Labels added to the Kubernetes Jobs and Pods created for this sync
+	// Operator-managed labels take precedence and cannot be overridden
+	JobLabels map[string]string `json:"jobLabels,omitempty"`
+
+	// Annotations added to the Kubernetes Jobs and Pods created for this sync
+	JobAnnotations map[string]string `json:"jobAnnotations,omitempty"`
+
+	// Maximum execution time for a running sync, measured from syncStats.startTime (in seconds, 0 = no timeout)
+	Timeout int `json:"timeout,omitempty"`
+
+	// Upload a JSON result summary of each finished sync run (optional, disabled when unset)
+	ResultArtifact *ResultArtifactSpec `json:"resultArtifact,omitempty"`
+
+	// References to credentials; gitSecretRef is passed to the sync job for pushing to the remote
+	Credentials *CredentialRefs `json:"credentials,omitempty"`
+
+	// Commit checkpoints of the sync job (optional, one final commit when unset)
+	Checkpoint *CheckpointSpec `json:"checkpoint,omitempty"`
+}
+
+// CheckpointSpec makes a sync job commit as it goes rather than once at the end
+type CheckpointSpec struct {
+	// Changed issues per commit; an interrupted sync keeps the issues committed so far
+	Interval int `json:"interval"`
+}
+
+// ResultArtifactSpec configures where sync result summaries are stored
+type ResultArtifactSpec struct {
+	// ConfigMap receiving one summary key per run (default: <name>-sync-results)
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Number of run summaries to keep; the oldest are removed first (default: 10)
+	History int `json:"history,omitempty"`
 }
 
 // SyncTarget defines what JIRA issues to sync
@@ -64,6 +101,12 @@ type RetryPolicy struct {
 
 	// Initial delay before first retry (in seconds)
 	InitialDelay int `json:"initialDelay,omitempty"`
+
+	// Total time budget for retries, measured from the first failure (in seconds, 0 = unlimited)
+	MaxRetryDuration int `json:"maxRetryDuration,omitempty"`
+
+	// Make exhaustion terminal: editing the spec does not start a new retry budget
+	StopOnExhaustion bool `json:"stopOnExhaustion,omitempty"`
 }
 
 // JIRASyncStatus defines the observed state of JIRASync
@@ -97,6 +140,28 @@ type JIRASyncStatus struct {
 
 	// Timestamp of last status update
 	LastStatusUpdate *metav1.Time `json:"lastStatusUpdate,omitempty"`
+
+	// Location of the latest sync result summary, when spec.resultArtifact is set
+	ResultArtifact *ArtifactReference `json:"resultArtifact,omitempty"`
+}
+
+// ArtifactReference locates a stored sync result summary
+type ArtifactReference struct {
+	// Kind of object holding the artifact (ConfigMap)
+	Kind string `json:"kind"`
+
+	// Name and namespace of the object
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Data key of the summary within the object
+	Key string `json:"key"`
+
+	// Job the summary describes
+	JobID string `json:"jobId,omitempty"`
+
+	// Time the summary was stored
+	UploadedAt *metav1.Time `json:"uploadedAt,omitempty"`
 }
 
 // SyncStats provides statistics about sync operations
@@ -368,6 +433,35 @@ func (in *JIRASyncSpec) DeepCopyInto(out *JIRASyncSpec) {
 		*out = new(RetryPolicy)
 		**out = **in
 	}
+	if in.JobLabels != nil {
+		in, out := &in.JobLabels, &out.JobLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.JobAnnotations != nil {
+		in, out := &in.JobAnnotations, &out.JobAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResultArtifact != nil {
+		in, out := &in.ResultArtifact, &out.ResultArtifact
+		*out = new(ResultArtifactSpec)
+		**out = **in
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(CredentialRefs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		*out = new(CheckpointSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy copies the receiver, creating a new JIRASyncSpec.
@@ -415,6 +509,21 @@ func (in *JIRASyncStatus) DeepCopyInto(out *JIRASyncStatus) {
 		in, out := &in.LastStatusUpdate, &out.LastStatusUpdate
 		*out = (*in).DeepCopy()
 	}
+	if in.ResultArtifact != nil {
+		in, out := &in.ResultArtifact, &out.ResultArtifact
+		*out = new(ArtifactReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopyInto copies all properties of this object into another object of the
+// same type that is provided as a pointer.
+func (in *ArtifactReference) DeepCopyInto(out *ArtifactReference) {
+	*out = *in
+	if in.UploadedAt != nil {
+		in, out := &in.UploadedAt, &out.UploadedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy copies the receiver, creating a new JIRASyncStatus.
@@ -2,13 +2,16 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/chambrid/jira-cdc-git/pkg/client"
 	"github.com/chambrid/jira-cdc-git/pkg/git"
 	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/ratelimit"
 	"github.com/chambrid/jira-cdc-git/pkg/schema"
 )
 
@@ -28,18 +31,68 @@ type BatchSyncEngine struct {
 	linkManager  links.LinkManager
 	concurrency  int
 	progressChan chan ProgressUpdate
+
+	// linkNanos accumulates relationship link creation time across workers for the current run
+	linkNanos atomic.Int64
+
+	// budget limits API calls and duration; nil when no budget is set
+	budget *BudgetTracker
+
+	// bulkFetchSize is the number of issues fetched per bulk request; 0 or 1 fetches one at a time
+	bulkFetchSize int
+
+	// retryPolicy decides which failed issues are retried; nil never retries
+	retryPolicy *client.RetryPolicy
+
+	// commitStrategy decides how issue files are committed; empty commits per issue
+	commitStrategy CommitStrategy
+
+	// pending collects the changed issue files of a run when commits are deferred
+	pending pendingCommit
+
+	// checkpointInterval commits deferred changes every this many issues; 0 commits once per run
+	checkpointInterval int
+
+	// commitMu serializes the commits of deferred changes made by workers and at the end of a run
+	commitMu sync.Mutex
+
+	// rampUp spreads worker starts over this duration; 0 starts every worker at once
+	rampUp time.Duration
+
+	// issueFilter skips fetched issues that do not match; nil writes every issue
+	issueFilter IssueFilter
+
+	// quietHours throttles syncs during a daily window; nil never throttles
+	quietHours *ratelimit.QuietHours
+
+	// quietSlot admits one worker at a time during slow quiet hours, and its holder records
+	// in quietLast when its issue finished; the next issue waits quietInterval after it
+	quietSlot     chan struct{}
+	quietLast     time.Time
+	quietInterval time.Duration
+
+	// quietActive is whether the quiet hours were active when a worker last checked
+	quietActive atomic.Bool
 }
 
 // BatchResult contains the results of a batch sync operation
 type BatchResult struct {
+	OperationID     string             `json:"operation_id,omitempty"` // ID of the sync run, set by the caller
 	TotalIssues     int                `json:"total_issues"`
 	ProcessedIssues int                `json:"processed_issues"`
 	SuccessfulSync  int                `json:"successful_sync"`
 	FailedSync      int                `json:"failed_sync"`
 	ProcessedFiles  []string           `json:"processed_files"`
 	Errors          []BatchError       `json:"errors"`
+	IssueResults    []IssueResult      `json:"issue_results"`
 	Duration        time.Duration      `json:"duration"`
 	Performance     PerformanceMetrics `json:"performance"`
+	Plan            []PlannedChange    `json:"plan,omitempty"`          // planned file changes, recorded by dry runs
+	Budget          *BudgetUsage       `json:"budget,omitempty"`        // consumption of the sync budget, when one is set
+	Transitions     []IssueTransition  `json:"transitions,omitempty"`   // status changes that selected issues, for transitions-only syncs
+	Deleted         []string           `json:"deleted,omitempty"`       // mirrored issues deleted in JIRA (404), with --handle-deletions
+	AccessDenied    []string           `json:"access_denied,omitempty"` // mirrored issues no longer readable (403), with --handle-deletions
+	Filtered        []string           `json:"filtered,omitempty"`      // fetched issues skipped by the filter expression
 }
 
 // BatchError represents an error that occurred during batch processing
@@ -50,6 +103,16 @@ type BatchError struct {
 	Error    error  `json:"-"`
 }
 
+// IssueResult records the outcome of syncing a single issue, in processing order
+type IssueResult struct {
+	IssueKey string        `json:"issue_key"`
+	FilePath string        `json:"file_path,omitempty"`
+	Success  bool          `json:"success"`
+	Step     string        `json:"step,omitempty"`
+	Message  string        `json:"message,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
 // PerformanceMetrics contains performance statistics for batch operations
 // Based on SPIKE-005 performance validation
 type PerformanceMetrics struct {
@@ -57,6 +120,9 @@ type PerformanceMetrics struct {
 	MemoryUsageKB   int64         `json:"memory_usage_kb"`
 	WorkerCount     int           `json:"worker_count"`
 	AvgProcessTime  time.Duration `json:"avg_process_time"`
+
+	// LinkCreationTime is the time spent creating relationship links, summed across workers
+	LinkCreationTime time.Duration `json:"link_creation_time"`
 }
 
 // ProgressUpdate represents progress information for batch operations
@@ -68,12 +134,21 @@ type ProgressUpdate struct {
 	Step           string    `json:"step"`
 	Timestamp      time.Time `json:"timestamp"`
 	WorkerID       int       `json:"worker_id"`
+
+	// ActiveWorkers and TotalWorkers report the ramp-up, on "ramp_up" updates
+	ActiveWorkers int `json:"active_workers,omitempty"`
+	TotalWorkers  int `json:"total_workers,omitempty"`
+
+	// QuietHoursMode and QuietHoursEnd describe the throttle, on "quiet_hours_start" updates
+	QuietHoursMode string     `json:"quiet_hours_mode,omitempty"`
+	QuietHoursEnd  *time.Time `json:"quiet_hours_end,omitempty"`
 }
 
 // SyncTask represents a single issue sync task for worker processing
 type SyncTask struct {
 	IssueKey string
 	Index    int
+	Issue    *client.Issue // prefetched by a bulk fetch; nil to fetch in the worker
 }
 
 // SyncResult represents the result of a single issue sync operation
@@ -109,11 +184,13 @@ func NewBatchSyncEngine(client client.Client, fileWriter schema.FileWriter, gitR
 // SyncIssuesSync performs batch sync for a list of issue keys WITHOUT concurrency (for testing)
 func (b *BatchSyncEngine) SyncIssuesSync(ctx context.Context, issues []string, repoPath string) (*BatchResult, error) {
 	startTime := time.Now()
+	b.linkNanos.Store(0)
 
 	result := &BatchResult{
 		TotalIssues:    len(issues),
 		ProcessedFiles: make([]string, 0, len(issues)),
 		Errors:         make([]BatchError, 0),
+		IssueResults:   make([]IssueResult, 0, len(issues)),
 		Performance: PerformanceMetrics{
 			WorkerCount: 1, // Always 1 for sync mode
 		},
@@ -121,32 +198,30 @@ func (b *BatchSyncEngine) SyncIssuesSync(ctx context.Context, issues []string, r
 
 	// Process each issue sequentially
 	var totalProcessTime time.Duration
-	for _, issueKey := range issues {
+	var remaining []string
+	for i, issueKey := range issues {
 		select {
 		case <-ctx.Done():
+			if err := b.commitPending(repoPath); err != nil {
+				return result, err
+			}
 			return result, ctx.Err()
 		default:
 		}
 
+		if !b.budget.admit() {
+			remaining = issues[i:]
+			break
+		}
+
 		startTime := time.Now()
-		filePath, err := b.processSingleIssue(ctx, issueKey, repoPath, 0)
+		filePath, err := b.processWithRetries(ctx, SyncTask{IssueKey: issueKey, Index: i}, repoPath, 0)
 		processTime := time.Since(startTime)
 
 		result.ProcessedIssues++
 		totalProcessTime += processTime
 
-		if err != nil {
-			result.FailedSync++
-			result.Errors = append(result.Errors, BatchError{
-				IssueKey: issueKey,
-				Step:     "sync",
-				Message:  err.Error(),
-				Error:    err,
-			})
-		} else {
-			result.SuccessfulSync++
-			result.ProcessedFiles = append(result.ProcessedFiles, filePath)
-		}
+		result.recordIssue(issueKey, filePath, err, processTime)
 
 		// Send progress update (non-blocking)
 		select {
@@ -171,18 +246,25 @@ func (b *BatchSyncEngine) SyncIssuesSync(ctx context.Context, issues []string, r
 	if result.ProcessedIssues > 0 {
 		result.Performance.AvgProcessTime = totalProcessTime / time.Duration(result.ProcessedIssues)
 	}
+	result.Performance.LinkCreationTime = time.Duration(b.linkNanos.Load())
+	result.Budget = b.budget.usage(len(remaining), remaining)
 
+	if err := b.commitPending(repoPath); err != nil {
+		return result, err
+	}
 	return result, nil
 }
 
 // SyncIssues performs batch sync for a list of issue keys with parallel processing
 func (b *BatchSyncEngine) SyncIssues(ctx context.Context, issues []string, repoPath string) (*BatchResult, error) {
 	startTime := time.Now()
+	b.linkNanos.Store(0)
 
 	result := &BatchResult{
 		TotalIssues:    len(issues),
 		ProcessedFiles: make([]string, 0, len(issues)),
 		Errors:         make([]BatchError, 0),
+		IssueResults:   make([]IssueResult, 0, len(issues)),
 		Performance: PerformanceMetrics{
 			WorkerCount: b.concurrency,
 		},
@@ -194,18 +276,29 @@ func (b *BatchSyncEngine) SyncIssues(ctx context.Context, issues []string, repoP
 
 	// Start worker goroutines
 	var wg sync.WaitGroup
-	for i := 0; i < b.concurrency; i++ {
-		wg.Add(1)
-		go b.worker(ctx, i, taskChan, resultChan, repoPath, &wg)
-	}
+	b.startWorkers(ctx, taskChan, resultChan, repoPath, &wg)
 
-	// Send tasks to workers
+	// Send tasks to workers a fetch batch at a time, stopping once the budget is spent
+	var remaining []string
 	go func() {
 		defer close(taskChan)
-		for i, issueKey := range issues {
-			select {
-			case taskChan <- SyncTask{IssueKey: issueKey, Index: i}:
-			case <-ctx.Done():
+		batchSize := b.fetchBatchSize()
+		for start := 0; start < len(issues); start += batchSize {
+			batch := issues[start:min(start+batchSize, len(issues))]
+			if admitted := b.budget.admitBatch(len(batch)); admitted < len(batch) {
+				remaining = issues[start+admitted:]
+				batch = batch[:admitted]
+			}
+
+			prefetched := b.prefetchIssues(batch)
+			for i, issueKey := range batch {
+				select {
+				case taskChan <- SyncTask{IssueKey: issueKey, Index: start + i, Issue: prefetched[issueKey]}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if remaining != nil {
 				return
 			}
 		}
@@ -223,18 +316,7 @@ func (b *BatchSyncEngine) SyncIssues(ctx context.Context, issues []string, repoP
 		result.ProcessedIssues++
 		totalProcessTime += syncResult.ProcessTime
 
-		if syncResult.Error != nil {
-			result.FailedSync++
-			result.Errors = append(result.Errors, BatchError{
-				IssueKey: syncResult.IssueKey,
-				Step:     "sync",
-				Message:  syncResult.Error.Error(),
-				Error:    syncResult.Error,
-			})
-		} else {
-			result.SuccessfulSync++
-			result.ProcessedFiles = append(result.ProcessedFiles, syncResult.FilePath)
-		}
+		result.recordIssue(syncResult.IssueKey, syncResult.FilePath, syncResult.Error, syncResult.ProcessTime)
 
 		// Send progress update
 		select {
@@ -259,10 +341,47 @@ func (b *BatchSyncEngine) SyncIssues(ctx context.Context, issues []string, repoP
 	if result.ProcessedIssues > 0 {
 		result.Performance.AvgProcessTime = totalProcessTime / time.Duration(result.ProcessedIssues)
 	}
+	result.Performance.LinkCreationTime = time.Duration(b.linkNanos.Load())
+	result.Budget = b.budget.usage(len(remaining), remaining)
 
+	if err := b.commitPending(repoPath); err != nil {
+		return result, err
+	}
 	return result, nil
 }
 
+// recordIssue tallies the outcome of a single issue sync
+func (r *BatchResult) recordIssue(issueKey, filePath string, err error, processTime time.Duration) {
+	issueResult := IssueResult{
+		IssueKey: issueKey,
+		Duration: processTime,
+	}
+
+	if errors.Is(err, errIssueFiltered) {
+		r.Filtered = append(r.Filtered, issueKey)
+		return
+	}
+
+	if err != nil {
+		r.FailedSync++
+		r.Errors = append(r.Errors, BatchError{
+			IssueKey: issueKey,
+			Step:     "sync",
+			Message:  err.Error(),
+			Error:    err,
+		})
+		issueResult.Step = "sync"
+		issueResult.Message = err.Error()
+	} else {
+		r.SuccessfulSync++
+		r.ProcessedFiles = append(r.ProcessedFiles, filePath)
+		issueResult.Success = true
+		issueResult.FilePath = filePath
+	}
+
+	r.IssueResults = append(r.IssueResults, issueResult)
+}
+
 // SyncJQL performs batch sync for issues matching a JQL query
 func (b *BatchSyncEngine) SyncJQL(ctx context.Context, jql string, repoPath string) (*BatchResult, error) {
 	// First, fetch all issues matching the JQL query
@@ -299,6 +418,125 @@ func (b *BatchSyncEngine) SyncJQLSync(ctx context.Context, jql string, repoPath
 	return b.SyncIssuesSync(ctx, issueKeys, repoPath)
 }
 
+// SyncJQLStream performs batch sync for issues matching a JQL query without loading the full
+// result set. Issue keys are fetched page by page and fed straight into the worker queue, so
+// memory stays bounded by the page size and worker count rather than the number of matches.
+// TotalIssues (and progress percentages) become available once the first page reports the total.
+func (b *BatchSyncEngine) SyncJQLStream(ctx context.Context, jql string, repoPath string, pageSize int) (*BatchResult, error) {
+	startTime := time.Now()
+	b.linkNanos.Store(0)
+
+	if pageSize <= 0 {
+		pageSize = client.DefaultSearchPageSize
+	}
+
+	result := &BatchResult{
+		ProcessedFiles: make([]string, 0),
+		Errors:         make([]BatchError, 0),
+		IssueResults:   make([]IssueResult, 0),
+		Performance: PerformanceMetrics{
+			WorkerCount: b.concurrency,
+		},
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Keep the queue small: the producer blocks once workers fall behind
+	taskChan := make(chan SyncTask, b.concurrency*2)
+	resultChan := make(chan SyncResult, b.concurrency*2)
+
+	var wg sync.WaitGroup
+	b.startWorkers(streamCtx, taskChan, resultChan, repoPath, &wg)
+
+	// Stream search pages into the worker queue
+	var total atomic.Int64
+	total.Store(-1)
+	searchErr := make(chan error, 1)
+	go func() {
+		defer close(taskChan)
+		index := 0
+		searchErr <- client.StreamIssueKeys(streamCtx, b.client, jql, pageSize, func(page client.SearchPage) error {
+			total.Store(int64(page.Total))
+			for _, issueKey := range page.Keys {
+				if !b.budget.admit() {
+					return ErrBudgetExhausted
+				}
+				select {
+				case taskChan <- SyncTask{IssueKey: issueKey, Index: index}:
+					index++
+				case <-streamCtx.Done():
+					return streamCtx.Err()
+				}
+			}
+			return nil
+		})
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	var totalProcessTime time.Duration
+	for syncResult := range resultChan {
+		result.ProcessedIssues++
+		totalProcessTime += syncResult.ProcessTime
+		result.recordIssue(syncResult.IssueKey, syncResult.FilePath, syncResult.Error, syncResult.ProcessTime)
+
+		update := ProgressUpdate{
+			CurrentIssue:   syncResult.IssueKey,
+			ProcessedCount: result.ProcessedIssues,
+			Step:           "processing",
+			Timestamp:      time.Now(),
+		}
+		if known := total.Load(); known > 0 {
+			update.TotalCount = int(known)
+			update.Percentage = float64(result.ProcessedIssues) / float64(known) * 100
+		}
+
+		select {
+		case b.progressChan <- update:
+		default:
+			// Non-blocking send - skip if channel is full
+		}
+	}
+
+	err := <-searchErr
+	if errors.Is(err, ErrBudgetExhausted) && total.Load() >= 0 {
+		// Stopping early is the point of a budget; the rest is left for a later run
+		err = nil
+	}
+	if err != nil && total.Load() < 0 {
+		// The search failed before anything was queued
+		return nil, fmt.Errorf("failed to execute JQL search: %w", err)
+	}
+
+	result.TotalIssues = int(total.Load())
+	if result.TotalIssues < result.ProcessedIssues {
+		result.TotalIssues = result.ProcessedIssues
+	}
+
+	result.Duration = time.Since(startTime)
+	if result.Duration > 0 {
+		result.Performance.IssuesPerSecond = float64(result.ProcessedIssues) / result.Duration.Seconds()
+	}
+	if result.ProcessedIssues > 0 {
+		result.Performance.AvgProcessTime = totalProcessTime / time.Duration(result.ProcessedIssues)
+	}
+	result.Performance.LinkCreationTime = time.Duration(b.linkNanos.Load())
+	result.Budget = b.budget.usage(result.TotalIssues-result.ProcessedIssues, nil)
+
+	if commitErr := b.commitPending(repoPath); commitErr != nil {
+		return result, commitErr
+	}
+	if err != nil {
+		return result, fmt.Errorf("JQL search failed after %d issues: %w", result.ProcessedIssues, err)
+	}
+
+	return result, nil
+}
+
 // GetProgressChannel returns a channel for receiving progress updates
 func (b *BatchSyncEngine) GetProgressChannel() <-chan ProgressUpdate {
 	return b.progressChan
@@ -320,9 +558,15 @@ func (b *BatchSyncEngine) worker(ctx context.Context, workerID int, tasks <-chan
 				return // Channel closed, worker done
 			}
 
+			leaveQuietHours, err := b.enterQuietHours(ctx, workerID)
+			if err != nil {
+				return // Cancelled while held back by the quiet hours
+			}
+
 			startTime := time.Now()
-			filePath, err := b.processSingleIssue(ctx, task.IssueKey, repoPath, workerID)
+			filePath, err := b.processWithRetries(ctx, task, repoPath, workerID)
 			processTime := time.Since(startTime)
+			leaveQuietHours()
 
 			result := SyncResult{
 				IssueKey:    task.IssueKey,
@@ -345,7 +589,8 @@ func (b *BatchSyncEngine) worker(ctx context.Context, workerID int, tasks <-chan
 }
 
 // processSingleIssue handles the sync of a single issue (fetch, write, commit)
-func (b *BatchSyncEngine) processSingleIssue(ctx context.Context, issueKey, repoPath string, workerID int) (string, error) {
+// An issue prefetched by a bulk fetch is not fetched again.
+func (b *BatchSyncEngine) processSingleIssue(ctx context.Context, issueKey string, prefetched *client.Issue, repoPath string, workerID int) (string, error) {
 	// Send progress update for fetch step
 	select {
 	case b.progressChan <- ProgressUpdate{
@@ -358,9 +603,16 @@ func (b *BatchSyncEngine) processSingleIssue(ctx context.Context, issueKey, repo
 	}
 
 	// Fetch issue data
-	issueData, err := b.client.GetIssue(issueKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch issue %s: %w", issueKey, err)
+	issueData := prefetched
+	if issueData == nil {
+		var err error
+		issueData, err = b.client.GetIssue(issueKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch issue %s: %w", issueKey, err)
+		}
+	}
+	if b.issueFilter != nil && !b.issueFilter.Match(issueData) {
+		return "", errIssueFiltered
 	}
 
 	// Send progress update for write step
@@ -374,6 +626,12 @@ func (b *BatchSyncEngine) processSingleIssue(ctx context.Context, issueKey, repo
 	default:
 	}
 
+	// Remember the file's content so a deferred commit can tell added, modified and unchanged apart
+	var previousChecksum string
+	if b.deferCommits() {
+		previousChecksum = fileChecksum(b.fileWriter.GetIssueFilePath(repoPath, extractProjectKey(issueKey), issueKey))
+	}
+
 	// Write YAML file
 	yamlFilePath, err := b.fileWriter.WriteIssueToYAML(issueData, repoPath)
 	if err != nil {
@@ -392,7 +650,10 @@ func (b *BatchSyncEngine) processSingleIssue(ctx context.Context, issueKey, repo
 	}
 
 	// Create relationship links (symbolic links)
-	if err := b.linkManager.CreateRelationshipLinks(issueData, repoPath); err != nil {
+	linkStart := time.Now()
+	linkErr := b.linkManager.CreateRelationshipLinks(issueData, repoPath)
+	b.linkNanos.Add(int64(time.Since(linkStart)))
+	if linkErr != nil {
 		// Don't fail the whole sync if symbolic links fail, just log and continue
 		// This makes the system more robust on platforms with limited symlink support
 		select {
@@ -406,6 +667,21 @@ func (b *BatchSyncEngine) processSingleIssue(ctx context.Context, issueKey, repo
 		}
 	}
 
+	// Leave the commit to the end of the run, listing only files whose content changed
+	if b.deferCommits() {
+		if checksum := fileChecksum(yamlFilePath); checksum != previousChecksum {
+			action := PlanActionUpdate
+			if previousChecksum == "" {
+				action = PlanActionAdd
+			}
+			b.pending.record(IssueChange{IssueKey: issueKey, Summary: issueData.Summary, FilePath: yamlFilePath, Action: action})
+		}
+		if err := b.commitCheckpoint(repoPath); err != nil {
+			return yamlFilePath, err
+		}
+		return yamlFilePath, nil
+	}
+
 	// Send progress update for commit step
 	select {
 	case b.progressChan <- ProgressUpdate{
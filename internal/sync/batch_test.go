@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -333,3 +334,84 @@ func TestBatchResult_PerformanceMetrics(t *testing.T) {
 		t.Errorf("Expected %f%% success rate, got %f%%", expectedSuccessRate, successRate)
 	}
 }
+
+func TestBatchSyncEngine_SyncIssues_LinkCreationTime(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-1", Summary: "One"})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-2", Summary: "Two"})
+
+	mockLinks := links.NewMockLinkManager()
+	mockLinks.CreateRelationshipLinksFunc = func(*client.Issue, string) error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+
+	engine := NewBatchSyncEngine(mockClient, schema.NewMockFileWriter(), git.NewMockRepository(), mockLinks, 1)
+	result, err := engine.SyncIssues(context.Background(), []string{"PROJ-1", "PROJ-2"}, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	if result.Performance.LinkCreationTime < 10*time.Millisecond {
+		t.Errorf("Expected link time summed across issues (>= 10ms), got %v", result.Performance.LinkCreationTime)
+	}
+
+	// A new run starts from zero
+	mockLinks.CreateRelationshipLinksFunc = nil
+	result, err = engine.SyncIssues(context.Background(), []string{"PROJ-1"}, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	if result.Performance.LinkCreationTime >= 5*time.Millisecond {
+		t.Errorf("Expected link time reset between runs, got %v", result.Performance.LinkCreationTime)
+	}
+}
+
+func TestBatchSyncEngine_SyncJQLStream_Pagination(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockWriter := schema.NewMockFileWriter()
+	mockGit := git.NewMockRepository()
+	mockLinks := links.NewMockLinkManager()
+
+	jql := "project = PROJ"
+	for i := 1; i <= 5; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		mockClient.Issues[key] = &client.Issue{Key: key, Summary: "Streamed issue"}
+		mockClient.JQLResults[jql] = append(mockClient.JQLResults[jql], key)
+	}
+
+	repoPath := "/test/repo"
+	mockGit.Repositories[repoPath] = true
+
+	engine := NewBatchSyncEngine(mockClient, mockWriter, mockGit, mockLinks, 2)
+
+	// A page size of 2 forces three search pages for five issues
+	result, err := engine.SyncJQLStream(context.Background(), jql, repoPath, 2)
+	if err != nil {
+		t.Fatalf("SyncJQLStream() error = %v, want nil", err)
+	}
+
+	if result.TotalIssues != 5 {
+		t.Errorf("SyncJQLStream() TotalIssues = %d, want 5", result.TotalIssues)
+	}
+	if result.SuccessfulSync != 5 {
+		t.Errorf("SyncJQLStream() SuccessfulSync = %d, want 5", result.SuccessfulSync)
+	}
+	if len(result.IssueResults) != 5 {
+		t.Errorf("SyncJQLStream() recorded %d issue results, want 5", len(result.IssueResults))
+	}
+}
+
+func TestBatchSyncEngine_SyncJQLStream_SearchFailure(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.JQLError = errors.New("JQL search failed")
+
+	engine := NewBatchSyncEngine(mockClient, schema.NewMockFileWriter(), git.NewMockRepository(), links.NewMockLinkManager(), 1)
+
+	result, err := engine.SyncJQLStream(context.Background(), "project = PROJ", "/test/repo", 10)
+	if err == nil {
+		t.Fatal("SyncJQLStream() expected error for failed search")
+	}
+	if result != nil {
+		t.Error("SyncJQLStream() result should be nil when the first page fails")
+	}
+}
@@ -0,0 +1,244 @@
+package sync
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// ErrBudgetExhausted is returned for JIRA requests refused because the sync budget is used up
+var ErrBudgetExhausted = errors.New("sync budget exhausted")
+
+// Budget exhaustion reasons
+const (
+	BudgetReasonAPICalls = "api_calls"
+	BudgetReasonDuration = "duration"
+)
+
+// SyncBudget limits how much of a JIRA API quota a single sync may consume
+// A zero limit is unlimited.
+type SyncBudget struct {
+	MaxAPICalls int
+	MaxDuration time.Duration
+}
+
+// BudgetUsage reports how much of a sync budget was consumed
+type BudgetUsage struct {
+	APICalls       int64         `json:"api_calls"`
+	MaxAPICalls    int           `json:"max_api_calls,omitempty"`
+	Elapsed        time.Duration `json:"elapsed"`
+	MaxDuration    time.Duration `json:"max_duration,omitempty"`
+	Exhausted      bool          `json:"exhausted"`
+	Reason         string        `json:"reason,omitempty"`          // api_calls or duration
+	RemainingCount int           `json:"remaining_count,omitempty"` // issues left for a later run
+	Remaining      []string      `json:"remaining,omitempty"`       // their keys, when known
+}
+
+// BudgetTracker counts a sync's JIRA requests against a SyncBudget
+// Issues are admitted one at a time before they start. Admission reserves the calls the issue
+// will need, so an admitted issue always completes. Requests outside admitted issues (searches,
+// incremental checks) are refused once the budget is spent. Requests an admitted issue makes
+// beyond its reservation, such as further worklog pages or retries, still complete and count,
+// so they can take the count past the limit; no further issue is admitted then.
+type BudgetTracker struct {
+	budget   SyncBudget
+	start    time.Time
+	observed bool   // the client's transport counts every request
+	stop     func() // stops observing the client's requests
+
+	mu        sync.Mutex
+	calls     int64
+	reserved  int64 // calls admitted issues have yet to make
+	issueCost int64 // calls reserved per admitted issue
+	reason    string
+}
+
+// NewBudgetTracker starts tracking a budget for the requests sent through c; nil when the
+// budget is unlimited. The clock starts now. When c reports the HTTP requests it sends (see
+// client.RequestObserverClient), every request counts from now on: setup lookups such as
+// permission checks and field metadata, worklog pages, user lookups, remote links, and retries.
+// Otherwise the requests the sync engine makes are counted.
+func NewBudgetTracker(c client.Client, budget SyncBudget) *BudgetTracker {
+	if budget.MaxAPICalls <= 0 && budget.MaxDuration <= 0 {
+		return nil
+	}
+	t := &BudgetTracker{budget: budget, start: time.Now(), issueCost: 1}
+	if observable, ok := c.(client.RequestObserverClient); ok {
+		t.stop, t.observed = observable.ObserveRequests(t.record)
+	}
+	return t
+}
+
+// Stop stops counting the client's requests, once the sync is done
+func (t *BudgetTracker) Stop() {
+	if t != nil && t.stop != nil {
+		t.stop()
+	}
+}
+
+// record counts one request sent by the client, using up a reserved call if there is one
+func (t *BudgetTracker) record() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.reserved > 0 {
+		t.reserved--
+	}
+	t.calls++
+}
+
+// setIssueCost sets how many requests each admitted issue makes
+func (t *BudgetTracker) setIssueCost(calls int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.issueCost = calls
+	t.mu.Unlock()
+}
+
+// admit reserves the calls for one more issue, or reports that the budget is spent
+func (t *BudgetTracker) admit() bool {
+	if t == nil {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.withinLimits(t.issueCost) {
+		return false
+	}
+	t.reserved += t.issueCost
+	return true
+}
+
+// take accounts for one request made through the engine, refusing it if it was not reserved
+// and the budget is spent. When the client's transport counts requests, it only checks.
+func (t *BudgetTracker) take() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.reserved > 0 {
+		if !t.observed {
+			t.reserved--
+			t.calls++
+		}
+		return true
+	}
+	if !t.withinLimits(1) {
+		return false
+	}
+	if !t.observed {
+		t.calls++
+	}
+	return true
+}
+
+// admitBatch admits up to n issues whose data is fetched together by one bulk request,
+// returning how many fit. The batch reserves the shared fetch once, plus each issue's other calls.
+func (t *BudgetTracker) admitBatch(n int) int {
+	if t == nil {
+		return n
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	admitted := 0
+	for admitted < n {
+		cost := t.issueCost - 1
+		if admitted == 0 {
+			cost = t.issueCost
+		}
+		if !t.withinLimits(cost) {
+			break
+		}
+		t.reserved += cost
+		admitted++
+	}
+	return admitted
+}
+
+// withinLimits reports whether n more calls fit the budget, recording why not; callers hold mu
+func (t *BudgetTracker) withinLimits(n int64) bool {
+	if t.reason != "" {
+		return false
+	}
+	if t.budget.MaxDuration > 0 && time.Since(t.start) >= t.budget.MaxDuration {
+		t.reason = BudgetReasonDuration
+		return false
+	}
+	if t.budget.MaxAPICalls > 0 && t.calls+t.reserved+n > int64(t.budget.MaxAPICalls) {
+		t.reason = BudgetReasonAPICalls
+		return false
+	}
+	return true
+}
+
+// usage snapshots consumption; remaining lists the issues that were not admitted
+func (t *BudgetTracker) usage(remainingCount int, remaining []string) *BudgetUsage {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage := &BudgetUsage{
+		APICalls:    t.calls,
+		MaxAPICalls: t.budget.MaxAPICalls,
+		Elapsed:     time.Since(t.start),
+		MaxDuration: t.budget.MaxDuration,
+		Exhausted:   t.reason != "",
+		Reason:      t.reason,
+	}
+	if usage.Exhausted {
+		usage.RemainingCount = remainingCount
+		usage.Remaining = remaining
+	}
+	return usage
+}
+
+// budgetClient refuses requests made through it once a budget is spent, counting them unless
+// the client's transport does
+type budgetClient struct {
+	client.Client
+	tracker *BudgetTracker
+}
+
+func (c *budgetClient) GetIssue(issueKey string) (*client.Issue, error) {
+	if !c.tracker.take() {
+		return nil, ErrBudgetExhausted
+	}
+	return c.Client.GetIssue(issueKey)
+}
+
+func (c *budgetClient) SearchIssues(jql string) ([]*client.Issue, error) {
+	if !c.tracker.take() {
+		return nil, ErrBudgetExhausted
+	}
+	return c.Client.SearchIssues(jql)
+}
+
+func (c *budgetClient) SearchIssuesWithPagination(jql string, startAt, maxResults int) ([]*client.Issue, int, error) {
+	if !c.tracker.take() {
+		return nil, 0, ErrBudgetExhausted
+	}
+	return c.Client.SearchIssuesWithPagination(jql, startAt, maxResults)
+}
+
+// SetBudget limits the API calls and wall time of the engine's syncs
+// Once the budget is spent, no further issues are started: the sync returns a partial result
+// whose Budget lists the issues left for a later run. The clock starts when SetBudget is called.
+func (b *BatchSyncEngine) SetBudget(budget SyncBudget) {
+	b.UseBudget(NewBudgetTracker(b.client, budget))
+}
+
+// UseBudget limits the engine's syncs like SetBudget, with a budget tracked since it was
+// started, so requests made while setting up the sync count too. A nil tracker is unlimited.
+func (b *BatchSyncEngine) UseBudget(tracker *BudgetTracker) {
+	if tracker == nil {
+		return
+	}
+	b.budget = tracker
+	b.client = &budgetClient{Client: b.client, tracker: tracker}
+}
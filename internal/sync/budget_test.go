@@ -0,0 +1,167 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+func newBudgetTestEngine(issueCount int) (*BatchSyncEngine, *client.MockClient, []string) {
+	mockClient := client.NewMockClient()
+	var issues []string
+	for i := 1; i <= issueCount; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		issues = append(issues, key)
+		mockClient.Issues[key] = &client.Issue{Key: key, Summary: "Test issue " + key}
+	}
+	mockGit := git.NewMockRepository()
+	mockGit.Repositories["/test/repo"] = true
+	engine := NewBatchSyncEngine(mockClient, schema.NewMockFileWriter(), mockGit, links.NewMockLinkManager(), 1)
+	return engine, mockClient, issues
+}
+
+func TestBatchSyncEngine_APICallBudget(t *testing.T) {
+	engine, mockClient, issues := newBudgetTestEngine(10)
+	engine.SetBudget(SyncBudget{MaxAPICalls: 4})
+
+	result, err := engine.SyncIssues(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+
+	if result.ProcessedIssues != 4 || result.SuccessfulSync != 4 || result.FailedSync != 0 {
+		t.Errorf("Expected 4 issues synced within budget, got processed=%d success=%d failed=%d",
+			result.ProcessedIssues, result.SuccessfulSync, result.FailedSync)
+	}
+	if mockClient.GetIssueCallCount != 4 {
+		t.Errorf("Expected 4 API calls, got %d", mockClient.GetIssueCallCount)
+	}
+
+	usage := result.Budget
+	if usage == nil || !usage.Exhausted || usage.Reason != BudgetReasonAPICalls {
+		t.Fatalf("Expected exhausted API call budget, got %+v", usage)
+	}
+	if usage.APICalls != 4 || usage.RemainingCount != 6 || !reflect.DeepEqual(usage.Remaining, issues[4:]) {
+		t.Errorf("Unexpected budget usage %+v", usage)
+	}
+}
+
+func TestBatchSyncEngine_BudgetNotExhausted(t *testing.T) {
+	engine, _, issues := newBudgetTestEngine(3)
+	engine.SetBudget(SyncBudget{MaxAPICalls: 10, MaxDuration: time.Hour})
+
+	result, err := engine.SyncIssuesSync(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssuesSync() error = %v", err)
+	}
+	if result.SuccessfulSync != 3 || result.Budget == nil || result.Budget.Exhausted || result.Budget.APICalls != 3 {
+		t.Errorf("Expected all issues synced with 3 of 10 calls used, got %+v", result.Budget)
+	}
+
+	// Without a budget nothing is tracked
+	plain, _, _ := newBudgetTestEngine(1)
+	result, err = plain.SyncIssuesSync(context.Background(), []string{"PROJ-1"}, "/test/repo")
+	if err != nil || result.Budget != nil {
+		t.Errorf("Expected no budget usage without a budget, got %+v (err %v)", result.Budget, err)
+	}
+}
+
+func TestBatchSyncEngine_DurationBudget(t *testing.T) {
+	engine, _, issues := newBudgetTestEngine(3)
+	engine.SetBudget(SyncBudget{MaxDuration: time.Nanosecond})
+	time.Sleep(time.Millisecond)
+
+	result, err := engine.SyncIssuesSync(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssuesSync() error = %v", err)
+	}
+	if result.ProcessedIssues != 0 || result.Budget.Reason != BudgetReasonDuration || result.Budget.RemainingCount != 3 {
+		t.Errorf("Expected nothing started after the deadline, got processed=%d budget=%+v", result.ProcessedIssues, result.Budget)
+	}
+}
+
+func TestBatchSyncEngine_StreamBudget(t *testing.T) {
+	engine, mockClient, issues := newBudgetTestEngine(10)
+	mockClient.AddJQLResult("project = PROJ", issues)
+
+	// One call for the first page leaves room for two issues
+	engine.SetBudget(SyncBudget{MaxAPICalls: 3})
+	result, err := engine.SyncJQLStream(context.Background(), "project = PROJ", "/test/repo", 5)
+	if err != nil {
+		t.Fatalf("SyncJQLStream() error = %v", err)
+	}
+	if result.ProcessedIssues != 2 || result.TotalIssues != 10 {
+		t.Errorf("Expected 2 of 10 issues processed, got %d of %d", result.ProcessedIssues, result.TotalIssues)
+	}
+	if !result.Budget.Exhausted || result.Budget.APICalls != 3 || result.Budget.RemainingCount != 8 {
+		t.Errorf("Unexpected budget usage %+v", result.Budget)
+	}
+}
+
+// observedClient reports its requests like a JIRA client's transport: each issue fetch also
+// reads a page of worklogs
+type observedClient struct {
+	*client.MockClient
+	observe func()
+}
+
+func (c *observedClient) ObserveRequests(observe func()) (func(), bool) {
+	c.observe = observe
+	return func() { c.observe = nil }, true
+}
+
+func (c *observedClient) request() {
+	if c.observe != nil {
+		c.observe()
+	}
+}
+
+func (c *observedClient) GetIssue(issueKey string) (*client.Issue, error) {
+	c.request()
+	c.request()
+	return c.MockClient.GetIssue(issueKey)
+}
+
+func (c *observedClient) CheckProjectAccess(projectKey string) (*client.ProjectAccess, error) {
+	c.request()
+	return c.MockClient.CheckProjectAccess(projectKey)
+}
+
+func TestBatchSyncEngine_BudgetCountsClientRequests(t *testing.T) {
+	_, mockClient, issues := newBudgetTestEngine(10)
+	observed := &observedClient{MockClient: mockClient}
+	mockGit := git.NewMockRepository()
+	mockGit.Repositories["/test/repo"] = true
+	engine := NewBatchSyncEngine(observed, schema.NewMockFileWriter(), mockGit, links.NewMockLinkManager(), 1)
+
+	// A permission check before syncing counts, and each issue costs its fetch and worklog page
+	tracker := NewBudgetTracker(observed, SyncBudget{MaxAPICalls: 7})
+	_, _ = observed.CheckProjectAccess("PROJ")
+	engine.UseBudget(tracker)
+
+	result, err := engine.SyncIssuesSync(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssuesSync() error = %v", err)
+	}
+	if result.ProcessedIssues != 3 {
+		t.Errorf("Expected 3 issues synced within budget, got %d", result.ProcessedIssues)
+	}
+	if usage := result.Budget; usage == nil || usage.APICalls != 7 || !usage.Exhausted || usage.RemainingCount != 7 {
+		t.Errorf("Unexpected budget usage %+v", usage)
+	}
+
+	tracker.Stop()
+	if observed.observe != nil {
+		t.Error("Expected Stop to stop observing the client's requests")
+	}
+	if NewBudgetTracker(observed, SyncBudget{}) != nil {
+		t.Error("Expected no tracker for an unlimited budget")
+	}
+}
@@ -0,0 +1,75 @@
+package sync
+
+import (
+	"fmt"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// SetBulkFetchSize makes issue-list syncs fetch issue data in batches of size issues, one
+// search call per batch, instead of one request per issue. 0 uses client.DefaultBulkFetchSize,
+// 1 fetches issues one at a time, and sizes above client.MaxBulkFetchSize are capped.
+// Issues a batch does not return, or a whole batch that fails, are fetched individually.
+func (b *BatchSyncEngine) SetBulkFetchSize(size int) {
+	switch {
+	case size == 0:
+		size = client.DefaultBulkFetchSize
+	case size > client.MaxBulkFetchSize:
+		size = client.MaxBulkFetchSize
+	}
+	b.bulkFetchSize = size
+}
+
+// fetchBatchSize is the number of issues admitted and fetched together; 1 without bulk fetching
+func (b *BatchSyncEngine) fetchBatchSize() int {
+	if b.bulkFetchSize < 2 {
+		return 1
+	}
+	c := b.client
+	if budgeted, ok := c.(*budgetClient); ok {
+		c = budgeted.Client
+	}
+	if cached, ok := c.(*FetchOnceClient); ok {
+		c = cached.Client
+	}
+	if _, ok := c.(client.BulkIssueClient); !ok {
+		return 1
+	}
+	return b.bulkFetchSize
+}
+
+// prefetchIssues bulk fetches a batch of admitted issues for the workers
+// Issues missing from the result are left out, so workers fetch them individually and report
+// why. Nil is returned when the client cannot bulk fetch or the request fails. Issues fetched
+// individually after a bulk fetch may use more calls than a budget reserved for the batch.
+func (b *BatchSyncEngine) prefetchIssues(issueKeys []string) map[string]*client.Issue {
+	if len(issueKeys) < 2 {
+		return nil
+	}
+	bulk, ok := b.client.(client.BulkIssueClient)
+	if !ok {
+		return nil
+	}
+	issues, err := bulk.GetIssues(issueKeys)
+	if err != nil {
+		return nil
+	}
+
+	fetched := make(map[string]*client.Issue, len(issues))
+	for _, issue := range issues {
+		fetched[issue.Key] = issue
+	}
+	return fetched
+}
+
+// GetIssues forwards bulk fetches, counting each as one request against the budget
+func (c *budgetClient) GetIssues(issueKeys []string) ([]*client.Issue, error) {
+	bulk, ok := c.Client.(client.BulkIssueClient)
+	if !ok {
+		return nil, fmt.Errorf("JIRA client does not support bulk issue fetches")
+	}
+	if !c.tracker.take() {
+		return nil, ErrBudgetExhausted
+	}
+	return bulk.GetIssues(issueKeys)
+}
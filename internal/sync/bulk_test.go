@@ -0,0 +1,102 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestBatchSyncEngine_BulkFetch(t *testing.T) {
+	engine, mockClient, issues := newBudgetTestEngine(120)
+	engine.SetBulkFetchSize(50)
+
+	result, err := engine.SyncIssues(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	if result.SuccessfulSync != 120 {
+		t.Errorf("Expected 120 issues synced, got %d", result.SuccessfulSync)
+	}
+	if mockClient.GetIssuesCallCount != 3 || mockClient.GetIssueCallCount != 0 {
+		t.Errorf("Expected 3 bulk fetches and no single fetches, got %d bulk and %d single",
+			mockClient.GetIssuesCallCount, mockClient.GetIssueCallCount)
+	}
+}
+
+func TestBatchSyncEngine_BulkFetchFallback(t *testing.T) {
+	engine, mockClient, issues := newBudgetTestEngine(4)
+	engine.SetBulkFetchSize(10)
+
+	// Issues the bulk fetch does not return are fetched individually, reporting why they failed
+	issues = append(issues, "PROJ-404")
+	result, err := engine.SyncIssues(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	if result.SuccessfulSync != 4 || result.FailedSync != 1 || result.Errors[0].IssueKey != "PROJ-404" {
+		t.Errorf("Expected PROJ-404 to fail alone, got %+v", result.Errors)
+	}
+	if mockClient.GetIssuesCallCount != 1 || mockClient.GetIssueCallCount != 1 {
+		t.Errorf("Expected 1 bulk and 1 single fetch, got %d and %d", mockClient.GetIssuesCallCount, mockClient.GetIssueCallCount)
+	}
+
+	// A failed bulk fetch falls back to one request per issue
+	engine, mockClient, issues = newBudgetTestEngine(3)
+	engine.SetBulkFetchSize(10)
+	engine.client = &failingBulkClient{MockClient: mockClient}
+	result, err = engine.SyncIssues(context.Background(), issues, "/test/repo")
+	if err != nil || result.SuccessfulSync != 3 || mockClient.GetIssueCallCount != 3 {
+		t.Errorf("Expected 3 single fetches after a failed bulk fetch, got %d (err %v)", mockClient.GetIssueCallCount, err)
+	}
+}
+
+func TestBatchSyncEngine_BulkFetchBudget(t *testing.T) {
+	engine, mockClient, issues := newBudgetTestEngine(10)
+	engine.SetBulkFetchSize(4)
+	engine.SetBudget(SyncBudget{MaxAPICalls: 2})
+
+	result, err := engine.SyncIssues(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	// Each batch of 4 issues costs one call
+	if result.SuccessfulSync != 8 || mockClient.GetIssuesCallCount != 2 {
+		t.Errorf("Expected 8 issues in 2 bulk fetches, got %d in %d", result.SuccessfulSync, mockClient.GetIssuesCallCount)
+	}
+	if result.Budget == nil || !result.Budget.Exhausted || result.Budget.RemainingCount != 2 {
+		t.Errorf("Expected 2 issues left by the budget, got %+v", result.Budget)
+	}
+}
+
+// failingBulkClient is a mock client whose bulk fetches fail
+type failingBulkClient struct {
+	*client.MockClient
+}
+
+func (c *failingBulkClient) GetIssues(issueKeys []string) ([]*client.Issue, error) {
+	return nil, errors.New("search unavailable")
+}
+
+// The bulk benchmarks compare API calls per issue-list sync; see the calls/op metric
+func BenchmarkSyncIssues_PerIssueFetch(b *testing.B) {
+	benchmarkSyncIssuesFetch(b, 1)
+}
+
+func BenchmarkSyncIssues_BulkFetch(b *testing.B) {
+	benchmarkSyncIssuesFetch(b, 0)
+}
+
+func benchmarkSyncIssuesFetch(b *testing.B, bulkFetchSize int) {
+	calls := 0
+	for i := 0; i < b.N; i++ {
+		engine, mockClient, issues := newBudgetTestEngine(500)
+		engine.SetBulkFetchSize(bulkFetchSize)
+		if _, err := engine.SyncIssues(context.Background(), issues, "/test/repo"); err != nil {
+			b.Fatal(err)
+		}
+		calls += mockClient.GetIssueCallCount + mockClient.GetIssuesCallCount
+	}
+	b.ReportMetric(float64(calls)/float64(b.N), "calls/op")
+}
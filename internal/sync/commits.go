@@ -0,0 +1,221 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+)
+
+// CommitStrategy decides how synced issue files are committed
+type CommitStrategy string
+
+const (
+	// CommitPerIssue commits each issue file as it is synced, with a conventional commit message
+	CommitPerIssue CommitStrategy = "per-issue"
+	// CommitBatch commits a run's changed issue files together, listing the added and modified issues
+	CommitBatch CommitStrategy = "batch"
+	// CommitSummary commits a run's changed issue files together with a one-line count
+	CommitSummary CommitStrategy = "summary"
+)
+
+// DefaultCommitListLimit caps how many issues a batch commit message lists before "+N more"
+const DefaultCommitListLimit = 50
+
+// ParseCommitStrategy parses a commit strategy name (empty selects per-issue)
+func ParseCommitStrategy(value string) (CommitStrategy, error) {
+	switch strategy := CommitStrategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case "":
+		return CommitPerIssue, nil
+	case CommitPerIssue, CommitBatch, CommitSummary:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown commit strategy '%s' (use per-issue, batch, or summary)", value)
+	}
+}
+
+// ValidateCheckpointInterval checks a checkpoint interval against the commit strategy it applies to
+// Per-issue commits are already incremental, so an interval only goes with batch or summary.
+func ValidateCheckpointInterval(strategy CommitStrategy, interval int) error {
+	if interval < 0 {
+		return fmt.Errorf("checkpoint interval cannot be negative, got %d", interval)
+	}
+	if interval > 0 && strategy != CommitBatch && strategy != CommitSummary {
+		return fmt.Errorf("a checkpoint interval requires the batch or summary commit strategy")
+	}
+	return nil
+}
+
+// IssueChange is an issue file written by a run whose commit is deferred to the end of the run
+type IssueChange struct {
+	IssueKey string
+	Summary  string
+	FilePath string
+	Action   string // PlanActionAdd or PlanActionUpdate
+}
+
+// pendingCommit collects the issue files a run changed, for strategies that commit once per run
+type pendingCommit struct {
+	mu      sync.Mutex
+	changes []IssueChange
+}
+
+// record adds a changed issue file; safe for concurrent workers
+func (p *pendingCommit) record(change IssueChange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changes = append(p.changes, change)
+}
+
+// takeAtLeast returns the recorded changes like take once there are at least n, and nil before
+func (p *pendingCommit) takeAtLeast(n int) []IssueChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.changes) < n {
+		return nil
+	}
+	return p.takeLocked()
+}
+
+// take returns the recorded changes sorted by issue key and clears them
+func (p *pendingCommit) take() []IssueChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.takeLocked()
+}
+
+// takeLocked is take for callers holding the lock
+func (p *pendingCommit) takeLocked() []IssueChange {
+	changes := p.changes
+	p.changes = nil
+	sort.Slice(changes, func(i, j int) bool { return changes[i].IssueKey < changes[j].IssueKey })
+	return changes
+}
+
+// fileChecksum returns a file's content checksum, or "" when it does not exist yet
+func fileChecksum(filePath string) string {
+	checksum, err := state.CalculateFileChecksum(filePath)
+	if err != nil {
+		return ""
+	}
+	return checksum
+}
+
+// SetCommitStrategy sets how synced issue files are committed (per-issue by default)
+func (b *BatchSyncEngine) SetCommitStrategy(strategy CommitStrategy) {
+	b.commitStrategy = strategy
+}
+
+// SetCheckpointInterval makes the batch and summary strategies commit every interval changed
+// issues instead of once at the end of the run, so an interrupted run keeps its progress
+// An interval of 0 keeps the single commit per run.
+func (b *BatchSyncEngine) SetCheckpointInterval(interval int) {
+	b.checkpointInterval = interval
+}
+
+// deferCommits reports whether issue files are committed once at the end of the run
+func (b *BatchSyncEngine) deferCommits() bool {
+	return b.commitStrategy == CommitBatch || b.commitStrategy == CommitSummary
+}
+
+// commitPending commits the issue files the run changed in one commit
+// Files whose content did not change were never recorded, so they are neither committed nor listed.
+func (b *BatchSyncEngine) commitPending(repoPath string) error {
+	return b.commitChanges(repoPath, b.pending.take())
+}
+
+// commitCheckpoint commits the deferred changes once a checkpoint interval's worth has collected
+func (b *BatchSyncEngine) commitCheckpoint(repoPath string) error {
+	if b.checkpointInterval <= 0 {
+		return nil
+	}
+	return b.commitChanges(repoPath, b.pending.takeAtLeast(b.checkpointInterval))
+}
+
+// commitChanges commits deferred issue changes in one commit
+func (b *BatchSyncEngine) commitChanges(repoPath string, changes []IssueChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	b.commitMu.Lock()
+	defer b.commitMu.Unlock()
+
+	filePaths := make([]string, len(changes))
+	for i, change := range changes {
+		filePaths[i] = change.FilePath
+	}
+
+	message := FormatSummaryCommitMessage(changes)
+	if b.commitStrategy == CommitBatch {
+		message = FormatBatchCommitMessage(changes, DefaultCommitListLimit)
+	}
+	if err := b.gitRepo.CommitFiles(repoPath, message, filePaths...); err != nil {
+		return fmt.Errorf("failed to commit %d synced issue(s): %w", len(changes), err)
+	}
+	return nil
+}
+
+// FormatSummaryCommitMessage describes a run's commit by its issue counts alone
+func FormatSummaryCommitMessage(changes []IssueChange) string {
+	return fmt.Sprintf("chore(sync): sync %d issue(s)", len(changes))
+}
+
+// FormatBatchCommitMessage describes a run's commit by the issues it adds and modifies
+// At most limit issues are listed across both sections; the rest are counted as "+N more".
+func FormatBatchCommitMessage(changes []IssueChange, limit int) string {
+	var added, modified []IssueChange
+	for _, change := range changes {
+		if change.Action == PlanActionAdd {
+			added = append(added, change)
+		} else {
+			modified = append(modified, change)
+		}
+	}
+
+	if len(changes) == 1 {
+		verb := "update"
+		if len(added) == 1 {
+			verb = "add"
+		}
+		change := changes[0]
+		return fmt.Sprintf("chore(sync): %s %s - %s", verb, change.IssueKey, change.Summary)
+	}
+
+	subject := fmt.Sprintf("chore(sync): sync %d issue(s) (%d added, %d modified)",
+		len(changes), len(added), len(modified))
+
+	var body strings.Builder
+	remaining := limit
+	for _, section := range []struct {
+		title   string
+		changes []IssueChange
+	}{{"Added", added}, {"Modified", modified}} {
+		if len(section.changes) == 0 {
+			continue
+		}
+		if body.Len() > 0 {
+			body.WriteString("\n")
+		}
+		body.WriteString(section.title + ":\n")
+		shown := min(len(section.changes), max(remaining, 0))
+		for _, change := range section.changes[:shown] {
+			fmt.Fprintf(&body, "- %s: %s\n", change.IssueKey, change.Summary)
+		}
+		if hidden := len(section.changes) - shown; hidden > 0 {
+			fmt.Fprintf(&body, "- +%d more\n", hidden)
+		}
+		remaining -= shown
+	}
+
+	return subject + "\n\n" + strings.TrimRight(body.String(), "\n")
+}
+
+// formatIssueKeyList joins issue keys, listing at most limit of them before "+N more"
+func formatIssueKeyList(issueKeys []string, limit int) string {
+	if len(issueKeys) <= limit {
+		return strings.Join(issueKeys, ", ")
+	}
+	return fmt.Sprintf("%s +%d more", strings.Join(issueKeys[:limit], ", "), len(issueKeys)-limit)
+}
@@ -0,0 +1,136 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+func TestParseCommitStrategy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    CommitStrategy
+		wantErr bool
+	}{
+		{"", CommitPerIssue, false},
+		{"per-issue", CommitPerIssue, false},
+		{"batch", CommitBatch, false},
+		{" Summary ", CommitSummary, false},
+		{"squash", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCommitStrategy(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseCommitStrategy(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseCommitStrategy(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBatchCommitMessage(t *testing.T) {
+	changes := []IssueChange{
+		{IssueKey: "PROJ-1", Summary: "New login page", Action: PlanActionAdd},
+		{IssueKey: "PROJ-2", Summary: "Fix crash", Action: PlanActionUpdate},
+		{IssueKey: "PROJ-3", Summary: "Docs", Action: PlanActionUpdate},
+	}
+
+	message := FormatBatchCommitMessage(changes, DefaultCommitListLimit)
+	want := "chore(sync): sync 3 issue(s) (1 added, 2 modified)\n\n" +
+		"Added:\n- PROJ-1: New login page\n\n" +
+		"Modified:\n- PROJ-2: Fix crash\n- PROJ-3: Docs"
+	if message != want {
+		t.Errorf("message =\n%s\nwant\n%s", message, want)
+	}
+
+	truncated := FormatBatchCommitMessage(changes, 2)
+	if !strings.Contains(truncated, "- PROJ-2: Fix crash\n- +1 more") || strings.Contains(truncated, "PROJ-3") {
+		t.Errorf("Expected the list to stop after 2 issues with +1 more, got:\n%s", truncated)
+	}
+
+	single := FormatBatchCommitMessage(changes[:1], DefaultCommitListLimit)
+	if single != "chore(sync): add PROJ-1 - New login page" {
+		t.Errorf("single-issue message = %q", single)
+	}
+}
+
+func TestBatchSyncEngine_BatchCommitStrategy(t *testing.T) {
+	repoPath := t.TempDir()
+	mockClient := client.NewMockClient()
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-1", Summary: "First", IssueType: "Story"})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-2", Summary: "Second", IssueType: "Bug"})
+	mockGit := git.NewMockRepository()
+	_ = mockGit.Initialize(repoPath)
+
+	engine := NewBatchSyncEngine(mockClient, schema.NewYAMLFileWriter(), mockGit, links.NewMockLinkManager(), 2)
+	engine.SetCommitStrategy(CommitBatch)
+
+	result, err := engine.SyncIssues(context.Background(), []string{"PROJ-1", "PROJ-2"}, repoPath)
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	if result.SuccessfulSync != 2 {
+		t.Fatalf("SuccessfulSync = %d, want 2", result.SuccessfulSync)
+	}
+	if mockGit.CommitCallCount != 1 {
+		t.Fatalf("Expected one commit for the run, got %d", mockGit.CommitCallCount)
+	}
+	committed := mockGit.CommittedFiles[repoPath]
+	if len(committed) != 2 || !strings.HasPrefix(committed[0].CommitMessage, "chore(sync): sync 2 issue(s) (2 added, 0 modified)") {
+		t.Errorf("Unexpected commit: %d files, message %q", len(committed), committed[0].CommitMessage)
+	}
+
+	// A second run over unchanged issues writes identical files, so nothing is committed
+	if _, err := engine.SyncIssues(context.Background(), []string{"PROJ-1", "PROJ-2"}, repoPath); err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	if mockGit.CommitCallCount != 1 {
+		t.Errorf("Expected no commit for unchanged issues, got %d commits", mockGit.CommitCallCount)
+	}
+
+	// A changed issue is listed as modified
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-2", Summary: "Second, renamed", IssueType: "Bug"})
+	if _, err := engine.SyncIssues(context.Background(), []string{"PROJ-1", "PROJ-2"}, repoPath); err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	committed = mockGit.CommittedFiles[repoPath]
+	last := committed[len(committed)-1]
+	if mockGit.CommitCallCount != 2 || last.CommitMessage != "chore(sync): update PROJ-2 - Second, renamed" {
+		t.Errorf("Expected a commit for PROJ-2 alone, got %d commits, last %q", mockGit.CommitCallCount, last.CommitMessage)
+	}
+}
+
+func TestBatchSyncEngine_CheckpointInterval(t *testing.T) {
+	repoPath := t.TempDir()
+	mockClient := client.NewMockClient()
+	var keys []string
+	for i := 1; i <= 5; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		mockClient.AddIssue(&client.Issue{Key: key, Summary: "Issue " + key, IssueType: "Story"})
+		keys = append(keys, key)
+	}
+	mockGit := git.NewMockRepository()
+	_ = mockGit.Initialize(repoPath)
+
+	engine := NewBatchSyncEngine(mockClient, schema.NewYAMLFileWriter(), mockGit, links.NewMockLinkManager(), 2)
+	engine.SetCommitStrategy(CommitSummary)
+	engine.SetCheckpointInterval(2)
+
+	if _, err := engine.SyncIssues(context.Background(), keys, repoPath); err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	// Two checkpoints of 2 issues, then the remaining issue at the end of the run
+	if mockGit.CommitCallCount != 3 {
+		t.Fatalf("Expected 3 commits, got %d", mockGit.CommitCallCount)
+	}
+	if committed := mockGit.CommittedFiles[repoPath]; len(committed) != 5 {
+		t.Errorf("Expected every issue file to be committed once, got %d", len(committed))
+	}
+}
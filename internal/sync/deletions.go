@@ -0,0 +1,168 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+)
+
+// DeletionCheck classifies mirrored issues that JIRA no longer returns
+// A 404 means the issue was deleted; a 403 means it still exists but can no longer be read, so
+// its file must be kept.
+type DeletionCheck struct {
+	Deleted      []string `json:"deleted,omitempty"`
+	AccessDenied []string `json:"access_denied,omitempty"`
+}
+
+// fetchFailureStatus returns 404 or 403 when an error reports a missing or forbidden issue, else 0
+func fetchFailureStatus(err error) int {
+	var clientErr *client.ClientError
+	if !errors.As(err, &clientErr) {
+		return 0
+	}
+	switch {
+	case clientErr.Type == "not_found" || clientErr.StatusCode == http.StatusNotFound:
+		return http.StatusNotFound
+	case clientErr.Type == "authorization_error" || clientErr.StatusCode == http.StatusForbidden:
+		return http.StatusForbidden
+	}
+	return 0
+}
+
+// add classifies one failed fetch, ignoring failures that are neither 404 nor 403
+func (c *DeletionCheck) add(issueKey string, err error) {
+	switch fetchFailureStatus(err) {
+	case http.StatusNotFound:
+		c.Deleted = append(c.Deleted, issueKey)
+	case http.StatusForbidden:
+		c.AccessDenied = append(c.AccessDenied, issueKey)
+	}
+}
+
+// sort orders both lists by issue key
+func (c *DeletionCheck) sort() {
+	for _, keys := range [][]string{c.Deleted, c.AccessDenied} {
+		sort.Slice(keys, func(i, j int) bool {
+			return client.CompareIssueKeys(keys[i], keys[j]) < 0
+		})
+	}
+}
+
+// ClassifyFetchFailures finds the failed issues of a sync that have a file in the repository and
+// that JIRA reported as deleted (404) or forbidden (403)
+// Failures of issues never mirrored, such as mistyped keys, are not deletions.
+func ClassifyFetchFailures(repoPath string, result *BatchResult) DeletionCheck {
+	var check DeletionCheck
+	for _, batchErr := range result.Errors {
+		if !issueFileExists(repoPath, batchErr.IssueKey) {
+			continue
+		}
+		check.add(batchErr.IssueKey, batchErr.Error)
+	}
+	check.sort()
+	return check
+}
+
+// ProbeIssues fetches mirrored issues one at a time to find those deleted or no longer readable
+// A JQL search silently leaves deleted issues out, so the issues that left a query's results are
+// probed to tell deletions apart from issues that merely stopped matching. Issues that are still
+// readable, or that fail for other reasons, are left alone.
+func ProbeIssues(jiraClient client.Client, issueKeys []string) DeletionCheck {
+	var check DeletionCheck
+	for _, issueKey := range issueKeys {
+		if _, err := jiraClient.GetIssue(issueKey); err != nil {
+			check.add(issueKey, err)
+		}
+	}
+	check.sort()
+	return check
+}
+
+// Merge adds the issues of another check that this one does not list yet
+func (c *DeletionCheck) Merge(other DeletionCheck) {
+	seen := make(map[string]bool, len(c.Deleted)+len(c.AccessDenied))
+	for _, issueKey := range append(append([]string{}, c.Deleted...), c.AccessDenied...) {
+		seen[issueKey] = true
+	}
+	for _, issueKey := range other.Deleted {
+		if !seen[issueKey] {
+			c.Deleted = append(c.Deleted, issueKey)
+		}
+	}
+	for _, issueKey := range other.AccessDenied {
+		if !seen[issueKey] {
+			c.AccessDenied = append(c.AccessDenied, issueKey)
+		}
+	}
+	c.sort()
+}
+
+// RecordDeletions reports the checked issues in a sync result
+// Deleted issues are no longer counted as failures: their removal is the faithful outcome.
+// Issues that can no longer be read stay failures.
+func (r *BatchResult) RecordDeletions(check DeletionCheck) {
+	r.Deleted = append(r.Deleted, check.Deleted...)
+	r.AccessDenied = append(r.AccessDenied, check.AccessDenied...)
+
+	deleted := make(map[string]bool, len(check.Deleted))
+	for _, issueKey := range check.Deleted {
+		deleted[issueKey] = true
+	}
+	errs := r.Errors[:0]
+	for _, batchErr := range r.Errors {
+		if deleted[batchErr.IssueKey] {
+			r.FailedSync--
+			continue
+		}
+		errs = append(errs, batchErr)
+	}
+	r.Errors = errs
+	for i := range r.IssueResults {
+		if deleted[r.IssueResults[i].IssueKey] {
+			r.IssueResults[i].Step = "deleted"
+			r.IssueResults[i].Message = "deleted in JIRA"
+		}
+	}
+}
+
+// RemoveDeletedIssues removes the files and relationship links of issues deleted in JIRA and
+// commits the removal
+func RemoveDeletedIssues(gitRepo git.Repository, repoPath string, issueKeys []string) (*PruneResult, error) {
+	if len(issueKeys) == 0 {
+		return &PruneResult{Pruned: []PrunedIssue{}}, nil
+	}
+	return removeIssues(gitRepo, repoPath, issueKeys, "", formatDeletionCommitMessage(issueKeys))
+}
+
+// PlanDeletions records the planned removal of deleted issues in a dry-run result
+func PlanDeletions(result *BatchResult, repoPath string, issueKeys []string) {
+	for _, issueKey := range issueKeys {
+		result.Plan = append(result.Plan, PlannedChange{
+			IssueKey: issueKey,
+			Action:   PlanActionDelete,
+			FilePath: filepath.Join(repoPath, "projects", extractProjectKey(issueKey), "issues", issueKey+".yaml"),
+			Message:  "deleted in JIRA",
+		})
+	}
+}
+
+// formatDeletionCommitMessage describes the commit removing deleted issues
+func formatDeletionCommitMessage(issueKeys []string) string {
+	subject := fmt.Sprintf("chore(delete): remove %d issue(s) deleted in JIRA", len(issueKeys))
+	if len(issueKeys) == 1 {
+		subject = fmt.Sprintf("chore(delete): remove %s (deleted in JIRA)", issueKeys[0])
+	}
+	return subject + "\n\nIssues: " + formatIssueKeyList(issueKeys, DefaultCommitListLimit)
+}
+
+// issueFileExists reports whether an issue has a file in the repository
+func issueFileExists(repoPath, issueKey string) bool {
+	_, err := os.Stat(filepath.Join(repoPath, "projects", extractProjectKey(issueKey), "issues", issueKey+".yaml"))
+	return err == nil
+}
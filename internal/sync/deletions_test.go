@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+)
+
+// forbiddingClient answers 403 for some issues and defers to the mock for the rest
+type forbiddingClient struct {
+	*client.MockClient
+	forbidden map[string]bool
+}
+
+func (c *forbiddingClient) GetIssue(issueKey string) (*client.Issue, error) {
+	if c.forbidden[issueKey] {
+		return nil, &client.ClientError{Type: "authorization_error", Message: "access denied", Context: issueKey, StatusCode: 403}
+	}
+	return c.MockClient.GetIssue(issueKey)
+}
+
+func TestClassifyFetchFailures(t *testing.T) {
+	repoPath := setupPruneRepo(t)
+	notFound := &client.ClientError{Type: "not_found", Message: "issue not found", StatusCode: 404}
+	result := &BatchResult{
+		TotalIssues: 5,
+		FailedSync:  4,
+		Errors: []BatchError{
+			{IssueKey: "PROJ-3", Step: "sync", Error: fmt.Errorf("failed to fetch issue PROJ-3: %w", notFound)},
+			{IssueKey: "PROJ-2", Step: "sync", Error: &client.ClientError{Type: "authorization_error", StatusCode: 403}},
+			{IssueKey: "PROJ-99", Step: "sync", Error: notFound}, // never mirrored
+			{IssueKey: "PROJ-4", Step: "sync", Error: fmt.Errorf("connection reset")},
+		},
+		IssueResults: []IssueResult{{IssueKey: "PROJ-3", Step: "sync"}, {IssueKey: "PROJ-2", Step: "sync"}},
+	}
+
+	check := ClassifyFetchFailures(repoPath, result)
+	if !reflect.DeepEqual(check.Deleted, []string{"PROJ-3"}) || !reflect.DeepEqual(check.AccessDenied, []string{"PROJ-2"}) {
+		t.Fatalf("Unexpected check: %+v", check)
+	}
+
+	result.RecordDeletions(check)
+	if result.FailedSync != 3 || len(result.Errors) != 3 {
+		t.Errorf("Expected the deletion to stop counting as a failure, got %d failed, %d errors", result.FailedSync, len(result.Errors))
+	}
+	if !reflect.DeepEqual(result.Deleted, []string{"PROJ-3"}) || !reflect.DeepEqual(result.AccessDenied, []string{"PROJ-2"}) {
+		t.Errorf("Expected deletions reported in the result, got %v / %v", result.Deleted, result.AccessDenied)
+	}
+	if result.IssueResults[0].Step != "deleted" || result.IssueResults[1].Step != "sync" {
+		t.Errorf("Unexpected issue results: %+v", result.IssueResults)
+	}
+}
+
+func TestProbeIssues(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.AddIssue(client.CreateTestIssue("PROJ-2"))
+	jiraClient := &forbiddingClient{MockClient: mock, forbidden: map[string]bool{"PROJ-4": true}}
+
+	check := ProbeIssues(jiraClient, []string{"PROJ-2", "PROJ-4", "PROJ-3"})
+	if !reflect.DeepEqual(check.Deleted, []string{"PROJ-3"}) {
+		t.Errorf("Expected PROJ-3 deleted, got %v", check.Deleted)
+	}
+	if !reflect.DeepEqual(check.AccessDenied, []string{"PROJ-4"}) {
+		t.Errorf("Expected PROJ-4 forbidden, got %v", check.AccessDenied)
+	}
+
+	check.Merge(DeletionCheck{Deleted: []string{"PROJ-3", "PROJ-10"}, AccessDenied: []string{"PROJ-4"}})
+	if !reflect.DeepEqual(check.Deleted, []string{"PROJ-3", "PROJ-10"}) || len(check.AccessDenied) != 1 {
+		t.Errorf("Unexpected merged check: %+v", check)
+	}
+}
+
+func TestRemoveDeletedIssues(t *testing.T) {
+	repoPath := setupPruneRepo(t)
+	gitRepo := git.NewMockRepository()
+	if err := gitRepo.Initialize(repoPath); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	result, err := RemoveDeletedIssues(gitRepo, repoPath, []string{"PROJ-4"})
+	if err != nil {
+		t.Fatalf("RemoveDeletedIssues() error = %v", err)
+	}
+	if len(result.Pruned) != 1 || result.LinksRemoved == 0 {
+		t.Errorf("Expected PROJ-4 and its links removed, got %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-4.yaml")); !os.IsNotExist(err) {
+		t.Error("Expected PROJ-4 to be removed")
+	}
+
+	committed := gitRepo.CommittedFiles[repoPath]
+	if len(committed) != 1 || !strings.HasPrefix(committed[0].CommitMessage, "chore(delete): remove PROJ-4 (deleted in JIRA)") {
+		t.Errorf("Unexpected commits: %+v", committed)
+	}
+}
@@ -0,0 +1,99 @@
+package sync
+
+import (
+	"fmt"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// DefaultExpandMaxIssues caps how many issues a relationship expansion may add to a sync
+const DefaultExpandMaxIssues = 200
+
+// ExpansionResult is the sync set produced by following relationships from requested issues
+type ExpansionResult struct {
+	Keys      []string // requested issues first, then added issues in discovery order
+	Requested int
+	Added     int
+	Truncated bool     // the issue limit stopped the expansion early
+	Skipped   []string // added issues that could not be fetched, so were not expanded further
+}
+
+// ExpandRelationships follows relationships from the requested issues up to depth hops
+// EPIC links, parents, subtasks and issue links are all followed. Expansion is breadth-first,
+// so closer issues are always added before more distant ones, and stops once the sync set
+// reaches maxIssues. A requested issue that cannot be fetched is kept in the set so the sync
+// reports its error; it just contributes no neighbours.
+func ExpandRelationships(jiraClient client.Client, requested []string, depth, maxIssues int) (*ExpansionResult, error) {
+	if depth < 0 {
+		return nil, fmt.Errorf("expansion depth cannot be negative, got %d", depth)
+	}
+	if maxIssues < len(requested) {
+		return nil, fmt.Errorf("issue limit %d is smaller than the %d requested issues", maxIssues, len(requested))
+	}
+
+	result := &ExpansionResult{Requested: len(requested)}
+	seen := make(map[string]bool, len(requested))
+	for _, key := range requested {
+		if !seen[key] {
+			seen[key] = true
+			result.Keys = append(result.Keys, key)
+		}
+	}
+
+	frontier := append([]string(nil), result.Keys...)
+	for hop := 0; hop < depth && len(frontier) > 0 && !result.Truncated; hop++ {
+		var next []string
+		for _, key := range frontier {
+			issue, err := jiraClient.GetIssue(key)
+			if err != nil {
+				if hop > 0 {
+					result.Skipped = append(result.Skipped, key)
+				}
+				continue
+			}
+
+			for _, related := range relatedIssueKeys(issue) {
+				if seen[related] {
+					continue
+				}
+				if len(result.Keys) >= maxIssues {
+					result.Truncated = true
+					break
+				}
+				seen[related] = true
+				result.Keys = append(result.Keys, related)
+				result.Added++
+				next = append(next, related)
+			}
+			if result.Truncated {
+				break
+			}
+		}
+		frontier = next
+	}
+
+	return result, nil
+}
+
+// relatedIssueKeys lists the issues directly related to an issue, in file order
+func relatedIssueKeys(issue *client.Issue) []string {
+	if issue.Relationships == nil {
+		return nil
+	}
+
+	var keys []string
+	rel := issue.Relationships
+	if rel.EpicLink != "" {
+		keys = append(keys, rel.EpicLink)
+	}
+	if rel.ParentIssue != "" {
+		keys = append(keys, rel.ParentIssue)
+	}
+	keys = append(keys, rel.Subtasks...)
+	for _, link := range rel.IssueLinks {
+		if link.IssueKey != "" {
+			keys = append(keys, link.IssueKey)
+		}
+	}
+	return keys
+}
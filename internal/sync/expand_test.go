@@ -0,0 +1,83 @@
+package sync
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func newExpansionMockClient() *client.MockClient {
+	mockClient := client.NewMockClient()
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-1", Relationships: &client.Relationships{
+		EpicLink: "PROJ-100",
+		Subtasks: []string{"PROJ-2"},
+		IssueLinks: []client.IssueLink{
+			{Type: "blocks", Direction: "outward", IssueKey: "OTHER-5"},
+		},
+	}})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-2", Relationships: &client.Relationships{ParentIssue: "PROJ-1"}})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-100", Relationships: &client.Relationships{
+		IssueLinks: []client.IssueLink{{Type: "relates", Direction: "inward", IssueKey: "PROJ-200"}},
+	}})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-200"})
+	return mockClient
+}
+
+func TestExpandRelationships(t *testing.T) {
+	mockClient := newExpansionMockClient()
+
+	tests := []struct {
+		name      string
+		depth     int
+		maxIssues int
+		wantKeys  []string
+		truncated bool
+		skipped   []string
+	}{
+		{"no expansion", 0, 10, []string{"PROJ-1"}, false, nil},
+		{"direct neighbours", 1, 10, []string{"PROJ-1", "PROJ-100", "PROJ-2", "OTHER-5"}, false, nil},
+		{"two hops", 2, 10, []string{"PROJ-1", "PROJ-100", "PROJ-2", "OTHER-5", "PROJ-200"}, false, []string{"OTHER-5"}},
+		{"limited", 2, 3, []string{"PROJ-1", "PROJ-100", "PROJ-2"}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExpandRelationships(mockClient, []string{"PROJ-1"}, tt.depth, tt.maxIssues)
+			if err != nil {
+				t.Fatalf("ExpandRelationships() error = %v", err)
+			}
+			if !reflect.DeepEqual(result.Keys, tt.wantKeys) {
+				t.Errorf("Keys = %v, want %v", result.Keys, tt.wantKeys)
+			}
+			if result.Requested != 1 || result.Added != len(tt.wantKeys)-1 {
+				t.Errorf("Expected 1 requested and %d added, got %d and %d", len(tt.wantKeys)-1, result.Requested, result.Added)
+			}
+			if result.Truncated != tt.truncated {
+				t.Errorf("Truncated = %v, want %v", result.Truncated, tt.truncated)
+			}
+			if !reflect.DeepEqual(result.Skipped, tt.skipped) {
+				t.Errorf("Skipped = %v, want %v", result.Skipped, tt.skipped)
+			}
+		})
+	}
+}
+
+func TestExpandRelationships_Validation(t *testing.T) {
+	mockClient := newExpansionMockClient()
+
+	if _, err := ExpandRelationships(mockClient, []string{"PROJ-1"}, -1, 10); err == nil {
+		t.Error("Expected error for negative depth")
+	}
+	if _, err := ExpandRelationships(mockClient, []string{"PROJ-1", "PROJ-2"}, 1, 1); err == nil {
+		t.Error("Expected error when the limit is below the requested issues")
+	}
+
+	// Requested issues that cannot be fetched stay in the set without being reported as skipped
+	result, err := ExpandRelationships(mockClient, []string{"MISSING-1", "PROJ-2"}, 1, 10)
+	if err != nil {
+		t.Fatalf("ExpandRelationships() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.Keys, []string{"MISSING-1", "PROJ-2", "PROJ-1"}) || len(result.Skipped) != 0 {
+		t.Errorf("Unexpected expansion %+v", result)
+	}
+}
@@ -0,0 +1,161 @@
+package sync
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// FetchOnceClient remembers the issues and searches it fetches, so several syncs of the same
+// issues (one per destination repository) make each request once. It is meant for a single
+// run: nothing expires, and the cached issues are shared, so callers must not modify them.
+type FetchOnceClient struct {
+	client.Client
+
+	mu          sync.Mutex
+	issues      map[string]*client.Issue
+	searches    map[string][]*client.Issue
+	pages       map[string]searchPage
+	transitions map[string][]client.StatusTransition
+}
+
+type searchPage struct {
+	issues []*client.Issue
+	total  int
+}
+
+// NewFetchOnceClient wraps a client with a per-run issue cache
+func NewFetchOnceClient(c client.Client) *FetchOnceClient {
+	return &FetchOnceClient{
+		Client:      c,
+		issues:      make(map[string]*client.Issue),
+		searches:    make(map[string][]*client.Issue),
+		pages:       make(map[string]searchPage),
+		transitions: make(map[string][]client.StatusTransition),
+	}
+}
+
+// GetIssue returns the cached issue, fetching it the first time
+func (c *FetchOnceClient) GetIssue(issueKey string) (*client.Issue, error) {
+	c.mu.Lock()
+	issue, ok := c.issues[issueKey]
+	c.mu.Unlock()
+	if ok {
+		return issue, nil
+	}
+
+	issue, err := c.Client.GetIssue(issueKey)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.issues[issueKey] = issue
+	c.mu.Unlock()
+	return issue, nil
+}
+
+// GetIssues returns the cached issues, bulk fetching only the ones not seen yet
+func (c *FetchOnceClient) GetIssues(issueKeys []string) ([]*client.Issue, error) {
+	c.mu.Lock()
+	issues := make([]*client.Issue, 0, len(issueKeys))
+	var missing []string
+	for _, key := range issueKeys {
+		if issue, ok := c.issues[key]; ok {
+			issues = append(issues, issue)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	c.mu.Unlock()
+	if len(missing) == 0 {
+		return issues, nil
+	}
+
+	bulk, ok := c.Client.(client.BulkIssueClient)
+	if !ok {
+		return nil, fmt.Errorf("JIRA client does not support bulk issue fetches")
+	}
+	fetched, err := bulk.GetIssues(missing)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	for _, issue := range fetched {
+		c.issues[issue.Key] = issue
+	}
+	c.mu.Unlock()
+	return append(issues, fetched...), nil
+}
+
+// SearchIssues returns the cached results of a query, searching the first time
+func (c *FetchOnceClient) SearchIssues(jql string) ([]*client.Issue, error) {
+	c.mu.Lock()
+	issues, ok := c.searches[jql]
+	c.mu.Unlock()
+	if ok {
+		return issues, nil
+	}
+
+	issues, err := c.Client.SearchIssues(jql)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.searches[jql] = issues
+	c.mu.Unlock()
+	return issues, nil
+}
+
+// SearchIssuesWithPagination returns a cached page of a query, searching the first time
+func (c *FetchOnceClient) SearchIssuesWithPagination(jql string, startAt, maxResults int) ([]*client.Issue, int, error) {
+	key := fmt.Sprintf("%d:%d:%s", startAt, maxResults, jql)
+	c.mu.Lock()
+	page, ok := c.pages[key]
+	c.mu.Unlock()
+	if ok {
+		return page.issues, page.total, nil
+	}
+
+	issues, total, err := c.Client.SearchIssuesWithPagination(jql, startAt, maxResults)
+	if err != nil {
+		return nil, 0, err
+	}
+	c.mu.Lock()
+	c.pages[key] = searchPage{issues: issues, total: total}
+	c.mu.Unlock()
+	return issues, total, nil
+}
+
+// GetStatusTransitions returns an issue's cached status changes, reading its changelog the first time
+func (c *FetchOnceClient) GetStatusTransitions(issueKey string) ([]client.StatusTransition, error) {
+	changelog, ok := c.Client.(client.ChangelogClient)
+	if !ok {
+		return nil, fmt.Errorf("JIRA client does not support issue changelogs")
+	}
+
+	c.mu.Lock()
+	transitions, cached := c.transitions[issueKey]
+	c.mu.Unlock()
+	if cached {
+		return transitions, nil
+	}
+
+	transitions, err := changelog.GetStatusTransitions(issueKey)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.transitions[issueKey] = transitions
+	c.mu.Unlock()
+	return transitions, nil
+}
+
+// ObserveRequests observes the requests of the wrapped client, which sends them
+func (c *FetchOnceClient) ObserveRequests(observe func()) (func(), bool) {
+	observable, ok := c.Client.(client.RequestObserverClient)
+	if !ok {
+		return nil, false
+	}
+	return observable.ObserveRequests(observe)
+}
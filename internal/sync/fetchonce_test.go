@@ -0,0 +1,69 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestFetchOnceClient(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-1", Summary: "First"})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-2", Summary: "Second"})
+	mockClient.AddJQLResult("project = PROJ", []string{"PROJ-1", "PROJ-2"})
+	cached := NewFetchOnceClient(mockClient)
+
+	for i := 0; i < 3; i++ {
+		if _, err := cached.GetIssue("PROJ-1"); err != nil {
+			t.Fatalf("GetIssue() error = %v", err)
+		}
+		if issues, err := cached.SearchIssues("project = PROJ"); err != nil || len(issues) != 2 {
+			t.Fatalf("SearchIssues() = %d issues, %v", len(issues), err)
+		}
+	}
+	if mockClient.GetIssueCallCount != 1 || mockClient.SearchIssuesCallCount != 1 {
+		t.Errorf("Expected 1 fetch and 1 search, got %d and %d", mockClient.GetIssueCallCount, mockClient.SearchIssuesCallCount)
+	}
+
+	// Bulk fetches only request issues that are not cached yet
+	issues, err := cached.GetIssues([]string{"PROJ-1", "PROJ-2"})
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("GetIssues() = %d issues, %v", len(issues), err)
+	}
+	if _, err := cached.GetIssues([]string{"PROJ-2", "PROJ-1"}); err != nil {
+		t.Fatalf("GetIssues() error = %v", err)
+	}
+	if mockClient.GetIssuesCallCount != 1 {
+		t.Errorf("Expected 1 bulk fetch, got %d", mockClient.GetIssuesCallCount)
+	}
+
+	// Failed fetches are not cached
+	if _, err := cached.GetIssue("PROJ-404"); err == nil {
+		t.Error("Expected an error for a missing issue")
+	}
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-404", Summary: "Late"})
+	if _, err := cached.GetIssue("PROJ-404"); err != nil {
+		t.Errorf("Expected a retried fetch to succeed, got %v", err)
+	}
+}
+
+func TestFetchOnceClient_SharedAcrossEngines(t *testing.T) {
+	first, mockClient, issues := newBudgetTestEngine(20)
+	cached := NewFetchOnceClient(mockClient)
+	first.client = cached
+	second, _, _ := newBudgetTestEngine(0)
+	second.client = cached
+	second.gitRepo = first.gitRepo
+
+	for _, engine := range []*BatchSyncEngine{first, second} {
+		engine.SetBulkFetchSize(50)
+		result, err := engine.SyncIssues(context.Background(), issues, "/test/repo")
+		if err != nil || result.SuccessfulSync != 20 {
+			t.Fatalf("SyncIssues() = %d synced, %v", result.SuccessfulSync, err)
+		}
+	}
+	if calls := mockClient.GetIssueCallCount + mockClient.GetIssuesCallCount; calls != 1 {
+		t.Errorf("Expected the second sync to reuse the first one's fetch, got %d calls", calls)
+	}
+}
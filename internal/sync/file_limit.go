@@ -0,0 +1,42 @@
+package sync
+
+import (
+	"fmt"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// DefaultMaxFiles caps how many issue files one sync may write unless the limit is disabled
+// It is far above any single project mirror, but stops a mistyped JQL from filling the disk.
+const DefaultMaxFiles = 50000
+
+// FileLimitError reports a sync scope larger than the file limit
+type FileLimitError struct {
+	Count    int
+	MaxFiles int
+}
+
+func (e *FileLimitError) Error() string {
+	return fmt.Sprintf("sync would write %d issue files, more than the limit of %d; narrow the query, raise --max-files, or pass --no-file-limit", e.Count, e.MaxFiles)
+}
+
+// CountSyncScope returns how many issue files a sync of the given issues or query would write
+// Issue keys are counted directly; a query costs one search requesting a single result.
+func CountSyncScope(jiraClient client.Client, issueKeys []string, query string) (int, error) {
+	if query == "" {
+		return len(issueKeys), nil
+	}
+	_, total, err := jiraClient.SearchIssuesWithPagination(query, 0, 1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count issues for the file limit: %w", err)
+	}
+	return total, nil
+}
+
+// CheckFileLimit returns a *FileLimitError when count exceeds maxFiles
+func CheckFileLimit(count, maxFiles int) error {
+	if count > maxFiles {
+		return &FileLimitError{Count: count, MaxFiles: maxFiles}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package sync
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestCountSyncScope(t *testing.T) {
+	mockClient := client.NewMockClient()
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-3"} {
+		mockClient.AddIssue(&client.Issue{Key: key})
+	}
+	mockClient.AddJQLResult("project = PROJ", []string{"PROJ-1", "PROJ-2", "PROJ-3"})
+
+	if count, err := CountSyncScope(mockClient, nil, "project = PROJ"); err != nil || count != 3 {
+		t.Errorf("CountSyncScope(query) = %d, %v, want 3", count, err)
+	}
+	if count, err := CountSyncScope(mockClient, []string{"PROJ-1", "PROJ-2"}, ""); err != nil || count != 2 {
+		t.Errorf("CountSyncScope(keys) = %d, %v, want 2", count, err)
+	}
+
+	mockClient.SetJQLError(errors.New("bad query"))
+	if _, err := CountSyncScope(mockClient, nil, "project = PROJ"); err == nil || !strings.Contains(err.Error(), "file limit") {
+		t.Errorf("Expected the search failure to be reported, got %v", err)
+	}
+}
+
+func TestCheckFileLimit(t *testing.T) {
+	if err := CheckFileLimit(100, 100); err != nil {
+		t.Errorf("Expected a scope at the limit to pass, got %v", err)
+	}
+
+	err := CheckFileLimit(250000, DefaultMaxFiles)
+	var limitErr *FileLimitError
+	if !errors.As(err, &limitErr) || limitErr.Count != 250000 || limitErr.MaxFiles != DefaultMaxFiles {
+		t.Fatalf("Expected a FileLimitError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "250000 issue files") || !strings.Contains(err.Error(), "--no-file-limit") {
+		t.Errorf("Expected the error to name the count and the way out, got %q", err)
+	}
+}
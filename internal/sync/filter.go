@@ -0,0 +1,23 @@
+package sync
+
+import (
+	"errors"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// IssueFilter decides after fetching whether an issue is written
+// It complements JQL with conditions JIRA cannot evaluate server-side.
+type IssueFilter interface {
+	Match(issue *client.Issue) bool
+}
+
+// errIssueFiltered marks an issue the filter skipped; it is reported in BatchResult.Filtered
+// rather than as a failure
+var errIssueFiltered = errors.New("issue does not match the filter expression")
+
+// SetIssueFilter skips fetched issues that do not match filter: they are not written,
+// committed, or recorded as synced. Nil writes every issue.
+func (b *BatchSyncEngine) SetIssueFilter(filter IssueFilter) {
+	b.issueFilter = filter
+}
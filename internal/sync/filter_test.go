@@ -0,0 +1,33 @@
+package sync
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/filter"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+)
+
+func TestBatchSyncEngine_IssueFilter(t *testing.T) {
+	engine, _, issues := newBudgetTestEngine(5)
+	expr, err := filter.Parse(`key in ["PROJ-2", "PROJ-4"]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.SetIssueFilter(expr)
+
+	result, err := engine.SyncIssuesSync(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssuesSync() error = %v", err)
+	}
+	if result.SuccessfulSync != 2 || result.FailedSync != 0 || len(result.Errors) != 0 {
+		t.Errorf("Expected 2 issues synced and no failures, got success=%d failed=%d", result.SuccessfulSync, result.FailedSync)
+	}
+	if want := []string{"PROJ-1", "PROJ-3", "PROJ-5"}; !reflect.DeepEqual(result.Filtered, want) {
+		t.Errorf("Filtered = %v, want %v", result.Filtered, want)
+	}
+	if committed := len(engine.gitRepo.(*git.MockRepository).CommittedFiles["/test/repo"]); committed != 2 {
+		t.Errorf("Expected only matching issues to be committed, got %d", committed)
+	}
+}
@@ -0,0 +1,110 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// DefaultHookTimeout bounds how long a post-sync hook may run
+const DefaultHookTimeout = 5 * time.Minute
+
+// Post-sync hook events
+const (
+	HookEventSuccess = "success"
+	HookEventFailure = "failure"
+)
+
+// HookSummary is the result of a sync as passed to post-sync hooks, as JSON on stdin
+type HookSummary struct {
+	Event          string       `json:"event"` // success or failure
+	Success        bool         `json:"success"`
+	OperationID    string       `json:"operation_id,omitempty"`
+	Repository     string       `json:"repository,omitempty"`
+	DryRun         bool         `json:"dry_run,omitempty"`
+	Total          int          `json:"total"`
+	Processed      int          `json:"processed"`
+	Successful     int          `json:"successful"`
+	Failed         int          `json:"failed"`
+	DurationMs     int64        `json:"duration_ms"`
+	ProcessedFiles []string     `json:"processed_files,omitempty"`
+	Errors         []BatchError `json:"errors,omitempty"`
+	Error          string       `json:"error,omitempty"` // why the sync failed, when it did
+	FinishedAt     time.Time    `json:"finished_at"`
+}
+
+// NewHookSummary summarizes a sync for hooks; result is nil when the sync did not run to the end
+// A sync succeeded when it returned no error.
+func NewHookSummary(result *BatchResult, syncErr error, finishedAt time.Time) HookSummary {
+	summary := HookSummary{
+		Event:      HookEventSuccess,
+		Success:    syncErr == nil,
+		FinishedAt: finishedAt.UTC().Truncate(time.Second),
+	}
+	if syncErr != nil {
+		summary.Event = HookEventFailure
+		summary.Error = syncErr.Error()
+	}
+	if result != nil {
+		summary.OperationID = result.OperationID
+		summary.Total = result.TotalIssues
+		summary.Processed = result.ProcessedIssues
+		summary.Successful = result.SuccessfulSync
+		summary.Failed = result.FailedSync
+		summary.DurationMs = result.Duration.Milliseconds()
+		summary.ProcessedFiles = result.ProcessedFiles
+		summary.Errors = result.Errors
+	}
+	return summary
+}
+
+// Env returns the summary as JIRA_SYNC_* environment variables, for hooks that do not read JSON
+func (s HookSummary) Env() []string {
+	return []string{
+		"JIRA_SYNC_EVENT=" + s.Event,
+		"JIRA_SYNC_SUCCESS=" + strconv.FormatBool(s.Success),
+		"JIRA_SYNC_OPERATION_ID=" + s.OperationID,
+		"JIRA_SYNC_REPOSITORY=" + s.Repository,
+		"JIRA_SYNC_DRY_RUN=" + strconv.FormatBool(s.DryRun),
+		"JIRA_SYNC_TOTAL=" + strconv.Itoa(s.Total),
+		"JIRA_SYNC_SUCCESSFUL=" + strconv.Itoa(s.Successful),
+		"JIRA_SYNC_FAILED=" + strconv.Itoa(s.Failed),
+		"JIRA_SYNC_DURATION_MS=" + strconv.FormatInt(s.DurationMs, 10),
+		"JIRA_SYNC_ERROR=" + s.Error,
+	}
+}
+
+// RunHook runs a post-sync hook command through sh with the summary as JSON on stdin and in
+// the environment, and returns its combined stdout and stderr
+func RunHook(ctx context.Context, command string, summary HookSummary, timeout time.Duration) ([]byte, error) {
+	input, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sync summary: %w", err)
+	}
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) // #nosec G204 -- the hook command is user-supplied by design
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = append(os.Environ(), summary.Env()...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.WaitDelay = time.Second // do not wait for children of a killed hook that hold its output open
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return output.Bytes(), fmt.Errorf("hook timed out after %s", timeout)
+		}
+		return output.Bytes(), fmt.Errorf("hook failed: %w", err)
+	}
+	return output.Bytes(), nil
+}
@@ -0,0 +1,56 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHookSummary(t *testing.T) {
+	result := &BatchResult{
+		OperationID:     "op-1",
+		TotalIssues:     3,
+		ProcessedIssues: 3,
+		SuccessfulSync:  2,
+		FailedSync:      1,
+		Duration:        1500 * time.Millisecond,
+		Errors:          []BatchError{{IssueKey: "PROJ-3", Step: "fetch", Message: "not found"}},
+	}
+	summary := NewHookSummary(result, errors.New("1 of 3 issues failed to sync"), time.Now())
+	if summary.Event != HookEventFailure || summary.Success || summary.Failed != 1 || summary.DurationMs != 1500 || summary.OperationID != "op-1" {
+		t.Errorf("Unexpected failure summary: %+v", summary)
+	}
+
+	summary = NewHookSummary(nil, nil, time.Now())
+	if summary.Event != HookEventSuccess || !summary.Success || summary.Total != 0 {
+		t.Errorf("Unexpected summary without a result: %+v", summary)
+	}
+}
+
+func TestRunHook(t *testing.T) {
+	summary := NewHookSummary(&BatchResult{TotalIssues: 2, SuccessfulSync: 2}, nil, time.Now())
+	summary.Repository = "/repo"
+
+	output, err := RunHook(context.Background(), `cat; echo; echo "$JIRA_SYNC_EVENT $JIRA_SYNC_SUCCESSFUL $JIRA_SYNC_REPOSITORY" >&2`, summary, time.Minute)
+	if err != nil {
+		t.Fatalf("RunHook() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 2 || lines[1] != "success 2 /repo" {
+		t.Fatalf("Unexpected hook output: %q", output)
+	}
+	var decoded HookSummary
+	if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil || decoded.Successful != 2 || decoded.Repository != "/repo" {
+		t.Errorf("Expected the summary as JSON on stdin, got %q (%v)", lines[0], err)
+	}
+
+	if output, err := RunHook(context.Background(), "echo broken; exit 3", summary, time.Minute); err == nil || strings.TrimSpace(string(output)) != "broken" {
+		t.Errorf("Expected a failing hook error with its output, got %q, %v", output, err)
+	}
+	if _, err := RunHook(context.Background(), "sleep 5", summary, 50*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected a timeout error, got %v", err)
+	}
+}
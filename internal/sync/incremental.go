@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"time"
 
@@ -18,6 +19,7 @@ type IncrementalBatchSyncEngine struct {
 	*BatchSyncEngine
 	stateManager state.StateManager
 	state        *state.SyncState
+	operationID  string // recorded for sync operations instead of a generated ID when set
 }
 
 // IncrementalSyncOptions contains options for incremental sync operations
@@ -29,6 +31,10 @@ type IncrementalSyncOptions struct {
 	Projects        []string      `json:"projects"`
 	IncludeNew      bool          `json:"include_new"`
 	IncludeModified bool          `json:"include_modified"`
+	ShowDiff        bool          `json:"show_diff"` // include file diffs in the dry-run plan
+
+	// StatusTransitionsOnly syncs only issues whose status changed within the window, per the changelog
+	StatusTransitionsOnly bool `json:"status_transitions_only"`
 }
 
 // NewIncrementalBatchSyncEngine creates a new incremental batch sync engine
@@ -49,6 +55,19 @@ func NewIncrementalBatchSyncEngine(
 	}
 }
 
+// SetOperationID records the run's operation ID for the sync operations in the state history,
+// so state entries match the Sync-Operation-Id trailer of the run's commits
+func (e *IncrementalBatchSyncEngine) SetOperationID(operationID string) {
+	e.operationID = operationID
+}
+
+// applyOperationID replaces the generated ID of an operation with the run's ID
+func (e *IncrementalBatchSyncEngine) applyOperationID(operation *state.SyncOperation) {
+	if e.operationID != "" && operation != nil {
+		operation.ID = e.operationID
+	}
+}
+
 // InitializeRepository initializes or loads the sync state for a repository
 func (e *IncrementalBatchSyncEngine) InitializeRepository(repoPath string) error {
 	// Try to load existing state
@@ -97,9 +116,14 @@ func (e *IncrementalBatchSyncEngine) SyncIssuesIncremental(
 
 	// Start sync operation
 	operation := e.stateManager.StartSyncOperation(e.state, state.SyncTypeIncremental, syncConfig)
+	e.applyOperationID(operation)
 
 	// Filter issues based on incremental options
+	var transitions []IssueTransition
 	filteredIssues, err := e.filterIssuesForIncremental(ctx, issues, options)
+	if err == nil && options.StatusTransitionsOnly && !options.Force {
+		filteredIssues, transitions, err = e.filterStatusTransitions(filteredIssues, options)
+	}
 	if err != nil {
 		_ = e.stateManager.FailSyncOperation(e.state, operation, err)
 		_ = e.stateManager.SaveState(repoPath, e.state)
@@ -121,8 +145,16 @@ func (e *IncrementalBatchSyncEngine) SyncIssuesIncremental(
 		}
 
 		_ = e.stateManager.CompleteSyncOperation(e.state, operation, results)
+		if !options.DryRun {
+			e.recordRunSnapshot(repoPath, operation)
+		}
 		_ = e.stateManager.SaveState(repoPath, e.state)
 
+		var plan []PlannedChange
+		if options.DryRun {
+			plan = orderPlan(issues, nil)
+		}
+
 		return &BatchResult{
 			TotalIssues:     len(issues),
 			ProcessedIssues: 0,
@@ -130,19 +162,24 @@ func (e *IncrementalBatchSyncEngine) SyncIssuesIncremental(
 			FailedSync:      0,
 			ProcessedFiles:  make([]string, 0),
 			Errors:          make([]BatchError, 0),
+			IssueResults:    make([]IssueResult, 0),
 			Duration:        time.Since(operation.StartTime),
 			Performance: PerformanceMetrics{
 				IssuesPerSecond: 0,
 				WorkerCount:     e.concurrency,
 				AvgProcessTime:  0,
 			},
+			Plan:        plan,
+			Budget:      e.budget.usage(0, nil),
+			Transitions: transitions,
 		}, nil
 	}
 
 	// Perform the actual sync
 	var result *BatchResult
 	if options.DryRun {
-		result = e.performDryRunSync(ctx, filteredIssues, repoPath)
+		result = e.performDryRunSync(ctx, filteredIssues, repoPath, options.ShowDiff)
+		result.Plan = orderPlan(issues, result.Plan)
 	} else {
 		result, err = e.performIncrementalSync(ctx, filteredIssues, repoPath)
 		if err != nil {
@@ -152,13 +189,15 @@ func (e *IncrementalBatchSyncEngine) SyncIssuesIncremental(
 		}
 	}
 
+	result.Transitions = transitions
+
 	// Update operation results
 	operationResults := state.OperationResults{
 		TotalIssues:     len(issues),
 		ProcessedIssues: result.ProcessedIssues,
 		SuccessfulSync:  result.SuccessfulSync,
 		FailedSync:      result.FailedSync,
-		SkippedIssues:   len(issues) - len(filteredIssues),
+		SkippedIssues:   len(issues) - len(filteredIssues) + len(result.Filtered),
 		ProcessedFiles:  result.ProcessedFiles,
 		ErrorCount:      len(result.Errors),
 	}
@@ -169,6 +208,9 @@ func (e *IncrementalBatchSyncEngine) SyncIssuesIncremental(
 	} else {
 		_ = e.stateManager.FailSyncOperation(e.state, operation, fmt.Errorf("%d issues failed to sync", result.FailedSync))
 	}
+	if !options.DryRun {
+		e.recordRunSnapshot(repoPath, operation)
+	}
 
 	// Save state
 	if err := e.stateManager.SaveState(repoPath, e.state); err != nil {
@@ -178,6 +220,23 @@ func (e *IncrementalBatchSyncEngine) SyncIssuesIncremental(
 	return result, nil
 }
 
+// recordRunSnapshot records the issues mirrored at the end of a run so compare-runs can diff it
+// against other runs, and drops the snapshots of runs that fell out of the history
+// Snapshots are best effort: a sync does not fail because one could not be written.
+func (e *IncrementalBatchSyncEngine) recordRunSnapshot(repoPath string, operation *state.SyncOperation) {
+	if _, err := os.Stat(repoPath); err != nil {
+		return
+	}
+	snapshot, err := state.BuildRunSnapshot(repoPath, operation.ID)
+	if err != nil {
+		return
+	}
+	if err := state.SaveRunSnapshot(repoPath, snapshot); err != nil {
+		return
+	}
+	_ = state.PruneRunSnapshots(repoPath, e.state.History)
+}
+
 // SyncJQLIncremental performs incremental sync for issues matching a JQL query
 func (e *IncrementalBatchSyncEngine) SyncJQLIncremental(
 	ctx context.Context,
@@ -202,6 +261,7 @@ func (e *IncrementalBatchSyncEngine) SyncJQLIncremental(
 
 	// Start sync operation
 	operation := e.stateManager.StartSyncOperation(e.state, state.SyncTypeJQL, syncConfig)
+	e.applyOperationID(operation)
 	operation.Query = jql
 
 	// First, fetch all issues matching the JQL query
@@ -369,6 +429,9 @@ func (e *IncrementalBatchSyncEngine) performIncrementalSync(
 	repoPath string,
 ) (*BatchResult, error) {
 
+	// Each synced issue is fetched again below to record its state
+	e.budget.setIssueCost(2)
+
 	// Use the parent BatchSyncEngine for the actual sync
 	//nolint:staticcheck // Explicit field access needed to avoid method overriding issues
 	result, err := e.BatchSyncEngine.SyncIssues(ctx, issues, repoPath)
@@ -401,10 +464,12 @@ func (e *IncrementalBatchSyncEngine) performIncrementalSync(
 }
 
 // performDryRunSync simulates sync without making changes
+// Each fetched issue is rendered and compared with its file to plan the change.
 func (e *IncrementalBatchSyncEngine) performDryRunSync(
 	ctx context.Context,
 	issues []string,
 	repoPath string,
+	showDiff bool,
 ) *BatchResult {
 
 	startTime := time.Now()
@@ -413,13 +478,21 @@ func (e *IncrementalBatchSyncEngine) performDryRunSync(
 		TotalIssues:    len(issues),
 		ProcessedFiles: make([]string, 0, len(issues)),
 		Errors:         make([]BatchError, 0),
+		IssueResults:   make([]IssueResult, 0, len(issues)),
 		Performance: PerformanceMetrics{
 			WorkerCount: e.concurrency,
 		},
+		Plan: make([]PlannedChange, 0, len(issues)),
 	}
 
 	// Simulate processing each issue
-	for _, issueKey := range issues {
+	var remaining []string
+	for i, issueKey := range issues {
+		if !e.budget.admit() {
+			remaining = issues[i:]
+			break
+		}
+
 		select {
 		case <-ctx.Done():
 			result.FailedSync++
@@ -428,12 +501,25 @@ func (e *IncrementalBatchSyncEngine) performDryRunSync(
 				Step:     "dry_run",
 				Message:  "context cancelled",
 			})
+			result.IssueResults = append(result.IssueResults, IssueResult{
+				IssueKey: issueKey,
+				Step:     "dry_run",
+				Message:  "context cancelled",
+			})
+			result.Plan = append(result.Plan, PlannedChange{IssueKey: issueKey, Action: PlanActionError, Message: "context cancelled"})
 			continue
 		default:
 		}
 
 		// Try to fetch issue to validate it exists
-		_, err := e.client.GetIssue(issueKey)
+		issueStart := time.Now()
+		issue, err := e.client.GetIssue(issueKey)
+		issueResult := IssueResult{IssueKey: issueKey, Duration: time.Since(issueStart)}
+		if err == nil && e.issueFilter != nil && !e.issueFilter.Match(issue) {
+			result.Filtered = append(result.Filtered, issueKey)
+			result.ProcessedIssues++
+			continue
+		}
 		if err != nil {
 			result.FailedSync++
 			result.Errors = append(result.Errors, BatchError{
@@ -441,13 +527,18 @@ func (e *IncrementalBatchSyncEngine) performDryRunSync(
 				Step:     "fetch",
 				Message:  err.Error(),
 			})
+			issueResult.Step = "fetch"
+			issueResult.Message = err.Error()
+			result.Plan = append(result.Plan, PlannedChange{IssueKey: issueKey, Action: PlanActionError, Message: err.Error()})
 		} else {
 			result.SuccessfulSync++
-			// Simulate file path
-			projectKey := extractProjectKey(issueKey)
-			filePath := fmt.Sprintf("%s/projects/%s/issues/%s.yaml", repoPath, projectKey, issueKey)
-			result.ProcessedFiles = append(result.ProcessedFiles, filePath)
+			change := e.planIssueChange(issue, repoPath, showDiff)
+			result.ProcessedFiles = append(result.ProcessedFiles, change.FilePath)
+			result.Plan = append(result.Plan, change)
+			issueResult.Success = true
+			issueResult.FilePath = change.FilePath
 		}
+		result.IssueResults = append(result.IssueResults, issueResult)
 
 		result.ProcessedIssues++
 	}
@@ -460,15 +551,19 @@ func (e *IncrementalBatchSyncEngine) performDryRunSync(
 	if result.ProcessedIssues > 0 {
 		result.Performance.AvgProcessTime = result.Duration / time.Duration(result.ProcessedIssues)
 	}
+	for _, issueKey := range remaining {
+		result.Plan = append(result.Plan, PlannedChange{IssueKey: issueKey, Action: PlanActionUnchanged, Message: "skipped: sync budget exhausted"})
+	}
+	result.Budget = e.budget.usage(len(remaining), remaining)
 
 	return result
 }
 
 // extractIssueKeyFromFilePath extracts issue key from a file path
 func (e *IncrementalBatchSyncEngine) extractIssueKeyFromFilePath(filePath string) string {
-	// File path format: {repo}/projects/{project-key}/issues/{issue-key}.yaml
+	// File path format: {repo}/projects/{project-key}/issues/{issue-key}.yaml (or .md)
 	base := filepath.Base(filePath)
-	if ext := filepath.Ext(base); ext == ".yaml" {
+	if ext := filepath.Ext(base); ext == ".yaml" || ext == ".md" {
 		return base[:len(base)-len(ext)]
 	}
 	return ""
@@ -0,0 +1,127 @@
+package sync
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report
+// Each synced issue is reported as a test case so sync failures surface in CI dashboards.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite groups the test cases of one JIRA project
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase reports the sync outcome of a single issue
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+// JUnitFailure carries the sync error for a failed issue
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// BuildJUnitReport converts per-issue sync results into JUnit test suites grouped by project
+// Suites are ordered by project key; test cases keep processing order within each suite.
+func BuildJUnitReport(result *BatchResult) *JUnitTestSuites {
+	report := &JUnitTestSuites{
+		Name: "jira-sync",
+		Time: formatJUnitSeconds(result.Duration.Seconds()),
+	}
+
+	suites := make(map[string]*JUnitTestSuite)
+	suiteSeconds := make(map[string]float64)
+	for _, issueResult := range result.IssueResults {
+		project := extractProjectKey(issueResult.IssueKey)
+		suite, exists := suites[project]
+		if !exists {
+			suite = &JUnitTestSuite{Name: project}
+			suites[project] = suite
+		}
+
+		testCase := JUnitTestCase{
+			Name:      issueResult.IssueKey,
+			ClassName: project,
+			Time:      formatJUnitSeconds(issueResult.Duration.Seconds()),
+			File:      issueResult.FilePath,
+		}
+		if !issueResult.Success {
+			testCase.Failure = &JUnitFailure{
+				Message: issueResult.Message,
+				Type:    issueResult.Step,
+				Text:    issueResult.Message,
+			}
+			suite.Failures++
+			report.Failures++
+		}
+
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+		suiteSeconds[project] += issueResult.Duration.Seconds()
+		report.Tests++
+	}
+
+	projects := make([]string, 0, len(suites))
+	for project := range suites {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	for _, project := range projects {
+		suite := suites[project]
+		suite.Time = formatJUnitSeconds(suiteSeconds[project])
+		report.Suites = append(report.Suites, *suite)
+	}
+
+	return report
+}
+
+// WriteJUnitReport writes the sync result as a JUnit XML file
+func WriteJUnitReport(path string, result *BatchResult) error {
+	data, err := xml.MarshalIndent(BuildJUnitReport(result), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create JUnit report directory: %w", err)
+		}
+	}
+
+	content := append([]byte(xml.Header), data...)
+	content = append(content, '\n')
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+
+	return nil
+}
+
+// formatJUnitSeconds formats a duration in seconds the way JUnit consumers expect
+func formatJUnitSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
@@ -0,0 +1,117 @@
+package sync
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+func TestBuildJUnitReport_GroupsByProject(t *testing.T) {
+	result := &BatchResult{
+		Duration: 3 * time.Second,
+		IssueResults: []IssueResult{
+			{IssueKey: "PROJ-2", Success: true, FilePath: "projects/PROJ/issues/PROJ-2.yaml", Duration: 500 * time.Millisecond},
+			{IssueKey: "OTHER-1", Success: false, Step: "sync", Message: "issue not found", Duration: time.Second},
+			{IssueKey: "PROJ-1", Success: true, FilePath: "projects/PROJ/issues/PROJ-1.yaml", Duration: 250 * time.Millisecond},
+		},
+	}
+
+	report := BuildJUnitReport(result)
+
+	if report.Tests != 3 || report.Failures != 1 {
+		t.Errorf("Expected 3 tests with 1 failure, got %d tests with %d failures", report.Tests, report.Failures)
+	}
+	if len(report.Suites) != 2 {
+		t.Fatalf("Expected 2 suites, got %d", len(report.Suites))
+	}
+
+	other, proj := report.Suites[0], report.Suites[1]
+	if other.Name != "OTHER" || proj.Name != "PROJ" {
+		t.Errorf("Expected suites ordered OTHER, PROJ; got %s, %s", other.Name, proj.Name)
+	}
+	if other.Failures != 1 || other.TestCases[0].Failure == nil || other.TestCases[0].Failure.Message != "issue not found" {
+		t.Errorf("Expected OTHER-1 failure with message, got %+v", other.TestCases[0])
+	}
+	if proj.Tests != 2 || proj.Failures != 0 || proj.Time != "0.750" {
+		t.Errorf("Expected PROJ suite with 2 passing tests in 0.750s, got %+v", proj)
+	}
+	if proj.TestCases[0].Name != "PROJ-2" || proj.TestCases[0].ClassName != "PROJ" {
+		t.Errorf("Expected processing order preserved, got %s", proj.TestCases[0].Name)
+	}
+}
+
+func TestWriteJUnitReport_EscapesSpecialCharacters(t *testing.T) {
+	message := `unexpected <html> response & "quoted" 'text'` + "\n\x00second line"
+	result := &BatchResult{
+		IssueResults: []IssueResult{
+			{IssueKey: "PROJ-1", Step: "sync", Message: message},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	if err := WriteJUnitReport(path, result); err != nil {
+		t.Fatalf("WriteJUnitReport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "<?xml") {
+		t.Error("Expected XML declaration header")
+	}
+	if strings.Contains(string(data), "<html>") {
+		t.Error("Expected markup in error message to be escaped")
+	}
+
+	var parsed JUnitTestSuites
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("Report is not valid XML: %v", err)
+	}
+	failure := parsed.Suites[0].TestCases[0].Failure
+	if failure == nil {
+		t.Fatal("Expected failure element")
+	}
+	if !strings.Contains(failure.Message, `<html> response & "quoted"`) {
+		t.Errorf("Expected failure message to round-trip, got %q", failure.Message)
+	}
+}
+
+func TestBatchSyncEngine_RecordsIssueResults(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.Issues["PROJ-1"] = &client.Issue{Key: "PROJ-1", Summary: "Test issue PROJ-1"}
+
+	mockGit := git.NewMockRepository()
+	mockGit.Repositories["/test/repo"] = true
+
+	engine := NewBatchSyncEngine(mockClient, schema.NewMockFileWriter(), mockGit, links.NewMockLinkManager(), 2)
+	result, err := engine.SyncIssues(context.Background(), []string{"PROJ-1", "PROJ-2"}, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+
+	if len(result.IssueResults) != 2 {
+		t.Fatalf("Expected 2 issue results, got %d", len(result.IssueResults))
+	}
+	for _, issueResult := range result.IssueResults {
+		switch issueResult.IssueKey {
+		case "PROJ-1":
+			if !issueResult.Success || issueResult.FilePath == "" {
+				t.Errorf("Expected PROJ-1 to succeed with a file path, got %+v", issueResult)
+			}
+		case "PROJ-2":
+			if issueResult.Success || issueResult.Message == "" {
+				t.Errorf("Expected PROJ-2 to fail with a message, got %+v", issueResult)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package sync
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"github.com/chambrid/jira-cdc-git/pkg/profile"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFileName is the sync manifest written at the repository root
+const ManifestFileName = ".jira-sync-manifest.yaml"
+
+// manifestHeader explains the manifest to readers of the repository
+const manifestHeader = "# How this repository was produced, rewritten by every jira-sync run.\n" +
+	"# This is declarative configuration; operational sync state lives in .jira-sync-state.yaml.\n"
+
+// Manifest is the declarative record of how a repository is produced: the scope, options and
+// JIRA settings of its syncs, enough to reproduce or audit them from the repository alone
+// Unlike the state file it holds no timestamps or results, so it only changes when the
+// configuration does. Secrets are never recorded.
+type Manifest struct {
+	ToolVersion string                 `yaml:"tool_version"`
+	Profile     string                 `yaml:"profile,omitempty"`
+	Scope       ManifestScope          `yaml:"scope"`
+	Options     profile.ProfileOptions `yaml:"options"`
+	JIRA        ManifestJIRA           `yaml:"jira"`
+}
+
+// ManifestScope records which issues a sync selects
+type ManifestScope struct {
+	JQL     string   `yaml:"jql,omitempty"`
+	Issues  []string `yaml:"issues,omitempty"`
+	EpicKey string   `yaml:"epic_key,omitempty"`
+	Within  string   `yaml:"within,omitempty"`
+}
+
+// ManifestJIRA records the JIRA instance and the settings that decide what issue files contain
+type ManifestJIRA struct {
+	BaseURL             string                    `yaml:"base_url"`
+	PublicURL           string                    `yaml:"public_url,omitempty"`
+	IncludeCustomFields bool                      `yaml:"include_custom_fields"`
+	IncludeChangelog    bool                      `yaml:"include_changelog"`
+	IncludeWorklogs     bool                      `yaml:"include_worklogs"`
+	ResolveUsers        bool                      `yaml:"resolve_users"`
+	ExternalRefs        *config.ExternalRefConfig `yaml:"external_refs,omitempty"`
+}
+
+// NewManifestJIRA records the content settings of cfg
+// Credentials (JIRA_PAT, JIRA_SYNC_SIGNING_KEY) are left out, and a password embedded in a
+// URL is masked.
+func NewManifestJIRA(cfg *config.Config) ManifestJIRA {
+	return ManifestJIRA{
+		BaseURL:             redactURL(cfg.JIRABaseURL),
+		PublicURL:           redactURL(cfg.JIRAPublicURL),
+		IncludeCustomFields: cfg.IncludeCustomFields,
+		IncludeChangelog:    cfg.IncludeChangelog,
+		IncludeWorklogs:     cfg.IncludeWorklogs,
+		ResolveUsers:        cfg.ResolveUsers,
+		ExternalRefs:        cfg.ExternalRefs,
+	}
+}
+
+// redactURL masks the password of a URL with credentials, leaving other values unchanged
+func redactURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.User == nil {
+		return value
+	}
+	return parsed.Redacted()
+}
+
+// WriteManifest writes the manifest to the repository root, returning its path and whether the
+// file changed
+func WriteManifest(repoPath string, manifest Manifest) (string, bool, error) {
+	var buf bytes.Buffer
+	buf.WriteString(manifestHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(manifest); err != nil {
+		return "", false, fmt.Errorf("failed to encode sync manifest: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", false, fmt.Errorf("failed to encode sync manifest: %w", err)
+	}
+
+	path := filepath.Join(repoPath, ManifestFileName)
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, buf.Bytes()) {
+		return path, false, nil
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return "", false, fmt.Errorf("failed to write sync manifest: %w", err)
+	}
+	return path, true, nil
+}
+
+// ReadManifest reads the sync manifest at the repository root, returning nil when the repository
+// has none (it was synced before manifests, or with --no-manifest)
+func ReadManifest(repoPath string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, ManifestFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync manifest: %w", err)
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid sync manifest %s: %w", ManifestFileName, err)
+	}
+	return &manifest, nil
+}
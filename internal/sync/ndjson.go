@@ -0,0 +1,134 @@
+package sync
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+// NDJSONSchemaVersion is the version of the NDJSON record layout, written on every record
+// Bump it whenever a field is renamed or removed so pipelines can tell layouts apart.
+const NDJSONSchemaVersion = 1
+
+// NDJSONRecord is one line of an NDJSON export: an issue with the record layout version
+type NDJSONRecord struct {
+	SchemaVersion int `json:"schema_version"`
+	*client.Issue
+}
+
+// NDJSONResult summarizes an NDJSON export
+type NDJSONResult struct {
+	TotalIssues int           `json:"total_issues"`
+	Exported    int           `json:"exported"`
+	Errors      []BatchError  `json:"errors"`
+	Duration    time.Duration `json:"duration"`
+}
+
+// NDJSONExporter writes issues as newline-delimited JSON, one issue per line, to a single stream
+// Issues are written as they are fetched and nothing is kept once written, so memory stays
+// constant however many issues are exported.
+type NDJSONExporter struct {
+	client       client.Client
+	out          *bufio.Writer
+	encoder      *json.Encoder
+	adfRender    schema.ADFRenderMode
+	fieldSchemas map[string]client.FieldSchema
+	flatten      schema.FlattenStrategy
+}
+
+// NewNDJSONExporter creates an exporter writing to out
+// Descriptions are rendered per adfRender (empty: Markdown) and custom fields by fieldSchemas
+// (nil: raw), the same way issue files are written.
+func NewNDJSONExporter(c client.Client, out io.Writer, adfRender schema.ADFRenderMode, fieldSchemas map[string]client.FieldSchema) *NDJSONExporter {
+	buffered := bufio.NewWriter(out)
+	encoder := json.NewEncoder(buffered)
+	encoder.SetEscapeHTML(false)
+	return &NDJSONExporter{
+		client:       c,
+		out:          buffered,
+		encoder:      encoder,
+		adfRender:    adfRender,
+		fieldSchemas: fieldSchemas,
+	}
+}
+
+// SetFlattenStrategy overrides how custom field objects are flattened, as for issue files
+func (e *NDJSONExporter) SetFlattenStrategy(strategy schema.FlattenStrategy) {
+	e.flatten = strategy
+}
+
+// ExportJQL exports every issue matching a query, page by page
+// The issues of each search page are written directly, without fetching them again. A search
+// or write error stops the export; issues written before it remain in the output.
+func (e *NDJSONExporter) ExportJQL(ctx context.Context, jql string, pageSize int) (*NDJSONResult, error) {
+	start := time.Now()
+	result := &NDJSONResult{}
+
+	err := client.StreamIssues(ctx, e.client, jql, pageSize, func(page client.IssuePage) error {
+		result.TotalIssues = page.Total
+		for _, issue := range page.Issues {
+			if err := e.write(issue); err != nil {
+				return err
+			}
+			result.Exported++
+		}
+		return nil
+	})
+	if flushErr := e.out.Flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("failed to write NDJSON output: %w", flushErr)
+	}
+	result.Duration = time.Since(start)
+	return result, err
+}
+
+// ExportIssues exports issues by key, in order
+// Issues that cannot be fetched are recorded in the result and skipped; a write error stops
+// the export.
+func (e *NDJSONExporter) ExportIssues(ctx context.Context, issueKeys []string) (*NDJSONResult, error) {
+	start := time.Now()
+	result := &NDJSONResult{TotalIssues: len(issueKeys)}
+
+	var err error
+	for _, issueKey := range issueKeys {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		issue, fetchErr := e.client.GetIssue(issueKey)
+		if fetchErr != nil {
+			result.Errors = append(result.Errors, BatchError{
+				IssueKey: issueKey,
+				Step:     "fetching",
+				Message:  fetchErr.Error(),
+				Error:    fetchErr,
+			})
+			continue
+		}
+		if err = e.write(issue); err != nil {
+			break
+		}
+		result.Exported++
+	}
+	if flushErr := e.out.Flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("failed to write NDJSON output: %w", flushErr)
+	}
+	result.Duration = time.Since(start)
+	return result, err
+}
+
+// write encodes one issue as a single line
+func (e *NDJSONExporter) write(issue *client.Issue) error {
+	record := NDJSONRecord{
+		SchemaVersion: NDJSONSchemaVersion,
+		Issue:         schema.RenderIssueValues(issue, e.adfRender, e.fieldSchemas, e.flatten),
+	}
+	if err := e.encoder.Encode(record); err != nil {
+		return fmt.Errorf("failed to write issue %s: %w", issue.Key, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package sync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// decodeNDJSON decodes every line of an NDJSON export
+func decodeNDJSON(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var record map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Line is not a JSON object: %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestNDJSONExporter_ExportJQL(t *testing.T) {
+	mockClient := client.NewMockClient()
+	var keys []string
+	for i := 1; i <= 5; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		keys = append(keys, key)
+		mockClient.AddIssue(&client.Issue{Key: key, Summary: "Issue " + key, Status: client.Status{Name: "Open"}})
+	}
+	mockClient.AddJQLResult("project = PROJ", keys)
+
+	var out bytes.Buffer
+	exporter := NewNDJSONExporter(mockClient, &out, "", nil)
+	result, err := exporter.ExportJQL(context.Background(), "project = PROJ", 2)
+	if err != nil {
+		t.Fatalf("ExportJQL() error = %v", err)
+	}
+	if result.Exported != 5 || result.TotalIssues != 5 {
+		t.Errorf("Expected 5 of 5 issues exported, got %d of %d", result.Exported, result.TotalIssues)
+	}
+	if mockClient.SearchIssuesWithPaginationCallCount != 3 {
+		t.Errorf("Expected 3 search pages, got %d", mockClient.SearchIssuesWithPaginationCallCount)
+	}
+	if mockClient.GetIssueCallCount != 0 {
+		t.Errorf("Expected search results to be written without refetching, got %d GetIssue calls", mockClient.GetIssueCallCount)
+	}
+
+	records := decodeNDJSON(t, out.Bytes())
+	if len(records) != 5 {
+		t.Fatalf("Expected 5 lines, got %d:\n%s", len(records), out.String())
+	}
+	for i, record := range records {
+		if record["schema_version"] != float64(NDJSONSchemaVersion) {
+			t.Errorf("Expected schema_version %d, got %v", NDJSONSchemaVersion, record["schema_version"])
+		}
+		if record["key"] != keys[i] {
+			t.Errorf("Expected issue fields at the top level in result order, got key %v at line %d", record["key"], i+1)
+		}
+		if status, _ := record["status"].(map[string]interface{}); status["name"] != "Open" {
+			t.Errorf("Expected status to be written, got %v", record["status"])
+		}
+	}
+}
+
+func TestNDJSONExporter_ExportIssues(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-1", Summary: "First <one>"})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-3", Summary: "Third"})
+
+	var out bytes.Buffer
+	exporter := NewNDJSONExporter(mockClient, &out, "", nil)
+	result, err := exporter.ExportIssues(context.Background(), []string{"PROJ-1", "PROJ-2", "PROJ-3"})
+	if err != nil {
+		t.Fatalf("ExportIssues() error = %v", err)
+	}
+	if result.Exported != 2 || result.TotalIssues != 3 {
+		t.Errorf("Expected 2 of 3 issues exported, got %d of %d", result.Exported, result.TotalIssues)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].IssueKey != "PROJ-2" {
+		t.Errorf("Expected the missing issue to be recorded as an error, got %+v", result.Errors)
+	}
+
+	records := decodeNDJSON(t, out.Bytes())
+	if len(records) != 2 || records[0]["key"] != "PROJ-1" || records[1]["key"] != "PROJ-3" {
+		t.Fatalf("Expected PROJ-1 and PROJ-3, got:\n%s", out.String())
+	}
+	if !bytes.Contains(out.Bytes(), []byte("First <one>")) {
+		t.Errorf("Expected HTML characters to be written unescaped, got:\n%s", out.String())
+	}
+}
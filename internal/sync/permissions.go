@@ -0,0 +1,86 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/jql"
+)
+
+// PermissionCheckMode selects what a pre-sync permission check does about inaccessible projects
+type PermissionCheckMode string
+
+const (
+	// PermissionCheckOff skips the check (the default)
+	PermissionCheckOff PermissionCheckMode = "off"
+	// PermissionCheckWarn reports inaccessible projects and syncs anyway
+	PermissionCheckWarn PermissionCheckMode = "warn"
+	// PermissionCheckFail aborts the sync before fetching if any project is inaccessible
+	PermissionCheckFail PermissionCheckMode = "fail"
+)
+
+// ParsePermissionCheckMode validates a --permission-check value; empty disables the check
+func ParsePermissionCheckMode(value string) (PermissionCheckMode, error) {
+	switch mode := PermissionCheckMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return PermissionCheckOff, nil
+	case PermissionCheckOff, PermissionCheckWarn, PermissionCheckFail:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported permission check mode %q (expected off, warn, or fail)", value)
+	}
+}
+
+// PermissionReport is the result of checking access to the projects a sync will read
+type PermissionReport struct {
+	Projects     []string                // projects checked, sorted
+	Inaccessible []*client.ProjectAccess // projects the user cannot browse
+	Unresolved   bool                    // the scope names no projects, so nothing could be checked
+}
+
+// SyncScopeProjects returns the projects a sync reads, from its issue keys or JQL query
+func SyncScopeProjects(issueKeys []string, query string) []string {
+	if len(issueKeys) == 0 {
+		return jql.ProjectKeys(query)
+	}
+
+	seen := make(map[string]bool)
+	var projects []string
+	for _, key := range issueKeys {
+		project := strings.ToUpper(extractProjectKey(key))
+		if project != "" && !seen[project] {
+			seen[project] = true
+			projects = append(projects, project)
+		}
+	}
+	sort.Strings(projects)
+	return projects
+}
+
+// CheckProjectPermissions checks that the user can browse every project before a sync starts
+// Each project costs one API call; clients cache answers, so repeated checks within a run are
+// free. A client that cannot check permissions is an error, as is a failed permission request.
+func CheckProjectPermissions(jiraClient client.Client, projects []string) (*PermissionReport, error) {
+	report := &PermissionReport{Projects: projects, Unresolved: len(projects) == 0}
+	if report.Unresolved {
+		return report, nil
+	}
+
+	checker, ok := jiraClient.(client.ProjectAccessChecker)
+	if !ok {
+		return nil, fmt.Errorf("JIRA client does not support permission checks")
+	}
+
+	for _, project := range projects {
+		access, err := checker.CheckProjectAccess(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check access to project %s: %w", project, err)
+		}
+		if !access.Accessible {
+			report.Inaccessible = append(report.Inaccessible, access)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,74 @@
+package sync
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// plainClient hides the permission support of the wrapped client
+type plainClient struct {
+	client.Client
+}
+
+func TestParsePermissionCheckMode(t *testing.T) {
+	for input, expected := range map[string]PermissionCheckMode{
+		"":     PermissionCheckOff,
+		"off":  PermissionCheckOff,
+		"Warn": PermissionCheckWarn,
+		"fail": PermissionCheckFail,
+	} {
+		mode, err := ParsePermissionCheckMode(input)
+		if err != nil || mode != expected {
+			t.Errorf("ParsePermissionCheckMode(%q) = %q, %v; want %q", input, mode, err, expected)
+		}
+	}
+
+	if _, err := ParsePermissionCheckMode("strict"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+func TestSyncScopeProjects(t *testing.T) {
+	got := SyncScopeProjects([]string{"OPS-2", "PROJ-1", "PROJ-7"}, "")
+	if want := []string{"OPS", "PROJ"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SyncScopeProjects(keys) = %v, want %v", got, want)
+	}
+
+	got = SyncScopeProjects(nil, "project = WEB AND status = Open")
+	if want := []string{"WEB"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SyncScopeProjects(jql) = %v, want %v", got, want)
+	}
+}
+
+func TestCheckProjectPermissions(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.InaccessibleProjects = map[string]string{"SECRET": "project not found or not visible"}
+
+	report, err := CheckProjectPermissions(mockClient, []string{"PROJ", "SECRET"})
+	if err != nil {
+		t.Fatalf("CheckProjectPermissions() error = %v", err)
+	}
+	if len(report.Inaccessible) != 1 || report.Inaccessible[0].Project != "SECRET" {
+		t.Errorf("Expected SECRET to be inaccessible, got %+v", report.Inaccessible)
+	}
+	if mockClient.CheckProjectAccessCallCount != 2 {
+		t.Errorf("Expected one lookup per project, got %d", mockClient.CheckProjectAccessCallCount)
+	}
+
+	report, err = CheckProjectPermissions(mockClient, nil)
+	if err != nil || !report.Unresolved {
+		t.Errorf("Expected unresolved report for empty scope, got %+v, %v", report, err)
+	}
+
+	if _, err := CheckProjectPermissions(plainClient{mockClient}, []string{"PROJ"}); err == nil {
+		t.Error("Expected error for a client without permission checks")
+	}
+
+	mockClient.APIError = errors.New("connection reset")
+	if _, err := CheckProjectPermissions(mockClient, []string{"PROJ"}); err == nil {
+		t.Error("Expected lookup failure to be returned")
+	}
+}
@@ -0,0 +1,252 @@
+package sync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Planned change actions recorded by dry runs
+const (
+	PlanActionAdd       = "add"       // issue file does not exist yet
+	PlanActionUpdate    = "update"    // issue file exists and its content would change
+	PlanActionUnchanged = "unchanged" // issue is skipped or its file would not change
+	PlanActionDelete    = "delete"    // issue file would be removed
+	PlanActionError     = "error"     // issue could not be fetched or rendered
+)
+
+// DryRunReportVersion identifies the dry-run report layout for tooling that applies plans
+const DryRunReportVersion = "v1"
+
+// maxDiffLines bounds the files that are diffed line by line; larger files only report the change
+const maxDiffLines = 2000
+
+// PlannedChange describes what a sync would do to one issue file
+type PlannedChange struct {
+	IssueKey string `json:"issue_key" yaml:"issue_key"`
+	Action   string `json:"action" yaml:"action"`
+	FilePath string `json:"file_path,omitempty" yaml:"file_path,omitempty"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+	Diff     string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// DryRunParameters records the sync parameters a plan was computed with
+type DryRunParameters struct {
+	Incremental   bool     `json:"incremental" yaml:"incremental"`
+	Force         bool     `json:"force" yaml:"force"`
+	Concurrency   int      `json:"concurrency" yaml:"concurrency"`
+	OrderBy       string   `json:"order_by,omitempty" yaml:"order_by,omitempty"`
+	ExcludeFields []string `json:"exclude_fields,omitempty" yaml:"exclude_fields,omitempty"`
+	ShowDiff      bool     `json:"show_diff" yaml:"show_diff"`
+}
+
+// DryRunSummary counts planned changes by action
+type DryRunSummary struct {
+	Add       int `json:"add" yaml:"add"`
+	Update    int `json:"update" yaml:"update"`
+	Unchanged int `json:"unchanged" yaml:"unchanged"`
+	Delete    int `json:"delete" yaml:"delete"`
+	Errors    int `json:"errors" yaml:"errors"`
+}
+
+// DryRunReport is a reviewable sync plan
+// Issues is the resolved issue set in processing order, so a reviewed plan can be applied
+// with `sync --issues` against exactly the same issues. Planned deletions are not part of it.
+type DryRunReport struct {
+	Version     string           `json:"version" yaml:"version"`
+	GeneratedAt time.Time        `json:"generated_at" yaml:"generated_at"`
+	Repository  string           `json:"repository" yaml:"repository"`
+	JQL         string           `json:"jql,omitempty" yaml:"jql,omitempty"`
+	Issues      []string         `json:"issues" yaml:"issues"`
+	Parameters  DryRunParameters `json:"parameters" yaml:"parameters"`
+	Summary     DryRunSummary    `json:"summary" yaml:"summary"`
+	Changes     []PlannedChange  `json:"changes" yaml:"changes"`
+}
+
+// BuildDryRunReport combines the plan recorded in a dry-run result with the report header
+func BuildDryRunReport(result *BatchResult, repository, jql string, params DryRunParameters) *DryRunReport {
+	report := &DryRunReport{
+		Version:     DryRunReportVersion,
+		GeneratedAt: time.Now().UTC(),
+		Repository:  repository,
+		JQL:         jql,
+		Issues:      make([]string, 0, len(result.Plan)),
+		Parameters:  params,
+		Changes:     result.Plan,
+	}
+	if report.Changes == nil {
+		report.Changes = make([]PlannedChange, 0)
+	}
+
+	for _, change := range report.Changes {
+		if change.Action != PlanActionDelete {
+			report.Issues = append(report.Issues, change.IssueKey)
+		}
+		switch change.Action {
+		case PlanActionAdd:
+			report.Summary.Add++
+		case PlanActionUpdate:
+			report.Summary.Update++
+		case PlanActionUnchanged:
+			report.Summary.Unchanged++
+		case PlanActionDelete:
+			report.Summary.Delete++
+		case PlanActionError:
+			report.Summary.Errors++
+		}
+	}
+
+	return report
+}
+
+// WriteDryRunReport writes a dry-run report as YAML (.yaml/.yml) or JSON (any other extension)
+func WriteDryRunReport(path string, report *DryRunReport) error {
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(report)
+	default:
+		data, err = json.MarshalIndent(report, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode dry-run report: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create dry-run report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write dry-run report: %w", err)
+	}
+
+	return nil
+}
+
+// planIssueChange determines how syncing an issue would change its file
+// Writers that cannot render content are planned by file existence alone.
+func (e *IncrementalBatchSyncEngine) planIssueChange(issue *client.Issue, repoPath string, showDiff bool) PlannedChange {
+	filePath := e.fileWriter.GetIssueFilePath(repoPath, extractProjectKey(issue.Key), issue.Key)
+	change := PlannedChange{IssueKey: issue.Key, FilePath: filePath}
+
+	existing, readErr := os.ReadFile(filePath)
+	if readErr != nil {
+		change.Action = PlanActionAdd
+	} else {
+		change.Action = PlanActionUpdate
+	}
+
+	renderer, ok := e.fileWriter.(schema.IssueRenderer)
+	if !ok {
+		return change
+	}
+
+	var planned []byte
+	var err error
+	if mergeRenderer, merges := e.fileWriter.(schema.IssueMergeRenderer); merges && readErr == nil {
+		planned, err = mergeRenderer.RenderIssueUpdate(issue, existing)
+	} else {
+		planned, err = renderer.RenderIssue(issue)
+	}
+	if err != nil {
+		change.Action = PlanActionError
+		change.Message = err.Error()
+		return change
+	}
+
+	if readErr == nil && bytes.Equal(existing, planned) {
+		change.Action = PlanActionUnchanged
+		return change
+	}
+	if showDiff {
+		change.Diff = renderLineDiff(string(existing), string(planned))
+	}
+	return change
+}
+
+// orderPlan lists a planned change for every requested issue in request order
+// Issues without a planned change were skipped by incremental filtering and are unchanged.
+func orderPlan(issues []string, planned []PlannedChange) []PlannedChange {
+	byKey := make(map[string]PlannedChange, len(planned))
+	for _, change := range planned {
+		byKey[change.IssueKey] = change
+	}
+
+	plan := make([]PlannedChange, 0, len(issues))
+	for _, issueKey := range issues {
+		change, exists := byKey[issueKey]
+		if !exists {
+			change = PlannedChange{IssueKey: issueKey, Action: PlanActionUnchanged, Message: "skipped: no changes since last sync"}
+		}
+		plan = append(plan, change)
+	}
+	return plan
+}
+
+// renderLineDiff renders a minimal line diff with unified-style hunk headers
+func renderLineDiff(oldText, newText string) string {
+	oldLines := splitDiffLines(oldText)
+	newLines := splitDiffLines(newText)
+	if len(oldLines) > maxDiffLines || len(newLines) > maxDiffLines {
+		return fmt.Sprintf("(diff omitted: %d -> %d lines)\n", len(oldLines), len(newLines))
+	}
+
+	// Longest common subsequence table, filled from the end
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var b strings.Builder
+	inHunk := false
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			inHunk = false
+			i++
+			j++
+			continue
+		case !inHunk:
+			fmt.Fprintf(&b, "@@ -%d +%d @@\n", i+1, j+1)
+			inHunk = true
+		}
+
+		if i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]) {
+			fmt.Fprintf(&b, "-%s\n", oldLines[i])
+			i++
+		} else {
+			fmt.Fprintf(&b, "+%s\n", newLines[j])
+			j++
+		}
+	}
+	return b.String()
+}
+
+// splitDiffLines splits text into lines without a trailing empty line
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
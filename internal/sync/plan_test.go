@@ -0,0 +1,113 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderLineDiff(t *testing.T) {
+	diff := renderLineDiff("key: PROJ-1\nsummary: Old\nstatus: Open\n", "key: PROJ-1\nsummary: New\nstatus: Open\n")
+	expected := "@@ -2 +2 @@\n-summary: Old\n+summary: New\n"
+	if diff != expected {
+		t.Errorf("renderLineDiff() = %q, want %q", diff, expected)
+	}
+
+	if diff := renderLineDiff("", "key: PROJ-1\n"); diff != "@@ -1 +1 @@\n+key: PROJ-1\n" {
+		t.Errorf("renderLineDiff() for new file = %q", diff)
+	}
+}
+
+func TestIncrementalBatchSyncEngine_DryRunPlan(t *testing.T) {
+	repoPath := t.TempDir()
+	writer := schema.NewYAMLFileWriter()
+
+	mockClient := client.NewMockClient()
+	unchanged := &client.Issue{Key: "PROJ-1", Summary: "Same"}
+	updated := &client.Issue{Key: "PROJ-2", Summary: "Renamed"}
+	mockClient.AddIssue(unchanged)
+	mockClient.AddIssue(updated)
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-3", Summary: "Brand new"})
+
+	// Existing files: PROJ-1 matches JIRA, PROJ-2 has an old summary
+	if _, err := writer.WriteIssueToYAML(unchanged, repoPath); err != nil {
+		t.Fatalf("Failed to write issue: %v", err)
+	}
+	if _, err := writer.WriteIssueToYAML(&client.Issue{Key: "PROJ-2", Summary: "Original"}, repoPath); err != nil {
+		t.Fatalf("Failed to write issue: %v", err)
+	}
+
+	engine := NewIncrementalBatchSyncEngine(mockClient, writer, git.NewMockRepository(), links.NewMockLinkManager(), state.NewMockStateManager(), 1)
+	result, err := engine.SyncIssuesIncremental(context.Background(), []string{"PROJ-3", "PROJ-1", "PROJ-2", "PROJ-404"}, repoPath,
+		IncrementalSyncOptions{Force: true, DryRun: true, IncludeNew: true, IncludeModified: true, ShowDiff: true})
+	if err != nil {
+		t.Fatalf("SyncIssuesIncremental() error = %v", err)
+	}
+
+	actions := make([]string, 0, len(result.Plan))
+	for _, change := range result.Plan {
+		actions = append(actions, change.IssueKey+"="+change.Action)
+	}
+	if got := strings.Join(actions, ","); got != "PROJ-3=add,PROJ-1=unchanged,PROJ-2=update,PROJ-404=error" {
+		t.Errorf("Unexpected plan %s", got)
+	}
+	if !strings.Contains(result.Plan[2].Diff, "-summary: Original") || !strings.Contains(result.Plan[2].Diff, "+summary: Renamed") {
+		t.Errorf("Expected summary diff, got %q", result.Plan[2].Diff)
+	}
+
+	// Nothing was written
+	if _, err := os.Stat(filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-3.yaml")); !os.IsNotExist(err) {
+		t.Error("Dry run must not write new issue files")
+	}
+}
+
+func TestWriteDryRunReport(t *testing.T) {
+	result := &BatchResult{Plan: []PlannedChange{
+		{IssueKey: "PROJ-1", Action: PlanActionAdd},
+		{IssueKey: "PROJ-2", Action: PlanActionUpdate, Diff: "@@ -1 +1 @@\n-a\n+b\n"},
+		{IssueKey: "PROJ-3", Action: PlanActionUnchanged},
+	}}
+	report := BuildDryRunReport(result, "/repo", "project = PROJ ORDER BY key ASC", DryRunParameters{Concurrency: 5, ShowDiff: true})
+	if report.Summary.Add != 1 || report.Summary.Update != 1 || report.Summary.Unchanged != 1 {
+		t.Errorf("Unexpected summary %+v", report.Summary)
+	}
+
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "plans", "plan.json")
+	if err := WriteDryRunReport(jsonPath, report); err != nil {
+		t.Fatalf("WriteDryRunReport() error = %v", err)
+	}
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	var decoded DryRunReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Report is not valid JSON: %v", err)
+	}
+	if decoded.Version != DryRunReportVersion || decoded.JQL != report.JQL || strings.Join(decoded.Issues, ",") != "PROJ-1,PROJ-2,PROJ-3" {
+		t.Errorf("Unexpected report header %+v", decoded)
+	}
+
+	yamlPath := filepath.Join(dir, "plan.yaml")
+	if err := WriteDryRunReport(yamlPath, report); err != nil {
+		t.Fatalf("WriteDryRunReport() error = %v", err)
+	}
+	data, err = os.ReadFile(yamlPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	if err := yaml.Unmarshal(data, &decoded); err != nil || decoded.Changes[1].Diff != report.Changes[1].Diff {
+		t.Errorf("YAML report did not round-trip: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+)
+
+// PrunedIssue records what happened to the file of an issue that left the sync scope
+type PrunedIssue struct {
+	IssueKey    string `json:"issue_key"`
+	FilePath    string `json:"file_path"`
+	ArchivePath string `json:"archive_path,omitempty"` // empty when the file was deleted
+}
+
+// PruneResult summarizes a prune
+type PruneResult struct {
+	Pruned       []PrunedIssue `json:"pruned"`
+	LinksRemoved int           `json:"links_removed"`
+}
+
+// ValidateArchiveDir checks that an archive directory is a relative path inside the repository
+// and outside the projects tree, so archived files never count as synced issues
+func ValidateArchiveDir(archiveDir string) error {
+	cleaned := filepath.Clean(archiveDir)
+	if archiveDir == "" || cleaned == "." {
+		return fmt.Errorf("archive directory cannot be empty")
+	}
+	if filepath.IsAbs(cleaned) {
+		return fmt.Errorf("archive directory must be relative to the repository: %s", archiveDir)
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("archive directory must be inside the repository: %s", archiveDir)
+	}
+	first := strings.Split(filepath.ToSlash(cleaned), "/")[0]
+	if first == "projects" || first == ".git" {
+		return fmt.Errorf("archive directory cannot be inside %s/: %s", first, archiveDir)
+	}
+	return nil
+}
+
+// FindDepartedIssues lists issues that have a file in the repository but are no longer in scope
+// Only projects that still have at least one in-scope issue are considered, so issue files
+// written by unrelated syncs into the same repository are never pruned. Keys are sorted.
+func FindDepartedIssues(repoPath string, scope []string) ([]string, error) {
+	inScope := make(map[string]bool, len(scope))
+	projects := make(map[string]bool)
+	for _, issueKey := range scope {
+		inScope[issueKey] = true
+		projects[extractProjectKey(issueKey)] = true
+	}
+
+	var departed []string
+	for projectKey := range projects {
+		issueFiles, err := filepath.Glob(filepath.Join(repoPath, "projects", projectKey, "issues", "*.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issue files for project %s: %w", projectKey, err)
+		}
+		for _, issueFile := range issueFiles {
+			issueKey := strings.TrimSuffix(filepath.Base(issueFile), ".yaml")
+			if !inScope[issueKey] {
+				departed = append(departed, issueKey)
+			}
+		}
+	}
+
+	sort.Slice(departed, func(i, j int) bool {
+		return client.CompareIssueKeys(departed[i], departed[j]) < 0
+	})
+	return departed, nil
+}
+
+// PruneIssues removes the files of departed issues and commits the removal
+// With an archive directory the files are moved to {archive}/{project}/{issue-key}.yaml instead
+// of deleted, so their history stays browsable. Relationship links named after a departed
+// issue or pointing at its file are removed either way.
+func PruneIssues(gitRepo git.Repository, repoPath string, issueKeys []string, archiveDir string) (*PruneResult, error) {
+	result := &PruneResult{Pruned: make([]PrunedIssue, 0, len(issueKeys))}
+	if len(issueKeys) == 0 {
+		return result, nil
+	}
+	if archiveDir != "" {
+		if err := ValidateArchiveDir(archiveDir); err != nil {
+			return nil, err
+		}
+	}
+	return removeIssues(gitRepo, repoPath, issueKeys, archiveDir, formatPruneCommitMessage(issueKeys, archiveDir))
+}
+
+// removeIssues deletes (or archives) issue files and their relationship links, and commits the
+// removal with message
+func removeIssues(gitRepo git.Repository, repoPath string, issueKeys []string, archiveDir, message string) (*PruneResult, error) {
+	result := &PruneResult{Pruned: make([]PrunedIssue, 0, len(issueKeys))}
+	var changedPaths []string
+	for _, issueKey := range issueKeys {
+		projectKey := extractProjectKey(issueKey)
+		filePath := filepath.Join(repoPath, "projects", projectKey, "issues", issueKey+".yaml")
+		pruned := PrunedIssue{IssueKey: issueKey, FilePath: filePath}
+
+		if archiveDir == "" {
+			if err := os.Remove(filePath); err != nil {
+				return nil, fmt.Errorf("failed to remove %s: %w", filePath, err)
+			}
+		} else {
+			pruned.ArchivePath = filepath.Join(repoPath, archiveDir, projectKey, issueKey+".yaml")
+			if err := os.MkdirAll(filepath.Dir(pruned.ArchivePath), 0755); err != nil {
+				return nil, fmt.Errorf("failed to create archive directory: %w", err)
+			}
+			if err := os.Rename(filePath, pruned.ArchivePath); err != nil {
+				return nil, fmt.Errorf("failed to archive %s: %w", filePath, err)
+			}
+			changedPaths = append(changedPaths, pruned.ArchivePath)
+		}
+
+		changedPaths = append(changedPaths, filePath)
+		result.Pruned = append(result.Pruned, pruned)
+	}
+
+	removed, err := removeDepartedLinks(repoPath, issueKeys)
+	if err != nil {
+		return nil, err
+	}
+	result.LinksRemoved = removed
+
+	if err := gitRepo.CommitFiles(repoPath, message, changedPaths...); err != nil {
+		return nil, fmt.Errorf("failed to commit removed issues: %w", err)
+	}
+	return result, nil
+}
+
+// PlanPrune records the planned removal of departed issues in a dry-run result
+func PlanPrune(result *BatchResult, repoPath string, issueKeys []string, archiveDir string) {
+	for _, issueKey := range issueKeys {
+		projectKey := extractProjectKey(issueKey)
+		change := PlannedChange{
+			IssueKey: issueKey,
+			Action:   PlanActionDelete,
+			FilePath: filepath.Join(repoPath, "projects", projectKey, "issues", issueKey+".yaml"),
+			Message:  "no longer matches the sync query",
+		}
+		if archiveDir != "" {
+			change.Message = fmt.Sprintf("no longer matches the sync query; archive to %s",
+				filepath.Join(archiveDir, projectKey, issueKey+".yaml"))
+		}
+		result.Plan = append(result.Plan, change)
+	}
+}
+
+// removeDepartedLinks deletes relationship links that belong to or point at departed issues
+// (see findDepartedLinks). Subtask directories left empty are removed as well.
+func removeDepartedLinks(repoPath string, issueKeys []string) (int, error) {
+	linkPaths, emptyCandidates, err := findDepartedLinks(repoPath, issueKeys)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, path := range linkPaths {
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove relationship link %s: %w", path, err)
+		}
+		removed++
+	}
+
+	// Deepest first, so nested directories empty out before their parents
+	for i := len(emptyCandidates) - 1; i >= 0; i-- {
+		_ = os.Remove(emptyCandidates[i]) // only succeeds when empty
+	}
+	return removed, nil
+}
+
+// findDepartedLinks lists the relationship links that belong to or point at departed issues,
+// and the directories named after a departed issue that may be left empty by their removal
+// A link belongs to an issue when the issue key is a component of its path below relationships/
+// (e.g. epic/PROJ-5 or subtasks/PROJ-5/PROJ-6), or one of the parts of a component's link name
+// (epic/PROJ-5--EPIC-1); it points at an issue when its target is the issue's file. Directories
+// are listed parents first.
+func findDepartedLinks(repoPath string, issueKeys []string) ([]string, []string, error) {
+	departed := make(map[string]bool, len(issueKeys))
+	for _, issueKey := range issueKeys {
+		departed[issueKey] = true
+	}
+
+	projectDirs, err := filepath.Glob(filepath.Join(repoPath, "projects", "*", "relationships"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list relationship directories: %w", err)
+	}
+
+	var linkPaths, emptyCandidates []string
+	for _, relationshipsDir := range projectDirs {
+		_ = filepath.Walk(relationshipsDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if info.IsDir() {
+				if departed[info.Name()] {
+					emptyCandidates = append(emptyCandidates, path)
+				}
+				return nil
+			}
+			if info.Mode()&os.ModeSymlink != 0 && linkInvolvesDeparted(relationshipsDir, path, departed) {
+				linkPaths = append(linkPaths, path)
+			}
+			return nil
+		})
+	}
+	return linkPaths, emptyCandidates, nil
+}
+
+// linkInvolvesDeparted reports whether a link belongs to or points at a departed issue
+func linkInvolvesDeparted(relationshipsDir, linkPath string, departed map[string]bool) bool {
+	if relativePath, err := filepath.Rel(relationshipsDir, linkPath); err == nil {
+		for _, component := range strings.Split(filepath.ToSlash(relativePath), "/") {
+			for _, part := range strings.Split(component, links.LinkNameSeparator) {
+				if departed[part] {
+					return true
+				}
+			}
+		}
+	}
+
+	target, err := os.Readlink(linkPath)
+	if err != nil {
+		return false
+	}
+	return departed[strings.TrimSuffix(filepath.Base(target), ".yaml")]
+}
+
+// formatPruneCommitMessage describes a prune commit
+func formatPruneCommitMessage(issueKeys []string, archiveDir string) string {
+	verb := "remove"
+	if archiveDir != "" {
+		verb = "archive"
+	}
+
+	subject := fmt.Sprintf("chore(prune): %s %d issue(s) no longer in scope", verb, len(issueKeys))
+	if len(issueKeys) == 1 {
+		subject = fmt.Sprintf("chore(prune): %s %s (no longer in scope)", verb, issueKeys[0])
+	}
+
+	body := "Issues: " + formatIssueKeyList(issueKeys, DefaultCommitListLimit)
+	if archiveDir != "" {
+		body += "\nArchived to: " + filepath.ToSlash(filepath.Clean(archiveDir))
+	}
+	return subject + "\n\n" + body
+}
@@ -0,0 +1,195 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+// setupPruneRepo writes PROJ-1 (epic), PROJ-2 and PROJ-3 (stories in the epic, PROJ-3 with a
+// subtask PROJ-4) and an unrelated OTHER-1, with their relationship links
+func setupPruneRepo(t *testing.T) string {
+	t.Helper()
+	repoPath := t.TempDir()
+	writer := schema.NewYAMLFileWriter()
+	linkManager := links.NewSymbolicLinkManager()
+
+	issues := []*client.Issue{
+		{Key: "PROJ-1", Summary: "Epic"},
+		{Key: "PROJ-2", Summary: "Story", Relationships: &client.Relationships{EpicLink: "PROJ-1"}},
+		{Key: "PROJ-3", Summary: "Story", Relationships: &client.Relationships{EpicLink: "PROJ-1", Subtasks: []string{"PROJ-4"}}},
+		{Key: "PROJ-4", Summary: "Subtask", Relationships: &client.Relationships{ParentIssue: "PROJ-3"}},
+		{Key: "OTHER-1", Summary: "Unrelated"},
+	}
+	for _, issue := range issues {
+		if _, err := writer.WriteIssueToYAML(issue, repoPath); err != nil {
+			t.Fatalf("Failed to write issue: %v", err)
+		}
+		if err := linkManager.CreateRelationshipLinks(issue, repoPath); err != nil {
+			t.Fatalf("Failed to create links: %v", err)
+		}
+	}
+	return repoPath
+}
+
+func TestFindDepartedIssues(t *testing.T) {
+	repoPath := setupPruneRepo(t)
+
+	departed, err := FindDepartedIssues(repoPath, []string{"PROJ-1", "PROJ-2"})
+	if err != nil {
+		t.Fatalf("FindDepartedIssues() error = %v", err)
+	}
+	// OTHER-1 belongs to a project outside the scope and is kept
+	if expected := []string{"PROJ-3", "PROJ-4"}; !reflect.DeepEqual(departed, expected) {
+		t.Errorf("FindDepartedIssues() = %v, want %v", departed, expected)
+	}
+
+	departed, err = FindDepartedIssues(repoPath, nil)
+	if err != nil || len(departed) != 0 {
+		t.Errorf("FindDepartedIssues() with empty scope = %v, %v; want nothing", departed, err)
+	}
+}
+
+func TestPruneIssues_Archive(t *testing.T) {
+	repoPath := setupPruneRepo(t)
+	gitRepo := git.NewMockRepository()
+	if err := gitRepo.Initialize(repoPath); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	result, err := PruneIssues(gitRepo, repoPath, []string{"PROJ-3", "PROJ-4"}, "archive")
+	if err != nil {
+		t.Fatalf("PruneIssues() error = %v", err)
+	}
+	if len(result.Pruned) != 2 {
+		t.Fatalf("Expected 2 pruned issues, got %d", len(result.Pruned))
+	}
+
+	for _, issueKey := range []string{"PROJ-3", "PROJ-4"} {
+		if _, err := os.Stat(filepath.Join(repoPath, "projects", "PROJ", "issues", issueKey+".yaml")); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed from the active set", issueKey)
+		}
+		if _, err := os.Stat(filepath.Join(repoPath, "archive", "PROJ", issueKey+".yaml")); err != nil {
+			t.Errorf("Expected %s in the archive: %v", issueKey, err)
+		}
+	}
+
+	// Links of pruned issues are gone; PROJ-2's epic link remains
+	relationships := filepath.Join(repoPath, "projects", "PROJ", "relationships")
+	for _, linkPath := range []string{"epic/PROJ-3", "parent/PROJ-4", "subtasks/PROJ-3"} {
+		if _, err := os.Lstat(filepath.Join(relationships, linkPath)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", linkPath)
+		}
+	}
+	if _, err := os.Lstat(filepath.Join(relationships, "epic", "PROJ-2")); err != nil {
+		t.Errorf("Expected epic link of PROJ-2 to remain: %v", err)
+	}
+	if result.LinksRemoved != 3 {
+		t.Errorf("Expected 3 links removed, got %d", result.LinksRemoved)
+	}
+
+	// Both sides of each move are committed together
+	committed := gitRepo.CommittedFiles[repoPath]
+	if len(committed) != 4 {
+		t.Fatalf("Expected 4 committed paths, got %d", len(committed))
+	}
+	if !strings.HasPrefix(committed[0].CommitMessage, "chore(prune): archive 2 issue(s)") {
+		t.Errorf("Unexpected commit message: %q", committed[0].CommitMessage)
+	}
+}
+
+func TestPruneIssues_Delete(t *testing.T) {
+	repoPath := setupPruneRepo(t)
+	gitRepo := git.NewMockRepository()
+	if err := gitRepo.Initialize(repoPath); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	result, err := PruneIssues(gitRepo, repoPath, []string{"PROJ-1"}, "")
+	if err != nil {
+		t.Fatalf("PruneIssues() error = %v", err)
+	}
+	if result.Pruned[0].ArchivePath != "" {
+		t.Errorf("Expected no archive path, got %s", result.Pruned[0].ArchivePath)
+	}
+	// Epic links of both stories pointed at PROJ-1
+	if result.LinksRemoved != 2 {
+		t.Errorf("Expected 2 links removed, got %d", result.LinksRemoved)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "archive")); !os.IsNotExist(err) {
+		t.Error("Expected no archive directory when deleting")
+	}
+}
+
+func TestPruneIssues_NamedLinks(t *testing.T) {
+	repoPath := t.TempDir()
+	writer := schema.NewYAMLFileWriter()
+	linkManager := links.NewSymbolicLinkManagerWithNaming(1, links.LinkNamingTypeKey)
+	for _, issue := range []*client.Issue{
+		{Key: "PROJ-1", Summary: "Epic"},
+		{Key: "PROJ-2", Summary: "Story", Relationships: &client.Relationships{EpicLink: "PROJ-1"}},
+	} {
+		if _, err := writer.WriteIssueToYAML(issue, repoPath); err != nil {
+			t.Fatalf("Failed to write issue: %v", err)
+		}
+		if err := linkManager.CreateRelationshipLinks(issue, repoPath); err != nil {
+			t.Fatalf("Failed to create links: %v", err)
+		}
+	}
+	gitRepo := git.NewMockRepository()
+	if err := gitRepo.Initialize(repoPath); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	// The story's link points at the remaining epic; it belongs to the story by its name
+	result, err := PruneIssues(gitRepo, repoPath, []string{"PROJ-2"}, "")
+	if err != nil {
+		t.Fatalf("PruneIssues() error = %v", err)
+	}
+	if result.LinksRemoved != 1 {
+		t.Errorf("Expected 1 link removed, got %d", result.LinksRemoved)
+	}
+	linkPath := filepath.Join(repoPath, "projects", "PROJ", "relationships", "epic", "epic--PROJ-2--PROJ-1")
+	if _, err := os.Lstat(linkPath); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed", linkPath)
+	}
+}
+
+func TestValidateArchiveDir(t *testing.T) {
+	valid := []string{"archive", "archive/closed", "./archive"}
+	for _, dir := range valid {
+		if err := ValidateArchiveDir(dir); err != nil {
+			t.Errorf("ValidateArchiveDir(%q) error = %v", dir, err)
+		}
+	}
+
+	invalid := []string{"", ".", "/tmp/archive", "../archive", "projects/archive", ".git/archive"}
+	for _, dir := range invalid {
+		if err := ValidateArchiveDir(dir); err == nil {
+			t.Errorf("ValidateArchiveDir(%q) expected error", dir)
+		}
+	}
+}
+
+func TestPlanPrune(t *testing.T) {
+	result := &BatchResult{Plan: []PlannedChange{{IssueKey: "PROJ-1", Action: PlanActionUnchanged}}}
+	PlanPrune(result, "/repo", []string{"PROJ-2"}, "archive")
+
+	report := BuildDryRunReport(result, "/repo", "project = PROJ", DryRunParameters{})
+	if report.Summary.Delete != 1 {
+		t.Errorf("Expected 1 planned delete, got %d", report.Summary.Delete)
+	}
+	if !reflect.DeepEqual(report.Issues, []string{"PROJ-1"}) {
+		t.Errorf("Expected planned deletions to be left out of the issue set, got %v", report.Issues)
+	}
+	if !strings.Contains(result.Plan[1].Message, "archive/PROJ/PROJ-2.yaml") {
+		t.Errorf("Expected archive destination in message, got %q", result.Plan[1].Message)
+	}
+}
@@ -0,0 +1,89 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/ratelimit"
+)
+
+// QuietHoursIssueInterval is the least time between issues while slow quiet hours are active
+const QuietHoursIssueInterval = time.Second
+
+// SetQuietHours throttles syncs during the quiet hours: in slow mode a single worker syncs at
+// most one issue per QuietHoursIssueInterval, in pause mode no issue is synced until they end.
+// The throttle is checked before each issue, so a long run slows down when quiet hours begin and
+// speeds up again when they end. nil never throttles.
+func (b *BatchSyncEngine) SetQuietHours(hours *ratelimit.QuietHours) {
+	b.quietHours = hours
+	b.quietSlot = make(chan struct{}, 1)
+	b.quietInterval = QuietHoursIssueInterval
+}
+
+// enterQuietHours blocks a worker while the quiet hours hold it back, returning a function the
+// worker calls once its issue is synced
+func (b *BatchSyncEngine) enterQuietHours(ctx context.Context, workerID int) (func(), error) {
+	noop := func() {}
+	now := time.Now()
+	if !b.quietHours.Active(now) {
+		b.reportQuietHours(false, time.Time{}, workerID)
+		return noop, nil
+	}
+	end := b.quietHours.End(now)
+	b.reportQuietHours(true, end, workerID)
+
+	if b.quietHours.Mode == ratelimit.QuietHoursPause {
+		if err := sleepContext(ctx, time.Until(end)); err != nil {
+			return nil, err
+		}
+		b.reportQuietHours(false, time.Time{}, workerID)
+		return noop, nil
+	}
+
+	// Slow: one issue at a time, spaced out; the slot holder owns quietLast
+	select {
+	case b.quietSlot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err := sleepContext(ctx, time.Until(b.quietLast.Add(b.quietInterval))); err != nil {
+		<-b.quietSlot
+		return nil, err
+	}
+	return func() {
+		b.quietLast = time.Now()
+		<-b.quietSlot
+	}, nil
+}
+
+// reportQuietHours announces the start and end of quiet hours, once per change
+func (b *BatchSyncEngine) reportQuietHours(active bool, end time.Time, workerID int) {
+	if !b.quietActive.CompareAndSwap(!active, active) {
+		return
+	}
+	update := ProgressUpdate{Step: "quiet_hours_end", Timestamp: time.Now(), WorkerID: workerID}
+	if active {
+		update.Step = "quiet_hours_start"
+		update.QuietHoursMode = b.quietHours.Mode
+		update.QuietHoursEnd = &end
+	}
+	select {
+	case b.progressChan <- update:
+	default:
+	}
+}
+
+// sleepContext waits for d, or returns ctx's error if it is cancelled first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,105 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/ratelimit"
+)
+
+// activeQuietHours returns quiet hours that began an hour ago and end in two hours
+func activeQuietHours(t *testing.T, mode string) *ratelimit.QuietHours {
+	t.Helper()
+	now := time.Now().UTC()
+	window := now.Add(-time.Hour).Format("15:04") + "-" + now.Add(2*time.Hour).Format("15:04")
+	hours, err := ratelimit.ParseQuietHours(window, "UTC", mode)
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+	return hours
+}
+
+func collectQuietHoursUpdates(engine *BatchSyncEngine) (*[]ProgressUpdate, chan struct{}) {
+	var updates []ProgressUpdate
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range engine.GetProgressChannel() {
+			if update.Step == "quiet_hours_start" || update.Step == "quiet_hours_end" {
+				updates = append(updates, update)
+			}
+		}
+	}()
+	return &updates, done
+}
+
+func TestBatchSyncEngine_QuietHoursSlow(t *testing.T) {
+	engine, jiraClient, keys, repoPath := newRampUpEngine(t, 6, 10*time.Millisecond)
+	engine.SetQuietHours(activeQuietHours(t, ratelimit.QuietHoursSlow))
+	engine.quietInterval = 30 * time.Millisecond
+	updates, done := collectQuietHoursUpdates(engine)
+
+	start := time.Now()
+	jiraClient.start = start
+	result, err := engine.SyncIssues(context.Background(), keys, repoPath)
+	elapsed := time.Since(start)
+	engine.CloseProgressChannel()
+	<-done
+	if err != nil || result.SuccessfulSync != 6 {
+		t.Fatalf("SyncIssues() = %d synced, %v", result.SuccessfulSync, err)
+	}
+
+	for _, sample := range jiraClient.samples {
+		if sample.inFlight > 1 {
+			t.Errorf("Expected a single worker during quiet hours, got %d in flight at %v", sample.inFlight, sample.at)
+		}
+	}
+	if elapsed < 5*30*time.Millisecond {
+		t.Errorf("Expected issues to be spaced out during quiet hours, took %v", elapsed)
+	}
+	if len(*updates) != 1 || (*updates)[0].Step != "quiet_hours_start" || (*updates)[0].QuietHoursMode != ratelimit.QuietHoursSlow || (*updates)[0].QuietHoursEnd == nil {
+		t.Errorf("Expected the quiet hours to be reported once, got %+v", *updates)
+	}
+}
+
+func TestBatchSyncEngine_QuietHoursPause(t *testing.T) {
+	engine, jiraClient, keys, repoPath := newRampUpEngine(t, 3, 0)
+	engine.SetQuietHours(activeQuietHours(t, ratelimit.QuietHoursPause))
+	updates, done := collectQuietHoursUpdates(engine)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	result, _ := engine.SyncIssues(ctx, keys, repoPath)
+	engine.CloseProgressChannel()
+	<-done
+
+	if len(jiraClient.samples) != 0 || (result != nil && result.SuccessfulSync != 0) {
+		t.Errorf("Expected no issues to be synced while paused, fetched %d", len(jiraClient.samples))
+	}
+	if len(*updates) != 1 || (*updates)[0].QuietHoursMode != ratelimit.QuietHoursPause {
+		t.Errorf("Expected the pause to be reported once, got %+v", *updates)
+	}
+}
+
+func TestBatchSyncEngine_QuietHoursInactive(t *testing.T) {
+	engine, jiraClient, keys, repoPath := newRampUpEngine(t, 8, 20*time.Millisecond)
+	now := time.Now().UTC()
+	hours, err := ratelimit.ParseQuietHours(now.Add(2*time.Hour).Format("15:04")+"-"+now.Add(3*time.Hour).Format("15:04"), "UTC", ratelimit.QuietHoursPause)
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+	engine.SetQuietHours(hours)
+
+	result, err := engine.SyncIssues(context.Background(), keys, repoPath)
+	if err != nil || result.SuccessfulSync != 8 {
+		t.Fatalf("SyncIssues() = %d synced, %v", result.SuccessfulSync, err)
+	}
+	maxInFlight := 0
+	for _, sample := range jiraClient.samples {
+		maxInFlight = max(maxInFlight, sample.inFlight)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("Expected full concurrency outside quiet hours, got at most %d in flight", maxInFlight)
+	}
+}
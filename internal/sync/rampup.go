@@ -0,0 +1,71 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SetRampUp makes syncs start their workers gradually: one worker starts at once and the others
+// follow at even intervals, so all are active once the ramp-up has passed. 0 starts every
+// worker at once.
+func (b *BatchSyncEngine) SetRampUp(rampUp time.Duration) {
+	if rampUp < 0 {
+		rampUp = 0
+	}
+	b.rampUp = rampUp
+}
+
+// startWorkers starts the engine's workers over its ramp-up period
+// Workers still waiting when the queue drains or ctx is cancelled are never started, so a short
+// sync does not wait out the ramp-up.
+func (b *BatchSyncEngine) startWorkers(ctx context.Context, tasks <-chan SyncTask, results chan<- SyncResult, repoPath string, wg *sync.WaitGroup) {
+	wg.Add(b.concurrency)
+	if b.rampUp <= 0 || b.concurrency == 1 {
+		for i := 0; i < b.concurrency; i++ {
+			go b.worker(ctx, i, tasks, results, repoPath, wg)
+		}
+		return
+	}
+
+	// A worker returns once the queue is closed and empty (or ctx is done): later starts are moot
+	drained := make(chan struct{})
+	var once sync.Once
+	run := func(workerID int) {
+		b.worker(ctx, workerID, tasks, results, repoPath, wg)
+		once.Do(func() { close(drained) })
+	}
+
+	interval := b.rampUp / time.Duration(b.concurrency-1)
+	b.reportRampUp(1)
+	go run(0)
+	for i := 1; i < b.concurrency; i++ {
+		go func(workerID int) {
+			timer := time.NewTimer(time.Duration(workerID) * interval)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				b.reportRampUp(workerID + 1)
+				run(workerID)
+			case <-drained:
+				wg.Done()
+			case <-ctx.Done():
+				wg.Done()
+			}
+		}(i)
+	}
+}
+
+// reportRampUp announces the number of active workers during the ramp-up
+func (b *BatchSyncEngine) reportRampUp(active int) {
+	select {
+	case b.progressChan <- ProgressUpdate{
+		Step:          "ramp_up",
+		ActiveWorkers: active,
+		TotalWorkers:  b.concurrency,
+		Timestamp:     time.Now(),
+		WorkerID:      active - 1,
+	}:
+	default:
+	}
+}
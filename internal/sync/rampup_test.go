@@ -0,0 +1,121 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	gosync "sync"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+// concurrencyClient records how many fetches are in flight when each one starts
+type concurrencyClient struct {
+	*client.MockClient
+	delay time.Duration
+	start time.Time
+
+	mu       gosync.Mutex
+	inFlight int
+	samples  []inFlightSample
+}
+
+type inFlightSample struct {
+	at       time.Duration
+	inFlight int
+}
+
+func (c *concurrencyClient) GetIssue(issueKey string) (*client.Issue, error) {
+	c.mu.Lock()
+	c.inFlight++
+	c.samples = append(c.samples, inFlightSample{at: time.Since(c.start), inFlight: c.inFlight})
+	c.mu.Unlock()
+
+	time.Sleep(c.delay)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+	return c.MockClient.GetIssue(issueKey)
+}
+
+func newRampUpEngine(t *testing.T, issueCount int, delay time.Duration) (*BatchSyncEngine, *concurrencyClient, []string, string) {
+	t.Helper()
+	repoPath := t.TempDir()
+	mock := client.NewMockClient()
+	keys := make([]string, issueCount)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("PROJ-%d", i+1)
+		mock.AddIssue(client.CreateTestIssue(keys[i]))
+	}
+	jiraClient := &concurrencyClient{MockClient: mock, delay: delay}
+	gitRepo := git.NewMockRepository()
+	_ = gitRepo.Initialize(repoPath)
+	engine := NewBatchSyncEngine(jiraClient, schema.NewYAMLFileWriter(), gitRepo, links.NewMockLinkManager(), 4)
+	engine.SetBulkFetchSize(1)
+	return engine, jiraClient, keys, repoPath
+}
+
+func TestBatchSyncEngine_RampUp(t *testing.T) {
+	engine, jiraClient, keys, repoPath := newRampUpEngine(t, 40, 10*time.Millisecond)
+	engine.SetRampUp(300 * time.Millisecond) // a worker joins every 100ms
+
+	var ramp []int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range engine.GetProgressChannel() {
+			if update.Step == "ramp_up" {
+				if update.TotalWorkers != 4 {
+					t.Errorf("Expected 4 target workers, got %d", update.TotalWorkers)
+				}
+				ramp = append(ramp, update.ActiveWorkers)
+			}
+		}
+	}()
+
+	jiraClient.start = time.Now()
+	result, err := engine.SyncIssues(context.Background(), keys, repoPath)
+	engine.CloseProgressChannel()
+	<-done
+	if err != nil || result.SuccessfulSync != 40 {
+		t.Fatalf("SyncIssues() = %d synced, %v", result.SuccessfulSync, err)
+	}
+
+	maxInFlight := 0
+	for _, sample := range jiraClient.samples {
+		if sample.at < 80*time.Millisecond && sample.inFlight > 1 {
+			t.Errorf("Expected a single worker before the first ramp step, got %d at %v", sample.inFlight, sample.at)
+		}
+		maxInFlight = max(maxInFlight, sample.inFlight)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("Expected more workers after the ramp-up, got at most %d in flight", maxInFlight)
+	}
+	if len(ramp) == 0 || ramp[0] != 1 {
+		t.Errorf("Expected the ramp to be reported starting at 1 worker, got %v", ramp)
+	}
+	for i := 1; i < len(ramp); i++ {
+		if ramp[i] != ramp[i-1]+1 {
+			t.Errorf("Expected the ramp to add one worker at a time, got %v", ramp)
+		}
+	}
+}
+
+func TestBatchSyncEngine_RampUpShortSync(t *testing.T) {
+	engine, _, keys, repoPath := newRampUpEngine(t, 2, 0)
+	engine.SetRampUp(10 * time.Second)
+
+	start := time.Now()
+	result, err := engine.SyncIssues(context.Background(), keys, repoPath)
+	if err != nil || result.SuccessfulSync != 2 {
+		t.Fatalf("SyncIssues() = %d synced, %v", result.SuccessfulSync, err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected a short sync not to wait out the ramp-up, took %v", elapsed)
+	}
+}
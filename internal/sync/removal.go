@@ -0,0 +1,66 @@
+package sync
+
+import (
+	"path/filepath"
+)
+
+// RemovalPlan lists the issue files and relationship links a prune or deletion would remove
+// or move
+type RemovalPlan struct {
+	Files []PrunedIssue `json:"files"`
+	Links []string      `json:"links,omitempty"`
+}
+
+// PlanRemoval lists what removing issues would touch, without modifying anything
+// With an archive directory the files are listed with the path they would be moved to, as
+// PruneIssues would move them.
+func PlanRemoval(repoPath string, issueKeys []string, archiveDir string) (*RemovalPlan, error) {
+	plan := &RemovalPlan{Files: make([]PrunedIssue, 0, len(issueKeys))}
+	for _, issueKey := range issueKeys {
+		projectKey := extractProjectKey(issueKey)
+		file := PrunedIssue{
+			IssueKey: issueKey,
+			FilePath: filepath.Join(repoPath, "projects", projectKey, "issues", issueKey+".yaml"),
+		}
+		if archiveDir != "" {
+			file.ArchivePath = filepath.Join(repoPath, archiveDir, projectKey, issueKey+".yaml")
+		}
+		plan.Files = append(plan.Files, file)
+	}
+
+	linkPaths, _, err := findDepartedLinks(repoPath, issueKeys)
+	if err != nil {
+		return nil, err
+	}
+	plan.Links = linkPaths
+	return plan, nil
+}
+
+// Count returns how many paths the plan removes or moves
+func (p *RemovalPlan) Count() int {
+	return len(p.Files) + len(p.Links)
+}
+
+// Paths describes every affected path relative to the repository, files before links
+// Moved files read "from -> to".
+func (p *RemovalPlan) Paths(repoPath string) []string {
+	relative := func(path string) string {
+		if rel, err := filepath.Rel(repoPath, path); err == nil {
+			return filepath.ToSlash(rel)
+		}
+		return path
+	}
+
+	paths := make([]string, 0, p.Count())
+	for _, file := range p.Files {
+		if file.ArchivePath != "" {
+			paths = append(paths, relative(file.FilePath)+" -> "+relative(file.ArchivePath))
+		} else {
+			paths = append(paths, relative(file.FilePath))
+		}
+	}
+	for _, link := range p.Links {
+		paths = append(paths, relative(link))
+	}
+	return paths
+}
@@ -0,0 +1,46 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPlanRemoval(t *testing.T) {
+	repoPath := setupPruneRepo(t)
+
+	plan, err := PlanRemoval(repoPath, []string{"PROJ-3", "PROJ-4"}, "archive")
+	if err != nil {
+		t.Fatalf("PlanRemoval() error = %v", err)
+	}
+	expected := []string{
+		"projects/PROJ/issues/PROJ-3.yaml -> archive/PROJ/PROJ-3.yaml",
+		"projects/PROJ/issues/PROJ-4.yaml -> archive/PROJ/PROJ-4.yaml",
+		"projects/PROJ/relationships/epic/PROJ-3",
+		"projects/PROJ/relationships/parent/PROJ-4",
+		"projects/PROJ/relationships/subtasks/PROJ-3/PROJ-4",
+	}
+	if got := plan.Paths(repoPath); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Paths() = %v, want %v", got, expected)
+	}
+	if plan.Count() != 5 {
+		t.Errorf("Count() = %d, want 5", plan.Count())
+	}
+
+	// Planning leaves the repository untouched
+	if _, err := os.Lstat(filepath.Join(repoPath, "projects", "PROJ", "relationships", "epic", "PROJ-3")); err != nil {
+		t.Errorf("Expected links to remain after planning: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "archive")); !os.IsNotExist(err) {
+		t.Error("Expected no archive directory after planning")
+	}
+
+	deletion, err := PlanRemoval(repoPath, []string{"PROJ-1"}, "")
+	if err != nil {
+		t.Fatalf("PlanRemoval() error = %v", err)
+	}
+	if got := deletion.Paths(repoPath); len(got) != 3 || got[0] != "projects/PROJ/issues/PROJ-1.yaml" {
+		t.Errorf("Expected the epic file and the two epic links, got %v", got)
+	}
+}
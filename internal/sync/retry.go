@@ -0,0 +1,39 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// SetRetryPolicy retries issues whose sync failed on a transient JIRA error, as classified by
+// the policy, up to policy.IssueRetries times with its backoff. Nil disables issue retries.
+func (b *BatchSyncEngine) SetRetryPolicy(policy *client.RetryPolicy) {
+	b.retryPolicy = policy
+}
+
+// processWithRetries syncs one issue, retrying transient JIRA failures per the retry policy
+// A retry fetches the issue again rather than reusing a prefetched copy, and is admitted
+// against the budget like a new issue, so retries never use calls reserved for other issues.
+func (b *BatchSyncEngine) processWithRetries(ctx context.Context, task SyncTask, repoPath string, workerID int) (string, error) {
+	filePath, err := b.processSingleIssue(ctx, task.IssueKey, task.Issue, repoPath, workerID)
+	if b.retryPolicy == nil {
+		return filePath, err
+	}
+
+	for attempt := 1; attempt <= b.retryPolicy.IssueRetries && err != nil && b.retryPolicy.RetryableError(err); attempt++ {
+		timer := time.NewTimer(b.retryPolicy.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return filePath, err
+		case <-timer.C:
+		}
+		if !b.budget.admit() {
+			return filePath, err
+		}
+		filePath, err = b.processSingleIssue(ctx, task.IssueKey, nil, repoPath, workerID)
+	}
+	return filePath, err
+}
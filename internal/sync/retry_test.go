@@ -0,0 +1,77 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// flakyClient fails the first GetIssue calls for each issue with a JIRA error of the given status
+type flakyClient struct {
+	*client.MockClient
+	status   int
+	failures int
+	calls    map[string]int
+}
+
+func (c *flakyClient) GetIssue(issueKey string) (*client.Issue, error) {
+	c.calls[issueKey]++
+	if c.calls[issueKey] <= c.failures {
+		return nil, &client.ClientError{Type: "api_error", Message: "server error", Context: issueKey, StatusCode: c.status}
+	}
+	return c.MockClient.GetIssue(issueKey)
+}
+
+func TestBatchSyncEngine_IssueRetries(t *testing.T) {
+	policy := &client.RetryPolicy{
+		IssueRetries: 1,
+		StatusCodes:  map[int]bool{503: true},
+		BaseDelay:    time.Millisecond,
+		MaxDelay:     time.Millisecond,
+	}
+	tests := []struct {
+		name        string
+		status      int
+		failures    int
+		policy      *client.RetryPolicy
+		wantSuccess int
+	}{
+		{"transient failure retried", 503, 1, policy, 3},
+		{"retries exhausted", 503, 2, policy, 0},
+		{"status not retryable", 500, 1, policy, 0},
+		{"no policy", 503, 1, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, mockClient, issues := newBudgetTestEngine(3)
+			engine.client = &flakyClient{MockClient: mockClient, status: tt.status, failures: tt.failures, calls: map[string]int{}}
+			engine.SetRetryPolicy(tt.policy)
+
+			result, err := engine.SyncIssues(context.Background(), issues, "/test/repo")
+			if err != nil {
+				t.Fatalf("SyncIssues() error = %v", err)
+			}
+			if result.SuccessfulSync != tt.wantSuccess || result.ProcessedIssues != 3 {
+				t.Errorf("Expected %d of 3 issues synced, got %d of %d", tt.wantSuccess, result.SuccessfulSync, result.ProcessedIssues)
+			}
+		})
+	}
+}
+
+func TestBatchSyncEngine_IssueRetriesWithinBudget(t *testing.T) {
+	engine, mockClient, issues := newBudgetTestEngine(3)
+	engine.client = &flakyClient{MockClient: mockClient, status: 503, failures: 1, calls: map[string]int{}}
+	engine.SetRetryPolicy(&client.RetryPolicy{IssueRetries: 1, StatusCodes: map[int]bool{503: true}})
+	engine.SetBudget(SyncBudget{MaxAPICalls: 4})
+
+	result, err := engine.SyncIssues(context.Background(), issues, "/test/repo")
+	if err != nil {
+		t.Fatalf("SyncIssues() error = %v", err)
+	}
+	if result.Budget == nil || result.Budget.APICalls > 4 {
+		t.Errorf("Expected retries to stay within 4 API calls, got %+v", result.Budget)
+	}
+}
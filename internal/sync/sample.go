@@ -0,0 +1,109 @@
+package sync
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/jql"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+// MaxSampleSize bounds --sample so a preview stays fast and readable
+const MaxSampleSize = 50
+
+// IssueSample is one issue fetched and rendered exactly as a sync would write it
+type IssueSample struct {
+	Key      string `json:"key"`
+	FilePath string `json:"file_path"` // relative to the repository
+	Content  []byte `json:"-"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SampleResult is a rendered preview of the first issues of a sync scope
+type SampleResult struct {
+	Total   int           `json:"total"` // issues in the full scope
+	Samples []IssueSample `json:"samples"`
+}
+
+// SampleJQL renders the first n issues matching a query, ordered by key, without writing them
+// Only one page of search results is requested, so sampling a large scope stays cheap. Any
+// ORDER BY in the query is replaced so the same query always yields the same samples.
+func SampleJQL(jiraClient client.Client, writer schema.FileWriter, repoPath, query string, n int) (*SampleResult, error) {
+	renderer, err := sampleRenderer(writer, n)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, total, err := jiraClient.SearchIssuesWithPagination(jql.ApplyOrderBy(query, "key ASC"), 0, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for sample issues: %w", err)
+	}
+
+	keys := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return client.CompareIssueKeys(keys[i], keys[j]) < 0 })
+
+	return &SampleResult{Total: total, Samples: renderSamples(jiraClient, writer, renderer, repoPath, keys)}, nil
+}
+
+// SampleIssueKeys renders the first n of the given issues by key, without writing them
+func SampleIssueKeys(jiraClient client.Client, writer schema.FileWriter, repoPath string, issueKeys []string, n int) (*SampleResult, error) {
+	renderer, err := sampleRenderer(writer, n)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := append([]string(nil), issueKeys...)
+	sort.Slice(keys, func(i, j int) bool { return client.CompareIssueKeys(keys[i], keys[j]) < 0 })
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+
+	return &SampleResult{Total: len(issueKeys), Samples: renderSamples(jiraClient, writer, renderer, repoPath, keys)}, nil
+}
+
+// sampleRenderer validates the sample size and returns the writer's renderer
+func sampleRenderer(writer schema.FileWriter, n int) (schema.IssueRenderer, error) {
+	if n < 1 || n > MaxSampleSize {
+		return nil, fmt.Errorf("sample size must be between 1 and %d, got %d", MaxSampleSize, n)
+	}
+	renderer, ok := writer.(schema.IssueRenderer)
+	if !ok {
+		return nil, fmt.Errorf("file writer cannot render issues without writing them")
+	}
+	return renderer, nil
+}
+
+// renderSamples fetches each issue the way a sync does and renders its file content
+// Fetch and render failures are recorded per sample so one bad issue does not hide the rest.
+func renderSamples(jiraClient client.Client, writer schema.FileWriter, renderer schema.IssueRenderer, repoPath string, keys []string) []IssueSample {
+	samples := make([]IssueSample, 0, len(keys))
+	for _, key := range keys {
+		sample := IssueSample{Key: key}
+		if absPath := writer.GetIssueFilePath(repoPath, extractProjectKey(key), key); absPath != "" {
+			if relPath, err := filepath.Rel(repoPath, absPath); err == nil {
+				sample.FilePath = relPath
+			}
+		}
+
+		issue, err := jiraClient.GetIssue(key)
+		if err != nil {
+			sample.Error = fmt.Sprintf("failed to fetch issue: %v", err)
+			samples = append(samples, sample)
+			continue
+		}
+
+		content, err := renderer.RenderIssue(issue)
+		if err != nil {
+			sample.Error = fmt.Sprintf("failed to render issue: %v", err)
+		} else {
+			sample.Content = content
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
@@ -0,0 +1,78 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+func TestSampleJQL(t *testing.T) {
+	repoPath := t.TempDir()
+	mockClient := client.NewMockClient()
+	for _, n := range []int{10, 2, 1, 3} {
+		key := fmt.Sprintf("PROJ-%d", n)
+		mockClient.AddIssue(&client.Issue{Key: key, Summary: "Issue " + key})
+	}
+	mockClient.AddJQLResult("project = PROJ ORDER BY key ASC", []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-10"})
+
+	writer := schema.NewYAMLFileWriter()
+	result, err := SampleJQL(mockClient, writer, repoPath, "project = PROJ ORDER BY updated DESC", 2)
+	if err != nil {
+		t.Fatalf("SampleJQL() error = %v", err)
+	}
+
+	if result.Total != 4 {
+		t.Errorf("Expected total of 4, got %d", result.Total)
+	}
+	var sampled []string
+	for _, sample := range result.Samples {
+		sampled = append(sampled, sample.Key)
+		if sample.Error != "" {
+			t.Errorf("Unexpected sample error for %s: %s", sample.Key, sample.Error)
+		}
+		if !strings.Contains(string(sample.Content), "summary: Issue "+sample.Key) {
+			t.Errorf("Expected rendered content for %s, got:\n%s", sample.Key, sample.Content)
+		}
+	}
+	if !reflect.DeepEqual(sampled, []string{"PROJ-1", "PROJ-2"}) {
+		t.Errorf("Expected first two issues by key, got %v", sampled)
+	}
+	if result.Samples[0].FilePath != filepath.Join("projects", "PROJ", "issues", "PROJ-1.yaml") {
+		t.Errorf("Unexpected sample file path %q", result.Samples[0].FilePath)
+	}
+
+	// Nothing is written
+	if entries, _ := os.ReadDir(repoPath); len(entries) != 0 {
+		t.Errorf("Expected no files written, found %d entries", len(entries))
+	}
+}
+
+func TestSampleIssueKeys(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-2", Summary: "Two"})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-10", Summary: "Ten"})
+
+	result, err := SampleIssueKeys(mockClient, schema.NewYAMLFileWriter(), t.TempDir(), []string{"PROJ-10", "PROJ-7", "PROJ-2"}, 2)
+	if err != nil {
+		t.Fatalf("SampleIssueKeys() error = %v", err)
+	}
+	if result.Total != 3 || len(result.Samples) != 2 {
+		t.Fatalf("Expected 2 of 3 samples, got %d of %d", len(result.Samples), result.Total)
+	}
+	if result.Samples[0].Key != "PROJ-2" || result.Samples[1].Key != "PROJ-7" {
+		t.Errorf("Expected PROJ-2 and PROJ-7 (ordered by key), got %s and %s", result.Samples[0].Key, result.Samples[1].Key)
+	}
+	if result.Samples[1].Error == "" {
+		t.Error("Expected a fetch error for the missing PROJ-7")
+	}
+
+	if _, err := SampleIssueKeys(mockClient, schema.NewYAMLFileWriter(), t.TempDir(), []string{"PROJ-2"}, MaxSampleSize+1); err == nil {
+		t.Error("Expected error for oversized sample")
+	}
+}
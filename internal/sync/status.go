@@ -0,0 +1,52 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StatusBadgeFileName is the sync status summary written at the repository root
+const StatusBadgeFileName = ".jira-sync-status.json"
+
+// StatusBadge summarizes the last sync of a repository for dashboards
+// It is a small, stable subset of the sync state that is cheap to scrape.
+type StatusBadge struct {
+	LastSync    time.Time `json:"last_sync"`
+	OperationID string    `json:"operation_id,omitempty"`
+	Success     bool      `json:"success"`
+	Total       int       `json:"total"`
+	Successful  int       `json:"successful"`
+	Failed      int       `json:"failed"`
+	DurationMs  int64     `json:"duration_ms"`
+}
+
+// NewStatusBadge summarizes a finished sync
+// A sync succeeded when no issue failed.
+func NewStatusBadge(result *BatchResult, finishedAt time.Time) StatusBadge {
+	return StatusBadge{
+		LastSync:    finishedAt.UTC().Truncate(time.Second),
+		OperationID: result.OperationID,
+		Success:     result.FailedSync == 0,
+		Total:       result.TotalIssues,
+		Successful:  result.SuccessfulSync,
+		Failed:      result.FailedSync,
+		DurationMs:  result.Duration.Milliseconds(),
+	}
+}
+
+// WriteStatusBadge writes the status summary of a finished sync to the repository root and
+// returns its path
+func WriteStatusBadge(repoPath string, result *BatchResult, finishedAt time.Time) (string, error) {
+	data, err := json.MarshalIndent(NewStatusBadge(result, finishedAt), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode sync status: %w", err)
+	}
+	path := filepath.Join(repoPath, StatusBadgeFileName)
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write sync status: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,61 @@
+package sync
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteStatusBadge(t *testing.T) {
+	repoPath := t.TempDir()
+	finishedAt := time.Date(2024, 1, 15, 11, 30, 0, 500, time.FixedZone("CET", 3600))
+	result := &BatchResult{
+		OperationID:    "sync-1705314600",
+		TotalIssues:    3,
+		SuccessfulSync: 2,
+		FailedSync:     1,
+		Duration:       1500 * time.Millisecond,
+	}
+
+	path, err := WriteStatusBadge(repoPath, result, finishedAt)
+	if err != nil {
+		t.Fatalf("WriteStatusBadge() error = %v", err)
+	}
+	if path != filepath.Join(repoPath, StatusBadgeFileName) {
+		t.Errorf("Expected the badge at the repository root, got %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read badge: %v", err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Badge is not valid JSON: %v", err)
+	}
+	if raw["last_sync"] != "2024-01-15T10:30:00Z" {
+		t.Errorf("Expected last_sync in UTC to the second, got %v", raw["last_sync"])
+	}
+	if raw["operation_id"] != "sync-1705314600" || raw["success"] != false {
+		t.Errorf("Unexpected badge: %s", data)
+	}
+	if raw["total"] != 3.0 || raw["successful"] != 2.0 || raw["failed"] != 1.0 || raw["duration_ms"] != 1500.0 {
+		t.Errorf("Unexpected counts: %s", data)
+	}
+
+	// The next run replaces the badge
+	result.FailedSync, result.SuccessfulSync = 0, 3
+	if _, err := WriteStatusBadge(repoPath, result, finishedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("WriteStatusBadge() error = %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	var badge StatusBadge
+	if err := json.Unmarshal(data, &badge); err != nil {
+		t.Fatalf("Badge is not valid JSON: %v", err)
+	}
+	if !badge.Success || badge.Successful != 3 || !badge.LastSync.Equal(time.Date(2024, 1, 15, 11, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the badge to be replaced, got %+v", badge)
+	}
+}
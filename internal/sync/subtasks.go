@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// SubtaskInclusion is the sync set produced by adding the sub-tasks of requested issues
+type SubtaskInclusion struct {
+	Keys      []string // requested issues first, then added sub-tasks in parent order
+	Requested int
+	Added     int
+	Skipped   []string // requested issues whose sub-tasks could not be looked up
+}
+
+// IncludeSubtasks adds the sub-tasks of the requested issues to the sync set
+// Sub-tasks cannot have sub-tasks of their own, so one level is followed, and all of them are
+// found with a single search rather than by fetching every requested issue, which the sync does
+// anyway. JQL rejects a parent key that does not exist, so when the combined search fails each
+// parent is searched alone and those that still fail are skipped; they stay in the set so the
+// sync reports their error. A sub-task that is already requested is not added again.
+func IncludeSubtasks(jiraClient client.Client, requested []string) *SubtaskInclusion {
+	result := &SubtaskInclusion{Requested: len(requested)}
+	seen := make(map[string]bool, len(requested))
+	for _, key := range requested {
+		if !seen[key] {
+			seen[key] = true
+			result.Keys = append(result.Keys, key)
+		}
+	}
+	if len(result.Keys) == 0 {
+		return result
+	}
+
+	parents := append([]string(nil), result.Keys...)
+	subtasks, err := searchSubtasks(jiraClient, parents)
+	if err != nil {
+		subtasks = make(map[string][]string, len(parents))
+		for _, parent := range parents {
+			found, err := searchSubtasks(jiraClient, []string{parent})
+			if err != nil {
+				result.Skipped = append(result.Skipped, parent)
+				continue
+			}
+			subtasks[parent] = found[parent]
+		}
+	}
+
+	for _, parent := range parents {
+		for _, subtask := range subtasks[parent] {
+			if seen[subtask] {
+				continue
+			}
+			seen[subtask] = true
+			result.Keys = append(result.Keys, subtask)
+			result.Added++
+		}
+	}
+	return result
+}
+
+// subtasksQuery builds a query matching the sub-tasks of the given parent issues
+func subtasksQuery(parents []string) string {
+	return fmt.Sprintf("parent in (%s) AND issuetype in subTaskIssueTypes()", strings.Join(parents, ", "))
+}
+
+// searchSubtasks finds the sub-tasks of parents, keyed by parent in issue key order
+func searchSubtasks(jiraClient client.Client, parents []string) (map[string][]string, error) {
+	issues, err := jiraClient.SearchIssues(subtasksQuery(parents))
+	if err != nil {
+		return nil, err
+	}
+	subtasks := make(map[string][]string, len(parents))
+	for _, issue := range issues {
+		if issue.Relationships == nil || issue.Relationships.ParentIssue == "" {
+			continue
+		}
+		parent := issue.Relationships.ParentIssue
+		subtasks[parent] = append(subtasks[parent], issue.Key)
+	}
+	for _, keys := range subtasks {
+		sort.SliceStable(keys, func(i, j int) bool {
+			return client.CompareIssueKeys(keys[i], keys[j]) < 0
+		})
+	}
+	return subtasks, nil
+}
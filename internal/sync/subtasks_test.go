@@ -0,0 +1,85 @@
+package sync
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// subtaskSearchClient answers sub-task searches like JIRA, rejecting queries naming a parent
+// that does not exist
+type subtaskSearchClient struct {
+	*client.MockClient
+	searches int
+}
+
+func (c *subtaskSearchClient) SearchIssues(jql string) ([]*client.Issue, error) {
+	c.searches++
+	list := strings.TrimSuffix(strings.TrimPrefix(jql, "parent in ("), ") AND issuetype in subTaskIssueTypes()")
+	parents := make(map[string]bool)
+	for _, key := range strings.Split(list, ", ") {
+		if _, ok := c.Issues[key]; !ok {
+			return nil, fmt.Errorf("an issue with key '%s' does not exist for field 'parent'", key)
+		}
+		parents[key] = true
+	}
+	var issues []*client.Issue
+	for _, issue := range c.Issues {
+		if issue.Relationships != nil && parents[issue.Relationships.ParentIssue] {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+func TestIncludeSubtasks(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-1", Relationships: &client.Relationships{
+		EpicLink: "PROJ-100",
+		Subtasks: []string{"PROJ-2", "PROJ-3"},
+	}})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-2", Relationships: &client.Relationships{ParentIssue: "PROJ-1"}})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-3", Relationships: &client.Relationships{ParentIssue: "PROJ-1"}})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-10", Relationships: &client.Relationships{Subtasks: []string{"PROJ-11"}}})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-11", Relationships: &client.Relationships{ParentIssue: "PROJ-10"}})
+	mockClient.AddIssue(&client.Issue{Key: "PROJ-20"})
+
+	tests := []struct {
+		name      string
+		requested []string
+		wantKeys  []string
+		added     int
+		skipped   []string
+		searches  int
+	}{
+		{"adds sub-tasks after requested issues", []string{"PROJ-1", "PROJ-10"}, []string{"PROJ-1", "PROJ-10", "PROJ-2", "PROJ-3", "PROJ-11"}, 3, nil, 1},
+		{"requested sub-task not added twice", []string{"PROJ-1", "PROJ-3"}, []string{"PROJ-1", "PROJ-3", "PROJ-2"}, 1, nil, 1},
+		{"no sub-tasks", []string{"PROJ-20"}, []string{"PROJ-20"}, 0, nil, 1},
+		{"missing issue kept", []string{"PROJ-404", "PROJ-10"}, []string{"PROJ-404", "PROJ-10", "PROJ-11"}, 1, []string{"PROJ-404"}, 3},
+		{"duplicate requests", []string{"PROJ-10", "PROJ-10"}, []string{"PROJ-10", "PROJ-11"}, 1, nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchClient := &subtaskSearchClient{MockClient: mockClient}
+			result := IncludeSubtasks(searchClient, tt.requested)
+			if !reflect.DeepEqual(result.Keys, tt.wantKeys) {
+				t.Errorf("Keys = %v, want %v", result.Keys, tt.wantKeys)
+			}
+			if result.Requested != len(tt.requested) || result.Added != tt.added {
+				t.Errorf("Expected %d requested and %d added, got %d and %d", len(tt.requested), tt.added, result.Requested, result.Added)
+			}
+			if !reflect.DeepEqual(result.Skipped, tt.skipped) {
+				t.Errorf("Skipped = %v, want %v", result.Skipped, tt.skipped)
+			}
+			if searchClient.searches != tt.searches {
+				t.Errorf("Expected %d searches, got %d", tt.searches, searchClient.searches)
+			}
+		})
+	}
+	if mockClient.GetIssueCallCount != 0 {
+		t.Errorf("Expected no requested issue to be fetched, got %d GetIssue calls", mockClient.GetIssueCallCount)
+	}
+}
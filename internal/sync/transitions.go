@@ -0,0 +1,94 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// IssueTransition records the status change that selected an issue for a transitions-only sync
+type IssueTransition struct {
+	IssueKey string    `json:"issue_key"`
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	At       time.Time `json:"at"`
+}
+
+// GetStatusTransitions forwards changelog requests, counting them against the budget
+func (c *budgetClient) GetStatusTransitions(issueKey string) ([]client.StatusTransition, error) {
+	changelog, ok := c.Client.(client.ChangelogClient)
+	if !ok {
+		return nil, fmt.Errorf("JIRA client does not support issue changelogs")
+	}
+	if !c.tracker.take() {
+		return nil, ErrBudgetExhausted
+	}
+	return changelog.GetStatusTransitions(issueKey)
+}
+
+// filterStatusTransitions keeps the issues whose status changed within the incremental window
+// The window starts at options.Since, else at the issue's last sync, else at the repository's
+// last sync; an issue that was never synced in a never-synced repository counts every transition.
+// Issues that were only edited are skipped. A disabled changelog is an error; an issue whose
+// changelog cannot be fetched for another reason is included to be safe.
+func (e *IncrementalBatchSyncEngine) filterStatusTransitions(
+	issues []string,
+	options IncrementalSyncOptions,
+) ([]string, []IssueTransition, error) {
+
+	changelog, ok := e.client.(client.ChangelogClient)
+	if !ok {
+		return nil, nil, fmt.Errorf("status-transitions-only sync requires a JIRA client with changelog support")
+	}
+
+	repoLastSync := e.GetLastSyncTime()
+	var filtered []string
+	var transitions []IssueTransition
+
+	for _, issueKey := range issues {
+		windowStart := options.Since
+		if windowStart.IsZero() {
+			if issueState, exists := e.stateManager.GetIssueState(e.state, issueKey); exists {
+				windowStart = issueState.LastSynced
+			} else {
+				windowStart = repoLastSync
+			}
+		}
+
+		history, err := changelog.GetStatusTransitions(issueKey)
+		if err != nil {
+			if errors.Is(err, client.ErrChangelogDisabled) {
+				return nil, nil, err
+			}
+			filtered = append(filtered, issueKey)
+			continue
+		}
+
+		if latest, found := latestTransitionSince(history, windowStart); found {
+			filtered = append(filtered, issueKey)
+			transitions = append(transitions, IssueTransition{
+				IssueKey: issueKey,
+				From:     latest.From,
+				To:       latest.To,
+				At:       latest.At,
+			})
+		}
+	}
+
+	return filtered, transitions, nil
+}
+
+// latestTransitionSince returns the last status change after since, if any
+func latestTransitionSince(history []client.StatusTransition, since time.Time) (client.StatusTransition, bool) {
+	var latest client.StatusTransition
+	found := false
+	for _, transition := range history {
+		if transition.At.After(since) && (!found || !transition.At.Before(latest.At)) {
+			latest = transition
+			found = true
+		}
+	}
+	return latest, found
+}
@@ -0,0 +1,105 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+)
+
+func newTransitionsTestEngine(lastSynced time.Time) (*IncrementalBatchSyncEngine, *client.MockClient) {
+	mockClient := client.NewMockClient()
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-4"} {
+		mockClient.Issues[key] = &client.Issue{Key: key, Summary: "Test issue " + key}
+	}
+
+	stateManager := state.NewMockStateManager()
+	stateManager.States["/test/repo"] = &state.SyncState{
+		Issues: map[string]state.IssueState{
+			"PROJ-1": {Key: "PROJ-1", ProjectKey: "PROJ", LastSynced: lastSynced},
+			"PROJ-2": {Key: "PROJ-2", ProjectKey: "PROJ", LastSynced: lastSynced},
+		},
+	}
+
+	mockGit := git.NewMockRepository()
+	mockGit.Repositories["/test/repo"] = true
+	engine := NewIncrementalBatchSyncEngine(mockClient, schema.NewMockFileWriter(), mockGit,
+		links.NewMockLinkManager(), stateManager, 1)
+	return engine, mockClient
+}
+
+func TestSyncIssuesIncremental_StatusTransitionsOnly(t *testing.T) {
+	lastSynced := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	engine, mockClient := newTransitionsTestEngine(lastSynced)
+	mockClient.StatusTransitions = map[string][]client.StatusTransition{
+		// Reopened after the last sync
+		"PROJ-1": {
+			{From: "In Progress", To: "Done", At: lastSynced.Add(-time.Hour)},
+			{From: "Done", To: "Reopened", At: lastSynced.Add(time.Hour)},
+		},
+		// Only transitioned before the last sync, so later changes were pure edits
+		"PROJ-2": {{From: "To Do", To: "In Progress", At: lastSynced.Add(-time.Hour)}},
+		// New issue in a repository that never completed a sync
+		"PROJ-3": {{From: "To Do", To: "In Progress", At: lastSynced.Add(-48 * time.Hour)}},
+	}
+
+	issues := []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-4"}
+	result, err := engine.SyncIssuesIncremental(context.Background(), issues, "/test/repo",
+		IncrementalSyncOptions{IncludeNew: true, IncludeModified: true, StatusTransitionsOnly: true})
+	if err != nil {
+		t.Fatalf("SyncIssuesIncremental() error = %v", err)
+	}
+
+	if result.SuccessfulSync != 2 {
+		t.Errorf("Expected 2 transitioned issues synced, got %d", result.SuccessfulSync)
+	}
+	want := []IssueTransition{
+		{IssueKey: "PROJ-1", From: "Done", To: "Reopened", At: lastSynced.Add(time.Hour)},
+		{IssueKey: "PROJ-3", From: "To Do", To: "In Progress", At: lastSynced.Add(-48 * time.Hour)},
+	}
+	if !reflect.DeepEqual(result.Transitions, want) {
+		t.Errorf("Transitions = %+v, want %+v", result.Transitions, want)
+	}
+}
+
+func TestSyncIssuesIncremental_StatusTransitionsSince(t *testing.T) {
+	lastSynced := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	engine, mockClient := newTransitionsTestEngine(lastSynced)
+	mockClient.StatusTransitions = map[string][]client.StatusTransition{
+		"PROJ-2": {{From: "To Do", To: "In Progress", At: lastSynced.Add(-time.Hour)}},
+	}
+
+	result, err := engine.SyncIssuesIncremental(context.Background(), []string{"PROJ-1", "PROJ-2"}, "/test/repo",
+		IncrementalSyncOptions{IncludeModified: true, StatusTransitionsOnly: true, Since: lastSynced.Add(-2 * time.Hour)})
+	if err != nil {
+		t.Fatalf("SyncIssuesIncremental() error = %v", err)
+	}
+	if len(result.Transitions) != 1 || result.Transitions[0].IssueKey != "PROJ-2" {
+		t.Errorf("Expected the --since window to select PROJ-2, got %+v", result.Transitions)
+	}
+}
+
+func TestSyncIssuesIncremental_StatusTransitionsChangelogDisabled(t *testing.T) {
+	engine, mockClient := newTransitionsTestEngine(time.Now())
+	mockClient.ChangelogError = client.ErrChangelogDisabled
+
+	_, err := engine.SyncIssuesIncremental(context.Background(), []string{"PROJ-1"}, "/test/repo",
+		IncrementalSyncOptions{IncludeModified: true, StatusTransitionsOnly: true})
+	if !errors.Is(err, client.ErrChangelogDisabled) {
+		t.Errorf("Expected ErrChangelogDisabled, got %v", err)
+	}
+
+	// Without changelog support the mode cannot run at all
+	engine.client = &plainClient{Client: mockClient}
+	if _, err := engine.SyncIssuesIncremental(context.Background(), []string{"PROJ-1"}, "/test/repo",
+		IncrementalSyncOptions{IncludeModified: true, StatusTransitionsOnly: true}); err == nil {
+		t.Error("Expected error for a client without changelog support")
+	}
+}
@@ -0,0 +1,220 @@
+package checksum
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+)
+
+// Manifest and signature file names, written per project next to the issues directory
+const (
+	ManifestFileName  = "checksums.sha256"
+	SignatureFileName = "checksums.sha256.sig"
+)
+
+// Entry is one line of a checksum manifest: an issue file and its SHA-256 digest
+type Entry struct {
+	Path     string // relative to the project directory, e.g. issues/PROJ-1.yaml
+	Checksum string // lower-case hex
+}
+
+// GetManifestPath returns the checksum manifest path for a project
+// Pattern: /projects/{project-key}/checksums.sha256
+func GetManifestPath(basePath, projectKey string) string {
+	return filepath.Join(basePath, "projects", projectKey, ManifestFileName)
+}
+
+// GetSignaturePath returns the detached signature path for a project's manifest
+func GetSignaturePath(basePath, projectKey string) string {
+	return filepath.Join(basePath, "projects", projectKey, SignatureFileName)
+}
+
+// BuildManifest hashes every issue file present for a project, sorted by issue key
+// The manifest carries no timestamps, so an unchanged project always yields the same bytes.
+func BuildManifest(basePath, projectKey string) ([]Entry, error) {
+	projectDir := filepath.Join(basePath, "projects", projectKey)
+	issueFiles, err := filepath.Glob(filepath.Join(projectDir, "issues", "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue files for project %s: %w", projectKey, err)
+	}
+
+	entries := make([]Entry, 0, len(issueFiles))
+	for _, issueFile := range issueFiles {
+		sum, err := state.CalculateFileChecksum(issueFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", issueFile, err)
+		}
+		entries = append(entries, Entry{
+			Path:     filepath.ToSlash(filepath.Join("issues", filepath.Base(issueFile))),
+			Checksum: sum,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return client.CompareIssueKeys(entryKey(entries[i]), entryKey(entries[j])) < 0
+	})
+	return entries, nil
+}
+
+// RenderManifest serializes entries in the sha256sum format, so a project directory can
+// also be checked with `sha256sum -c checksums.sha256`
+func RenderManifest(entries []Entry) []byte {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		fmt.Fprintf(&buf, "%s  %s\n", entry.Checksum, entry.Path)
+	}
+	return buf.Bytes()
+}
+
+// ParseManifest parses a manifest written by RenderManifest
+func ParseManifest(data []byte) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		sum, path, ok := strings.Cut(text, "  ")
+		if !ok || len(sum) != 64 || strings.TrimSpace(path) == "" {
+			return nil, fmt.Errorf("line %d is not a '<sha256>  <path>' entry", line)
+		}
+		entries = append(entries, Entry{Path: strings.TrimSpace(path), Checksum: strings.ToLower(sum)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return entries, nil
+}
+
+// WriteProjectManifest regenerates a project's manifest, and its signature when a key is given
+// Returns the files that changed. Ed25519 signatures are deterministic, so re-signing an
+// unchanged manifest with the same key rewrites nothing. Without a key, a signature left by an
+// earlier signed run is removed when the manifest changes.
+func WriteProjectManifest(basePath, projectKey string, signingKey ed25519.PrivateKey) ([]string, error) {
+	entries, err := BuildManifest(basePath, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	manifest := RenderManifest(entries)
+
+	var changed []string
+	manifestPath := GetManifestPath(basePath, projectKey)
+	wrote, err := writeIfChanged(manifestPath, manifest)
+	if err != nil {
+		return nil, err
+	}
+	if wrote {
+		changed = append(changed, manifestPath)
+	}
+
+	signaturePath := GetSignaturePath(basePath, projectKey)
+	if signingKey != nil {
+		wrote, err := writeIfChanged(signaturePath, Sign(signingKey, manifest))
+		if err != nil {
+			return nil, err
+		}
+		if wrote {
+			changed = append(changed, signaturePath)
+		}
+	} else if wrote {
+		// A signature from an earlier signed run no longer matches the new manifest
+		if err := os.Remove(signaturePath); err == nil {
+			changed = append(changed, signaturePath)
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale signature %s: %w", signaturePath, err)
+		}
+	}
+	return changed, nil
+}
+
+// Sign returns a detached, base64-encoded Ed25519 signature of a manifest
+func Sign(key ed25519.PrivateKey, manifest []byte) []byte {
+	signature := ed25519.Sign(key, manifest)
+	return []byte(base64.StdEncoding.EncodeToString(signature) + "\n")
+}
+
+// VerifySignature checks a detached signature produced by Sign
+func VerifySignature(key ed25519.PublicKey, manifest, signature []byte) error {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("signature is not valid base64: %w", err)
+	}
+	if !ed25519.Verify(key, manifest, decoded) {
+		return fmt.Errorf("signature does not match the manifest")
+	}
+	return nil
+}
+
+// LoadPrivateKey reads a PEM-encoded (PKCS #8) Ed25519 private key, as written by
+// `openssl genpkey -algorithm ed25519`
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not an Ed25519 key", path)
+	}
+	return key, nil
+}
+
+// LoadPublicKey reads a PEM-encoded (PKIX) Ed25519 public key, as written by
+// `openssl pkey -pubout`
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key %s is not an Ed25519 key", path)
+	}
+	return key, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key file %s is not PEM-encoded", path)
+	}
+	return block, nil
+}
+
+func writeIfChanged(path string, data []byte) (bool, error) {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return false, nil
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+func entryKey(entry Entry) string {
+	return strings.TrimSuffix(filepath.Base(entry.Path), ".yaml")
+}
@@ -0,0 +1,130 @@
+package checksum
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeIssueFile(t *testing.T, basePath, key, content string) string {
+	t.Helper()
+	dir := filepath.Join(basePath, "projects", "PROJ", "issues")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	path := filepath.Join(dir, key+".yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestBuildManifest_SortedAndReproducible(t *testing.T) {
+	basePath := t.TempDir()
+	writeIssueFile(t, basePath, "PROJ-10", "key: PROJ-10\n")
+	writeIssueFile(t, basePath, "PROJ-2", "key: PROJ-2\n")
+
+	entries, err := BuildManifest(basePath, "PROJ")
+	if err != nil {
+		t.Fatalf("BuildManifest() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Path != "issues/PROJ-2.yaml" || entries[1].Path != "issues/PROJ-10.yaml" {
+		t.Fatalf("Expected entries in issue key order, got %+v", entries)
+	}
+
+	rendered := RenderManifest(entries)
+	parsed, err := ParseManifest(rendered)
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	if string(RenderManifest(parsed)) != string(rendered) {
+		t.Errorf("Expected the manifest to round-trip, got %q", RenderManifest(parsed))
+	}
+	if !strings.HasPrefix(string(rendered), entries[0].Checksum+"  issues/PROJ-2.yaml\n") {
+		t.Errorf("Expected sha256sum format, got %q", rendered)
+	}
+}
+
+func TestParseManifest_Malformed(t *testing.T) {
+	if _, err := ParseManifest([]byte("abc issues/PROJ-1.yaml\n")); err == nil {
+		t.Error("Expected an error for a malformed line")
+	}
+}
+
+func TestWriteProjectManifest_Signed(t *testing.T) {
+	basePath := t.TempDir()
+	writeIssueFile(t, basePath, "PROJ-1", "key: PROJ-1\n")
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	changed, err := WriteProjectManifest(basePath, "PROJ", privateKey)
+	if err != nil {
+		t.Fatalf("WriteProjectManifest() error = %v", err)
+	}
+	if len(changed) != 2 {
+		t.Fatalf("Expected manifest and signature to be written, got %v", changed)
+	}
+
+	manifest, _ := os.ReadFile(GetManifestPath(basePath, "PROJ"))
+	signature, _ := os.ReadFile(GetSignaturePath(basePath, "PROJ"))
+	if err := VerifySignature(publicKey, manifest, signature); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+	if err := VerifySignature(publicKey, append(manifest, '\n'), signature); err == nil {
+		t.Error("Expected a modified manifest to fail verification")
+	}
+
+	changed, err = WriteProjectManifest(basePath, "PROJ", privateKey)
+	if err != nil || len(changed) != 0 {
+		t.Errorf("Expected an unchanged project to rewrite nothing, got %v (%v)", changed, err)
+	}
+
+	// An unsigned run keeps a signature that still matches, and removes one that no longer does
+	changed, err = WriteProjectManifest(basePath, "PROJ", nil)
+	if err != nil || len(changed) != 0 {
+		t.Errorf("Expected an unchanged unsigned run to rewrite nothing, got %v (%v)", changed, err)
+	}
+	writeIssueFile(t, basePath, "PROJ-2", "key: PROJ-2\n")
+	changed, err = WriteProjectManifest(basePath, "PROJ", nil)
+	if err != nil || len(changed) != 2 {
+		t.Fatalf("Expected the manifest to change and its signature to be removed, got %v (%v)", changed, err)
+	}
+	if _, err := os.Stat(GetSignaturePath(basePath, "PROJ")); !os.IsNotExist(err) {
+		t.Errorf("Expected the stale signature to be removed, got %v", err)
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	privateDER, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	publicDER, _ := x509.MarshalPKIXPublicKey(publicKey)
+	privatePath := filepath.Join(dir, "sign.pem")
+	publicPath := filepath.Join(dir, "sign.pub")
+	_ = os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600)
+	_ = os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0644)
+
+	loadedPrivate, err := LoadPrivateKey(privatePath)
+	if err != nil || !loadedPrivate.Equal(privateKey) {
+		t.Errorf("LoadPrivateKey() = %v, %v", loadedPrivate, err)
+	}
+	loadedPublic, err := LoadPublicKey(publicPath)
+	if err != nil || !loadedPublic.Equal(publicKey) {
+		t.Errorf("LoadPublicKey() = %v, %v", loadedPublic, err)
+	}
+
+	if _, err := LoadPrivateKey(publicPath); err == nil {
+		t.Error("Expected a public key to be rejected as a signing key")
+	}
+	if _, err := LoadPublicKey(filepath.Join(dir, "missing.pub")); err == nil {
+		t.Error("Expected an error for a missing key file")
+	}
+}
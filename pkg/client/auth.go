@@ -0,0 +1,117 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+// checkCredentials catches credentials that cannot work before any request is sent
+// A token pasted with its "Bearer " prefix, quotes, or a stray newline is rejected by JIRA with
+// the same 401 as an expired one, so these mistakes are named here instead.
+func checkCredentials(cfg *config.Config) error {
+	token := cfg.JIRAPAT
+	if token == "" {
+		return &ClientError{
+			Type:    "missing_credentials",
+			Message: "JIRA_PAT is not set - create a personal access token in your JIRA profile and set JIRA_PAT",
+			Context: "authentication",
+		}
+	}
+
+	var problem string
+	switch {
+	case strings.TrimSpace(token) != token:
+		problem = "has leading or trailing whitespace - check for a copied newline"
+	case strings.HasPrefix(strings.ToLower(token), "bearer "):
+		problem = `includes the "Bearer " prefix - set only the token itself`
+	case strings.ContainsAny(token[:1], `"'`) && strings.ContainsAny(token[len(token)-1:], `"'`):
+		problem = "is wrapped in quotes - remove them"
+	case strings.IndexFunc(token, unicode.IsSpace) >= 0:
+		problem = "contains whitespace - tokens are a single word"
+	case strings.IndexFunc(token, unicode.IsControl) >= 0:
+		problem = "contains control characters"
+	}
+	if problem != "" {
+		return &ClientError{
+			Type:    "malformed_credentials",
+			Message: "JIRA_PAT " + problem,
+			Context: "authentication",
+		}
+	}
+	return nil
+}
+
+// authenticationError explains a failed credential check in terms of which setting is wrong
+func (c *JIRAClient) authenticationError(err error, response *jira.Response) error {
+	if response == nil {
+		return &ClientError{
+			Type:    "connection_error",
+			Message: unreachableMessage(c.baseURL, err),
+			Err:     err,
+			Context: "authentication",
+		}
+	}
+
+	statusCode := response.StatusCode
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		message := "token present but rejected - check JIRA_PAT hasn't expired or been revoked"
+		if strings.Contains(response.Header.Get("X-Seraph-LoginReason"), "AUTHENTICATION_DENIED") {
+			message = "token rejected because of too many failed logins - sign in to JIRA in a browser to clear the CAPTCHA, then retry"
+		}
+		return &ClientError{Type: "authentication_error", Message: message, Err: err, Context: "authentication", StatusCode: statusCode}
+	case statusCode == http.StatusForbidden:
+		return &ClientError{
+			Type:       "authorization_error",
+			Message:    "token accepted but access denied - the account may be deactivated or lack REST API access",
+			Err:        err,
+			Context:    "authentication",
+			StatusCode: statusCode,
+		}
+	case statusCode == http.StatusNotFound:
+		return &ClientError{
+			Type:       "wrong_url",
+			Message:    "JIRA_BASE_URL " + c.baseURL + " does not serve the JIRA REST API - check the host and any context path such as /jira",
+			Err:        err,
+			Context:    "authentication",
+			StatusCode: statusCode,
+		}
+	case statusCode >= 200 && statusCode < 300:
+		// A success that does not decode is a login page or proxy answering in JIRA's place
+		return &ClientError{
+			Type:       "wrong_url",
+			Message:    "JIRA_BASE_URL " + c.baseURL + " answered but not as JIRA - check it points at JIRA and not a login page or proxy",
+			Err:        err,
+			Context:    "authentication",
+			StatusCode: statusCode,
+		}
+	}
+	return c.handleAPIError(err, response, "authentication")
+}
+
+// unreachableMessage describes why JIRA_BASE_URL could not be reached
+func unreachableMessage(baseURL string, err error) string {
+	message := "JIRA_BASE_URL " + baseURL + " unreachable"
+
+	var dnsErr *net.DNSError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var verifyErr *tls.CertificateVerificationError
+	switch {
+	case errors.As(err, &dnsErr):
+		return message + " - the host name does not resolve"
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr), errors.As(err, &verifyErr):
+		return message + " - its TLS certificate is not trusted; set JIRA_CA_CERT for a private CA"
+	case err != nil:
+		return message + ": " + err.Error()
+	}
+	return message
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func newAuthTestClient(t *testing.T, baseURL, token string) Client {
+	t.Helper()
+	jiraClient, err := NewClient(&config.Config{
+		JIRABaseURL:           baseURL,
+		JIRAPAT:               token,
+		MaxConcurrentRequests: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return jiraClient
+}
+
+func TestJIRAClient_AuthenticateFailureModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		handler  http.HandlerFunc
+		wantType string
+		wantText string
+	}{
+		{
+			name:     "missing token",
+			token:    "",
+			wantType: "missing_credentials",
+			wantText: "JIRA_PAT is not set",
+		},
+		{
+			name:     "bearer prefix",
+			token:    "Bearer test-pat-token-123",
+			wantType: "malformed_credentials",
+			wantText: "Bearer",
+		},
+		{
+			name:     "trailing newline",
+			token:    "test-pat-token-123\n",
+			wantType: "malformed_credentials",
+			wantText: "whitespace",
+		},
+		{
+			name:     "quoted",
+			token:    `"test-pat-token-123"`,
+			wantType: "malformed_credentials",
+			wantText: "quotes",
+		},
+		{
+			name:  "rejected token",
+			token: "test-pat-token-123",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantType: "authentication_error",
+			wantText: "token present but rejected",
+		},
+		{
+			name:  "captcha",
+			token: "test-pat-token-123",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Seraph-LoginReason", "AUTHENTICATION_DENIED")
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantType: "authentication_error",
+			wantText: "CAPTCHA",
+		},
+		{
+			name:  "permission denied",
+			token: "test-pat-token-123",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantType: "authorization_error",
+			wantText: "token accepted but access denied",
+		},
+		{
+			name:  "wrong context path",
+			token: "test-pat-token-123",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.NotFound(w, r)
+			},
+			wantType: "wrong_url",
+			wantText: "context path",
+		},
+		{
+			name:  "login page",
+			token: "test-pat-token-123",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				_, _ = w.Write([]byte("<html><body>Sign in</body></html>"))
+			},
+			wantType: "wrong_url",
+			wantText: "not as JIRA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/rest/api/2/myself" {
+					t.Errorf("Unexpected request: %s", r.URL)
+				}
+				tt.handler(w, r)
+			}))
+			defer server.Close()
+
+			err := newAuthTestClient(t, server.URL, tt.token).Authenticate()
+			clientErr, ok := err.(*ClientError)
+			if !ok || clientErr.Type != tt.wantType || !strings.Contains(err.Error(), tt.wantText) {
+				t.Fatalf("Authenticate() error = %v, want %s mentioning %q", err, tt.wantType, tt.wantText)
+			}
+			if tt.handler == nil && requests != 0 {
+				t.Errorf("Expected invalid credentials to be rejected without a request, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestJIRAClient_AuthenticateUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	baseURL := server.URL
+	server.Close()
+
+	err := newAuthTestClient(t, baseURL, "test-pat-token-123").Authenticate()
+	clientErr, ok := err.(*ClientError)
+	if !ok || clientErr.Type != "connection_error" || !strings.Contains(err.Error(), "JIRA_BASE_URL") || !strings.Contains(err.Error(), "unreachable") {
+		t.Fatalf("Authenticate() error = %v, want an unreachable JIRA_BASE_URL", err)
+	}
+}
+
+func TestJIRAClient_AuthenticateSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-pat-token-123" {
+			t.Errorf("Unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"jane","displayName":"Jane Doe"}`))
+	}))
+	defer server.Close()
+
+	if err := newAuthTestClient(t, server.URL, "test-pat-token-123").Authenticate(); err != nil {
+		t.Errorf("Authenticate() error = %v", err)
+	}
+}
+
+func TestIsAuthenticationError_CredentialChecks(t *testing.T) {
+	for _, errType := range []string{"authentication_error", "missing_credentials", "malformed_credentials"} {
+		if !IsAuthenticationError(&ClientError{Type: errType}) {
+			t.Errorf("Expected %s to be an authentication error", errType)
+		}
+	}
+	if IsAuthenticationError(&ClientError{Type: "wrong_url"}) {
+		t.Error("Expected wrong_url not to be an authentication error")
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// Bulk fetch sizes: issues requested per search call
+const (
+	DefaultBulkFetchSize = 50
+	MaxBulkFetchSize     = 100 // JIRA caps search pages at 100 issues
+)
+
+// BulkIssueClient is implemented by clients that can fetch many issues in one request
+type BulkIssueClient interface {
+	// GetIssues returns the full data of the given issues, fetched with a single JQL search.
+	// Issues that do not exist or cannot be viewed are left out rather than failing the call,
+	// so callers should fetch missing keys individually to learn why.
+	GetIssues(issueKeys []string) ([]*Issue, error)
+}
+
+// GetIssues fetches up to MaxBulkFetchSize issues with one search call instead of one GET each
+// The search requests all fields, so the issues match GetIssue. Data from per-issue endpoints
+// (remote links for external references) is still fetched for each issue.
+func (c *JIRAClient) GetIssues(issueKeys []string) ([]*Issue, error) {
+	if len(issueKeys) == 0 {
+		return nil, nil
+	}
+	if len(issueKeys) > MaxBulkFetchSize {
+		return nil, &ClientError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("cannot bulk fetch %d issues, at most %d per request", len(issueKeys), MaxBulkFetchSize),
+		}
+	}
+
+	jql := BulkFetchJQL(issueKeys)
+	searchOptions := &jira.SearchOptions{
+		MaxResults: len(issueKeys),
+		Fields:     []string{"*all"},
+		// Unknown keys are reported as warnings instead of rejecting the whole query
+		ValidateQuery: "warn",
+	}
+
+	jiraIssues, response, err := c.client.Issue.Search(jql, searchOptions)
+	if err != nil {
+		return nil, c.handleJQLError(err, response, jql)
+	}
+
+	issues := make([]*Issue, 0, len(jiraIssues))
+	for i := range jiraIssues {
+		issue := c.convertJIRAIssue(&jiraIssues[i])
+		c.attachExternalRefs(issue)
+		if err := c.attachWorklogs(issue, &jiraIssues[i]); err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// BulkFetchJQL returns the query selecting exactly the given issues
+func BulkFetchJQL(issueKeys []string) string {
+	return fmt.Sprintf("key in (%s)", strings.Join(issueKeys, ","))
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestJIRAClient_GetIssues(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if r.URL.Path != "/rest/api/2/search" || query.Get("jql") != "key in (PROJ-1,PROJ-2,PROJ-404)" {
+			t.Errorf("Unexpected request: %s", r.URL)
+		}
+		if query.Get("fields") != "*all" || query.Get("validateQuery") != "warn" || query.Get("maxResults") != "3" {
+			t.Errorf("Unexpected search options: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total":2,"issues":[
+			{"key":"PROJ-1","fields":{"summary":"First","status":{"name":"Done"}}},
+			{"key":"PROJ-2","fields":{"summary":"Second","status":{"name":"To Do"}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	jiraClient, err := NewClient(&config.Config{
+		JIRABaseURL:           server.URL,
+		JIRAPAT:               "test-pat-token-123",
+		MaxConcurrentRequests: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	bulk, ok := jiraClient.(BulkIssueClient)
+	if !ok {
+		t.Fatal("Expected JIRAClient to implement BulkIssueClient")
+	}
+
+	issues, err := bulk.GetIssues([]string{"PROJ-1", "PROJ-2", "PROJ-404"})
+	if err != nil {
+		t.Fatalf("GetIssues() error = %v", err)
+	}
+	if requests != 1 || len(issues) != 2 {
+		t.Fatalf("Expected 2 issues from 1 request, got %d from %d", len(issues), requests)
+	}
+	if issues[0].Summary != "First" || issues[1].Status.Name != "To Do" {
+		t.Errorf("Issues not converted: %+v, %+v", issues[0], issues[1])
+	}
+
+	tooMany := make([]string, MaxBulkFetchSize+1)
+	if _, err := bulk.GetIssues(tooMany); err == nil {
+		t.Error("Expected an error for more than MaxBulkFetchSize keys")
+	}
+}
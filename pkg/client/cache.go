@@ -0,0 +1,203 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CacheMode controls how the local response cache is used
+type CacheMode string
+
+const (
+	// CacheOff sends every request to JIRA (the default)
+	CacheOff CacheMode = "off"
+	// CacheRead answers requests from the cache only, never contacting JIRA
+	CacheRead CacheMode = "read"
+	// CacheWrite sends every request to JIRA and stores the responses
+	CacheWrite CacheMode = "write"
+	// CacheReadWrite answers from the cache and fetches and stores what is missing or expired
+	CacheReadWrite CacheMode = "readwrite"
+)
+
+// ParseCacheMode validates a --cache-mode value; empty disables the cache
+func ParseCacheMode(value string) (CacheMode, error) {
+	switch mode := CacheMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return CacheOff, nil
+	case CacheOff, CacheRead, CacheWrite, CacheReadWrite:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported cache mode %q (expected off, read, write, or readwrite)", value)
+	}
+}
+
+// reads reports whether responses are served from the cache
+func (m CacheMode) reads() bool {
+	return m == CacheRead || m == CacheReadWrite
+}
+
+// writes reports whether responses are stored in the cache
+func (m CacheMode) writes() bool {
+	return m == CacheWrite || m == CacheReadWrite
+}
+
+// ErrCacheMiss is returned in read mode for requests the cache cannot answer
+var ErrCacheMiss = errors.New("response not in cache")
+
+// cacheEntry is a stored JIRA response
+type cacheEntry struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"stored_at"`
+}
+
+// cacheTransport answers GET requests from a directory of stored responses
+// Entries are keyed by method and URL, so the query string (JQL, fields, paging) is part
+// of the key. Only successful responses are stored. A TTL of zero never expires entries;
+// refresh skips cache reads but still stores responses in the write modes.
+type cacheTransport struct {
+	base    http.RoundTripper
+	dir     string
+	mode    CacheMode
+	ttl     time.Duration
+	refresh bool
+	now     func() time.Time
+}
+
+// newCacheTransport wraps base with the response cache, or returns base when the cache is off
+func newCacheTransport(base http.RoundTripper, dir string, mode CacheMode, ttl time.Duration, refresh bool) (http.RoundTripper, error) {
+	if mode == "" || mode == CacheOff {
+		return base, nil
+	}
+	if dir == "" {
+		return nil, fmt.Errorf("cache mode %s requires a cache directory", mode)
+	}
+	if mode == CacheRead && refresh {
+		return nil, fmt.Errorf("cache mode read cannot refresh the cache (use readwrite or write)")
+	}
+	if mode.writes() {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create cache directory: %w", err)
+		}
+	}
+	return &cacheTransport{base: base, dir: dir, mode: mode, ttl: ttl, refresh: refresh, now: time.Now}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		if t.mode == CacheRead {
+			return nil, fmt.Errorf("%s %s: cache mode read cannot send requests to JIRA", req.Method, req.URL.Redacted())
+		}
+		return t.base.RoundTrip(req)
+	}
+
+	path := t.entryPath(req)
+	if t.mode.reads() && !t.refresh {
+		if entry, ok := t.load(path); ok {
+			return entry.response(req), nil
+		}
+		if t.mode == CacheRead {
+			return nil, fmt.Errorf("GET %s: %w", req.URL.Redacted(), ErrCacheMiss)
+		}
+	}
+
+	response, err := t.base.RoundTrip(req)
+	if err != nil || !t.mode.writes() || response.StatusCode < 200 || response.StatusCode > 299 {
+		return response, err
+	}
+
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A failed store only costs a later cache miss, so the response is returned regardless
+	_ = t.store(path, &cacheEntry{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Status:   response.StatusCode,
+		Header:   response.Header,
+		Body:     body,
+		StoredAt: t.now().UTC(),
+	})
+	return response, nil
+}
+
+// entryPath returns the file that holds the response to req
+func (t *cacheTransport) entryPath(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	key := hex.EncodeToString(sum[:])
+	return filepath.Join(t.dir, key[:2], key+".json")
+}
+
+// load reads an entry, reporting false when it is missing, unreadable, or expired
+func (t *cacheTransport) load(path string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if t.ttl > 0 && t.now().Sub(entry.StoredAt) > t.ttl {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// store writes an entry through a temporary file so concurrent readers never see partial JSON
+func (t *cacheTransport) store(path string, entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// response rebuilds the HTTP response an entry was stored from
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCacheTestServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"request":%d}`, n)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func cacheGet(t *testing.T, transport http.RoundTripper, url string) (int, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	response, err := transport.RoundTrip(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	return response.StatusCode, string(body), nil
+}
+
+func TestParseCacheMode(t *testing.T) {
+	for value, want := range map[string]CacheMode{"": CacheOff, "off": CacheOff, "READ": CacheRead, "write": CacheWrite, " readwrite ": CacheReadWrite} {
+		got, err := ParseCacheMode(value)
+		if err != nil || got != want {
+			t.Errorf("ParseCacheMode(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseCacheMode("sometimes"); err == nil {
+		t.Error("ParseCacheMode(sometimes) should fail")
+	}
+}
+
+func TestNewCacheTransport_Validation(t *testing.T) {
+	base := http.DefaultTransport
+	if transport, err := newCacheTransport(base, "", CacheOff, 0, false); err != nil || transport != base {
+		t.Errorf("cache off should return the base transport, got %v, %v", transport, err)
+	}
+	if _, err := newCacheTransport(base, "", CacheReadWrite, 0, false); err == nil {
+		t.Error("a cache mode without a directory should fail")
+	}
+	if _, err := newCacheTransport(base, t.TempDir(), CacheRead, 0, true); err == nil {
+		t.Error("read mode with refresh should fail")
+	}
+}
+
+func TestCacheTransport_ReadWrite(t *testing.T) {
+	server, requests := newCacheTestServer(t)
+	transport, err := newCacheTransport(http.DefaultTransport, t.TempDir(), CacheReadWrite, 0, false)
+	if err != nil {
+		t.Fatalf("newCacheTransport() error = %v", err)
+	}
+
+	_, first, err := cacheGet(t, transport, server.URL+"/rest/api/2/search?jql=project%3DPROJ")
+	if err != nil {
+		t.Fatalf("first request error = %v", err)
+	}
+	status, second, err := cacheGet(t, transport, server.URL+"/rest/api/2/search?jql=project%3DPROJ")
+	if err != nil {
+		t.Fatalf("second request error = %v", err)
+	}
+	if status != http.StatusOK || second != first || atomic.LoadInt32(requests) != 1 {
+		t.Errorf("expected the second request to be served from the cache: status %d, body %q vs %q, %d requests", status, second, first, *requests)
+	}
+
+	// A different query is a different entry
+	if _, _, err := cacheGet(t, transport, server.URL+"/rest/api/2/search?jql=project%3DOTHER"); err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	if atomic.LoadInt32(requests) != 2 {
+		t.Errorf("expected a new query to reach the server, got %d requests", *requests)
+	}
+
+	// Failed responses are not stored
+	for i := 0; i < 2; i++ {
+		if status, _, err := cacheGet(t, transport, server.URL+"/missing"); err != nil || status != http.StatusNotFound {
+			t.Fatalf("missing request = %d, %v", status, err)
+		}
+	}
+	if atomic.LoadInt32(requests) != 4 {
+		t.Errorf("expected failed responses to bypass the cache, got %d requests", *requests)
+	}
+}
+
+func TestCacheTransport_ReadOnlyOffline(t *testing.T) {
+	server, requests := newCacheTestServer(t)
+	dir := t.TempDir()
+	url := server.URL + "/rest/api/2/issue/PROJ-1"
+
+	writer, err := newCacheTransport(http.DefaultTransport, dir, CacheWrite, 0, false)
+	if err != nil {
+		t.Fatalf("newCacheTransport() error = %v", err)
+	}
+	_, stored, err := cacheGet(t, writer, url)
+	if err != nil {
+		t.Fatalf("write request error = %v", err)
+	}
+	server.Close()
+
+	reader, err := newCacheTransport(http.DefaultTransport, dir, CacheRead, 0, false)
+	if err != nil {
+		t.Fatalf("newCacheTransport() error = %v", err)
+	}
+	_, cached, err := cacheGet(t, reader, url)
+	if err != nil || cached != stored {
+		t.Errorf("read mode = %q, %v; want %q", cached, err, stored)
+	}
+	if _, _, err := cacheGet(t, reader, server.URL+"/rest/api/2/issue/PROJ-2"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected ErrCacheMiss for an uncached request, got %v", err)
+	}
+	if atomic.LoadInt32(requests) != 1 {
+		t.Errorf("read mode should never contact the server, got %d requests", *requests)
+	}
+}
+
+func TestCacheTransport_TTLAndRefresh(t *testing.T) {
+	server, requests := newCacheTestServer(t)
+	dir := t.TempDir()
+	url := server.URL + "/rest/api/2/myself"
+
+	transport, err := newCacheTransport(http.DefaultTransport, dir, CacheReadWrite, time.Hour, false)
+	if err != nil {
+		t.Fatalf("newCacheTransport() error = %v", err)
+	}
+	cache := transport.(*cacheTransport)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := cacheGet(t, transport, url); err != nil {
+			t.Fatalf("request error = %v", err)
+		}
+	}
+	if atomic.LoadInt32(requests) != 1 {
+		t.Fatalf("expected a fresh entry to be reused, got %d requests", *requests)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, _, err := cacheGet(t, transport, url); err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	if atomic.LoadInt32(requests) != 2 {
+		t.Errorf("expected an expired entry to be fetched again, got %d requests", *requests)
+	}
+
+	refresh, err := newCacheTransport(http.DefaultTransport, dir, CacheReadWrite, 0, true)
+	if err != nil {
+		t.Fatalf("newCacheTransport() error = %v", err)
+	}
+	_, refreshed, err := cacheGet(t, refresh, url)
+	if err != nil {
+		t.Fatalf("refresh request error = %v", err)
+	}
+	if atomic.LoadInt32(requests) != 3 {
+		t.Errorf("expected refresh to bypass the cache, got %d requests", *requests)
+	}
+	reader, err := newCacheTransport(http.DefaultTransport, dir, CacheRead, 0, false)
+	if err != nil {
+		t.Fatalf("newCacheTransport() error = %v", err)
+	}
+	_, cached, err := cacheGet(t, reader, url)
+	if err != nil || cached != refreshed {
+		t.Errorf("expected the refreshed response to be stored, got %q, %v; want %q", cached, err, refreshed)
+	}
+}
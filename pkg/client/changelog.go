@@ -0,0 +1,74 @@
+package client
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// ErrChangelogDisabled is returned for changelog requests when JIRA_INCLUDE_CHANGELOG is false
+var ErrChangelogDisabled = errors.New("issue changelog is disabled (set JIRA_INCLUDE_CHANGELOG=true)")
+
+// changelogTimeLayout is the timestamp format of JIRA changelog entries
+const changelogTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// StatusTransition is one status change recorded in an issue's changelog
+type StatusTransition struct {
+	From string    `json:"from" yaml:"from"`
+	To   string    `json:"to" yaml:"to"`
+	At   time.Time `json:"at" yaml:"at"`
+}
+
+// ChangelogClient is implemented by clients that can read issue changelogs
+type ChangelogClient interface {
+	// GetStatusTransitions returns the status changes of an issue, oldest first
+	GetStatusTransitions(issueKey string) ([]StatusTransition, error)
+}
+
+// GetStatusTransitions fetches an issue's changelog and returns its status changes, oldest first
+func (c *JIRAClient) GetStatusTransitions(issueKey string) ([]StatusTransition, error) {
+	if issueKey == "" {
+		return nil, &ClientError{
+			Type:    "invalid_input",
+			Message: "issue key cannot be empty",
+		}
+	}
+	if !c.config.IncludeChangelog {
+		return nil, ErrChangelogDisabled
+	}
+
+	jiraIssue, response, err := c.client.Issue.Get(issueKey, &jira.GetQueryOptions{Expand: "changelog"})
+	if err != nil {
+		return nil, c.handleAPIError(err, response, issueKey)
+	}
+	return statusTransitions(jiraIssue.Changelog), nil
+}
+
+// statusTransitions extracts the status changes from a changelog, skipping unparseable entries
+func statusTransitions(changelog *jira.Changelog) []StatusTransition {
+	if changelog == nil {
+		return nil
+	}
+
+	var transitions []StatusTransition
+	for _, history := range changelog.Histories {
+		at, err := time.Parse(changelogTimeLayout, history.Created)
+		if err != nil {
+			continue
+		}
+		for _, item := range history.Items {
+			if !strings.EqualFold(item.Field, "status") {
+				continue
+			}
+			transitions = append(transitions, StatusTransition{From: item.FromString, To: item.ToString, At: at})
+		}
+	}
+
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].At.Before(transitions[j].At)
+	})
+	return transitions
+}
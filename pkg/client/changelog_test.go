@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestJIRAClient_GetStatusTransitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1" || r.URL.Query().Get("expand") != "changelog" {
+			t.Errorf("Unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"PROJ-1","fields":{},"changelog":{"histories":[
+			{"created":"2024-03-02T10:00:00.000+0000","items":[{"field":"status","fromString":"In Progress","toString":"Done"}]},
+			{"created":"2024-03-01T09:00:00.000+0000","items":[
+				{"field":"summary","fromString":"Old","toString":"New"},
+				{"field":"status","fromString":"To Do","toString":"In Progress"}]},
+			{"created":"not a time","items":[{"field":"status","fromString":"Done","toString":"Reopened"}]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		JIRABaseURL:           server.URL,
+		JIRAPAT:               "test-pat-token-123",
+		MaxConcurrentRequests: 1,
+		IncludeChangelog:      true,
+	}
+	jiraClient, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	changelog, ok := jiraClient.(ChangelogClient)
+	if !ok {
+		t.Fatal("Expected JIRAClient to implement ChangelogClient")
+	}
+
+	transitions, err := changelog.GetStatusTransitions("PROJ-1")
+	if err != nil {
+		t.Fatalf("GetStatusTransitions() error = %v", err)
+	}
+	if len(transitions) != 2 {
+		t.Fatalf("Expected 2 status transitions, got %+v", transitions)
+	}
+	if transitions[0].From != "To Do" || transitions[0].To != "In Progress" || transitions[1].To != "Done" {
+		t.Errorf("Transitions not in changelog order: %+v", transitions)
+	}
+
+	cfg.IncludeChangelog = false
+	if _, err := changelog.GetStatusTransitions("PROJ-1"); !errors.Is(err, ErrChangelogDisabled) {
+		t.Errorf("Expected ErrChangelogDisabled, got %v", err)
+	}
+}
@@ -3,6 +3,9 @@ package client
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/andygrunwald/go-jira"
@@ -24,6 +27,18 @@ type JIRAClient struct {
 	client      *jira.Client
 	config      *config.Config
 	rateLimiter ratelimit.RateLimiter
+	baseURL     string // normalized base URL including any context path (e.g. https://host/jira/)
+
+	userResolver UserResolver // nil unless account ID resolution is enabled
+
+	refExtractor    *ExternalRefExtractor // nil unless external reference patterns are configured
+	scanRemoteLinks bool                  // also extract references from remote links
+
+	accessChecker *CachingProjectAccessChecker // per-run cache of project permission checks
+
+	fieldSchemas fieldSchemaCache // custom field metadata, fetched on first use
+
+	requests *requestObservers // notified of every request sent to JIRA
 }
 
 // Issue represents a JIRA issue with essential fields and relationships
@@ -39,7 +54,16 @@ type Issue struct {
 	Updated       string         `json:"updated" yaml:"updated"`
 	Priority      string         `json:"priority" yaml:"priority"`
 	IssueType     string         `json:"issuetype" yaml:"issuetype"`
+	Labels        []string       `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Relationships *Relationships `json:"relationships,omitempty" yaml:"relationships,omitempty"`
+	ExternalRefs  []ExternalRef  `json:"externalRefs,omitempty" yaml:"externalRefs,omitempty"`
+	CodeRefs      []CodeRef      `json:"codeRefs,omitempty" yaml:"codeRefs,omitempty"`
+	TimeTracking  *TimeTracking  `json:"time_tracking,omitempty" yaml:"time_tracking,omitempty"`
+	Worklogs      []Worklog      `json:"worklogs,omitempty" yaml:"worklogs,omitempty"`
+
+	// CustomFields holds the raw values of the custom fields set on the issue, keyed by field ID
+	// Only populated when JIRA_INCLUDE_CUSTOM_FIELDS is enabled; writers render them by FieldSchema.
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty" yaml:"custom_fields,omitempty"`
 }
 
 // Status represents JIRA issue status information
@@ -49,9 +73,12 @@ type Status struct {
 }
 
 // User represents JIRA user information
+// AccountID and Deactivated are only populated when account ID resolution is enabled
 type User struct {
-	Name  string `json:"name" yaml:"name"`
-	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	Name        string `json:"name" yaml:"name"`
+	Email       string `json:"email,omitempty" yaml:"email,omitempty"`
+	AccountID   string `json:"account_id,omitempty" yaml:"account_id,omitempty"`
+	Deactivated bool   `json:"deactivated,omitempty" yaml:"deactivated,omitempty"`
 }
 
 // Relationships represents JIRA issue relationships
@@ -100,16 +127,48 @@ func NewClient(cfg *config.Config) (Client, error) {
 	// Create rate limiter with configuration
 	rateLimiter := ratelimit.NewRateLimiter(cfg)
 
+	// Base transport carries any custom CA bundle for self-signed deployments
+	baseTransport, err := NewHTTPTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create rate-limited HTTP transport with Bearer token authentication
 	transport := ratelimit.NewBearerTokenRateLimitedTransport(cfg.JIRAPAT, rateLimiter)
+	transport.Base = baseTransport
 
+	// Retry transient failures, each attempt going through the rate limiter
+	retryPolicy, err := NewRetryPolicy(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cached responses are answered before the rate limiter, so cache hits cost no delay
+	cacheMode, err := ParseCacheMode(cfg.CacheMode)
+	if err != nil {
+		return nil, &ClientError{Type: "invalid_input", Message: "invalid cache configuration", Err: err}
+	}
+	requests := &requestObservers{}
+	observed := &observingTransport{base: transport, observers: requests}
+	cachedTransport, err := newCacheTransport(&retryTransport{base: observed, policy: retryPolicy}, cfg.CacheDir, cacheMode, cfg.CacheTTL, cfg.RefreshCache)
+	if err != nil {
+		return nil, &ClientError{Type: "invalid_input", Message: "invalid cache configuration", Err: err}
+	}
+
+	// Every request, including searches and downloads, identifies the tool to JIRA
 	httpClient := &http.Client{
-		Transport: transport,
+		Transport: NewIdentifyingTransport(cachedTransport, cfg.UserAgent, cfg.RequestIDs),
 		Timeout:   30 * time.Second, // 30-second timeout to prevent hanging requests
 	}
 
+	// Normalize base URL so context-path deployments (https://host/jira/) resolve REST paths correctly
+	baseURL, err := NormalizeBaseURL(cfg.JIRABaseURL)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create JIRA client with rate-limited HTTP client
-	jiraClient, err := jira.NewClient(httpClient, cfg.JIRABaseURL)
+	jiraClient, err := jira.NewClient(httpClient, baseURL)
 	if err != nil {
 		return nil, &ClientError{
 			Type:    "connection_error",
@@ -118,11 +177,35 @@ func NewClient(cfg *config.Config) (Client, error) {
 		}
 	}
 
-	return &JIRAClient{
+	jc := &JIRAClient{
 		client:      jiraClient,
 		config:      cfg,
 		rateLimiter: rateLimiter,
-	}, nil
+		baseURL:     baseURL,
+		requests:    requests,
+	}
+
+	jc.accessChecker = NewCachingProjectAccessChecker(jc.fetchProjectAccess)
+
+	// Account ID resolution costs extra API calls, so it is opt-in
+	if cfg.ResolveUsers {
+		jc.userResolver = NewCachingUserResolver(jiraClient.User.GetByAccountID)
+	}
+
+	if cfg.ExternalRefs != nil {
+		extractor, err := NewExternalRefExtractor(cfg.ExternalRefs.Patterns)
+		if err != nil {
+			return nil, &ClientError{
+				Type:    "invalid_input",
+				Message: "invalid external_refs configuration",
+				Err:     err,
+			}
+		}
+		jc.refExtractor = extractor
+		jc.scanRemoteLinks = cfg.ExternalRefs.RemoteLinks
+	}
+
+	return jc, nil
 }
 
 // GetIssue retrieves a single JIRA issue by key
@@ -142,9 +225,34 @@ func (c *JIRAClient) GetIssue(issueKey string) (*Issue, error) {
 
 	// Convert JIRA issue to our internal Issue structure
 	issue := c.convertJIRAIssue(jiraIssue)
+	c.attachExternalRefs(issue)
+	if err := c.attachWorklogs(issue, jiraIssue); err != nil {
+		return nil, err
+	}
 	return issue, nil
 }
 
+// attachExternalRefs records code-hosting references found in the description and, when
+// enabled, the remote links of an issue. Remote links are best effort: if they cannot be
+// fetched, references are still taken from the description.
+func (c *JIRAClient) attachExternalRefs(issue *Issue) {
+	if c.refExtractor == nil {
+		return
+	}
+
+	texts := []string{issue.Description}
+	if c.scanRemoteLinks {
+		if remoteLinks, _, err := c.client.Issue.GetRemoteLinks(issue.Key); err == nil && remoteLinks != nil {
+			for _, link := range *remoteLinks {
+				if link.Object != nil {
+					texts = append(texts, link.Object.URL)
+				}
+			}
+		}
+	}
+	issue.ExternalRefs = c.refExtractor.Extract(texts...)
+}
+
 // SearchIssues searches for JIRA issues using JQL query with pagination support
 // Based on SPIKE-002 findings: supports StartAt/MaxResults parameters, handles 33k+ issues efficiently
 func (c *JIRAClient) SearchIssues(jql string) ([]*Issue, error) {
@@ -175,6 +283,9 @@ func (c *JIRAClient) SearchIssues(jql string) ([]*Issue, error) {
 		// Convert JIRA issues to our internal Issue structure
 		for _, jiraIssue := range issues {
 			issue := c.convertJIRAIssue(&jiraIssue)
+			if err := c.attachWorklogs(issue, &jiraIssue); err != nil {
+				return nil, err
+			}
 			allIssues = append(allIssues, issue)
 		}
 
@@ -216,18 +327,32 @@ func (c *JIRAClient) SearchIssuesWithPagination(jql string, startAt, maxResults
 	var issues []*Issue
 	for _, jiraIssue := range jiraIssues {
 		issue := c.convertJIRAIssue(&jiraIssue)
+		if err := c.attachWorklogs(issue, &jiraIssue); err != nil {
+			return nil, 0, err
+		}
 		issues = append(issues, issue)
 	}
 
 	return issues, response.Total, nil
 }
 
+// BaseURL returns the normalized JIRA base URL, including any context path
+func (c *JIRAClient) BaseURL() string {
+	return c.baseURL
+}
+
 // Authenticate verifies the connection and credentials
+// Failures name the setting at fault: a missing or malformed JIRA_PAT, an unreachable or
+// wrong JIRA_BASE_URL, a rejected token, or an account without access.
 func (c *JIRAClient) Authenticate() error {
+	if err := checkCredentials(c.config); err != nil {
+		return err
+	}
+
 	// Try to get current user info to validate authentication
 	_, response, err := c.client.User.GetSelf()
 	if err != nil {
-		return c.handleAPIError(err, response, "authentication")
+		return c.authenticationError(err, response)
 	}
 	return nil
 }
@@ -253,18 +378,12 @@ func (c *JIRAClient) convertJIRAIssue(jiraIssue *jira.Issue) *Issue {
 
 	// Extract assignee information
 	if jiraIssue.Fields.Assignee != nil {
-		issue.Assignee = User{
-			Name:  jiraIssue.Fields.Assignee.DisplayName,
-			Email: jiraIssue.Fields.Assignee.EmailAddress,
-		}
+		issue.Assignee = c.convertUser(jiraIssue.Fields.Assignee)
 	}
 
 	// Extract reporter information
 	if jiraIssue.Fields.Reporter != nil {
-		issue.Reporter = User{
-			Name:  jiraIssue.Fields.Reporter.DisplayName,
-			Email: jiraIssue.Fields.Reporter.EmailAddress,
-		}
+		issue.Reporter = c.convertUser(jiraIssue.Fields.Reporter)
 	}
 
 	// Extract priority
@@ -275,9 +394,19 @@ func (c *JIRAClient) convertJIRAIssue(jiraIssue *jira.Issue) *Issue {
 	// Extract issue type
 	issue.IssueType = jiraIssue.Fields.Type.Name
 
+	// Extract labels
+	if len(jiraIssue.Fields.Labels) > 0 {
+		issue.Labels = append([]string(nil), jiraIssue.Fields.Labels...)
+	}
+
 	// Extract relationships based on SPIKE-003 findings
 	issue.Relationships = c.extractRelationships(jiraIssue)
 
+	// Custom fields make files much larger, so they are opt-in
+	if c.config != nil && c.config.IncludeCustomFields {
+		issue.CustomFields = extractCustomFields(jiraIssue.Fields.Unknowns)
+	}
+
 	return issue
 }
 
@@ -331,11 +460,65 @@ func (c *JIRAClient) extractRelationships(jiraIssue *jira.Issue) *Relationships
 
 	// Only return relationships if we found any
 	if hasRelationships {
+		SortRelationships(relationships)
 		return relationships
 	}
 	return nil
 }
 
+// SortRelationships orders subtasks and issue links deterministically
+// JIRA returns links in creation order, which differs between instances and after edits;
+// sorting keeps the YAML stable so Git diffs only show real relationship changes.
+func SortRelationships(relationships *Relationships) {
+	if relationships == nil {
+		return
+	}
+
+	sort.SliceStable(relationships.Subtasks, func(i, j int) bool {
+		return CompareIssueKeys(relationships.Subtasks[i], relationships.Subtasks[j]) < 0
+	})
+	sort.SliceStable(relationships.IssueLinks, func(i, j int) bool {
+		a, b := relationships.IssueLinks[i], relationships.IssueLinks[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Direction != b.Direction {
+			return a.Direction < b.Direction
+		}
+		return CompareIssueKeys(a.IssueKey, b.IssueKey) < 0
+	})
+}
+
+// CompareIssueKeys orders issue keys the way JQL "ORDER BY key" does: by project, then by
+// issue number numerically (PROJ-2 before PROJ-10). Returns -1, 0, or 1.
+func CompareIssueKeys(a, b string) int {
+	projectA, numberA := splitIssueKey(a)
+	projectB, numberB := splitIssueKey(b)
+	if projectA != projectB {
+		return strings.Compare(projectA, projectB)
+	}
+	if numberA >= 0 && numberB >= 0 && numberA != numberB {
+		if numberA < numberB {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// splitIssueKey splits "PROJ-123" into its project and number (-1 when not numeric)
+func splitIssueKey(key string) (string, int) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return key, -1
+	}
+	number, err := strconv.Atoi(key[i+1:])
+	if err != nil {
+		return key, -1
+	}
+	return key[:i], number
+}
+
 // extractEpicLink extracts epic link from Red Hat JIRA customfield_12311140
 func (c *JIRAClient) extractEpicLink(jiraIssue *jira.Issue) string {
 	if jiraIssue.Fields.Unknowns != nil {
@@ -398,64 +581,75 @@ func (c *JIRAClient) extractIssueLinks(jiraIssue *jira.Issue) []IssueLink {
 
 // handleJQLError creates appropriate error for JQL search operations
 func (c *JIRAClient) handleJQLError(err error, response *jira.Response, jql string) error {
+	var statusCode int
 	if response != nil {
+		statusCode = response.StatusCode
 		switch response.StatusCode {
 		case 400:
 			return &ClientError{
-				Type:    "jql_syntax_error",
-				Message: "invalid JQL syntax",
-				Err:     err,
-				Context: jql,
+				Type:       "jql_syntax_error",
+				Message:    "invalid JQL syntax",
+				Err:        err,
+				Context:    jql,
+				StatusCode: statusCode,
 			}
 		case 401:
 			return &ClientError{
-				Type:    "authentication_error",
-				Message: "authentication failed - check JIRA credentials",
-				Err:     err,
-				Context: jql,
+				Type:       "authentication_error",
+				Message:    "authentication failed - check JIRA credentials",
+				Err:        err,
+				Context:    jql,
+				StatusCode: statusCode,
 			}
 		case 403:
 			return &ClientError{
-				Type:    "authorization_error",
-				Message: "access denied - insufficient permissions for JQL search",
-				Err:     err,
-				Context: jql,
+				Type:       "authorization_error",
+				Message:    "access denied - insufficient permissions for JQL search",
+				Err:        err,
+				Context:    jql,
+				StatusCode: statusCode,
 			}
 		}
 	}
 
 	return &ClientError{
-		Type:    "jql_search_error",
-		Message: "JQL search request failed",
-		Err:     err,
-		Context: jql,
+		Type:       "jql_search_error",
+		Message:    "JQL search request failed",
+		Err:        err,
+		Context:    jql,
+		StatusCode: statusCode,
 	}
 }
 
 // handleAPIError creates appropriate error based on HTTP response
 func (c *JIRAClient) handleAPIError(err error, response *jira.Response, context string) error {
+	var statusCode int
 	if response != nil {
+		statusCode = response.StatusCode
 		switch response.StatusCode {
 		case 401:
 			return &ClientError{
-				Type:    "authentication_error",
-				Message: "authentication failed - check JIRA credentials",
-				Err:     err,
-				Context: context,
+				Type:       "authentication_error",
+				Message:    "authentication failed - check JIRA credentials",
+				Err:        err,
+				Context:    context,
+				StatusCode: statusCode,
 			}
 		case 403:
 			return &ClientError{
-				Type:    "authorization_error",
-				Message: "access denied - insufficient permissions",
-				Err:     err,
-				Context: context,
+				Type:       "authorization_error",
+				Message:    "access denied - insufficient permissions",
+				Err:        err,
+				Context:    context,
+				StatusCode: statusCode,
 			}
 		case 404:
 			return &ClientError{
-				Type:    "not_found",
-				Message: "issue not found",
-				Err:     err,
-				Context: context,
+				Type:       "not_found",
+				Message:    "issue not found",
+				Err:        err,
+				Context:    context,
+				StatusCode: statusCode,
 			}
 		}
 	}
@@ -476,9 +670,10 @@ func (c *JIRAClient) handleAPIError(err error, response *jira.Response, context
 	}
 
 	return &ClientError{
-		Type:    "api_error",
-		Message: message,
-		Err:     err,
-		Context: context,
+		Type:       "api_error",
+		Message:    message,
+		Err:        err,
+		Context:    context,
+		StatusCode: statusCode,
 	}
 }
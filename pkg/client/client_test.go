@@ -687,3 +687,71 @@ func TestCreateSubtaskIssue(t *testing.T) {
 		t.Errorf("Expected parent issue 'PARENT-123', got '%s'", subtask.Relationships.ParentIssue)
 	}
 }
+
+func TestExtractRelationships_SortedDeterministically(t *testing.T) {
+	cfg := &config.Config{
+		JIRABaseURL: "https://test.atlassian.net",
+		JIRAEmail:   "test@example.com",
+		JIRAPAT:     "test-pat-token-123",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	jiraClient := client.(*JIRAClient)
+
+	jiraIssue := &jira.Issue{
+		Key: "TEST-1",
+		Fields: &jira.IssueFields{
+			Subtasks: []*jira.Subtasks{{Key: "TEST-10"}, {Key: "TEST-2"}, {Key: "ALPHA-5"}},
+			IssueLinks: []*jira.IssueLink{
+				{Type: jira.IssueLinkType{Name: "Relates"}, OutwardIssue: &jira.Issue{Key: "TEST-30", Fields: &jira.IssueFields{}}},
+				{Type: jira.IssueLinkType{Name: "Blocks"}, OutwardIssue: &jira.Issue{Key: "TEST-12", Fields: &jira.IssueFields{}}},
+				{Type: jira.IssueLinkType{Name: "Blocks"}, InwardIssue: &jira.Issue{Key: "TEST-11", Fields: &jira.IssueFields{}}},
+				{Type: jira.IssueLinkType{Name: "Blocks"}, OutwardIssue: &jira.Issue{Key: "TEST-9", Fields: &jira.IssueFields{}}},
+			},
+		},
+	}
+
+	relationships := jiraClient.extractRelationships(jiraIssue)
+	if relationships == nil {
+		t.Fatal("Expected relationships to be extracted")
+	}
+
+	expectedSubtasks := []string{"ALPHA-5", "TEST-2", "TEST-10"}
+	for i, expected := range expectedSubtasks {
+		if relationships.Subtasks[i] != expected {
+			t.Errorf("Expected subtask[%d] '%s', got '%s'", i, expected, relationships.Subtasks[i])
+		}
+	}
+
+	expectedLinks := []string{"Blocks/inward/TEST-11", "Blocks/outward/TEST-9", "Blocks/outward/TEST-12", "Relates/outward/TEST-30"}
+	for i, expected := range expectedLinks {
+		link := relationships.IssueLinks[i]
+		if got := link.Type + "/" + link.Direction + "/" + link.IssueKey; got != expected {
+			t.Errorf("Expected link[%d] '%s', got '%s'", i, expected, got)
+		}
+	}
+}
+
+func TestCompareIssueKeys(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"PROJ-2", "PROJ-10", -1},
+		{"PROJ-10", "PROJ-2", 1},
+		{"PROJ-1", "PROJ-1", 0},
+		{"ALPHA-99", "BETA-1", -1},
+		{"MY-PROJECT-3", "MY-PROJECT-20", -1},
+		{"PROJ-abc", "PROJ-1", 1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareIssueKeys(tt.a, tt.b); got != tt.expected {
+			t.Errorf("CompareIssueKeys(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
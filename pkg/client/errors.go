@@ -8,6 +8,8 @@ type ClientError struct {
 	Message string // Human-readable error message
 	Err     error  // Underlying error
 	Context string // Additional context (issue key, operation, etc.)
+
+	StatusCode int // HTTP status of the failed request (0 when there was no response)
 }
 
 func (e *ClientError) Error() string {
@@ -21,10 +23,14 @@ func (e *ClientError) Unwrap() error {
 	return e.Err
 }
 
-// IsAuthenticationError checks if the error is related to authentication
+// IsAuthenticationError checks if the error is related to authentication, including
+// credentials that were missing or malformed before any request was sent
 func IsAuthenticationError(err error) bool {
 	if clientErr, ok := err.(*ClientError); ok {
-		return clientErr.Type == "authentication_error"
+		switch clientErr.Type {
+		case "authentication_error", "missing_credentials", "malformed_credentials":
+			return true
+		}
 	}
 	return false
 }
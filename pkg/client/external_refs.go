@@ -0,0 +1,109 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+// ExternalRef is a code-hosting reference (pull request, merge request, issue) found in an issue
+type ExternalRef struct {
+	Repo   string `json:"repo" yaml:"repo"`
+	Number int    `json:"number" yaml:"number"`
+	URL    string `json:"url" yaml:"url"`
+}
+
+// BuiltinExternalRefPatterns are the patterns used for pattern entries that only give a name
+var BuiltinExternalRefPatterns = map[string]string{
+	"github": `https://github\.com/(?P<repo>[\w.-]+/[\w.-]+)/(?:pull|issues)/(?P<number>\d+)`,
+	"gitlab": `https://gitlab\.com/(?P<repo>[\w.-]+(?:/[\w.-]+)+?)/-/(?:merge_requests|issues)/(?P<number>\d+)`,
+}
+
+// ExternalRefExtractor finds external references in issue text using configured URL patterns
+// Extraction is read-only: matched URLs are recorded as found, nothing is looked up remotely.
+type ExternalRefExtractor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewExternalRefExtractor compiles the configured patterns
+// Every pattern must define `repo` and `number` named groups.
+func NewExternalRefExtractor(patterns []config.ExternalRefPattern) (*ExternalRefExtractor, error) {
+	extractor := &ExternalRefExtractor{}
+	for _, p := range patterns {
+		source := p.Pattern
+		if source == "" {
+			builtin, ok := BuiltinExternalRefPatterns[p.Name]
+			if !ok {
+				return nil, fmt.Errorf("external ref pattern %q needs a pattern (built-ins: github, gitlab)", p.Name)
+			}
+			source = builtin
+		}
+
+		re, err := regexp.Compile(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid external ref pattern %q: %w", p.Name, err)
+		}
+		if re.SubexpIndex("repo") < 0 || re.SubexpIndex("number") < 0 {
+			return nil, fmt.Errorf("external ref pattern %q must define (?P<repo>...) and (?P<number>...) groups", p.Name)
+		}
+		extractor.patterns = append(extractor.patterns, re)
+	}
+	return extractor, nil
+}
+
+// Extract returns the references found in the given texts, in order of first appearance
+// A URL matched by several patterns or repeated across texts is recorded once.
+func (e *ExternalRefExtractor) Extract(texts ...string) []ExternalRef {
+	var refs []ExternalRef
+	seen := make(map[string]bool)
+
+	for _, text := range texts {
+		type match struct {
+			start int
+			ref   ExternalRef
+		}
+		var matches []match
+		for _, re := range e.patterns {
+			for _, loc := range re.FindAllStringSubmatchIndex(text, -1) {
+				number, err := strconv.Atoi(submatch(text, loc, re.SubexpIndex("number")))
+				if err != nil {
+					continue
+				}
+				matches = append(matches, match{start: loc[0], ref: ExternalRef{
+					Repo:   submatch(text, loc, re.SubexpIndex("repo")),
+					Number: number,
+					URL:    text[loc[0]:loc[1]],
+				}})
+			}
+		}
+
+		// Patterns are applied one after another; restore the order of the text
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+		for _, m := range matches {
+			if !seen[m.ref.URL] {
+				seen[m.ref.URL] = true
+				refs = append(refs, m.ref)
+			}
+		}
+	}
+	return refs
+}
+
+// submatch returns the text of a numbered group from a FindStringSubmatchIndex result
+func submatch(text string, loc []int, group int) string {
+	if 2*group+1 >= len(loc) || loc[2*group] < 0 {
+		return ""
+	}
+	return text[loc[2*group]:loc[2*group+1]]
+}
+
+// CodeRef is a commit of a local Git repository whose message references the issue, as in a
+// JIRA smart commit ("PROJ-123 #comment fixed")
+type CodeRef struct {
+	SHA     string `json:"sha" yaml:"sha"`
+	Subject string `json:"subject" yaml:"subject"`
+	Date    string `json:"date" yaml:"date"`
+}
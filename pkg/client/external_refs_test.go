@@ -0,0 +1,66 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestExternalRefExtractor_Builtins(t *testing.T) {
+	extractor, err := NewExternalRefExtractor([]config.ExternalRefPattern{{Name: "github"}, {Name: "gitlab"}})
+	if err != nil {
+		t.Fatalf("NewExternalRefExtractor() error = %v", err)
+	}
+
+	description := "Fixed by https://gitlab.com/group/sub/app/-/merge_requests/12 after " +
+		"https://github.com/acme/api/pull/42. See also https://github.com/acme/api/pull/42 and " +
+		"https://github.com/acme/web/issues/7."
+	got := extractor.Extract(description, "https://github.com/acme/web/issues/7")
+
+	want := []ExternalRef{
+		{Repo: "group/sub/app", Number: 12, URL: "https://gitlab.com/group/sub/app/-/merge_requests/12"},
+		{Repo: "acme/api", Number: 42, URL: "https://github.com/acme/api/pull/42"},
+		{Repo: "acme/web", Number: 7, URL: "https://github.com/acme/web/issues/7"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExternalRefExtractor_CustomPattern(t *testing.T) {
+	extractor, err := NewExternalRefExtractor([]config.ExternalRefPattern{{
+		Name:    "internal",
+		Pattern: `https://git\.example\.com/(?P<repo>[\w-]+/[\w-]+)/-/merge_requests/(?P<number>\d+)`,
+	}})
+	if err != nil {
+		t.Fatalf("NewExternalRefExtractor() error = %v", err)
+	}
+
+	got := extractor.Extract("MR: https://git.example.com/team/service/-/merge_requests/3, not https://github.com/acme/api/pull/1")
+	if len(got) != 1 || got[0].Repo != "team/service" || got[0].Number != 3 {
+		t.Errorf("Extract() = %+v, want one team/service!3 reference", got)
+	}
+
+	if refs := extractor.Extract(""); len(refs) != 0 {
+		t.Errorf("Expected no references in empty text, got %+v", refs)
+	}
+}
+
+func TestNewExternalRefExtractor_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern config.ExternalRefPattern
+	}{
+		{"unknown built-in", config.ExternalRefPattern{Name: "bitbucket"}},
+		{"invalid regex", config.ExternalRefPattern{Name: "bad", Pattern: `(?P<repo>[`}},
+		{"missing number group", config.ExternalRefPattern{Name: "bad", Pattern: `https://x/(?P<repo>\w+)/(\d+)`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewExternalRefExtractor([]config.ExternalRefPattern{tt.pattern}); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// ErrCustomFieldsDisabled is returned for field schema requests when JIRA_INCLUDE_CUSTOM_FIELDS is false
+var ErrCustomFieldsDisabled = errors.New("custom fields are disabled (set JIRA_INCLUDE_CUSTOM_FIELDS=true)")
+
+// customFieldPrefix marks the IDs of custom fields in issue payloads
+const customFieldPrefix = "customfield_"
+
+// FieldSchema describes the value type of a JIRA field, from the /rest/api/2/field metadata
+// Type is the JSON schema type (string, number, date, datetime, option, user, array, ...),
+// Items the element type of arrays, and Custom the plugin type key of custom fields
+// (e.g. com.pyxis.greenhopper.jira:gh-sprint).
+type FieldSchema struct {
+	ID     string `json:"id" yaml:"id"`
+	Name   string `json:"name" yaml:"name"`
+	Type   string `json:"type,omitempty" yaml:"type,omitempty"`
+	Items  string `json:"items,omitempty" yaml:"items,omitempty"`
+	Custom string `json:"custom,omitempty" yaml:"custom,omitempty"`
+}
+
+// FieldSchemaClient is implemented by clients that can describe the custom fields of a JIRA instance
+type FieldSchemaClient interface {
+	// GetFieldSchemas returns the schema of every custom field, keyed by field ID
+	GetFieldSchemas() (map[string]FieldSchema, error)
+}
+
+// fieldSchemaCache holds the field metadata fetched once per client
+type fieldSchemaCache struct {
+	mu      sync.Mutex
+	schemas map[string]FieldSchema
+}
+
+// GetFieldSchemas fetches the custom field metadata of the JIRA instance
+// The metadata is fetched once and cached for the lifetime of the client; failures are not cached.
+func (c *JIRAClient) GetFieldSchemas() (map[string]FieldSchema, error) {
+	if !c.config.IncludeCustomFields {
+		return nil, ErrCustomFieldsDisabled
+	}
+
+	c.fieldSchemas.mu.Lock()
+	defer c.fieldSchemas.mu.Unlock()
+	if c.fieldSchemas.schemas != nil {
+		return c.fieldSchemas.schemas, nil
+	}
+
+	fields, response, err := c.client.Field.GetList()
+	if err != nil {
+		return nil, c.handleAPIError(err, response, "field metadata")
+	}
+	c.fieldSchemas.schemas = customFieldSchemas(fields)
+	return c.fieldSchemas.schemas, nil
+}
+
+// customFieldSchemas keeps the custom fields of a field list, keyed by ID
+func customFieldSchemas(fields []jira.Field) map[string]FieldSchema {
+	schemas := make(map[string]FieldSchema)
+	for _, field := range fields {
+		if !field.Custom && !strings.HasPrefix(field.ID, customFieldPrefix) {
+			continue
+		}
+		schemas[field.ID] = FieldSchema{
+			ID:     field.ID,
+			Name:   field.Name,
+			Type:   field.Schema.Type,
+			Items:  field.Schema.Items,
+			Custom: field.Schema.Custom,
+		}
+	}
+	return schemas
+}
+
+// extractCustomFields returns the raw values of the custom fields set on an issue
+// Empty fields are dropped so files do not list every custom field of the instance.
+func extractCustomFields(unknowns map[string]interface{}) map[string]interface{} {
+	var values map[string]interface{}
+	for id, value := range unknowns {
+		if !strings.HasPrefix(id, customFieldPrefix) || isEmptyFieldValue(value) {
+			continue
+		}
+		if values == nil {
+			values = make(map[string]interface{})
+		}
+		values[id] = value
+	}
+	return values
+}
+
+// isEmptyFieldValue reports whether a decoded field value carries nothing worth writing
+func isEmptyFieldValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestExtractCustomFields(t *testing.T) {
+	values := extractCustomFields(map[string]interface{}{
+		"customfield_10001": map[string]interface{}{"value": "High"},
+		"customfield_10002": nil,
+		"customfield_10003": []interface{}{},
+		"customfield_10004": "",
+		"customfield_10005": float64(8),
+		"environment":       "prod",
+	})
+	if len(values) != 2 || values["customfield_10001"] == nil || values["customfield_10005"] != float64(8) {
+		t.Errorf("Expected only the two set custom fields, got %v", values)
+	}
+	if extractCustomFields(map[string]interface{}{"customfield_1": nil}) != nil {
+		t.Error("Expected nil when no custom field is set")
+	}
+}
+
+func TestJIRAClient_GetFieldSchemas(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/rest/api/2/field" {
+			t.Errorf("Unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id":"summary","name":"Summary","custom":false,"schema":{"type":"string","system":"summary"}},
+			{"id":"customfield_10020","name":"Sprint","custom":true,
+			 "schema":{"type":"array","items":"json","custom":"com.pyxis.greenhopper.jira:gh-sprint","customId":10020}},
+			{"id":"customfield_10030","name":"Teams","custom":true,
+			 "schema":{"type":"array","items":"option","custom":"com.atlassian.jira.plugin.system.customfieldtypes:multiselect"}}
+		]`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{JIRABaseURL: server.URL, JIRAPAT: "test-pat-token-123", MaxConcurrentRequests: 1}
+	jiraClient, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	fields, ok := jiraClient.(FieldSchemaClient)
+	if !ok {
+		t.Fatal("Expected JIRAClient to implement FieldSchemaClient")
+	}
+
+	if _, err := fields.GetFieldSchemas(); !errors.Is(err, ErrCustomFieldsDisabled) {
+		t.Fatalf("Expected ErrCustomFieldsDisabled, got %v", err)
+	}
+
+	cfg.IncludeCustomFields = true
+	for i := 0; i < 2; i++ {
+		schemas, err := fields.GetFieldSchemas()
+		if err != nil {
+			t.Fatalf("GetFieldSchemas() error = %v", err)
+		}
+		if len(schemas) != 2 {
+			t.Fatalf("Expected the two custom fields, got %v", schemas)
+		}
+		teams := schemas["customfield_10030"]
+		if teams.Name != "Teams" || teams.Type != "array" || teams.Items != "option" {
+			t.Errorf("Unexpected schema: %+v", teams)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected field metadata to be fetched once, got %d requests", calls)
+	}
+}
@@ -29,6 +29,9 @@ type MockClient struct {
 	// GetIssueCallCount tracks how many times GetIssue was called
 	GetIssueCallCount int
 
+	// GetIssuesCallCount tracks how many times GetIssues (bulk fetch) was called
+	GetIssuesCallCount int
+
 	// SearchIssuesCallCount tracks how many times SearchIssues was called
 	SearchIssuesCallCount int
 
@@ -40,6 +43,18 @@ type MockClient struct {
 
 	// LastJQLQuery tracks the last JQL query executed
 	LastJQLQuery string
+
+	// InaccessibleProjects maps project keys the user cannot browse to the reason reported
+	InaccessibleProjects map[string]string
+
+	// CheckProjectAccessCallCount tracks how many times CheckProjectAccess was called
+	CheckProjectAccessCallCount int
+
+	// StatusTransitions holds the changelog status changes returned per issue key
+	StatusTransitions map[string][]StatusTransition
+
+	// ChangelogError simulates changelog failures when set
+	ChangelogError error
 }
 
 // NewMockClient creates a new mock JIRA client for testing
@@ -90,6 +105,34 @@ func (m *MockClient) GetIssue(issueKey string) (*Issue, error) {
 	}
 }
 
+// GetIssues returns the mock issues among the given keys, leaving out unknown ones
+func (m *MockClient) GetIssues(issueKeys []string) ([]*Issue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.GetIssuesCallCount++
+
+	if m.APIError != nil {
+		return nil, m.APIError
+	}
+	if m.AuthenticationError != nil {
+		return nil, m.AuthenticationError
+	}
+	if len(issueKeys) > MaxBulkFetchSize {
+		return nil, &ClientError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("cannot bulk fetch %d issues, at most %d per request", len(issueKeys), MaxBulkFetchSize),
+		}
+	}
+
+	issues := make([]*Issue, 0, len(issueKeys))
+	for _, key := range issueKeys {
+		if issue, exists := m.Issues[key]; exists {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
 // SearchIssues simulates JQL search functionality for testing
 func (m *MockClient) SearchIssues(jql string) ([]*Issue, error) {
 	m.mu.Lock()
@@ -227,6 +270,37 @@ func (m *MockClient) Authenticate() error {
 	return nil
 }
 
+// CheckProjectAccess reports the project as accessible unless listed in InaccessibleProjects
+func (m *MockClient) CheckProjectAccess(projectKey string) (*ProjectAccess, error) {
+	m.mu.Lock()
+	m.CheckProjectAccessCallCount++
+	apiError := m.APIError
+	reason, inaccessible := m.InaccessibleProjects[projectKey]
+	m.mu.Unlock()
+
+	if apiError != nil {
+		return nil, apiError
+	}
+	if inaccessible {
+		return &ProjectAccess{Project: projectKey, Reason: reason}, nil
+	}
+	return &ProjectAccess{Project: projectKey, Accessible: true}, nil
+}
+
+// GetStatusTransitions returns the configured status transitions of an issue
+func (m *MockClient) GetStatusTransitions(issueKey string) ([]StatusTransition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ChangelogError != nil {
+		return nil, m.ChangelogError
+	}
+	if m.APIError != nil {
+		return nil, m.APIError
+	}
+	return m.StatusTransitions[issueKey], nil
+}
+
 // AddIssue adds a mock issue for testing
 func (m *MockClient) AddIssue(issue *Issue) {
 	m.mu.Lock()
@@ -271,6 +345,7 @@ func (m *MockClient) Reset() {
 	m.APIError = nil
 	m.JQLError = nil
 	m.GetIssueCallCount = 0
+	m.GetIssuesCallCount = 0
 	m.SearchIssuesCallCount = 0
 	m.SearchIssuesWithPaginationCallCount = 0
 	m.LastRequestedIssue = ""
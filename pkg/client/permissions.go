@@ -0,0 +1,115 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// PermissionBrowseProjects is the JIRA permission needed to read a project's issues
+const PermissionBrowseProjects = "BROWSE_PROJECTS"
+
+// ProjectAccess reports whether the current user can browse a project
+type ProjectAccess struct {
+	Project    string `json:"project"`
+	Accessible bool   `json:"accessible"`
+	Reason     string `json:"reason,omitempty"` // why the project is inaccessible
+}
+
+// ProjectAccessChecker is implemented by clients that can check project permissions up front
+// Checking before a bulk sync reports inaccessible projects once instead of as scattered 403s.
+type ProjectAccessChecker interface {
+	CheckProjectAccess(projectKey string) (*ProjectAccess, error)
+}
+
+// ProjectAccessLookupFunc fetches the current user's access to a single project
+type ProjectAccessLookupFunc func(projectKey string) (*ProjectAccess, error)
+
+// CachingProjectAccessChecker checks project access and caches the answers
+// The cache lives as long as the checker, so each project is checked at most once per run.
+// Failed lookups are not cached.
+type CachingProjectAccessChecker struct {
+	lookup ProjectAccessLookupFunc
+
+	mu    sync.Mutex
+	cache map[string]*ProjectAccess
+}
+
+// NewCachingProjectAccessChecker creates a project access checker backed by the given lookup
+func NewCachingProjectAccessChecker(lookup ProjectAccessLookupFunc) *CachingProjectAccessChecker {
+	return &CachingProjectAccessChecker{
+		lookup: lookup,
+		cache:  make(map[string]*ProjectAccess),
+	}
+}
+
+// CheckProjectAccess returns the cached access for a project, looking it up on first use
+func (c *CachingProjectAccessChecker) CheckProjectAccess(projectKey string) (*ProjectAccess, error) {
+	if projectKey == "" {
+		return nil, &ClientError{
+			Type:    "invalid_input",
+			Message: "project key cannot be empty",
+		}
+	}
+
+	c.mu.Lock()
+	if access, exists := c.cache[projectKey]; exists {
+		c.mu.Unlock()
+		return access, nil
+	}
+	c.mu.Unlock()
+
+	access, err := c.lookup(projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[projectKey] = access
+	c.mu.Unlock()
+	return access, nil
+}
+
+// CheckProjectAccess reports whether the authenticated user can browse a project
+// Results are cached for the lifetime of the client.
+func (c *JIRAClient) CheckProjectAccess(projectKey string) (*ProjectAccess, error) {
+	return c.accessChecker.CheckProjectAccess(projectKey)
+}
+
+// fetchProjectAccess asks JIRA for the user's Browse Projects permission on a project
+// JIRA answers 404 both for projects that do not exist and for projects the user cannot see.
+func (c *JIRAClient) fetchProjectAccess(projectKey string) (*ProjectAccess, error) {
+	endpoint := fmt.Sprintf("rest/api/2/mypermissions?projectKey=%s&permissions=%s",
+		url.QueryEscape(projectKey), PermissionBrowseProjects)
+	req, err := c.client.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, &ClientError{
+			Type:    "invalid_input",
+			Message: "failed to build permission request",
+			Err:     err,
+			Context: projectKey,
+		}
+	}
+
+	var payload struct {
+		Permissions map[string]struct {
+			HavePermission bool `json:"havePermission"`
+		} `json:"permissions"`
+	}
+	response, err := c.client.Do(req, &payload)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return &ProjectAccess{Project: projectKey, Reason: "project not found or not visible"}, nil
+		}
+		return nil, c.handleAPIError(err, response, projectKey)
+	}
+
+	access := &ProjectAccess{Project: projectKey}
+	if permission, ok := payload.Permissions[PermissionBrowseProjects]; ok && permission.HavePermission {
+		access.Accessible = true
+	} else {
+		access.Reason = "missing Browse Projects permission"
+	}
+	return access, nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestCachingProjectAccessChecker(t *testing.T) {
+	calls := 0
+	fail := true
+	checker := NewCachingProjectAccessChecker(func(projectKey string) (*ProjectAccess, error) {
+		calls++
+		if fail {
+			return nil, errors.New("timeout")
+		}
+		return &ProjectAccess{Project: projectKey, Accessible: true}, nil
+	})
+
+	if _, err := checker.CheckProjectAccess(""); err == nil {
+		t.Error("Expected error for empty project key")
+	}
+	if _, err := checker.CheckProjectAccess("PROJ"); err == nil {
+		t.Fatal("Expected lookup error")
+	}
+
+	fail = false
+	for i := 0; i < 3; i++ {
+		access, err := checker.CheckProjectAccess("PROJ")
+		if err != nil || !access.Accessible {
+			t.Fatalf("CheckProjectAccess() = %+v, %v", access, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected failed lookups to be retried and successes cached (2 calls), got %d", calls)
+	}
+}
+
+func TestJIRAClient_CheckProjectAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jira/rest/api/2/mypermissions" || r.URL.Query().Get("permissions") != PermissionBrowseProjects {
+			t.Errorf("Unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("projectKey") {
+		case "PROJ":
+			_, _ = w.Write([]byte(`{"permissions":{"BROWSE_PROJECTS":{"havePermission":true}}}`))
+		case "HIDDEN":
+			_, _ = w.Write([]byte(`{"permissions":{"BROWSE_PROJECTS":{"havePermission":false}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["No project could be found"]}`))
+		}
+	}))
+	defer server.Close()
+
+	jiraClient, err := NewClient(&config.Config{
+		JIRABaseURL:           server.URL + "/jira",
+		JIRAPAT:               "test-pat-token-123",
+		MaxConcurrentRequests: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	checker, ok := jiraClient.(ProjectAccessChecker)
+	if !ok {
+		t.Fatal("Expected JIRAClient to implement ProjectAccessChecker")
+	}
+
+	tests := map[string]string{"PROJ": "", "HIDDEN": "permission", "GONE": "not found"}
+	for project, reason := range tests {
+		access, err := checker.CheckProjectAccess(project)
+		if err != nil {
+			t.Fatalf("CheckProjectAccess(%s) error = %v", project, err)
+		}
+		if access.Accessible != (reason == "") || !strings.Contains(access.Reason, reason) {
+			t.Errorf("CheckProjectAccess(%s) = %+v, want reason containing %q", project, access, reason)
+		}
+	}
+}
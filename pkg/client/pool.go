@@ -0,0 +1,106 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+// Pool shares authenticated clients between concurrent syncs
+// Syncs against the same host with the same credentials and rate limit get one client, so
+// they authenticate once and pace their requests through one rate limiter. Any other host or
+// configuration gets a client of its own. Clients are safe for concurrent use.
+type Pool struct {
+	newClient func(cfg *config.Config) (Client, error)
+
+	mu      sync.Mutex
+	entries map[string]*poolEntry
+}
+
+// poolEntry holds the shared client of one host; its lock makes concurrent first requests
+// wait for a single authentication
+type poolEntry struct {
+	mu     sync.Mutex
+	client Client
+}
+
+// NewPool creates an empty client pool
+func NewPool() *Pool {
+	return NewPoolWithFactory(NewClient)
+}
+
+// NewPoolWithFactory creates an empty client pool that builds clients with newClient
+func NewPoolWithFactory(newClient func(cfg *config.Config) (Client, error)) *Pool {
+	return &Pool{
+		newClient: newClient,
+		entries:   make(map[string]*poolEntry),
+	}
+}
+
+// Get returns the authenticated client shared by syncs with this configuration
+// A client is created and authenticated on first use; failures are not cached, so a later
+// sync retries.
+func (p *Pool) Get(cfg *config.Config) (Client, error) {
+	key, err := poolKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	entry, ok := p.entries[key]
+	if !ok {
+		entry = &poolEntry{}
+		p.entries[key] = entry
+	}
+	p.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.client != nil {
+		return entry.client, nil
+	}
+
+	jiraClient, err := p.newClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JIRA client: %w", err)
+	}
+	if err := jiraClient.Authenticate(); err != nil {
+		return nil, fmt.Errorf("failed to authenticate with JIRA: %w", err)
+	}
+	entry.client = jiraClient
+	return jiraClient, nil
+}
+
+// Len returns the number of authenticated clients in the pool
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	entries := make([]*poolEntry, 0, len(p.entries))
+	for _, entry := range p.entries {
+		entries = append(entries, entry)
+	}
+	p.mu.Unlock()
+
+	count := 0
+	for _, entry := range entries {
+		entry.mu.Lock()
+		if entry.client != nil {
+			count++
+		}
+		entry.mu.Unlock()
+	}
+	return count
+}
+
+// poolKey identifies the configurations that may share a client: the host, the credentials
+// (hashed, so tokens are not kept as map keys), and the rate limit
+func poolKey(cfg *config.Config) (string, error) {
+	baseURL, err := NormalizeBaseURL(cfg.JIRABaseURL)
+	if err != nil {
+		return "", err
+	}
+	token := sha256.Sum256([]byte(cfg.JIRAPAT))
+	return fmt.Sprintf("%s|%s|%s|%s", baseURL, cfg.JIRAEmail, hex.EncodeToString(token[:]), cfg.RateLimitDelay), nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestPool_SharesClientPerHost(t *testing.T) {
+	var created int32
+	pool := NewPoolWithFactory(func(cfg *config.Config) (Client, error) {
+		atomic.AddInt32(&created, 1)
+		time.Sleep(10 * time.Millisecond) // let concurrent callers pile up
+		return NewMockClient(), nil
+	})
+	cfg := &config.Config{JIRABaseURL: "https://jira.example.com", JIRAEmail: "a@example.com", JIRAPAT: "token-1234567", RateLimitDelay: 100 * time.Millisecond}
+
+	clients := make([]Client, 8)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			shared, err := pool.Get(cfg)
+			if err != nil {
+				t.Errorf("Get() error = %v", err)
+			}
+			clients[i] = shared
+		}(i)
+	}
+	wg.Wait()
+
+	if created != 1 {
+		t.Errorf("Expected one client for concurrent syncs of one host, created %d", created)
+	}
+	for _, shared := range clients[1:] {
+		if shared != clients[0] {
+			t.Fatal("Expected every sync to get the same client")
+		}
+	}
+
+	// The same host with a trailing slash shares the client; other hosts get their own
+	sameHost := *cfg
+	sameHost.JIRABaseURL = "https://jira.example.com/"
+	if shared, _ := pool.Get(&sameHost); shared != clients[0] {
+		t.Error("Expected an equivalent base URL to share the client")
+	}
+	otherHost := *cfg
+	otherHost.JIRABaseURL = "https://other.example.com"
+	if other, _ := pool.Get(&otherHost); other == clients[0] {
+		t.Error("Expected another host to get its own client")
+	}
+	otherToken := *cfg
+	otherToken.JIRAPAT = "token-7654321"
+	if other, _ := pool.Get(&otherToken); other == clients[0] {
+		t.Error("Expected other credentials to get their own client")
+	}
+	if pool.Len() != 3 {
+		t.Errorf("Expected 3 pooled clients, got %d", pool.Len())
+	}
+}
+
+func TestPool_DoesNotCacheFailures(t *testing.T) {
+	mock := NewMockClient()
+	mock.AuthenticationError = errors.New("unauthorized")
+	pool := NewPoolWithFactory(func(cfg *config.Config) (Client, error) { return mock, nil })
+	cfg := &config.Config{JIRABaseURL: "https://jira.example.com", JIRAPAT: "token-1234567"}
+
+	if _, err := pool.Get(cfg); err == nil {
+		t.Fatal("Expected an authentication failure")
+	}
+	if pool.Len() != 0 {
+		t.Errorf("Expected a failed client not to be pooled, got %d", pool.Len())
+	}
+
+	mock.AuthenticationError = nil
+	if _, err := pool.Get(cfg); err != nil {
+		t.Fatalf("Expected a retry to authenticate, got %v", err)
+	}
+	if _, err := pool.Get(&config.Config{JIRABaseURL: ""}); err == nil {
+		t.Error("Expected an invalid base URL to fail")
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+)
+
+// RequestObserverClient is implemented by clients that can report each HTTP request they send
+type RequestObserverClient interface {
+	// ObserveRequests calls observe before every request sent to JIRA until stop is called,
+	// including retries, worklog pages, user lookups, and remote links. Responses answered
+	// from the response cache are not sent, so they are not observed. ok is false when the
+	// client cannot observe its requests.
+	ObserveRequests(observe func()) (stop func(), ok bool)
+}
+
+// requestObservers holds the functions notified of each request a client sends
+type requestObservers struct {
+	mu        sync.RWMutex
+	next      int
+	observers map[int]func()
+}
+
+func (o *requestObservers) add(observe func()) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.observers == nil {
+		o.observers = make(map[int]func())
+	}
+	id := o.next
+	o.next++
+	o.observers[id] = observe
+	return func() {
+		o.mu.Lock()
+		delete(o.observers, id)
+		o.mu.Unlock()
+	}
+}
+
+func (o *requestObservers) notify() {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	for _, observe := range o.observers {
+		observe()
+	}
+}
+
+// observingTransport reports every request to the client's observers before sending it
+// It sits below the retry transport, so each retry is reported, and below the response cache,
+// so cache hits are not.
+type observingTransport struct {
+	base      http.RoundTripper
+	observers *requestObservers
+}
+
+func (t *observingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.observers.notify()
+	return t.base.RoundTrip(req)
+}
+
+// ObserveRequests reports every HTTP request the client sends to observe until stop is called
+func (c *JIRAClient) ObserveRequests(observe func()) (func(), bool) {
+	if c.requests == nil {
+		return nil, false
+	}
+	return c.requests.add(observe), true
+}
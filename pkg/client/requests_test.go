@@ -0,0 +1,58 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestJIRAClient_ObserveRequests(t *testing.T) {
+	var served atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt fails transiently and is retried
+		if served.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"PROJ-1","fields":{"summary":"Test"}}`))
+	}))
+	defer server.Close()
+
+	jiraClient, err := NewClient(&config.Config{
+		JIRABaseURL:            server.URL,
+		JIRAPAT:                "test-pat-token-123",
+		MaxConcurrentRequests:  1,
+		MaxRetries:             2,
+		RetryStatusCodes:       "503",
+		ExponentialBackoffBase: time.Millisecond,
+		MaxBackoffDelay:        time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var observed atomic.Int64
+	stop, ok := jiraClient.(RequestObserverClient).ObserveRequests(func() { observed.Add(1) })
+	if !ok {
+		t.Fatal("Expected JIRAClient to observe its requests")
+	}
+	if _, err := jiraClient.GetIssue("PROJ-1"); err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if observed.Load() != 2 {
+		t.Errorf("Expected the request and its retry to be observed, got %d", observed.Load())
+	}
+
+	stop()
+	if _, err := jiraClient.GetIssue("PROJ-1"); err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if observed.Load() != 2 {
+		t.Errorf("Expected no requests observed after stop, got %d", observed.Load())
+	}
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+// RetryPolicy classifies failed JIRA requests as transient and spaces out their retries
+// The client retries a request MaxRetries times; the batch engine retries an issue whose
+// sync failed on a transient JIRA error IssueRetries times, after the client gave up.
+type RetryPolicy struct {
+	MaxRetries    int
+	IssueRetries  int
+	StatusCodes   map[int]bool
+	ErrorPatterns []string // lower-case substrings of transport errors
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+}
+
+// NewRetryPolicy builds the retry policy from configuration
+func NewRetryPolicy(cfg *config.Config) (*RetryPolicy, error) {
+	codes, err := config.ParseRetryStatusCodes(cfg.RetryStatusCodes)
+	if err != nil {
+		return nil, &ClientError{
+			Type:    "invalid_input",
+			Message: "invalid JIRA_RETRY_STATUS_CODES",
+			Err:     err,
+		}
+	}
+
+	policy := &RetryPolicy{
+		MaxRetries:    cfg.MaxRetries,
+		IssueRetries:  cfg.IssueRetries,
+		StatusCodes:   make(map[int]bool, len(codes)),
+		ErrorPatterns: config.ParseRetryErrorPatterns(cfg.RetryErrorPatterns),
+		BaseDelay:     cfg.ExponentialBackoffBase,
+		MaxDelay:      cfg.MaxBackoffDelay,
+	}
+	for _, code := range codes {
+		policy.StatusCodes[code] = true
+	}
+	return policy, nil
+}
+
+// RetryableStatus reports whether a response status is transient
+func (p *RetryPolicy) RetryableStatus(statusCode int) bool {
+	return p != nil && p.StatusCodes[statusCode]
+}
+
+// RetryableTransportError reports whether a request that got no response failed transiently
+func (p *RetryPolicy) RetryableTransportError(err error) bool {
+	if p == nil || err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, pattern := range p.ErrorPatterns {
+		if strings.Contains(message, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryableError reports whether an operation failed on a transient JIRA error
+// Only JIRA client errors qualify: by their HTTP status, or by the error patterns when the
+// request got no response. Errors from writing files or committing are never retried.
+func (p *RetryPolicy) RetryableError(err error) bool {
+	var clientErr *ClientError
+	if p == nil || !errors.As(err, &clientErr) {
+		return false
+	}
+	if clientErr.StatusCode != 0 {
+		return p.RetryableStatus(clientErr.StatusCode)
+	}
+	return p.RetryableTransportError(clientErr) || p.RetryableTransportError(clientErr.Err)
+}
+
+// Delay returns the wait before a retry: exponential from BaseDelay, capped at MaxDelay
+func (p *RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// retryTransport retries idempotent requests that fail transiently, per the retry policy
+type retryTransport struct {
+	base   http.RoundTripper
+	policy *RetryPolicy
+}
+
+// RoundTrip implements http.RoundTripper, honoring Retry-After on retried responses
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	response, err := t.base.RoundTrip(req)
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return response, err
+	}
+
+	for attempt := 1; attempt <= t.policy.MaxRetries; attempt++ {
+		if err != nil && !t.policy.RetryableTransportError(err) {
+			return response, err
+		}
+		if err == nil && !t.policy.RetryableStatus(response.StatusCode) {
+			return response, err
+		}
+
+		delay := t.policy.Delay(attempt)
+		if response != nil {
+			if seconds, parseErr := strconv.Atoi(response.Header.Get("Retry-After")); parseErr == nil && seconds >= 0 {
+				delay = min(time.Duration(seconds)*time.Second, t.policy.MaxDelay)
+			}
+			_ = response.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		response, err = t.base.RoundTrip(req)
+	}
+	return response, err
+}
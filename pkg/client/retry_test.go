@@ -0,0 +1,156 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func testRetryPolicy(t *testing.T, statusCodes, patterns string) *RetryPolicy {
+	t.Helper()
+	policy, err := NewRetryPolicy(&config.Config{
+		MaxRetries:             2,
+		IssueRetries:           1,
+		RetryStatusCodes:       statusCodes,
+		RetryErrorPatterns:     patterns,
+		ExponentialBackoffBase: time.Millisecond,
+		MaxBackoffDelay:        4 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewRetryPolicy() error = %v", err)
+	}
+	return policy
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCodes  string
+		method       string
+		failures     int
+		wantRequests int
+		wantStatus   int
+	}{
+		{"recovers after transient failures", "", http.MethodGet, 2, 3, http.StatusOK},
+		{"gives up after max retries", "", http.MethodGet, 5, 3, http.StatusServiceUnavailable},
+		{"status not retryable", "429,504", http.MethodGet, 1, 1, http.StatusServiceUnavailable},
+		{"no status retryable", "none", http.MethodGet, 1, 1, http.StatusServiceUnavailable},
+		{"non-idempotent method", "", http.MethodPost, 1, 1, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			httpClient := &http.Client{Transport: &retryTransport{
+				base:   http.DefaultTransport,
+				policy: testRetryPolicy(t, tt.statusCodes, ""),
+			}}
+			req, _ := http.NewRequest(tt.method, server.URL, nil)
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				t.Fatalf("request error = %v", err)
+			}
+			_ = resp.Body.Close()
+			if requests != tt.wantRequests || resp.StatusCode != tt.wantStatus {
+				t.Errorf("got HTTP %d after %d requests, want HTTP %d after %d", resp.StatusCode, requests, tt.wantStatus, tt.wantRequests)
+			}
+		})
+	}
+}
+
+// failingRoundTripper fails the first failures requests with err
+type failingRoundTripper struct {
+	err      error
+	failures int
+	requests int
+}
+
+func (f *failingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests++
+	if f.requests <= f.failures {
+		return nil, f.err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRetryTransport_ErrorPatterns(t *testing.T) {
+	reset := &failingRoundTripper{err: errors.New("read tcp: connection reset by peer"), failures: 1}
+	transport := &retryTransport{base: reset, policy: testRetryPolicy(t, "", "")}
+	req, _ := http.NewRequest(http.MethodGet, "http://jira.example.com", nil)
+	if _, err := transport.RoundTrip(req); err != nil || reset.requests != 2 {
+		t.Errorf("Expected a retried connection reset to succeed, got %v after %d requests", err, reset.requests)
+	}
+
+	dns := &failingRoundTripper{err: errors.New("dial tcp: lookup jira.example.com: no such host"), failures: 1}
+	transport = &retryTransport{base: dns, policy: testRetryPolicy(t, "", "")}
+	if _, err := transport.RoundTrip(req); err == nil || dns.requests != 1 {
+		t.Errorf("Expected an unmatched error not to be retried, got %v after %d requests", err, dns.requests)
+	}
+
+	custom := &failingRoundTripper{err: errors.New("proxy: upstream MAINTENANCE window"), failures: 1}
+	transport = &retryTransport{base: custom, policy: testRetryPolicy(t, "", "maintenance")}
+	if _, err := transport.RoundTrip(req); err != nil || custom.requests != 2 {
+		t.Errorf("Expected a custom pattern to match case-insensitively, got %v after %d requests", err, custom.requests)
+	}
+}
+
+func TestRetryPolicy_RetryableError(t *testing.T) {
+	policy := testRetryPolicy(t, "", "")
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"retryable status", &ClientError{Type: "api_error", StatusCode: 503}, true},
+		{"wrapped", fmt.Errorf("failed to fetch issue PROJ-1: %w", &ClientError{Type: "api_error", StatusCode: 502}), true},
+		{"not found", &ClientError{Type: "not_found", StatusCode: 404}, false},
+		{"network error", &ClientError{Type: "api_error", Message: "network/connection error", Err: errors.New("i/o timeout")}, true},
+		{"not a JIRA error", errors.New("failed to write YAML: i/o timeout"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.RetryableError(tt.err); got != tt.want {
+				t.Errorf("RetryableError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var disabled *RetryPolicy
+	if disabled.RetryableError(&ClientError{StatusCode: 503}) {
+		t.Error("Expected a nil policy to retry nothing")
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	var delays []string
+	for attempt := 1; attempt <= 4; attempt++ {
+		delays = append(delays, policy.Delay(attempt).String())
+	}
+	if got := strings.Join(delays, ","); got != "1s,2s,4s,5s" {
+		t.Errorf("delays = %s, want 1s,2s,4s,5s", got)
+	}
+}
+
+func TestNewRetryPolicy_InvalidStatusCodes(t *testing.T) {
+	if _, err := NewRetryPolicy(&config.Config{RetryStatusCodes: "503,abc"}); err == nil {
+		t.Error("Expected an error for an invalid status code")
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import "context"
+
+// DefaultSearchPageSize is the number of issues requested per JQL search page
+const DefaultSearchPageSize = 100
+
+// SearchPage is one page of issue keys returned by a streaming JQL search
+type SearchPage struct {
+	Keys    []string // issue keys on this page, in result order
+	StartAt int      // offset of the first key within the full result set
+	Total   int      // total number of matching issues as reported by JIRA
+}
+
+// IssuePage is one page of issues returned by a streaming JQL search
+type IssuePage struct {
+	Issues  []*Issue // issues on this page, in result order
+	StartAt int      // offset of the first issue within the full result set
+	Total   int      // total number of matching issues as reported by JIRA
+}
+
+// StreamIssueKeys runs a JQL search page by page, handing each page of issue keys to handle
+// Only one page is held in memory at a time, so callers that consume keys as they arrive keep
+// memory bounded regardless of how many issues match. Streaming stops at the first error from
+// the search or from handle, or when ctx is cancelled.
+func StreamIssueKeys(ctx context.Context, c Client, jql string, pageSize int, handle func(SearchPage) error) error {
+	return StreamIssues(ctx, c, jql, pageSize, func(page IssuePage) error {
+		keys := make([]string, len(page.Issues))
+		for i, issue := range page.Issues {
+			keys[i] = issue.Key
+		}
+		return handle(SearchPage{Keys: keys, StartAt: page.StartAt, Total: page.Total})
+	})
+}
+
+// StreamIssues runs a JQL search page by page, handing each page of issues to handle
+// It bounds memory the same way as StreamIssueKeys, for callers that use the search results
+// directly instead of fetching each issue again.
+func StreamIssues(ctx context.Context, c Client, jql string, pageSize int, handle func(IssuePage) error) error {
+	if jql == "" {
+		return &ClientError{
+			Type:    "invalid_input",
+			Message: "JQL query cannot be empty",
+		}
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultSearchPageSize
+	}
+
+	startAt := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		issues, total, err := c.SearchIssuesWithPagination(jql, startAt, pageSize)
+		if err != nil {
+			return err
+		}
+
+		if err := handle(IssuePage{Issues: issues, StartAt: startAt, Total: total}); err != nil {
+			return err
+		}
+
+		// An empty page guards against totals that shrink while paging
+		if len(issues) == 0 || startAt+len(issues) >= total {
+			return nil
+		}
+
+		startAt += len(issues)
+	}
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestStreamIssueKeys_Pages(t *testing.T) {
+	mock := NewMockClient()
+	jql := "project = PROJ"
+	for i := 1; i <= 5; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		mock.Issues[key] = &Issue{Key: key}
+		mock.JQLResults[jql] = append(mock.JQLResults[jql], key)
+	}
+
+	var pages []SearchPage
+	err := StreamIssueKeys(context.Background(), mock, jql, 2, func(page SearchPage) error {
+		pages = append(pages, page)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamIssueKeys() error = %v", err)
+	}
+
+	if len(pages) != 3 {
+		t.Fatalf("Expected 3 pages, got %d", len(pages))
+	}
+	if pages[2].StartAt != 4 || len(pages[2].Keys) != 1 || pages[2].Keys[0] != "PROJ-5" {
+		t.Errorf("Unexpected last page: %+v", pages[2])
+	}
+	for _, page := range pages {
+		if page.Total != 5 {
+			t.Errorf("Expected total 5 on every page, got %d", page.Total)
+		}
+	}
+}
+
+func TestStreamIssueKeys_Errors(t *testing.T) {
+	mock := NewMockClient()
+	mock.JQLResults["project = PROJ"] = []string{"PROJ-1"}
+	mock.Issues["PROJ-1"] = &Issue{Key: "PROJ-1"}
+
+	if err := StreamIssueKeys(context.Background(), mock, "", 10, func(SearchPage) error { return nil }); err == nil {
+		t.Error("Expected error for empty JQL")
+	}
+
+	stop := errors.New("stop")
+	if err := StreamIssueKeys(context.Background(), mock, "project = PROJ", 10, func(SearchPage) error { return stop }); err != stop {
+		t.Errorf("Expected handler error to stop streaming, got %v", err)
+	}
+
+	mock.JQLError = errors.New("search failed")
+	if err := StreamIssueKeys(context.Background(), mock, "project = PROJ", 10, func(SearchPage) error { return nil }); err == nil {
+		t.Error("Expected search error to propagate")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mock.JQLError = nil
+	if err := StreamIssueKeys(ctx, mock, "project = PROJ", 10, func(SearchPage) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context cancellation, got %v", err)
+	}
+}
+
+// syntheticSearchClient generates search pages on demand to simulate very large result sets
+type syntheticSearchClient struct {
+	MockClient
+	total int
+}
+
+func (s *syntheticSearchClient) SearchIssuesWithPagination(jql string, startAt, maxResults int) ([]*Issue, int, error) {
+	end := startAt + maxResults
+	if end > s.total {
+		end = s.total
+	}
+	issues := make([]*Issue, 0, end-startAt)
+	for i := startAt; i < end; i++ {
+		issues = append(issues, &Issue{
+			Key:         fmt.Sprintf("BIG-%d", i+1),
+			Summary:     "Synthetic issue used to simulate a large JQL result set",
+			Description: "Padding that makes full issue payloads noticeably larger than their keys alone",
+		})
+	}
+	return issues, s.total, nil
+}
+
+func (s *syntheticSearchClient) SearchIssues(jql string) ([]*Issue, error) {
+	var all []*Issue
+	for startAt := 0; startAt < s.total; startAt += DefaultSearchPageSize {
+		page, _, _ := s.SearchIssuesWithPagination(jql, startAt, DefaultSearchPageSize)
+		all = append(all, page...)
+	}
+	return all, nil
+}
+
+// BenchmarkStreamIssueKeys shows live heap stays flat while streaming 50k matches
+// Compare peak-live-KB with BenchmarkSearchIssuesAll, whose retained slice grows with the result size.
+func BenchmarkStreamIssueKeys(b *testing.B) {
+	c := &syntheticSearchClient{total: 50000}
+	b.ReportAllocs()
+
+	var peak uint64
+	for i := 0; i < b.N; i++ {
+		base := liveHeap()
+		processed := 0
+		err := StreamIssueKeys(context.Background(), c, "project = BIG", DefaultSearchPageSize, func(page SearchPage) error {
+			processed += len(page.Keys)
+			if processed%(DefaultSearchPageSize*100) == 0 {
+				if live := liveHeap(); live > base && live-base > peak {
+					peak = live - base
+				}
+			}
+			return nil
+		})
+		if err != nil || processed != c.total {
+			b.Fatalf("streamed %d issues, err = %v", processed, err)
+		}
+	}
+	b.ReportMetric(float64(peak)/1024, "peak-live-KB")
+}
+
+// BenchmarkSearchIssuesAll is the non-streaming baseline that materializes every issue
+func BenchmarkSearchIssuesAll(b *testing.B) {
+	c := &syntheticSearchClient{total: 50000}
+	b.ReportAllocs()
+
+	var peak uint64
+	for i := 0; i < b.N; i++ {
+		base := liveHeap()
+		issues, _ := c.SearchIssues("project = BIG")
+		if live := liveHeap(); live > base && live-base > peak {
+			peak = live - base
+		}
+		runtime.KeepAlive(issues)
+	}
+	b.ReportMetric(float64(peak)/1024, "peak-live-KB")
+}
+
+// liveHeap returns the heap in use after a collection
+func liveHeap() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+// insecureSkipVerifyWarning is printed whenever certificate verification is disabled
+const insecureSkipVerifyWarning = "⚠️  WARNING: JIRA_INSECURE_SKIP_VERIFY is set - TLS certificates are NOT verified. " +
+	"Connections can be intercepted; use JIRA_CA_CERT with your internal CA instead."
+
+// NewTLSConfig builds the TLS configuration for JIRA connections
+// A CA bundle is added to the system roots, so public certificates keep working.
+// Returns nil when neither option is set, meaning Go's defaults apply.
+func NewTLSConfig(caCertPath string, insecureSkipVerify bool) (*tls.Config, error) {
+	if caCertPath == "" && !insecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caCertPath != "" {
+		pem, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, &ClientError{
+				Type:    "configuration_error",
+				Message: fmt.Sprintf("failed to read CA certificate bundle %s", caCertPath),
+				Err:     err,
+			}
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, &ClientError{
+				Type:    "configuration_error",
+				Message: fmt.Sprintf("no PEM certificates found in CA bundle %s", caCertPath),
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if insecureSkipVerify {
+		fmt.Fprintln(os.Stderr, insecureSkipVerifyWarning)
+		tlsConfig.InsecureSkipVerify = true // #nosec G402 -- explicit opt-in via JIRA_INSECURE_SKIP_VERIFY
+	}
+
+	return tlsConfig, nil
+}
+
+// NewHTTPTransport returns the base HTTP transport for JIRA requests, applying any custom CA
+// bundle or verification override from the configuration
+func NewHTTPTransport(cfg *config.Config) (http.RoundTripper, error) {
+	tlsConfig, err := NewTLSConfig(cfg.JIRACACert, cfg.JIRAInsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return http.DefaultTransport, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
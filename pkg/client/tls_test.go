@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+// writeServerCA writes the test server's self-signed certificate as a PEM bundle
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caPath, data, 0600); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+	return caPath
+}
+
+func TestNewHTTPTransport_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Without the CA the self-signed certificate is rejected
+	defaultTransport, err := NewHTTPTransport(&config.Config{})
+	if err != nil {
+		t.Fatalf("NewHTTPTransport() error = %v", err)
+	}
+	if defaultTransport != http.DefaultTransport {
+		t.Error("Expected default transport when no TLS options are set")
+	}
+	if _, err := (&http.Client{Transport: defaultTransport}).Get(server.URL); err == nil {
+		t.Error("Expected TLS error without custom CA")
+	}
+
+	transport, err := NewHTTPTransport(&config.Config{JIRACACert: writeServerCA(t, server)})
+	if err != nil {
+		t.Fatalf("NewHTTPTransport() error = %v", err)
+	}
+	httpTransport, ok := transport.(*http.Transport)
+	if !ok || httpTransport.TLSClientConfig == nil || httpTransport.TLSClientConfig.RootCAs == nil {
+		t.Fatal("Expected custom CA pool on transport")
+	}
+	if httpTransport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("Custom CA must not disable verification")
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected request to succeed with custom CA, got %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestNewTLSConfig_InsecureSkipVerify(t *testing.T) {
+	tlsConfig, err := NewTLSConfig("", true)
+	if err != nil {
+		t.Fatalf("NewTLSConfig() error = %v", err)
+	}
+	if tlsConfig == nil || !tlsConfig.InsecureSkipVerify {
+		t.Error("Expected verification to be disabled")
+	}
+}
+
+func TestNewTLSConfig_InvalidBundle(t *testing.T) {
+	if _, err := NewTLSConfig(filepath.Join(t.TempDir(), "missing.pem"), false); err == nil {
+		t.Error("Expected error for missing CA bundle")
+	}
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := NewTLSConfig(notPEM, false); err == nil {
+		t.Error("Expected error for bundle without certificates")
+	}
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// restAPISuffixRegex matches a REST API root accidentally included in JIRA_BASE_URL
+// (e.g. https://host/jira/rest/api/2) so it can be stripped back to the context path
+var restAPISuffixRegex = regexp.MustCompile(`/rest(/api(/(\d+|latest))?)?/?$`)
+
+// NormalizeBaseURL prepares a JIRA base URL for endpoint resolution
+// On-prem JIRA is often served under a context path (https://host/jira/), so the
+// path component must be preserved and terminated with a slash; otherwise relative
+// REST paths resolve against the host root and every request returns 404.
+func NormalizeBaseURL(rawURL string) (string, error) {
+	trimmed := strings.TrimSpace(rawURL)
+	if trimmed == "" {
+		return "", &ClientError{
+			Type:    "invalid_input",
+			Message: "JIRA base URL cannot be empty",
+		}
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil {
+		return "", &ClientError{
+			Type:    "invalid_input",
+			Message: "failed to parse JIRA base URL",
+			Err:     err,
+			Context: trimmed,
+		}
+	}
+
+	// Query strings and fragments have no meaning for the REST base
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	parsed.RawPath = ""
+
+	contextPath := parsed.Path
+	if contextPath != "" {
+		contextPath = path.Clean("/" + contextPath)
+		contextPath = restAPISuffixRegex.ReplaceAllString(contextPath, "")
+	}
+	if !strings.HasSuffix(contextPath, "/") {
+		contextPath += "/"
+	}
+	parsed.Path = contextPath
+
+	return parsed.String(), nil
+}
+
+// BuildEndpointURL joins a REST endpoint onto the JIRA base URL, keeping any context path
+// Example: ("https://host/jira", "/rest/api/2/issue/PROJ-1") -> "https://host/jira/rest/api/2/issue/PROJ-1"
+func BuildEndpointURL(baseURL, endpoint string) (string, error) {
+	normalized, err := NormalizeBaseURL(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	base, err := url.Parse(normalized)
+	if err != nil {
+		return "", &ClientError{
+			Type:    "invalid_input",
+			Message: "failed to parse JIRA base URL",
+			Err:     err,
+			Context: normalized,
+		}
+	}
+
+	rel, err := url.Parse(endpoint)
+	if err != nil {
+		return "", &ClientError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("invalid endpoint path: %s", endpoint),
+			Err:     err,
+		}
+	}
+
+	// A leading slash would make the endpoint absolute and drop the context path
+	rel.Path = strings.TrimLeft(rel.Path, "/")
+
+	return base.ResolveReference(rel).String(), nil
+}
+
+// BuildIssueURL returns the human-facing browse URL for an issue
+// Example: ("https://host/jira", "PROJ-1") -> "https://host/jira/browse/PROJ-1"
+func BuildIssueURL(baseURL, issueKey string) (string, error) {
+	if strings.TrimSpace(issueKey) == "" {
+		return "", &ClientError{
+			Type:    "invalid_input",
+			Message: "issue key cannot be empty",
+		}
+	}
+
+	return BuildEndpointURL(baseURL, "browse/"+url.PathEscape(issueKey))
+}
+
+// CheckReachable confirms the JIRA server answers at the base URL without authenticating
+// The unauthenticated serverInfo endpoint is requested; any HTTP response below 500 counts
+// as reachable, since some deployments require authentication even for server info.
+func CheckReachable(ctx context.Context, httpClient *http.Client, baseURL string) (int, error) {
+	endpoint, err := BuildEndpointURL(baseURL, "rest/api/2/serverInfo")
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, &ClientError{
+			Type:    "invalid_input",
+			Message: "failed to build reachability request",
+			Err:     err,
+			Context: endpoint,
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, &ClientError{
+			Type:    "network_error",
+			Message: "JIRA server is not reachable",
+			Err:     err,
+			Context: endpoint,
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return resp.StatusCode, &ClientError{
+			Type:    "server_error",
+			Message: fmt.Sprintf("JIRA server responded with HTTP %d", resp.StatusCode),
+			Context: endpoint,
+		}
+	}
+
+	return resp.StatusCode, nil
+}
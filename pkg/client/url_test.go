@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"root without slash", "https://jira.example.com", "https://jira.example.com/"},
+		{"root with slash", "https://jira.example.com/", "https://jira.example.com/"},
+		{"context path without slash", "https://host.example.com/jira", "https://host.example.com/jira/"},
+		{"context path with slash", "https://host.example.com/jira/", "https://host.example.com/jira/"},
+		{"nested context path", "https://host.example.com/tools/jira", "https://host.example.com/tools/jira/"},
+		{"duplicate slashes", "https://host.example.com//jira//", "https://host.example.com/jira/"},
+		{"surrounding whitespace", "  https://host.example.com/jira  ", "https://host.example.com/jira/"},
+		{"rest api root pasted", "https://host.example.com/jira/rest/api/2", "https://host.example.com/jira/"},
+		{"rest api latest pasted", "https://jira.example.com/rest/api/latest/", "https://jira.example.com/"},
+		{"query and fragment dropped", "https://host.example.com/jira?os_authType=basic#top", "https://host.example.com/jira/"},
+		{"port preserved", "http://localhost:8080/jira", "http://localhost:8080/jira/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeBaseURL(tt.input)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeBaseURL_Empty(t *testing.T) {
+	_, err := NormalizeBaseURL("   ")
+	if err == nil {
+		t.Fatal("Expected error for empty base URL, got nil")
+	}
+
+	if clientErr, ok := err.(*ClientError); !ok {
+		t.Errorf("Expected *ClientError, got %T", err)
+	} else if clientErr.Type != "invalid_input" {
+		t.Errorf("Expected invalid_input error, got %s", clientErr.Type)
+	}
+}
+
+func TestBuildEndpointURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		endpoint string
+		expected string
+	}{
+		{
+			name:     "root base with leading slash endpoint",
+			baseURL:  "https://jira.example.com",
+			endpoint: "/rest/api/2/issue/PROJ-1",
+			expected: "https://jira.example.com/rest/api/2/issue/PROJ-1",
+		},
+		{
+			name:     "root base with relative endpoint",
+			baseURL:  "https://jira.example.com/",
+			endpoint: "rest/api/2/search",
+			expected: "https://jira.example.com/rest/api/2/search",
+		},
+		{
+			name:     "context path with leading slash endpoint",
+			baseURL:  "https://host.example.com/jira",
+			endpoint: "/rest/api/2/issue/PROJ-1",
+			expected: "https://host.example.com/jira/rest/api/2/issue/PROJ-1",
+		},
+		{
+			name:     "context path with trailing slash",
+			baseURL:  "https://host.example.com/jira/",
+			endpoint: "rest/api/2/myself",
+			expected: "https://host.example.com/jira/rest/api/2/myself",
+		},
+		{
+			name:     "endpoint query preserved",
+			baseURL:  "https://host.example.com/jira",
+			endpoint: "/rest/api/2/search?jql=project%3DPROJ&startAt=0",
+			expected: "https://host.example.com/jira/rest/api/2/search?jql=project%3DPROJ&startAt=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildEndpointURL(tt.baseURL, tt.endpoint)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildIssueURL(t *testing.T) {
+	got, err := BuildIssueURL("https://host.example.com/jira/rest/api/2", "PROJ-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got != "https://host.example.com/jira/browse/PROJ-1" {
+		t.Errorf("Expected context path browse URL, got '%s'", got)
+	}
+
+	if _, err := BuildIssueURL("https://jira.example.com", " "); err == nil {
+		t.Error("Expected error for empty issue key")
+	}
+	if _, err := BuildIssueURL("", "PROJ-1"); err == nil {
+		t.Error("Expected error for empty base URL")
+	}
+}
+
+func TestCheckReachable(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	// Authentication failures still prove the server is reachable
+	status, err := CheckReachable(context.Background(), server.Client(), server.URL+"/jira")
+	if err != nil || status != http.StatusUnauthorized {
+		t.Errorf("Expected reachable with HTTP 401, got %d, %v", status, err)
+	}
+	if requestedPath != "/jira/rest/api/2/serverInfo" {
+		t.Errorf("Expected serverInfo under context path, got %s", requestedPath)
+	}
+
+	server.Close()
+	if _, err := CheckReachable(context.Background(), http.DefaultClient, server.URL); err == nil {
+		t.Error("Expected error for unreachable server")
+	}
+}
+
+func TestJIRAClient_ContextPathRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		contextPath  string
+		expectedPath string
+	}{
+		{"root deployment", "", "/rest/api/2/issue/PROJ-1"},
+		{"context path deployment", "/jira", "/jira/rest/api/2/issue/PROJ-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestedPath = r.URL.Path
+				if r.URL.Path != tt.expectedPath {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"key": "PROJ-1",
+					"fields": map[string]interface{}{
+						"summary":   "Context path issue",
+						"issuetype": map[string]interface{}{"name": "Story"},
+					},
+				})
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{
+				JIRABaseURL: server.URL + tt.contextPath,
+				JIRAEmail:   "test@example.com",
+				JIRAPAT:     "test-pat-token-123",
+
+				RateLimitDelay:         time.Millisecond,
+				MaxConcurrentRequests:  1,
+				ExponentialBackoffBase: time.Millisecond,
+				MaxBackoffDelay:        10 * time.Millisecond,
+			}
+
+			jiraClient, err := NewClient(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			issue, err := jiraClient.GetIssue("PROJ-1")
+			if err != nil {
+				t.Fatalf("Expected no error fetching issue, got: %v (requested path %s)", err, requestedPath)
+			}
+
+			if requestedPath != tt.expectedPath {
+				t.Errorf("Expected request path '%s', got '%s'", tt.expectedPath, requestedPath)
+			}
+			if issue.Summary != "Context path issue" {
+				t.Errorf("Expected summary 'Context path issue', got '%s'", issue.Summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+)
+
+// Version is the tool version reported in the default User-Agent
+// The CLI and API server set it from their build information at startup.
+var Version = "dev"
+
+// RequestIDHeader carries a unique ID per JIRA request so it can be found in JIRA's access logs
+const RequestIDHeader = "X-Request-ID"
+
+// DefaultUserAgent identifies the tool and its version to JIRA
+func DefaultUserAgent() string {
+	return fmt.Sprintf("jira-cdc-git/%s (+https://github.com/chambrid/jira-cdc-git)", Version)
+}
+
+// identifyingTransport sets the User-Agent and, optionally, a request ID header on every request
+// It wraps the other transports, so retries of a request keep its ID and cached responses
+// never reach JIRA.
+type identifyingTransport struct {
+	base       http.RoundTripper
+	userAgent  string
+	requestIDs bool
+}
+
+// NewIdentifyingTransport wraps base so requests identify the tool; an empty userAgent uses
+// DefaultUserAgent
+func NewIdentifyingTransport(base http.RoundTripper, userAgent string, requestIDs bool) http.RoundTripper {
+	if userAgent == "" {
+		userAgent = DefaultUserAgent()
+	}
+	return &identifyingTransport{base: base, userAgent: userAgent, requestIDs: requestIDs}
+}
+
+func (t *identifyingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	if t.requestIDs && req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, newRequestID())
+	}
+	return t.base.RoundTrip(req)
+}
+
+// newRequestID returns a random 16-character hex ID
+func newRequestID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+func TestNewClient_IdentifiesRequests(t *testing.T) {
+	var userAgents, requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		requestIDs = append(requestIDs, r.Header.Get(RequestIDHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"startAt":0,"maxResults":50,"total":0,"issues":[]}`))
+	}))
+	defer server.Close()
+
+	jiraClient, err := NewClient(&config.Config{
+		JIRABaseURL:           server.URL,
+		JIRAPAT:               "test-pat-token-123",
+		MaxConcurrentRequests: 1,
+		UserAgent:             "acme-mirror/2.1 (ops@acme.example)",
+		RequestIDs:            true,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := jiraClient.SearchIssues("project = PROJ"); err != nil {
+			t.Fatalf("SearchIssues() error = %v", err)
+		}
+	}
+
+	if len(userAgents) < 2 {
+		t.Fatalf("Expected at least 2 requests, got %d", len(userAgents))
+	}
+	seen := make(map[string]bool)
+	for i, userAgent := range userAgents {
+		if userAgent != "acme-mirror/2.1 (ops@acme.example)" {
+			t.Errorf("Request %d User-Agent = %q, want the configured one", i, userAgent)
+		}
+		if len(requestIDs[i]) != 16 || seen[requestIDs[i]] {
+			t.Errorf("Request %d %s = %q, want a unique 16-character ID", i, RequestIDHeader, requestIDs[i])
+		}
+		seen[requestIDs[i]] = true
+	}
+}
+
+func TestIdentifyingTransport_Defaults(t *testing.T) {
+	var userAgent, requestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent, requestID = r.Header.Get("User-Agent"), r.Header.Get(RequestIDHeader)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: NewIdentifyingTransport(http.DefaultTransport, "", false)}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if !strings.HasPrefix(userAgent, "jira-cdc-git/"+Version+" ") {
+		t.Errorf("User-Agent = %q, want the default naming the tool and version", userAgent)
+	}
+	if requestID != "" {
+		t.Errorf("Expected no request ID when disabled, got %q", requestID)
+	}
+	if req.Header.Get("User-Agent") != "" {
+		t.Error("Expected the caller's request to be left unmodified")
+	}
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// UnknownUserName is written for account IDs that JIRA no longer knows about
+const UnknownUserName = "Unknown user"
+
+// UserResolver maps JIRA Cloud account IDs to readable user details
+// JIRA Cloud user fields may only carry opaque account IDs, which makes the YAML unreadable
+type UserResolver interface {
+	ResolveUser(accountID string) (User, error)
+}
+
+// UserLookupFunc fetches a single user by account ID (matches go-jira UserService.GetByAccountID)
+type UserLookupFunc func(accountID string) (*jira.User, *jira.Response, error)
+
+// CachingUserResolver resolves account IDs through the JIRA user API and caches the results
+// The cache lives as long as the resolver, so every issue processed by one client during a
+// run shares it and each distinct user costs at most one lookup.
+type CachingUserResolver struct {
+	lookup UserLookupFunc
+
+	mu       sync.Mutex
+	cache    map[string]User
+	inflight map[string]*userLookupCall
+}
+
+// userLookupCall lets concurrent workers wait on a lookup already in progress
+type userLookupCall struct {
+	done chan struct{}
+	user User
+	err  error
+}
+
+// NewCachingUserResolver creates a user resolver backed by the given lookup function
+func NewCachingUserResolver(lookup UserLookupFunc) *CachingUserResolver {
+	return &CachingUserResolver{
+		lookup:   lookup,
+		cache:    make(map[string]User),
+		inflight: make(map[string]*userLookupCall),
+	}
+}
+
+// ResolveUser returns the display name and email for an account ID
+// Deactivated users resolve normally but are flagged; users JIRA reports as missing are
+// cached as UnknownUserName so they are not looked up again. Transient failures are
+// returned as errors and not cached.
+func (r *CachingUserResolver) ResolveUser(accountID string) (User, error) {
+	if accountID == "" {
+		return User{}, &ClientError{
+			Type:    "invalid_input",
+			Message: "account ID cannot be empty",
+		}
+	}
+
+	r.mu.Lock()
+	if user, exists := r.cache[accountID]; exists {
+		r.mu.Unlock()
+		return user, nil
+	}
+	if call, exists := r.inflight[accountID]; exists {
+		r.mu.Unlock()
+		<-call.done
+		return call.user, call.err
+	}
+	call := &userLookupCall{done: make(chan struct{})}
+	r.inflight[accountID] = call
+	r.mu.Unlock()
+
+	call.user, call.err = r.fetchUser(accountID)
+
+	r.mu.Lock()
+	if call.err == nil {
+		r.cache[accountID] = call.user
+	}
+	delete(r.inflight, accountID)
+	r.mu.Unlock()
+	close(call.done)
+
+	return call.user, call.err
+}
+
+// CacheSize returns the number of account IDs resolved so far
+func (r *CachingUserResolver) CacheSize() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.cache)
+}
+
+// fetchUser performs the actual user lookup against JIRA
+func (r *CachingUserResolver) fetchUser(accountID string) (User, error) {
+	jiraUser, response, err := r.lookup(accountID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return User{Name: UnknownUserName, AccountID: accountID}, nil
+		}
+		return User{}, &ClientError{
+			Type:    "user_resolution_error",
+			Message: "failed to resolve user account ID",
+			Err:     err,
+			Context: accountID,
+		}
+	}
+
+	if jiraUser == nil {
+		return User{Name: UnknownUserName, AccountID: accountID}, nil
+	}
+
+	user := User{
+		Name:        jiraUser.DisplayName,
+		Email:       jiraUser.EmailAddress,
+		AccountID:   accountID,
+		Deactivated: !jiraUser.Active,
+	}
+	if user.Name == "" {
+		user.Name = UnknownUserName
+	}
+
+	return user, nil
+}
+
+// convertUser converts a go-jira user, resolving opaque account IDs when resolution is enabled
+func (c *JIRAClient) convertUser(jiraUser *jira.User) User {
+	user := User{
+		Name:  jiraUser.DisplayName,
+		Email: jiraUser.EmailAddress,
+	}
+
+	if c.userResolver == nil || jiraUser.AccountID == "" {
+		return user
+	}
+
+	user.AccountID = jiraUser.AccountID
+	if user.Name != "" && user.Name != user.AccountID && user.Email != "" {
+		return user
+	}
+
+	// Resolution failures fall back to whatever the issue payload carried
+	resolved, err := c.userResolver.ResolveUser(jiraUser.AccountID)
+	if err != nil {
+		return user
+	}
+
+	if resolved.Name != "" && (user.Name == "" || user.Name == user.AccountID) {
+		user.Name = resolved.Name
+	}
+	if resolved.Email != "" {
+		user.Email = resolved.Email
+	}
+	user.Deactivated = resolved.Deactivated
+
+	return user
+}
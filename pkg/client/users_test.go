@@ -0,0 +1,188 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// fakeUserLookup serves users from a map and counts lookups
+type fakeUserLookup struct {
+	users    map[string]*jira.User
+	failWith error
+	calls    int32
+}
+
+func (f *fakeUserLookup) lookup(accountID string) (*jira.User, *jira.Response, error) {
+	atomic.AddInt32(&f.calls, 1)
+	if f.failWith != nil {
+		return nil, &jira.Response{Response: &http.Response{StatusCode: 503}}, f.failWith
+	}
+	user, exists := f.users[accountID]
+	if !exists {
+		return nil, &jira.Response{Response: &http.Response{StatusCode: 404}}, errors.New("user not found")
+	}
+	return user, &jira.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+func TestCachingUserResolver_ResolveAndCache(t *testing.T) {
+	fake := &fakeUserLookup{users: map[string]*jira.User{
+		"5b10ac8d82e05b22cc7d4ef5": {AccountID: "5b10ac8d82e05b22cc7d4ef5", DisplayName: "Jane Doe", EmailAddress: "jane@example.com", Active: true},
+	}}
+	resolver := NewCachingUserResolver(fake.lookup)
+
+	for i := 0; i < 3; i++ {
+		user, err := resolver.ResolveUser("5b10ac8d82e05b22cc7d4ef5")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if user.Name != "Jane Doe" || user.Email != "jane@example.com" {
+			t.Errorf("Expected Jane Doe <jane@example.com>, got %s <%s>", user.Name, user.Email)
+		}
+		if user.Deactivated {
+			t.Error("Expected active user")
+		}
+	}
+
+	if fake.calls != 1 {
+		t.Errorf("Expected 1 lookup with caching, got %d", fake.calls)
+	}
+	if resolver.CacheSize() != 1 {
+		t.Errorf("Expected cache size 1, got %d", resolver.CacheSize())
+	}
+}
+
+func TestCachingUserResolver_DeactivatedAndUnknownUsers(t *testing.T) {
+	fake := &fakeUserLookup{users: map[string]*jira.User{
+		"former-employee": {AccountID: "former-employee", DisplayName: "Former Employee", Active: false},
+	}}
+	resolver := NewCachingUserResolver(fake.lookup)
+
+	user, err := resolver.ResolveUser("former-employee")
+	if err != nil {
+		t.Fatalf("Expected no error for deactivated user, got: %v", err)
+	}
+	if !user.Deactivated || user.Name != "Former Employee" {
+		t.Errorf("Expected deactivated Former Employee, got %+v", user)
+	}
+
+	user, err = resolver.ResolveUser("deleted-account")
+	if err != nil {
+		t.Fatalf("Expected no error for unknown user, got: %v", err)
+	}
+	if user.Name != UnknownUserName || user.AccountID != "deleted-account" {
+		t.Errorf("Expected unknown user placeholder, got %+v", user)
+	}
+
+	// Unknown users are cached too
+	_, _ = resolver.ResolveUser("deleted-account")
+	if fake.calls != 2 {
+		t.Errorf("Expected 2 lookups, got %d", fake.calls)
+	}
+}
+
+func TestCachingUserResolver_TransientErrorsNotCached(t *testing.T) {
+	fake := &fakeUserLookup{users: map[string]*jira.User{}, failWith: errors.New("service unavailable")}
+	resolver := NewCachingUserResolver(fake.lookup)
+
+	_, err := resolver.ResolveUser("some-account")
+	if err == nil {
+		t.Fatal("Expected error for transient failure")
+	}
+	clientErr, ok := err.(*ClientError)
+	if !ok || clientErr.Type != "user_resolution_error" {
+		t.Errorf("Expected user_resolution_error, got %v", err)
+	}
+
+	_, _ = resolver.ResolveUser("some-account")
+	if fake.calls != 2 {
+		t.Errorf("Expected transient failure to be retried, got %d lookups", fake.calls)
+	}
+	if resolver.CacheSize() != 0 {
+		t.Errorf("Expected empty cache, got %d", resolver.CacheSize())
+	}
+}
+
+func TestCachingUserResolver_EmptyAccountID(t *testing.T) {
+	resolver := NewCachingUserResolver((&fakeUserLookup{}).lookup)
+
+	if _, err := resolver.ResolveUser(""); err == nil {
+		t.Error("Expected error for empty account ID")
+	}
+}
+
+func TestCachingUserResolver_ConcurrentLookups(t *testing.T) {
+	fake := &fakeUserLookup{users: map[string]*jira.User{
+		"shared": {AccountID: "shared", DisplayName: "Shared User", Active: true},
+	}}
+	resolver := NewCachingUserResolver(fake.lookup)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			user, err := resolver.ResolveUser("shared")
+			if err != nil || user.Name != "Shared User" {
+				t.Errorf("Unexpected result: %+v, %v", user, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if fake.calls > 20 || resolver.CacheSize() != 1 {
+		t.Errorf("Expected a single cached entry, got %d entries after %d lookups", resolver.CacheSize(), fake.calls)
+	}
+}
+
+func TestJIRAClient_ConvertUser(t *testing.T) {
+	fake := &fakeUserLookup{users: map[string]*jira.User{
+		"acct-1": {AccountID: "acct-1", DisplayName: "Resolved Name", EmailAddress: "resolved@example.com", Active: true},
+	}}
+
+	tests := []struct {
+		name     string
+		resolver UserResolver
+		input    *jira.User
+		expected User
+	}{
+		{
+			name:     "resolution disabled keeps payload",
+			resolver: nil,
+			input:    &jira.User{AccountID: "acct-1"},
+			expected: User{},
+		},
+		{
+			name:     "opaque account ID resolved",
+			resolver: NewCachingUserResolver(fake.lookup),
+			input:    &jira.User{AccountID: "acct-1", DisplayName: "acct-1"},
+			expected: User{Name: "Resolved Name", Email: "resolved@example.com", AccountID: "acct-1"},
+		},
+		{
+			name:     "complete payload not looked up",
+			resolver: NewCachingUserResolver(fake.lookup),
+			input:    &jira.User{AccountID: "acct-2", DisplayName: "Payload Name", EmailAddress: "payload@example.com"},
+			expected: User{Name: "Payload Name", Email: "payload@example.com", AccountID: "acct-2"},
+		},
+		{
+			name:     "unknown user keeps payload name",
+			resolver: NewCachingUserResolver(fake.lookup),
+			input:    &jira.User{AccountID: "gone", DisplayName: "Cached Name"},
+			expected: User{Name: "Cached Name", AccountID: "gone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &JIRAClient{userResolver: tt.resolver}
+			got := c.convertUser(tt.input)
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// worklogPageSize is the number of worklogs requested per page when an issue has more
+// worklogs than JIRA embeds in the issue payload
+const worklogPageSize = 100
+
+// Worklog is one entry of work logged against an issue
+// TimeSpent is normalized from TimeSpentSeconds (see FormatTimeSpent), so it does not depend
+// on the working-day settings of the JIRA instance.
+type Worklog struct {
+	ID               string `json:"id" yaml:"id"`
+	Author           User   `json:"author" yaml:"author"`
+	Started          string `json:"started" yaml:"started"`
+	TimeSpent        string `json:"time_spent" yaml:"time_spent"`
+	TimeSpentSeconds int    `json:"time_spent_seconds" yaml:"time_spent_seconds"`
+	Comment          string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+// TimeTracking holds the estimates and logged time of an issue, in seconds and normalized text
+// The aggregate values include the issue's subtasks.
+type TimeTracking struct {
+	OriginalEstimate          string `json:"original_estimate,omitempty" yaml:"original_estimate,omitempty"`
+	OriginalEstimateSeconds   int    `json:"original_estimate_seconds,omitempty" yaml:"original_estimate_seconds,omitempty"`
+	RemainingEstimate         string `json:"remaining_estimate,omitempty" yaml:"remaining_estimate,omitempty"`
+	RemainingEstimateSeconds  int    `json:"remaining_estimate_seconds,omitempty" yaml:"remaining_estimate_seconds,omitempty"`
+	TimeSpent                 string `json:"time_spent,omitempty" yaml:"time_spent,omitempty"`
+	TimeSpentSeconds          int    `json:"time_spent_seconds,omitempty" yaml:"time_spent_seconds,omitempty"`
+	AggregateOriginalEstimate int    `json:"aggregate_original_estimate_seconds,omitempty" yaml:"aggregate_original_estimate_seconds,omitempty"`
+	AggregateRemaining        int    `json:"aggregate_remaining_estimate_seconds,omitempty" yaml:"aggregate_remaining_estimate_seconds,omitempty"`
+	AggregateTimeSpent        int    `json:"aggregate_time_spent_seconds,omitempty" yaml:"aggregate_time_spent_seconds,omitempty"`
+}
+
+// FormatTimeSpent renders a duration in seconds as hours and minutes ("1h 30m", "45m", "0m")
+// JIRA's own strings use days and weeks whose length depends on instance settings; hours
+// and minutes mean the same thing everywhere, so mirrored values compare across instances.
+func FormatTimeSpent(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// extractTimeTracking returns the time tracking of an issue, or nil if it has none
+// The timetracking field is preferred; searches that omit it fall back to the flat fields.
+func extractTimeTracking(fields *jira.IssueFields) *TimeTracking {
+	original, remaining, spent := fields.TimeOriginalEstimate, fields.TimeEstimate, fields.TimeSpent
+	if tracking := fields.TimeTracking; tracking != nil {
+		original = tracking.OriginalEstimateSeconds
+		remaining = tracking.RemainingEstimateSeconds
+		spent = tracking.TimeSpentSeconds
+	}
+
+	result := &TimeTracking{
+		OriginalEstimateSeconds:   original,
+		RemainingEstimateSeconds:  remaining,
+		TimeSpentSeconds:          spent,
+		AggregateOriginalEstimate: fields.AggregateTimeOriginalEstimate,
+		AggregateRemaining:        fields.AggregateTimeEstimate,
+		AggregateTimeSpent:        fields.AggregateTimeSpent,
+	}
+	if *result == (TimeTracking{}) {
+		return nil
+	}
+	if original > 0 {
+		result.OriginalEstimate = FormatTimeSpent(original)
+	}
+	if remaining > 0 {
+		result.RemainingEstimate = FormatTimeSpent(remaining)
+	}
+	if spent > 0 {
+		result.TimeSpent = FormatTimeSpent(spent)
+	}
+	return result
+}
+
+// attachWorklogs records the time tracking and every worklog of an issue
+// JIRA embeds only the first page of worklogs in issue payloads (and searches may omit them),
+// so the rest are fetched from the worklog endpoint.
+func (c *JIRAClient) attachWorklogs(issue *Issue, jiraIssue *jira.Issue) error {
+	if c.config == nil || !c.config.IncludeWorklogs || jiraIssue.Fields == nil {
+		return nil
+	}
+
+	issue.TimeTracking = extractTimeTracking(jiraIssue.Fields)
+
+	var records []jira.WorklogRecord
+	embedded := jiraIssue.Fields.Worklog
+	if embedded != nil && embedded.StartAt == 0 && len(embedded.Worklogs) >= embedded.Total {
+		records = embedded.Worklogs
+	} else {
+		fetched, err := c.fetchWorklogs(issue.Key)
+		if err != nil {
+			return err
+		}
+		records = fetched
+	}
+
+	issue.Worklogs = nil
+	for _, record := range records {
+		worklog := Worklog{
+			ID:               record.ID,
+			TimeSpent:        FormatTimeSpent(record.TimeSpentSeconds),
+			TimeSpentSeconds: record.TimeSpentSeconds,
+			Comment:          record.Comment,
+		}
+		if record.Author != nil {
+			worklog.Author = c.convertUser(record.Author)
+		}
+		if record.Started != nil {
+			worklog.Started = formatJIRATime(*record.Started)
+		}
+		issue.Worklogs = append(issue.Worklogs, worklog)
+	}
+	return nil
+}
+
+// fetchWorklogs pages through the worklog endpoint of an issue
+func (c *JIRAClient) fetchWorklogs(issueKey string) ([]jira.WorklogRecord, error) {
+	var records []jira.WorklogRecord
+	for {
+		options := &jira.GetWorklogsQueryOptions{StartAt: int64(len(records)), MaxResults: worklogPageSize}
+		page, response, err := c.client.Issue.GetWorklogs(issueKey, jira.WithQueryOptions(options))
+		if err != nil {
+			return nil, c.handleAPIError(err, response, issueKey+" worklogs")
+		}
+		records = append(records, page.Worklogs...)
+		if len(page.Worklogs) == 0 || len(records) >= page.Total {
+			return records, nil
+		}
+	}
+}
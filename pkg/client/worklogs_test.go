@@ -0,0 +1,110 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+)
+
+// worklogJSON renders one worklog record as JIRA returns it
+func worklogJSON(id string, seconds int) string {
+	return fmt.Sprintf(`{"id":%q,"author":{"displayName":"Jane Doe"},"started":"2024-03-15T09:30:00.000+0200","timeSpent":"1d","timeSpentSeconds":%d,"comment":"log %s"}`, id, seconds, id)
+}
+
+func TestGetIssue_IncludesAllWorklogPages(t *testing.T) {
+	var worklogRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/worklog"):
+			worklogRequests = append(worklogRequests, r.URL.Query().Get("startAt"))
+			// The server returns fewer worklogs per page than requested
+			if r.URL.Query().Get("startAt") == "" {
+				fmt.Fprintf(w, `{"startAt":0,"maxResults":2,"total":3,"worklogs":[%s,%s]}`, worklogJSON("1", 3600), worklogJSON("2", 5400))
+			} else {
+				fmt.Fprintf(w, `{"startAt":2,"maxResults":2,"total":3,"worklogs":[%s]}`, worklogJSON("3", 60))
+			}
+		default:
+			fmt.Fprintf(w, `{"key":"PROJ-1","fields":{"summary":"Log work","issuetype":{"name":"Task"},
+				"timetracking":{"originalEstimate":"1d","originalEstimateSeconds":28800,"remainingEstimateSeconds":14400,"timeSpentSeconds":9060},
+				"aggregatetimespent":12660,
+				"worklog":{"startAt":0,"maxResults":2,"total":3,"worklogs":[%s,%s]}}}`, worklogJSON("1", 3600), worklogJSON("2", 5400))
+		}
+	}))
+	defer server.Close()
+
+	jiraClient, err := NewClient(&config.Config{
+		JIRABaseURL:           server.URL,
+		JIRAPAT:               "test-pat-token-123",
+		MaxConcurrentRequests: 1,
+		IncludeWorklogs:       true,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	issue, err := jiraClient.GetIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+
+	if len(worklogRequests) != 2 || worklogRequests[1] != "2" {
+		t.Errorf("Expected the truncated embedded page to be refetched in two pages, got startAt %v", worklogRequests)
+	}
+	if len(issue.Worklogs) != 3 {
+		t.Fatalf("Expected 3 worklogs, got %+v", issue.Worklogs)
+	}
+	first := issue.Worklogs[0]
+	if first.Author.Name != "Jane Doe" || first.Started != "2024-03-15T09:30:00.000Z" || first.TimeSpent != "1h" || first.Comment != "log 1" {
+		t.Errorf("Unexpected worklog: %+v", first)
+	}
+	if issue.Worklogs[1].TimeSpent != "1h 30m" || issue.Worklogs[2].TimeSpent != "1m" {
+		t.Errorf("Expected time spent in hours and minutes, got %q and %q", issue.Worklogs[1].TimeSpent, issue.Worklogs[2].TimeSpent)
+	}
+
+	want := TimeTracking{
+		OriginalEstimate: "8h", OriginalEstimateSeconds: 28800,
+		RemainingEstimate: "4h", RemainingEstimateSeconds: 14400,
+		TimeSpent: "2h 31m", TimeSpentSeconds: 9060,
+		AggregateTimeSpent: 12660,
+	}
+	if issue.TimeTracking == nil || *issue.TimeTracking != want {
+		t.Errorf("TimeTracking = %+v, want %+v", issue.TimeTracking, want)
+	}
+}
+
+func TestGetIssue_WorklogsDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/worklog") {
+			t.Error("Expected no worklog requests when worklogs are disabled")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"key":"PROJ-1","fields":{"summary":"Log work","issuetype":{"name":"Task"},"timespent":60,
+			"worklog":{"startAt":0,"maxResults":1,"total":2,"worklogs":[%s]}}}`, worklogJSON("1", 60))
+	}))
+	defer server.Close()
+
+	jiraClient, err := NewClient(&config.Config{JIRABaseURL: server.URL, JIRAPAT: "test-pat-token-123", MaxConcurrentRequests: 1})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	issue, err := jiraClient.GetIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if issue.Worklogs != nil || issue.TimeTracking != nil {
+		t.Errorf("Expected no worklogs or time tracking, got %+v and %+v", issue.Worklogs, issue.TimeTracking)
+	}
+}
+
+func TestFormatTimeSpent(t *testing.T) {
+	tests := map[int]string{0: "0m", 59: "0m", 60: "1m", 3600: "1h", 5400: "1h 30m", 28800 * 5: "40h"}
+	for seconds, want := range tests {
+		if got := FormatTimeSpent(seconds); got != want {
+			t.Errorf("FormatTimeSpent(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
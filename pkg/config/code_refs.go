@@ -0,0 +1,65 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultCodeRefMaxCommits bounds the commits scanned for code references when max_commits is unset
+const DefaultCodeRefMaxCommits = 10000
+
+// CodeRefConfig is the code_refs section of .jira-sync.yaml
+//
+//	code_refs:
+//	  repo: ../my-service
+//	  ref: main
+//	  max_commits: 5000
+type CodeRefConfig struct {
+	// Repo is the local Git repository whose log is scanned for issue keys
+	Repo string `yaml:"repo"`
+	// Ref is the revision the log starts from (default: HEAD)
+	Ref string `yaml:"ref,omitempty"`
+	// MaxCommits bounds the commits scanned, newest first (0: DefaultCodeRefMaxCommits)
+	MaxCommits int `yaml:"max_commits,omitempty"`
+}
+
+// LoadCodeRefConfig reads the code_refs section from the given settings files
+// The last file that defines the section wins; missing files are skipped. Returns nil when
+// no file configures a repository to scan.
+func LoadCodeRefConfig(paths ...string) (*CodeRefConfig, error) {
+	var result *CodeRefConfig
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		var settings struct {
+			CodeRefs *CodeRefConfig `yaml:"code_refs"`
+		}
+		if err := yaml.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("invalid code_refs configuration in %s: %w", path, err)
+		}
+		if settings.CodeRefs != nil {
+			result = settings.CodeRefs
+		}
+	}
+
+	if result == nil || strings.TrimSpace(result.Repo) == "" {
+		return nil, nil
+	}
+	if result.MaxCommits < 0 {
+		return nil, fmt.Errorf("invalid code_refs configuration: max_commits must not be negative")
+	}
+	if result.MaxCommits == 0 {
+		result.MaxCommits = DefaultCodeRefMaxCommits
+	}
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCodeRefConfig(t *testing.T) {
+	dir := t.TempDir()
+	home := filepath.Join(dir, "home.yaml")
+	project := filepath.Join(dir, "project.yaml")
+
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(home, "code_refs:\n  repo: ../other\n  max_commits: 50\n")
+	writeFile(project, "code_refs:\n  repo: ../service\n  ref: main\n")
+
+	// The last file defining the section wins and max_commits defaults
+	cfg, err := LoadCodeRefConfig(home, project, filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadCodeRefConfig() error = %v", err)
+	}
+	if cfg == nil || cfg.Repo != "../service" || cfg.Ref != "main" || cfg.MaxCommits != DefaultCodeRefMaxCommits {
+		t.Errorf("Expected project configuration, got %+v", cfg)
+	}
+
+	writeFile(project, "jql_aliases:\n  mine: assignee = currentUser()\n")
+	if cfg, err := LoadCodeRefConfig(project); err != nil || cfg != nil {
+		t.Errorf("Expected nil configuration without code_refs, got %+v (err %v)", cfg, err)
+	}
+
+	writeFile(project, "code_refs:\n  ref: main\n")
+	if cfg, _ := LoadCodeRefConfig(project); cfg != nil {
+		t.Errorf("Expected nil configuration without a repository, got %+v", cfg)
+	}
+
+	writeFile(project, "code_refs:\n  repo: ../service\n  max_commits: -1\n")
+	if _, err := LoadCodeRefConfig(project); err == nil {
+		t.Error("Expected error for negative max_commits")
+	}
+
+	writeFile(project, "code_refs: [")
+	if _, err := LoadCodeRefConfig(project); err == nil {
+		t.Error("Expected error for invalid YAML")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // Config represents the application configuration
@@ -16,17 +17,72 @@ type Config struct {
 	JIRAEmail   string `env:"JIRA_EMAIL" validate:"required,email"`
 	JIRAPAT     string `env:"JIRA_PAT" validate:"required,min=10"`
 
+	// Public JIRA URL for human-facing issue links, when users reach JIRA through a different
+	// host than the API (empty uses JIRABaseURL)
+	JIRAPublicURL string `env:"JIRA_PUBLIC_URL" validate:"omitempty,url"`
+
 	// Rate limiting configuration (JCG-010)
 	RateLimitDelay         time.Duration `env:"RATE_LIMIT_DELAY" default:"100ms"`
 	MaxConcurrentRequests  int           `env:"MAX_CONCURRENT_REQUESTS" default:"5"`
 	ExponentialBackoffBase time.Duration `env:"EXPONENTIAL_BACKOFF_BASE" default:"1s"`
 	MaxBackoffDelay        time.Duration `env:"MAX_BACKOFF_DELAY" default:"30s"`
 
+	// Retry classification: which failures are transient, and how often a request (client level)
+	// and an issue (batch level) are retried; lists are comma-separated, "none" empties them
+	MaxRetries         int    `env:"JIRA_MAX_RETRIES" default:"3"`
+	IssueRetries       int    `env:"JIRA_ISSUE_RETRIES" default:"1"`
+	RetryStatusCodes   string `env:"JIRA_RETRY_STATUS_CODES" default:"429,502,503,504"`
+	RetryErrorPatterns string `env:"JIRA_RETRY_ERROR_PATTERNS"`
+
+	// User resolution configuration (adds one API call per distinct user, cached per run)
+	ResolveUsers bool `env:"JIRA_RESOLVE_USERS" default:"false"`
+
+	// Changelog access; false never requests issue changelogs, so features that read them refuse to run
+	IncludeChangelog bool `env:"JIRA_INCLUDE_CHANGELOG" default:"true"`
+
+	// Custom field values in issue files, rendered by field type (adds one metadata request per run)
+	IncludeCustomFields bool `env:"JIRA_INCLUDE_CUSTOM_FIELDS" default:"false"`
+
+	// Worklogs and time tracking in issue files (issues with many worklogs need extra requests)
+	IncludeWorklogs bool `env:"JIRA_INCLUDE_WORKLOGS" default:"false"`
+
+	// External reference extraction, loaded from the external_refs section of .jira-sync.yaml
+	// rather than the environment (nil disables extraction)
+	ExternalRefs *ExternalRefConfig
+
+	// TLS configuration for JIRA instances behind an internal CA
+	JIRACACert             string `env:"JIRA_CA_CERT"`
+	JIRAInsecureSkipVerify bool   `env:"JIRA_INSECURE_SKIP_VERIFY" default:"false"`
+
+	// Local cache of JIRA responses: off, read (offline, cache only), write, or readwrite;
+	// a TTL of zero never expires entries, and RefreshCache skips cache reads for one run
+	CacheDir     string        `env:"JIRA_CACHE_DIR"`
+	CacheMode    string        `env:"JIRA_CACHE_MODE" validate:"oneof=off read write readwrite" default:"off"`
+	CacheTTL     time.Duration `env:"JIRA_CACHE_TTL" default:"0"`
+	RefreshCache bool
+
+	// Request identification: a User-Agent sent on every JIRA request (empty uses the tool name and
+	// version) and a unique X-Request-ID header per request, so JIRA admins can trace our traffic
+	UserAgent  string `env:"JIRA_USER_AGENT"`
+	RequestIDs bool   `env:"JIRA_REQUEST_IDS" default:"true"`
+
+	// PEM Ed25519 private key that sync --sign uses to sign checksum manifests
+	SigningKey string `env:"JIRA_SYNC_SIGNING_KEY"`
+
 	// Application configuration
 	LogLevel  string `env:"LOG_LEVEL" validate:"oneof=debug info warn error" default:"info"`
 	LogFormat string `env:"LOG_FORMAT" validate:"oneof=text json" default:"text"`
 }
 
+// IssueLinkBaseURL returns the JIRA URL for links people follow, such as issue browse URLs
+// API requests always use JIRABaseURL; links use JIRAPublicURL when it is set.
+func (c *Config) IssueLinkBaseURL() string {
+	if c.JIRAPublicURL != "" {
+		return c.JIRAPublicURL
+	}
+	return c.JIRABaseURL
+}
+
 // Provider defines the interface for configuration management
 // This enables dependency injection and easy testing
 type Provider interface {
@@ -79,12 +135,25 @@ func (l *Loader) Load() (*Config, error) {
 
 // LoadFromEnv loads configuration from environment variables
 func (l *Loader) LoadFromEnv() (*Config, error) {
+	config := l.loadUnvalidated()
+
+	// Validate configuration
+	if err := l.Validate(config); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return config, nil
+}
+
+// loadUnvalidated reads every setting from the environment, applying defaults
+func (l *Loader) loadUnvalidated() *Config {
 	config := &Config{}
 
 	// Load JIRA configuration
 	config.JIRABaseURL = l.envLoader.Getenv("JIRA_BASE_URL")
 	config.JIRAEmail = l.envLoader.Getenv("JIRA_EMAIL")
 	config.JIRAPAT = l.envLoader.Getenv("JIRA_PAT")
+	config.JIRAPublicURL = l.envLoader.Getenv("JIRA_PUBLIC_URL")
 
 	// Load rate limiting configuration with defaults (JCG-010)
 	config.RateLimitDelay = l.getDurationWithDefault("RATE_LIMIT_DELAY", 100*time.Millisecond)
@@ -92,16 +161,43 @@ func (l *Loader) LoadFromEnv() (*Config, error) {
 	config.ExponentialBackoffBase = l.getDurationWithDefault("EXPONENTIAL_BACKOFF_BASE", 1*time.Second)
 	config.MaxBackoffDelay = l.getDurationWithDefault("MAX_BACKOFF_DELAY", 30*time.Second)
 
+	// Load retry classification
+	config.MaxRetries = l.getIntWithDefault("JIRA_MAX_RETRIES", DefaultMaxRetries)
+	config.IssueRetries = l.getIntWithDefault("JIRA_ISSUE_RETRIES", DefaultIssueRetries)
+	config.RetryStatusCodes = l.getEnvWithDefault("JIRA_RETRY_STATUS_CODES", DefaultRetryStatusCodes)
+	config.RetryErrorPatterns = l.getEnvWithDefault("JIRA_RETRY_ERROR_PATTERNS", DefaultRetryErrorPatterns)
+
+	// Load user resolution configuration
+	config.ResolveUsers = l.getBoolWithDefault("JIRA_RESOLVE_USERS", false)
+
+	// Load changelog access
+	config.IncludeChangelog = l.getBoolWithDefault("JIRA_INCLUDE_CHANGELOG", true)
+
+	// Load custom field inclusion
+	config.IncludeCustomFields = l.getBoolWithDefault("JIRA_INCLUDE_CUSTOM_FIELDS", false)
+	config.IncludeWorklogs = l.getBoolWithDefault("JIRA_INCLUDE_WORKLOGS", false)
+
+	// Load TLS configuration
+	config.JIRACACert = l.envLoader.Getenv("JIRA_CA_CERT")
+	config.JIRAInsecureSkipVerify = l.getBoolWithDefault("JIRA_INSECURE_SKIP_VERIFY", false)
+
+	// Load response cache configuration
+	config.CacheDir = l.envLoader.Getenv("JIRA_CACHE_DIR")
+	config.CacheMode = l.getEnvWithDefault("JIRA_CACHE_MODE", "off")
+	config.CacheTTL = l.getDurationWithDefault("JIRA_CACHE_TTL", 0)
+
+	// Load request identification
+	config.UserAgent = l.envLoader.Getenv("JIRA_USER_AGENT")
+	config.RequestIDs = l.getBoolWithDefault("JIRA_REQUEST_IDS", true)
+
+	// Load manifest signing configuration
+	config.SigningKey = l.envLoader.Getenv("JIRA_SYNC_SIGNING_KEY")
+
 	// Load application configuration with defaults
 	config.LogLevel = l.getEnvWithDefault("LOG_LEVEL", "info")
 	config.LogFormat = l.getEnvWithDefault("LOG_FORMAT", "text")
 
-	// Validate configuration
-	if err := l.Validate(config); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
-	}
-
-	return config, nil
+	return config
 }
 
 // Validate validates the configuration
@@ -115,6 +211,12 @@ func (l *Loader) Validate(config *Config) error {
 		errors = append(errors, fmt.Sprintf("JIRA_BASE_URL is invalid: %v", err))
 	}
 
+	if config.JIRAPublicURL != "" {
+		if err := l.validateURL(config.JIRAPublicURL); err != nil {
+			errors = append(errors, fmt.Sprintf("JIRA_PUBLIC_URL is invalid: %v", err))
+		}
+	}
+
 	if config.JIRAEmail == "" {
 		errors = append(errors, "JIRA_EMAIL is required")
 	} else if err := l.validateEmail(config.JIRAEmail); err != nil {
@@ -143,6 +245,35 @@ func (l *Loader) Validate(config *Config) error {
 	if config.MaxBackoffDelay < config.ExponentialBackoffBase {
 		errors = append(errors, "MAX_BACKOFF_DELAY must be greater than or equal to EXPONENTIAL_BACKOFF_BASE")
 	}
+	errors = append(errors, validateRetryConfig(config)...)
+
+	// Validate TLS configuration
+	if config.JIRACACert != "" {
+		if info, err := os.Stat(config.JIRACACert); err != nil {
+			errors = append(errors, fmt.Sprintf("JIRA_CA_CERT is invalid: %v", err))
+		} else if info.IsDir() {
+			errors = append(errors, "JIRA_CA_CERT must be a file, not a directory")
+		}
+	}
+
+	// Validate response cache configuration
+	switch config.CacheMode {
+	case "", "off":
+	case "read", "write", "readwrite":
+		if config.CacheDir == "" {
+			errors = append(errors, fmt.Sprintf("JIRA_CACHE_DIR is required when JIRA_CACHE_MODE is %s", config.CacheMode))
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("JIRA_CACHE_MODE is invalid: unsupported cache mode %q (expected off, read, write, or readwrite)", config.CacheMode))
+	}
+	if config.CacheTTL < 0 {
+		errors = append(errors, "JIRA_CACHE_TTL must be non-negative")
+	}
+
+	// Header values cannot carry line breaks or other control characters
+	if strings.ContainsFunc(config.UserAgent, unicode.IsControl) {
+		errors = append(errors, "JIRA_USER_AGENT must not contain control characters")
+	}
 
 	// Validate application configuration
 	if err := l.validateLogLevel(config.LogLevel); err != nil {
@@ -253,3 +384,17 @@ func (l *Loader) getIntWithDefault(key string, defaultValue int) int {
 
 	return defaultValue
 }
+
+// getBoolWithDefault gets a boolean from environment with fallback to default
+func (l *Loader) getBoolWithDefault(key string, defaultValue bool) bool {
+	valueStr := l.envLoader.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+
+	return defaultValue
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 // MockEnvLoader implements EnvLoader for testing
@@ -83,6 +86,184 @@ func TestConfig_LoadFromEnv_WithDefaults(t *testing.T) {
 	}
 }
 
+func TestConfig_LoadFromEnv_ResolveUsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{"unset defaults to disabled", "", false},
+		{"enabled", "true", true},
+		{"enabled numeric", "1", true},
+		{"disabled", "false", false},
+		{"invalid falls back to default", "sometimes", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envVars := map[string]string{
+				"JIRA_BASE_URL":      "https://test.atlassian.net",
+				"JIRA_EMAIL":         "test@example.com",
+				"JIRA_PAT":           "test-pat-token-123",
+				"JIRA_RESOLVE_USERS": tt.value,
+			}
+
+			loader := NewLoaderWithEnv(NewMockEnvLoader(envVars))
+			config, err := loader.Load()
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if config.ResolveUsers != tt.expected {
+				t.Errorf("Expected ResolveUsers %t, got %t", tt.expected, config.ResolveUsers)
+			}
+		})
+	}
+}
+
+func TestConfig_LoadFromEnv_IncludeChangelog(t *testing.T) {
+	for value, expected := range map[string]bool{"": true, "true": true, "false": false, "0": false} {
+		envVars := map[string]string{
+			"JIRA_BASE_URL":          "https://test.atlassian.net",
+			"JIRA_EMAIL":             "test@example.com",
+			"JIRA_PAT":               "test-pat-token-123",
+			"JIRA_INCLUDE_CHANGELOG": value,
+		}
+
+		config, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load()
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if config.IncludeChangelog != expected {
+			t.Errorf("JIRA_INCLUDE_CHANGELOG=%q: expected IncludeChangelog %t, got %t", value, expected, config.IncludeChangelog)
+		}
+	}
+}
+
+func TestConfig_LoadFromEnv_TLS(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caPath, []byte("placeholder"), 0600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+
+	envVars := map[string]string{
+		"JIRA_BASE_URL":             "https://jira.internal",
+		"JIRA_EMAIL":                "test@example.com",
+		"JIRA_PAT":                  "test-pat-token-123",
+		"JIRA_CA_CERT":              caPath,
+		"JIRA_INSECURE_SKIP_VERIFY": "true",
+	}
+	config, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.JIRACACert != caPath || !config.JIRAInsecureSkipVerify {
+		t.Errorf("Unexpected TLS config: %q, %t", config.JIRACACert, config.JIRAInsecureSkipVerify)
+	}
+
+	envVars["JIRA_CA_CERT"] = filepath.Join(t.TempDir(), "missing.pem")
+	if _, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load(); err == nil || !strings.Contains(err.Error(), "JIRA_CA_CERT") {
+		t.Errorf("Expected JIRA_CA_CERT validation error, got %v", err)
+	}
+}
+
+func TestConfig_LoadFromEnv_RequestIdentification(t *testing.T) {
+	envVars := map[string]string{
+		"JIRA_BASE_URL": "https://jira.internal",
+		"JIRA_EMAIL":    "test@example.com",
+		"JIRA_PAT":      "test-pat-token-123",
+	}
+	config, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.UserAgent != "" || !config.RequestIDs {
+		t.Errorf("Expected the default User-Agent and request IDs on, got %q, %t", config.UserAgent, config.RequestIDs)
+	}
+
+	envVars["JIRA_USER_AGENT"] = "acme-mirror/2.1"
+	envVars["JIRA_REQUEST_IDS"] = "false"
+	config, err = NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.UserAgent != "acme-mirror/2.1" || config.RequestIDs {
+		t.Errorf("Unexpected request identification: %q, %t", config.UserAgent, config.RequestIDs)
+	}
+
+	envVars["JIRA_USER_AGENT"] = "acme\r\nX-Injected: 1"
+	if _, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load(); err == nil || !strings.Contains(err.Error(), "JIRA_USER_AGENT") {
+		t.Errorf("Expected JIRA_USER_AGENT validation error, got %v", err)
+	}
+}
+
+func TestConfig_LoadFromEnv_PublicURL(t *testing.T) {
+	envVars := map[string]string{
+		"JIRA_BASE_URL": "https://jira-api.internal",
+		"JIRA_EMAIL":    "test@example.com",
+		"JIRA_PAT":      "test-pat-token-123",
+	}
+	config, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := config.IssueLinkBaseURL(); got != "https://jira-api.internal" {
+		t.Errorf("Expected links to fall back to JIRA_BASE_URL, got %q", got)
+	}
+
+	envVars["JIRA_PUBLIC_URL"] = "https://jira.example.com/jira"
+	config, err = NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := config.IssueLinkBaseURL(); got != "https://jira.example.com/jira" {
+		t.Errorf("Expected links to use JIRA_PUBLIC_URL, got %q", got)
+	}
+	if config.JIRABaseURL != "https://jira-api.internal" {
+		t.Errorf("Expected API URL to be unchanged, got %q", config.JIRABaseURL)
+	}
+
+	envVars["JIRA_PUBLIC_URL"] = "jira.example.com"
+	if _, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load(); err == nil || !strings.Contains(err.Error(), "JIRA_PUBLIC_URL") {
+		t.Errorf("Expected JIRA_PUBLIC_URL validation error, got %v", err)
+	}
+}
+
+func TestConfig_LoadFromEnv_Cache(t *testing.T) {
+	envVars := map[string]string{
+		"JIRA_BASE_URL": "https://jira.internal",
+		"JIRA_EMAIL":    "test@example.com",
+		"JIRA_PAT":      "test-pat-token-123",
+	}
+	config, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.CacheMode != "off" || config.CacheDir != "" || config.CacheTTL != 0 {
+		t.Errorf("Expected the cache to be off by default, got %q %q %v", config.CacheMode, config.CacheDir, config.CacheTTL)
+	}
+
+	envVars["JIRA_CACHE_MODE"] = "readwrite"
+	if _, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load(); err == nil || !strings.Contains(err.Error(), "JIRA_CACHE_DIR") {
+		t.Errorf("Expected JIRA_CACHE_DIR validation error, got %v", err)
+	}
+
+	envVars["JIRA_CACHE_DIR"] = "/tmp/jira-cache"
+	envVars["JIRA_CACHE_TTL"] = "1h"
+	config, err = NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.CacheMode != "readwrite" || config.CacheDir != "/tmp/jira-cache" || config.CacheTTL != time.Hour {
+		t.Errorf("Unexpected cache config: %q %q %v", config.CacheMode, config.CacheDir, config.CacheTTL)
+	}
+
+	envVars["JIRA_CACHE_MODE"] = "sometimes"
+	if _, err := NewLoaderWithEnv(NewMockEnvLoader(envVars)).Load(); err == nil || !strings.Contains(err.Error(), "JIRA_CACHE_MODE") {
+		t.Errorf("Expected JIRA_CACHE_MODE validation error, got %v", err)
+	}
+}
+
 func TestConfig_Validation_MissingRequired(t *testing.T) {
 	tests := []struct {
 		name     string
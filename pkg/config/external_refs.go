@@ -0,0 +1,62 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExternalRefPattern names a URL pattern whose matches are recorded as external references
+// Pattern is a regular expression with `repo` and `number` named groups. It may be omitted
+// for the built-in "github" and "gitlab" patterns.
+type ExternalRefPattern struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern,omitempty"`
+}
+
+// ExternalRefConfig is the external_refs section of .jira-sync.yaml
+//
+//	external_refs:
+//	  remote_links: true
+//	  patterns:
+//	    - name: github
+//	    - name: internal-gitlab
+//	      pattern: 'https://gitlab\.example\.com/(?P<repo>[\w.-]+/[\w.-]+)/-/merge_requests/(?P<number>\d+)'
+type ExternalRefConfig struct {
+	// RemoteLinks also scans the issue's remote links (one extra API call per issue)
+	RemoteLinks bool                 `yaml:"remote_links"`
+	Patterns    []ExternalRefPattern `yaml:"patterns"`
+}
+
+// LoadExternalRefConfig reads the external_refs section from the given settings files
+// The last file that defines the section wins; missing files are skipped. Returns nil when
+// no file configures external references.
+func LoadExternalRefConfig(paths ...string) (*ExternalRefConfig, error) {
+	var result *ExternalRefConfig
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		var settings struct {
+			ExternalRefs *ExternalRefConfig `yaml:"external_refs"`
+		}
+		if err := yaml.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("invalid external_refs configuration in %s: %w", path, err)
+		}
+		if settings.ExternalRefs != nil {
+			result = settings.ExternalRefs
+		}
+	}
+
+	if result != nil && len(result.Patterns) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
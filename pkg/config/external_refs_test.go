@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadExternalRefConfig(t *testing.T) {
+	dir := t.TempDir()
+	home := filepath.Join(dir, "home.yaml")
+	project := filepath.Join(dir, "project.yaml")
+	aliasesOnly := filepath.Join(dir, "aliases.yaml")
+
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(home, "external_refs:\n  patterns:\n    - name: github\n")
+	writeFile(project, "external_refs:\n  remote_links: true\n  patterns:\n    - name: gitlab\n    - name: internal\n      pattern: 'https://git/(?P<repo>\\w+)/(?P<number>\\d+)'\n")
+	writeFile(aliasesOnly, "jql_aliases:\n  mine: assignee = currentUser()\n")
+
+	// The last file defining the section wins; files without it are ignored
+	cfg, err := LoadExternalRefConfig(home, project, aliasesOnly, filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadExternalRefConfig() error = %v", err)
+	}
+	if cfg == nil || !cfg.RemoteLinks || len(cfg.Patterns) != 2 || cfg.Patterns[1].Name != "internal" {
+		t.Errorf("Expected project configuration, got %+v", cfg)
+	}
+
+	cfg, err = LoadExternalRefConfig(aliasesOnly)
+	if err != nil || cfg != nil {
+		t.Errorf("Expected nil configuration without external_refs, got %+v (err %v)", cfg, err)
+	}
+
+	writeFile(project, "external_refs:\n  remote_links: true\n")
+	if cfg, _ := LoadExternalRefConfig(home, project); cfg != nil {
+		t.Errorf("Expected nil configuration without patterns, got %+v", cfg)
+	}
+
+	writeFile(project, "external_refs: [")
+	if _, err := LoadExternalRefConfig(project); err == nil {
+		t.Error("Expected error for invalid YAML")
+	}
+}
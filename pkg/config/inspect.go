@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Configuration value sources
+const (
+	SourceEnvironment = "environment"
+	SourceDefault     = "default"
+	SourceNotSet      = "not set"
+)
+
+// Setting describes one resolved configuration value and where it came from
+type Setting struct {
+	Key    string // environment variable name
+	Value  string // resolved value (masked when Secret is true)
+	Source string // environment, default, not set, or the .env file that supplied it
+	Secret bool
+}
+
+// Inspection is the resolved configuration together with the origin of every value
+type Inspection struct {
+	Config   *Config
+	Settings []Setting
+	EnvFiles []string // .env files that exist and were read
+}
+
+// settingDefaults lists every configuration key in display order with its default
+// (empty when the value is required) and how its raw value is parsed
+var settingDefaults = []struct {
+	key          string
+	defaultValue string
+	kind         string
+	secret       bool
+}{
+	{"JIRA_BASE_URL", "", "string", false},
+	{"JIRA_EMAIL", "", "string", false},
+	{"JIRA_PAT", "", "string", true},
+	{"JIRA_PUBLIC_URL", "", "string", false},
+	{"RATE_LIMIT_DELAY", "100ms", "duration", false},
+	{"MAX_CONCURRENT_REQUESTS", "5", "int", false},
+	{"EXPONENTIAL_BACKOFF_BASE", "1s", "duration", false},
+	{"MAX_BACKOFF_DELAY", "30s", "duration", false},
+	{"JIRA_MAX_RETRIES", "3", "int", false},
+	{"JIRA_ISSUE_RETRIES", "1", "int", false},
+	{"JIRA_RETRY_STATUS_CODES", DefaultRetryStatusCodes, "string", false},
+	{"JIRA_RETRY_ERROR_PATTERNS", DefaultRetryErrorPatterns, "string", false},
+	{"JIRA_RESOLVE_USERS", "false", "bool", false},
+	{"JIRA_INCLUDE_CHANGELOG", "true", "bool", false},
+	{"JIRA_INCLUDE_CUSTOM_FIELDS", "false", "bool", false},
+	{"JIRA_INCLUDE_WORKLOGS", "false", "bool", false},
+	{"JIRA_CA_CERT", "", "string", false},
+	{"JIRA_INSECURE_SKIP_VERIFY", "false", "bool", false},
+	{"JIRA_CACHE_DIR", "", "string", false},
+	{"JIRA_CACHE_MODE", "off", "string", false},
+	{"JIRA_CACHE_TTL", "0s", "duration", false},
+	{"JIRA_USER_AGENT", "", "string", false},
+	{"JIRA_REQUEST_IDS", "true", "bool", false},
+	{"JIRA_SYNC_SIGNING_KEY", "", "string", false},
+	{"LOG_LEVEL", "info", "string", false},
+	{"LOG_FORMAT", "text", "string", false},
+}
+
+// Inspect resolves configuration the same way DotEnvLoader.Load does, without validating it
+// and without modifying the process environment, and records the source of each value.
+// Values from .env files override the environment, and later files override earlier ones.
+func Inspect(envFiles ...string) (*Inspection, error) {
+	return inspect(&OSEnvLoader{}, envFiles...)
+}
+
+// inspect implements Inspect on top of an arbitrary environment (for testing)
+func inspect(env EnvLoader, envFiles ...string) (*Inspection, error) {
+	if len(envFiles) == 0 {
+		envFiles = []string{".env"}
+	}
+
+	inspection := &Inspection{}
+	fileValues := make(map[string]string)
+	fileSources := make(map[string]string)
+	for _, envFile := range envFiles {
+		if _, err := os.Stat(envFile); err != nil {
+			continue
+		}
+		values, err := godotenv.Read(envFile)
+		if err != nil {
+			return nil, NewEnvFileError(envFile, err)
+		}
+		inspection.EnvFiles = append(inspection.EnvFiles, envFile)
+		for key, value := range values {
+			fileValues[key] = value
+			fileSources[key] = envFile
+		}
+	}
+
+	layered := &layeredEnvLoader{base: env, overrides: fileValues}
+	inspection.Config = (&Loader{envLoader: layered}).loadUnvalidated()
+
+	for _, setting := range settingDefaults {
+		raw := layered.Getenv(setting.key)
+		value, source := raw, SourceEnvironment
+		if envFile := fileSources[setting.key]; envFile != "" && raw != "" {
+			source = fmt.Sprintf(".env file (%s)", envFile)
+		}
+
+		switch {
+		case raw == "" && setting.defaultValue != "":
+			value, source = setting.defaultValue, SourceDefault
+		case raw == "":
+			source = SourceNotSet
+		case !parsesAs(setting.kind, raw):
+			// The loader silently falls back to the default for unparseable values
+			value = setting.defaultValue
+			source = fmt.Sprintf("%s (invalid value %q from %s ignored)", SourceDefault, raw, source)
+		}
+
+		if setting.secret {
+			value = MaskSecret(value)
+		}
+		inspection.Settings = append(inspection.Settings, Setting{
+			Key:    setting.key,
+			Value:  value,
+			Source: source,
+			Secret: setting.secret,
+		})
+	}
+
+	return inspection, nil
+}
+
+// MaskSecret hides a credential for display
+// Only the length and, for long values, the last four characters are revealed.
+func MaskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	if len(value) < 16 {
+		return fmt.Sprintf("%s (%d chars)", strings.Repeat("*", 8), len(value))
+	}
+	return fmt.Sprintf("%s%s (%d chars)", strings.Repeat("*", 8), value[len(value)-4:], len(value))
+}
+
+// parsesAs reports whether a raw value parses the way the loader reads that setting
+func parsesAs(kind, raw string) bool {
+	var err error
+	switch kind {
+	case "duration":
+		_, err = time.ParseDuration(raw)
+	case "int":
+		_, err = strconv.Atoi(raw)
+	case "bool":
+		_, err = strconv.ParseBool(raw)
+	}
+	return err == nil
+}
+
+// layeredEnvLoader serves .env file values on top of a base environment
+type layeredEnvLoader struct {
+	base      EnvLoader
+	overrides map[string]string
+}
+
+func (l *layeredEnvLoader) Getenv(key string) string {
+	if value, exists := l.overrides[key]; exists {
+		return value
+	}
+	return l.base.Getenv(key)
+}
+
+func (l *layeredEnvLoader) LookupEnv(key string) (string, bool) {
+	if value, exists := l.overrides[key]; exists {
+		return value, true
+	}
+	return l.base.LookupEnv(key)
+}
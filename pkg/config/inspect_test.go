@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInspect_Sources(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	content := "JIRA_BASE_URL=https://file.atlassian.net\nJIRA_PAT=super-secret-token-value-1234\nMAX_CONCURRENT_REQUESTS=lots\n"
+	if err := os.WriteFile(envFile, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write .env file: %v", err)
+	}
+
+	env := NewMockEnvLoader(map[string]string{
+		"JIRA_BASE_URL":    "https://env.atlassian.net",
+		"JIRA_EMAIL":       "user@example.com",
+		"RATE_LIMIT_DELAY": "250ms",
+	})
+
+	inspection, err := inspect(env, envFile, filepath.Join(t.TempDir(), "missing.env"))
+	if err != nil {
+		t.Fatalf("inspect() error = %v", err)
+	}
+
+	if len(inspection.EnvFiles) != 1 || inspection.EnvFiles[0] != envFile {
+		t.Errorf("Expected only the existing .env file to be reported, got %v", inspection.EnvFiles)
+	}
+	if inspection.Config.JIRABaseURL != "https://file.atlassian.net" {
+		t.Errorf("Expected .env to override the environment, got %s", inspection.Config.JIRABaseURL)
+	}
+
+	settings := make(map[string]Setting)
+	for _, setting := range inspection.Settings {
+		settings[setting.Key] = setting
+	}
+
+	if !strings.HasPrefix(settings["JIRA_BASE_URL"].Source, ".env file") {
+		t.Errorf("Expected JIRA_BASE_URL from .env file, got %s", settings["JIRA_BASE_URL"].Source)
+	}
+	if settings["JIRA_EMAIL"].Source != SourceEnvironment {
+		t.Errorf("Expected JIRA_EMAIL from environment, got %s", settings["JIRA_EMAIL"].Source)
+	}
+	if settings["RATE_LIMIT_DELAY"].Value != "250ms" || settings["RATE_LIMIT_DELAY"].Source != SourceEnvironment {
+		t.Errorf("Unexpected RATE_LIMIT_DELAY setting: %+v", settings["RATE_LIMIT_DELAY"])
+	}
+	if settings["LOG_LEVEL"].Value != "info" || settings["LOG_LEVEL"].Source != SourceDefault {
+		t.Errorf("Expected LOG_LEVEL default, got %+v", settings["LOG_LEVEL"])
+	}
+	if customFields := settings["JIRA_INCLUDE_CUSTOM_FIELDS"]; customFields.Value != "false" || customFields.Source != SourceDefault {
+		t.Errorf("Expected JIRA_INCLUDE_CUSTOM_FIELDS default, got %+v", customFields)
+	}
+	if concurrency := settings["MAX_CONCURRENT_REQUESTS"]; concurrency.Value != "5" || !strings.Contains(concurrency.Source, "invalid value") {
+		t.Errorf("Expected invalid value to be reported as ignored, got %+v", concurrency)
+	}
+
+	pat := settings["JIRA_PAT"]
+	if strings.Contains(pat.Value, "super-secret") || !pat.Secret {
+		t.Errorf("Expected JIRA_PAT to be masked, got %q", pat.Value)
+	}
+}
+
+func TestInspect_ListsEverySetting(t *testing.T) {
+	listed := make(map[string]bool)
+	for _, setting := range settingDefaults {
+		listed[setting.key] = true
+	}
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		if key := configType.Field(i).Tag.Get("env"); key != "" && !listed[key] {
+			t.Errorf("Config.%s (%s) is missing from config show", configType.Field(i).Name, key)
+		}
+	}
+}
+
+func TestInspect_MissingRequiredValues(t *testing.T) {
+	inspection, err := inspect(NewMockEnvLoader(map[string]string{}), filepath.Join(t.TempDir(), ".env"))
+	if err != nil {
+		t.Fatalf("inspect() error = %v", err)
+	}
+
+	for _, setting := range inspection.Settings {
+		if setting.Key == "JIRA_EMAIL" && setting.Source != SourceNotSet {
+			t.Errorf("Expected JIRA_EMAIL to be reported as not set, got %s", setting.Source)
+		}
+	}
+	if err := NewLoader().Validate(inspection.Config); err == nil {
+		t.Error("Expected validation to fail without required values")
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	tests := map[string]string{
+		"":                             "",
+		"short-token":                  "******** (11 chars)",
+		"ATATT3xFfGF0abcdefghijkl9876": "********9876 (28 chars)",
+	}
+	for input, expected := range tests {
+		if got := MaskSecret(input); got != expected {
+			t.Errorf("MaskSecret(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
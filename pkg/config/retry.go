@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Default retry classification: the HTTP statuses and transport error substrings treated as
+// transient, and how often a request and an issue are retried
+const (
+	DefaultRetryStatusCodes   = "429,502,503,504"
+	DefaultRetryErrorPatterns = "connection reset,connection refused,i/o timeout,TLS handshake timeout,unexpected EOF,broken pipe"
+	DefaultMaxRetries         = 3
+	DefaultIssueRetries       = 1
+
+	// RetryListNone disables a retry list, e.g. JIRA_RETRY_STATUS_CODES=none
+	RetryListNone = "none"
+)
+
+// ParseRetryStatusCodes parses a comma-separated list of retryable HTTP status codes (400-599)
+// An empty value uses DefaultRetryStatusCodes, and "none" retries no status.
+func ParseRetryStatusCodes(value string) ([]int, error) {
+	if strings.TrimSpace(value) == "" {
+		value = DefaultRetryStatusCodes
+	}
+	if strings.EqualFold(strings.TrimSpace(value), RetryListNone) {
+		return nil, nil
+	}
+
+	var codes []int
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		code, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an HTTP status code", field)
+		}
+		if code < 400 || code > 599 {
+			return nil, fmt.Errorf("status %d is not an error status (400-599)", code)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// ParseRetryErrorPatterns parses a comma-separated list of error substrings, matched
+// case-insensitively against failed requests' errors
+// An empty value uses DefaultRetryErrorPatterns, and "none" matches no error.
+func ParseRetryErrorPatterns(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		value = DefaultRetryErrorPatterns
+	}
+	if strings.EqualFold(strings.TrimSpace(value), RetryListNone) {
+		return nil
+	}
+
+	var patterns []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			patterns = append(patterns, strings.ToLower(field))
+		}
+	}
+	return patterns
+}
+
+// validateRetryConfig checks the retry classification settings
+func validateRetryConfig(config *Config) []string {
+	var errors []string
+	if config.MaxRetries < 0 {
+		errors = append(errors, "JIRA_MAX_RETRIES must be non-negative")
+	}
+	if config.IssueRetries < 0 {
+		errors = append(errors, "JIRA_ISSUE_RETRIES must be non-negative")
+	}
+	if _, err := ParseRetryStatusCodes(config.RetryStatusCodes); err != nil {
+		errors = append(errors, fmt.Sprintf("JIRA_RETRY_STATUS_CODES is invalid: %v", err))
+	}
+	return errors
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfig_LoadFromEnv_RetryDefaults(t *testing.T) {
+	config, err := NewLoaderWithEnv(NewMockEnvLoader(map[string]string{
+		"JIRA_BASE_URL": "https://test.atlassian.net",
+		"JIRA_EMAIL":    "test@example.com",
+		"JIRA_PAT":      "test-pat-token-123",
+	})).Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.MaxRetries != DefaultMaxRetries || config.IssueRetries != DefaultIssueRetries {
+		t.Errorf("Expected default retries, got %d and %d", config.MaxRetries, config.IssueRetries)
+	}
+	if config.RetryStatusCodes != DefaultRetryStatusCodes || config.RetryErrorPatterns != DefaultRetryErrorPatterns {
+		t.Errorf("Expected default retry classification, got %q and %q", config.RetryStatusCodes, config.RetryErrorPatterns)
+	}
+}
+
+func TestConfig_Validate_Retry(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"custom", map[string]string{"JIRA_RETRY_STATUS_CODES": "429, 504", "JIRA_MAX_RETRIES": "0"}, ""},
+		{"none", map[string]string{"JIRA_RETRY_STATUS_CODES": "none"}, ""},
+		{"not a number", map[string]string{"JIRA_RETRY_STATUS_CODES": "503,soon"}, "JIRA_RETRY_STATUS_CODES"},
+		{"not an error status", map[string]string{"JIRA_RETRY_STATUS_CODES": "200"}, "JIRA_RETRY_STATUS_CODES"},
+		{"negative retries", map[string]string{"JIRA_MAX_RETRIES": "-1"}, "JIRA_MAX_RETRIES"},
+		{"negative issue retries", map[string]string{"JIRA_ISSUE_RETRIES": "-2"}, "JIRA_ISSUE_RETRIES"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{
+				"JIRA_BASE_URL": "https://test.atlassian.net",
+				"JIRA_EMAIL":    "test@example.com",
+				"JIRA_PAT":      "test-pat-token-123",
+			}
+			for key, value := range tt.env {
+				env[key] = value
+			}
+			_, err := NewLoaderWithEnv(NewMockEnvLoader(env)).Load()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error about %s, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseRetryLists(t *testing.T) {
+	codes, err := ParseRetryStatusCodes("")
+	if err != nil || !reflect.DeepEqual(codes, []int{429, 502, 503, 504}) {
+		t.Errorf("ParseRetryStatusCodes(\"\") = %v, %v", codes, err)
+	}
+	if codes, _ := ParseRetryStatusCodes("NONE"); codes != nil {
+		t.Errorf("Expected none to disable status retries, got %v", codes)
+	}
+
+	patterns := ParseRetryErrorPatterns(" Connection Reset , ,maintenance")
+	if !reflect.DeepEqual(patterns, []string{"connection reset", "maintenance"}) {
+		t.Errorf("ParseRetryErrorPatterns() = %q", patterns)
+	}
+	if len(ParseRetryErrorPatterns("")) == 0 || ParseRetryErrorPatterns("none") != nil {
+		t.Error("Expected an empty list to use the defaults and none to disable patterns")
+	}
+}
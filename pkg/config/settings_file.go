@@ -0,0 +1,23 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// SettingsFileName is the settings file whose sections configure JQL aliases, external and code
+// references, and profile lint rules
+const SettingsFileName = ".jira-sync.yaml"
+
+// SettingsFilePaths returns the settings files read by the CLI: the user's home directory, then
+// the current directory, so project settings override personal ones
+func SettingsFilePaths() []string {
+	var paths []string
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, SettingsFileName))
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		paths = append(paths, filepath.Join(cwd, SettingsFileName))
+	}
+	return paths
+}
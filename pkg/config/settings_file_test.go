@@ -0,0 +1,18 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSettingsFilePaths(t *testing.T) {
+	home, cwd := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(cwd)
+
+	paths := SettingsFilePaths()
+	want := []string{filepath.Join(home, SettingsFileName), filepath.Join(cwd, SettingsFileName)}
+	if len(paths) != 2 || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("SettingsFilePaths() = %v, want %v", paths, want)
+	}
+}
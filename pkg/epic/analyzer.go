@@ -1,6 +1,8 @@
 package epic
 
 import (
+	"time"
+
 	"github.com/chambrid/jira-cdc-git/pkg/client"
 )
 
@@ -31,6 +33,7 @@ type AnalysisResult struct {
 	Hierarchy         *HierarchyMap       `json:"hierarchy" yaml:"hierarchy"`
 	Performance       *PerformanceMetrics `json:"performance" yaml:"performance"`
 	Completeness      *CompletenessReport `json:"completeness" yaml:"completeness"`
+	CachedAt          *time.Time          `json:"cached_at,omitempty" yaml:"cached_at,omitempty"` // when the result was cached, for results reused from an AnalysisCache
 }
 
 // HierarchyMap represents the hierarchical structure of issues in an EPIC
@@ -93,6 +96,9 @@ type DiscoveryOptions struct {
 	IncludeLinkedIssues bool                  `json:"include_linked_issues" yaml:"include_linked_issues"`
 	BatchSize           int                   `json:"batch_size" yaml:"batch_size"`
 	UseCache            bool                  `json:"use_cache" yaml:"use_cache"`
+	Concurrency         int                   `json:"concurrency" yaml:"concurrency"`           // Parallel per-issue fetches
+	RateLimit           time.Duration         `json:"rate_limit" yaml:"rate_limit"`             // Minimum spacing between fetches (0: rely on the client's limiter)
+	Within              string                `json:"within,omitempty" yaml:"within,omitempty"` // JQL sub-filter limiting discovery to a slice of the EPIC
 }
 
 // DefaultDiscoveryOptions returns sensible default options for EPIC discovery
@@ -104,5 +110,6 @@ func DefaultDiscoveryOptions() *DiscoveryOptions {
 		IncludeLinkedIssues: true,
 		BatchSize:           100,
 		UseCache:            true,
+		Concurrency:         DefaultDiscoveryConcurrency,
 	}
 }
@@ -0,0 +1,193 @@
+package epic
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultAnalysisCacheTTL is how long a cached EPIC analysis is reused
+const DefaultAnalysisCacheTTL = time.Hour
+
+// analysisCacheVersion is bumped whenever the cached layout changes, so old entries are ignored
+const analysisCacheVersion = 1
+
+// cachedAnalysis is one cache file: an EPIC analysis with when and how it was made
+type cachedAnalysis struct {
+	Version  int             `json:"version"`
+	EpicKey  string          `json:"epic_key"`
+	Options  string          `json:"options"`
+	CachedAt time.Time       `json:"cached_at"`
+	Result   *AnalysisResult `json:"result"`
+}
+
+// AnalysisCache stores EPIC analysis results on disk, one JSON file per EPIC key
+// Entries expire after TTL and are only reused for the discovery options they were made with.
+type AnalysisCache struct {
+	Dir string
+	TTL time.Duration
+
+	now func() time.Time
+}
+
+// DefaultAnalysisCacheDir returns the cache directory under the user's home (~/.jira-sync/epic-cache)
+func DefaultAnalysisCacheDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".jira-sync", "epic-cache")
+}
+
+// NewAnalysisCache creates a cache in dir whose entries expire after ttl (0 uses DefaultAnalysisCacheTTL)
+func NewAnalysisCache(dir string, ttl time.Duration) *AnalysisCache {
+	if ttl <= 0 {
+		ttl = DefaultAnalysisCacheTTL
+	}
+	return &AnalysisCache{Dir: dir, TTL: ttl, now: time.Now}
+}
+
+// Load returns the cached analysis of an EPIC made with options, and when it was cached
+// Missing, expired, unreadable, and outdated entries all report a miss.
+func (c *AnalysisCache) Load(epicKey string, options *DiscoveryOptions) (*AnalysisResult, time.Time, bool) {
+	data, err := os.ReadFile(c.path(epicKey))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	var entry cachedAnalysis
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, time.Time{}, false
+	}
+	if entry.Version != analysisCacheVersion || entry.EpicKey != epicKey || entry.Options != optionsFingerprint(options) || entry.Result == nil {
+		return nil, time.Time{}, false
+	}
+	if c.now().Sub(entry.CachedAt) >= c.TTL {
+		return nil, time.Time{}, false
+	}
+	return entry.Result, entry.CachedAt, true
+}
+
+// Store saves the analysis of an EPIC made with options, replacing any earlier entry
+// The file is written to a temporary name and renamed, so concurrent readers never see half an entry.
+func (c *AnalysisCache) Store(result *AnalysisResult, options *DiscoveryOptions) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create EPIC cache directory: %w", err)
+	}
+	data, err := json.Marshal(cachedAnalysis{
+		Version:  analysisCacheVersion,
+		EpicKey:  result.EpicKey,
+		Options:  optionsFingerprint(options),
+		CachedAt: c.now().UTC(),
+		Result:   result,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode EPIC analysis: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, "."+result.EpicKey+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write EPIC cache: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write EPIC cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write EPIC cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(result.EpicKey)); err != nil {
+		return fmt.Errorf("failed to write EPIC cache: %w", err)
+	}
+	return nil
+}
+
+// Invalidate removes the cached analysis of an EPIC
+func (c *AnalysisCache) Invalidate(epicKey string) error {
+	if err := os.Remove(c.path(epicKey)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove EPIC cache entry: %w", err)
+	}
+	return nil
+}
+
+// path returns the cache file of an EPIC
+func (c *AnalysisCache) path(epicKey string) string {
+	return filepath.Join(c.Dir, filepath.Base(epicKey)+".json")
+}
+
+// optionsFingerprint describes the discovery options that change what an analysis finds
+func optionsFingerprint(options *DiscoveryOptions) string {
+	if options == nil {
+		options = DefaultDiscoveryOptions()
+	}
+	return strings.Join([]string{
+		string(options.Strategy),
+		fmt.Sprintf("depth=%d", options.MaxDepth),
+		fmt.Sprintf("subtasks=%t", options.IncludeSubtasks),
+		fmt.Sprintf("links=%t", options.IncludeLinkedIssues),
+		"within=" + options.Within,
+	}, ";")
+}
+
+// CachingEpicAnalyzer reuses cached analyses of an analyzer across invocations
+// AnalyzeEpic, GetEpicHierarchy, and ValidateEpicCompleteness answer from the cache while an
+// entry is fresh; DiscoverEpicIssues always asks the wrapped analyzer, since issues are not
+// cached. With Refresh, cached entries are ignored and replaced by new analyses.
+type CachingEpicAnalyzer struct {
+	EpicAnalyzer
+
+	cache   *AnalysisCache
+	options *DiscoveryOptions
+
+	// Refresh re-analyzes every EPIC instead of reusing cached results
+	Refresh bool
+}
+
+// NewCachingEpicAnalyzer wraps an analyzer running with options in an analysis cache
+func NewCachingEpicAnalyzer(analyzer EpicAnalyzer, cache *AnalysisCache, options *DiscoveryOptions, refresh bool) *CachingEpicAnalyzer {
+	return &CachingEpicAnalyzer{EpicAnalyzer: analyzer, cache: cache, options: options, Refresh: refresh}
+}
+
+// AnalyzeEpic returns the cached analysis of an EPIC, analyzing and caching it when there is none
+// A cached result has CachedAt set. Failing to write the cache does not fail the analysis.
+func (ca *CachingEpicAnalyzer) AnalyzeEpic(epicKey string) (*AnalysisResult, error) {
+	if result, ok := ca.cached(epicKey); ok {
+		return result, nil
+	}
+
+	result, err := ca.EpicAnalyzer.AnalyzeEpic(epicKey)
+	if err != nil {
+		return nil, err
+	}
+	_ = ca.cache.Store(result, ca.options) // the analysis stands on its own; the next run retries caching
+	return result, nil
+}
+
+// GetEpicHierarchy returns the hierarchy of a cached analysis, or asks the wrapped analyzer
+func (ca *CachingEpicAnalyzer) GetEpicHierarchy(epicKey string) (*HierarchyMap, error) {
+	if result, ok := ca.cached(epicKey); ok && result.Hierarchy != nil {
+		return result.Hierarchy, nil
+	}
+	return ca.EpicAnalyzer.GetEpicHierarchy(epicKey)
+}
+
+// ValidateEpicCompleteness returns the completeness of a cached analysis, or asks the wrapped analyzer
+func (ca *CachingEpicAnalyzer) ValidateEpicCompleteness(epicKey string) (*CompletenessReport, error) {
+	if result, ok := ca.cached(epicKey); ok && result.Completeness != nil {
+		return result.Completeness, nil
+	}
+	return ca.EpicAnalyzer.ValidateEpicCompleteness(epicKey)
+}
+
+// cached loads a fresh cache entry unless refreshing
+func (ca *CachingEpicAnalyzer) cached(epicKey string) (*AnalysisResult, bool) {
+	if ca.Refresh {
+		return nil, false
+	}
+	result, cachedAt, ok := ca.cache.Load(epicKey, ca.options)
+	if !ok {
+		return nil, false
+	}
+	result.CachedAt = &cachedAt
+	return result, true
+}
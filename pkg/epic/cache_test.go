@@ -0,0 +1,176 @@
+package epic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestCache returns a cache in a temporary directory with a controllable clock
+func newTestCache(t *testing.T, ttl time.Duration) (*AnalysisCache, *time.Time) {
+	t.Helper()
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	cache := NewAnalysisCache(t.TempDir(), ttl)
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestAnalysisCache_StoreLoad(t *testing.T) {
+	cache, now := newTestCache(t, time.Hour)
+	options := DefaultDiscoveryOptions()
+
+	if _, _, ok := cache.Load("PROJ-100", options); ok {
+		t.Fatal("Expected a miss on an empty cache")
+	}
+
+	result := &AnalysisResult{EpicKey: "PROJ-100", EpicSummary: "Checkout", TotalIssues: 42}
+	if err := cache.Store(result, options); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	cached, cachedAt, ok := cache.Load("PROJ-100", options)
+	if !ok {
+		t.Fatal("Expected a hit after storing")
+	}
+	if cached.TotalIssues != 42 || cached.EpicSummary != "Checkout" {
+		t.Errorf("Unexpected cached result: %+v", cached)
+	}
+	if !cachedAt.Equal(*now) {
+		t.Errorf("Expected cached at %v, got %v", *now, cachedAt)
+	}
+
+	// The temporary file is renamed into place
+	entries, _ := os.ReadDir(cache.Dir)
+	if len(entries) != 1 || entries[0].Name() != "PROJ-100.json" {
+		t.Errorf("Expected a single PROJ-100.json entry, got %v", entries)
+	}
+
+	*now = now.Add(59 * time.Minute)
+	if _, _, ok := cache.Load("PROJ-100", options); !ok {
+		t.Error("Expected a hit within the TTL")
+	}
+	*now = now.Add(time.Minute)
+	if _, _, ok := cache.Load("PROJ-100", options); ok {
+		t.Error("Expected a miss once the TTL has passed")
+	}
+}
+
+func TestAnalysisCache_OptionsAndInvalidation(t *testing.T) {
+	cache, _ := newTestCache(t, time.Hour)
+	options := DefaultDiscoveryOptions()
+	if err := cache.Store(&AnalysisResult{EpicKey: "PROJ-100"}, options); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	sliced := DefaultDiscoveryOptions()
+	sliced.Within = "component = Payments"
+	if _, _, ok := cache.Load("PROJ-100", sliced); ok {
+		t.Error("Expected a miss for different discovery options")
+	}
+	if _, _, ok := cache.Load("PROJ-200", options); ok {
+		t.Error("Expected a miss for another EPIC")
+	}
+
+	if err := os.WriteFile(filepath.Join(cache.Dir, "PROJ-300.json"), []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to write corrupt entry: %v", err)
+	}
+	if _, _, ok := cache.Load("PROJ-300", options); ok {
+		t.Error("Expected a miss for a corrupt entry")
+	}
+
+	if err := cache.Invalidate("PROJ-100"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if _, _, ok := cache.Load("PROJ-100", options); ok {
+		t.Error("Expected a miss after invalidation")
+	}
+	if err := cache.Invalidate("PROJ-100"); err != nil {
+		t.Errorf("Invalidating a missing entry should succeed, got %v", err)
+	}
+}
+
+func TestCachingEpicAnalyzer(t *testing.T) {
+	cache, now := newTestCache(t, time.Hour)
+	options := DefaultDiscoveryOptions()
+	mock := NewMockEpicAnalyzer()
+
+	analyzer := NewCachingEpicAnalyzer(mock, cache, options, false)
+	first, err := analyzer.AnalyzeEpic("PROJ-100")
+	if err != nil {
+		t.Fatalf("AnalyzeEpic() error = %v", err)
+	}
+	if first.CachedAt != nil {
+		t.Error("A fresh analysis should not be marked as cached")
+	}
+
+	// A later invocation (a new analyzer over the same cache directory) reuses the analysis
+	analyzer = NewCachingEpicAnalyzer(mock, NewAnalysisCache(cache.Dir, time.Hour), options, false)
+	analyzer.cache.now = cache.now
+	second, err := analyzer.AnalyzeEpic("PROJ-100")
+	if err != nil {
+		t.Fatalf("AnalyzeEpic() error = %v", err)
+	}
+	if len(mock.AnalyzeEpicCalls) != 1 {
+		t.Errorf("Expected the cached analysis to be reused, got %d analyses", len(mock.AnalyzeEpicCalls))
+	}
+	if second.CachedAt == nil || second.TotalIssues != first.TotalIssues {
+		t.Errorf("Expected the cached result, got %+v", second)
+	}
+
+	// Refresh re-analyzes and replaces the entry
+	refreshing := NewCachingEpicAnalyzer(mock, cache, options, true)
+	*now = now.Add(10 * time.Minute)
+	if _, err := refreshing.AnalyzeEpic("PROJ-100"); err != nil {
+		t.Fatalf("AnalyzeEpic() error = %v", err)
+	}
+	if len(mock.AnalyzeEpicCalls) != 2 {
+		t.Errorf("Expected --refresh-epic to re-analyze, got %d analyses", len(mock.AnalyzeEpicCalls))
+	}
+	if _, cachedAt, ok := cache.Load("PROJ-100", options); !ok || !cachedAt.Equal(*now) {
+		t.Errorf("Expected the refreshed analysis to replace the entry, got %v (hit %t)", cachedAt, ok)
+	}
+
+	// Expired entries are re-analyzed
+	*now = now.Add(time.Hour)
+	if _, err := analyzer.AnalyzeEpic("PROJ-100"); err != nil {
+		t.Fatalf("AnalyzeEpic() error = %v", err)
+	}
+	if len(mock.AnalyzeEpicCalls) != 3 {
+		t.Errorf("Expected an expired entry to be re-analyzed, got %d analyses", len(mock.AnalyzeEpicCalls))
+	}
+}
+
+func TestCachingEpicAnalyzer_HierarchyFromCache(t *testing.T) {
+	cache, _ := newTestCache(t, time.Hour)
+	options := DefaultDiscoveryOptions()
+	mock := NewMockEpicAnalyzer()
+	mock.SetMockAnalysis("PROJ-100", &AnalysisResult{
+		EpicKey:      "PROJ-100",
+		Hierarchy:    &HierarchyMap{EpicKey: "PROJ-100", Levels: 2},
+		Completeness: &CompletenessReport{CompletenessPercent: 90},
+	})
+	analyzer := NewCachingEpicAnalyzer(mock, cache, options, false)
+
+	if _, err := analyzer.AnalyzeEpic("PROJ-100"); err != nil {
+		t.Fatalf("AnalyzeEpic() error = %v", err)
+	}
+	hierarchy, err := analyzer.GetEpicHierarchy("PROJ-100")
+	if err != nil || hierarchy.Levels != 2 {
+		t.Errorf("Expected the cached hierarchy, got %+v, %v", hierarchy, err)
+	}
+	report, err := analyzer.ValidateEpicCompleteness("PROJ-100")
+	if err != nil || report.CompletenessPercent != 90 {
+		t.Errorf("Expected the cached completeness report, got %+v, %v", report, err)
+	}
+	if len(mock.GetEpicHierarchyCalls) != 0 || len(mock.ValidateEpicCompletenessCalls) != 0 {
+		t.Error("Expected cached answers without asking the wrapped analyzer")
+	}
+
+	if _, err := analyzer.DiscoverEpicIssues("PROJ-100"); err != nil {
+		t.Fatalf("DiscoverEpicIssues() error = %v", err)
+	}
+	if len(mock.DiscoverEpicIssuesCalls) != 1 {
+		t.Error("Expected issue discovery to always reach the wrapped analyzer")
+	}
+}
@@ -0,0 +1,125 @@
+package epic
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// DefaultDiscoveryConcurrency bounds concurrent per-issue fetches during discovery
+const DefaultDiscoveryConcurrency = 5
+
+// fetchResult is the outcome of fetching one issue
+type fetchResult struct {
+	issue *client.Issue
+	err   error
+}
+
+// fetchIssues retrieves issues by key with a bounded worker pool
+// Requests are spaced by options.RateLimit across all workers. Issues that fail to load are
+// returned in failed (sorted) rather than aborting the batch, so callers keep partial results.
+// Fetched issues are returned in input order.
+func (ja *JIRAEpicAnalyzer) fetchIssues(keys []string) ([]*client.Issue, []string) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	workers := ja.options.Concurrency
+	if workers <= 0 {
+		workers = DefaultDiscoveryConcurrency
+	}
+	if workers > len(keys) {
+		workers = len(keys)
+	}
+
+	var throttle <-chan time.Time
+	if ja.options.RateLimit > 0 {
+		ticker := time.NewTicker(ja.options.RateLimit)
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	results := make([]fetchResult, len(keys))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				issue, err := ja.getIssue(keys[i])
+				results[i] = fetchResult{issue: issue, err: err}
+			}
+		}()
+	}
+	for i := range keys {
+		if throttle != nil && i > 0 {
+			<-throttle
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var issues []*client.Issue
+	var failed []string
+	for i, result := range results {
+		if result.err != nil {
+			failed = append(failed, keys[i])
+			continue
+		}
+		issues = append(issues, result.issue)
+	}
+	sort.Strings(failed)
+	return issues, failed
+}
+
+// expandSubtasks fetches subtasks referenced by discovered issues that the search did not return
+// Keys that cannot be fetched are remembered and reported as missing in the completeness report.
+func (ja *JIRAEpicAnalyzer) expandSubtasks(issues []*client.Issue) []*client.Issue {
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		known[issue.Key] = true
+	}
+
+	var missing []string
+	for _, issue := range issues {
+		if issue.Relationships == nil {
+			continue
+		}
+		for _, subtaskKey := range issue.Relationships.Subtasks {
+			if !known[subtaskKey] {
+				known[subtaskKey] = true
+				missing = append(missing, subtaskKey)
+			}
+		}
+	}
+
+	fetched, failed := ja.fetchIssues(missing)
+	ja.setFailedFetches(failed)
+	if len(fetched) == 0 {
+		return issues
+	}
+
+	expanded := append(issues, fetched...)
+	sort.SliceStable(expanded, func(i, j int) bool {
+		return client.CompareIssueKeys(expanded[i].Key, expanded[j].Key) < 0
+	})
+	return expanded
+}
+
+// setFailedFetches records the keys that could not be fetched during the last discovery
+func (ja *JIRAEpicAnalyzer) setFailedFetches(keys []string) {
+	ja.mu.Lock()
+	ja.failedFetches = keys
+	ja.mu.Unlock()
+}
+
+// getFailedFetches returns the keys that could not be fetched during the last discovery
+func (ja *JIRAEpicAnalyzer) getFailedFetches() []string {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	return append([]string(nil), ja.failedFetches...)
+}
@@ -0,0 +1,132 @@
+package epic
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// latencyClient simulates per-request latency and tracks peak concurrency
+type latencyClient struct {
+	*client.MockClient
+	latency  time.Duration
+	failKeys map[string]bool
+	inFlight int32
+	peak     int32
+}
+
+func (c *latencyClient) GetIssue(issueKey string) (*client.Issue, error) {
+	current := atomic.AddInt32(&c.inFlight, 1)
+	defer atomic.AddInt32(&c.inFlight, -1)
+	for {
+		peak := atomic.LoadInt32(&c.peak)
+		if current <= peak || atomic.CompareAndSwapInt32(&c.peak, peak, current) {
+			break
+		}
+	}
+
+	time.Sleep(c.latency)
+	if c.failKeys[issueKey] {
+		return nil, &client.ClientError{Type: "api_error", Message: "simulated failure", Context: issueKey}
+	}
+	return c.MockClient.GetIssue(issueKey)
+}
+
+// newLargeEpicClient builds an EPIC whose single story lists subtaskCount subtasks the search omits
+func newLargeEpicClient(subtaskCount int, latency time.Duration) *latencyClient {
+	mock := client.NewMockClient()
+	mock.AddIssue(&client.Issue{Key: "BIG-1", IssueType: "Epic", Summary: "Large epic"})
+
+	story := &client.Issue{Key: "BIG-2", IssueType: "Story", Relationships: &client.Relationships{EpicLink: "BIG-1"}}
+	for i := 0; i < subtaskCount; i++ {
+		key := fmt.Sprintf("BIG-%d", i+3)
+		story.Relationships.Subtasks = append(story.Relationships.Subtasks, key)
+		mock.AddIssue(&client.Issue{Key: key, IssueType: "Sub-task", Relationships: &client.Relationships{ParentIssue: "BIG-2"}})
+	}
+	mock.AddIssue(story)
+	mock.AddJQLResult(`"Epic Link" = BIG-1`, []string{"BIG-2"})
+
+	return &latencyClient{MockClient: mock, latency: latency}
+}
+
+func TestJIRAEpicAnalyzer_DiscoverEpicIssues_FetchesSubtasksInParallel(t *testing.T) {
+	jiraClient := newLargeEpicClient(20, 5*time.Millisecond)
+	options := DefaultDiscoveryOptions()
+	options.Concurrency = 4
+	analyzer := NewJIRAEpicAnalyzer(jiraClient, options)
+
+	issues, err := analyzer.DiscoverEpicIssues("BIG-1")
+	if err != nil {
+		t.Fatalf("DiscoverEpicIssues() error = %v", err)
+	}
+	if len(issues) != 21 {
+		t.Fatalf("Expected story plus 20 subtasks, got %d issues", len(issues))
+	}
+	if issues[0].Key != "BIG-2" || issues[1].Key != "BIG-3" || issues[20].Key != "BIG-22" {
+		t.Errorf("Expected issues sorted by key, got %s, %s, ..., %s", issues[0].Key, issues[1].Key, issues[20].Key)
+	}
+	if peak := atomic.LoadInt32(&jiraClient.peak); peak < 2 || peak > 4 {
+		t.Errorf("Expected between 2 and 4 concurrent fetches, peak was %d", peak)
+	}
+}
+
+func TestJIRAEpicAnalyzer_AnalyzeEpic_PartialFetchFailures(t *testing.T) {
+	jiraClient := newLargeEpicClient(5, 0)
+	jiraClient.failKeys = map[string]bool{"BIG-4": true, "BIG-6": true}
+	analyzer := NewJIRAEpicAnalyzer(jiraClient, nil)
+
+	result, err := analyzer.AnalyzeEpic("BIG-1")
+	if err != nil {
+		t.Fatalf("AnalyzeEpic() error = %v", err)
+	}
+	if result.TotalIssues != 4 {
+		t.Errorf("Expected story plus 3 fetched subtasks, got %d", result.TotalIssues)
+	}
+
+	missing := result.Completeness.MissingIssues
+	if len(missing) != 2 || missing[0] != "BIG-4" || missing[1] != "BIG-6" {
+		t.Errorf("Expected failed fetches reported as missing, got %v", missing)
+	}
+	if result.Completeness.TotalExpectedIssues != 6 {
+		t.Errorf("Expected 6 expected issues, got %d", result.Completeness.TotalExpectedIssues)
+	}
+}
+
+func TestJIRAEpicAnalyzer_DiscoverEpicIssues_SubtasksDisabled(t *testing.T) {
+	jiraClient := newLargeEpicClient(3, 0)
+	options := DefaultDiscoveryOptions()
+	options.IncludeSubtasks = false
+	analyzer := NewJIRAEpicAnalyzer(jiraClient, options)
+
+	issues, err := analyzer.DiscoverEpicIssues("BIG-1")
+	if err != nil {
+		t.Fatalf("DiscoverEpicIssues() error = %v", err)
+	}
+	if len(issues) != 1 || jiraClient.GetIssueCallCount != 0 {
+		t.Errorf("Expected only search results without fetches, got %d issues and %d fetches", len(issues), jiraClient.GetIssueCallCount)
+	}
+}
+
+func benchmarkDiscovery(b *testing.B, concurrency int) {
+	for i := 0; i < b.N; i++ {
+		jiraClient := newLargeEpicClient(500, 200*time.Microsecond)
+		options := DefaultDiscoveryOptions()
+		options.Concurrency = concurrency
+		analyzer := NewJIRAEpicAnalyzer(jiraClient, options)
+
+		if _, err := analyzer.DiscoverEpicIssues("BIG-1"); err != nil {
+			b.Fatalf("DiscoverEpicIssues() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkDiscoverEpicIssues_Serial(b *testing.B) {
+	benchmarkDiscovery(b, 1)
+}
+
+func BenchmarkDiscoverEpicIssues_Parallel(b *testing.B) {
+	benchmarkDiscovery(b, DefaultDiscoveryConcurrency)
+}
@@ -178,6 +178,27 @@ func TestJIRAEpicAnalyzer_AnalyzeEpic(t *testing.T) {
 	}
 }
 
+func TestJIRAEpicAnalyzer_DiscoverEpicIssues_Within(t *testing.T) {
+	mockClient := client.NewMockClient()
+	for _, issue := range []*client.Issue{
+		{Key: "TEST-124", IssueType: "Story"},
+		{Key: "TEST-125", IssueType: "Story"},
+	} {
+		mockClient.AddIssue(issue)
+	}
+	mockClient.AddJQLResult(`"Epic Link" = TEST-123`, []string{"TEST-124", "TEST-125"})
+	mockClient.AddJQLResult(`("Epic Link" = TEST-123) AND (component = Payments)`, []string{"TEST-125"})
+
+	analyzer := NewJIRAEpicAnalyzer(mockClient, &DiscoveryOptions{Strategy: StrategyEpicLink, Within: "component = Payments"})
+	issues, err := analyzer.DiscoverEpicIssues("TEST-123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "TEST-125" {
+		t.Errorf("expected only the filtered slice, got %v", issues)
+	}
+}
+
 func TestJIRAEpicAnalyzer_DiscoverEpicIssues(t *testing.T) {
 	tests := []struct {
 		name     string
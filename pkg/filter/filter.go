@@ -0,0 +1,619 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// Expression is a parsed issue predicate, evaluated against the fields of each fetched issue
+//
+// The language is deliberately small:
+//
+//	customfield_10016 > 8 and assignee.name == null
+//	status.name in ["To Do", "In Progress"] && not (labels contains "wontfix")
+//
+// Fields are the issue's JSON field names (key, summary, status.name, assignee.email, labels,
+// relationships.epic_link, custom_fields.customfield_10016, ...); customfield_NNNNN is shorthand
+// for custom_fields.customfield_NNNNN. A path through a list collects the values of every element.
+type Expression struct {
+	source string
+	root   node
+}
+
+// fieldNames maps the accepted top-level field names, lower-cased, to their JSON names
+var fieldNames = map[string]string{
+	"key":           "key",
+	"summary":       "summary",
+	"description":   "description",
+	"status":        "status",
+	"assignee":      "assignee",
+	"reporter":      "reporter",
+	"created":       "created",
+	"updated":       "updated",
+	"priority":      "priority",
+	"issuetype":     "issuetype",
+	"labels":        "labels",
+	"relationships": "relationships",
+	"externalrefs":  "externalRefs",
+	"coderefs":      "codeRefs",
+	"time_tracking": "time_tracking",
+	"worklogs":      "worklogs",
+	"custom_fields": "custom_fields",
+}
+
+var customFieldPattern = regexp.MustCompile(`^customfield_\d+$`)
+
+// Parse validates and compiles a filter expression
+// Syntax errors and unknown fields are reported with their position, so a typo fails before
+// the sync starts rather than silently filtering out every issue.
+func Parse(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{source: source, tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, fmt.Errorf("filter expression is empty")
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorAt(tok, "unexpected %q", tok.text)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the expression as written
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Match reports whether an issue satisfies the expression
+func (e *Expression) Match(issue *client.Issue) bool {
+	return truthy(e.root.eval(issueFields(issue)))
+}
+
+// issueFields converts an issue to the generic values the expression is evaluated against
+func issueFields(issue *client.Issue) map[string]interface{} {
+	fields := make(map[string]interface{})
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+// Lexer
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOperator
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// operators lists the symbolic operators, longest first; = is accepted as ==
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "="}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: start})
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]) && !followsOperand(tokens)):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i]), pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			var text strings.Builder
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				text.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("invalid filter expression at position %d: unterminated string", start+1)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokString, text: text.String(), pos: start})
+		case r == '(' || r == ')' || r == '[' || r == ']' || r == ',':
+			kinds := map[rune]tokenKind{'(': tokLParen, ')': tokRParen, '[': tokLBracket, ']': tokRBracket, ',': tokComma}
+			tokens = append(tokens, token{kind: kinds[r], text: string(r), pos: i})
+			i++
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, token{kind: tokOperator, text: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("invalid filter expression at position %d: unexpected character %q", i+1, r)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
+
+// followsOperand reports whether a '-' would be a binary minus, which the language does not have,
+// rather than the sign of a number
+func followsOperand(tokens []token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	switch tokens[len(tokens)-1].kind {
+	case tokNumber, tokString, tokRParen, tokRBracket:
+		return true
+	case tokIdent:
+		return !isKeyword(tokens[len(tokens)-1].text)
+	}
+	return false
+}
+
+// Parser
+
+var keywords = map[string]bool{"and": true, "or": true, "not": true, "contains": true, "in": true, "true": true, "false": true, "null": true}
+
+func isKeyword(text string) bool {
+	return keywords[strings.ToLower(text)]
+}
+
+type parser struct {
+	source string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isWord reports whether a token is the keyword or symbolic operator
+func isWord(tok token, keyword, symbol string) bool {
+	return (tok.kind == tokIdent && strings.EqualFold(tok.text, keyword)) || (tok.kind == tokOperator && tok.text == symbol)
+}
+
+func (p *parser) errorAt(tok token, format string, args ...interface{}) error {
+	if tok.kind == tokEOF {
+		return fmt.Errorf("invalid filter expression: unexpected end, %s", fmt.Sprintf(format, args...))
+	}
+	return fmt.Errorf("invalid filter expression at position %d: %s", tok.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isWord(p.peek(), "or", "||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{or: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for isWord(p.peek(), "and", "&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if isWord(p.peek(), "not", "!") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	var op string
+	negate := false
+	switch {
+	case tok.kind == tokOperator && tok.text != "!" && tok.text != "&&" && tok.text != "||":
+		op = tok.text
+		if op == "=" {
+			op = "=="
+		}
+	case tok.kind == tokIdent && (strings.EqualFold(tok.text, "contains") || strings.EqualFold(tok.text, "in")):
+		op = strings.ToLower(tok.text)
+	case tok.kind == tokIdent && strings.EqualFold(tok.text, "not") && p.pos+1 < len(p.tokens) &&
+		p.tokens[p.pos+1].kind == tokIdent && strings.EqualFold(p.tokens[p.pos+1].text, "in"):
+		p.next()
+		op, negate = "in", true
+	default:
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	var comparison node = &compareNode{op: op, left: left, right: right}
+	if negate {
+		comparison = &notNode{operand: comparison}
+	}
+	return comparison, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorAt(closing, "expected )")
+		}
+		return inner, nil
+	case tokLBracket:
+		list := &listNode{}
+		if p.peek().kind == tokRBracket {
+			p.next()
+			return list, nil
+		}
+		for {
+			item, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			list.items = append(list.items, item)
+			sep := p.next()
+			if sep.kind == tokRBracket {
+				return list, nil
+			}
+			if sep.kind != tokComma {
+				return nil, p.errorAt(sep, "expected , or ]")
+			}
+		}
+	case tokNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorAt(tok, "invalid number %q", tok.text)
+		}
+		return &literalNode{value: value}, nil
+	case tokString:
+		return &literalNode{value: tok.text}, nil
+	case tokIdent:
+		switch strings.ToLower(tok.text) {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if isKeyword(tok.text) {
+			return nil, p.errorAt(tok, "expected a value, found %q", tok.text)
+		}
+		return p.fieldNode(tok)
+	}
+	return nil, p.errorAt(tok, "expected a value, found %q", tok.text)
+}
+
+// fieldNode resolves a field reference, rejecting unknown fields
+func (p *parser) fieldNode(tok token) (node, error) {
+	path := strings.Split(tok.text, ".")
+	for _, segment := range path {
+		if segment == "" {
+			return nil, p.errorAt(tok, "invalid field %q", tok.text)
+		}
+	}
+
+	if customFieldPattern.MatchString(path[0]) {
+		return &fieldNode{path: append([]string{"custom_fields"}, path...)}, nil
+	}
+	root, ok := fieldNames[strings.ToLower(path[0])]
+	if !ok {
+		return nil, p.errorAt(tok, "unknown field %q (use issue field names such as summary, status.name, or customfield_10016)", path[0])
+	}
+	path[0] = root
+	return &fieldNode{path: path}, nil
+}
+
+// Evaluation
+
+type node interface {
+	eval(fields map[string]interface{}) interface{}
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(map[string]interface{}) interface{} {
+	return n.value
+}
+
+type listNode struct {
+	items []node
+}
+
+func (n *listNode) eval(fields map[string]interface{}) interface{} {
+	values := make([]interface{}, len(n.items))
+	for i, item := range n.items {
+		values[i] = item.eval(fields)
+	}
+	return values
+}
+
+type fieldNode struct {
+	path []string
+}
+
+func (n *fieldNode) eval(fields map[string]interface{}) interface{} {
+	return lookup(fields, n.path)
+}
+
+// lookup follows a field path; a path through a list collects the non-null values of its elements
+func lookup(value interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return lookup(v[path[0]], path[1:])
+	case []interface{}:
+		var values []interface{}
+		for _, element := range v {
+			if found := lookup(element, path); found != nil {
+				if list, ok := found.([]interface{}); ok {
+					values = append(values, list...)
+				} else {
+					values = append(values, found)
+				}
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(fields map[string]interface{}) interface{} {
+	return !truthy(n.operand.eval(fields))
+}
+
+type logicalNode struct {
+	or          bool
+	left, right node
+}
+
+func (n *logicalNode) eval(fields map[string]interface{}) interface{} {
+	left := truthy(n.left.eval(fields))
+	if left == n.or {
+		return left
+	}
+	return truthy(n.right.eval(fields))
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n *compareNode) eval(fields map[string]interface{}) interface{} {
+	left, right := n.left.eval(fields), n.right.eval(fields)
+	switch n.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	case "contains":
+		if text, ok := scalar(left).(string); ok {
+			sub, ok := scalar(right).(string)
+			return ok && strings.Contains(text, sub)
+		}
+		return anyEqual(left, right)
+	case "in":
+		if values, ok := left.([]interface{}); ok {
+			for _, value := range values {
+				if anyEqual(right, value) {
+					return true
+				}
+			}
+			return false
+		}
+		return anyEqual(right, left)
+	}
+
+	order, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	default:
+		return order >= 0
+	}
+}
+
+// scalar unwraps custom field options ({"value": "High", "id": "1"}) and named objects to
+// their value or name, so they compare like plain strings
+func scalar(value interface{}) interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		for _, key := range []string{"value", "name"} {
+			if inner, ok := object[key].(string); ok {
+				return inner
+			}
+		}
+	}
+	return value
+}
+
+// empty reports whether a value counts as null: missing, an empty string, or an empty list
+func empty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func equal(a, b interface{}) bool {
+	a, b = scalar(a), scalar(b)
+	if empty(a) || empty(b) {
+		return empty(a) && empty(b)
+	}
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return x == y
+		}
+	}
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		return ok && a == b
+	case bool:
+		b, ok := b.(bool)
+		return ok && a == b
+	}
+	return false
+}
+
+// anyEqual reports whether list holds an element equal to value
+func anyEqual(list, value interface{}) bool {
+	values, ok := list.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, element := range values {
+		if equal(element, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare orders two numbers, or two strings (ISO timestamps order correctly as strings)
+func compare(a, b interface{}) (int, bool) {
+	a, b = scalar(a), scalar(b)
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	x, ok1 := a.(string)
+	y, ok2 := b.(string)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return strings.Compare(x, y), true
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	}
+	return !empty(value)
+}
@@ -0,0 +1,97 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func testIssue() *client.Issue {
+	return &client.Issue{
+		Key:       "PROJ-1",
+		Summary:   "Fix login timeout",
+		Status:    client.Status{Name: "In Progress", Category: "indeterminate"},
+		Assignee:  client.User{},
+		Reporter:  client.User{Name: "Jane Doe", Email: "jane@example.com"},
+		Created:   "2024-01-10T09:00:00Z",
+		Priority:  "High",
+		IssueType: "Story",
+		Labels:    []string{"backend", "auth"},
+		Relationships: &client.Relationships{
+			EpicLink: "PROJ-100",
+			IssueLinks: []client.IssueLink{
+				{Type: "Blocks", Direction: "outward", IssueKey: "PROJ-2"},
+			},
+		},
+		CustomFields: map[string]interface{}{
+			"customfield_10016": 13.0,
+			"customfield_10020": map[string]interface{}{"value": "Team A", "id": "10001"},
+		},
+	}
+}
+
+func TestExpression_Match(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`customfield_10016 > 8 and assignee == null`, true},
+		{`customfield_10016 > 13`, false},
+		{`custom_fields.customfield_10016 >= 13`, true},
+		{`customfield_10020 == "Team A"`, true},
+		{`customfield_99999 == null`, true},
+		{`status.name in ["To Do", "In Progress"]`, true},
+		{`status.name not in ["To Do", "In Progress"]`, false},
+		{`labels contains "auth" && !(labels contains "wontfix")`, true},
+		{`labels in ['frontend', 'backend']`, true},
+		{`summary contains 'timeout' or priority = 'Low'`, true},
+		{`reporter.email != null AND issuetype == "Bug"`, false},
+		{`created >= "2024-01-01" and created < "2024-02-01"`, true},
+		{`relationships.issue_links.type contains "Blocks"`, true},
+		{`relationships.epic_link == "PROJ-100"`, true},
+		{`relationships.subtasks == null`, true},
+		{`not labels`, false},
+		{`customfield_10016 > -1`, true},
+	}
+
+	issue := testIssue()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := expr.Match(issue); got != tt.want {
+				t.Errorf("Match() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{``, "empty"},
+		{`assigne == null`, `unknown field "assigne"`},
+		{`summary ==`, "unexpected end"},
+		{`(status.name == "Done"`, "expected )"},
+		{`summary == "open`, "unterminated string"},
+		{`priority == High`, `unknown field "High"`},
+		{`priority == "High" Low`, `position 20: unexpected "Low"`},
+		{`labels in ["a" "b"]`, "expected , or ]"},
+		{`summary ~ "x"`, "unexpected character"},
+		{`and`, "expected a value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %v, want mention of %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
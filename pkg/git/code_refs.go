@@ -0,0 +1,95 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// smartCommitKey matches the issue keys referenced by a commit message ("PROJ-123 #close")
+var smartCommitKey = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[0-9]+\b`)
+
+// ScanCodeRefs indexes the commits of a local repository by the issue keys their messages
+// reference, newest first. The log starts at ref (HEAD when empty) and stops after maxCommits
+// commits (0 scans the whole history). The repository is only read, never modified.
+func ScanCodeRefs(repoPath, ref string, maxCommits int) (map[string][]client.CodeRef, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, &GitError{
+			Type:    "repository_not_found",
+			Message: "failed to open Git repository",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	if ref == "" {
+		ref = "HEAD"
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, &GitError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("failed to resolve revision: %s", ref),
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	commits, err := repo.Log(&git.LogOptions{From: *hash, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to read commit log",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+	defer commits.Close()
+
+	index := make(map[string][]client.CodeRef)
+	scanned := 0
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if maxCommits > 0 && scanned >= maxCommits {
+			return storer.ErrStop
+		}
+		scanned++
+
+		seen := make(map[string]bool)
+		for _, key := range smartCommitKey.FindAllString(commit.Message, -1) {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			index[key] = append(index[key], client.CodeRef{
+				SHA:     commit.Hash.String(),
+				Subject: commitSubject(commit.Message),
+				Date:    commit.Committer.When.UTC().Format(time.RFC3339),
+			})
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, storer.ErrStop) {
+		return nil, &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to read commit log",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+	return index, nil
+}
+
+// commitSubject returns the first line of a commit message
+func commitSubject(message string) string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(subject)
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestScanCodeRefs(t *testing.T) {
+	repoPath := t.TempDir()
+	r, err := git.PlainInit(repoPath, false)
+	if err != nil {
+		t.Fatalf("Failed to initialize repository: %v", err)
+	}
+	worktree, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to open worktree: %v", err)
+	}
+
+	start := time.Date(2024, 3, 15, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	for i, message := range []string{
+		"PROJ-1 initial import",
+		"Fix parser\n\nRefs OTHER-7",
+		"PROJ-1 #comment PROJ-1 again; closes PROJ-2 #resolve",
+		"chore: bump deps (not an issue: utf-8, proj-3)",
+	} {
+		signature := &object.Signature{Name: "Dev", Email: "dev@example.com", When: start.Add(time.Duration(i) * time.Hour)}
+		if _, err := worktree.Commit(message, &git.CommitOptions{Author: signature, Committer: signature, AllowEmptyCommits: true}); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+	}
+
+	index, err := ScanCodeRefs(repoPath, "", 0)
+	if err != nil {
+		t.Fatalf("ScanCodeRefs() error = %v", err)
+	}
+	if len(index) != 3 {
+		t.Fatalf("Expected PROJ-1, PROJ-2 and OTHER-7, got %v", index)
+	}
+	proj1 := index["PROJ-1"]
+	if len(proj1) != 2 {
+		t.Fatalf("Expected one ref per commit for PROJ-1, got %+v", proj1)
+	}
+	if proj1[0].Subject != "PROJ-1 #comment PROJ-1 again; closes PROJ-2 #resolve" || proj1[1].Subject != "PROJ-1 initial import" {
+		t.Errorf("Expected newest commit first, got %+v", proj1)
+	}
+	if proj1[1].Date != "2024-03-15T08:00:00Z" || len(proj1[1].SHA) != 40 {
+		t.Errorf("Expected SHA and UTC date, got %+v", proj1[1])
+	}
+	if refs := index["OTHER-7"]; len(refs) != 1 || refs[0].Subject != "Fix parser" {
+		t.Errorf("Expected key in commit body with subject line, got %+v", refs)
+	}
+
+	// Only the newest commits are scanned
+	limited, err := ScanCodeRefs(repoPath, "HEAD", 2)
+	if err != nil {
+		t.Fatalf("ScanCodeRefs() error = %v", err)
+	}
+	if len(limited["PROJ-1"]) != 1 || limited["OTHER-7"] != nil {
+		t.Errorf("Expected only the two newest commits to be scanned, got %v", limited)
+	}
+
+	if _, err := ScanCodeRefs(repoPath, "no-such-branch", 0); err == nil {
+		t.Error("Expected error for an unknown ref")
+	}
+	if _, err := ScanCodeRefs(t.TempDir(), "", 0); err == nil {
+		t.Error("Expected error for a directory without a repository")
+	}
+}
@@ -0,0 +1,499 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// DirtyPolicy controls what a sync does when the target repository has uncommitted changes
+type DirtyPolicy string
+
+const (
+	// DirtyPolicyFail refuses to sync into a dirty repository (default)
+	DirtyPolicyFail DirtyPolicy = "fail"
+
+	// DirtyPolicyStash shelves local changes before the sync and restores them afterwards
+	DirtyPolicyStash DirtyPolicy = "stash"
+
+	// DirtyPolicyCommit commits local changes in a separate commit before the sync
+	DirtyPolicyCommit DirtyPolicy = "commit"
+
+	// DirtyPolicyIgnore syncs on top of local changes. Staged changes are swept into the
+	// first sync commit and local edits to issue files are overwritten, so only use it
+	// when the local changes are known to be unrelated and unstaged.
+	DirtyPolicyIgnore DirtyPolicy = "ignore"
+)
+
+// DefaultDirtyCommitMessage is used when local changes are committed before a sync
+const DefaultDirtyCommitMessage = "chore: commit local changes before JIRA sync"
+
+// ParseDirtyPolicy parses a --on-dirty value (empty means fail)
+func ParseDirtyPolicy(value string) (DirtyPolicy, error) {
+	switch policy := DirtyPolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return DirtyPolicyFail, nil
+	case DirtyPolicyFail, DirtyPolicyStash, DirtyPolicyCommit, DirtyPolicyIgnore:
+		return policy, nil
+	default:
+		return "", &GitError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("invalid dirty working tree policy '%s': must be one of fail, stash, commit, ignore", value),
+		}
+	}
+}
+
+// Stash records local changes shelved outside the repository during a sync
+// Saved copies live in Dir; restored changes come back unstaged.
+type Stash struct {
+	Dir        string        // temporary directory holding saved file contents
+	BaseCommit string        // HEAD at the time the changes were stashed
+	Files      []StashedFile // shelved paths, relative to the repository root
+}
+
+// StashedFile describes one shelved path
+type StashedFile struct {
+	Path       string
+	Tracked    bool   // path exists in BaseCommit
+	Removed    bool   // path was deleted locally
+	LinkTarget string // set when the local file is a symbolic link
+}
+
+// PreparedWorkingTree is the outcome of applying a DirtyPolicy before a sync
+type PreparedWorkingTree struct {
+	Policy     DirtyPolicy
+	DirtyFiles []string // local changes found before the sync (empty when clean)
+	Stash      *Stash   // set when changes were stashed
+
+	repo     Repository
+	repoPath string
+}
+
+// PrepareWorkingTree validates the working tree and applies the policy to local changes
+// Callers must call Restore once the sync has finished, including when it failed.
+func PrepareWorkingTree(repo Repository, repoPath string, policy DirtyPolicy) (*PreparedWorkingTree, error) {
+	prepared := &PreparedWorkingTree{Policy: policy, repo: repo, repoPath: repoPath}
+
+	err := repo.ValidateWorkingTree(repoPath)
+	if err == nil {
+		return prepared, nil
+	}
+	if !IsDirtyWorkingTreeError(err) {
+		return nil, err
+	}
+
+	if status, statusErr := repo.GetRepositoryStatus(repoPath); statusErr == nil {
+		prepared.DirtyFiles = changedFiles(status)
+	}
+
+	switch policy {
+	case DirtyPolicyIgnore:
+		return prepared, nil
+	case DirtyPolicyCommit:
+		if err := repo.CommitAllChanges(repoPath, DefaultDirtyCommitMessage); err != nil {
+			return nil, fmt.Errorf("failed to commit local changes: %w", err)
+		}
+		return prepared, nil
+	case DirtyPolicyStash:
+		stash, err := repo.StashChanges(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stash local changes: %w", err)
+		}
+		prepared.Stash = stash
+		return prepared, nil
+	default:
+		return nil, err
+	}
+}
+
+// Restore puts stashed changes back after the sync (no-op for other policies)
+func (p *PreparedWorkingTree) Restore() error {
+	if p == nil || p.Stash == nil {
+		return nil
+	}
+	err := p.repo.RestoreStash(p.repoPath, p.Stash)
+	p.Stash = nil
+	return err
+}
+
+// StashChanges shelves every local change and resets the working tree and index to HEAD
+// go-git has no stash support, so changed files are copied to a temporary directory.
+func (g *GitRepository) StashChanges(repoPath string) (*Stash, error) {
+	repo, worktree, err := openWorktree(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to get repository status",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+	if status.IsClean() {
+		return nil, nil
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, &GitError{
+			Type:    "no_commits",
+			Message: "cannot stash changes in a repository without commits",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+	baseTree, err := commitTree(repo, head.Hash())
+	if err != nil {
+		return nil, &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to read HEAD tree",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "jira-sync-stash-")
+	if err != nil {
+		return nil, &GitError{
+			Type:    "filesystem_error",
+			Message: "failed to create stash directory",
+			Err:     err,
+		}
+	}
+
+	stash := &Stash{Dir: dir, BaseCommit: head.Hash().String()}
+	paths := make([]string, 0, len(status))
+	for path := range status {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		fileStatus := status[path]
+		if fileStatus.Staging == git.Unmodified && fileStatus.Worktree == git.Unmodified {
+			continue
+		}
+		stashed, err := saveFile(repoPath, dir, path)
+		if err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, err
+		}
+		_, stashed.Tracked = treeEntryHash(baseTree, path)
+		stash.Files = append(stash.Files, stashed)
+	}
+
+	if err := worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.HardReset}); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to reset working tree",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	// A hard reset leaves untracked files behind
+	for _, file := range stash.Files {
+		if !file.Tracked && !file.Removed {
+			if err := os.Remove(filepath.Join(repoPath, file.Path)); err != nil && !os.IsNotExist(err) {
+				return stash, &GitError{
+					Type:    "filesystem_error",
+					Message: fmt.Sprintf("failed to remove stashed file (saved copy kept in %s)", dir),
+					Err:     err,
+					Context: file.Path,
+				}
+			}
+		}
+	}
+
+	return stash, nil
+}
+
+// RestoreStash puts shelved changes back into the working tree
+// Paths the sync also changed are left as synced and reported in a stash_conflict error;
+// their saved copies stay in the stash directory so nothing is lost.
+func (g *GitRepository) RestoreStash(repoPath string, stash *Stash) error {
+	if stash == nil {
+		return nil
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return &GitError{
+			Type:    "repository_not_found",
+			Message: "failed to open Git repository",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to resolve HEAD",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+	baseTree, err := commitTree(repo, plumbing.NewHash(stash.BaseCommit))
+	if err != nil {
+		return &GitError{
+			Type:    "git_operation_error",
+			Message: fmt.Sprintf("failed to read stash base commit (saved copies kept in %s)", stash.Dir),
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+	headTree, err := commitTree(repo, head.Hash())
+	if err != nil {
+		return &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to read HEAD tree",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	var conflicts []string
+	for _, file := range stash.Files {
+		target := filepath.Join(repoPath, file.Path)
+		baseHash, inBase := treeEntryHash(baseTree, file.Path)
+		headHash, inHead := treeEntryHash(headTree, file.Path)
+		if inBase != inHead || baseHash != headHash {
+			conflicts = append(conflicts, file.Path)
+			continue
+		}
+		if !file.Tracked {
+			if _, err := os.Lstat(target); err == nil {
+				conflicts = append(conflicts, file.Path)
+				continue
+			}
+		}
+
+		if err := restoreFile(stash.Dir, repoPath, file); err != nil {
+			return err
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &GitError{
+			Type: "stash_conflict",
+			Message: fmt.Sprintf("%d stashed file(s) were also changed by the sync and were not restored: %s (saved copies kept in %s)",
+				len(conflicts), strings.Join(conflicts, ", "), stash.Dir),
+			Context: repoPath,
+		}
+	}
+
+	if err := os.RemoveAll(stash.Dir); err != nil {
+		return &GitError{
+			Type:    "filesystem_error",
+			Message: "failed to remove stash directory",
+			Err:     err,
+			Context: stash.Dir,
+		}
+	}
+
+	return nil
+}
+
+// CommitAllChanges stages every local change (including deletions) and commits it
+func (g *GitRepository) CommitAllChanges(repoPath, message string) error {
+	return g.withRetry(func() error {
+		return g.commitAllChanges(repoPath, message)
+	})
+}
+
+func (g *GitRepository) commitAllChanges(repoPath, message string) error {
+	_, worktree, err := openWorktree(repoPath)
+	if err != nil {
+		return err
+	}
+
+	if err := worktree.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to stage local changes",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	if message == "" {
+		message = DefaultDirtyCommitMessage
+	}
+
+	_, err = worktree.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  g.AuthorName,
+			Email: g.AuthorEmail,
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		return &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to commit local changes",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	return nil
+}
+
+// IsStashConflictError checks if stashed changes could not be restored because the sync changed the same paths
+func IsStashConflictError(err error) bool {
+	if gitErr, ok := err.(*GitError); ok {
+		return gitErr.Type == "stash_conflict"
+	}
+	return false
+}
+
+// changedFiles lists every path reported in a repository status
+func changedFiles(status *RepositoryStatus) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, group := range [][]string{status.StagedFiles, status.ModifiedFiles, status.UntrackedFiles} {
+		for _, file := range group {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// openWorktree opens a repository and its working tree
+func openWorktree(repoPath string) (*git.Repository, *git.Worktree, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, nil, &GitError{
+			Type:    "repository_not_found",
+			Message: "failed to open Git repository",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, nil, &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to get working tree",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	return repo, worktree, nil
+}
+
+// commitTree returns the tree of a commit
+func commitTree(repo *git.Repository, hash plumbing.Hash) (*object.Tree, error) {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+// treeEntryHash returns the blob hash of a path in a tree
+func treeEntryHash(tree *object.Tree, path string) (plumbing.Hash, bool) {
+	entry, err := tree.FindEntry(filepath.ToSlash(path))
+	if err != nil {
+		return plumbing.ZeroHash, false
+	}
+	return entry.Hash, true
+}
+
+// saveFile copies a changed path into the stash directory
+func saveFile(repoPath, stashDir, path string) (StashedFile, error) {
+	stashed := StashedFile{Path: path}
+	source := filepath.Join(repoPath, path)
+
+	info, err := os.Lstat(source)
+	if os.IsNotExist(err) {
+		stashed.Removed = true
+		return stashed, nil
+	}
+	if err != nil {
+		return stashed, &GitError{Type: "filesystem_error", Message: "failed to stat changed file", Err: err, Context: path}
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		stashed.LinkTarget, err = os.Readlink(source)
+		if err != nil {
+			return stashed, &GitError{Type: "filesystem_error", Message: "failed to read symbolic link", Err: err, Context: path}
+		}
+		return stashed, nil
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return stashed, &GitError{Type: "filesystem_error", Message: "failed to read changed file", Err: err, Context: path}
+	}
+	destination := filepath.Join(stashDir, path)
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return stashed, &GitError{Type: "filesystem_error", Message: "failed to create stash directory", Err: err, Context: path}
+	}
+	if err := os.WriteFile(destination, data, info.Mode().Perm()); err != nil {
+		return stashed, &GitError{Type: "filesystem_error", Message: "failed to save changed file", Err: err, Context: path}
+	}
+
+	return stashed, nil
+}
+
+// restoreFile writes a shelved path back into the working tree
+func restoreFile(stashDir, repoPath string, file StashedFile) error {
+	target := filepath.Join(repoPath, file.Path)
+
+	if file.Removed {
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return &GitError{Type: "filesystem_error", Message: "failed to restore deletion", Err: err, Context: file.Path}
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return &GitError{Type: "filesystem_error", Message: "failed to create directory", Err: err, Context: file.Path}
+	}
+
+	if file.LinkTarget != "" {
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return &GitError{Type: "filesystem_error", Message: "failed to replace symbolic link", Err: err, Context: file.Path}
+		}
+		if err := os.Symlink(file.LinkTarget, target); err != nil {
+			return &GitError{Type: "filesystem_error", Message: "failed to restore symbolic link", Err: err, Context: file.Path}
+		}
+		return nil
+	}
+
+	source := filepath.Join(stashDir, file.Path)
+	info, err := os.Stat(source)
+	if err != nil {
+		return &GitError{Type: "filesystem_error", Message: "stashed copy is missing", Err: err, Context: file.Path}
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return &GitError{Type: "filesystem_error", Message: "failed to read stashed copy", Err: err, Context: file.Path}
+	}
+	if err := os.WriteFile(target, data, info.Mode().Perm()); err != nil {
+		return &GitError{Type: "filesystem_error", Message: "failed to restore file", Err: err, Context: file.Path}
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// setupDirtyRepository creates a repository with one committed issue file plus local changes
+func setupDirtyRepository(t *testing.T) (Repository, string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	repo := NewGitRepository("Test User", "test@example.com")
+	if err := repo.Initialize(tempDir); err != nil {
+		t.Fatalf("Failed to initialize repository: %v", err)
+	}
+
+	issueFile := filepath.Join(tempDir, "projects", "PROJ", "issues", "PROJ-1.yaml")
+	if err := os.MkdirAll(filepath.Dir(issueFile), 0755); err != nil {
+		t.Fatalf("Failed to create issue directory: %v", err)
+	}
+	if err := os.WriteFile(issueFile, []byte("key: PROJ-1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write issue file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("original\n"), 0644); err != nil {
+		t.Fatalf("Failed to write README: %v", err)
+	}
+	if err := repo.CommitAllChanges(tempDir, "initial"); err != nil {
+		t.Fatalf("Failed to create initial commit: %v", err)
+	}
+
+	// Unrelated local edits
+	if err := os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("local edit\n"), 0644); err != nil {
+		t.Fatalf("Failed to edit README: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "notes.txt"), []byte("scratch\n"), 0644); err != nil {
+		t.Fatalf("Failed to write notes: %v", err)
+	}
+
+	return repo, tempDir
+}
+
+func TestParseDirtyPolicy(t *testing.T) {
+	tests := map[string]DirtyPolicy{
+		"":       DirtyPolicyFail,
+		"fail":   DirtyPolicyFail,
+		"Stash":  DirtyPolicyStash,
+		"commit": DirtyPolicyCommit,
+		"ignore": DirtyPolicyIgnore,
+	}
+	for input, expected := range tests {
+		policy, err := ParseDirtyPolicy(input)
+		if err != nil || policy != expected {
+			t.Errorf("ParseDirtyPolicy(%q) = %q, %v; want %q", input, policy, err, expected)
+		}
+	}
+
+	if _, err := ParseDirtyPolicy("discard"); !IsInvalidInputError(err) {
+		t.Errorf("Expected invalid_input error for unknown policy, got %v", err)
+	}
+}
+
+func TestGitRepository_Integration_StashAndRestore(t *testing.T) {
+	repo, tempDir := setupDirtyRepository(t)
+
+	prepared, err := PrepareWorkingTree(repo, tempDir, DirtyPolicyStash)
+	if err != nil {
+		t.Fatalf("PrepareWorkingTree() error = %v", err)
+	}
+	if len(prepared.DirtyFiles) != 2 {
+		t.Errorf("Expected 2 dirty files, got %v", prepared.DirtyFiles)
+	}
+	if err := repo.ValidateWorkingTree(tempDir); err != nil {
+		t.Fatalf("Expected clean working tree after stash, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "notes.txt")); !os.IsNotExist(err) {
+		t.Error("Expected untracked file to be shelved")
+	}
+
+	// Simulate the sync committing an issue file
+	issueFile := filepath.Join(tempDir, "projects", "PROJ", "issues", "PROJ-1.yaml")
+	if err := os.WriteFile(issueFile, []byte("key: PROJ-1\nsummary: synced\n"), 0644); err != nil {
+		t.Fatalf("Failed to update issue file: %v", err)
+	}
+	if err := repo.CommitIssueFile(tempDir, issueFile, &client.Issue{Key: "PROJ-1", Summary: "synced"}); err != nil {
+		t.Fatalf("Failed to commit issue file: %v", err)
+	}
+
+	stashDir := prepared.Stash.Dir
+	if err := prepared.Restore(); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	readme, _ := os.ReadFile(filepath.Join(tempDir, "README.md"))
+	notes, _ := os.ReadFile(filepath.Join(tempDir, "notes.txt"))
+	if string(readme) != "local edit\n" || string(notes) != "scratch\n" {
+		t.Errorf("Local changes not restored: README=%q notes=%q", readme, notes)
+	}
+	if _, err := os.Stat(stashDir); !os.IsNotExist(err) {
+		t.Error("Expected stash directory to be removed after a clean restore")
+	}
+}
+
+func TestGitRepository_Integration_StashConflict(t *testing.T) {
+	repo, tempDir := setupDirtyRepository(t)
+	issueFile := filepath.Join(tempDir, "projects", "PROJ", "issues", "PROJ-1.yaml")
+	if err := os.WriteFile(issueFile, []byte("key: PROJ-1\nlocal: edit\n"), 0644); err != nil {
+		t.Fatalf("Failed to edit issue file: %v", err)
+	}
+
+	stash, err := repo.StashChanges(tempDir)
+	if err != nil {
+		t.Fatalf("StashChanges() error = %v", err)
+	}
+	defer func() { _ = os.RemoveAll(stash.Dir) }()
+
+	if err := os.WriteFile(issueFile, []byte("key: PROJ-1\nsummary: synced\n"), 0644); err != nil {
+		t.Fatalf("Failed to update issue file: %v", err)
+	}
+	if err := repo.CommitIssueFile(tempDir, issueFile, &client.Issue{Key: "PROJ-1"}); err != nil {
+		t.Fatalf("Failed to commit issue file: %v", err)
+	}
+
+	err = repo.RestoreStash(tempDir, stash)
+	if !IsStashConflictError(err) {
+		t.Fatalf("Expected stash_conflict error, got %v", err)
+	}
+
+	// The synced content wins; the local edit survives in the stash directory
+	synced, _ := os.ReadFile(issueFile)
+	if string(synced) != "key: PROJ-1\nsummary: synced\n" {
+		t.Errorf("Expected synced content to be kept, got %q", synced)
+	}
+	saved, _ := os.ReadFile(filepath.Join(stash.Dir, "projects", "PROJ", "issues", "PROJ-1.yaml"))
+	if string(saved) != "key: PROJ-1\nlocal: edit\n" {
+		t.Errorf("Expected local edit in stash directory, got %q", saved)
+	}
+	readme, _ := os.ReadFile(filepath.Join(tempDir, "README.md"))
+	if string(readme) != "local edit\n" {
+		t.Errorf("Expected non-conflicting change to be restored, got %q", readme)
+	}
+}
+
+func TestGitRepository_Integration_CommitPolicy(t *testing.T) {
+	repo, tempDir := setupDirtyRepository(t)
+	if err := os.Remove(filepath.Join(tempDir, "README.md")); err != nil {
+		t.Fatalf("Failed to delete README: %v", err)
+	}
+
+	if _, err := PrepareWorkingTree(repo, tempDir, DirtyPolicyCommit); err != nil {
+		t.Fatalf("PrepareWorkingTree() error = %v", err)
+	}
+	if err := repo.ValidateWorkingTree(tempDir); err != nil {
+		t.Errorf("Expected clean working tree after committing local changes, got %v", err)
+	}
+}
+
+func TestPrepareWorkingTree_Policies(t *testing.T) {
+	dirty := func() *MockRepository {
+		mock := NewMockRepository()
+		mock.SetRepositoryAsInitialized("/repo", false)
+		mock.RepositoryStatuses["/repo"].ModifiedFiles = []string{"README.md"}
+		return mock
+	}
+
+	if _, err := PrepareWorkingTree(dirty(), "/repo", DirtyPolicyFail); !IsDirtyWorkingTreeError(err) {
+		t.Errorf("fail policy: expected dirty_working_tree error, got %v", err)
+	}
+
+	mock := dirty()
+	prepared, err := PrepareWorkingTree(mock, "/repo", DirtyPolicyIgnore)
+	if err != nil || len(prepared.DirtyFiles) != 1 || prepared.Stash != nil {
+		t.Errorf("ignore policy: unexpected result %+v, %v", prepared, err)
+	}
+
+	mock = dirty()
+	if _, err := PrepareWorkingTree(mock, "/repo", DirtyPolicyCommit); err != nil {
+		t.Fatalf("commit policy: unexpected error %v", err)
+	}
+	if len(mock.LocalCommits["/repo"]) != 1 || mock.LocalCommits["/repo"][0] != DefaultDirtyCommitMessage {
+		t.Errorf("commit policy: expected one local commit, got %v", mock.LocalCommits["/repo"])
+	}
+
+	mock = dirty()
+	prepared, err = PrepareWorkingTree(mock, "/repo", DirtyPolicyStash)
+	if err != nil || prepared.Stash == nil {
+		t.Fatalf("stash policy: unexpected result %+v, %v", prepared, err)
+	}
+	if err := mock.ValidateWorkingTree("/repo"); err != nil {
+		t.Errorf("stash policy: expected clean tree during sync, got %v", err)
+	}
+	if err := prepared.Restore(); err != nil {
+		t.Errorf("stash policy: restore failed: %v", err)
+	}
+	if mock.RepositoryStatuses["/repo"].IsClean {
+		t.Error("stash policy: expected local changes back after restore")
+	}
+
+	// Clean repositories are left alone regardless of policy
+	clean := NewMockRepository()
+	clean.SetRepositoryAsInitialized("/repo", true)
+	prepared, err = PrepareWorkingTree(clean, "/repo", DirtyPolicyStash)
+	if err != nil || prepared.Stash != nil || len(prepared.DirtyFiles) != 0 {
+		t.Errorf("clean repository: unexpected result %+v, %v", prepared, err)
+	}
+}
+
+func TestGitRepository_CommitFiles_OnlyListedPaths(t *testing.T) {
+	repo, tempDir := setupDirtyRepository(t)
+
+	indexFile := filepath.Join(tempDir, "projects", "PROJ", "index.yaml")
+	if err := os.WriteFile(indexFile, []byte("project: PROJ\n"), 0644); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+
+	if err := repo.CommitFiles(tempDir, "docs(index): update issue index for PROJ", indexFile); err != nil {
+		t.Fatalf("CommitFiles() error = %v", err)
+	}
+
+	status, err := repo.GetRepositoryStatus(tempDir)
+	if err != nil {
+		t.Fatalf("GetRepositoryStatus() error = %v", err)
+	}
+	for _, file := range append(status.ModifiedFiles, status.UntrackedFiles...) {
+		if file == "projects/PROJ/index.yaml" {
+			t.Error("Expected index file to be committed")
+		}
+	}
+	if status.IsClean {
+		t.Error("Expected unrelated local edits to stay uncommitted")
+	}
+
+	// Committing an unchanged path is a no-op
+	if err := repo.CommitFiles(tempDir, "noop", indexFile); err != nil {
+		t.Errorf("CommitFiles() with nothing staged error = %v", err)
+	}
+}
+
+func TestGitRepository_CommitFiles_RemovedPaths(t *testing.T) {
+	repo, tempDir := setupDirtyRepository(t)
+
+	indexFile := filepath.Join(tempDir, "projects", "PROJ", "index.yaml")
+	if err := os.WriteFile(indexFile, []byte("project: PROJ\n"), 0644); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+	if err := repo.CommitFiles(tempDir, "docs(index): add index", indexFile); err != nil {
+		t.Fatalf("CommitFiles() error = %v", err)
+	}
+
+	// A removed tracked file is committed as a removal; a path Git never knew is skipped
+	if err := os.Remove(indexFile); err != nil {
+		t.Fatal(err)
+	}
+	neverTracked := filepath.Join(tempDir, "projects", "PROJ", "gone.yaml")
+	if err := repo.CommitFiles(tempDir, "docs(index): remove index", indexFile, neverTracked); err != nil {
+		t.Fatalf("CommitFiles() error = %v", err)
+	}
+	if message := headCommitMessage(t, tempDir); message != "docs(index): remove index" {
+		t.Errorf("Expected the removal to be committed, HEAD is %q", message)
+	}
+}
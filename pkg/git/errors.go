@@ -37,6 +37,14 @@ func IsDirtyWorkingTreeError(err error) bool {
 	return false
 }
 
+// IsNoCommitsError checks if the error is caused by a repository without any commits
+func IsNoCommitsError(err error) bool {
+	if gitErr, ok := err.(*GitError); ok {
+		return gitErr.Type == "no_commits"
+	}
+	return false
+}
+
 // IsGitOperationError checks if the error is related to Git operations
 func IsGitOperationError(err error) bool {
 	if gitErr, ok := err.(*GitError); ok {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/chambrid/jira-cdc-git/pkg/client"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
@@ -31,6 +32,21 @@ type Repository interface {
 
 	// GetRepositoryStatus returns the current status of the repository
 	GetRepositoryStatus(repoPath string) (*RepositoryStatus, error)
+
+	// GetCommitTime returns the committer time of a revision (HEAD when ref is empty)
+	GetCommitTime(repoPath, ref string) (time.Time, error)
+
+	// StashChanges shelves local changes and resets the working tree to HEAD (nil when clean)
+	StashChanges(repoPath string) (*Stash, error)
+
+	// RestoreStash puts changes shelved by StashChanges back into the working tree
+	RestoreStash(repoPath string, stash *Stash) error
+
+	// CommitAllChanges stages and commits every local change
+	CommitAllChanges(repoPath, message string) error
+
+	// CommitFiles stages and commits the given files (no commit when none of them changed)
+	CommitFiles(repoPath, message string, filePaths ...string) error
 }
 
 // GitRepository implements Repository using go-git library
@@ -38,6 +54,15 @@ type GitRepository struct {
 	// Author information for commits
 	AuthorName  string
 	AuthorEmail string
+
+	// Trailers are appended to sync commit messages (see NewGitRepositoryWithTrailers)
+	Trailers []CommitTrailer
+
+	// Retry retries init and commits that fail transiently; the zero value never retries
+	Retry RetryPolicy
+
+	// sleep waits between retries; nil uses time.Sleep
+	sleep func(time.Duration)
 }
 
 // RepositoryStatus represents the current status of a Git repository
@@ -81,7 +106,10 @@ func (g *GitRepository) Initialize(repoPath string) error {
 	}
 
 	// Initialize Git repository
-	_, err := git.PlainInit(repoPath, false)
+	err := g.withRetry(func() error {
+		_, err := git.PlainInit(repoPath, false)
+		return err
+	})
 	if err != nil {
 		return &GitError{
 			Type:    "git_operation_error",
@@ -210,8 +238,70 @@ func (g *GitRepository) GetRepositoryStatus(repoPath string) (*RepositoryStatus,
 	return repoStatus, nil
 }
 
+// GetCommitTime returns the committer time of a revision (HEAD when ref is empty)
+// Repositories without any commits return a no_commits error so callers can fall back to a full sync.
+func (g *GitRepository) GetCommitTime(repoPath, ref string) (time.Time, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return time.Time{}, &GitError{
+			Type:    "repository_not_found",
+			Message: "failed to open Git repository",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return time.Time{}, &GitError{
+				Type:    "no_commits",
+				Message: "repository has no commits",
+				Context: repoPath,
+			}
+		}
+		return time.Time{}, &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to resolve HEAD",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+	if ref == "" {
+		ref = head.Hash().String()
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return time.Time{}, &GitError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("failed to resolve revision: %s", ref),
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return time.Time{}, &GitError{
+			Type:    "git_operation_error",
+			Message: fmt.Sprintf("failed to read commit: %s", ref),
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	return commit.Committer.When, nil
+}
+
 // CommitIssueFile adds and commits a YAML issue file with conventional commit message
 func (g *GitRepository) CommitIssueFile(repoPath, filePath string, issue *client.Issue) error {
+	return g.withRetry(func() error {
+		return g.commitIssueFile(repoPath, filePath, issue)
+	})
+}
+
+func (g *GitRepository) commitIssueFile(repoPath, filePath string, issue *client.Issue) error {
 	if issue == nil || issue.Key == "" {
 		return &GitError{
 			Type:    "invalid_input",
@@ -262,7 +352,7 @@ func (g *GitRepository) CommitIssueFile(repoPath, filePath string, issue *client
 	}
 
 	// Create conventional commit message
-	commitMessage := g.formatConventionalCommitMessage(issue)
+	commitMessage := g.withTrailers(g.formatConventionalCommitMessage(issue))
 
 	// Create commit
 	commit := &git.CommitOptions{
@@ -286,6 +376,97 @@ func (g *GitRepository) CommitIssueFile(repoPath, filePath string, issue *client
 	return nil
 }
 
+// CommitFiles stages the given files and commits them with the provided message
+// Only the listed paths are staged, so unrelated local changes stay out of the commit. Deleted
+// files are staged as removals; paths that neither exist nor are tracked are skipped.
+// Returns nil without committing when none of the files differ from HEAD.
+func (g *GitRepository) CommitFiles(repoPath, message string, filePaths ...string) error {
+	return g.withRetry(func() error {
+		return g.commitFiles(repoPath, message, filePaths...)
+	})
+}
+
+func (g *GitRepository) commitFiles(repoPath, message string, filePaths ...string) error {
+	repo, worktree, err := openWorktree(repoPath)
+	if err != nil {
+		return err
+	}
+	index, err := repo.Storer.Index()
+	if err != nil {
+		return &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to read index",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	relativePaths := make([]string, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		relativeFilePath, err := filepath.Rel(repoPath, filePath)
+		if err != nil {
+			return &GitError{
+				Type:    "filesystem_error",
+				Message: "failed to convert file path to relative path",
+				Err:     err,
+				Context: filePath,
+			}
+		}
+		if _, err := os.Lstat(filePath); os.IsNotExist(err) {
+			if _, err := index.Entry(filepath.ToSlash(relativeFilePath)); err != nil {
+				continue
+			}
+		}
+		if _, err := worktree.Add(relativeFilePath); err != nil {
+			return &GitError{
+				Type:    "git_operation_error",
+				Message: fmt.Sprintf("failed to add file to staging area: %s", relativeFilePath),
+				Err:     err,
+				Context: repoPath,
+			}
+		}
+		relativePaths = append(relativePaths, filepath.ToSlash(relativeFilePath))
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to get repository status",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+	staged := false
+	for _, relativePath := range relativePaths {
+		if fileStatus, exists := status[relativePath]; exists && fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked {
+			staged = true
+			break
+		}
+	}
+	if !staged {
+		return nil
+	}
+
+	_, err = worktree.Commit(g.withTrailers(message), &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  g.AuthorName,
+			Email: g.AuthorEmail,
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		return &GitError{
+			Type:    "git_operation_error",
+			Message: "failed to create commit",
+			Err:     err,
+			Context: repoPath,
+		}
+	}
+
+	return nil
+}
+
 // formatConventionalCommitMessage creates a conventional commit message for an issue
 // Format: feat(PROJ): add issue PROJ-123 - Summary
 //
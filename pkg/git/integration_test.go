@@ -331,3 +331,52 @@ func containsAt(s, substr string, start int) bool {
 	}
 	return containsAt(s, substr, start+1)
 }
+
+func TestGitRepository_Integration_GetCommitTime(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := NewGitRepository("Test User", "test@example.com")
+
+	if err := repo.Initialize(tempDir); err != nil {
+		t.Fatalf("Failed to initialize repository: %v", err)
+	}
+
+	// Empty repository has no commit to measure from
+	_, err := repo.GetCommitTime(tempDir, "")
+	if !IsNoCommitsError(err) {
+		t.Fatalf("Expected no_commits error for empty repository, got %v", err)
+	}
+
+	before := time.Now().Add(-time.Second)
+	testFile := filepath.Join(tempDir, "TEST-1.yaml")
+	if err := os.WriteFile(testFile, []byte("key: TEST-1"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := repo.CommitIssueFile(tempDir, testFile, &client.Issue{Key: "TEST-1", IssueType: "Task"}); err != nil {
+		t.Fatalf("Failed to commit issue file: %v", err)
+	}
+
+	headTime, err := repo.GetCommitTime(tempDir, "")
+	if err != nil {
+		t.Fatalf("Failed to get HEAD commit time: %v", err)
+	}
+	if headTime.Before(before) || headTime.After(time.Now().Add(time.Second)) {
+		t.Errorf("HEAD commit time %v outside expected range", headTime)
+	}
+
+	branch, err := repo.GetCurrentBranch(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to get current branch: %v", err)
+	}
+	refTime, err := repo.GetCommitTime(tempDir, branch)
+	if err != nil {
+		t.Fatalf("Failed to get commit time for %s: %v", branch, err)
+	}
+	if !refTime.Equal(headTime) {
+		t.Errorf("Expected %s commit time %v to equal HEAD %v", branch, refTime, headTime)
+	}
+
+	_, err = repo.GetCommitTime(tempDir, "does-not-exist")
+	if !IsInvalidInputError(err) {
+		t.Errorf("Expected invalid_input error for unknown ref, got %v", err)
+	}
+}
@@ -1,11 +1,18 @@
 package git
 
 import (
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/chambrid/jira-cdc-git/pkg/client"
 )
 
 // MockRepository implements Repository for testing
+// Its methods are safe for concurrent use, as sync workers commit in parallel.
 type MockRepository struct {
+	mu sync.Mutex
+
 	// Repositories tracks which paths are considered Git repositories
 	Repositories map[string]bool
 
@@ -15,6 +22,10 @@ type MockRepository struct {
 	// CommittedFiles tracks files that have been committed
 	CommittedFiles map[string][]*CommitInfo
 
+	// CommitTimes maps "repoPath" or "repoPath@ref" to the commit time returned by GetCommitTime
+	// Repositories without an entry behave as if they have no commits
+	CommitTimes map[string]time.Time
+
 	// InitializeError simulates initialization failures when set
 	InitializeError error
 
@@ -24,6 +35,15 @@ type MockRepository struct {
 	// CommitError simulates commit failures when set
 	CommitError error
 
+	// StashError simulates stash and restore failures when set
+	StashError error
+
+	// Stashes tracks changes shelved per repository
+	Stashes map[string]*Stash
+
+	// LocalCommits tracks messages passed to CommitAllChanges per repository
+	LocalCommits map[string][]string
+
 	// CallCounts track method invocations
 	InitializeCallCount       int
 	IsRepositoryCallCount     int
@@ -48,11 +68,17 @@ func NewMockRepository() *MockRepository {
 		Repositories:       make(map[string]bool),
 		RepositoryStatuses: make(map[string]*RepositoryStatus),
 		CommittedFiles:     make(map[string][]*CommitInfo),
+		CommitTimes:        make(map[string]time.Time),
+		Stashes:            make(map[string]*Stash),
+		LocalCommits:       make(map[string][]string),
 	}
 }
 
 // Initialize simulates Git repository initialization
 func (m *MockRepository) Initialize(repoPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.InitializeCallCount++
 
 	// Simulate initialization error if configured
@@ -83,12 +109,23 @@ func (m *MockRepository) Initialize(repoPath string) error {
 
 // IsRepository simulates checking if a path is a Git repository
 func (m *MockRepository) IsRepository(repoPath string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.isRepository(repoPath)
+}
+
+// isRepository implements IsRepository for callers holding the lock
+func (m *MockRepository) isRepository(repoPath string) bool {
 	m.IsRepositoryCallCount++
 	return m.Repositories[repoPath]
 }
 
 // ValidateWorkingTree simulates working tree validation
 func (m *MockRepository) ValidateWorkingTree(repoPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.ValidateCallCount++
 
 	// Simulate validation error if configured
@@ -97,7 +134,7 @@ func (m *MockRepository) ValidateWorkingTree(repoPath string) error {
 	}
 
 	// Check if repository exists
-	if !m.IsRepository(repoPath) {
+	if !m.isRepository(repoPath) {
 		return &GitError{
 			Type:    "repository_not_found",
 			Message: "repository not found",
@@ -120,10 +157,13 @@ func (m *MockRepository) ValidateWorkingTree(repoPath string) error {
 
 // GetCurrentBranch simulates getting the current branch
 func (m *MockRepository) GetCurrentBranch(repoPath string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.GetCurrentBranchCallCount++
 
 	// Check if repository exists
-	if !m.IsRepository(repoPath) {
+	if !m.isRepository(repoPath) {
 		return "", &GitError{
 			Type:    "repository_not_found",
 			Message: "repository not found",
@@ -141,8 +181,16 @@ func (m *MockRepository) GetCurrentBranch(repoPath string) (string, error) {
 
 // GetRepositoryStatus simulates getting repository status
 func (m *MockRepository) GetRepositoryStatus(repoPath string) (*RepositoryStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.repositoryStatus(repoPath)
+}
+
+// repositoryStatus implements GetRepositoryStatus for callers holding the lock
+func (m *MockRepository) repositoryStatus(repoPath string) (*RepositoryStatus, error) {
 	// Check if repository exists
-	if !m.IsRepository(repoPath) {
+	if !m.isRepository(repoPath) {
 		return nil, &GitError{
 			Type:    "repository_not_found",
 			Message: "repository not found",
@@ -162,8 +210,144 @@ func (m *MockRepository) GetRepositoryStatus(repoPath string) (*RepositoryStatus
 	return status, nil
 }
 
+// GetCommitTime simulates resolving a revision's commit time
+func (m *MockRepository) GetCommitTime(repoPath, ref string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.isRepository(repoPath) {
+		return time.Time{}, &GitError{
+			Type:    "repository_not_found",
+			Message: "repository not found",
+			Context: repoPath,
+		}
+	}
+
+	if ref != "" {
+		if when, exists := m.CommitTimes[repoPath+"@"+ref]; exists {
+			return when, nil
+		}
+		return time.Time{}, &GitError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("failed to resolve revision: %s", ref),
+			Context: repoPath,
+		}
+	}
+
+	if when, exists := m.CommitTimes[repoPath]; exists {
+		return when, nil
+	}
+
+	return time.Time{}, &GitError{
+		Type:    "no_commits",
+		Message: "repository has no commits",
+		Context: repoPath,
+	}
+}
+
+// StashChanges simulates shelving local changes, leaving the repository clean
+func (m *MockRepository) StashChanges(repoPath string) (*Stash, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.StashError != nil {
+		return nil, m.StashError
+	}
+
+	status, err := m.repositoryStatus(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if status.IsClean {
+		return nil, nil
+	}
+
+	stash := &Stash{Dir: "mock-stash"}
+	for _, file := range changedFiles(status) {
+		stash.Files = append(stash.Files, StashedFile{Path: file})
+	}
+	m.Stashes[repoPath] = stash
+	m.RepositoryStatuses[repoPath] = &RepositoryStatus{IsClean: true, CurrentBranch: status.CurrentBranch}
+
+	return stash, nil
+}
+
+// RestoreStash simulates restoring shelved changes, making the repository dirty again
+func (m *MockRepository) RestoreStash(repoPath string, stash *Stash) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if stash == nil {
+		return nil
+	}
+	if m.StashError != nil {
+		return m.StashError
+	}
+
+	status := &RepositoryStatus{IsClean: false, CurrentBranch: "main"}
+	for _, file := range stash.Files {
+		status.ModifiedFiles = append(status.ModifiedFiles, file.Path)
+	}
+	m.RepositoryStatuses[repoPath] = status
+	delete(m.Stashes, repoPath)
+
+	return nil
+}
+
+// CommitAllChanges simulates committing every local change
+func (m *MockRepository) CommitAllChanges(repoPath, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CommitError != nil {
+		return m.CommitError
+	}
+
+	status, err := m.repositoryStatus(repoPath)
+	if err != nil {
+		return err
+	}
+
+	m.LocalCommits[repoPath] = append(m.LocalCommits[repoPath], message)
+	m.RepositoryStatuses[repoPath] = &RepositoryStatus{IsClean: true, CurrentBranch: status.CurrentBranch}
+
+	return nil
+}
+
+// CommitFiles simulates committing a set of files
+func (m *MockRepository) CommitFiles(repoPath, message string, filePaths ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.CommitCallCount++
+
+	if m.CommitError != nil {
+		return m.CommitError
+	}
+
+	if !m.isRepository(repoPath) {
+		return &GitError{
+			Type:    "repository_not_found",
+			Message: "repository not found",
+			Context: repoPath,
+		}
+	}
+
+	for _, filePath := range filePaths {
+		m.CommittedFiles[repoPath] = append(m.CommittedFiles[repoPath], &CommitInfo{
+			FilePath:      filePath,
+			CommitMessage: message,
+		})
+	}
+
+	return nil
+}
+
 // CommitIssueFile simulates committing an issue file
 func (m *MockRepository) CommitIssueFile(repoPath, filePath string, issue *client.Issue) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.CommitCallCount++
 	m.LastCommittedIssue = issue
 
@@ -180,7 +364,7 @@ func (m *MockRepository) CommitIssueFile(repoPath, filePath string, issue *clien
 	}
 
 	// Check if repository exists
-	if !m.IsRepository(repoPath) {
+	if !m.isRepository(repoPath) {
 		return &GitError{
 			Type:    "repository_not_found",
 			Message: "repository not found",
@@ -210,6 +394,9 @@ func (m *MockRepository) CommitIssueFile(repoPath, filePath string, issue *clien
 
 // SetRepositoryAsInitialized marks a path as a Git repository
 func (m *MockRepository) SetRepositoryAsInitialized(repoPath string, clean bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.Repositories[repoPath] = true
 	m.RepositoryStatuses[repoPath] = &RepositoryStatus{
 		IsClean:       clean,
@@ -219,31 +406,49 @@ func (m *MockRepository) SetRepositoryAsInitialized(repoPath string, clean bool)
 
 // SetRepositoryStatus sets the status for a repository
 func (m *MockRepository) SetRepositoryStatus(repoPath string, status *RepositoryStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.RepositoryStatuses[repoPath] = status
 }
 
 // SetInitializeError configures the mock to return an initialization error
 func (m *MockRepository) SetInitializeError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.InitializeError = err
 }
 
 // SetValidateError configures the mock to return a validation error
 func (m *MockRepository) SetValidateError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.ValidateError = err
 }
 
 // SetCommitError configures the mock to return a commit error
 func (m *MockRepository) SetCommitError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.CommitError = err
 }
 
 // GetCommittedFiles returns all files committed to a repository
 func (m *MockRepository) GetCommittedFiles(repoPath string) []*CommitInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	return m.CommittedFiles[repoPath]
 }
 
 // VerifyFileCommitted checks if a specific file was committed
 func (m *MockRepository) VerifyFileCommitted(repoPath, filePath string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	commits := m.CommittedFiles[repoPath]
 	for _, commit := range commits {
 		if commit.FilePath == filePath {
@@ -255,12 +460,18 @@ func (m *MockRepository) VerifyFileCommitted(repoPath, filePath string) bool {
 
 // Reset clears all mock state for clean test setup
 func (m *MockRepository) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.Repositories = make(map[string]bool)
 	m.RepositoryStatuses = make(map[string]*RepositoryStatus)
 	m.CommittedFiles = make(map[string][]*CommitInfo)
+	m.Stashes = make(map[string]*Stash)
+	m.LocalCommits = make(map[string][]string)
 	m.InitializeError = nil
 	m.ValidateError = nil
 	m.CommitError = nil
+	m.StashError = nil
 	m.InitializeCallCount = 0
 	m.IsRepositoryCallCount = 0
 	m.ValidateCallCount = 0
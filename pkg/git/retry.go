@@ -0,0 +1,117 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// DefaultRetryBackoff is the wait before the first retry of a failed Git operation
+const DefaultRetryBackoff = 500 * time.Millisecond
+
+// maxRetryBackoff caps the doubling wait between retries
+const maxRetryBackoff = 30 * time.Second
+
+// RetryPolicy retries Git operations (init and commits) that fail transiently, such as on a lock
+// held by a concurrent process or a dropped connection
+type RetryPolicy struct {
+	Retries int           // retries after the first attempt; 0 disables retries
+	Backoff time.Duration // wait before the first retry, doubled for each further retry
+}
+
+// ParseRetryPolicy validates a retry count and backoff; a zero backoff uses DefaultRetryBackoff
+func ParseRetryPolicy(retries int, backoff time.Duration) (RetryPolicy, error) {
+	if retries < 0 || retries > 10 {
+		return RetryPolicy{}, fmt.Errorf("git retries must be between 0 and 10, got %d", retries)
+	}
+	if backoff < 0 {
+		return RetryPolicy{}, fmt.Errorf("git retry backoff cannot be negative, got %s", backoff)
+	}
+	if backoff == 0 {
+		backoff = DefaultRetryBackoff
+	}
+	return RetryPolicy{Retries: retries, Backoff: backoff}, nil
+}
+
+// delay returns the wait before a retry, capped at maxRetryBackoff
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
+
+// SetRetryPolicy configures retries on a repository that supports them
+// Other implementations, such as the mock, are left unchanged.
+func SetRetryPolicy(repo Repository, policy RetryPolicy) {
+	if gitRepo, ok := repo.(*GitRepository); ok {
+		gitRepo.Retry = policy
+	}
+}
+
+// transientErrorPatterns are lower-case fragments of errors worth retrying: locks held by
+// another process and transient network failures
+var transientErrorPatterns = []string{
+	".lock",
+	"lock held",
+	"unable to create lock",
+	"resource temporarily unavailable",
+	"device or resource busy",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"timed out",
+	"temporary failure",
+	"unexpected eof",
+}
+
+// IsRetryableError reports whether a Git operation failed transiently
+// Bad credentials, missing or invalid repositories, and invalid input are never retried.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, transport.ErrAuthenticationRequired) || errors.Is(err, transport.ErrAuthorizationFailed) ||
+		errors.Is(err, transport.ErrInvalidAuthMethod) || errors.Is(err, transport.ErrRepositoryNotFound) ||
+		errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return false
+	}
+	var gitErr *GitError
+	if errors.As(err, &gitErr) && gitErr.Type != "git_operation_error" && gitErr.Type != "filesystem_error" {
+		return false
+	}
+	if errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EBUSY) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	if gitErr != nil && gitErr.Err != nil {
+		message = strings.ToLower(gitErr.Err.Error())
+	}
+	for _, pattern := range transientErrorPatterns {
+		if strings.Contains(message, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetry runs a Git operation, retrying transient failures per the repository's retry policy
+func (g *GitRepository) withRetry(operation func() error) error {
+	err := operation()
+	for attempt := 1; attempt <= g.Retry.Retries && IsRetryableError(err); attempt++ {
+		sleep := g.sleep
+		if sleep == nil {
+			sleep = time.Sleep
+		}
+		sleep(g.Retry.delay(attempt))
+		err = operation()
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+func TestGitRepository_RetriesTransientLockFailure(t *testing.T) {
+	repoPath := t.TempDir()
+	var waits []time.Duration
+	repo := &GitRepository{
+		AuthorName:  "Test User",
+		AuthorEmail: "test@example.com",
+		Retry:       RetryPolicy{Retries: 3, Backoff: 10 * time.Millisecond},
+		sleep:       func(d time.Duration) { waits = append(waits, d) },
+	}
+	if err := repo.Initialize(repoPath); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	filePath := filepath.Join(repoPath, "PROJ-1.yaml")
+	if err := os.WriteFile(filePath, []byte("key: PROJ-1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A concurrent process holds the index lock for the first two attempts
+	attempts := 0
+	err := repo.withRetry(func() error {
+		attempts++
+		if attempts <= 2 {
+			return &GitError{Type: "git_operation_error", Message: "failed to create commit", Context: repoPath,
+				Err: errors.New("unable to create '.git/index.lock': File exists")}
+		}
+		return repo.commitFiles(repoPath, "feat(PROJ): add issue PROJ-1", filePath)
+	})
+	if err != nil {
+		t.Fatalf("Expected the commit to succeed once the lock was released, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}; !reflect.DeepEqual(waits, want) {
+		t.Errorf("Expected backoff %v, got %v", want, waits)
+	}
+	if _, err := repo.GetCommitTime(repoPath, ""); err != nil {
+		t.Errorf("Expected a commit after the retry, got %v", err)
+	}
+}
+
+func TestGitRepository_DoesNotRetryPermanentFailures(t *testing.T) {
+	repo := &GitRepository{Retry: RetryPolicy{Retries: 3, Backoff: time.Millisecond}, sleep: func(time.Duration) {}}
+
+	attempts := 0
+	err := repo.withRetry(func() error {
+		attempts++
+		return &GitError{Type: "git_operation_error", Message: "failed to push", Err: transport.ErrAuthenticationRequired}
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("Expected bad credentials to fail without retries, got %d attempts (err %v)", attempts, err)
+	}
+
+	// Without a policy, transient failures are not retried either
+	repo.Retry = RetryPolicy{}
+	attempts = 0
+	_ = repo.withRetry(func() error {
+		attempts++
+		return &GitError{Type: "git_operation_error", Err: errors.New("index.lock: File exists")}
+	})
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt without retries, got %d", attempts)
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"index lock", &GitError{Type: "git_operation_error", Err: errors.New("unable to create '.git/index.lock': File exists")}, true},
+		{"connection reset", fmt.Errorf("push failed: %w", errors.New("read tcp: connection reset by peer")), true},
+		{"timeout", &GitError{Type: "git_operation_error", Err: errors.New("dial tcp: i/o timeout")}, true},
+		{"authentication", &GitError{Type: "git_operation_error", Err: transport.ErrAuthenticationRequired}, false},
+		{"authorization", fmt.Errorf("push: %w", transport.ErrAuthorizationFailed), false},
+		{"missing remote", &GitError{Type: "git_operation_error", Err: transport.ErrRepositoryNotFound}, false},
+		{"not a repository", &GitError{Type: "repository_not_found", Err: errors.New("repository does not exist")}, false},
+		{"invalid input", &GitError{Type: "invalid_input", Message: "lock held"}, false},
+		{"other failure", &GitError{Type: "git_operation_error", Err: errors.New("object not found")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableError(tt.err); got != tt.want {
+				t.Errorf("IsRetryableError(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	policy, err := ParseRetryPolicy(2, 0)
+	if err != nil || policy.Retries != 2 || policy.Backoff != DefaultRetryBackoff {
+		t.Errorf("ParseRetryPolicy(2, 0) = %+v, %v", policy, err)
+	}
+	if policy := (RetryPolicy{Backoff: 10 * time.Second}); policy.delay(4) != maxRetryBackoff {
+		t.Errorf("Expected the backoff to be capped at %s, got %s", maxRetryBackoff, policy.delay(4))
+	}
+	for _, tt := range []struct {
+		retries int
+		backoff time.Duration
+	}{{-1, 0}, {11, 0}, {1, -time.Second}} {
+		if _, err := ParseRetryPolicy(tt.retries, tt.backoff); err == nil {
+			t.Errorf("ParseRetryPolicy(%d, %s) expected an error", tt.retries, tt.backoff)
+		}
+	}
+}
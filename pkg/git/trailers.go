@@ -0,0 +1,67 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Trailer keys added to sync commits
+const (
+	TrailerOperationID = "Sync-Operation-Id"
+	TrailerQuery       = "Sync-Query"
+	TrailerProfile     = "Sync-Profile"
+)
+
+// CommitTrailer is a "Key: value" line appended to commit messages
+// Trailers identify the sync run that produced a commit (see git interpret-trailers).
+type CommitTrailer struct {
+	Key   string
+	Value string
+}
+
+// trailerLine matches an existing trailer such as "Co-Authored-By: name <email>"
+var trailerLine = regexp.MustCompile(`^[A-Za-z0-9-]+: .+$`)
+
+// NewGitRepositoryWithTrailers creates a Git repository manager that appends the given trailers
+// to every sync commit it creates (issue files and CommitFiles), so commits of one run can be
+// correlated with its logs and reports. Trailers with an empty value are skipped.
+func NewGitRepositoryWithTrailers(authorName, authorEmail string, trailers ...CommitTrailer) Repository {
+	repo := &GitRepository{
+		AuthorName:  authorName,
+		AuthorEmail: authorEmail,
+	}
+	for _, trailer := range trailers {
+		value := strings.Join(strings.Fields(trailer.Value), " ")
+		if trailer.Key != "" && value != "" {
+			repo.Trailers = append(repo.Trailers, CommitTrailer{Key: trailer.Key, Value: value})
+		}
+	}
+	return repo
+}
+
+// withTrailers appends the repository's trailers to a commit message
+// They join an existing trailer block at the end of the message, or start a new paragraph.
+func (g *GitRepository) withTrailers(message string) string {
+	if len(g.Trailers) == 0 {
+		return message
+	}
+
+	message = strings.TrimRight(message, "\n")
+	lines := strings.Split(message, "\n")
+	separator := "\n\n"
+	if len(lines) > 1 && trailerLine.MatchString(lines[len(lines)-1]) && strings.Contains(message, "\n\n") {
+		separator = "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(message)
+	b.WriteString(separator)
+	for i, trailer := range g.Trailers {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s: %s", trailer.Key, trailer.Value)
+	}
+	return b.String()
+}
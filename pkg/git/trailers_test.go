@@ -0,0 +1,113 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/go-git/go-git/v5"
+)
+
+func TestNewGitRepositoryWithTrailers(t *testing.T) {
+	repo := NewGitRepositoryWithTrailers("Test User", "test@example.com",
+		CommitTrailer{Key: TrailerOperationID, Value: "sync-1"},
+		CommitTrailer{Key: TrailerProfile, Value: ""},
+		CommitTrailer{Key: TrailerQuery, Value: "project = PROJ\n  AND status = Open"},
+	).(*GitRepository)
+
+	if len(repo.Trailers) != 2 {
+		t.Fatalf("Expected empty trailers to be skipped, got %v", repo.Trailers)
+	}
+	if repo.Trailers[1].Value != "project = PROJ AND status = Open" {
+		t.Errorf("Expected whitespace to be collapsed, got %q", repo.Trailers[1].Value)
+	}
+}
+
+func TestGitRepository_WithTrailers(t *testing.T) {
+	repo := &GitRepository{Trailers: []CommitTrailer{
+		{Key: TrailerOperationID, Value: "sync-1"},
+		{Key: TrailerQuery, Value: "project = PROJ"},
+	}}
+
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{
+			name:     "subject only",
+			message:  "feat(PROJ): sync 3 issues",
+			expected: "feat(PROJ): sync 3 issues\n\nSync-Operation-Id: sync-1\nSync-Query: project = PROJ",
+		},
+		{
+			name:     "existing trailer block",
+			message:  "feat(PROJ): add issue\n\nDetails here\n\nReporter: Jane <jane@example.com>\n",
+			expected: "feat(PROJ): add issue\n\nDetails here\n\nReporter: Jane <jane@example.com>\nSync-Operation-Id: sync-1\nSync-Query: project = PROJ",
+		},
+		{
+			name:     "body without trailers",
+			message:  "feat(PROJ): add issue\n\nThe body text",
+			expected: "feat(PROJ): add issue\n\nThe body text\n\nSync-Operation-Id: sync-1\nSync-Query: project = PROJ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repo.withTrailers(tt.message); got != tt.expected {
+				t.Errorf("withTrailers() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	plain := &GitRepository{}
+	if got := plain.withTrailers("message"); got != "message" {
+		t.Errorf("Expected message unchanged without trailers, got %q", got)
+	}
+}
+
+func TestGitRepository_CommitIssueFileWithTrailers(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := NewGitRepositoryWithTrailers("Test User", "test@example.com",
+		CommitTrailer{Key: TrailerOperationID, Value: "sync-20260101T000000Z-abc123"},
+		CommitTrailer{Key: TrailerQuery, Value: "project = PROJ"},
+	)
+	if err := repo.Initialize(tempDir); err != nil {
+		t.Fatalf("Failed to initialize repository: %v", err)
+	}
+
+	issueFile := filepath.Join(tempDir, "PROJ-1.yaml")
+	if err := os.WriteFile(issueFile, []byte("key: PROJ-1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write issue file: %v", err)
+	}
+	issue := &client.Issue{Key: "PROJ-1", Summary: "Test issue", IssueType: "Story"}
+	if err := repo.CommitIssueFile(tempDir, issueFile, issue); err != nil {
+		t.Fatalf("CommitIssueFile failed: %v", err)
+	}
+
+	message := headCommitMessage(t, tempDir)
+	for _, expected := range []string{"Sync-Operation-Id: sync-20260101T000000Z-abc123", "Sync-Query: project = PROJ"} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected commit message to contain %q, got:\n%s", expected, message)
+		}
+	}
+}
+
+// headCommitMessage returns the message of the repository's HEAD commit
+func headCommitMessage(t *testing.T, repoPath string) string {
+	t.Helper()
+	r, err := git.PlainOpen(repoPath)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		t.Fatalf("Failed to read HEAD: %v", err)
+	}
+	commit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("Failed to read HEAD commit: %v", err)
+	}
+	return commit.Message
+}
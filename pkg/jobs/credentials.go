@@ -0,0 +1,113 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Environment variables exposing Git credentials to the sync container
+const (
+	GitUsernameEnvVar = "GIT_USERNAME"
+	GitTokenEnvVar    = "GIT_TOKEN"
+)
+
+// Data keys of the per-job Git credentials Secret
+const (
+	gitCredentialsUsernameKey = "username"
+	gitCredentialsTokenKey    = "token"
+)
+
+// GitCredentials authenticate a sync job's pushes to its remote Git repository
+// The scheduler hands them to the job through a Secret owned by the Job, never as plain
+// environment values, and they are redacted whenever they are formatted.
+type GitCredentials struct {
+	Username string `json:"username"`
+	Token    string `json:"token"`
+}
+
+// String describes the credentials without revealing them
+func (c GitCredentials) String() string {
+	return "GitCredentials{<redacted>}"
+}
+
+// GoString keeps %#v from printing the credentials
+func (c GitCredentials) GoString() string {
+	return c.String()
+}
+
+// validate checks that both the username and the token are set
+func (c *GitCredentials) validate() error {
+	if c.Username == "" || c.Token == "" {
+		return fmt.Errorf("git credentials require a username and a token")
+	}
+	return nil
+}
+
+// gitCredentialsSecretName returns the Secret holding the Git credentials of a job
+func (s *KubernetesJobScheduler) gitCredentialsSecretName(jobID string) string {
+	return s.generateJobName(jobID) + "-git-credentials"
+}
+
+// gitCredentialsEnv returns the container environment reading a job's Git credentials
+func (s *KubernetesJobScheduler) gitCredentialsEnv(config *SyncJobConfig) []corev1.EnvVar {
+	secretName := s.gitCredentialsSecretName(config.ID)
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		secretEnv(GitUsernameEnvVar, gitCredentialsUsernameKey),
+		secretEnv(GitTokenEnvVar, gitCredentialsTokenKey),
+	}
+}
+
+// createGitCredentialsSecret stores a job's Git credentials in a Secret the job can read
+func (s *KubernetesJobScheduler) createGitCredentialsSecret(ctx context.Context, config *SyncJobConfig) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.gitCredentialsSecretName(config.ID),
+			Namespace: s.namespace,
+			Labels:    s.generateJobLabels(config),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			gitCredentialsUsernameKey: []byte(config.GitCredentials.Username),
+			gitCredentialsTokenKey:    []byte(config.GitCredentials.Token),
+		},
+	}
+	if _, err := s.clientset.CoreV1().Secrets(s.namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create Git credentials secret: %w", err)
+	}
+	return nil
+}
+
+// adoptGitCredentialsSecret makes the Job own its Git credentials Secret, so Kubernetes deletes
+// the Secret together with the Job
+func (s *KubernetesJobScheduler) adoptGitCredentialsSecret(ctx context.Context, config *SyncJobConfig, job *batchv1.Job) error {
+	secrets := s.clientset.CoreV1().Secrets(s.namespace)
+	secret, err := secrets.Get(ctx, s.gitCredentialsSecretName(config.ID), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Git credentials secret: %w", err)
+	}
+	secret.OwnerReferences = append(secret.OwnerReferences, *metav1.NewControllerRef(job, batchv1.SchemeGroupVersion.WithKind("Job")))
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to set owner of Git credentials secret: %w", err)
+	}
+	return nil
+}
+
+// deleteGitCredentialsSecret removes the Git credentials Secret of a job that could not be created
+func (s *KubernetesJobScheduler) deleteGitCredentialsSecret(ctx context.Context, config *SyncJobConfig) {
+	_ = s.clientset.CoreV1().Secrets(s.namespace).Delete(ctx, s.gitCredentialsSecretName(config.ID), metav1.DeleteOptions{})
+}
@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateJob_GitCredentials(t *testing.T) {
+	scheduler := &KubernetesJobScheduler{
+		clientset:       fake.NewSimpleClientset(),
+		namespace:       "jira-sync",
+		defaultImage:    "jira-sync:latest",
+		templateManager: NewFileJobTemplateManager(),
+	}
+	config := &SyncJobConfig{
+		ID:             "jql-abc123",
+		Type:           JobTypeJQL,
+		Created:        time.Now(),
+		Target:         "project = PROJ",
+		Repository:     "/repo",
+		GitCredentials: &GitCredentials{Username: "bot", Token: "s3cr3t-token"},
+	}
+
+	if _, err := scheduler.CreateJob(context.Background(), config); err != nil {
+		t.Fatalf("CreateJob() error = %v", err)
+	}
+
+	secret, err := scheduler.clientset.CoreV1().Secrets("jira-sync").Get(context.Background(), "jira-sync-jql-abc123-git-credentials", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected a Git credentials secret: %v", err)
+	}
+	if string(secret.Data["username"]) != "bot" || string(secret.Data["token"]) != "s3cr3t-token" {
+		t.Errorf("Unexpected secret keys: %v", secret.Data)
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Kind != "Job" || secret.OwnerReferences[0].Name != "jira-sync-jql-abc123" {
+		t.Errorf("Expected the secret to be owned by the job, got %+v", secret.OwnerReferences)
+	}
+
+	job, err := scheduler.clientset.BatchV1().Jobs("jira-sync").Get(context.Background(), "jira-sync-jql-abc123", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the job to be created: %v", err)
+	}
+	found := map[string]bool{}
+	for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+		if env.Name != GitUsernameEnvVar && env.Name != GitTokenEnvVar {
+			continue
+		}
+		if env.Value != "" || env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil || env.ValueFrom.SecretKeyRef.Name != secret.Name {
+			t.Errorf("Expected %s to be read from the secret, got %+v", env.Name, env)
+		}
+		found[env.Name] = true
+	}
+	if !found[GitUsernameEnvVar] || !found[GitTokenEnvVar] {
+		t.Errorf("Expected Git credential env vars, got %+v", job.Spec.Template.Spec.Containers[0].Env)
+	}
+}
+
+func TestGitCredentials_Validation(t *testing.T) {
+	scheduler := &KubernetesJobScheduler{clientset: fake.NewSimpleClientset(), templateManager: NewFileJobTemplateManager()}
+	config := &SyncJobConfig{
+		ID: "jql-abc123", Type: JobTypeJQL, Target: "project = PROJ", Repository: "/repo",
+		GitCredentials: &GitCredentials{Username: "bot"},
+	}
+	if _, err := scheduler.CreateJob(context.Background(), config); err == nil {
+		t.Error("Expected credentials without a token to be rejected")
+	}
+
+	credentials := GitCredentials{Username: "bot", Token: "s3cr3t-token"}
+	config.GitCredentials = &credentials
+	for _, format := range []string{"%v", "%+v", "%#v"} {
+		if strings.Contains(fmt.Sprintf(format, credentials), "s3cr3t-token") || strings.Contains(fmt.Sprintf(format, config), "s3cr3t-token") {
+			t.Errorf("Expected credentials to be redacted with %s", format)
+		}
+	}
+}
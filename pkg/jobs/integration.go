@@ -20,6 +20,7 @@ type SyncJobOrchestrator struct {
 	scheduler    JobScheduler
 	idGenerator  JobIDGenerator
 	configLoader config.Provider
+	clients      *client.Pool // local syncs against the same host share one authenticated client
 }
 
 // NewSyncJobOrchestrator creates a new sync job orchestrator
@@ -28,6 +29,7 @@ func NewSyncJobOrchestrator(scheduler JobScheduler) *SyncJobOrchestrator {
 		scheduler:    scheduler,
 		idGenerator:  NewJobIDGenerator(),
 		configLoader: config.NewDotEnvLoader(),
+		clients:      client.NewPool(),
 	}
 }
 
@@ -43,22 +45,27 @@ func (o *SyncJobOrchestrator) SubmitSingleIssueSync(ctx context.Context, req *Si
 
 	// Create job configuration
 	config := &SyncJobConfig{
-		ID:          jobID,
-		Type:        JobTypeSingle,
-		Name:        fmt.Sprintf("Single Issue Sync: %s", req.IssueKey),
-		Created:     time.Now(),
-		Target:      req.IssueKey,
-		Repository:  req.Repository,
-		Concurrency: 1, // Single issue sync uses 1 worker
-		RateLimit:   req.RateLimit,
-		Incremental: req.Incremental,
-		Force:       req.Force,
-		DryRun:      req.DryRun,
-		SafeMode:    req.SafeMode,
-		Namespace:   req.Namespace,
-		Image:       req.Image,
-		Resources:   req.Resources,
-		TimeoutSec:  req.TimeoutSec,
+		ID:             jobID,
+		Type:           JobTypeSingle,
+		Name:           fmt.Sprintf("Single Issue Sync: %s", req.IssueKey),
+		Created:        time.Now(),
+		Target:         req.IssueKey,
+		Repository:     req.Repository,
+		Concurrency:    1, // Single issue sync uses 1 worker
+		RateLimit:      req.RateLimit,
+		Incremental:    req.Incremental,
+		Force:          req.Force,
+		DryRun:         req.DryRun,
+		SafeMode:       req.SafeMode,
+		Namespace:      req.Namespace,
+		Image:          req.Image,
+		Resources:      req.Resources,
+		TimeoutSec:     req.TimeoutSec,
+		JobLabels:      req.JobLabels,
+		JobAnnotations: req.JobAnnotations,
+		GitCredentials: req.GitCredentials,
+
+		CheckpointInterval: req.CheckpointInterval,
 	}
 
 	// Submit job
@@ -77,25 +84,30 @@ func (o *SyncJobOrchestrator) SubmitBatchSync(ctx context.Context, req *BatchSyn
 
 	// Create job configuration
 	config := &SyncJobConfig{
-		ID:          jobID,
-		Type:        JobTypeBatch,
-		Name:        fmt.Sprintf("Batch Sync: %d issues", len(req.IssueKeys)),
-		Created:     time.Now(),
-		Target:      strings.Join(req.IssueKeys, ","),
-		Repository:  req.Repository,
-		BatchSize:   req.BatchSize,
-		Concurrency: req.Concurrency,
-		RateLimit:   req.RateLimit,
-		Incremental: req.Incremental,
-		Force:       req.Force,
-		DryRun:      req.DryRun,
-		SafeMode:    req.SafeMode,
-		Namespace:   req.Namespace,
-		Image:       req.Image,
-		Resources:   req.Resources,
-		Parallelism: req.Parallelism,
-		Completions: req.Completions,
-		TimeoutSec:  req.TimeoutSec,
+		ID:             jobID,
+		Type:           JobTypeBatch,
+		Name:           fmt.Sprintf("Batch Sync: %d issues", len(req.IssueKeys)),
+		Created:        time.Now(),
+		Target:         strings.Join(req.IssueKeys, ","),
+		Repository:     req.Repository,
+		BatchSize:      req.BatchSize,
+		Concurrency:    req.Concurrency,
+		RateLimit:      req.RateLimit,
+		Incremental:    req.Incremental,
+		Force:          req.Force,
+		DryRun:         req.DryRun,
+		SafeMode:       req.SafeMode,
+		Namespace:      req.Namespace,
+		Image:          req.Image,
+		Resources:      req.Resources,
+		Parallelism:    req.Parallelism,
+		Completions:    req.Completions,
+		TimeoutSec:     req.TimeoutSec,
+		JobLabels:      req.JobLabels,
+		JobAnnotations: req.JobAnnotations,
+		GitCredentials: req.GitCredentials,
+
+		CheckpointInterval: req.CheckpointInterval,
 	}
 
 	// Submit job
@@ -114,25 +126,30 @@ func (o *SyncJobOrchestrator) SubmitJQLSync(ctx context.Context, req *JQLSyncReq
 
 	// Create job configuration
 	config := &SyncJobConfig{
-		ID:          jobID,
-		Type:        JobTypeJQL,
-		Name:        fmt.Sprintf("JQL Sync: %s", req.JQL),
-		Created:     time.Now(),
-		Target:      req.JQL,
-		Repository:  req.Repository,
-		BatchSize:   req.BatchSize,
-		Concurrency: req.Concurrency,
-		RateLimit:   req.RateLimit,
-		Incremental: req.Incremental,
-		Force:       req.Force,
-		DryRun:      req.DryRun,
-		SafeMode:    req.SafeMode,
-		Namespace:   req.Namespace,
-		Image:       req.Image,
-		Resources:   req.Resources,
-		Parallelism: req.Parallelism,
-		Completions: req.Completions,
-		TimeoutSec:  req.TimeoutSec,
+		ID:             jobID,
+		Type:           JobTypeJQL,
+		Name:           fmt.Sprintf("JQL Sync: %s", req.JQL),
+		Created:        time.Now(),
+		Target:         req.JQL,
+		Repository:     req.Repository,
+		BatchSize:      req.BatchSize,
+		Concurrency:    req.Concurrency,
+		RateLimit:      req.RateLimit,
+		Incremental:    req.Incremental,
+		Force:          req.Force,
+		DryRun:         req.DryRun,
+		SafeMode:       req.SafeMode,
+		Namespace:      req.Namespace,
+		Image:          req.Image,
+		Resources:      req.Resources,
+		Parallelism:    req.Parallelism,
+		Completions:    req.Completions,
+		TimeoutSec:     req.TimeoutSec,
+		JobLabels:      req.JobLabels,
+		JobAnnotations: req.JobAnnotations,
+		GitCredentials: req.GitCredentials,
+
+		CheckpointInterval: req.CheckpointInterval,
 	}
 
 	// Submit job
@@ -152,14 +169,10 @@ func (o *SyncJobOrchestrator) ExecuteLocalSync(ctx context.Context, req *LocalSy
 		cfg.RateLimitDelay = req.RateLimit
 	}
 
-	// Initialize JIRA client
-	jiraClient, err := client.NewClient(cfg)
+	// Concurrent syncs against the same host reuse one authenticated client and its rate limiter
+	jiraClient, err := o.clients.Get(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create JIRA client: %w", err)
-	}
-
-	if err := jiraClient.Authenticate(); err != nil {
-		return nil, fmt.Errorf("failed to authenticate with JIRA: %w", err)
+		return nil, err
 	}
 
 	// Initialize Git repository
@@ -274,55 +287,67 @@ func (o *SyncJobOrchestrator) GetQueueStatus(ctx context.Context) (*QueueStatus,
 
 // SingleIssueSyncRequest represents a request to sync a single JIRA issue
 type SingleIssueSyncRequest struct {
-	IssueKey    string                   `json:"issue_key"`
-	Repository  string                   `json:"repository"`
-	RateLimit   time.Duration            `json:"rate_limit,omitempty"`
-	Incremental bool                     `json:"incremental,omitempty"`
-	Force       bool                     `json:"force,omitempty"`
-	DryRun      bool                     `json:"dry_run,omitempty"`
-	SafeMode    bool                     `json:"safe_mode,omitempty"`
-	Namespace   string                   `json:"namespace,omitempty"`
-	Image       string                   `json:"image,omitempty"`
-	Resources   *JobResourceRequirements `json:"resources,omitempty"`
-	TimeoutSec  *int64                   `json:"timeout_sec,omitempty"`
+	IssueKey           string                   `json:"issue_key"`
+	Repository         string                   `json:"repository"`
+	RateLimit          time.Duration            `json:"rate_limit,omitempty"`
+	Incremental        bool                     `json:"incremental,omitempty"`
+	Force              bool                     `json:"force,omitempty"`
+	DryRun             bool                     `json:"dry_run,omitempty"`
+	SafeMode           bool                     `json:"safe_mode,omitempty"`
+	Namespace          string                   `json:"namespace,omitempty"`
+	Image              string                   `json:"image,omitempty"`
+	Resources          *JobResourceRequirements `json:"resources,omitempty"`
+	TimeoutSec         *int64                   `json:"timeout_sec,omitempty"`
+	JobLabels          map[string]string        `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string        `json:"job_annotations,omitempty"`
+	GitCredentials     *GitCredentials          `json:"-"`
+	CheckpointInterval int                      `json:"checkpoint_interval,omitempty"`
 }
 
 // BatchSyncRequest represents a request to sync multiple JIRA issues
 type BatchSyncRequest struct {
-	IssueKeys   []string                 `json:"issue_keys"`
-	Repository  string                   `json:"repository"`
-	BatchSize   int                      `json:"batch_size,omitempty"`
-	Concurrency int                      `json:"concurrency,omitempty"`
-	RateLimit   time.Duration            `json:"rate_limit,omitempty"`
-	Incremental bool                     `json:"incremental,omitempty"`
-	Force       bool                     `json:"force,omitempty"`
-	DryRun      bool                     `json:"dry_run,omitempty"`
-	SafeMode    bool                     `json:"safe_mode,omitempty"`
-	Namespace   string                   `json:"namespace,omitempty"`
-	Image       string                   `json:"image,omitempty"`
-	Resources   *JobResourceRequirements `json:"resources,omitempty"`
-	Parallelism *int32                   `json:"parallelism,omitempty"`
-	Completions *int32                   `json:"completions,omitempty"`
-	TimeoutSec  *int64                   `json:"timeout_sec,omitempty"`
+	IssueKeys          []string                 `json:"issue_keys"`
+	Repository         string                   `json:"repository"`
+	BatchSize          int                      `json:"batch_size,omitempty"`
+	Concurrency        int                      `json:"concurrency,omitempty"`
+	RateLimit          time.Duration            `json:"rate_limit,omitempty"`
+	Incremental        bool                     `json:"incremental,omitempty"`
+	Force              bool                     `json:"force,omitempty"`
+	DryRun             bool                     `json:"dry_run,omitempty"`
+	SafeMode           bool                     `json:"safe_mode,omitempty"`
+	Namespace          string                   `json:"namespace,omitempty"`
+	Image              string                   `json:"image,omitempty"`
+	Resources          *JobResourceRequirements `json:"resources,omitempty"`
+	Parallelism        *int32                   `json:"parallelism,omitempty"`
+	Completions        *int32                   `json:"completions,omitempty"`
+	TimeoutSec         *int64                   `json:"timeout_sec,omitempty"`
+	JobLabels          map[string]string        `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string        `json:"job_annotations,omitempty"`
+	GitCredentials     *GitCredentials          `json:"-"`
+	CheckpointInterval int                      `json:"checkpoint_interval,omitempty"`
 }
 
 // JQLSyncRequest represents a request to sync issues matching a JQL query
 type JQLSyncRequest struct {
-	JQL         string                   `json:"jql"`
-	Repository  string                   `json:"repository"`
-	BatchSize   int                      `json:"batch_size,omitempty"`
-	Concurrency int                      `json:"concurrency,omitempty"`
-	RateLimit   time.Duration            `json:"rate_limit,omitempty"`
-	Incremental bool                     `json:"incremental,omitempty"`
-	Force       bool                     `json:"force,omitempty"`
-	DryRun      bool                     `json:"dry_run,omitempty"`
-	SafeMode    bool                     `json:"safe_mode,omitempty"`
-	Namespace   string                   `json:"namespace,omitempty"`
-	Image       string                   `json:"image,omitempty"`
-	Resources   *JobResourceRequirements `json:"resources,omitempty"`
-	Parallelism *int32                   `json:"parallelism,omitempty"`
-	Completions *int32                   `json:"completions,omitempty"`
-	TimeoutSec  *int64                   `json:"timeout_sec,omitempty"`
+	JQL                string                   `json:"jql"`
+	Repository         string                   `json:"repository"`
+	BatchSize          int                      `json:"batch_size,omitempty"`
+	Concurrency        int                      `json:"concurrency,omitempty"`
+	RateLimit          time.Duration            `json:"rate_limit,omitempty"`
+	Incremental        bool                     `json:"incremental,omitempty"`
+	Force              bool                     `json:"force,omitempty"`
+	DryRun             bool                     `json:"dry_run,omitempty"`
+	SafeMode           bool                     `json:"safe_mode,omitempty"`
+	Namespace          string                   `json:"namespace,omitempty"`
+	Image              string                   `json:"image,omitempty"`
+	Resources          *JobResourceRequirements `json:"resources,omitempty"`
+	Parallelism        *int32                   `json:"parallelism,omitempty"`
+	Completions        *int32                   `json:"completions,omitempty"`
+	TimeoutSec         *int64                   `json:"timeout_sec,omitempty"`
+	JobLabels          map[string]string        `json:"job_labels,omitempty"`
+	JobAnnotations     map[string]string        `json:"job_annotations,omitempty"`
+	GitCredentials     *GitCredentials          `json:"-"`
+	CheckpointInterval int                      `json:"checkpoint_interval,omitempty"`
 }
 
 // LocalSyncRequest represents a request for local (non-Kubernetes) sync
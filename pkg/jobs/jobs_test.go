@@ -2,6 +2,7 @@ package jobs
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -326,6 +327,22 @@ func TestJobConfiguration(t *testing.T) {
 	})
 }
 
+func TestGenerateContainerArgs_Checkpoint(t *testing.T) {
+	scheduler := &KubernetesJobScheduler{}
+	config := &SyncJobConfig{Type: JobTypeJQL, Target: "project = PROJ", Repository: "/repo"}
+
+	args := strings.Join(scheduler.generateContainerArgs(config), " ")
+	if strings.Contains(args, "--commit-strategy") || strings.Contains(args, "--checkpoint-interval") {
+		t.Errorf("Expected no commit options without a checkpoint, got %s", args)
+	}
+
+	config.CheckpointInterval = 200
+	args = strings.Join(scheduler.generateContainerArgs(config), " ")
+	if !strings.HasSuffix(args, "--commit-strategy=batch --checkpoint-interval=200") {
+		t.Errorf("Expected checkpointed batch commits, got %s", args)
+	}
+}
+
 func TestParseJobID(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,55 @@
+package jobs
+
+import "strings"
+
+// reservedJobLabels are set by the scheduler or by Kubernetes and used for job selection
+var reservedJobLabels = map[string]bool{
+	"app":            true,
+	"sync-type":      true,
+	"sync-id":        true,
+	"managed-by":     true,
+	"job-name":       true,
+	"controller-uid": true,
+}
+
+// reservedMetadataPrefixes cover label and annotation keys owned by the scheduler or Kubernetes
+var reservedMetadataPrefixes = []string{
+	"jira-sync/",
+	"batch.kubernetes.io/",
+	"kubernetes.io/",
+	"k8s.io/",
+}
+
+// IsReservedJobMetadataKey reports whether a label or annotation key is managed by the scheduler
+// Custom job metadata may never override these keys.
+func IsReservedJobMetadataKey(key string) bool {
+	if reservedJobLabels[key] {
+		return true
+	}
+	for _, prefix := range reservedMetadataPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+		// Also match subdomains such as "node.kubernetes.io/"
+		if slash := strings.Index(key, "/"); slash > 0 && strings.HasSuffix(key[:slash+1], "."+prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeJobMetadata overlays user-supplied metadata onto managed metadata
+// Reserved keys are skipped so selectors and bookkeeping used by the scheduler keep working.
+func mergeJobMetadata(managed, custom map[string]string) map[string]string {
+	merged := make(map[string]string, len(managed)+len(custom))
+	for key, value := range custom {
+		if IsReservedJobMetadataKey(key) {
+			continue
+		}
+		merged[key] = value
+	}
+	for key, value := range managed {
+		merged[key] = value
+	}
+	return merged
+}
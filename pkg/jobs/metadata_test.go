@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsReservedJobMetadataKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		reserved bool
+	}{
+		{"app", true},
+		{"sync-id", true},
+		{"job-name", true},
+		{"jira-sync/target", true},
+		{"batch.kubernetes.io/job-name", true},
+		{"node.kubernetes.io/instance-type", true},
+		{"cost-center", false},
+		{"example.com/team", false},
+		{"application", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := IsReservedJobMetadataKey(tt.key); got != tt.reserved {
+				t.Errorf("IsReservedJobMetadataKey(%q) = %v, want %v", tt.key, got, tt.reserved)
+			}
+		})
+	}
+}
+
+func TestCreateKubernetesJob_CustomMetadata(t *testing.T) {
+	scheduler := &KubernetesJobScheduler{
+		namespace:       "jira-sync",
+		defaultImage:    "jira-sync:latest",
+		templateManager: NewFileJobTemplateManager(),
+	}
+
+	config := &SyncJobConfig{
+		ID:         "single-abc123",
+		Type:       JobTypeSingle,
+		Created:    time.Now(),
+		Target:     "PROJ-1",
+		Repository: "/repo",
+		JobLabels: map[string]string{
+			"cost-center": "eng-42",
+			"app":         "hijacked",
+		},
+		JobAnnotations: map[string]string{
+			"example.com/owner": "platform",
+			"jira-sync/target":  "hijacked",
+		},
+	}
+
+	template, err := scheduler.templateManager.GetTemplate(JobTypeSingle)
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+
+	job, err := scheduler.createKubernetesJob(config, template)
+	if err != nil {
+		t.Fatalf("createKubernetesJob() error = %v", err)
+	}
+
+	if job.Labels["cost-center"] != "eng-42" || job.Spec.Template.Labels["cost-center"] != "eng-42" {
+		t.Error("Expected custom label on both Job and Pod template")
+	}
+	if job.Labels["app"] != "jira-sync" || job.Spec.Template.Labels["app"] != "jira-sync" {
+		t.Errorf("Expected reserved label to keep managed value, got job=%q pod=%q", job.Labels["app"], job.Spec.Template.Labels["app"])
+	}
+	if job.Annotations["example.com/owner"] != "platform" || job.Spec.Template.Annotations["example.com/owner"] != "platform" {
+		t.Error("Expected custom annotation on both Job and Pod template")
+	}
+	if job.Annotations["jira-sync/target"] != "PROJ-1" {
+		t.Errorf("Expected reserved annotation to keep managed value, got %q", job.Annotations["jira-sync/target"])
+	}
+	if _, exists := job.Spec.Template.Annotations["jira-sync/target"]; exists {
+		t.Error("Expected reserved annotation to be dropped from Pod template")
+	}
+
+	// The shared template must not be mutated by a job's custom metadata
+	if _, exists := template.Template.Spec.Template.Labels["cost-center"]; exists {
+		t.Error("Expected job template to remain unchanged")
+	}
+}
@@ -64,11 +64,26 @@ func (s *KubernetesJobScheduler) CreateJob(ctx context.Context, config *SyncJobC
 		return nil, fmt.Errorf("failed to create Kubernetes job: %w", err)
 	}
 
+	// Store Git credentials in a Secret the job reads them from
+	if config.GitCredentials != nil {
+		if err := s.createGitCredentialsSecret(ctx, config); err != nil {
+			return nil, err
+		}
+	}
+
 	// Submit job to Kubernetes
 	createdJob, err := s.clientset.BatchV1().Jobs(s.namespace).Create(ctx, job, metav1.CreateOptions{})
 	if err != nil {
+		if config.GitCredentials != nil {
+			s.deleteGitCredentialsSecret(ctx, config)
+		}
 		return nil, fmt.Errorf("failed to submit job to Kubernetes: %w", err)
 	}
+	if config.GitCredentials != nil {
+		if err := s.adoptGitCredentialsSecret(ctx, config, createdJob); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create job result
 	result := &JobResult{
@@ -311,6 +326,9 @@ func (s *KubernetesJobScheduler) validateJobConfig(config *SyncJobConfig) error
 	if config.Type == "" {
 		return fmt.Errorf("job type is required")
 	}
+	if config.GitCredentials != nil {
+		return config.GitCredentials.validate()
+	}
 	return nil
 }
 
@@ -323,8 +341,12 @@ func (s *KubernetesJobScheduler) createKubernetesJob(config *SyncJobConfig, temp
 	// Update metadata
 	job.Name = jobName
 	job.Namespace = s.namespace
-	job.Labels = s.generateJobLabels(config)
-	job.Annotations = s.generateJobAnnotations(config)
+	job.Labels = mergeJobMetadata(s.generateJobLabels(config), config.JobLabels)
+	job.Annotations = mergeJobMetadata(s.generateJobAnnotations(config), config.JobAnnotations)
+
+	// Propagate custom metadata to the Pods so label selectors and chargeback see sync workloads
+	job.Spec.Template.Labels = mergeJobMetadata(job.Spec.Template.Labels, config.JobLabels)
+	job.Spec.Template.Annotations = mergeJobMetadata(job.Spec.Template.Annotations, config.JobAnnotations)
 
 	// Update container args
 	container := &job.Spec.Template.Spec.Containers[0]
@@ -355,6 +377,9 @@ func (s *KubernetesJobScheduler) createKubernetesJob(config *SyncJobConfig, temp
 
 	// Add environment variables
 	container.Env = append(container.Env, s.generateEnvironmentVars(config)...)
+	if config.GitCredentials != nil {
+		container.Env = append(container.Env, s.gitCredentialsEnv(config)...)
+	}
 
 	return job, nil
 }
@@ -409,6 +434,9 @@ func (s *KubernetesJobScheduler) generateContainerArgs(config *SyncJobConfig) []
 	if config.DryRun {
 		args = append(args, "--dry-run")
 	}
+	if config.CheckpointInterval > 0 {
+		args = append(args, "--commit-strategy=batch", fmt.Sprintf("--checkpoint-interval=%d", config.CheckpointInterval))
+	}
 
 	return args
 }
@@ -48,6 +48,9 @@ type SyncJobConfig struct {
 	Force       bool          `json:"force,omitempty"`
 	DryRun      bool          `json:"dry_run,omitempty"`
 
+	// Commit every N changed issues instead of once at the end (0: one commit)
+	CheckpointInterval int `json:"checkpoint_interval,omitempty"`
+
 	// Kubernetes options
 	Namespace   string                   `json:"namespace,omitempty"`
 	Image       string                   `json:"image,omitempty"`
@@ -56,8 +59,15 @@ type SyncJobConfig struct {
 	Completions *int32                   `json:"completions,omitempty"`
 	TimeoutSec  *int64                   `json:"timeout_sec,omitempty"`
 
+	// Custom metadata propagated onto the Job and its Pods (reserved keys are ignored)
+	JobLabels      map[string]string `json:"job_labels,omitempty"`
+	JobAnnotations map[string]string `json:"job_annotations,omitempty"`
+
 	// Security
 	SafeMode bool `json:"safe_mode,omitempty"`
+
+	// Credentials for pushing to the remote repository, never serialized
+	GitCredentials *GitCredentials `json:"-"`
 }
 
 // JobResourceRequirements defines CPU and memory requirements for jobs
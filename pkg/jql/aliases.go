@@ -0,0 +1,195 @@
+package jql
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// AliasConfigFile is the file that defines custom JQL aliases
+const AliasConfigFile = config.SettingsFileName
+
+// maxAliasDepth bounds nested alias expansion
+const maxAliasDepth = 10
+
+// aliasNamePattern matches an alias name after the @ sign
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*`)
+
+// BuiltinAliases are the JQL shortcuts available without configuration
+var BuiltinAliases = map[string]string{
+	"mine":       "assignee = currentUser()",
+	"reported":   "reporter = currentUser()",
+	"watching":   "watcher = currentUser()",
+	"unassigned": "assignee is EMPTY",
+	"open":       "resolution = Unresolved",
+	"resolved":   "resolution is not EMPTY",
+	"recent":     "updated >= -7d",
+	"bugs":       "issuetype = Bug",
+}
+
+// AliasConfig is the alias section of .jira-sync.yaml
+//
+//	jql_aliases:
+//	  team: "component in (Backend, API)"
+//	  hot: "@open AND priority in (Highest, High)"
+type AliasConfig struct {
+	JQLAliases map[string]string `yaml:"jql_aliases"`
+}
+
+// LoadAliases returns the built-in aliases overridden by the aliases defined in each config file
+// Later files take precedence; missing files are skipped.
+func LoadAliases(paths ...string) (map[string]string, error) {
+	aliases := make(map[string]string, len(BuiltinAliases))
+	for name, expansion := range BuiltinAliases {
+		aliases[name] = expansion
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, &JQLError{
+				Type:    ErrorTypeFilesystem,
+				Message: "failed to read alias configuration",
+				Context: map[string]interface{}{"path": path},
+				Err:     err,
+			}
+		}
+
+		var config AliasConfig
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, NewValidationError(fmt.Sprintf("invalid alias configuration in %s: %v", path, err), "")
+		}
+		for name, expansion := range config.JQLAliases {
+			if aliasNamePattern.FindString(name) != name {
+				return nil, NewValidationError(fmt.Sprintf("invalid alias name %q in %s (use letters, digits, '-' and '_')", name, path), "")
+			}
+			if strings.TrimSpace(expansion) == "" {
+				return nil, NewValidationError(fmt.Sprintf("alias %q in %s has an empty expansion", name, path), "")
+			}
+			aliases[name] = expansion
+		}
+	}
+
+	return aliases, nil
+}
+
+// DefaultAliasPaths returns the alias files read by the CLI, the settings files located by
+// config.SettingsFilePaths
+func DefaultAliasPaths() []string {
+	return config.SettingsFilePaths()
+}
+
+// HasAliases reports whether a JQL string references an @alias outside quoted values
+func HasAliases(jql string) bool {
+	found := false
+	_, _ = scanAliases(jql, func(string) (string, error) {
+		found = true
+		return "", nil
+	})
+	return found
+}
+
+// ExpandAliases replaces @alias references with their expansions and validates the result
+// Aliases may reference other aliases. Expansions containing OR are parenthesized so they
+// combine safely with surrounding AND clauses. An @ inside quoted values or directly after a
+// word character (as in an e-mail address) is left alone. Unknown aliases are an error.
+func ExpandAliases(jql string, aliases map[string]string) (string, error) {
+	expanded, err := expandAliases(jql, aliases, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if expanded != jql {
+		if problems := validateJQLSyntax(expanded); len(problems) > 0 {
+			return "", NewValidationError(fmt.Sprintf("expanded JQL is invalid: %s", strings.Join(problems, "; ")), expanded)
+		}
+	}
+	return expanded, nil
+}
+
+// expandAliases expands one level of aliases, recursing into expansions; stack detects cycles
+func expandAliases(jql string, aliases map[string]string, stack []string) (string, error) {
+	if len(stack) > maxAliasDepth {
+		return "", NewValidationError(fmt.Sprintf("alias expansion nested deeper than %d levels", maxAliasDepth), jql)
+	}
+
+	return scanAliases(jql, func(name string) (string, error) {
+		for _, active := range stack {
+			if active == name {
+				return "", NewValidationError(fmt.Sprintf("alias cycle: @%s -> @%s", strings.Join(stack, " -> @"), name), jql)
+			}
+		}
+
+		expansion, exists := aliases[name]
+		if !exists {
+			return "", NewValidationError(fmt.Sprintf("unknown JQL alias @%s (known: %s)", name, knownAliases(aliases)), jql)
+		}
+
+		nested, err := expandAliases(expansion, aliases, append(stack, name))
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(strings.ToLower(expansion), " or ") {
+			nested = "(" + nested + ")"
+		}
+		return nested, nil
+	})
+}
+
+// scanAliases rebuilds jql, replacing each @alias outside quotes with the result of replace
+func scanAliases(jql string, replace func(name string) (string, error)) (string, error) {
+	var result strings.Builder
+	var quote byte
+
+	for i := 0; i < len(jql); i++ {
+		char := jql[i]
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '@' && (i == 0 || !isWordChar(jql[i-1])):
+			name := aliasNamePattern.FindString(jql[i+1:])
+			if name == "" {
+				break
+			}
+			expansion, err := replace(name)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(expansion)
+			i += len(name)
+			continue
+		}
+		result.WriteByte(char)
+	}
+
+	return result.String(), nil
+}
+
+// isWordChar reports whether a byte can be part of a word, such as the local part of an e-mail
+func isWordChar(char byte) bool {
+	return char == '_' || char == '.' || char == '-' ||
+		(char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
+
+// knownAliases lists alias names for error messages
+func knownAliases(aliases map[string]string) string {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, "@"+name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
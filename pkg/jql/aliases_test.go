@@ -0,0 +1,99 @@
+package jql
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandAliases(t *testing.T) {
+	aliases := map[string]string{
+		"mine":   "assignee = currentUser()",
+		"open":   "resolution = Unresolved",
+		"urgent": "priority = Highest OR labels = hotfix",
+		"hot":    "@open AND @urgent",
+		"loop":   "@cycle",
+		"cycle":  "@loop",
+		"broken": "status = \"Open",
+	}
+
+	tests := []struct {
+		name     string
+		jql      string
+		expected string
+		errPart  string
+	}{
+		{"no aliases", "project = PROJ", "project = PROJ", ""},
+		{"builtin style", "project = PROJ AND @mine AND @open", "project = PROJ AND assignee = currentUser() AND resolution = Unresolved", ""},
+		{"or expansion parenthesized", "@mine AND @urgent", "assignee = currentUser() AND (priority = Highest OR labels = hotfix)", ""},
+		{"nested aliases", "@hot", "resolution = Unresolved AND (priority = Highest OR labels = hotfix)", ""},
+		{"quoted values untouched", `summary ~ "@mine" AND reporter = 'a@b.com'`, `summary ~ "@mine" AND reporter = 'a@b.com'`, ""},
+		{"email untouched", "reporter = jane@example.com", "reporter = jane@example.com", ""},
+		{"parenthesized alias", "(@mine)", "(assignee = currentUser())", ""},
+		{"unknown alias", "@nope", "", "unknown JQL alias @nope"},
+		{"cycle", "@loop", "", "alias cycle"},
+		{"invalid expansion", "@broken", "", "expanded JQL is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, err := ExpandAliases(tt.jql, aliases)
+			if tt.errPart != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+					t.Fatalf("ExpandAliases() error = %v, want error containing %q", err, tt.errPart)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandAliases() error = %v", err)
+			}
+			if expanded != tt.expected {
+				t.Errorf("ExpandAliases() = %q, want %q", expanded, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHasAliases(t *testing.T) {
+	if !HasAliases("project = PROJ AND @mine") {
+		t.Error("Expected alias to be detected")
+	}
+	if HasAliases(`reporter = a@b.com AND summary ~ "@mine"`) {
+		t.Error("Expected no alias in e-mail address or quoted value")
+	}
+}
+
+func TestLoadAliases(t *testing.T) {
+	dir := t.TempDir()
+	user := filepath.Join(dir, "user.yaml")
+	project := filepath.Join(dir, "project.yaml")
+	if err := os.WriteFile(user, []byte("jql_aliases:\n  team: \"component = Backend\"\n  open: \"status != Done\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(project, []byte("jql_aliases:\n  team: \"component = API\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	aliases, err := LoadAliases(user, filepath.Join(dir, "missing.yaml"), project)
+	if err != nil {
+		t.Fatalf("LoadAliases() error = %v", err)
+	}
+	if aliases["team"] != "component = API" {
+		t.Errorf("Expected later file to win, got %q", aliases["team"])
+	}
+	if aliases["open"] != "status != Done" {
+		t.Errorf("Expected custom alias to override built-in, got %q", aliases["open"])
+	}
+	if aliases["mine"] != BuiltinAliases["mine"] {
+		t.Errorf("Expected built-in alias to remain, got %q", aliases["mine"])
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("jql_aliases:\n  \"bad name\": \"x = y\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAliases(invalid); err == nil {
+		t.Error("Expected error for invalid alias name")
+	}
+}
@@ -25,6 +25,10 @@ type QueryBuilder interface {
 	// PreviewQuery previews what issues a query would return without executing sync
 	PreviewQuery(jql string) (*PreviewResult, error)
 
+	// PreviewQueryWithSamples previews a query with its first sampleSize matching issues
+	// A sampleSize of 0 only counts the matches.
+	PreviewQueryWithSamples(jql string, sampleSize int) (*PreviewResult, error)
+
 	// GetTemplates returns available query templates
 	GetTemplates() []*Template
 
@@ -66,6 +70,9 @@ type PreviewResult struct {
 	ExecutionTimeMs  int64           `json:"execution_time_ms" yaml:"execution_time_ms"`
 }
 
+// MaxPreviewSampleSize caps the issues fetched for a preview, one search page
+const MaxPreviewSampleSize = 100
+
 // Template represents a predefined JQL query template
 type Template struct {
 	Name        string            `json:"name" yaml:"name"`
@@ -263,6 +270,11 @@ func parseEpicKey(epicKey string) (project string, number string, err error) {
 	return project, number, nil
 }
 
+// ValidateSyntax performs the offline JQL syntax checks, returning one message per problem
+func ValidateSyntax(jql string) []string {
+	return validateJQLSyntax(jql)
+}
+
 // validateJQLSyntax performs basic JQL syntax validation
 func validateJQLSyntax(jql string) []string {
 	var errors []string
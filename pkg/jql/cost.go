@@ -0,0 +1,150 @@
+package jql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Query cost rules, the known JQL patterns that are slow on JIRA
+const (
+	CostRuleLeadingWildcard  = "leading-wildcard"
+	CostRuleUnboundedQuery   = "unbounded-query"
+	CostRuleHistorySearch    = "history-search"
+	CostRuleScriptedFunction = "scripted-function"
+	CostRuleUnindexedSort    = "unindexed-sort"
+)
+
+// CostWarning is a slow pattern found in a query; warnings are advisory and never block a query
+type CostWarning struct {
+	Rule    string `json:"rule" yaml:"rule"`
+	Message string `json:"message" yaml:"message"`
+}
+
+var (
+	// textSearchPattern matches a text search clause and its quoted value
+	textSearchPattern = regexp.MustCompile(`("[^"]+"|[A-Za-z][\w.]*|cf\[\d+\])\s*(!?~)\s*("[^"]*"|'[^']*')`)
+
+	// historyPattern matches the operators that search the issue history
+	historyPattern = regexp.MustCompile(`(?i)\b(was|changed)\b`)
+
+	// scriptedFunctionPattern matches ScriptRunner's issueFunction, which runs a script per query
+	scriptedFunctionPattern = regexp.MustCompile(`(?i)\bissueFunction\b`)
+
+	// boundingFieldPattern matches the fields that restrict a query to a slice of the instance
+	boundingFieldPattern = regexp.MustCompile(`(?i)(\b(project|key|issuekey|parent|filter|sprint)\b|"epic link"|"parent link")\s*(!?=|\bin\b)`)
+
+	// customFieldPattern matches custom field references
+	customFieldPattern = regexp.MustCompile(`(?i)^(cf\[\d+\]|customfield_\d+)$`)
+)
+
+// unindexedSortFields are the fields JIRA sorts on without an index
+var unindexedSortFields = map[string]bool{
+	"summary": true, "description": true, "environment": true, "comment": true, "text": true,
+}
+
+// AnalyzeCost inspects a query for patterns that are known to be slow on JIRA, without running it
+func AnalyzeCost(jql string) []CostWarning {
+	if strings.TrimSpace(jql) == "" {
+		return nil
+	}
+	var warnings []CostWarning
+	masked := maskLiterals(jql)
+
+	// Text searches are matched on the original query, as their values are literals
+	for _, match := range textSearchPattern.FindAllStringSubmatchIndex(jql, -1) {
+		if masked[match[0]] == ' ' && jql[match[0]] != ' ' {
+			continue // inside another literal
+		}
+		field, operator, value := jql[match[2]:match[3]], jql[match[4]:match[5]], jql[match[6]:match[7]]
+		term := strings.TrimSpace(value[1 : len(value)-1])
+		if strings.HasPrefix(term, "*") || strings.HasPrefix(term, "?") {
+			warnings = append(warnings, CostWarning{
+				Rule:    CostRuleLeadingWildcard,
+				Message: fmt.Sprintf("%s %s %s may be slow: a leading wildcard cannot use the search index", field, operator, value),
+			})
+		}
+	}
+
+	// Keywords are matched with literals masked, except the restriction, as fields such as
+	// "Epic Link" are quoted
+	where, orderBy := len(masked), ""
+	if loc := orderByPattern.FindStringIndex(masked); loc != nil {
+		where, orderBy = loc[0], jql[loc[1]:]
+	}
+
+	if strings.TrimSpace(jql[:where]) != "" && !boundingFieldPattern.MatchString(jql[:where]) {
+		warnings = append(warnings, CostWarning{
+			Rule:    CostRuleUnboundedQuery,
+			Message: "query is not restricted to a project, issue, or filter and may scan every issue",
+		})
+	}
+	if historyPattern.MatchString(masked[:where]) {
+		warnings = append(warnings, CostWarning{
+			Rule:    CostRuleHistorySearch,
+			Message: "WAS and CHANGED search the issue history and may be slow on large projects",
+		})
+	}
+	if scriptedFunctionPattern.MatchString(masked[:where]) {
+		warnings = append(warnings, CostWarning{
+			Rule:    CostRuleScriptedFunction,
+			Message: "issueFunction runs a script for the query and may be slow",
+		})
+	}
+
+	for _, field := range orderByFields(orderBy) {
+		switch {
+		case unindexedSortFields[strings.ToLower(field)]:
+			warnings = append(warnings, CostWarning{
+				Rule:    CostRuleUnindexedSort,
+				Message: fmt.Sprintf("ORDER BY %s may be slow: text fields are sorted without an index", field),
+			})
+		case customFieldPattern.MatchString(field):
+			warnings = append(warnings, CostWarning{
+				Rule:    CostRuleUnindexedSort,
+				Message: fmt.Sprintf("ORDER BY %s may be slow: custom fields may not be indexed for sorting", field),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// orderByFields returns the field names of an ORDER BY clause body, without their directions
+func orderByFields(clause string) []string {
+	var fields []string
+	for _, term := range strings.Split(clause, ",") {
+		term = strings.TrimSpace(term)
+		if i := strings.LastIndexAny(term, " \t"); i > 0 && !strings.HasSuffix(term, `"`) {
+			if direction := strings.ToUpper(term[i+1:]); direction == "ASC" || direction == "DESC" {
+				term = strings.TrimSpace(term[:i])
+			}
+		}
+		if term != "" {
+			fields = append(fields, term)
+		}
+	}
+	return fields
+}
+
+// maskLiterals blanks the contents of quoted strings, keeping their quotes and the offsets of
+// the rest of the query, so keywords inside values are not mistaken for syntax
+func maskLiterals(jql string) string {
+	masked := []byte(jql)
+	var quote byte
+	for i := 0; i < len(masked); i++ {
+		c := masked[i]
+		switch {
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote != 0 && c == '\\' && i+1 < len(masked):
+			masked[i], masked[i+1] = ' ', ' '
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			masked[i] = ' '
+		}
+	}
+	return string(masked)
+}
@@ -0,0 +1,99 @@
+package jql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeCost(t *testing.T) {
+	tests := []struct {
+		name  string
+		jql   string
+		rules []string
+	}{
+		{
+			name: "efficient query",
+			jql:  `project = PROJ AND status = "In Progress" ORDER BY key ASC`,
+		},
+		{
+			name:  "leading wildcard",
+			jql:   `project = PROJ AND text ~ '*foo'`,
+			rules: []string{CostRuleLeadingWildcard},
+		},
+		{
+			name: "trailing wildcard",
+			jql:  `project = PROJ AND summary ~ "foo*"`,
+		},
+		{
+			name:  "unbounded query",
+			jql:   `assignee = currentUser() AND resolution = Unresolved`,
+			rules: []string{CostRuleUnboundedQuery},
+		},
+		{
+			name: "quoted epic link bounds the query",
+			jql:  `"Epic Link" = PROJ-1 AND (status = Done)`,
+		},
+		{
+			name:  "history search",
+			jql:   `project = PROJ AND status CHANGED AFTER -1w`,
+			rules: []string{CostRuleHistorySearch},
+		},
+		{
+			name: "keywords inside values are ignored",
+			jql:  `project = PROJ AND summary ~ "what was changed" AND labels = 'issueFunction'`,
+		},
+		{
+			name:  "scripted function",
+			jql:   `project = PROJ AND issueFunction in subtasksOf("project = PROJ")`,
+			rules: []string{CostRuleScriptedFunction},
+		},
+		{
+			name:  "unindexed sort",
+			jql:   `project = PROJ ORDER BY summary DESC, cf[10001], priority DESC, key`,
+			rules: []string{CostRuleUnindexedSort, CostRuleUnindexedSort},
+		},
+		{
+			name: "empty query",
+			jql:  "  ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules []string
+			for _, warning := range AnalyzeCost(tt.jql) {
+				rules = append(rules, warning.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.rules) {
+				t.Errorf("AnalyzeCost(%q) rules = %v, want %v", tt.jql, rules, tt.rules)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCost_Messages(t *testing.T) {
+	warnings := AnalyzeCost(`project = PROJ AND text ~ '*foo' ORDER BY description`)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %+v", warnings)
+	}
+	if want := "text ~ '*foo' may be slow"; !strings.HasPrefix(warnings[0].Message, want) {
+		t.Errorf("Message = %q, want prefix %q", warnings[0].Message, want)
+	}
+	if want := "ORDER BY description may be slow"; !strings.HasPrefix(warnings[1].Message, want) {
+		t.Errorf("Message = %q, want prefix %q", warnings[1].Message, want)
+	}
+}
+
+func TestValidateQuery_CostWarnings(t *testing.T) {
+	result, err := NewJIRAQueryBuilder(nil, nil, nil).ValidateQuery(`summary ~ "*timeout"`)
+	if err != nil {
+		t.Fatalf("ValidateQuery() error = %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("Expected cost warnings not to invalidate the query, got %+v", result)
+	}
+	if len(result.Warnings) != 2 {
+		t.Errorf("Expected leading wildcard and unbounded query warnings, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,80 @@
+package jql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// EpicMembershipJQL returns the query selecting the issues linked to an EPIC
+func EpicMembershipJQL(epicKey string) string {
+	return fmt.Sprintf(`"Epic Link" = %s`, epicKey)
+}
+
+// ScopeToEpic combines EPIC membership with an optional sub-filter such as "component = Payments"
+// The filter is parenthesized, so OR clauses cannot widen the result beyond the EPIC. Ordering
+// is rejected inside the filter because it is applied to the whole query by --order-by.
+func ScopeToEpic(epicKey, within string) (string, error) {
+	if _, _, err := parseEpicKey(epicKey); err != nil {
+		return "", NewValidationError(err.Error(), epicKey)
+	}
+
+	membership := EpicMembershipJQL(epicKey)
+	within = strings.TrimSpace(within)
+	if within == "" {
+		return membership, nil
+	}
+
+	if HasOrderBy(within) {
+		return "", NewValidationError("EPIC filter cannot contain ORDER BY (use --order-by)", within)
+	}
+	if problems := validateJQLSyntax(within); len(problems) > 0 {
+		return "", NewValidationError(fmt.Sprintf("invalid EPIC filter: %s", strings.Join(problems, ", ")), within)
+	}
+
+	return fmt.Sprintf("(%s) AND (%s)", membership, within), nil
+}
+
+// EpicSlice reports how much of an EPIC a filtered sync covers
+type EpicSlice struct {
+	EpicKey  string
+	Within   string
+	Total    int // issues in the EPIC
+	Included int // issues in the EPIC matching the filter
+}
+
+// FilteredOut returns the number of EPIC issues excluded by the filter
+func (s *EpicSlice) FilteredOut() int {
+	if s.Included > s.Total {
+		return 0
+	}
+	return s.Total - s.Included
+}
+
+// CountEpicSlice counts the EPIC's issues with and without the filter
+// Each count is a single search requesting one result, so no issue data is transferred.
+func CountEpicSlice(jiraClient client.Client, epicKey, within string) (*EpicSlice, error) {
+	scoped, err := ScopeToEpic(epicKey, within)
+	if err != nil {
+		return nil, err
+	}
+
+	membership := EpicMembershipJQL(epicKey)
+	_, total, err := jiraClient.SearchIssuesWithPagination(membership, 0, 1)
+	if err != nil {
+		return nil, NewQueryError(fmt.Sprintf("failed to count issues in EPIC %s", epicKey), membership, err)
+	}
+
+	slice := &EpicSlice{EpicKey: epicKey, Within: strings.TrimSpace(within), Total: total, Included: total}
+	if slice.Within == "" {
+		return slice, nil
+	}
+
+	_, included, err := jiraClient.SearchIssuesWithPagination(scoped, 0, 1)
+	if err != nil {
+		return nil, NewQueryError(fmt.Sprintf("failed to count filtered issues in EPIC %s", epicKey), scoped, err)
+	}
+	slice.Included = included
+	return slice, nil
+}
@@ -0,0 +1,67 @@
+package jql
+
+import (
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestScopeToEpic(t *testing.T) {
+	tests := []struct {
+		name     string
+		epicKey  string
+		within   string
+		expected string
+		wantErr  bool
+	}{
+		{name: "whole epic", epicKey: "PROJ-1", expected: `"Epic Link" = PROJ-1`},
+		{name: "component slice", epicKey: "PROJ-1", within: " component = Payments ", expected: `("Epic Link" = PROJ-1) AND (component = Payments)`},
+		{name: "or stays inside epic", epicKey: "PROJ-1", within: "type = Story OR type = Bug", expected: `("Epic Link" = PROJ-1) AND (type = Story OR type = Bug)`},
+		{name: "invalid epic key", epicKey: "PROJ", within: "type = Story", wantErr: true},
+		{name: "ordering rejected", epicKey: "PROJ-1", within: "type = Story ORDER BY key", wantErr: true},
+		{name: "unbalanced filter", epicKey: "PROJ-1", within: `component = "Payments`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScopeToEpic(tt.epicKey, tt.within)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ScopeToEpic() expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ScopeToEpic() unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("ScopeToEpic() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountEpicSlice(t *testing.T) {
+	mockClient := client.NewMockClient()
+	for _, key := range []string{"PROJ-2", "PROJ-3", "PROJ-4"} {
+		mockClient.AddIssue(&client.Issue{Key: key})
+	}
+	mockClient.AddJQLResult(`"Epic Link" = PROJ-1`, []string{"PROJ-2", "PROJ-3", "PROJ-4"})
+	mockClient.AddJQLResult(`("Epic Link" = PROJ-1) AND (component = Payments)`, []string{"PROJ-3"})
+
+	slice, err := CountEpicSlice(mockClient, "PROJ-1", "component = Payments")
+	if err != nil {
+		t.Fatalf("CountEpicSlice() error = %v", err)
+	}
+	if slice.Total != 3 || slice.Included != 1 || slice.FilteredOut() != 2 {
+		t.Errorf("unexpected slice counts: total=%d included=%d filtered=%d", slice.Total, slice.Included, slice.FilteredOut())
+	}
+
+	whole, err := CountEpicSlice(mockClient, "PROJ-1", "")
+	if err != nil {
+		t.Fatalf("CountEpicSlice() error = %v", err)
+	}
+	if whole.Included != 3 || whole.FilteredOut() != 0 {
+		t.Errorf("unfiltered EPIC should include every issue, got included=%d", whole.Included)
+	}
+}
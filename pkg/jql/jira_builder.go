@@ -183,6 +183,9 @@ func (qb *JIRAQueryBuilder) ValidateQuery(jql string) (*ValidationResult, error)
 	if strings.Count(jql, "OR") > 10 {
 		warnings = append(warnings, "many OR conditions may impact performance")
 	}
+	for _, warning := range AnalyzeCost(jql) {
+		warnings = append(warnings, warning.Message)
+	}
 	result.Warnings = warnings
 
 	// Overall validity
@@ -235,13 +238,29 @@ func (qb *JIRAQueryBuilder) OptimizeQuery(jql string) (*Query, error) {
 
 // PreviewQuery previews what issues a query would return without executing sync
 func (qb *JIRAQueryBuilder) PreviewQuery(jql string) (*PreviewResult, error) {
+	return qb.PreviewQueryWithSamples(jql, qb.options.MaxPreviewResults)
+}
+
+// PreviewQueryWithSamples previews a query with its first sampleSize matching issues
+// A sampleSize of 0 only counts the matches; breakdowns cover the sampled issues.
+func (qb *JIRAQueryBuilder) PreviewQueryWithSamples(jql string, sampleSize int) (*PreviewResult, error) {
+	if sampleSize < 0 || sampleSize > MaxPreviewSampleSize {
+		return nil, fmt.Errorf("preview sample size must be between 0 and %d, got %d", MaxPreviewSampleSize, sampleSize)
+	}
 	startTime := time.Now()
 
-	// Execute search with limited results using pagination
-	issues, totalCount, err := qb.client.SearchIssuesWithPagination(jql, 0, qb.options.MaxPreviewResults)
+	// JIRA returns its default page when asked for no results, so counting fetches a single issue
+	maxResults := sampleSize
+	if maxResults == 0 {
+		maxResults = 1
+	}
+	issues, totalCount, err := qb.client.SearchIssuesWithPagination(jql, 0, maxResults)
 	if err != nil {
 		return nil, fmt.Errorf("failed to preview query: %w", err)
 	}
+	if sampleSize == 0 {
+		issues = nil
+	}
 
 	// Build breakdowns
 	projectBreakdown := make(map[string]int)
@@ -287,6 +287,38 @@ func TestPreviewQuery(t *testing.T) {
 	assert.GreaterOrEqual(t, preview.ExecutionTimeMs, int64(0))
 }
 
+func TestPreviewQueryWithSamples(t *testing.T) {
+	mockClient := client.NewMockClient()
+	builder := NewJIRAQueryBuilder(mockClient, epic.NewMockEpicAnalyzer(), nil)
+	builder.queriesFile = filepath.Join(t.TempDir(), "test_queries.json")
+
+	keys := []string{"PROJ-1", "PROJ-2", "PROJ-3"}
+	for _, key := range keys {
+		mockClient.AddIssue(client.CreateTestIssue(key))
+	}
+	mockClient.AddJQLResult("project = PROJ", keys)
+
+	// Counting only keeps the default preview lightweight
+	preview, err := builder.PreviewQueryWithSamples("project = PROJ", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, preview.TotalCount)
+	assert.Empty(t, preview.SampleIssues)
+	assert.Empty(t, preview.StatusBreakdown)
+
+	preview, err = builder.PreviewQueryWithSamples("project = PROJ", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, preview.TotalCount)
+	require.Len(t, preview.SampleIssues, 2)
+	assert.Equal(t, "PROJ-1", preview.SampleIssues[0].Key)
+	assert.Equal(t, "PROJ-2", preview.SampleIssues[1].Key)
+	assert.Equal(t, 2, preview.ProjectBreakdown["PROJ"])
+
+	_, err = builder.PreviewQueryWithSamples("project = PROJ", MaxPreviewSampleSize+1)
+	assert.Error(t, err)
+	_, err = builder.PreviewQueryWithSamples("project = PROJ", -1)
+	assert.Error(t, err)
+}
+
 func TestPreviewQueryError(t *testing.T) {
 	// Create temporary directory for test
 	tempDir, err := os.MkdirTemp("", "jql_test_*")
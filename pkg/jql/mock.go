@@ -227,6 +227,23 @@ func (m *MockQueryBuilder) PreviewQuery(jql string) (*PreviewResult, error) {
 	return result, nil
 }
 
+// PreviewQueryWithSamples previews a mock JQL query, keeping at most sampleSize sample issues
+func (m *MockQueryBuilder) PreviewQueryWithSamples(jql string, sampleSize int) (*PreviewResult, error) {
+	result, err := m.PreviewQuery(jql)
+	if err != nil {
+		return nil, err
+	}
+
+	sampled := *result
+	if len(sampled.SampleIssues) > sampleSize {
+		sampled.SampleIssues = sampled.SampleIssues[:sampleSize]
+	}
+	if sampleSize == 0 {
+		sampled.SampleIssues = nil
+	}
+	return &sampled, nil
+}
+
 // GetTemplates returns mock templates
 func (m *MockQueryBuilder) GetTemplates() []*Template {
 	m.GetTemplatesCalls++
@@ -0,0 +1,73 @@
+package jql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultOrderBy is the ordering applied to JQL syncs when none is given
+// JIRA's default result order is not guaranteed to be stable between searches, so syncs
+// always request an explicit order to keep processing and generated files deterministic.
+const DefaultOrderBy = "key"
+
+// orderFieldPattern accepts plain field names, custom field references, and quoted names
+var orderFieldPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_.]*|cf\[\d+\]|"[^"]+")$`)
+
+// ParseOrderBy validates a comma-separated ordering such as "priority DESC, key"
+// and returns the normalized clause body ("priority DESC, key ASC"). Unless key is already
+// part of the ordering it is appended as a final tie-breaker, so the result order is total.
+func ParseOrderBy(spec string) (string, error) {
+	var terms []string
+	hasKey := false
+
+	for _, term := range strings.Split(spec, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		field, direction := term, "ASC"
+		if i := strings.LastIndexAny(term, " \t"); i > 0 && !strings.HasSuffix(term, `"`) {
+			field = strings.TrimSpace(term[:i])
+			direction = strings.ToUpper(term[i+1:])
+		}
+		if direction != "ASC" && direction != "DESC" {
+			return "", NewValidationError(fmt.Sprintf("invalid sort direction %q in %q (use ASC or DESC)", direction, term), spec)
+		}
+		if !orderFieldPattern.MatchString(field) {
+			return "", NewValidationError(fmt.Sprintf("invalid sort field %q", field), spec)
+		}
+
+		if strings.EqualFold(field, "key") || strings.EqualFold(field, "issuekey") {
+			hasKey = true
+		}
+		terms = append(terms, field+" "+direction)
+	}
+
+	if len(terms) == 0 {
+		return "", NewValidationError("order by must name at least one field", spec)
+	}
+	if !hasKey {
+		terms = append(terms, "key ASC")
+	}
+	return strings.Join(terms, ", "), nil
+}
+
+// HasOrderBy reports whether a query already carries an ORDER BY clause
+func HasOrderBy(jql string) bool {
+	return orderByPattern.MatchString(jql)
+}
+
+// ApplyOrderBy sets the ORDER BY clause of a query, replacing any existing clause
+// orderBy must already be normalized by ParseOrderBy.
+func ApplyOrderBy(jql, orderBy string) string {
+	query := strings.TrimSpace(jql)
+	if loc := orderByPattern.FindStringIndex(query); loc != nil {
+		query = strings.TrimSpace(query[:loc[0]])
+	}
+	if query == "" {
+		return "ORDER BY " + orderBy
+	}
+	return query + " ORDER BY " + orderBy
+}
@@ -0,0 +1,63 @@
+package jql
+
+import "testing"
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+		wantErr  bool
+	}{
+		{spec: "key", expected: "key ASC"},
+		{spec: "priority DESC, key", expected: "priority DESC, key ASC"},
+		{spec: "updated desc", expected: "updated DESC, key ASC"},
+		{spec: `"Epic Link", created ASC`, expected: `"Epic Link" ASC, created ASC, key ASC`},
+		{spec: "cf[12311140] DESC,issuekey DESC", expected: "cf[12311140] DESC, issuekey DESC"},
+		{spec: "", wantErr: true},
+		{spec: " , ", wantErr: true},
+		{spec: "priority SIDEWAYS", wantErr: true},
+		{spec: "key; DROP", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseOrderBy(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseOrderBy(%q) expected error, got %q", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOrderBy(%q) unexpected error: %v", tt.spec, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseOrderBy(%q) = %q, want %q", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyOrderBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		jql      string
+		expected string
+	}{
+		{name: "adds clause", jql: "project = PROJ", expected: "project = PROJ ORDER BY key ASC"},
+		{name: "replaces clause", jql: "project = PROJ order by updated DESC", expected: "project = PROJ ORDER BY key ASC"},
+		{name: "trims whitespace", jql: "  project = PROJ  ", expected: "project = PROJ ORDER BY key ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyOrderBy(tt.jql, "key ASC"); got != tt.expected {
+				t.Errorf("ApplyOrderBy() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if !HasOrderBy("project = PROJ ORDER BY key") || HasOrderBy("project = PROJ") {
+		t.Error("HasOrderBy() did not detect clause correctly")
+	}
+}
@@ -0,0 +1,59 @@
+package jql
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// projectClausePattern matches `project = X` and `project in (X, Y)`; negations never match
+	projectClausePattern = regexp.MustCompile(`(?i)\bproject\s*(?:=|\bin\b)\s*(\([^)]*\)|"[^"]*"|'[^']*'|[\w-]+)`)
+
+	// issueClausePattern matches clauses whose values are issue keys
+	issueClausePattern = regexp.MustCompile(`(?i)(?:\bkey|\bissuekey|\bparent|"epic link")\s*(?:=|\bin\b)\s*(\([^)]*\)|"[^"]*"|'[^']*'|[\w-]+)`)
+
+	issueKeyValuePattern   = regexp.MustCompile(`\b([A-Za-z][A-Za-z0-9_]*)-\d+\b`)
+	projectKeyValuePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+)
+
+// ProjectKeys returns the project keys a query names explicitly, sorted and uppercased
+// Projects come from project clauses and from the keys in key, parent, and EPIC link clauses.
+// Project names and IDs are ignored. An empty result means the projects cannot be known
+// without running the query.
+func ProjectKeys(query string) []string {
+	seen := make(map[string]bool)
+
+	for _, match := range projectClausePattern.FindAllStringSubmatch(query, -1) {
+		for _, value := range splitClauseValues(match[1]) {
+			if projectKeyValuePattern.MatchString(value) {
+				seen[strings.ToUpper(value)] = true
+			}
+		}
+	}
+	for _, match := range issueClausePattern.FindAllStringSubmatch(query, -1) {
+		for _, key := range issueKeyValuePattern.FindAllStringSubmatch(match[1], -1) {
+			seen[strings.ToUpper(key[1])] = true
+		}
+	}
+
+	projects := make([]string, 0, len(seen))
+	for project := range seen {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	return projects
+}
+
+// splitClauseValues splits a clause value such as `(A, "B")` into unquoted values
+func splitClauseValues(value string) []string {
+	value = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value), "("), ")")
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.Trim(strings.TrimSpace(part), `"'`)
+		if part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,29 @@
+package jql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectKeys(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`project = PROJ AND status = Open`, []string{"PROJ"}},
+		{`project in (proj, "OPS", 'Web_2') ORDER BY key`, []string{"OPS", "PROJ", "WEB_2"}},
+		{`key in (PROJ-1,OPS-22, PROJ-3)`, []string{"OPS", "PROJ"}},
+		{`"Epic Link" = PLAT-7`, []string{"PLAT"}},
+		{`(project = A OR parent = B-9) AND issuekey = C-1`, []string{"A", "B", "C"}},
+		{`project != PROJ`, []string{}},
+		{`project not in (PROJ)`, []string{}},
+		{`project = "My Project"`, []string{}},
+		{`assignee = currentUser()`, []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := ProjectKeys(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ProjectKeys(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package jql
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateVariableEnvPrefix is the environment prefix for JQL template variables
+// JQL_VAR_Project=PROJ provides {{.Project}}.
+const TemplateVariableEnvPrefix = "JQL_VAR_"
+
+// TemplateEngine resolves JQL containing Go text/template syntax at sync time
+// Queries such as `project = {{.Project}} AND updated >= {{quote .Since}}` let one profile
+// serve many parameterized runs. Every referenced variable must be supplied before execution.
+type TemplateEngine struct {
+	funcs template.FuncMap
+}
+
+// NewTemplateEngine creates a template engine with the built-in JQL helper functions
+//
+//	quote  wraps a value in double quotes, escaping embedded quotes and backslashes
+//	list   splits a comma-separated value into a quoted JQL list for use with IN
+//	upper  upper-cases a value (useful for project keys)
+//	lower  lower-cases a value
+func NewTemplateEngine() *TemplateEngine {
+	return &TemplateEngine{
+		funcs: template.FuncMap{
+			"quote": quoteJQLValue,
+			"list":  jqlValueList,
+			"upper": strings.ToUpper,
+			"lower": strings.ToLower,
+		},
+	}
+}
+
+// RegisterFunc adds a custom template function, replacing any existing function with the same name
+func (e *TemplateEngine) RegisterFunc(name string, fn interface{}) {
+	e.funcs[name] = fn
+}
+
+// IsTemplate reports whether a JQL string contains template syntax
+func IsTemplate(jql string) bool {
+	return strings.Contains(jql, "{{")
+}
+
+// Variables returns the sorted, de-duplicated variable names referenced by a JQL template
+func (e *TemplateEngine) Variables(jql string) ([]string, error) {
+	tmpl, err := e.parse(jql)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	collectTemplateVariables(tmpl.Tree.Root, seen)
+
+	variables := make([]string, 0, len(seen))
+	for name := range seen {
+		variables = append(variables, name)
+	}
+	sort.Strings(variables)
+
+	return variables, nil
+}
+
+// Resolve renders a JQL template with the given variables
+// JQL without template syntax is returned unchanged. All referenced variables are checked up
+// front so a single error names every missing value instead of failing on the first one.
+func (e *TemplateEngine) Resolve(jql string, variables map[string]string) (string, error) {
+	if !IsTemplate(jql) {
+		return jql, nil
+	}
+
+	referenced, err := e.Variables(jql)
+	if err != nil {
+		return "", err
+	}
+
+	var missing []string
+	for _, name := range referenced {
+		if _, exists := variables[name]; !exists {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", &JQLError{
+			Type:    ErrorTypeParameterMissing,
+			Message: fmt.Sprintf("unresolved JQL template variable(s): %s", strings.Join(missing, ", ")),
+			Context: map[string]interface{}{
+				"jql":       jql,
+				"variables": missing,
+			},
+		}
+	}
+
+	tmpl, err := e.parse(jql)
+	if err != nil {
+		return "", err
+	}
+
+	var resolved strings.Builder
+	if err := tmpl.Execute(&resolved, variables); err != nil {
+		return "", NewTemplateError("failed to resolve JQL template", jql, err)
+	}
+
+	return strings.TrimSpace(resolved.String()), nil
+}
+
+// parse parses a JQL template, failing on references to undefined variables at execution time
+func (e *TemplateEngine) parse(jql string) (*template.Template, error) {
+	tmpl, err := template.New("jql").Funcs(e.funcs).Option("missingkey=error").Parse(jql)
+	if err != nil {
+		return nil, NewTemplateError("invalid JQL template syntax", jql, err)
+	}
+	return tmpl, nil
+}
+
+// collectTemplateVariables walks a template parse tree recording top-level field references
+func collectTemplateVariables(node parse.Node, seen map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateVariables(child, seen)
+		}
+	case *parse.ActionNode:
+		collectTemplateVariables(n.Pipe, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateVariables(cmd, seen)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectTemplateVariables(arg, seen)
+		}
+	case *parse.FieldNode:
+		if len(n.Ident) > 0 {
+			seen[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		// $.Name always refers to the root variables
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			seen[n.Ident[1]] = true
+		}
+	case *parse.IfNode:
+		collectTemplateVariables(n.Pipe, seen)
+		collectTemplateVariables(n.List, seen)
+		collectTemplateVariables(n.ElseList, seen)
+	case *parse.RangeNode:
+		// Dot is rebound inside range/with bodies, so only the pipeline refers to root variables
+		collectTemplateVariables(n.Pipe, seen)
+		collectTemplateVariables(n.ElseList, seen)
+	case *parse.WithNode:
+		collectTemplateVariables(n.Pipe, seen)
+		collectTemplateVariables(n.ElseList, seen)
+	}
+}
+
+// TemplateVariablesFromEnv collects JQL template variables from JQL_VAR_* environment variables
+func TemplateVariablesFromEnv() map[string]string {
+	variables := make(map[string]string)
+	for _, entry := range os.Environ() {
+		key, value, found := strings.Cut(entry, "=")
+		if !found || !strings.HasPrefix(key, TemplateVariableEnvPrefix) {
+			continue
+		}
+		if name := strings.TrimPrefix(key, TemplateVariableEnvPrefix); name != "" {
+			variables[name] = value
+		}
+	}
+	return variables
+}
+
+// ParseTemplateVariables parses Name=value pairs (as given to --jql-var) into a variable map
+func ParseTemplateVariables(pairs []string) (map[string]string, error) {
+	variables := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, found := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, NewValidationError(fmt.Sprintf("invalid JQL variable %q, expected Name=value", pair), "")
+		}
+		variables[name] = value
+	}
+	return variables, nil
+}
+
+// quoteJQLValue renders a value as a double-quoted JQL string literal
+func quoteJQLValue(value string) string {
+	escaped := strings.ReplaceAll(value, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `"`, `\"`)
+	return `"` + escaped + `"`
+}
+
+// jqlValueList renders a comma-separated value as a parenthesized list of quoted JQL strings
+func jqlValueList(value string) string {
+	parts := strings.Split(value, ",")
+	quoted := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			quoted = append(quoted, quoteJQLValue(part))
+		}
+	}
+	return "(" + strings.Join(quoted, ", ") + ")"
+}
@@ -0,0 +1,127 @@
+package jql
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTemplateEngine_Resolve(t *testing.T) {
+	engine := NewTemplateEngine()
+
+	tests := []struct {
+		name      string
+		jql       string
+		variables map[string]string
+		expected  string
+	}{
+		{
+			name:      "plain JQL unchanged",
+			jql:       "project = PROJ",
+			variables: nil,
+			expected:  "project = PROJ",
+		},
+		{
+			name:      "simple substitution",
+			jql:       "project = {{.Project}} AND updated >= {{.Since}}",
+			variables: map[string]string{"Project": "PROJ", "Since": "-7d"},
+			expected:  "project = PROJ AND updated >= -7d",
+		},
+		{
+			name:      "quote helper escapes",
+			jql:       `summary ~ {{quote .Text}}`,
+			variables: map[string]string{"Text": `say "hi"`},
+			expected:  `summary ~ "say \"hi\""`,
+		},
+		{
+			name:      "list helper",
+			jql:       "status IN {{list .Statuses}}",
+			variables: map[string]string{"Statuses": "To Do, In Progress"},
+			expected:  `status IN ("To Do", "In Progress")`,
+		},
+		{
+			name:      "conditional clause",
+			jql:       `project = {{upper .Project}}{{if .Assignee}} AND assignee = {{.Assignee}}{{end}}`,
+			variables: map[string]string{"Project": "proj", "Assignee": ""},
+			expected:  "project = PROJ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := engine.Resolve(tt.jql, tt.variables)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Resolve() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTemplateEngine_ResolveMissingVariables(t *testing.T) {
+	engine := NewTemplateEngine()
+
+	_, err := engine.Resolve("project = {{.Project}} AND updated >= {{.Since}} AND fixVersion = {{$.Version}}",
+		map[string]string{"Project": "PROJ"})
+	if err == nil {
+		t.Fatal("Expected error for unresolved variables")
+	}
+
+	var jqlErr *JQLError
+	if !errors.As(err, &jqlErr) || jqlErr.Type != ErrorTypeParameterMissing {
+		t.Fatalf("Expected parameter missing error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Since, Version") {
+		t.Errorf("Expected all missing variables named, got %v", err)
+	}
+}
+
+func TestTemplateEngine_InvalidSyntax(t *testing.T) {
+	_, err := NewTemplateEngine().Resolve("project = {{.Project", map[string]string{"Project": "PROJ"})
+
+	var jqlErr *JQLError
+	if !errors.As(err, &jqlErr) || jqlErr.Type != ErrorTypeTemplate {
+		t.Fatalf("Expected template error, got %v", err)
+	}
+}
+
+func TestTemplateEngine_Variables(t *testing.T) {
+	engine := NewTemplateEngine()
+	engine.RegisterFunc("days", func(n string) string { return "-" + n + "d" })
+
+	variables, err := engine.Variables(`project = {{.Project}} AND updated >= {{days .Window}}{{with .Label}} AND labels = {{.}}{{end}} AND project = {{.Project}}`)
+	if err != nil {
+		t.Fatalf("Variables() error = %v", err)
+	}
+
+	expected := []string{"Label", "Project", "Window"}
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("Variables() = %v, want %v", variables, expected)
+	}
+}
+
+func TestParseTemplateVariables(t *testing.T) {
+	variables, err := ParseTemplateVariables([]string{"Project=PROJ", "Filter=status = Done"})
+	if err != nil {
+		t.Fatalf("ParseTemplateVariables() error = %v", err)
+	}
+	if variables["Project"] != "PROJ" || variables["Filter"] != "status = Done" {
+		t.Errorf("Unexpected variables: %v", variables)
+	}
+
+	if _, err := ParseTemplateVariables([]string{"missing-separator"}); err == nil {
+		t.Error("Expected error for pair without '='")
+	}
+}
+
+func TestTemplateVariablesFromEnv(t *testing.T) {
+	t.Setenv(TemplateVariableEnvPrefix+"Project", "ENVPROJ")
+
+	variables := TemplateVariablesFromEnv()
+	if variables["Project"] != "ENVPROJ" {
+		t.Errorf("Expected Project from environment, got %v", variables)
+	}
+}
@@ -0,0 +1,43 @@
+package jql
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// orderByPattern locates a trailing ORDER BY clause so filters can be inserted before it
+var orderByPattern = regexp.MustCompile(`(?i)\s+order\s+by\s+`)
+
+// RestrictUpdatedSince narrows a query to issues updated at or after since, as of now
+// An absolute JQL datetime is read in the JIRA user's time zone, which need not be this host's,
+// so the bound is relative to JIRA's clock instead: the minutes between since and now, rounded
+// up, since re-syncing an issue updated a minute early is harmless while missing one is not.
+// An existing ORDER BY clause is preserved.
+func RestrictUpdatedSince(jql string, since, now time.Time) string {
+	minutes := int64(math.Ceil(now.Sub(since).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	clause := fmt.Sprintf("updated >= -%dm", minutes)
+
+	query := strings.TrimSpace(jql)
+	orderBy := ""
+	// Quoted text may itself read "order by", so only clauses outside literals are matched
+	if loc := orderByPattern.FindStringIndex(maskLiterals(query)); loc != nil {
+		orderBy = query[loc[0]:]
+		query = strings.TrimSpace(query[:loc[0]])
+	}
+
+	if query == "" {
+		return clause + orderBy
+	}
+	return fmt.Sprintf("(%s) AND %s%s", query, clause, orderBy)
+}
+
+// IssueKeysQuery builds a query matching exactly the given issue keys
+func IssueKeysQuery(issueKeys []string) string {
+	return fmt.Sprintf("key in (%s)", strings.Join(issueKeys, ", "))
+}
@@ -0,0 +1,82 @@
+package jql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRestrictUpdatedSince(t *testing.T) {
+	now := time.Date(2024, 3, 5, 16, 0, 0, 0, time.UTC)
+	since := now.Add(-(90*time.Minute + 18*time.Second))
+
+	tests := []struct {
+		name     string
+		jql      string
+		since    time.Time
+		expected string
+	}{
+		{
+			name:     "simple query",
+			jql:      "project = PROJ",
+			since:    since,
+			expected: `(project = PROJ) AND updated >= -91m`,
+		},
+		{
+			name:     "or query is grouped",
+			jql:      "project = A OR project = B",
+			since:    since,
+			expected: `(project = A OR project = B) AND updated >= -91m`,
+		},
+		{
+			name:     "order by preserved",
+			jql:      "project = PROJ order by updated DESC",
+			since:    since,
+			expected: `(project = PROJ) AND updated >= -91m order by updated DESC`,
+		},
+		{
+			name:     "quoted order by is not a clause",
+			jql:      `summary ~ "sort order by date" order by key`,
+			since:    since,
+			expected: `(summary ~ "sort order by date") AND updated >= -91m order by key`,
+		},
+		{
+			name:     "empty query",
+			jql:      "  ",
+			since:    since,
+			expected: `updated >= -91m`,
+		},
+		{
+			name:     "whole minutes are not rounded up",
+			jql:      "project = PROJ",
+			since:    now.Add(-2 * time.Hour),
+			expected: `(project = PROJ) AND updated >= -120m`,
+		},
+		{
+			name:     "since in the future",
+			jql:      "project = PROJ",
+			since:    now.Add(time.Minute),
+			expected: `(project = PROJ) AND updated >= -1m`,
+		},
+		{
+			name:     "time zone of since is irrelevant",
+			jql:      "project = PROJ",
+			since:    since.In(time.FixedZone("UTC+9", 9*60*60)),
+			expected: `(project = PROJ) AND updated >= -91m`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RestrictUpdatedSince(tt.jql, tt.since, now); got != tt.expected {
+				t.Errorf("RestrictUpdatedSince() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIssueKeysQuery(t *testing.T) {
+	got := IssueKeysQuery([]string{"PROJ-1", "PROJ-2"})
+	if got != "key in (PROJ-1, PROJ-2)" {
+		t.Errorf("IssueKeysQuery() = %q", got)
+	}
+}
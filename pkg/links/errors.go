@@ -32,6 +32,7 @@ const (
 	ErrorTypeBrokenLink        = "broken_link"
 	ErrorTypeTargetAccess      = "target_access_error"
 	ErrorTypeCleanup           = "cleanup_error"
+	ErrorTypeIndexWrite        = "index_write_error"
 )
 
 // Helper functions for creating common errors
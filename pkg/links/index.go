@@ -0,0 +1,121 @@
+package links
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// RelationshipIndexFile is the path of the relationship index below the repository root
+const RelationshipIndexFile = "relationships/index.yaml"
+
+// Relationship types of the index that are not JIRA issue link types
+// Both point from the contained issue to its container.
+const (
+	IndexTypeEpic   = "epic"   // from is in the EPIC to
+	IndexTypeParent = "parent" // from is a sub-task of to
+)
+
+// IndexedRelationship is one edge of the relationship graph
+// Issue links are recorded in their outward direction under their JIRA link type name, so a
+// link stored on both of its issues appears once.
+type IndexedRelationship struct {
+	From string `yaml:"from"`
+	Type string `yaml:"type"`
+	To   string `yaml:"to"`
+}
+
+// RelationshipIndex lists every relationship recorded in a repository's issue files
+// It intentionally carries no timestamps so regenerating an unchanged graph is a no-op.
+type RelationshipIndex struct {
+	RelationshipCount int                   `yaml:"relationship_count"`
+	Relationships     []IndexedRelationship `yaml:"relationships"`
+}
+
+// BuildRelationshipIndex reads the issue files of every project and collects their relationships,
+// sorted by from, type, and to
+// The index is built from the files on disk rather than from sync results, so relationships of
+// pruned or deleted issues drop out on the next regeneration.
+func BuildRelationshipIndex(repoPath string) (*RelationshipIndex, error) {
+	issueFiles, err := filepath.Glob(filepath.Join(repoPath, "projects", "*", "issues", "*.yaml"))
+	if err != nil {
+		return nil, &LinkError{Type: ErrorTypeInvalidInput, Message: "failed to list issue files", Err: err}
+	}
+
+	seen := make(map[IndexedRelationship]bool)
+	index := &RelationshipIndex{Relationships: []IndexedRelationship{}}
+	add := func(from, relType, to string) {
+		edge := IndexedRelationship{From: from, Type: relType, To: to}
+		if from == "" || to == "" || seen[edge] {
+			return
+		}
+		seen[edge] = true
+		index.Relationships = append(index.Relationships, edge)
+	}
+
+	for _, issueFile := range issueFiles {
+		issue, err := readIssueRelationships(issueFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", issueFile, err)
+		}
+		rel := issue.Relationships
+		if rel == nil {
+			continue
+		}
+		add(issue.Key, IndexTypeEpic, rel.EpicLink)
+		add(issue.Key, IndexTypeParent, rel.ParentIssue)
+		for _, subtask := range rel.Subtasks {
+			add(subtask, IndexTypeParent, issue.Key)
+		}
+		for _, link := range rel.IssueLinks {
+			if link.Direction == "inward" {
+				add(link.IssueKey, link.Type, issue.Key)
+			} else {
+				add(issue.Key, link.Type, link.IssueKey)
+			}
+		}
+	}
+
+	sort.Slice(index.Relationships, func(i, j int) bool {
+		a, b := index.Relationships[i], index.Relationships[j]
+		if c := client.CompareIssueKeys(a.From, b.From); c != 0 {
+			return c < 0
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return client.CompareIssueKeys(a.To, b.To) < 0
+	})
+	index.RelationshipCount = len(index.Relationships)
+	return index, nil
+}
+
+// WriteRelationshipIndex regenerates the relationship index of a repository
+// Returns the index path and whether its content changed; unchanged files are not rewritten.
+func WriteRelationshipIndex(repoPath string) (string, bool, error) {
+	index, err := BuildRelationshipIndex(repoPath)
+	if err != nil {
+		return "", false, err
+	}
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		return "", false, &LinkError{Type: ErrorTypeIndexWrite, Message: "failed to marshal relationship index", Err: err}
+	}
+
+	indexPath := filepath.Join(repoPath, filepath.FromSlash(RelationshipIndexFile))
+	if existing, err := os.ReadFile(indexPath); err == nil && bytes.Equal(existing, data) {
+		return indexPath, false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(indexPath), 0755); err != nil {
+		return "", false, NewDirectoryCreationError(filepath.Dir(indexPath), err)
+	}
+	if err := os.WriteFile(indexPath, data, 0644); err != nil {
+		return "", false, &LinkError{Type: ErrorTypeIndexWrite, Message: fmt.Sprintf("failed to write relationship index: %s", indexPath), Err: err}
+	}
+	return indexPath, true, nil
+}
@@ -0,0 +1,65 @@
+package links
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuildRelationshipIndex(t *testing.T) {
+	repoPath := t.TempDir()
+	writeIssueFile(t, repoPath, "PROJ-10", "key: PROJ-10\nsummary: Epic\n")
+	writeIssueFile(t, repoPath, "PROJ-2", "key: PROJ-2\nrelationships:\n  epic_link: PROJ-10\n  subtasks: [PROJ-3]\n"+
+		"  issue_links:\n    - {type: Blocks, direction: outward, issue_key: OTHER-1}\n")
+	writeIssueFile(t, repoPath, "PROJ-3", "key: PROJ-3\nrelationships:\n  parent_issue: PROJ-2\n")
+	writeIssueFile(t, repoPath, "OTHER-1", "key: OTHER-1\nrelationships:\n  issue_links:\n"+
+		"    - {type: Blocks, direction: inward, issue_key: PROJ-2}\n    - {type: Clones, direction: outward, issue_key: PROJ-3}\n")
+
+	index, err := BuildRelationshipIndex(repoPath)
+	if err != nil {
+		t.Fatalf("BuildRelationshipIndex() error = %v", err)
+	}
+	// Links recorded on both issues appear once, in their outward direction
+	want := []IndexedRelationship{
+		{From: "OTHER-1", Type: "Clones", To: "PROJ-3"},
+		{From: "PROJ-2", Type: "Blocks", To: "OTHER-1"},
+		{From: "PROJ-2", Type: IndexTypeEpic, To: "PROJ-10"},
+		{From: "PROJ-3", Type: IndexTypeParent, To: "PROJ-2"},
+	}
+	if !reflect.DeepEqual(index.Relationships, want) || index.RelationshipCount != len(want) {
+		t.Errorf("Relationships = %+v, want %+v", index.Relationships, want)
+	}
+}
+
+func TestWriteRelationshipIndex(t *testing.T) {
+	repoPath := t.TempDir()
+	writeIssueFile(t, repoPath, "PROJ-1", "key: PROJ-1\n")
+	writeIssueFile(t, repoPath, "PROJ-2", "key: PROJ-2\nrelationships:\n  epic_link: PROJ-1\n")
+
+	indexPath, changed, err := WriteRelationshipIndex(repoPath)
+	if err != nil {
+		t.Fatalf("WriteRelationshipIndex() error = %v", err)
+	}
+	if !changed || indexPath != filepath.Join(repoPath, "relationships", "index.yaml") {
+		t.Errorf("Expected the index to be written to relationships/index.yaml, got %s (changed %t)", indexPath, changed)
+	}
+	if _, changed, _ := WriteRelationshipIndex(repoPath); changed {
+		t.Error("Expected regenerating an unchanged graph to be a no-op")
+	}
+
+	// Removing an issue file drops its relationships on the next regeneration
+	if err := os.Remove(filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-2.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if _, changed, err := WriteRelationshipIndex(repoPath); err != nil || !changed {
+		t.Fatalf("Expected the index to change after a removal, got %t, %v", changed, err)
+	}
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "relationship_count: 0\nrelationships: []\n" {
+		t.Errorf("Unexpected index after removal:\n%s", data)
+	}
+}
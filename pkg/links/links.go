@@ -21,15 +21,39 @@ type LinkManager interface {
 
 // SymbolicLinkManager implements LinkManager using OS symbolic links
 // Based on SPIKE-004 findings: 0.06ms per link creation on macOS
-type SymbolicLinkManager struct{}
+// It is safe for concurrent use: operations on the same directory or link path are serialized.
+type SymbolicLinkManager struct {
+	// Concurrency bounds parallel link creation per issue (0 uses DefaultLinkConcurrency)
+	Concurrency int
+	// Naming selects how links are named within their relationship directory (empty: LinkNamingKey)
+	Naming LinkNaming
+	// Extension is the extension of the issue files links point to (empty: .yaml)
+	Extension string
+
+	paths pathLocks
+}
 
 // NewSymbolicLinkManager creates a new symbolic link manager
 func NewSymbolicLinkManager() LinkManager {
 	return &SymbolicLinkManager{}
 }
 
+// NewSymbolicLinkManagerWithConcurrency creates a symbolic link manager that links up to
+// concurrency relationships of an issue in parallel
+func NewSymbolicLinkManagerWithConcurrency(concurrency int) LinkManager {
+	return &SymbolicLinkManager{Concurrency: concurrency}
+}
+
+// NewSymbolicLinkManagerWithNaming creates a symbolic link manager that names links per naming
+// and links up to concurrency relationships of an issue in parallel
+func NewSymbolicLinkManagerWithNaming(concurrency int, naming LinkNaming) LinkManager {
+	return &SymbolicLinkManager{Concurrency: concurrency, Naming: naming}
+}
+
 // CreateRelationshipLinks creates symbolic links for all relationships in an issue
-// Directory structure: /projects/{project}/relationships/{type}/{source-issue} -> ../../../issues/{target-issue}.yaml
+// Directory structure: /projects/{project}/relationships/{type}/{link-name} -> ../../../issues/{target-issue}.yaml
+// (or the target's file with Extension)
+// where the link name is the source issue key, or per Naming also carries the target and type.
 func (m *SymbolicLinkManager) CreateRelationshipLinks(issue *client.Issue, basePath string) error {
 	if issue == nil {
 		return &LinkError{
@@ -63,35 +87,42 @@ func (m *SymbolicLinkManager) CreateRelationshipLinks(issue *client.Issue, baseP
 		return fmt.Errorf("failed to create relationship directory structure: %w", err)
 	}
 
+	relationships := issue.Relationships
+	var jobs []linkJob
+
 	// Create epic link
-	if issue.Relationships.EpicLink != "" {
-		if err := m.createEpicLink(basePath, projectKey, issue.Key, issue.Relationships.EpicLink); err != nil {
-			return fmt.Errorf("failed to create epic link: %w", err)
-		}
+	if relationships.EpicLink != "" {
+		jobs = append(jobs, linkJob{
+			run:      func() error { return m.createEpicLink(basePath, projectKey, issue.Key, relationships.EpicLink) },
+			describe: "failed to create epic link",
+		})
 	}
 
 	// Create parent link for subtasks
-	if issue.Relationships.ParentIssue != "" {
-		if err := m.createSubtaskLink(basePath, projectKey, issue.Key, issue.Relationships.ParentIssue); err != nil {
-			return fmt.Errorf("failed to create subtask link: %w", err)
-		}
+	if relationships.ParentIssue != "" {
+		jobs = append(jobs, linkJob{
+			run:      func() error { return m.createSubtaskLink(basePath, projectKey, issue.Key, relationships.ParentIssue) },
+			describe: "failed to create subtask link",
+		})
 	}
 
 	// Create subtask links (reverse relationship)
-	for _, subtaskKey := range issue.Relationships.Subtasks {
-		if err := m.createParentLink(basePath, projectKey, issue.Key, subtaskKey); err != nil {
-			return fmt.Errorf("failed to create parent link for subtask %s: %w", subtaskKey, err)
-		}
+	for _, subtaskKey := range relationships.Subtasks {
+		jobs = append(jobs, linkJob{
+			run:      func() error { return m.createParentLink(basePath, projectKey, issue.Key, subtaskKey) },
+			describe: fmt.Sprintf("failed to create parent link for subtask %s", subtaskKey),
+		})
 	}
 
 	// Create issue links
-	for _, link := range issue.Relationships.IssueLinks {
-		if err := m.createIssueLink(basePath, projectKey, issue.Key, link); err != nil {
-			return fmt.Errorf("failed to create issue link %s: %w", link.Type, err)
-		}
+	for _, link := range relationships.IssueLinks {
+		jobs = append(jobs, linkJob{
+			run:      func() error { return m.createIssueLink(basePath, projectKey, issue.Key, link) },
+			describe: fmt.Sprintf("failed to create issue link %s", link.Type),
+		})
 	}
 
-	return nil
+	return m.runLinkJobs(jobs)
 }
 
 // CreateDirectoryStructure creates the relationships directory structure
@@ -116,7 +147,7 @@ func (m *SymbolicLinkManager) CreateDirectoryStructure(basePath, projectKey stri
 
 	for _, relType := range relationshipTypes {
 		relPath := filepath.Join(basePath, "projects", projectKey, "relationships", relType)
-		if err := os.MkdirAll(relPath, 0755); err != nil {
+		if err := m.ensureDir(relPath); err != nil {
 			return &LinkError{
 				Type:    "directory_creation_error",
 				Message: fmt.Sprintf("failed to create relationship directory: %s", relPath),
@@ -236,22 +267,30 @@ func (m *SymbolicLinkManager) GetRelationshipPath(basePath, projectKey, relation
 	return filepath.Join(basePath, "projects", projectKey, "relationships", relationshipType)
 }
 
+// issueFileName returns the name of an issue's file in its project's issues directory
+func (m *SymbolicLinkManager) issueFileName(issueKey string) string {
+	if m.Extension == "" {
+		return issueKey + ".yaml"
+	}
+	return issueKey + m.Extension
+}
+
 // Helper functions for creating specific relationship types
 
 func (m *SymbolicLinkManager) createEpicLink(basePath, projectKey, issueKey, epicKey string) error {
 	epicDir := m.GetRelationshipPath(basePath, projectKey, "epic")
-	linkPath := filepath.Join(epicDir, issueKey)
-	targetPath := "../../issues/" + epicKey + ".yaml"
+	linkPath := filepath.Join(epicDir, m.Naming.linkName("epic", issueKey, epicKey, ""))
+	targetPath := "../../issues/" + m.issueFileName(epicKey)
 
-	return m.createSymbolicLink(linkPath, targetPath, "epic")
+	return m.createNamedLink(linkPath, targetPath, "epic", issueKey, epicKey)
 }
 
 func (m *SymbolicLinkManager) createSubtaskLink(basePath, projectKey, subtaskKey, parentKey string) error {
 	parentDir := m.GetRelationshipPath(basePath, projectKey, "parent")
-	linkPath := filepath.Join(parentDir, subtaskKey)
-	targetPath := "../../issues/" + parentKey + ".yaml"
+	linkPath := filepath.Join(parentDir, m.Naming.linkName("parent", subtaskKey, parentKey, ""))
+	targetPath := "../../issues/" + m.issueFileName(parentKey)
 
-	return m.createSymbolicLink(linkPath, targetPath, "parent")
+	return m.createNamedLink(linkPath, targetPath, "parent", subtaskKey, parentKey)
 }
 
 func (m *SymbolicLinkManager) createParentLink(basePath, projectKey, parentKey, subtaskKey string) error {
@@ -259,7 +298,7 @@ func (m *SymbolicLinkManager) createParentLink(basePath, projectKey, parentKey,
 
 	// Create parent-specific directory for grouping subtasks
 	parentSubtasksDir := filepath.Join(subtasksDir, parentKey)
-	if err := os.MkdirAll(parentSubtasksDir, 0755); err != nil {
+	if err := m.ensureDir(parentSubtasksDir); err != nil {
 		return &LinkError{
 			Type:    "directory_creation_error",
 			Message: fmt.Sprintf("failed to create parent subtasks directory: %s", parentSubtasksDir),
@@ -267,8 +306,8 @@ func (m *SymbolicLinkManager) createParentLink(basePath, projectKey, parentKey,
 		}
 	}
 
-	linkPath := filepath.Join(parentSubtasksDir, subtaskKey)
-	targetPath := "../../../issues/" + subtaskKey + ".yaml"
+	linkPath := filepath.Join(parentSubtasksDir, m.Naming.linkName("subtask", subtaskKey, subtaskKey, ""))
+	targetPath := "../../../issues/" + m.issueFileName(subtaskKey)
 
 	return m.createSymbolicLink(linkPath, targetPath, "subtasks")
 }
@@ -292,7 +331,7 @@ func (m *SymbolicLinkManager) createIssueLink(basePath, projectKey, sourceKey st
 
 	// Create direction-specific subdirectory
 	directionDir := filepath.Join(linkDir, link.Direction)
-	if err := os.MkdirAll(directionDir, 0755); err != nil {
+	if err := m.ensureDir(directionDir); err != nil {
 		return &LinkError{
 			Type:    "directory_creation_error",
 			Message: fmt.Sprintf("failed to create direction directory: %s", directionDir),
@@ -300,13 +339,25 @@ func (m *SymbolicLinkManager) createIssueLink(basePath, projectKey, sourceKey st
 		}
 	}
 
-	linkPath := filepath.Join(directionDir, sourceKey)
-	targetPath := "../../../issues/" + link.IssueKey + ".yaml"
+	linkPath := filepath.Join(directionDir, m.Naming.linkName(link.Type, sourceKey, link.IssueKey, link.Summary))
+	targetPath := "../../../issues/" + m.issueFileName(link.IssueKey)
 
-	return m.createSymbolicLink(linkPath, targetPath, link.Type)
+	return m.createNamedLink(linkPath, targetPath, link.Type, sourceKey, link.IssueKey)
+}
+
+// createNamedLink creates a link, first removing the names the same relationship had before
+func (m *SymbolicLinkManager) createNamedLink(linkPath, targetPath, linkType, nameKey, targetKey string) error {
+	if err := m.removeRenamedLinks(linkPath, nameKey, targetKey); err != nil {
+		return err
+	}
+	return m.createSymbolicLink(linkPath, targetPath, linkType)
 }
 
 func (m *SymbolicLinkManager) createSymbolicLink(linkPath, targetPath, linkType string) error {
+	// Replacing a link is remove-then-create; keep concurrent replacements of one path apart
+	unlock := m.paths.lock(linkPath)
+	defer unlock()
+
 	// Remove existing link if it exists
 	if _, err := os.Lstat(linkPath); err == nil {
 		if err := os.Remove(linkPath); err != nil {
@@ -440,3 +440,35 @@ func TestCleanupBrokenLinks_Integration(t *testing.T) {
 		t.Error("Broken link was not removed")
 	}
 }
+
+func TestSymbolicLinkManager_Extension(t *testing.T) {
+	tempDir := t.TempDir()
+	issue := &client.Issue{
+		Key: "PROJ-123",
+		Relationships: &client.Relationships{
+			EpicLink: "PROJ-100",
+			IssueLinks: []client.IssueLink{
+				{Type: "blocks", Direction: "outward", IssueKey: "PROJ-200"},
+			},
+		},
+	}
+
+	manager := &SymbolicLinkManager{Extension: ".md"}
+	if err := manager.CreateRelationshipLinks(issue, tempDir); err != nil {
+		t.Fatalf("CreateRelationshipLinks failed: %v", err)
+	}
+
+	links := map[string]string{
+		filepath.Join("epic", "PROJ-123"):              "../../issues/PROJ-100.md",
+		filepath.Join("blocks", "outward", "PROJ-123"): "../../../issues/PROJ-200.md",
+	}
+	for link, want := range links {
+		target, err := os.Readlink(filepath.Join(tempDir, "projects", "PROJ", "relationships", link))
+		if err != nil {
+			t.Fatalf("Failed to read link %s: %v", link, err)
+		}
+		if target != want {
+			t.Errorf("Link %s targets %q, want %q", link, target, want)
+		}
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/chambrid/jira-cdc-git/pkg/client"
 )
 
 // MockLinkManager implements LinkManager for testing purposes
+// Its methods are safe for concurrent use, as sync workers create links in parallel.
 type MockLinkManager struct {
+	mu sync.Mutex
+
 	// Function fields allow test customization
 	CreateRelationshipLinksFunc  func(*client.Issue, string) error
 	CreateDirectoryStructureFunc func(string, string) error
@@ -37,6 +41,9 @@ func NewMockLinkManager() *MockLinkManager {
 }
 
 func (m *MockLinkManager) CreateRelationshipLinks(issue *client.Issue, basePath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.CallCount["CreateRelationshipLinks"]++
 
 	if m.CreateRelationshipLinksFunc != nil {
@@ -91,6 +98,9 @@ func (m *MockLinkManager) CreateRelationshipLinks(issue *client.Issue, basePath
 }
 
 func (m *MockLinkManager) CreateDirectoryStructure(basePath, projectKey string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.CallCount["CreateDirectoryStructure"]++
 
 	if m.CreateDirectoryStructureFunc != nil {
@@ -116,6 +126,9 @@ func (m *MockLinkManager) CreateDirectoryStructure(basePath, projectKey string)
 }
 
 func (m *MockLinkManager) ValidateLink(linkPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.CallCount["ValidateLink"]++
 	m.ValidatedLinks = append(m.ValidatedLinks, linkPath)
 
@@ -132,6 +145,9 @@ func (m *MockLinkManager) ValidateLink(linkPath string) error {
 }
 
 func (m *MockLinkManager) CleanupBrokenLinks(basePath, projectKey string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.CallCount["CleanupBrokenLinks"]++
 	m.CleanedUpProjects = append(m.CleanedUpProjects, projectKey)
 
@@ -152,6 +168,9 @@ func (m *MockLinkManager) CleanupBrokenLinks(basePath, projectKey string) error
 }
 
 func (m *MockLinkManager) GetRelationshipPath(basePath, projectKey, relationshipType string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.CallCount["GetRelationshipPath"]++
 
 	if m.GetRelationshipPathFunc != nil {
@@ -166,22 +185,34 @@ func (m *MockLinkManager) GetRelationshipPath(basePath, projectKey, relationship
 
 // GetCreatedLinksCount returns the number of links created
 func (m *MockLinkManager) GetCreatedLinksCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	return len(m.CreatedLinks)
 }
 
 // GetCreatedDirectoriesCount returns the number of directories created
 func (m *MockLinkManager) GetCreatedDirectoriesCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	return len(m.CreatedDirectories)
 }
 
 // HasCreatedLink checks if a specific link was created
 func (m *MockLinkManager) HasCreatedLink(linkPath, targetPath string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	target, exists := m.CreatedLinks[linkPath]
 	return exists && target == targetPath
 }
 
 // HasCreatedDirectory checks if a specific directory was created
 func (m *MockLinkManager) HasCreatedDirectory(dirPath string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	for _, created := range m.CreatedDirectories {
 		if created == dirPath {
 			return true
@@ -192,11 +223,17 @@ func (m *MockLinkManager) HasCreatedDirectory(dirPath string) bool {
 
 // GetCallCount returns the number of calls to a specific method
 func (m *MockLinkManager) GetCallCount(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	return m.CallCount[method]
 }
 
 // Reset clears all tracked state (useful between tests)
 func (m *MockLinkManager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.CreatedLinks = make(map[string]string)
 	m.CreatedDirectories = make([]string, 0)
 	m.ValidatedLinks = make([]string, 0)
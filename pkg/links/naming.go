@@ -0,0 +1,130 @@
+package links
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LinkNaming selects how relationship links are named within their relationship directory
+type LinkNaming string
+
+const (
+	// LinkNamingKey names a link by the synced issue's key alone (the default)
+	// An issue with several links of one type and direction keeps only the last of them.
+	LinkNamingKey LinkNaming = "key"
+	// LinkNamingKeySummary names a link by both issue keys and the linked issue's summary, when
+	// JIRA reports it (PROJ-1--PROJ-2--fix-login-bug)
+	LinkNamingKeySummary LinkNaming = "key-summary"
+	// LinkNamingTypeKey names a link by its relationship type and both issue keys
+	// (blocks--PROJ-1--PROJ-2)
+	LinkNamingTypeKey LinkNaming = "type-key"
+)
+
+// LinkNameSeparator joins the parts of link names; it never occurs in an issue key or a slug
+const LinkNameSeparator = "--"
+
+// maxSummarySlugLength bounds the summary part of key-summary link names
+const maxSummarySlugLength = 48
+
+// ParseLinkNaming validates a --link-naming value; empty selects the key scheme
+func ParseLinkNaming(value string) (LinkNaming, error) {
+	switch naming := LinkNaming(strings.ToLower(strings.TrimSpace(value))); naming {
+	case "":
+		return LinkNamingKey, nil
+	case LinkNamingKey, LinkNamingKeySummary, LinkNamingTypeKey:
+		return naming, nil
+	default:
+		return "", fmt.Errorf("unknown link naming %q (use %s, %s, or %s)", value, LinkNamingKey, LinkNamingKeySummary, LinkNamingTypeKey)
+	}
+}
+
+// linkName names the link of an issue (nameKey) to targetKey in a relationship directory
+// Names only depend on the relationship itself, so re-syncing an unchanged issue recreates the
+// same names. The target key is left out when it is the name key (subtask links).
+func (n LinkNaming) linkName(relationshipType, nameKey, targetKey, summary string) string {
+	if n == "" || n == LinkNamingKey {
+		return nameKey
+	}
+
+	parts := []string{nameKey}
+	if targetKey != nameKey {
+		parts = append(parts, targetKey)
+	}
+	switch n {
+	case LinkNamingKeySummary:
+		if slug := slugify(summary, maxSummarySlugLength); slug != "" {
+			parts = append(parts, slug)
+		}
+	case LinkNamingTypeKey:
+		parts = append([]string{slugify(relationshipType, 0)}, parts...)
+	}
+	return strings.Join(parts, LinkNameSeparator)
+}
+
+// slugify lowercases text to ASCII letters and digits separated by single dashes, cut to
+// maxLength bytes when positive
+func slugify(text string, maxLength int) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	slug := b.String()
+	if maxLength > 0 && len(slug) > maxLength {
+		slug = strings.TrimRight(slug[:maxLength], "-")
+	}
+	return slug
+}
+
+// removeRenamedLinks deletes the links a relationship had under an earlier summary
+// Only key-summary names change while the relationship stays the same; other links of the
+// directory are left alone.
+func (m *SymbolicLinkManager) removeRenamedLinks(linkPath, nameKey, targetKey string) error {
+	if m.Naming != LinkNamingKeySummary || nameKey == targetKey {
+		return nil
+	}
+	dir, name := filepath.Split(linkPath)
+	prefix := nameKey + LinkNameSeparator + targetKey + LinkNameSeparator
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil // nothing to reconcile in a directory that cannot be listed yet
+	}
+	for _, entry := range entries {
+		if entry.Name() == name || entry.Type()&os.ModeSymlink == 0 {
+			continue
+		}
+		if entry.Name() != nameKey+LinkNameSeparator+targetKey && !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		stale := filepath.Join(dir, entry.Name())
+		if err := m.removeLink(stale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeLink deletes a link, serialized with other operations on its path
+func (m *SymbolicLinkManager) removeLink(linkPath string) error {
+	unlock := m.paths.lock(linkPath)
+	defer unlock()
+	if err := os.Remove(linkPath); err != nil && !os.IsNotExist(err) {
+		return &LinkError{
+			Type:    ErrorTypeLinkRemoval,
+			Message: fmt.Sprintf("failed to remove renamed link: %s", linkPath),
+			Err:     err,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package links
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestParseLinkNaming(t *testing.T) {
+	tests := map[string]LinkNaming{
+		"":            LinkNamingKey,
+		"key":         LinkNamingKey,
+		"key-summary": LinkNamingKeySummary,
+		" Type-Key ":  LinkNamingTypeKey,
+	}
+	for value, want := range tests {
+		got, err := ParseLinkNaming(value)
+		if err != nil || got != want {
+			t.Errorf("ParseLinkNaming(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+
+	if _, err := ParseLinkNaming("summary"); err == nil {
+		t.Error("Expected error for unknown link naming")
+	}
+}
+
+func TestLinkNaming_LinkName(t *testing.T) {
+	tests := []struct {
+		naming  LinkNaming
+		relType string
+		nameKey string
+		target  string
+		summary string
+		want    string
+	}{
+		{LinkNamingKey, "Blocks", "PROJ-1", "PROJ-2", "Fix login", "PROJ-1"},
+		{"", "Blocks", "PROJ-1", "PROJ-2", "Fix login", "PROJ-1"},
+		{LinkNamingKeySummary, "Blocks", "PROJ-1", "PROJ-2", "Fix: login / SSO bug!", "PROJ-1--PROJ-2--fix-login-sso-bug"},
+		{LinkNamingKeySummary, "epic", "PROJ-1", "EPIC-5", "", "PROJ-1--EPIC-5"},
+		{LinkNamingKeySummary, "subtask", "PROJ-6", "PROJ-6", "", "PROJ-6"},
+		{LinkNamingKeySummary, "Blocks", "PROJ-1", "PROJ-2", "Ünïcödé ☃ only", "PROJ-1--PROJ-2--n-c-d-only"},
+		{LinkNamingTypeKey, "Is Blocked By", "PROJ-1", "PROJ-2", "Fix login", "is-blocked-by--PROJ-1--PROJ-2"},
+		{LinkNamingTypeKey, "epic", "PROJ-1", "EPIC-5", "", "epic--PROJ-1--EPIC-5"},
+		{LinkNamingTypeKey, "subtask", "PROJ-6", "PROJ-6", "", "subtask--PROJ-6"},
+	}
+	for _, tt := range tests {
+		if got := tt.naming.linkName(tt.relType, tt.nameKey, tt.target, tt.summary); got != tt.want {
+			t.Errorf("%s.linkName(%q, %q, %q, %q) = %q, want %q", tt.naming, tt.relType, tt.nameKey, tt.target, tt.summary, got, tt.want)
+		}
+	}
+
+	long := LinkNamingKeySummary.linkName("Blocks", "PROJ-1", "PROJ-2", strings.Repeat("word ", 30))
+	slug := strings.TrimPrefix(long, "PROJ-1--PROJ-2--")
+	if len(slug) > maxSummarySlugLength || strings.HasSuffix(slug, "-") {
+		t.Errorf("Expected summary slug cut to %d bytes without a trailing dash, got %q", maxSummarySlugLength, slug)
+	}
+}
+
+// createIssueFiles writes empty issue files for keys under basePath's PROJ project
+func createIssueFiles(t *testing.T, basePath string, keys ...string) {
+	t.Helper()
+	issuesDir := filepath.Join(basePath, "projects", "PROJ", "issues")
+	if err := os.MkdirAll(issuesDir, 0755); err != nil {
+		t.Fatalf("Failed to create issues directory: %v", err)
+	}
+	for _, key := range keys {
+		if err := os.WriteFile(filepath.Join(issuesDir, key+".yaml"), []byte("key: "+key), 0644); err != nil {
+			t.Fatalf("Failed to create issue file: %v", err)
+		}
+	}
+}
+
+// listLinks returns the link names in a directory with their targets
+func listLinks(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to list %s: %v", dir, err)
+	}
+	links := make(map[string]string)
+	for _, entry := range entries {
+		target, err := os.Readlink(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		links[entry.Name()] = target
+	}
+	return links
+}
+
+func linkNames(links map[string]string) []string {
+	names := make([]string, 0, len(links))
+	for name := range links {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCreateRelationshipLinks_NamingCollisions(t *testing.T) {
+	// PROJ-1 blocks two issues: named by key alone both links share one name
+	issue := &client.Issue{
+		Key: "PROJ-1",
+		Relationships: &client.Relationships{
+			IssueLinks: []client.IssueLink{
+				{Type: "Blocks", Direction: "outward", IssueKey: "PROJ-2", Summary: "Login page"},
+				{Type: "Blocks", Direction: "outward", IssueKey: "PROJ-3", Summary: "SSO support"},
+			},
+		},
+	}
+
+	tests := []struct {
+		naming LinkNaming
+		want   []string
+	}{
+		{LinkNamingKey, []string{"PROJ-1"}},
+		{LinkNamingKeySummary, []string{"PROJ-1--PROJ-2--login-page", "PROJ-1--PROJ-3--sso-support"}},
+		{LinkNamingTypeKey, []string{"blocks--PROJ-1--PROJ-2", "blocks--PROJ-1--PROJ-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.naming), func(t *testing.T) {
+			tempDir := t.TempDir()
+			createIssueFiles(t, tempDir, "PROJ-1", "PROJ-2", "PROJ-3")
+
+			manager := NewSymbolicLinkManagerWithNaming(1, tt.naming)
+			if err := manager.CreateRelationshipLinks(issue, tempDir); err != nil {
+				t.Fatalf("CreateRelationshipLinks failed: %v", err)
+			}
+
+			links := listLinks(t, filepath.Join(tempDir, "projects", "PROJ", "relationships", "blocks", "outward"))
+			if got := linkNames(links); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected links %v, got %v", tt.want, got)
+			}
+			if tt.naming != LinkNamingKey {
+				if links[tt.want[0]] != "../../../issues/PROJ-2.yaml" || links[tt.want[1]] != "../../../issues/PROJ-3.yaml" {
+					t.Errorf("Expected each link to point at its own issue, got %v", links)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateRelationshipLinks_NamingIsStable(t *testing.T) {
+	tempDir := t.TempDir()
+	createIssueFiles(t, tempDir, "PROJ-1", "PROJ-2", "EPIC-5")
+	issue := &client.Issue{
+		Key: "PROJ-1",
+		Relationships: &client.Relationships{
+			EpicLink:   "EPIC-5",
+			IssueLinks: []client.IssueLink{{Type: "Blocks", Direction: "outward", IssueKey: "PROJ-2", Summary: "Login page"}},
+		},
+	}
+	blocksDir := filepath.Join(tempDir, "projects", "PROJ", "relationships", "blocks", "outward")
+	epicDir := filepath.Join(tempDir, "projects", "PROJ", "relationships", "epic")
+
+	manager := NewSymbolicLinkManagerWithNaming(4, LinkNamingKeySummary)
+	for i := 0; i < 3; i++ {
+		if err := manager.CreateRelationshipLinks(issue, tempDir); err != nil {
+			t.Fatalf("CreateRelationshipLinks failed: %v", err)
+		}
+	}
+	if got := linkNames(listLinks(t, blocksDir)); len(got) != 1 || got[0] != "PROJ-1--PROJ-2--login-page" {
+		t.Errorf("Expected one stable link across re-syncs, got %v", got)
+	}
+	if got := linkNames(listLinks(t, epicDir)); len(got) != 1 || got[0] != "PROJ-1--EPIC-5" {
+		t.Errorf("Expected one stable epic link, got %v", got)
+	}
+
+	// A renamed issue replaces its link instead of leaving the old name behind
+	issue.Relationships.IssueLinks[0].Summary = "Login page (v2)"
+	if err := manager.CreateRelationshipLinks(issue, tempDir); err != nil {
+		t.Fatalf("CreateRelationshipLinks failed: %v", err)
+	}
+	if got := linkNames(listLinks(t, blocksDir)); len(got) != 1 || got[0] != "PROJ-1--PROJ-2--login-page-v2" {
+		t.Errorf("Expected the link to follow the new summary, got %v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package links
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultLinkConcurrency bounds concurrent link operations for a single issue
+const DefaultLinkConcurrency = 4
+
+// pathLocks serializes filesystem operations on the same path
+// Sync workers create links for different issues at the same time, and relationships of one
+// issue are linked in parallel, so two goroutines may create the same directory or replace
+// the same link. Operations on different paths never block each other, and a path's lock is
+// dropped once no goroutine holds or waits for it, so long syncs do not accumulate locks.
+type pathLocks struct {
+	mu    sync.Mutex
+	locks map[string]*pathLock
+}
+
+// pathLock is the lock of one path and the number of goroutines holding or waiting for it
+type pathLock struct {
+	sync.Mutex
+	users int
+}
+
+// lock acquires the lock for path and returns its release function
+func (p *pathLocks) lock(path string) func() {
+	p.mu.Lock()
+	if p.locks == nil {
+		p.locks = make(map[string]*pathLock)
+	}
+	lock, exists := p.locks[path]
+	if !exists {
+		lock = &pathLock{}
+		p.locks[path] = lock
+	}
+	lock.users++
+	p.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		p.mu.Lock()
+		lock.users--
+		if lock.users == 0 {
+			delete(p.locks, path)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// linkJob creates one relationship link; describe wraps its error for the caller
+type linkJob struct {
+	run      func() error
+	describe string
+}
+
+// ensureDir creates a directory, serialized with any other creation of the same path
+func (m *SymbolicLinkManager) ensureDir(path string) error {
+	unlock := m.paths.lock(path)
+	defer unlock()
+	return os.MkdirAll(path, 0755)
+}
+
+// runLinkJobs runs link jobs on a bounded pool
+// The error of the first failing job in input order is returned so failures are deterministic.
+func (m *SymbolicLinkManager) runLinkJobs(jobs []linkJob) error {
+	workers := m.Concurrency
+	if workers <= 0 {
+		workers = DefaultLinkConcurrency
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	errs := make([]error, len(jobs))
+	if workers <= 1 {
+		for i, job := range jobs {
+			errs[i] = job.run()
+		}
+	} else {
+		indexes := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexes {
+					errs[i] = jobs[i].run()
+				}
+			}()
+		}
+		for i := range jobs {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("%s: %w", jobs[i].describe, err)
+		}
+	}
+	return nil
+}
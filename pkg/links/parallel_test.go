@@ -0,0 +1,104 @@
+package links
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestCreateRelationshipLinks_ConcurrentIssues(t *testing.T) {
+	basePath := t.TempDir()
+	manager := NewSymbolicLinkManagerWithConcurrency(8)
+
+	// Many issues sharing the same parent, link type, and direction directories
+	var issues []*client.Issue
+	for i := 1; i <= 40; i++ {
+		issue := &client.Issue{
+			Key: fmt.Sprintf("PROJ-%d", i),
+			Relationships: &client.Relationships{
+				EpicLink:    "PROJ-100",
+				ParentIssue: "PROJ-200",
+			},
+		}
+		for j := 0; j < 5; j++ {
+			issue.Relationships.IssueLinks = append(issue.Relationships.IssueLinks,
+				CreateTestIssueLink(fmt.Sprintf("relates%d", j), "outward", fmt.Sprintf("PROJ-%d", 300+j)))
+		}
+		issues = append(issues, issue)
+	}
+	parent := CreateTestIssueWithSubtasks("PROJ-200", []string{"PROJ-1", "PROJ-2", "PROJ-3"})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(issues)+2)
+	// The parent is linked twice concurrently to exercise replacement of the same link paths
+	for _, issue := range append(issues, parent, parent) {
+		wg.Add(1)
+		go func(issue *client.Issue) {
+			defer wg.Done()
+			if err := manager.CreateRelationshipLinks(issue, basePath); err != nil {
+				errs <- err
+			}
+		}(issue)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("CreateRelationshipLinks() error = %v", err)
+	}
+
+	for _, issue := range issues {
+		epicLink := filepath.Join(basePath, "projects", "PROJ", "relationships", "epic", issue.Key)
+		if target, err := os.Readlink(epicLink); err != nil || target != "../../issues/PROJ-100.yaml" {
+			t.Errorf("Unexpected epic link for %s: %q, %v", issue.Key, target, err)
+		}
+		for j := 0; j < 5; j++ {
+			link := filepath.Join(basePath, "projects", "PROJ", "relationships", fmt.Sprintf("relates%d", j), "outward", issue.Key)
+			if _, err := os.Lstat(link); err != nil {
+				t.Errorf("Missing issue link %s: %v", link, err)
+			}
+		}
+	}
+
+	subtaskLink := filepath.Join(basePath, "projects", "PROJ", "relationships", "subtasks", "PROJ-200", "PROJ-3")
+	if target, err := os.Readlink(subtaskLink); err != nil || target != "../../../issues/PROJ-3.yaml" {
+		t.Errorf("Unexpected subtask link: %q, %v", target, err)
+	}
+
+	// Path locks are released once every operation on the path has finished
+	if held := len(manager.(*SymbolicLinkManager).paths.locks); held != 0 {
+		t.Errorf("Expected no path locks left after linking, got %d", held)
+	}
+}
+
+func TestCreateRelationshipLinks_FirstErrorInOrder(t *testing.T) {
+	basePath := t.TempDir()
+	manager := NewSymbolicLinkManagerWithConcurrency(4)
+
+	// A file where the "blocks" direction directory should be makes that link fail
+	blocksDir := filepath.Join(basePath, "projects", "PROJ", "relationships", "blocks")
+	if err := os.MkdirAll(blocksDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(blocksDir, "outward"), []byte("not a directory"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	issue := CreateTestIssueWithLinks("PROJ-1", []client.IssueLink{
+		CreateTestIssueLink("clones", "outward", "PROJ-2"),
+		CreateTestIssueLink("blocks", "outward", "PROJ-3"),
+	})
+	err := manager.CreateRelationshipLinks(issue, basePath)
+	if err == nil || !strings.Contains(err.Error(), "failed to create issue link blocks") {
+		t.Fatalf("Expected blocks link error, got %v", err)
+	}
+
+	// Other relationships are still linked
+	if _, err := os.Lstat(filepath.Join(basePath, "projects", "PROJ", "relationships", "clones", "outward", "PROJ-1")); err != nil {
+		t.Errorf("Expected clones link despite failure: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package links
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// RebuildSkip is an issue file whose relationships could not be read during a rebuild
+type RebuildSkip struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// RebuildResult summarizes a link rebuild; paths are absolute and sorted
+type RebuildResult struct {
+	IssuesRead int           `json:"issues_read"`
+	Removed    []string      `json:"removed"` // entries of relationships/ before the rebuild
+	Created    []string      `json:"created"` // links after the rebuild
+	Skipped    []RebuildSkip `json:"skipped,omitempty"`
+}
+
+// RebuildLinks recreates every relationship link of a repository from its issue files alone
+// Issue files are read before anything is changed; then each project's relationships/ directory
+// is removed, including broken links and links a filesystem checked out as plain files, and
+// the links are created again from the relationships recorded in the files. Nothing is fetched
+// from JIRA. Files that cannot be parsed are skipped and reported.
+func RebuildLinks(manager LinkManager, repoPath string) (*RebuildResult, error) {
+	issueFiles, err := filepath.Glob(filepath.Join(repoPath, "projects", "*", "issues", "*.yaml"))
+	if err != nil {
+		return nil, &LinkError{Type: ErrorTypeInvalidInput, Message: "failed to list issue files", Err: err}
+	}
+	if len(issueFiles) == 0 {
+		return nil, &LinkError{Type: ErrorTypeInvalidInput, Message: fmt.Sprintf("no issue files found under %s", filepath.Join(repoPath, "projects"))}
+	}
+
+	result := &RebuildResult{}
+	issues := make([]*client.Issue, 0, len(issueFiles))
+	for _, issueFile := range issueFiles {
+		issue, err := readIssueRelationships(issueFile)
+		if err != nil {
+			result.Skipped = append(result.Skipped, RebuildSkip{Path: issueFile, Reason: err.Error()})
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	result.IssuesRead = len(issues)
+
+	relationshipDirs, err := filepath.Glob(filepath.Join(repoPath, "projects", "*", "relationships"))
+	if err != nil {
+		return nil, &LinkError{Type: ErrorTypeLinkAccess, Message: "failed to list relationship directories", Err: err}
+	}
+	if result.Removed, err = listRelationshipEntries(relationshipDirs, false); err != nil {
+		return nil, err
+	}
+	for _, dir := range relationshipDirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, &LinkError{Type: ErrorTypeLinkRemoval, Message: fmt.Sprintf("failed to remove %s", dir), Err: err}
+		}
+	}
+
+	for _, issue := range issues {
+		if err := manager.CreateDirectoryStructure(repoPath, extractProjectKey(issue.Key)); err != nil {
+			return nil, err
+		}
+		if err := manager.CreateRelationshipLinks(issue, repoPath); err != nil {
+			return nil, fmt.Errorf("failed to create links of %s: %w", issue.Key, err)
+		}
+	}
+
+	if relationshipDirs, err = filepath.Glob(filepath.Join(repoPath, "projects", "*", "relationships")); err != nil {
+		return nil, &LinkError{Type: ErrorTypeLinkAccess, Message: "failed to list relationship directories", Err: err}
+	}
+	if result.Created, err = listRelationshipEntries(relationshipDirs, true); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readIssueRelationships reads the key and relationships of an issue file
+func readIssueRelationships(issueFile string) (*client.Issue, error) {
+	data, err := os.ReadFile(issueFile)
+	if err != nil {
+		return nil, err
+	}
+	var issue client.Issue
+	if err := yaml.Unmarshal(data, &issue); err != nil {
+		return nil, fmt.Errorf("invalid issue YAML: %w", err)
+	}
+	if issue.Key == "" || extractProjectKey(issue.Key) == "" {
+		return nil, fmt.Errorf("issue file has no valid key")
+	}
+	return &issue, nil
+}
+
+// listRelationshipEntries lists the non-directory entries below relationship directories,
+// only symbolic links when linksOnly is set
+func listRelationshipEntries(relationshipDirs []string, linksOnly bool) ([]string, error) {
+	entries := []string{}
+	for _, dir := range relationshipDirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || (linksOnly && info.Mode()&os.ModeSymlink == 0) {
+				return nil
+			}
+			entries = append(entries, path)
+			return nil
+		})
+		if err != nil {
+			return nil, &LinkError{Type: ErrorTypeLinkAccess, Message: fmt.Sprintf("failed to list %s", dir), Err: err}
+		}
+	}
+	sort.Strings(entries)
+	return entries, nil
+}
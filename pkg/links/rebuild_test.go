@@ -0,0 +1,70 @@
+package links
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeIssueFile(t *testing.T, repoPath, issueKey, content string) {
+	t.Helper()
+	path := filepath.Join(repoPath, "projects", extractProjectKey(issueKey), "issues", issueKey+".yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRebuildLinks(t *testing.T) {
+	repoPath := t.TempDir()
+	writeIssueFile(t, repoPath, "PROJ-1", "key: PROJ-1\nsummary: Epic\n")
+	writeIssueFile(t, repoPath, "PROJ-2", "key: PROJ-2\nrelationships:\n  epic_link: PROJ-1\n  subtasks: [PROJ-3]\n")
+	writeIssueFile(t, repoPath, "PROJ-3", "key: PROJ-3\nrelationships:\n  parent_issue: PROJ-2\n")
+	writeIssueFile(t, repoPath, "PROJ-4", "key: [broken\n")
+
+	// A link checked out as a plain file and a stale link of a relationship no issue records
+	relationships := filepath.Join(repoPath, "projects", "PROJ", "relationships")
+	if err := os.MkdirAll(filepath.Join(relationships, "epic"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(relationships, "epic", "PROJ-2"), []byte("../../issues/PROJ-1.yaml"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../../issues/PROJ-9.yaml", filepath.Join(relationships, "epic", "PROJ-9")); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := RebuildLinks(NewSymbolicLinkManager(), repoPath)
+	if err != nil {
+		t.Fatalf("RebuildLinks() error = %v", err)
+	}
+	if result.IssuesRead != 3 || len(result.Skipped) != 1 || !strings.HasSuffix(result.Skipped[0].Path, "PROJ-4.yaml") {
+		t.Errorf("Expected 3 issues read and PROJ-4 skipped, got %+v", result)
+	}
+	if len(result.Removed) != 2 {
+		t.Errorf("Expected the plain file and the stale link to be removed, got %v", result.Removed)
+	}
+	if len(result.Created) != 3 {
+		t.Errorf("Expected epic, parent and subtask links, got %v", result.Created)
+	}
+
+	epicLink := filepath.Join(relationships, "epic", "PROJ-2")
+	if info, err := os.Lstat(epicLink); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Expected the epic link to be a symbolic link again, got %v, %v", info, err)
+	}
+	if _, err := os.Stat(epicLink); err != nil {
+		t.Errorf("Expected the epic link to resolve: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(relationships, "epic", "PROJ-9")); !os.IsNotExist(err) {
+		t.Error("Expected the stale link to be gone")
+	}
+}
+
+func TestRebuildLinks_NoIssues(t *testing.T) {
+	if _, err := RebuildLinks(NewSymbolicLinkManager(), t.TempDir()); err == nil {
+		t.Error("Expected error for a repository without issue files")
+	}
+}
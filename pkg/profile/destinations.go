@@ -0,0 +1,76 @@
+package profile
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+)
+
+// Destination is an additional repository a profile syncs to, with its own view of the issues
+// Output settings left empty are inherited from the profile's options.
+type Destination struct {
+	Name          string   `json:"name,omitempty" yaml:"name,omitempty"` // label in results (default: the repository)
+	Repository    string   `json:"repository" yaml:"repository"`
+	ExcludeFields []string `json:"exclude_fields,omitempty" yaml:"exclude_fields,omitempty"` // YAML field patterns to omit (globs or re:regex)
+	ADFRender     string   `json:"adf_render,omitempty" yaml:"adf_render,omitempty"`         // markdown, text, or raw
+	Transform     string   `json:"transform,omitempty" yaml:"transform,omitempty"`           // executable or Go template applied before writing
+}
+
+// Label returns the destination's name, or its repository when unnamed
+func (d Destination) Label() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.Repository
+}
+
+// DestinationProfiles returns one profile per output repository: the profile itself first,
+// then one for each destination, with the destination's repository and output settings
+func (p *Profile) DestinationProfiles() []*Profile {
+	profiles := []*Profile{p}
+	for _, destination := range p.Destinations {
+		view := *p
+		view.Repository = destination.Repository
+		view.Destinations = nil
+		if destination.ExcludeFields != nil {
+			view.Options.ExcludeFields = destination.ExcludeFields
+		}
+		if destination.ADFRender != "" {
+			view.Options.ADFRender = destination.ADFRender
+		}
+		if destination.Transform != "" {
+			view.Options.Transform = destination.Transform
+		}
+		profiles = append(profiles, &view)
+	}
+	return profiles
+}
+
+// validateDestinations checks that every destination names a distinct, valid repository
+func validateDestinations(p *Profile) []string {
+	var problems []string
+	seen := map[string]bool{filepath.Clean(p.Repository): true}
+	for i, destination := range p.Destinations {
+		field := fmt.Sprintf("destinations[%d]", i)
+		if err := ValidateRepositoryPath(destination.Repository); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", field, validationMessage(err)))
+			continue
+		}
+		repository := filepath.Clean(destination.Repository)
+		if seen[repository] {
+			problems = append(problems, fmt.Sprintf("%s: repository %s is already a destination of this profile", field, destination.Repository))
+		}
+		seen[repository] = true
+
+		if len(destination.ExcludeFields) > 0 {
+			if _, err := schema.NewFieldFilter(nil, destination.ExcludeFields); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: exclude_fields: %v", field, err))
+			}
+		}
+		if _, err := schema.ParseADFRenderMode(destination.ADFRender); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: adf_render: %v", field, err))
+		}
+	}
+	return problems
+}
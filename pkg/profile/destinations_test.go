@@ -0,0 +1,111 @@
+package profile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestProfile_DestinationProfiles(t *testing.T) {
+	p := &Profile{
+		Name:       "team",
+		JQL:        "project = TEAM",
+		Repository: "./internal",
+		Options: ProfileOptions{
+			Concurrency: 4,
+			ADFRender:   "markdown",
+			Transform:   "./enrich.sh",
+		},
+		Destinations: []Destination{
+			{Name: "public", Repository: "./public", ExcludeFields: []string{"reporter", "assignee"}, ADFRender: "text"},
+			{Repository: "./mirror"},
+		},
+	}
+
+	profiles := p.DestinationProfiles()
+	if len(profiles) != 3 || profiles[0] != p {
+		t.Fatalf("Expected the profile followed by 2 destinations, got %d", len(profiles))
+	}
+
+	public := profiles[1]
+	if public.Repository != "./public" || public.Options.ADFRender != "text" || public.Destinations != nil {
+		t.Errorf("Destination settings not applied: %+v", public)
+	}
+	if !reflect.DeepEqual(public.Options.ExcludeFields, []string{"reporter", "assignee"}) {
+		t.Errorf("Expected the destination's exclude_fields, got %v", public.Options.ExcludeFields)
+	}
+	if public.Options.Transform != "./enrich.sh" || public.Options.Concurrency != 4 || public.JQL != p.JQL {
+		t.Errorf("Expected unset settings to be inherited, got %+v", public.Options)
+	}
+
+	mirror := profiles[2]
+	if mirror.Repository != "./mirror" || mirror.Options.ADFRender != "markdown" {
+		t.Errorf("Expected the mirror to inherit output settings, got %+v", mirror.Options)
+	}
+	if p.Options.ADFRender != "markdown" || p.Options.ExcludeFields != nil {
+		t.Errorf("Destination views modified the profile: %+v", p.Options)
+	}
+
+	if (Destination{Repository: "./mirror"}).Label() != "./mirror" || p.Destinations[0].Label() != "public" {
+		t.Error("Expected labels to fall back to the repository")
+	}
+}
+
+func TestValidateProfile_Destinations(t *testing.T) {
+	manager := NewFileProfileManager("", "yaml")
+	base := Profile{Name: "team", JQL: "project = TEAM", Repository: "./internal"}
+
+	tests := []struct {
+		name         string
+		destinations []Destination
+		wantError    string
+	}{
+		{name: "valid", destinations: []Destination{{Repository: "./public", ExcludeFields: []string{"re:^custom"}}}},
+		{name: "missing repository", destinations: []Destination{{Name: "public"}}, wantError: "destinations[0]"},
+		{name: "same as primary", destinations: []Destination{{Repository: "internal/"}}, wantError: "already a destination"},
+		{name: "duplicate", destinations: []Destination{{Repository: "./a"}, {Repository: "a"}}, wantError: "destinations[1]"},
+		{name: "bad exclude pattern", destinations: []Destination{{Repository: "./a", ExcludeFields: []string{"re:("}}}, wantError: "exclude_fields"},
+		{name: "bad adf_render", destinations: []Destination{{Repository: "./a", ADFRender: "html"}}, wantError: "adf_render"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := base
+			p.Destinations = tt.destinations
+			result, err := manager.ValidateProfile(&p)
+			if err != nil {
+				t.Fatalf("ValidateProfile() error = %v", err)
+			}
+			if tt.wantError == "" {
+				if !result.Valid {
+					t.Errorf("Expected valid profile, got %v", result.Errors)
+				}
+				return
+			}
+			if result.Valid || !strings.Contains(strings.Join(result.Errors, "; "), tt.wantError) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantError, result.Errors)
+			}
+		})
+	}
+}
+
+func TestDestination_YAML(t *testing.T) {
+	data := []byte(`name: team
+jql: project = TEAM
+repository: ./internal
+destinations:
+  - name: public
+    repository: ./public
+    exclude_fields: [reporter]
+`)
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := []Destination{{Name: "public", Repository: "./public", ExcludeFields: []string{"reporter"}}}
+	if !reflect.DeepEqual(p.Destinations, want) {
+		t.Errorf("Destinations = %+v, want %+v", p.Destinations, want)
+	}
+}
@@ -5,6 +5,13 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/filter"
+	"github.com/chambrid/jira-cdc-git/pkg/git"
+	"github.com/chambrid/jira-cdc-git/pkg/jql"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/ratelimit"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
 )
 
 // ProfileError represents a profile-related error
@@ -323,6 +330,26 @@ func ValidateProfileOptions(options ProfileOptions) *ProfileValidationError {
 			ValidationCodeOutOfRange, options.Concurrency)
 	}
 
+	// Validate link concurrency (0 uses the default)
+	if options.LinkConcurrency < 0 || options.LinkConcurrency > 20 {
+		validation.AddError("options.link_concurrency", "link_concurrency must be between 0 and 20 (0 uses the default)",
+			ValidationCodeOutOfRange, options.LinkConcurrency)
+	}
+
+	// Validate link naming
+	if options.LinkNaming != "" {
+		if _, err := links.ParseLinkNaming(options.LinkNaming); err != nil {
+			validation.AddError("options.link_naming", "link_naming must be key, key-summary, or type-key",
+				ValidationCodeInvalidValue, options.LinkNaming)
+		}
+	}
+
+	// Validate bulk fetch size (0 uses the default)
+	if options.BulkFetchSize < 0 || options.BulkFetchSize > 100 {
+		validation.AddError("options.bulk_fetch_size", "bulk_fetch_size must be between 0 and 100 (0 uses the default)",
+			ValidationCodeOutOfRange, options.BulkFetchSize)
+	}
+
 	// Validate rate limit
 	if options.RateLimit != "" {
 		if _, err := time.ParseDuration(options.RateLimit); err != nil {
@@ -331,6 +358,163 @@ func ValidateProfileOptions(options ProfileOptions) *ProfileValidationError {
 		}
 	}
 
+	// Validate timestamp normalization
+	if _, err := schema.ParseTimestampMode(options.NormalizeTimestamps); err != nil {
+		validation.AddError("options.normalize_timestamps", err.Error(),
+			ValidationCodeInvalidValue, options.NormalizeTimestamps)
+	}
+
+	// Validate issue field ordering
+	if _, err := schema.ParseFieldOrder(options.FieldOrder); err != nil {
+		validation.AddError("options.field_order", err.Error(),
+			ValidationCodeInvalidValue, options.FieldOrder)
+	}
+
+	// Validate custom field flattening
+	if options.FlattenFields != "" {
+		if options.NoFlatten {
+			validation.AddError("options.flatten_fields", "flatten_fields cannot be combined with no_flatten",
+				ValidationCodeInvalidValue, options.FlattenFields)
+		} else if _, err := schema.ParseFlattenStrategy(options.FlattenFields); err != nil {
+			validation.AddError("options.flatten_fields", err.Error(),
+				ValidationCodeInvalidFormat, options.FlattenFields)
+		}
+	}
+
+	// Validate commit checkpoints; per-issue commits need none
+	if options.CheckpointInterval < 0 {
+		validation.AddError("options.checkpoint_interval", "checkpoint_interval cannot be negative",
+			ValidationCodeOutOfRange, options.CheckpointInterval)
+	} else if options.CheckpointInterval > 0 && options.CommitStrategy != "batch" && options.CommitStrategy != "summary" {
+		validation.AddError("options.checkpoint_interval", "checkpoint_interval requires commit_strategy batch or summary",
+			ValidationCodeInvalidValue, options.CheckpointInterval)
+	}
+
+	// Validate worker ramp-up
+	if options.RampUp != "" {
+		if d, err := time.ParseDuration(options.RampUp); err != nil || d < 0 {
+			validation.AddError("options.ramp_up", "ramp_up must be a non-negative duration",
+				ValidationCodeInvalidFormat, options.RampUp)
+		}
+	}
+
+	// Validate Git operation retries
+	if options.GitRetries < 0 || options.GitRetries > 10 {
+		validation.AddError("options.git_retries", "git_retries must be between 0 and 10",
+			ValidationCodeOutOfRange, options.GitRetries)
+	}
+	if options.GitRetryBackoff != "" {
+		if d, err := time.ParseDuration(options.GitRetryBackoff); err != nil || d < 0 {
+			validation.AddError("options.git_retry_backoff", "git_retry_backoff must be a non-negative duration",
+				ValidationCodeInvalidFormat, options.GitRetryBackoff)
+		}
+	}
+
+	// Validate the file count safety limit
+	if options.MaxFiles < 0 {
+		validation.AddError("options.max_files", "max_files must be positive (or 0 for the default)",
+			ValidationCodeOutOfRange, options.MaxFiles)
+	}
+
+	// Validate pruning
+	if options.ArchivePruned != "" && !options.Prune {
+		validation.AddError("options.archive_pruned", "archive_pruned requires prune",
+			ValidationCodeDependencyMissing, options.ArchivePruned)
+	}
+
+	// Validate the sync budget (0 is unlimited)
+	if options.MaxAPICalls < 0 {
+		validation.AddError("options.max_api_calls", "max_api_calls cannot be negative",
+			ValidationCodeOutOfRange, options.MaxAPICalls)
+	}
+	if options.MaxDuration != "" {
+		if d, err := time.ParseDuration(options.MaxDuration); err != nil || d < 0 {
+			validation.AddError("options.max_duration", "max_duration must be a non-negative duration",
+				ValidationCodeInvalidFormat, options.MaxDuration)
+		}
+	}
+
+	// Validate the minimal preset
+	if options.MinimalRelationships && !options.Minimal {
+		validation.AddError("options.minimal_relationships", "minimal_relationships requires minimal",
+			ValidationCodeDependencyMissing, options.MinimalRelationships)
+	}
+
+	// Validate dirty working tree policy
+	if options.OnDirty != "" {
+		if _, err := git.ParseDirtyPolicy(options.OnDirty); err != nil {
+			validation.AddError("options.on_dirty", "on_dirty must be one of fail, stash, commit, ignore",
+				ValidationCodeInvalidValue, options.OnDirty)
+		}
+	}
+
+	// Validate excluded field patterns
+	if len(options.ExcludeFields) > 0 {
+		if _, err := schema.NewFieldFilter(nil, options.ExcludeFields); err != nil {
+			validation.AddError("options.exclude_fields", err.Error(),
+				ValidationCodeInvalidFormat, options.ExcludeFields)
+		}
+	}
+
+	// Validate the issue filter expression
+	if options.FilterExpr != "" {
+		if _, err := filter.Parse(options.FilterExpr); err != nil {
+			validation.AddError("options.filter_expr", err.Error(),
+				ValidationCodeInvalidFormat, options.FilterExpr)
+		}
+	}
+
+	// Validate result ordering
+	if options.OrderBy != "" {
+		if _, err := jql.ParseOrderBy(options.OrderBy); err != nil {
+			validation.AddError("options.order_by", "order_by must be comma-separated fields with optional ASC or DESC",
+				ValidationCodeInvalidFormat, options.OrderBy)
+		}
+	}
+
+	// Validate index format
+	if options.IndexFormat != "" {
+		if _, err := schema.ParseIndexFormat(options.IndexFormat); err != nil {
+			validation.AddError("options.index_format", "index_format must be yaml or markdown",
+				ValidationCodeInvalidValue, options.IndexFormat)
+		}
+	}
+	if options.IndexSort != "" {
+		if _, err := schema.ParseIndexSort(options.IndexSort); err != nil {
+			validation.AddError("options.index_sort", "index_sort must be key, status, updated, or priority",
+				ValidationCodeInvalidValue, options.IndexSort)
+		}
+	}
+
+	// Validate the issue file format; Markdown files take none of the YAML output options
+	if format, err := schema.ParseFileFormat(options.Format); err != nil {
+		validation.AddError("options.format", "format must be yaml or markdown",
+			ValidationCodeInvalidValue, options.Format)
+	} else if format == schema.FileFormatMarkdown {
+		for _, option := range options.YAMLOnlyOptions() {
+			validation.AddError("options."+option, option+" only applies to format yaml",
+				ValidationCodeIncompatible, options.Format)
+		}
+	} else if options.FrontmatterFields != "" {
+		validation.AddError("options.frontmatter_fields", "frontmatter_fields requires format markdown",
+			ValidationCodeDependencyMissing, options.FrontmatterFields)
+	}
+	if _, err := schema.ParseFrontmatterFields(options.FrontmatterFields); err != nil {
+		validation.AddError("options.frontmatter_fields", err.Error(),
+			ValidationCodeInvalidValue, options.FrontmatterFields)
+	}
+
+	// Validate the business-hours throttle
+	if options.QuietHours != "" {
+		if _, err := ratelimit.ParseQuietHours(options.QuietHours, options.QuietHoursTimezone, options.QuietHoursMode); err != nil {
+			validation.AddError("options.quiet_hours", err.Error(),
+				ValidationCodeInvalidValue, options.QuietHours)
+		}
+	} else if options.QuietHoursTimezone != "" || options.QuietHoursMode != "" {
+		validation.AddError("options.quiet_hours", "quiet_hours_timezone and quiet_hours_mode require quiet_hours",
+			ValidationCodeDependencyMissing, nil)
+	}
+
 	// Check mutually exclusive options
 	if options.Incremental && options.Force {
 		validation.AddError("options", "incremental and force options are mutually exclusive",
@@ -347,3 +531,29 @@ func ValidateProfileOptions(options ProfileOptions) *ProfileValidationError {
 
 	return validation
 }
+
+// YAMLOnlyOptions lists the set options that only apply to YAML issue files: how the YAML is
+// written, and the steps that read issue files back (indexes, checksums, deletions, pruning)
+func (options ProfileOptions) YAMLOnlyOptions() []string {
+	var set []string
+	add := func(condition bool, option string) {
+		if condition {
+			set = append(set, option)
+		}
+	}
+	add(len(options.ExcludeFields) > 0, "exclude_fields")
+	add(options.Minimal, "minimal")
+	add(options.Transform != "", "transform")
+	add(options.MergeUpdate, "merge_update")
+	add(options.ADFRender != "", "adf_render")
+	add(options.FlattenFields != "", "flatten_fields")
+	add(options.NoFlatten, "no_flatten")
+	add(options.NormalizeTimestamps != "", "normalize_timestamps")
+	add(options.FieldOrder != "", "field_order")
+	add(options.GenerateIndex, "generate_index")
+	add(options.GenerateRelationshipIndex, "generate_relationship_index")
+	add(options.Checksums || options.Sign, "checksums")
+	add(options.HandleDeletions, "handle_deletions")
+	add(options.Prune, "prune")
+	return set
+}
@@ -0,0 +1,78 @@
+package profile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateProfileOptions_Format(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ProfileOptions
+		errors  []string
+	}{
+		{"default", ProfileOptions{Concurrency: 2}, nil},
+		{"markdown", ProfileOptions{Concurrency: 2, Format: "markdown", LinkNaming: "key-summary"}, nil},
+		{"unknown", ProfileOptions{Concurrency: 2, Format: "ndjson"}, []string{"options.format"}},
+		{
+			"markdown with YAML options",
+			ProfileOptions{Concurrency: 2, Format: "markdown", ExcludeFields: []string{"comments"}, Sign: true},
+			[]string{"options.exclude_fields", "options.checksums"},
+		},
+		{"yaml with YAML options", ProfileOptions{Concurrency: 2, Format: "yaml", Checksums: true}, nil},
+		{"markdown prune", ProfileOptions{Concurrency: 2, Format: "markdown", Prune: true}, []string{"options.prune"}},
+		{"markdown frontmatter", ProfileOptions{Concurrency: 2, Format: "markdown", FrontmatterFields: "default,url"}, nil},
+		{"frontmatter without markdown", ProfileOptions{Concurrency: 2, FrontmatterFields: "key"}, []string{"options.frontmatter_fields"}},
+		{"unknown frontmatter field", ProfileOptions{Concurrency: 2, Format: "markdown", FrontmatterFields: "sprint"}, []string{"options.frontmatter_fields"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation := ValidateProfileOptions(tt.options)
+			var fields []string
+			for _, problem := range validation.Errors {
+				fields = append(fields, problem.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.errors, ",") {
+				t.Errorf("Errors on %v, want %v", fields, tt.errors)
+			}
+		})
+	}
+}
+
+func TestValidateProfileOptions_Prune(t *testing.T) {
+	if validation := ValidateProfileOptions(ProfileOptions{Concurrency: 2, Prune: true, ArchivePruned: "archive"}); validation.HasErrors() {
+		t.Errorf("Expected prune with an archive to be valid, got %v", validation.Errors)
+	}
+	validation := ValidateProfileOptions(ProfileOptions{Concurrency: 2, ArchivePruned: "archive"})
+	if len(validation.Errors) != 1 || validation.Errors[0].Field != "options.archive_pruned" {
+		t.Errorf("Expected archive_pruned without prune to be refused, got %v", validation.Errors)
+	}
+}
+
+func TestValidateProfileOptions_Budget(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ProfileOptions
+		errors  []string
+	}{
+		{"unlimited", ProfileOptions{Concurrency: 2}, nil},
+		{"limited", ProfileOptions{Concurrency: 2, MaxAPICalls: 2000, MaxDuration: "30m"}, nil},
+		{"negative calls", ProfileOptions{Concurrency: 2, MaxAPICalls: -1}, []string{"options.max_api_calls"}},
+		{"invalid duration", ProfileOptions{Concurrency: 2, MaxDuration: "soon"}, []string{"options.max_duration"}},
+		{"negative duration", ProfileOptions{Concurrency: 2, MaxDuration: "-5m"}, []string{"options.max_duration"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation := ValidateProfileOptions(tt.options)
+			var fields []string
+			for _, problem := range validation.Errors {
+				fields = append(fields, problem.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.errors, ",") {
+				t.Errorf("Errors on %v, want %v", fields, tt.errors)
+			}
+		})
+	}
+}
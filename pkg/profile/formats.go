@@ -0,0 +1,95 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile serialization formats
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+	FormatTOML = "toml"
+
+	// FormatAuto uses the format of the existing profiles file, or YAML when there is none
+	FormatAuto = "auto"
+)
+
+// storeFormats lists the profile store formats in detection order; YAML wins when several
+// profiles files exist
+var storeFormats = []string{FormatYAML, FormatJSON, FormatTOML}
+
+// FormatFromExtension returns the format a file extension selects (.yaml, .yml, .json, .toml)
+func FormatFromExtension(filePath string) (string, bool) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	case ".json":
+		return FormatJSON, true
+	case ".toml":
+		return FormatTOML, true
+	default:
+		return "", false
+	}
+}
+
+// isSupportedFormat reports whether format is yaml, json, or toml
+func isSupportedFormat(format string) bool {
+	for _, supported := range storeFormats {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// profilesFileName returns the name of the profiles file in a format
+func profilesFileName(format string) string {
+	if format == FormatYAML {
+		return ProfilesFile
+	}
+	return "profiles." + format
+}
+
+// detectStoreFormat returns the format of the profiles file in profilesDir, or YAML if none exists
+func detectStoreFormat(profilesDir string) string {
+	for _, format := range storeFormats {
+		if _, err := os.Stat(filepath.Join(profilesDir, profilesFileName(format))); err == nil {
+			return format
+		}
+	}
+	return FormatYAML
+}
+
+// marshalProfiles serializes a value in one of the profile formats
+func marshalProfiles(v interface{}, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(v, "", "  ")
+	case FormatTOML:
+		return marshalTOML(v)
+	case FormatYAML:
+		return yaml.Marshal(v)
+	default:
+		return nil, fmt.Errorf("unsupported profile format: %s", format)
+	}
+}
+
+// unmarshalProfiles parses a value in one of the profile formats
+func unmarshalProfiles(data []byte, format string, v interface{}) error {
+	switch format {
+	case FormatJSON:
+		return json.Unmarshal(data, v)
+	case FormatTOML:
+		return unmarshalTOML(data, v)
+	case FormatYAML:
+		return yaml.Unmarshal(data, v)
+	default:
+		return fmt.Errorf("unsupported profile format: %s", format)
+	}
+}
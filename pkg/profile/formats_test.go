@@ -0,0 +1,169 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// detailedProfile sets fields of every kind, including usage statistics
+func detailedProfile(name string) Profile {
+	used := time.Date(2024, 3, 2, 9, 0, 4, 500, time.UTC)
+	return Profile{
+		Name:        name,
+		Description: "Nightly \"mirror\"\nof PROJ",
+		JQL:         `project = PROJ AND labels in ("a", 'b')`,
+		Repository:  "./proj-mirror",
+		Destinations: []Destination{
+			{Name: "public", Repository: "./public", ExcludeFields: []string{"reporter.*"}, ADFRender: "text"},
+			{Repository: "./archive"},
+		},
+		Options: ProfileOptions{
+			Concurrency:   5,
+			RateLimit:     "500ms",
+			Incremental:   true,
+			ExcludeFields: []string{"re:^custom", "comments"},
+			FilterExpr:    `priority == "High"`,
+			GitRetries:    2,
+		},
+		Tags:      []string{"nightly", "team-a"},
+		Metadata:  map[string]string{"owner": "team-a", "key with spaces": "ünïcode"},
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 2, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600)),
+		Version:   ProfileVersion,
+		UsageStats: UsageStats{
+			TimesUsed:     12,
+			LastUsed:      used,
+			TotalSyncTime: 123456789012,
+			AvgSyncTime:   10288065751,
+			LastSuccess:   used,
+			SuccessRate:   91.66666666666667,
+		},
+	}
+}
+
+func TestFileProfileManager_RoundTripsEveryFormat(t *testing.T) {
+	for _, format := range []string{FormatYAML, FormatJSON, FormatTOML} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			manager := NewFileProfileManager(dir, format)
+			want := detailedProfile("nightly.proj")
+			collection := &ProfileCollection{Profiles: map[string]Profile{want.Name: want}, Templates: map[string]Profile{}}
+			if err := manager.SaveCollection(collection); err != nil {
+				t.Fatalf("SaveCollection() error = %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, ProfilesDir, profilesFileName(format))); err != nil {
+				t.Fatalf("Expected a %s profiles file: %v", format, err)
+			}
+
+			got, err := NewFileProfileManager(dir, format).GetProfile(want.Name)
+			if err != nil {
+				t.Fatalf("GetProfile() error = %v", err)
+			}
+			if !reflect.DeepEqual(normalizeTimes(*got), normalizeTimes(want)) {
+				t.Errorf("Round trip changed the profile:\n got %+v\nwant %+v", *got, want)
+			}
+		})
+	}
+}
+
+// normalizeTimes drops monotonic clock readings and locations so decoded times compare equal
+func normalizeTimes(p Profile) Profile {
+	for _, t := range []*time.Time{&p.CreatedAt, &p.UpdatedAt, &p.UsageStats.LastUsed, &p.UsageStats.LastSuccess, &p.UsageStats.LastFailure} {
+		*t = t.UTC()
+	}
+	return p
+}
+
+func TestNewFileProfileManager_DetectsStoreFormat(t *testing.T) {
+	dir := t.TempDir()
+	if manager := NewFileProfileManager(dir, FormatAuto); manager.format != FormatYAML {
+		t.Errorf("Expected YAML without a profiles file, got %s", manager.format)
+	}
+
+	if err := NewFileProfileManager(dir, FormatTOML).CreateProfile(&Profile{Name: "nightly", JQL: "project = PROJ", Repository: "./repo"}); err != nil {
+		t.Fatalf("CreateProfile() error = %v", err)
+	}
+	manager := NewFileProfileManager(dir, FormatAuto)
+	if manager.format != FormatTOML {
+		t.Fatalf("Expected the TOML profiles file to be detected, got %s", manager.format)
+	}
+	if !manager.ProfileExists("nightly") {
+		t.Error("Expected the profile saved as TOML to be found")
+	}
+}
+
+func TestExportImport_ConvertsBetweenFormats(t *testing.T) {
+	source := NewFileProfileManager(t.TempDir(), FormatYAML)
+	want := detailedProfile("nightly")
+	if err := source.SaveCollection(&ProfileCollection{Profiles: map[string]Profile{"nightly": want}}); err != nil {
+		t.Fatalf("SaveCollection() error = %v", err)
+	}
+
+	exportPath := filepath.Join(t.TempDir(), "team.toml")
+	if err := source.ExportToFile(exportPath, &ProfileExportOptions{IncludeStats: true}); err != nil {
+		t.Fatalf("ExportToFile() error = %v", err)
+	}
+	data, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "[profiles.nightly.usage_stats]") {
+		t.Errorf("Expected a TOML export, got:\n%s", data)
+	}
+
+	target := NewFileProfileManager(t.TempDir(), FormatJSON)
+	if err := target.ImportFromFile(exportPath, &ProfileImportOptions{}); err != nil {
+		t.Fatalf("ImportFromFile() error = %v", err)
+	}
+	got, err := target.GetProfile("nightly")
+	if err != nil {
+		t.Fatalf("GetProfile() error = %v", err)
+	}
+	if got.UsageStats.TimesUsed != want.UsageStats.TimesUsed || !got.UsageStats.LastUsed.Equal(want.UsageStats.LastUsed) ||
+		!reflect.DeepEqual(got.Destinations, want.Destinations) || got.Description != want.Description {
+		t.Errorf("Converted profile differs: %+v", got)
+	}
+}
+
+func TestUnmarshalTOML_HandWritten(t *testing.T) {
+	doc := `name = "nightly"
+repository = "./repo"
+tags = ["team", "nightly"]
+created_at = 1979-05-27T07:32:00Z
+
+[options]
+concurrency = 8
+incremental = true
+`
+	var got Profile
+	if err := unmarshalTOML([]byte(doc), &got); err != nil {
+		t.Fatalf("unmarshalTOML() error = %v", err)
+	}
+	if got.Name != "nightly" || got.Options.Concurrency != 8 || !got.Options.Incremental ||
+		!reflect.DeepEqual(got.Tags, []string{"team", "nightly"}) {
+		t.Errorf("Unexpected profile: %+v", got)
+	}
+	if want := time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC); !got.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, want)
+	}
+
+	if err := unmarshalTOML([]byte("name = \"open"), &got); err == nil {
+		t.Error("Expected an invalid TOML document to fail")
+	}
+}
+
+func TestMarshalTOML_WritesNumbers(t *testing.T) {
+	data, err := marshalTOML(detailedProfile("nightly"))
+	if err != nil {
+		t.Fatalf("marshalTOML() error = %v", err)
+	}
+	for _, want := range []string{"total_sync_time_ms = 123456789012", "success_rate = 91.66666666666667"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %q in:\n%s", want, data)
+		}
+	}
+}
@@ -1,14 +1,11 @@
 package profile
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // ExportProfiles exports profiles matching the criteria to a collection
@@ -105,16 +102,18 @@ func (m *FileProfileManager) ImportProfiles(importCollection *ProfileCollection,
 	}
 
 	// Validate import collection if requested
+	skipped := make(map[string]bool)
 	if options != nil && options.Validate {
-		for name, profile := range importCollection.Profiles {
-			validation, err := m.ValidateProfile(&profile)
-			if err != nil {
-				return NewImportError(fmt.Sprintf("validation failed for profile '%s'", name), err)
+		report := ValidateImportCollection(importCollection, options.Workers)
+		for _, p := range report.Profiles {
+			if p.Valid {
+				continue
 			}
-			if !validation.Valid {
-				return NewImportError(fmt.Sprintf("profile '%s' is invalid: %s", name,
-					strings.Join(validation.Errors, "; ")), nil)
+			if !options.SkipInvalid {
+				return NewImportError(fmt.Sprintf("profile '%s' is invalid: %s", p.Name,
+					strings.Join(p.Errors, "; ")), nil)
 			}
+			skipped[p.Name] = true
 		}
 	}
 
@@ -123,6 +122,9 @@ func (m *FileProfileManager) ImportProfiles(importCollection *ProfileCollection,
 	imported := 0
 
 	for name, profile := range importCollection.Profiles {
+		if skipped[name] {
+			continue
+		}
 		finalName := name
 
 		// Apply name prefix if specified
@@ -212,27 +214,24 @@ func (m *FileProfileManager) ExportToFile(filePath string, options *ProfileExpor
 	}
 
 	// Determine format from file extension or options
-	format := "yaml"
+	format := FormatYAML
 	if options != nil && options.Format != "" {
-		format = options.Format
-	} else {
-		ext := strings.ToLower(filepath.Ext(filePath))
-		if ext == ".json" {
-			format = "json"
-		}
+		format = strings.ToLower(options.Format)
+	} else if extFormat, ok := FormatFromExtension(filePath); ok {
+		format = extFormat
 	}
 
-	// Marshal to bytes
-	var data []byte
-	switch format {
-	case "json":
-		data, err = json.MarshalIndent(collection, "", "  ")
-	case "yaml":
-		data, err = yaml.Marshal(collection)
-	default:
-		return NewExportError(fmt.Sprintf("unsupported export format: %s", format), nil)
+	var workers int
+	var progress TransferProgressFunc
+	if options != nil {
+		workers, progress = options.Workers, options.Progress
 	}
 
+	// Marshal to bytes, encoding profiles in parallel for large collections
+	if !isSupportedFormat(format) {
+		return NewExportError(fmt.Sprintf("unsupported export format: %s", format), nil)
+	}
+	data, err := encodeCollection(collection, format, workers, progress)
 	if err != nil {
 		return NewExportError("failed to marshal export data", err)
 	}
@@ -264,26 +263,16 @@ func (m *FileProfileManager) ImportFromFile(filePath string, options *ProfileImp
 		return NewImportError("failed to read import file", err)
 	}
 
-	// Determine format from file extension
-	var collection ProfileCollection
-	ext := strings.ToLower(filepath.Ext(filePath))
+	var workers int
+	var progress TransferProgressFunc
+	if options != nil {
+		workers, progress = options.Workers, options.Progress
+	}
 
-	switch ext {
-	case ".json":
-		if err := json.Unmarshal(data, &collection); err != nil {
-			return NewImportError("failed to parse JSON import file", err)
-		}
-	case ".yaml", ".yml":
-		if err := yaml.Unmarshal(data, &collection); err != nil {
-			return NewImportError("failed to parse YAML import file", err)
-		}
-	default:
-		// Try YAML first, then JSON
-		if err := yaml.Unmarshal(data, &collection); err != nil {
-			if jsonErr := json.Unmarshal(data, &collection); jsonErr != nil {
-				return NewImportError("failed to parse import file (tried both YAML and JSON)", err)
-			}
-		}
+	// Determine format from file extension, decoding profiles in parallel for large collections
+	collection, err := decodeCollection(data, filepath.Ext(filePath), workers, progress)
+	if err != nil {
+		return err
 	}
 
 	// Validate collection structure
@@ -291,7 +280,7 @@ func (m *FileProfileManager) ImportFromFile(filePath string, options *ProfileImp
 		return NewImportError("import file does not contain profiles", nil)
 	}
 
-	return m.ImportProfiles(&collection, options)
+	return m.ImportProfiles(collection, options)
 }
 
 // ExportProfilesForSharing creates a shareable export with minimal metadata
@@ -299,7 +288,7 @@ func (m *FileProfileManager) ExportProfilesForSharing(profileNames []string) (*P
 	options := &ProfileExportOptions{
 		Names:        profileNames,
 		IncludeStats: false,
-		Format:       "yaml",
+		Format:       FormatYAML,
 	}
 
 	collection, err := m.ExportProfiles(options)
@@ -359,74 +348,31 @@ func (m *FileProfileManager) CreateQuickExport(limit int) (*ProfileCollection, e
 	options := &ProfileExportOptions{
 		Names:        names,
 		IncludeStats: true,
-		Format:       "yaml",
+		Format:       FormatYAML,
 	}
 
 	return m.ExportProfiles(options)
 }
 
 // ValidateImportFile validates an import file without actually importing
+// Messages are prefixed with the profile name; ValidateImportFileReport groups them per profile.
 func ValidateImportFile(filePath string) (*ProfileValidationResult, error) {
-	// Read and parse file
-	data, err := os.ReadFile(filePath)
+	report, err := ValidateImportFileReport(filePath, DefaultTransferWorkers)
 	if err != nil {
-		return nil, NewImportError("failed to read import file", err)
-	}
-
-	var collection ProfileCollection
-	ext := strings.ToLower(filepath.Ext(filePath))
-
-	switch ext {
-	case ".json":
-		if err := json.Unmarshal(data, &collection); err != nil {
-			return nil, NewImportError("failed to parse JSON import file", err)
-		}
-	case ".yaml", ".yml":
-		if err := yaml.Unmarshal(data, &collection); err != nil {
-			return nil, NewImportError("failed to parse YAML import file", err)
-		}
-	default:
-		// Try both formats
-		if err := yaml.Unmarshal(data, &collection); err != nil {
-			if jsonErr := json.Unmarshal(data, &collection); jsonErr != nil {
-				return nil, NewImportError("failed to parse import file (tried both YAML and JSON)", err)
-			}
-		}
+		return nil, err
 	}
 
-	// Validate collection
 	result := &ProfileValidationResult{
-		Valid:    true,
-		Errors:   make([]string, 0),
+		Valid:    report.Valid,
+		Errors:   append(make([]string, 0), report.Errors...),
 		Warnings: make([]string, 0),
 	}
-
-	if collection.Profiles == nil {
-		result.Valid = false
-		result.Errors = append(result.Errors, "import file does not contain profiles")
-		return result, nil
-	}
-
-	// Create temporary manager for validation
-	tempManager := NewFileProfileManager("", "yaml")
-
-	for name, profile := range collection.Profiles {
-		validation, err := tempManager.ValidateProfile(&profile)
-		if err != nil {
-			result.Valid = false
-			result.Errors = append(result.Errors, fmt.Sprintf("profile '%s': validation error: %v", name, err))
-			continue
+	for _, p := range report.Profiles {
+		for _, message := range p.Errors {
+			result.Errors = append(result.Errors, fmt.Sprintf("profile '%s': %s", p.Name, message))
 		}
-
-		if !validation.Valid {
-			result.Valid = false
-			for _, err := range validation.Errors {
-				result.Errors = append(result.Errors, fmt.Sprintf("profile '%s': %s", name, err))
-			}
-		}
-
-		for _, warning := range validation.Warnings {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("profile '%s': %s", name, warning))
+		for _, warning := range p.Warnings {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("profile '%s': %s", p.Name, warning))
 		}
 	}
 
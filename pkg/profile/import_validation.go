@@ -0,0 +1,176 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/jql"
+)
+
+// ImportProfileReport is the validation outcome for one profile of an import file
+type ImportProfileReport struct {
+	Name     string   `json:"name" yaml:"name"`
+	Valid    bool     `json:"valid" yaml:"valid"`
+	Errors   []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// ImportValidationReport is the per-profile validation report for an import file
+type ImportValidationReport struct {
+	Valid    bool                  `json:"valid" yaml:"valid"`
+	Errors   []string              `json:"errors,omitempty" yaml:"errors,omitempty"` // file-level problems
+	Profiles []ImportProfileReport `json:"profiles" yaml:"profiles"`                 // sorted by name
+
+	// Workers is how many profiles were validated concurrently (1: serially)
+	Workers int `json:"workers" yaml:"workers"`
+	// Duration is the time taken to validate every profile
+	Duration time.Duration `json:"duration" yaml:"duration"`
+}
+
+// ValidCount returns how many profiles passed validation
+func (r *ImportValidationReport) ValidCount() int {
+	count := 0
+	for _, p := range r.Profiles {
+		if p.Valid {
+			count++
+		}
+	}
+	return count
+}
+
+// InvalidNames lists the profiles that failed validation
+func (r *ImportValidationReport) InvalidNames() []string {
+	var names []string
+	for _, p := range r.Profiles {
+		if !p.Valid {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// ValidateImportCollection checks every profile of an import collection
+// Besides ValidateProfile, each profile's JQL syntax, repository path and options are checked.
+// Profiles are validated in parallel for large collections; the report is sorted by name.
+func ValidateImportCollection(collection *ProfileCollection, workers int) *ImportValidationReport {
+	report := &ImportValidationReport{Valid: true, Profiles: make([]ImportProfileReport, 0)}
+	if collection == nil || collection.Profiles == nil {
+		report.Valid = false
+		report.Errors = append(report.Errors, "import file does not contain profiles")
+		return report
+	}
+
+	start := time.Now()
+	validator := NewFileProfileManager("", FormatYAML)
+	names := sortedProfileNames(collection.Profiles)
+	report.Workers = transferWorkers(len(names), workers)
+	report.Profiles = make([]ImportProfileReport, len(names))
+	_ = forEachProfile(names, workers, nil, func(i int, name string) error {
+		p := collection.Profiles[name]
+		if p.Name == "" {
+			p.Name = name // import names profiles by their key
+		}
+		report.Profiles[i] = validateImportedProfile(validator, name, &p)
+		return nil
+	})
+	report.Duration = time.Since(start)
+
+	for _, p := range report.Profiles {
+		if !p.Valid {
+			report.Valid = false
+		}
+	}
+	return report
+}
+
+// ValidateImportFileReport parses an import file and validates each of its profiles
+func ValidateImportFileReport(filePath string, workers int) (*ImportValidationReport, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, NewImportError("failed to read import file", err)
+	}
+
+	collection, err := decodeCollection(data, filepath.Ext(filePath), workers, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateImportCollection(collection, workers), nil
+}
+
+// validateImportedProfile runs the import checks for one profile
+func validateImportedProfile(validator *FileProfileManager, name string, p *Profile) ImportProfileReport {
+	report := ImportProfileReport{Name: name, Valid: true}
+	addError := func(message string) {
+		report.Valid = false
+		report.Errors = appendUnique(report.Errors, message)
+	}
+
+	validation, err := validator.ValidateProfile(p)
+	if err != nil {
+		addError(fmt.Sprintf("validation error: %v", err))
+		return report
+	}
+	for _, message := range validation.Errors {
+		addError(message)
+	}
+	for _, message := range validation.Warnings {
+		report.Warnings = appendUnique(report.Warnings, message)
+	}
+
+	if p.JQL != "" {
+		if err := ValidateJQLQuery(p.JQL); err != nil {
+			addError(validationMessage(err))
+		}
+		for _, problem := range jql.ValidateSyntax(p.JQL) {
+			addError("JQL: " + problem)
+		}
+	}
+
+	if p.Repository != "" {
+		if err := ValidateRepositoryPath(p.Repository); err != nil {
+			addError(validationMessage(err))
+		} else if info, err := os.Stat(p.Repository); err == nil && !info.IsDir() {
+			addError(fmt.Sprintf("repository path %s is a file, not a directory", p.Repository))
+		} else if err != nil && filepath.IsAbs(p.Repository) {
+			report.Warnings = appendUnique(report.Warnings,
+				fmt.Sprintf("repository path %s does not exist on this machine", p.Repository))
+		}
+	}
+
+	options := ValidateProfileOptions(p.Options)
+	for _, fieldErr := range options.Errors {
+		// ValidateProfile already reports these (an unset concurrency uses the default)
+		if fieldErr.Field == "options.concurrency" || fieldErr.Field == "options.rate_limit" {
+			continue
+		}
+		addError(fieldErr.Message)
+	}
+	for _, warning := range options.Warnings {
+		report.Warnings = appendUnique(report.Warnings, warning.Message)
+	}
+
+	return report
+}
+
+// validationMessage returns the message of a validation error without its type prefix
+func validationMessage(err error) string {
+	var profileErr *ProfileError
+	if errors.As(err, &profileErr) {
+		return profileErr.Message
+	}
+	return err.Error()
+}
+
+// appendUnique appends message unless it is already present
+func appendUnique(messages []string, message string) []string {
+	for _, existing := range messages {
+		if strings.EqualFold(existing, message) {
+			return messages
+		}
+	}
+	return append(messages, message)
+}
@@ -0,0 +1,168 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mixedCollection has one valid profile and several invalid ones
+func mixedCollection() *ProfileCollection {
+	collection := largeCollection(0)
+	valid := Profile{JQL: "project = PROJ", Repository: "./repo", Options: ProfileOptions{Concurrency: 2, RateLimit: "200ms"}}
+	collection.Profiles["good"] = valid
+
+	badJQL := valid
+	badJQL.JQL = `project = PROJ AND summary ~ "broken`
+	collection.Profiles["bad-jql"] = badJQL
+
+	badRepo := valid
+	badRepo.Repository = "../../etc"
+	collection.Profiles["bad-repo"] = badRepo
+
+	noMode := valid
+	noMode.JQL = ""
+	collection.Profiles["no-mode"] = noMode
+
+	badOption := valid
+	badOption.Options.OnDirty = "discard"
+	collection.Profiles["bad-option"] = badOption
+
+	return collection
+}
+
+func TestValidateImportCollection_PerProfileReport(t *testing.T) {
+	report := ValidateImportCollection(mixedCollection(), 0)
+
+	if report.Valid {
+		t.Fatal("Expected report to be invalid")
+	}
+	if report.ValidCount() != 1 {
+		t.Errorf("ValidCount() = %d, want 1", report.ValidCount())
+	}
+
+	wantInvalid := []string{"bad-jql", "bad-option", "bad-repo", "no-mode"}
+	if got := report.InvalidNames(); strings.Join(got, ",") != strings.Join(wantInvalid, ",") {
+		t.Errorf("InvalidNames() = %v, want %v", got, wantInvalid)
+	}
+
+	expectedErrors := map[string]string{
+		"bad-jql":    "unbalanced quotes",
+		"bad-repo":   "directory traversal",
+		"no-mode":    "at least one sync mode",
+		"bad-option": "on_dirty",
+	}
+	for _, p := range report.Profiles {
+		want, ok := expectedErrors[p.Name]
+		if !ok {
+			if len(p.Errors) != 0 {
+				t.Errorf("Profile %s: unexpected errors %v", p.Name, p.Errors)
+			}
+			continue
+		}
+		if !strings.Contains(strings.Join(p.Errors, "; "), want) {
+			t.Errorf("Profile %s: errors %v do not mention %q", p.Name, p.Errors, want)
+		}
+	}
+}
+
+func TestValidateImportCollection_RepositoryIsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	collection := largeCollection(0)
+	collection.Profiles["file-repo"] = Profile{JQL: "project = PROJ", Repository: file, Options: ProfileOptions{Concurrency: 2}}
+	collection.Profiles["missing-repo"] = Profile{JQL: "project = PROJ", Repository: filepath.Join(t.TempDir(), "missing"), Options: ProfileOptions{Concurrency: 2}}
+
+	report := ValidateImportCollection(collection, 0)
+	for _, p := range report.Profiles {
+		switch p.Name {
+		case "file-repo":
+			if p.Valid {
+				t.Error("Expected a repository path that is a file to be invalid")
+			}
+		case "missing-repo":
+			if !p.Valid || len(p.Warnings) == 0 {
+				t.Errorf("Expected a missing absolute repository to only warn, got %+v", p)
+			}
+		}
+	}
+}
+
+func TestValidateImportCollection_Parallel(t *testing.T) {
+	collection := largeCollection(ParallelTransferThreshold * 2)
+	bad := collection.Profiles["profile-017"]
+	bad.JQL = "project = (P17"
+	collection.Profiles["profile-017"] = bad
+
+	report := ValidateImportCollection(collection, 8)
+	if len(report.Profiles) != len(collection.Profiles) {
+		t.Fatalf("Expected %d profile reports, got %d", len(collection.Profiles), len(report.Profiles))
+	}
+	for i := 1; i < len(report.Profiles); i++ {
+		if report.Profiles[i-1].Name >= report.Profiles[i].Name {
+			t.Fatalf("Report is not sorted by name at %d", i)
+		}
+	}
+	if got := report.InvalidNames(); len(got) != 1 || got[0] != "profile-017" {
+		t.Errorf("InvalidNames() = %v, want [profile-017]", got)
+	}
+	if report.Workers != 8 || report.Duration <= 0 {
+		t.Errorf("Expected timing for 8 workers, got %d workers in %v", report.Workers, report.Duration)
+	}
+
+	// Small collections are validated serially
+	if small := ValidateImportCollection(mixedCollection(), 8); small.Workers != 1 {
+		t.Errorf("Expected serial validation below the threshold, got %d workers", small.Workers)
+	}
+}
+
+func TestImportProfiles_SkipInvalid(t *testing.T) {
+	manager := NewFileProfileManager(t.TempDir(), "yaml")
+
+	err := manager.ImportProfiles(mixedCollection(), &ProfileImportOptions{Validate: true})
+	if err == nil {
+		t.Fatal("Expected import to fail on invalid profiles without SkipInvalid")
+	}
+	if manager.ProfileExists("good") {
+		t.Error("Expected nothing to be imported when validation fails")
+	}
+
+	err = manager.ImportProfiles(mixedCollection(), &ProfileImportOptions{Validate: true, SkipInvalid: true})
+	if err != nil {
+		t.Fatalf("ImportProfiles with SkipInvalid error = %v", err)
+	}
+	if !manager.ProfileExists("good") {
+		t.Error("Expected the valid profile to be imported")
+	}
+	for _, name := range []string{"bad-jql", "bad-repo", "no-mode", "bad-option"} {
+		if manager.ProfileExists(name) {
+			t.Errorf("Expected invalid profile %s to be skipped", name)
+		}
+	}
+}
+
+func TestValidateImportFile_PrefixesProfileNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	data, err := encodeCollection(mixedCollection(), "yaml", 0, nil)
+	if err != nil {
+		t.Fatalf("encodeCollection error = %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write import file: %v", err)
+	}
+
+	result, err := ValidateImportFile(path)
+	if err != nil {
+		t.Fatalf("ValidateImportFile error = %v", err)
+	}
+	if result.Valid {
+		t.Error("Expected import file to be invalid")
+	}
+	if !strings.Contains(strings.Join(result.Errors, "\n"), "profile 'bad-jql': JQL: unbalanced quotes in JQL") {
+		t.Errorf("Expected prefixed JQL error, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,213 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lint rule IDs
+const (
+	LintRuleUnboundedJQL     = "unbounded-jql"
+	LintRuleHighConcurrency  = "high-concurrency"
+	LintRuleMissingRateLimit = "missing-rate-limit"
+	LintRuleBroadScope       = "broad-scope"
+	LintRuleAlwaysForce      = "always-force"
+)
+
+const (
+	// DefaultLintMaxConcurrency is the concurrency above which high-concurrency warns
+	DefaultLintMaxConcurrency = 8
+
+	// DefaultLintMaxIssueKeys is the issue list size above which broad-scope warns
+	DefaultLintMaxIssueKeys = 200
+)
+
+// LintRule is an advisory best-practice check
+// Lint rules never make a profile invalid; that is what ValidateProfile is for.
+type LintRule struct {
+	ID          string
+	Description string
+	check       func(p *Profile, cfg *LintConfig) []string
+}
+
+// LintConfig tunes the lint rules, read from the profile_lint section of .jira-sync.yaml
+//
+//	profile_lint:
+//	  disabled: [missing-rate-limit]
+//	  max_concurrency: 6
+type LintConfig struct {
+	Disabled       []string `yaml:"disabled"`
+	MaxConcurrency int      `yaml:"max_concurrency"`
+	MaxIssueKeys   int      `yaml:"max_issue_keys"`
+}
+
+// LintWarning is one finding for a profile
+type LintWarning struct {
+	Profile string `json:"profile" yaml:"profile"`
+	Rule    string `json:"rule" yaml:"rule"`
+	Message string `json:"message" yaml:"message"`
+}
+
+var (
+	jqlScopeClause = regexp.MustCompile(`(?i)\b(project|key|issuekey|parent|"?epic link"?|filter|sprint)\s*(=|in\b|~)`)
+	jqlDateClause  = regexp.MustCompile(`(?i)\b(updated|created|resolved|resolutiondate|due|duedate)\s*(>=?|<=?|=)`)
+	jqlClauseSplit = regexp.MustCompile(`(?i)\s+(and|or)\s+`)
+)
+
+// LintRules lists every lint rule in report order
+func LintRules() []LintRule {
+	return []LintRule{
+		{
+			ID:          LintRuleUnboundedJQL,
+			Description: "JQL without a project, key, EPIC, sprint, filter or date restriction",
+			check: func(p *Profile, cfg *LintConfig) []string {
+				query := stripOrderBy(p.JQL)
+				if query == "" || jqlScopeClause.MatchString(query) || jqlDateClause.MatchString(query) {
+					return nil
+				}
+				return []string{"JQL has no project or date filter and may match every issue in JIRA"}
+			},
+		},
+		{
+			ID:          LintRuleHighConcurrency,
+			Description: "concurrency above the configured maximum",
+			check: func(p *Profile, cfg *LintConfig) []string {
+				if p.Options.Concurrency <= cfg.MaxConcurrency {
+					return nil
+				}
+				return []string{fmt.Sprintf("concurrency %d exceeds %d and may trip JIRA rate limits", p.Options.Concurrency, cfg.MaxConcurrency)}
+			},
+		},
+		{
+			ID:          LintRuleMissingRateLimit,
+			Description: "no delay between API calls",
+			check: func(p *Profile, cfg *LintConfig) []string {
+				if p.Options.RateLimit == "" {
+					return []string{"no rate_limit set; the default applies but is not recorded in the profile"}
+				}
+				if d, err := time.ParseDuration(p.Options.RateLimit); err == nil && d <= 0 {
+					return []string{fmt.Sprintf("rate_limit %s disables throttling between API calls", p.Options.RateLimit)}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          LintRuleBroadScope,
+			Description: "whole-project or very large scopes synced in full on every run",
+			check: func(p *Profile, cfg *LintConfig) []string {
+				if len(p.IssueKeys) > cfg.MaxIssueKeys {
+					return []string{fmt.Sprintf("%d issue keys exceeds %d; a JQL query is easier to maintain", len(p.IssueKeys), cfg.MaxIssueKeys)}
+				}
+				query := stripOrderBy(p.JQL)
+				if query != "" && !p.Options.Incremental && len(jqlClauseSplit.Split(query, -1)) == 1 &&
+					strings.HasPrefix(strings.ToLower(strings.TrimSpace(query)), "project") {
+					return []string{"JQL selects a whole project without incremental sync; every run refetches all of it"}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          LintRuleAlwaysForce,
+			Description: "force enabled in a saved profile",
+			check: func(p *Profile, cfg *LintConfig) []string {
+				if !p.Options.Force {
+					return nil
+				}
+				return []string{"force ignores sync state on every run; pass --force when needed instead"}
+			},
+		},
+	}
+}
+
+// LintProfile runs the enabled rules against a profile
+func LintProfile(p *Profile, cfg *LintConfig) ([]LintWarning, error) {
+	cfg, err := cfg.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	disabled := make(map[string]bool, len(cfg.Disabled))
+	for _, id := range cfg.Disabled {
+		disabled[id] = true
+	}
+
+	var warnings []LintWarning
+	for _, rule := range LintRules() {
+		if disabled[rule.ID] {
+			continue
+		}
+		for _, message := range rule.check(p, cfg) {
+			warnings = append(warnings, LintWarning{Profile: p.Name, Rule: rule.ID, Message: message})
+		}
+	}
+	return warnings, nil
+}
+
+// withDefaults fills unset thresholds and rejects unknown rule IDs
+func (cfg *LintConfig) withDefaults() (*LintConfig, error) {
+	result := LintConfig{}
+	if cfg != nil {
+		result = *cfg
+	}
+	if result.MaxConcurrency <= 0 {
+		result.MaxConcurrency = DefaultLintMaxConcurrency
+	}
+	if result.MaxIssueKeys <= 0 {
+		result.MaxIssueKeys = DefaultLintMaxIssueKeys
+	}
+
+	known := make(map[string]bool)
+	var ids []string
+	for _, rule := range LintRules() {
+		known[rule.ID] = true
+		ids = append(ids, rule.ID)
+	}
+	for _, id := range result.Disabled {
+		if !known[id] {
+			sort.Strings(ids)
+			return nil, fmt.Errorf("unknown lint rule %q (available: %s)", id, strings.Join(ids, ", "))
+		}
+	}
+	return &result, nil
+}
+
+// LoadLintConfig reads the profile_lint section from the given settings files
+// The last file that defines the section wins; missing files are skipped.
+func LoadLintConfig(paths ...string) (*LintConfig, error) {
+	result := &LintConfig{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		var settings struct {
+			ProfileLint *LintConfig `yaml:"profile_lint"`
+		}
+		if err := yaml.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("invalid profile_lint configuration in %s: %w", path, err)
+		}
+		if settings.ProfileLint != nil {
+			result = settings.ProfileLint
+		}
+	}
+	return result, nil
+}
+
+// stripOrderBy removes a trailing ORDER BY clause, which never narrows a query
+func stripOrderBy(query string) string {
+	if idx := strings.Index(strings.ToLower(query), "order by"); idx >= 0 {
+		query = query[:idx]
+	}
+	return strings.TrimSpace(query)
+}
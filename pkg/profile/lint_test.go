@@ -0,0 +1,104 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func lintRuleIDs(warnings []LintWarning) []string {
+	var ids []string
+	for _, w := range warnings {
+		ids = append(ids, w.Rule)
+	}
+	return ids
+}
+
+func TestLintProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		want    []string
+	}{
+		{
+			name:    "well-formed",
+			profile: Profile{Name: "ok", JQL: "project = PROJ AND updated >= -7d", Options: ProfileOptions{Concurrency: 5, RateLimit: "500ms"}},
+			want:    nil,
+		},
+		{
+			name:    "unbounded jql",
+			profile: Profile{Name: "open-bugs", JQL: "type = Bug AND status != Done ORDER BY project", Options: ProfileOptions{Concurrency: 5, RateLimit: "500ms"}},
+			want:    []string{LintRuleUnboundedJQL},
+		},
+		{
+			name:    "whole project without incremental",
+			profile: Profile{Name: "all", JQL: "project in (A, B)", Options: ProfileOptions{Concurrency: 10, RateLimit: "0s", Force: true}},
+			want:    []string{LintRuleHighConcurrency, LintRuleMissingRateLimit, LintRuleBroadScope, LintRuleAlwaysForce},
+		},
+		{
+			name:    "whole project incremental",
+			profile: Profile{Name: "all", JQL: "project = A", Options: ProfileOptions{Concurrency: 5, RateLimit: "1s", Incremental: true}},
+			want:    nil,
+		},
+		{
+			name:    "epic without rate limit",
+			profile: Profile{Name: "epic", EpicKey: "PROJ-1", Options: ProfileOptions{Concurrency: 5}},
+			want:    []string{LintRuleMissingRateLimit},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := LintProfile(&tt.profile, nil)
+			if err != nil {
+				t.Fatalf("LintProfile() error = %v", err)
+			}
+			if got := lintRuleIDs(warnings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintProfile() rules = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintProfile_Config(t *testing.T) {
+	p := &Profile{Name: "busy", JQL: "project = PROJ AND status = Open", Options: ProfileOptions{Concurrency: 6}}
+
+	warnings, err := LintProfile(p, &LintConfig{MaxConcurrency: 4, Disabled: []string{LintRuleMissingRateLimit}})
+	if err != nil {
+		t.Fatalf("LintProfile() error = %v", err)
+	}
+	if got := lintRuleIDs(warnings); !reflect.DeepEqual(got, []string{LintRuleHighConcurrency}) {
+		t.Errorf("Expected only high-concurrency, got %v", got)
+	}
+
+	if _, err := LintProfile(p, &LintConfig{Disabled: []string{"no-such-rule"}}); err == nil || !strings.Contains(err.Error(), "unknown lint rule") {
+		t.Errorf("Expected unknown rule error, got %v", err)
+	}
+}
+
+func TestLoadLintConfig(t *testing.T) {
+	dir := t.TempDir()
+	home := filepath.Join(dir, "home.yaml")
+	project := filepath.Join(dir, "project.yaml")
+	if err := os.WriteFile(home, []byte("profile_lint:\n  max_concurrency: 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(project, []byte("profile_lint:\n  disabled: [always-force]\n  max_issue_keys: 50\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadLintConfig(home, project, filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadLintConfig() error = %v", err)
+	}
+	if cfg.MaxConcurrency != 0 || cfg.MaxIssueKeys != 50 || !reflect.DeepEqual(cfg.Disabled, []string{"always-force"}) {
+		t.Errorf("Expected the project section to win, got %+v", cfg)
+	}
+
+	cfg, err = LoadLintConfig(filepath.Join(dir, "missing.yaml"))
+	if err != nil || cfg == nil || len(cfg.Disabled) != 0 {
+		t.Errorf("Expected empty configuration, got %+v (err %v)", cfg, err)
+	}
+}
@@ -1,7 +1,6 @@
 package profile
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,24 +8,25 @@ import (
 	"sort"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // FileProfileManager implements ProfileManager using file-based storage
 type FileProfileManager struct {
 	profilesDir string
-	format      string // "yaml" or "json"
+	format      string // FormatYAML, FormatJSON, or FormatTOML
 }
 
-// NewFileProfileManager creates a new file-based profile manager
+// NewFileProfileManager creates a new file-based profile manager storing profiles in format
+// FormatAuto (or any unsupported format) uses the format of the existing profiles file, and
+// YAML when there is none.
 func NewFileProfileManager(baseDir string, format string) *FileProfileManager {
-	if format != "yaml" && format != "json" {
-		format = "yaml" // Default to YAML
-	}
-
 	profilesDir := filepath.Join(baseDir, ProfilesDir)
 
+	format = strings.ToLower(format)
+	if !isSupportedFormat(format) {
+		format = detectStoreFormat(profilesDir)
+	}
+
 	return &FileProfileManager{
 		profilesDir: profilesDir,
 		format:      format,
@@ -35,10 +35,7 @@ func NewFileProfileManager(baseDir string, format string) *FileProfileManager {
 
 // getProfilesFilePath returns the path to the profiles file
 func (m *FileProfileManager) getProfilesFilePath() string {
-	if m.format == "json" {
-		return filepath.Join(m.profilesDir, "profiles.json")
-	}
-	return filepath.Join(m.profilesDir, ProfilesFile)
+	return filepath.Join(m.profilesDir, profilesFileName(m.format))
 }
 
 // ensureProfilesDir creates the profiles directory if it doesn't exist
@@ -79,14 +76,8 @@ func (m *FileProfileManager) loadCollection() (*ProfileCollection, error) {
 	}
 
 	var collection ProfileCollection
-	if m.format == "json" {
-		if err := json.Unmarshal(data, &collection); err != nil {
-			return nil, fmt.Errorf("failed to parse JSON profiles file: %w", err)
-		}
-	} else {
-		if err := yaml.Unmarshal(data, &collection); err != nil {
-			return nil, fmt.Errorf("failed to parse YAML profiles file: %w", err)
-		}
+	if err := unmarshalProfiles(data, m.format, &collection); err != nil {
+		return nil, fmt.Errorf("failed to parse %s profiles file: %w", strings.ToUpper(m.format), err)
 	}
 
 	// Initialize maps if nil
@@ -123,13 +114,7 @@ func (m *FileProfileManager) SaveCollection(collection *ProfileCollection) error
 	collection.UpdatedAt = time.Now()
 
 	// Marshal to bytes
-	var data []byte
-	var err error
-	if m.format == "json" {
-		data, err = json.MarshalIndent(collection, "", "  ")
-	} else {
-		data, err = yaml.Marshal(collection)
-	}
+	data, err := marshalProfiles(collection, m.format)
 	if err != nil {
 		return fmt.Errorf("failed to marshal collection: %w", err)
 	}
@@ -340,11 +325,27 @@ func (m *FileProfileManager) ValidateProfile(profile *Profile) (*ProfileValidati
 		result.Errors = append(result.Errors, "profile can only specify one sync mode (JQL, issue keys, or epic key)")
 	}
 
+	// Pruning compares the repository against a query's full result set
+	if profile.Options.Prune && len(profile.IssueKeys) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, "prune requires jql or epic_key")
+	}
+
+	// An EPIC slice filter only narrows an EPIC sync
+	if profile.EpicWithin != "" && profile.EpicKey == "" {
+		result.Valid = false
+		result.Errors = append(result.Errors, "epic_within requires epic_key")
+	}
+
 	// Validate repository path
 	if profile.Repository == "" {
 		result.Valid = false
 		result.Errors = append(result.Errors, "repository path is required")
 	}
+	if problems := validateDestinations(profile); len(problems) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, problems...)
+	}
 
 	// Validate options
 	if profile.Options.Concurrency < 1 || profile.Options.Concurrency > 10 {
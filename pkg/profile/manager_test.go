@@ -275,6 +275,45 @@ func TestFileProfileManager_ValidateProfile(t *testing.T) {
 			},
 			wantValid: true,
 		},
+		{
+			name: "valid epic slice profile",
+			profile: &Profile{
+				Name:       "valid-epic-slice",
+				EpicKey:    "TEST-123",
+				EpicWithin: "component = Payments",
+				Repository: "./repo",
+				Options: ProfileOptions{
+					Concurrency: 5,
+				},
+			},
+			wantValid: true,
+		},
+		{
+			name: "invalid - epic_within without epic",
+			profile: &Profile{
+				Name:       "within-no-epic",
+				JQL:        "project = TEST",
+				EpicWithin: "component = Payments",
+				Repository: "./repo",
+				Options: ProfileOptions{
+					Concurrency: 5,
+				},
+			},
+			wantValid: false,
+		},
+		{
+			name: "invalid - prune with issue keys",
+			profile: &Profile{
+				Name:       "prune-issues",
+				IssueKeys:  []string{"TEST-1"},
+				Repository: "./repo",
+				Options: ProfileOptions{
+					Concurrency: 5,
+					Prune:       true,
+				},
+			},
+			wantValid: false,
+		},
 		{
 			name: "invalid - no sync mode",
 			profile: &Profile{
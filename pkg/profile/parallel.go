@@ -0,0 +1,392 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultTransferWorkers bounds concurrent per-profile encoding and decoding
+	DefaultTransferWorkers = 4
+
+	// ParallelTransferThreshold is the collection size from which profiles are encoded and
+	// decoded by the worker pool; smaller collections are processed sequentially
+	ParallelTransferThreshold = 64
+)
+
+// TransferProgressFunc reports per-profile progress while exporting or importing
+// Calls are serialized, so implementations do not need their own locking.
+type TransferProgressFunc func(done, total int)
+
+// forEachProfile runs fn for every name, in parallel for large collections
+// Errors are reported for the first failing name in input order so results are deterministic.
+func forEachProfile(names []string, workers int, progress TransferProgressFunc, fn func(index int, name string) error) error {
+	total := len(names)
+	workers = transferWorkers(total, workers)
+
+	errs := make([]error, total)
+	var mu sync.Mutex
+	done := 0
+	report := func() {
+		if progress == nil {
+			return
+		}
+		mu.Lock()
+		done++
+		progress(done, total)
+		mu.Unlock()
+	}
+
+	if workers <= 1 {
+		for i, name := range names {
+			errs[i] = fn(i, name)
+			report()
+		}
+	} else {
+		indexes := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexes {
+					errs[i] = fn(i, names[i])
+					report()
+				}
+			}()
+		}
+		for i := range names {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferWorkers returns how many workers process a collection of total profiles
+// workers <= 0 uses DefaultTransferWorkers; collections below ParallelTransferThreshold are
+// processed by a single worker, as the pool's overhead outweighs its gain.
+func transferWorkers(total, workers int) int {
+	if workers <= 0 {
+		workers = DefaultTransferWorkers
+	}
+	if total < ParallelTransferThreshold {
+		workers = 1
+	}
+	if workers > total {
+		workers = total
+	}
+	return workers
+}
+
+// sortedProfileNames returns the keys of a profile map in stable order
+func sortedProfileNames(profiles map[string]Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonCollection mirrors ProfileCollection with pre-encoded profiles
+type jsonCollection struct {
+	Version   string                     `json:"version"`
+	Profiles  map[string]json.RawMessage `json:"profiles"`
+	Templates map[string]Profile         `json:"templates"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+	Metadata  map[string]string          `json:"metadata,omitempty"`
+}
+
+// yamlCollection mirrors ProfileCollection with profiles left as undecoded nodes
+type yamlCollection struct {
+	Version   string               `yaml:"version"`
+	Profiles  map[string]yaml.Node `yaml:"profiles"`
+	Templates map[string]Profile   `yaml:"templates"`
+	UpdatedAt time.Time            `yaml:"updated_at"`
+	Metadata  map[string]string    `yaml:"metadata,omitempty"`
+}
+
+// encodeCollection serializes a collection, encoding profiles concurrently
+// Output is identical to marshaling the collection directly: profiles are sorted by name.
+func encodeCollection(collection *ProfileCollection, format string, workers int, progress TransferProgressFunc) ([]byte, error) {
+	names := sortedProfileNames(collection.Profiles)
+
+	switch format {
+	case "json":
+		encoded := make([]json.RawMessage, len(names))
+		err := forEachProfile(names, workers, progress, func(i int, name string) error {
+			data, err := json.Marshal(collection.Profiles[name])
+			if err != nil {
+				return fmt.Errorf("profile '%s': %w", name, err)
+			}
+			encoded[i] = data
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		shell := jsonCollection{
+			Version:   collection.Version,
+			Templates: collection.Templates,
+			UpdatedAt: collection.UpdatedAt,
+			Metadata:  collection.Metadata,
+		}
+		if collection.Profiles != nil {
+			shell.Profiles = make(map[string]json.RawMessage, len(names))
+			for i, name := range names {
+				shell.Profiles[name] = encoded[i]
+			}
+		}
+		return json.MarshalIndent(shell, "", "  ")
+
+	case "yaml":
+		encoded := make([]*yaml.Node, len(names))
+		err := forEachProfile(names, workers, progress, func(i int, name string) error {
+			node := &yaml.Node{}
+			if err := node.Encode(collection.Profiles[name]); err != nil {
+				return fmt.Errorf("profile '%s': %w", name, err)
+			}
+			encoded[i] = node
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		shell := *collection
+		shell.Profiles = nil
+		var root yaml.Node
+		if err := root.Encode(&shell); err != nil {
+			return nil, err
+		}
+
+		profilesNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for i, name := range names {
+			key := &yaml.Node{}
+			if err := key.Encode(name); err != nil {
+				return nil, err
+			}
+			profilesNode.Content = append(profilesNode.Content, key, encoded[i])
+		}
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == "profiles" {
+				root.Content[i+1] = profilesNode
+			}
+		}
+		return yaml.Marshal(&root)
+
+	case FormatTOML:
+		tables := make([]map[string]interface{}, len(names))
+		err := forEachProfile(names, workers, progress, func(i int, name string) error {
+			table, err := toTOMLTable(collection.Profiles[name])
+			if err != nil {
+				return fmt.Errorf("profile '%s': %w", name, err)
+			}
+			tables[i] = table
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		shell := *collection
+		shell.Profiles = nil
+		root, err := toTOMLTable(&shell)
+		if err != nil {
+			return nil, err
+		}
+		if collection.Profiles != nil {
+			profiles := make(map[string]interface{}, len(names))
+			for i, name := range names {
+				profiles[name] = tables[i]
+			}
+			root["profiles"] = profiles
+		}
+		return encodeTOML(root)
+
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// decodeCollection parses an export file, decoding profiles concurrently
+// The file extension selects the format; unknown extensions try YAML, then JSON, then TOML.
+func decodeCollection(data []byte, ext string, workers int, progress TransferProgressFunc) (*ProfileCollection, error) {
+	switch strings.ToLower(ext) {
+	case ".json":
+		collection, err := decodeJSONCollection(data, workers, progress)
+		if err != nil {
+			return nil, NewImportError("failed to parse JSON import file", err)
+		}
+		return collection, nil
+	case ".yaml", ".yml":
+		collection, err := decodeYAMLCollection(data, workers, progress)
+		if err != nil {
+			return nil, NewImportError("failed to parse YAML import file", err)
+		}
+		return collection, nil
+	case ".toml":
+		collection, err := decodeTOMLCollection(data, workers, progress)
+		if err != nil {
+			return nil, NewImportError("failed to parse TOML import file", err)
+		}
+		return collection, nil
+	default:
+		collection, err := decodeYAMLCollection(data, workers, progress)
+		if err != nil {
+			var jsonErr, tomlErr error
+			if collection, jsonErr = decodeJSONCollection(data, workers, progress); jsonErr != nil {
+				if collection, tomlErr = decodeTOMLCollection(data, workers, progress); tomlErr != nil {
+					return nil, NewImportError("failed to parse import file (tried YAML, JSON, and TOML)", err)
+				}
+			}
+		}
+		return collection, nil
+	}
+}
+
+// decodeJSONCollection parses a JSON export
+func decodeJSONCollection(data []byte, workers int, progress TransferProgressFunc) (*ProfileCollection, error) {
+	var shell jsonCollection
+	if err := json.Unmarshal(data, &shell); err != nil {
+		return nil, err
+	}
+
+	collection := &ProfileCollection{
+		Version:   shell.Version,
+		Templates: shell.Templates,
+		UpdatedAt: shell.UpdatedAt,
+		Metadata:  shell.Metadata,
+	}
+	if shell.Profiles == nil {
+		return collection, nil
+	}
+
+	names := make([]string, 0, len(shell.Profiles))
+	for name := range shell.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	decoded := make([]Profile, len(names))
+	err := forEachProfile(names, workers, progress, func(i int, name string) error {
+		if err := json.Unmarshal(shell.Profiles[name], &decoded[i]); err != nil {
+			return fmt.Errorf("profile '%s': %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Profiles = make(map[string]Profile, len(names))
+	for i, name := range names {
+		collection.Profiles[name] = decoded[i]
+	}
+	return collection, nil
+}
+
+// decodeYAMLCollection parses a YAML export
+func decodeYAMLCollection(data []byte, workers int, progress TransferProgressFunc) (*ProfileCollection, error) {
+	var shell yamlCollection
+	if err := yaml.Unmarshal(data, &shell); err != nil {
+		return nil, err
+	}
+
+	collection := &ProfileCollection{
+		Version:   shell.Version,
+		Templates: shell.Templates,
+		UpdatedAt: shell.UpdatedAt,
+		Metadata:  shell.Metadata,
+	}
+	if shell.Profiles == nil {
+		return collection, nil
+	}
+
+	names := make([]string, 0, len(shell.Profiles))
+	for name := range shell.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	decoded := make([]Profile, len(names))
+	err := forEachProfile(names, workers, progress, func(i int, name string) error {
+		node := shell.Profiles[name]
+		if err := node.Decode(&decoded[i]); err != nil {
+			return fmt.Errorf("profile '%s': %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Profiles = make(map[string]Profile, len(names))
+	for i, name := range names {
+		collection.Profiles[name] = decoded[i]
+	}
+	return collection, nil
+}
+
+// decodeTOMLCollection parses a TOML export
+func decodeTOMLCollection(data []byte, workers int, progress TransferProgressFunc) (*ProfileCollection, error) {
+	root, err := parseTOML(data)
+	if err != nil {
+		return nil, err
+	}
+	profiles, hasProfiles := root["profiles"].(map[string]interface{})
+	if _, exists := root["profiles"]; exists && !hasProfiles {
+		return nil, fmt.Errorf("profiles must be a table")
+	}
+	delete(root, "profiles")
+
+	collection := &ProfileCollection{}
+	if err := fromTOMLTable(root, collection); err != nil {
+		return nil, err
+	}
+	if !hasProfiles {
+		return collection, nil
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	decoded := make([]Profile, len(names))
+	err = forEachProfile(names, workers, progress, func(i int, name string) error {
+		table, ok := profiles[name].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("profile '%s': must be a table", name)
+		}
+		if err := fromTOMLTable(table, &decoded[i]); err != nil {
+			return fmt.Errorf("profile '%s': %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Profiles = make(map[string]Profile, len(names))
+	for i, name := range names {
+		collection.Profiles[name] = decoded[i]
+	}
+	return collection, nil
+}
@@ -0,0 +1,128 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// largeCollection builds a collection big enough to use the worker pool
+func largeCollection(size int) *ProfileCollection {
+	collection := &ProfileCollection{
+		Version:   ProfileVersion,
+		Profiles:  make(map[string]Profile),
+		Templates: make(map[string]Profile),
+		UpdatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Metadata:  map[string]string{"exported_by": "jira-sync"},
+	}
+	for i := 0; i < size; i++ {
+		name := fmt.Sprintf("profile-%03d", i)
+		collection.Profiles[name] = Profile{
+			Name:       name,
+			JQL:        fmt.Sprintf("project = P%d", i),
+			Repository: "./repo",
+			Options:    ProfileOptions{Concurrency: 2, RateLimit: "200ms"},
+			Tags:       []string{"team"},
+			CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Version:    ProfileVersion,
+		}
+	}
+	return collection
+}
+
+func TestEncodeCollection_MatchesDirectMarshal(t *testing.T) {
+	for _, size := range []int{0, 3, ParallelTransferThreshold * 2} {
+		collection := largeCollection(size)
+
+		got, err := encodeCollection(collection, "yaml", 8, nil)
+		if err != nil {
+			t.Fatalf("encodeCollection(yaml) error = %v", err)
+		}
+		want, _ := yaml.Marshal(collection)
+		if string(got) != string(want) {
+			t.Errorf("YAML output for %d profiles differs from direct marshal", size)
+		}
+
+		got, err = encodeCollection(collection, "json", 8, nil)
+		if err != nil {
+			t.Fatalf("encodeCollection(json) error = %v", err)
+		}
+		want, _ = json.MarshalIndent(collection, "", "  ")
+		if string(got) != string(want) {
+			t.Errorf("JSON output for %d profiles differs from direct marshal", size)
+		}
+	}
+}
+
+func TestExportImport_LargeCollectionWithProgress(t *testing.T) {
+	source := NewFileProfileManager(t.TempDir(), "yaml")
+	if err := source.SaveCollection(largeCollection(ParallelTransferThreshold * 3)); err != nil {
+		t.Fatalf("Failed to save collection: %v", err)
+	}
+
+	for _, ext := range []string{".yaml", ".json"} {
+		exportFile := filepath.Join(t.TempDir(), "profiles"+ext)
+		var exportCalls, lastExport int32
+		err := source.ExportToFile(exportFile, &ProfileExportOptions{
+			Workers: 4,
+			Progress: func(done, total int) {
+				atomic.AddInt32(&exportCalls, 1)
+				atomic.StoreInt32(&lastExport, int32(done))
+			},
+		})
+		if err != nil {
+			t.Fatalf("ExportToFile(%s) error = %v", ext, err)
+		}
+		if exportCalls != int32(ParallelTransferThreshold*3) || lastExport != exportCalls {
+			t.Errorf("Expected one progress call per profile, got %d calls ending at %d", exportCalls, lastExport)
+		}
+
+		target := NewFileProfileManager(t.TempDir(), "yaml")
+		var importCalls int32
+		err = target.ImportFromFile(exportFile, &ProfileImportOptions{
+			Workers:  4,
+			Progress: func(done, total int) { atomic.AddInt32(&importCalls, 1) },
+		})
+		if err != nil {
+			t.Fatalf("ImportFromFile(%s) error = %v", ext, err)
+		}
+		if importCalls != int32(ParallelTransferThreshold*3) {
+			t.Errorf("Expected %d import progress calls, got %d", ParallelTransferThreshold*3, importCalls)
+		}
+
+		imported, err := target.GetProfile("profile-100")
+		if err != nil || imported.JQL != "project = P100" {
+			t.Errorf("Expected profile-100 to round-trip, got %+v, %v", imported, err)
+		}
+	}
+}
+
+func TestForEachProfile_DeterministicError(t *testing.T) {
+	names := make([]string, ParallelTransferThreshold)
+	for i := range names {
+		names[i] = fmt.Sprintf("p%03d", i)
+	}
+
+	err := forEachProfile(names, 8, nil, func(i int, name string) error {
+		if i == 10 || i == 40 {
+			return errors.New(name)
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "p010" {
+		t.Errorf("Expected the first failing profile in order, got %v", err)
+	}
+}
+
+func TestDecodeCollection_InvalidProfile(t *testing.T) {
+	data := []byte("version: v0.3.0\nprofiles:\n  broken:\n    options: not-a-map\n")
+	if _, err := decodeCollection(data, ".yaml", 4, nil); err == nil {
+		t.Error("Expected error for malformed profile")
+	}
+}
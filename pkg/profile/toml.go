@@ -0,0 +1,101 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Profiles are serialized to TOML through their JSON form, so the TOML keys and the set of
+// round-tripped fields are exactly those of the JSON format. TOML datetimes decode to times,
+// which the JSON form carries as RFC 3339 strings. TOML has no null, so null values are omitted.
+
+// marshalTOML encodes a value with JSON tags as a TOML document
+func marshalTOML(v interface{}) ([]byte, error) {
+	table, err := toTOMLTable(v)
+	if err != nil {
+		return nil, err
+	}
+	return encodeTOML(table)
+}
+
+// unmarshalTOML decodes a TOML document into a value with JSON tags
+func unmarshalTOML(data []byte, v interface{}) error {
+	table, err := parseTOML(data)
+	if err != nil {
+		return err
+	}
+	return fromTOMLTable(table, v)
+}
+
+// toTOMLTable converts a value with JSON tags to the table encodeTOML writes
+func toTOMLTable(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	table, ok := tomlNumbers(value).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("TOML documents must be tables, got %T", value)
+	}
+	return table, nil
+}
+
+// tomlNumbers replaces JSON numbers with integers, or floats when they have a fraction or
+// exponent, so they are written as TOML numbers rather than strings
+func tomlNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = tomlNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = tomlNumbers(item)
+		}
+	}
+	return value
+}
+
+// fromTOMLTable converts a parsed table into a value with JSON tags
+func fromTOMLTable(table map[string]interface{}, v interface{}) error {
+	data, err := json.Marshal(table)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// encodeTOML writes a table as a TOML document
+func encodeTOML(table map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := toml.NewEncoder(&buf)
+	encoder.Indent = ""
+	if err := encoder.Encode(table); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseTOML decodes a TOML document into maps, slices, and scalar values
+func parseTOML(data []byte) (map[string]interface{}, error) {
+	table := make(map[string]interface{})
+	if err := toml.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
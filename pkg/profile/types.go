@@ -6,30 +6,84 @@ import (
 
 // Profile represents a named sync configuration that can be reused
 type Profile struct {
-	Name        string            `json:"name" yaml:"name"`
-	Description string            `json:"description" yaml:"description"`
-	JQL         string            `json:"jql,omitempty" yaml:"jql,omitempty"`
-	IssueKeys   []string          `json:"issue_keys,omitempty" yaml:"issue_keys,omitempty"`
-	EpicKey     string            `json:"epic_key,omitempty" yaml:"epic_key,omitempty"`
-	Repository  string            `json:"repository" yaml:"repository"`
-	Options     ProfileOptions    `json:"options" yaml:"options"`
-	Tags        []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
-	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
-	CreatedAt   time.Time         `json:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at" yaml:"updated_at"`
-	CreatedBy   string            `json:"created_by,omitempty" yaml:"created_by,omitempty"`
-	Version     string            `json:"version" yaml:"version"`
-	UsageStats  UsageStats        `json:"usage_stats" yaml:"usage_stats"`
+	Name         string            `json:"name" yaml:"name"`
+	Description  string            `json:"description" yaml:"description"`
+	JQL          string            `json:"jql,omitempty" yaml:"jql,omitempty"`
+	IssueKeys    []string          `json:"issue_keys,omitempty" yaml:"issue_keys,omitempty"`
+	EpicKey      string            `json:"epic_key,omitempty" yaml:"epic_key,omitempty"`
+	EpicWithin   string            `json:"epic_within,omitempty" yaml:"epic_within,omitempty"` // JQL filter limiting an EPIC sync to a slice
+	Repository   string            `json:"repository" yaml:"repository"`
+	Destinations []Destination     `json:"destinations,omitempty" yaml:"destinations,omitempty"` // more repositories with their own views of the issues
+	Options      ProfileOptions    `json:"options" yaml:"options"`
+	Tags         []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	CreatedAt    time.Time         `json:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" yaml:"updated_at"`
+	CreatedBy    string            `json:"created_by,omitempty" yaml:"created_by,omitempty"`
+	Version      string            `json:"version" yaml:"version"`
+	UsageStats   UsageStats        `json:"usage_stats" yaml:"usage_stats"`
 }
 
 // ProfileOptions contains sync configuration options for a profile
 type ProfileOptions struct {
-	Concurrency  int    `json:"concurrency" yaml:"concurrency"`
-	RateLimit    string `json:"rate_limit" yaml:"rate_limit"`
-	Incremental  bool   `json:"incremental" yaml:"incremental"`
-	Force        bool   `json:"force" yaml:"force"`
-	DryRun       bool   `json:"dry_run" yaml:"dry_run"`
-	IncludeLinks bool   `json:"include_links" yaml:"include_links"`
+	Concurrency         int      `json:"concurrency" yaml:"concurrency"`
+	RateLimit           string   `json:"rate_limit" yaml:"rate_limit"`
+	Incremental         bool     `json:"incremental" yaml:"incremental"`
+	Force               bool     `json:"force" yaml:"force"`
+	DryRun              bool     `json:"dry_run" yaml:"dry_run"`
+	IncludeLinks        bool     `json:"include_links" yaml:"include_links"`
+	OnDirty             string   `json:"on_dirty,omitempty" yaml:"on_dirty,omitempty"`                         // fail (default), stash, commit, or ignore
+	ExcludeFields       []string `json:"exclude_fields,omitempty" yaml:"exclude_fields,omitempty"`             // YAML field patterns to omit (globs or re:regex)
+	OrderBy             string   `json:"order_by,omitempty" yaml:"order_by,omitempty"`                         // JQL result ordering (default: key)
+	GenerateIndex       bool     `json:"generate_index,omitempty" yaml:"generate_index,omitempty"`             // Write a per-project issue index after syncing
+	IndexFormat         string   `json:"index_format,omitempty" yaml:"index_format,omitempty"`                 // yaml (default) or markdown
+	Checksums           bool     `json:"checksums,omitempty" yaml:"checksums,omitempty"`                       // Write a per-project checksum manifest after syncing
+	Sign                bool     `json:"sign,omitempty" yaml:"sign,omitempty"`                                 // Sign checksum manifests with JIRA_SYNC_SIGNING_KEY (implies checksums)
+	WriteStatusBadge    bool     `json:"write_status_badge,omitempty" yaml:"write_status_badge,omitempty"`     // Write and commit .jira-sync-status.json after syncing
+	HandleDeletions     bool     `json:"handle_deletions,omitempty" yaml:"handle_deletions,omitempty"`         // Remove files of issues deleted in JIRA (404)
+	Prune               bool     `json:"prune,omitempty" yaml:"prune,omitempty"`                               // Remove files of issues that no longer match the profile's query
+	ArchivePruned       string   `json:"archive_pruned,omitempty" yaml:"archive_pruned,omitempty"`             // With prune, move pruned issue files to this repository directory
+	LinkConcurrency     int      `json:"link_concurrency,omitempty" yaml:"link_concurrency,omitempty"`         // Parallel link creation per issue (0: default)
+	LinkNaming          string   `json:"link_naming,omitempty" yaml:"link_naming,omitempty"`                   // Relationship link names: key (default), key-summary, or type-key
+	BulkFetchSize       int      `json:"bulk_fetch_size,omitempty" yaml:"bulk_fetch_size,omitempty"`           // Issues fetched per search call (0: default, 1: one request per issue)
+	Transform           string   `json:"transform,omitempty" yaml:"transform,omitempty"`                       // Executable or Go template applied to each issue before writing
+	FilterExpr          string   `json:"filter_expr,omitempty" yaml:"filter_expr,omitempty"`                   // Predicate fetched issues must match to be written
+	MergeUpdate         bool     `json:"merge_update,omitempty" yaml:"merge_update,omitempty"`                 // Update only changed fields of existing issue files
+	ADFRender           string   `json:"adf_render,omitempty" yaml:"adf_render,omitempty"`                     // ADF descriptions as markdown (default), text, or raw
+	FlattenFields       string   `json:"flatten_fields,omitempty" yaml:"flatten_fields,omitempty"`             // Custom field flattening overrides as type=property pairs
+	NoFlatten           bool     `json:"no_flatten,omitempty" yaml:"no_flatten,omitempty"`                     // Write custom field values raw
+	NormalizeTimestamps string   `json:"normalize_timestamps,omitempty" yaml:"normalize_timestamps,omitempty"` // Timestamps as preserve (default) or utc
+	FieldOrder          string   `json:"field_order,omitempty" yaml:"field_order,omitempty"`                   // Top-level issue fields written first, "*" for the rest
+	PermissionCheck     string   `json:"permission_check,omitempty" yaml:"permission_check,omitempty"`         // Check project browse permission before fetching: off (default), warn, or fail
+	CommitStrategy      string   `json:"commit_strategy,omitempty" yaml:"commit_strategy,omitempty"`           // per-issue (default), batch, or summary
+	CheckpointInterval  int      `json:"checkpoint_interval,omitempty" yaml:"checkpoint_interval,omitempty"`   // With batch or summary, commit every N changed issues (0: once)
+	RampUp              string   `json:"ramp_up,omitempty" yaml:"ramp_up,omitempty"`                           // Start workers gradually over this duration (e.g. 10s)
+
+	StatusTransitionsOnly     bool `json:"status_transitions_only,omitempty" yaml:"status_transitions_only,omitempty"`         // Incremental syncs only take issues whose status changed
+	GenerateRelationshipIndex bool `json:"generate_relationship_index,omitempty" yaml:"generate_relationship_index,omitempty"` // Write relationships/index.yaml after syncing
+
+	GitRetries      int    `json:"git_retries,omitempty" yaml:"git_retries,omitempty"`             // Retries of Git init and commits that fail transiently (0: none)
+	GitRetryBackoff string `json:"git_retry_backoff,omitempty" yaml:"git_retry_backoff,omitempty"` // Wait before the first Git retry, doubled per retry (default 500ms)
+
+	MaxFiles    int  `json:"max_files,omitempty" yaml:"max_files,omitempty"`         // Abort before writing if the scope has more issues (0: default 50000)
+	NoFileLimit bool `json:"no_file_limit,omitempty" yaml:"no_file_limit,omitempty"` // Disable the max_files safety limit
+
+	MaxAPICalls int    `json:"max_api_calls,omitempty" yaml:"max_api_calls,omitempty"` // Stop the sync after this many JIRA requests (0: unlimited)
+	MaxDuration string `json:"max_duration,omitempty" yaml:"max_duration,omitempty"`   // Stop the sync after this long, e.g. 30m (empty: unlimited)
+
+	Minimal              bool `json:"minimal,omitempty" yaml:"minimal,omitempty"`                             // Write only key, summary, status, assignee and updated
+	MinimalRelationships bool `json:"minimal_relationships,omitempty" yaml:"minimal_relationships,omitempty"` // With minimal, also write relationships
+
+	IndexSort string `json:"index_sort,omitempty" yaml:"index_sort,omitempty"` // Issue order of project indexes: key (default), status, updated, or priority
+
+	QuietHours         string `json:"quiet_hours,omitempty" yaml:"quiet_hours,omitempty"`                   // Daily window (e.g. 09:00-17:00) during which syncs slow down or pause
+	QuietHoursTimezone string `json:"quiet_hours_timezone,omitempty" yaml:"quiet_hours_timezone,omitempty"` // IANA timezone of quiet_hours (default: local time)
+	QuietHoursMode     string `json:"quiet_hours_mode,omitempty" yaml:"quiet_hours_mode,omitempty"`         // slow (default) or pause
+
+	NoManifest bool `json:"no_manifest,omitempty" yaml:"no_manifest,omitempty"` // Do not write and commit .jira-sync-manifest.yaml
+
+	Format            string `json:"format,omitempty" yaml:"format,omitempty"`                         // Issue files as yaml (default) or markdown
+	FrontmatterFields string `json:"frontmatter_fields,omitempty" yaml:"frontmatter_fields,omitempty"` // With format markdown, fields written as YAML frontmatter
 }
 
 // UsageStats tracks how often a profile is used
@@ -116,6 +170,11 @@ type ProfileExportOptions struct {
 	Tags         []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 	IncludeStats bool     `json:"include_stats" yaml:"include_stats"`
 	Format       string   `json:"format" yaml:"format"`
+
+	// Workers bounds parallel encoding for large collections (0 uses DefaultTransferWorkers)
+	Workers int `json:"-" yaml:"-"`
+	// Progress is called after each profile is encoded
+	Progress TransferProgressFunc `json:"-" yaml:"-"`
 }
 
 // ProfileImportOptions contains options for importing profiles
@@ -125,6 +184,12 @@ type ProfileImportOptions struct {
 	NamePrefix  string   `json:"name_prefix,omitempty" yaml:"name_prefix,omitempty"`
 	DefaultTags []string `json:"default_tags,omitempty" yaml:"default_tags,omitempty"`
 	Validate    bool     `json:"validate" yaml:"validate"`
+	SkipInvalid bool     `json:"skip_invalid" yaml:"skip_invalid"` // with Validate, import the valid profiles only
+
+	// Workers bounds parallel decoding and validation for large collections (0 uses DefaultTransferWorkers)
+	Workers int `json:"-" yaml:"-"`
+	// Progress is called after each profile is decoded
+	Progress TransferProgressFunc `json:"-" yaml:"-"`
 }
 
 // ProfileSearchOptions contains options for searching profiles
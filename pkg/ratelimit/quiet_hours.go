@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Quiet hours modes
+const (
+	QuietHoursSlow  = "slow"
+	QuietHoursPause = "pause"
+)
+
+// QuietHoursModes lists the supported quiet hours modes
+var QuietHoursModes = []string{QuietHoursSlow, QuietHoursPause}
+
+// QuietHours is a daily window, such as JIRA's business hours, during which syncs slow down to
+// one worker or pause entirely, leaving a shared instance to its interactive users
+type QuietHours struct {
+	// Mode is slow or pause
+	Mode string
+
+	start, end time.Duration // since midnight; an end before the start wraps past midnight
+	window     string
+	location   *time.Location
+}
+
+// ParseQuietHours parses a window such as "09:00-17:00" in an IANA timezone (empty: local
+// time) and a mode (empty: slow). An empty window returns nil: no quiet hours.
+func ParseQuietHours(window, timezone, mode string) (*QuietHours, error) {
+	window = strings.TrimSpace(window)
+	if window == "" {
+		return nil, nil
+	}
+
+	startArg, endArg, ok := strings.Cut(window, "-")
+	if !ok {
+		return nil, fmt.Errorf("quiet hours %q must be a window such as 09:00-17:00", window)
+	}
+	start, err := parseTimeOfDay(startArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours start: %w", err)
+	}
+	end, err := parseTimeOfDay(endArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours end: %w", err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("quiet hours %q start and end at the same time", window)
+	}
+
+	location := time.Local
+	if timezone = strings.TrimSpace(timezone); timezone != "" {
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("unknown quiet hours timezone %q: %w", timezone, err)
+		}
+	}
+
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = QuietHoursSlow
+	}
+	if mode != QuietHoursSlow && mode != QuietHoursPause {
+		return nil, fmt.Errorf("unsupported quiet hours mode %q (use %s)", mode, strings.Join(QuietHoursModes, ", "))
+	}
+
+	return &QuietHours{
+		Mode:     mode,
+		start:    start,
+		end:      end,
+		window:   strings.TrimSpace(startArg) + "-" + strings.TrimSpace(endArg),
+		location: location,
+	}, nil
+}
+
+// parseTimeOfDay parses an HH:MM time of day into the time since midnight
+func parseTimeOfDay(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an HH:MM time", strings.TrimSpace(value))
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// Active reports whether now falls within the quiet hours
+func (q *QuietHours) Active(now time.Time) bool {
+	if q == nil {
+		return false
+	}
+	local := now.In(q.location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+	if q.start < q.end {
+		return offset >= q.start && offset < q.end
+	}
+	return offset >= q.start || offset < q.end
+}
+
+// End returns the next time the quiet hours end after now
+func (q *QuietHours) End(now time.Time) time.Time {
+	local := now.In(q.location)
+	end := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, q.location).Add(q.end)
+	if !end.After(now) {
+		end = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, q.location).Add(q.end)
+	}
+	return end
+}
+
+// String describes the quiet hours window, e.g. "09:00-17:00 Europe/Berlin"
+func (q *QuietHours) String() string {
+	return q.window + " " + q.location.String()
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	if hours, err := ParseQuietHours("", "Europe/Berlin", "pause"); hours != nil || err != nil {
+		t.Errorf("Expected no quiet hours for an empty window, got %v, %v", hours, err)
+	}
+
+	hours, err := ParseQuietHours("09:00-17:00", "Europe/Berlin", "")
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+	if hours.Mode != QuietHoursSlow || hours.String() != "09:00-17:00 Europe/Berlin" {
+		t.Errorf("Expected slow 09:00-17:00 Europe/Berlin, got %s %s", hours.Mode, hours)
+	}
+
+	invalid := []struct {
+		window, timezone, mode, want string
+	}{
+		{"09:00", "", "", "window"},
+		{"9am-5pm", "", "", "HH:MM"},
+		{"09:00-25:00", "", "", "HH:MM"},
+		{"09:00-09:00", "", "", "same time"},
+		{"09:00-17:00", "Mars/Olympus", "", "timezone"},
+		{"09:00-17:00", "", "stop", "slow, pause"},
+	}
+	for _, tt := range invalid {
+		if _, err := ParseQuietHours(tt.window, tt.timezone, tt.mode); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseQuietHours(%q, %q, %q) error = %v, want it to mention %q", tt.window, tt.timezone, tt.mode, err, tt.want)
+		}
+	}
+}
+
+func TestQuietHours_Active(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	day, _ := ParseQuietHours("09:00-17:00", "Europe/Berlin", "slow")
+	night, _ := ParseQuietHours("22:00-06:00", "Europe/Berlin", "pause")
+
+	tests := []struct {
+		at         time.Time
+		day, night bool
+		dayEnd     time.Time
+	}{
+		{time.Date(2024, 3, 15, 8, 59, 0, 0, berlin), false, false, time.Date(2024, 3, 15, 17, 0, 0, 0, berlin)},
+		{time.Date(2024, 3, 15, 9, 0, 0, 0, berlin), true, false, time.Date(2024, 3, 15, 17, 0, 0, 0, berlin)},
+		{time.Date(2024, 3, 15, 16, 59, 59, 0, berlin), true, false, time.Date(2024, 3, 15, 17, 0, 0, 0, berlin)},
+		{time.Date(2024, 3, 15, 5, 59, 0, 0, berlin), false, true, time.Date(2024, 3, 15, 17, 0, 0, 0, berlin)},
+		{time.Date(2024, 3, 15, 17, 0, 0, 0, berlin), false, false, time.Date(2024, 3, 16, 17, 0, 0, 0, berlin)},
+		{time.Date(2024, 3, 15, 23, 30, 0, 0, berlin), false, true, time.Date(2024, 3, 16, 17, 0, 0, 0, berlin)},
+		// 08:30 UTC is 09:30 in Berlin
+		{time.Date(2024, 3, 15, 8, 30, 0, 0, time.UTC), true, false, time.Date(2024, 3, 15, 17, 0, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		if got := day.Active(tt.at); got != tt.day {
+			t.Errorf("09:00-17:00 Active(%v) = %t, want %t", tt.at, got, tt.day)
+		}
+		if got := night.Active(tt.at); got != tt.night {
+			t.Errorf("22:00-06:00 Active(%v) = %t, want %t", tt.at, got, tt.night)
+		}
+		if got := day.End(tt.at); !got.Equal(tt.dayEnd) {
+			t.Errorf("09:00-17:00 End(%v) = %v, want %v", tt.at, got, tt.dayEnd)
+		}
+	}
+
+	if end := night.End(time.Date(2024, 3, 15, 23, 30, 0, 0, berlin)); !end.Equal(time.Date(2024, 3, 16, 6, 0, 0, 0, berlin)) {
+		t.Errorf("Expected overnight quiet hours to end the next morning, got %v", end)
+	}
+
+	var none *QuietHours
+	if none.Active(time.Now()) {
+		t.Error("Expected nil quiet hours never to be active")
+	}
+}
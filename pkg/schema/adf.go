@@ -0,0 +1,346 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ADFRenderMode controls how Atlassian Document Format (ADF) descriptions are written
+type ADFRenderMode string
+
+const (
+	// ADFRenderMarkdown converts ADF to Markdown (the default)
+	ADFRenderMarkdown ADFRenderMode = "markdown"
+	// ADFRenderText converts ADF to plain text
+	ADFRenderText ADFRenderMode = "text"
+	// ADFRenderRaw writes the ADF JSON unchanged
+	ADFRenderRaw ADFRenderMode = "raw"
+)
+
+// ParseADFRenderMode validates an --adf-render value; empty selects Markdown
+func ParseADFRenderMode(value string) (ADFRenderMode, error) {
+	switch mode := ADFRenderMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return ADFRenderMarkdown, nil
+	case ADFRenderMarkdown, ADFRenderText, ADFRenderRaw:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported ADF render mode %q (expected raw, text, or markdown)", value)
+	}
+}
+
+// adfNode is one node of an ADF document
+// Raw keeps the node's original JSON so unknown node types can be preserved as-is.
+type adfNode struct {
+	Type    string                 `json:"type"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []adfMark              `json:"marks,omitempty"`
+	Content []*adfNode             `json:"content,omitempty"`
+	Raw     json.RawMessage        `json:"-"`
+}
+
+type adfMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// UnmarshalJSON decodes a node and keeps its raw JSON
+func (n *adfNode) UnmarshalJSON(data []byte) error {
+	type plain adfNode
+	if err := json.Unmarshal(data, (*plain)(n)); err != nil {
+		return err
+	}
+	n.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// parseADF decodes text as an ADF document, reporting false for anything else
+func parseADF(text string) (*adfNode, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") || !strings.Contains(trimmed, `"doc"`) {
+		return nil, false
+	}
+	var doc adfNode
+	if err := json.Unmarshal([]byte(trimmed), &doc); err != nil || doc.Type != "doc" {
+		return nil, false
+	}
+	return &doc, true
+}
+
+// IsADF reports whether text is an ADF document
+func IsADF(text string) bool {
+	_, ok := parseADF(text)
+	return ok
+}
+
+// RenderADF converts an ADF document to text or Markdown
+// Text that is not ADF, and any text in raw mode, is returned unchanged. Node types the renderer
+// does not know are kept as their original JSON, so no content is lost.
+func RenderADF(text string, mode ADFRenderMode) string {
+	if mode == ADFRenderRaw {
+		return text
+	}
+	doc, ok := parseADF(text)
+	if !ok {
+		return text
+	}
+
+	r := &adfRenderer{markdown: mode != ADFRenderText}
+	return strings.TrimRight(r.blocks(doc.Content, ""), "\n")
+}
+
+// adfRenderer walks an ADF tree; markdown selects Markdown syntax over plain text
+type adfRenderer struct {
+	markdown bool
+}
+
+// blocks renders block nodes separated by blank lines, each line prefixed by indent
+func (r *adfRenderer) blocks(nodes []*adfNode, indent string) string {
+	var parts []string
+	for _, node := range nodes {
+		if rendered := r.block(node, indent); rendered != "" {
+			parts = append(parts, rendered)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// block renders one block node
+func (r *adfRenderer) block(node *adfNode, indent string) string {
+	switch node.Type {
+	case "paragraph":
+		return prefixLines(r.inline(node.Content), indent)
+	case "heading":
+		text := r.inline(node.Content)
+		if r.markdown {
+			text = strings.Repeat("#", clampInt(attrInt(node.Attrs, "level"), 1, 6)) + " " + text
+		}
+		return prefixLines(text, indent)
+	case "bulletList", "orderedList":
+		return r.list(node, indent)
+	case "codeBlock":
+		code := r.plainText(node.Content)
+		if !r.markdown {
+			return prefixLines(code, indent+"    ")
+		}
+		return prefixLines("```"+attrString(node.Attrs, "language")+"\n"+code+"\n```", indent)
+	case "blockquote", "panel":
+		body := r.blocks(node.Content, "")
+		if r.markdown {
+			return prefixLines(body, indent+"> ")
+		}
+		return prefixLines(body, indent+"  ")
+	case "rule":
+		if r.markdown {
+			return indent + "---"
+		}
+		return indent + "----------"
+	case "table":
+		return r.table(node, indent)
+	case "mediaSingle", "mediaGroup":
+		return prefixLines(r.inline(node.Content), indent)
+	case "text", "hardBreak", "mention", "emoji", "inlineCard", "status", "date", "media":
+		return prefixLines(r.inline([]*adfNode{node}), indent)
+	case "blockCard", "embedCard":
+		return indent + attrString(node.Attrs, "url")
+	default:
+		return prefixLines(r.preserved(node, true), indent)
+	}
+}
+
+// list renders bullet and ordered lists, nesting sub-lists under their items
+func (r *adfRenderer) list(node *adfNode, indent string) string {
+	var lines []string
+	number := attrInt(node.Attrs, "order")
+	if number < 1 {
+		number = 1
+	}
+	for _, item := range node.Content {
+		marker := "- "
+		if node.Type == "orderedList" {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+		body := r.blocks(item.Content, "")
+		if item.Type != "listItem" {
+			body = r.block(item, "")
+		}
+		itemLines := strings.Split(body, "\n")
+		continuation := indent + strings.Repeat(" ", len(marker))
+		for i, line := range itemLines {
+			switch {
+			case i == 0:
+				lines = append(lines, indent+marker+line)
+			case line == "":
+				lines = append(lines, "")
+			default:
+				lines = append(lines, continuation+line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// table renders a Markdown table (the first row is the header), or tab-separated rows in text mode
+func (r *adfRenderer) table(node *adfNode, indent string) string {
+	var lines []string
+	for rowIndex, row := range node.Content {
+		var cells []string
+		for _, cell := range row.Content {
+			text := strings.ReplaceAll(r.blocks(cell.Content, ""), "\n\n", " ")
+			text = strings.ReplaceAll(text, "\n", " ")
+			if r.markdown {
+				text = strings.ReplaceAll(text, "|", `\|`)
+			}
+			cells = append(cells, text)
+		}
+		if !r.markdown {
+			lines = append(lines, indent+strings.Join(cells, "\t"))
+			continue
+		}
+		lines = append(lines, indent+"| "+strings.Join(cells, " | ")+" |")
+		if rowIndex == 0 {
+			separators := make([]string, len(cells))
+			for i := range separators {
+				separators[i] = "---"
+			}
+			lines = append(lines, indent+"| "+strings.Join(separators, " | ")+" |")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inline renders inline nodes with their marks
+func (r *adfRenderer) inline(nodes []*adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(r.marked(node.Text, node.Marks))
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			text := attrString(node.Attrs, "text")
+			if text == "" {
+				text = attrString(node.Attrs, "id")
+			}
+			if !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			b.WriteString(text)
+		case "emoji":
+			if text := attrString(node.Attrs, "text"); text != "" {
+				b.WriteString(text)
+			} else {
+				b.WriteString(attrString(node.Attrs, "shortName"))
+			}
+		case "inlineCard":
+			b.WriteString(attrString(node.Attrs, "url"))
+		case "status":
+			b.WriteString("[" + attrString(node.Attrs, "text") + "]")
+		case "date":
+			b.WriteString(attrString(node.Attrs, "timestamp"))
+		case "media":
+			name := attrString(node.Attrs, "alt")
+			if name == "" {
+				name = attrString(node.Attrs, "id")
+			}
+			b.WriteString("[attachment: " + name + "]")
+		default:
+			b.WriteString(r.preserved(node, false))
+		}
+	}
+	return b.String()
+}
+
+// marked applies text marks; plain text mode keeps link targets after the text
+func (r *adfRenderer) marked(text string, marks []adfMark) string {
+	for _, mark := range marks {
+		href := attrString(mark.Attrs, "href")
+		if !r.markdown {
+			if mark.Type == "link" && href != "" && href != text {
+				text = fmt.Sprintf("%s (%s)", text, href)
+			}
+			continue
+		}
+		switch mark.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "code":
+			text = "`" + text + "`"
+		case "link":
+			if href != "" {
+				text = fmt.Sprintf("[%s](%s)", text, href)
+			}
+		}
+	}
+	return text
+}
+
+// plainText concatenates the text of nodes without marks
+func (r *adfRenderer) plainText(nodes []*adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		if node.Type == "hardBreak" {
+			b.WriteString("\n")
+		}
+		b.WriteString(node.Text)
+	}
+	return b.String()
+}
+
+// preserved keeps an unknown node as its original JSON
+func (r *adfRenderer) preserved(node *adfNode, block bool) string {
+	raw := string(node.Raw)
+	if !r.markdown {
+		return raw
+	}
+	if block {
+		return "```adf\n" + raw + "\n```"
+	}
+	return "`" + raw + "`"
+}
+
+// prefixLines prefixes every non-empty line of text
+func prefixLines(text, prefix string) string {
+	if prefix == "" || text == "" {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" || strings.TrimSpace(prefix) != "" {
+			lines[i] = strings.TrimRight(prefix+line, " ")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func attrString(attrs map[string]interface{}, name string) string {
+	if value, ok := attrs[name]; ok && value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+func attrInt(attrs map[string]interface{}, name string) int {
+	if value, ok := attrs[name].(float64); ok {
+		return int(value)
+	}
+	return 0
+}
+
+func clampInt(value, low, high int) int {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
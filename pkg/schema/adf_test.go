@@ -0,0 +1,162 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// adfSample is a representative JIRA Cloud description
+const adfSample = `{
+  "version": 1,
+  "type": "doc",
+  "content": [
+    {"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Steps"}]},
+    {"type": "paragraph", "content": [
+      {"type": "text", "text": "Log in as "},
+      {"type": "mention", "attrs": {"id": "5b10a2844c20165700ede21g", "text": "@Jane Doe"}},
+      {"type": "text", "text": " and open "},
+      {"type": "text", "text": "settings", "marks": [{"type": "strong"}]},
+      {"type": "text", "text": ". See "},
+      {"type": "text", "text": "the docs", "marks": [{"type": "link", "attrs": {"href": "https://example.com/docs"}}]},
+      {"type": "hardBreak"},
+      {"type": "text", "text": "Then run "},
+      {"type": "text", "text": "make test", "marks": [{"type": "code"}]}
+    ]},
+    {"type": "orderedList", "content": [
+      {"type": "listItem", "content": [
+        {"type": "paragraph", "content": [{"type": "text", "text": "First"}]},
+        {"type": "bulletList", "content": [
+          {"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Nested"}]}]}
+        ]}
+      ]},
+      {"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Second"}]}]}
+    ]},
+    {"type": "codeBlock", "attrs": {"language": "go"}, "content": [{"type": "text", "text": "fmt.Println(1)"}]},
+    {"type": "blockquote", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Quoted"}]}]},
+    {"type": "table", "content": [
+      {"type": "tableRow", "content": [
+        {"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Env"}]}]},
+        {"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Result"}]}]}
+      ]},
+      {"type": "tableRow", "content": [
+        {"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "prod"}]}]},
+        {"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "fails"}]}]}
+      ]}
+    ]},
+    {"type": "extension", "attrs": {"extensionKey": "roadmap"}}
+  ]
+}`
+
+func TestRenderADF_Markdown(t *testing.T) {
+	got := RenderADF(adfSample, ADFRenderMarkdown)
+
+	want := strings.Join([]string{
+		"## Steps",
+		"",
+		"Log in as @Jane Doe and open **settings**. See [the docs](https://example.com/docs)",
+		"Then run `make test`",
+		"",
+		"1. First",
+		"",
+		"   - Nested",
+		"2. Second",
+		"",
+		"```go",
+		"fmt.Println(1)",
+		"```",
+		"",
+		"> Quoted",
+		"",
+		"| Env | Result |",
+		"| --- | --- |",
+		"| prod | fails |",
+		"",
+		"```adf",
+		`{"type": "extension", "attrs": {"extensionKey": "roadmap"}}`,
+		"```",
+	}, "\n")
+	if got != want {
+		t.Errorf("RenderADF(markdown) =\n%s\n\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderADF_Text(t *testing.T) {
+	got := RenderADF(adfSample, ADFRenderText)
+
+	for _, expected := range []string{
+		"Steps\n\nLog in as @Jane Doe and open settings. See the docs (https://example.com/docs)\nThen run make test",
+		"1. First",
+		"    fmt.Println(1)",
+		"  Quoted",
+		"Env\tResult\nprod\tfails",
+		`{"type": "extension", "attrs": {"extensionKey": "roadmap"}}`,
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("RenderADF(text) missing %q in:\n%s", expected, got)
+		}
+	}
+	for _, markup := range []string{"**", "##", "```", "[the docs]"} {
+		if strings.Contains(got, markup) {
+			t.Errorf("RenderADF(text) contains Markdown %q:\n%s", markup, got)
+		}
+	}
+}
+
+func TestRenderADF_Passthrough(t *testing.T) {
+	for _, text := range []string{
+		"Plain wiki *markup* description",
+		`{"type": "not-a-doc"}`,
+		`{"type": "doc", broken`,
+		"",
+	} {
+		if got := RenderADF(text, ADFRenderMarkdown); got != text {
+			t.Errorf("RenderADF(%q) = %q, want unchanged", text, got)
+		}
+	}
+	if got := RenderADF(adfSample, ADFRenderRaw); got != adfSample {
+		t.Error("Expected raw mode to keep the ADF JSON")
+	}
+}
+
+func TestParseADFRenderMode(t *testing.T) {
+	if mode, err := ParseADFRenderMode(""); err != nil || mode != ADFRenderMarkdown {
+		t.Errorf("Expected markdown default, got %q (err %v)", mode, err)
+	}
+	if mode, err := ParseADFRenderMode("TEXT"); err != nil || mode != ADFRenderText {
+		t.Errorf("Expected text, got %q (err %v)", mode, err)
+	}
+	if _, err := ParseADFRenderMode("html"); err == nil {
+		t.Error("Expected error for unsupported mode")
+	}
+}
+
+func TestYAMLFileWriter_ADFDescription(t *testing.T) {
+	issue := &client.Issue{Key: "PROJ-1", Summary: "ADF", Description: adfSample}
+
+	data, err := NewYAMLFileWriter().(*YAMLFileWriter).RenderIssue(issue)
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	var rendered client.Issue
+	if err := yaml.Unmarshal(data, &rendered); err != nil {
+		t.Fatalf("Failed to parse rendered YAML: %v", err)
+	}
+	if !strings.HasPrefix(rendered.Description, "## Steps\n") {
+		t.Errorf("Expected Markdown description by default, got:\n%s", rendered.Description)
+	}
+	if issue.Description != adfSample {
+		t.Error("Rendering must not modify the issue")
+	}
+
+	raw := &YAMLFileWriter{ADFRender: ADFRenderRaw}
+	data, err = raw.RenderIssue(issue)
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	if err := yaml.Unmarshal(data, &rendered); err != nil || rendered.Description != adfSample {
+		t.Errorf("Expected raw ADF description, got:\n%s", rendered.Description)
+	}
+}
@@ -0,0 +1,15 @@
+package schema
+
+import "github.com/chambrid/jira-cdc-git/pkg/client"
+
+// AttachCodeRefs returns a copy of the issue listing the commits that reference it in index
+// The issue is returned unchanged when no commit references it, and is never modified.
+func AttachCodeRefs(issue *client.Issue, index map[string][]client.CodeRef) *client.Issue {
+	refs := index[issue.Key]
+	if len(refs) == 0 {
+		return issue
+	}
+	attached := *issue
+	attached.CodeRefs = refs
+	return &attached
+}
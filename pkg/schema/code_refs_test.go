@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestYAMLFileWriter_RenderIssue_CodeRefs(t *testing.T) {
+	writer := &YAMLFileWriter{CodeRefs: map[string][]client.CodeRef{
+		"PROJ-1": {{SHA: "0123456789abcdef0123456789abcdef01234567", Subject: "PROJ-1 fix parser", Date: "2024-03-15T08:00:00Z"}},
+	}}
+
+	issue := &client.Issue{Key: "PROJ-1", Summary: "Parser bug"}
+	data, err := writer.RenderIssue(issue)
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	for _, want := range []string{"codeRefs:", "sha: 0123456789abcdef0123456789abcdef01234567", "subject: PROJ-1 fix parser"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %s, got:\n%s", want, data)
+		}
+	}
+	if issue.CodeRefs != nil {
+		t.Error("Attaching code refs must not modify the issue")
+	}
+
+	data, err = writer.RenderIssue(&client.Issue{Key: "PROJ-2"})
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	if strings.Contains(string(data), "codeRefs") {
+		t.Errorf("Expected no codeRefs for an unreferenced issue, got:\n%s", data)
+	}
+}
@@ -0,0 +1,287 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// sprintCustomType is the plugin type of the JIRA Software sprint field
+const sprintCustomType = "com.pyxis.greenhopper.jira:gh-sprint"
+
+// Timestamp layouts of JIRA date fields and of the issue files
+const (
+	jiraDateTimeLayout = "2006-01-02T15:04:05.000-0700"
+	jiraDateLayout     = "2006-01-02"
+	fileDateTimeLayout = "2006-01-02T15:04:05.000Z"
+)
+
+// legacySprintName extracts the name from the string form JIRA Server uses for sprints
+// (com.atlassian.greenhopper.service.sprint.Sprint@1f[id=12,rapidViewId=3,state=ACTIVE,name=Sprint 4,...])
+var legacySprintName = regexp.MustCompile(`[\[,]name=([^,\]]*)`)
+
+// CascadingValue is a cascading select value: a parent option and its optional child option
+type CascadingValue struct {
+	Value string `json:"value" yaml:"value"`
+	Child string `json:"child,omitempty" yaml:"child,omitempty"`
+}
+
+// Flatten strategy values and keys (see FlattenStrategy)
+const (
+	// FlattenRaw keeps values of a field type as the objects JIRA returned
+	FlattenRaw = "raw"
+	// FlattenAuto reduces an object to its value, name, displayName, or key, whichever is set first
+	FlattenAuto = "auto"
+	// FlattenOther is the strategy key of field types without built-in flattening and of fields
+	// without metadata, which are written raw unless it is set
+	FlattenOther = "other"
+	// flattenSprint is the strategy key of the sprint field
+	flattenSprint = "sprint"
+)
+
+// flattenTypes are the field types a FlattenStrategy can configure
+var flattenTypes = []string{
+	"component", "group", "issuetype", "option", "option-with-child", "priority", "project",
+	"resolution", "sd-customerrequesttype", flattenSprint, "status", "user", "version", FlattenOther,
+}
+
+// flattenProperty matches the JSON property names a field type can be flattened to
+var flattenProperty = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// FlattenStrategy overrides how custom field objects are reduced to a scalar, by field type
+// Each entry names the object property to write (e.g. user: accountId), FlattenAuto, or
+// FlattenRaw to keep the object. Types without an entry use the built-in flattening.
+type FlattenStrategy map[string]string
+
+// ParseFlattenStrategy parses comma-separated type=property pairs (e.g. "user=accountId,other=auto")
+// An empty spec uses the built-in flattening for every type.
+func ParseFlattenStrategy(spec string) (FlattenStrategy, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	strategy := make(FlattenStrategy)
+	for _, pair := range strings.Split(spec, ",") {
+		fieldType, property, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		fieldType, property = strings.TrimSpace(fieldType), strings.TrimSpace(property)
+		if !ok || fieldType == "" || property == "" {
+			return nil, fmt.Errorf("invalid flatten strategy %q (expected type=property)", pair)
+		}
+		if !slices.Contains(flattenTypes, fieldType) {
+			return nil, fmt.Errorf("unknown field type %q (expected one of %s)", fieldType, strings.Join(flattenTypes, ", "))
+		}
+		if !flattenProperty.MatchString(property) {
+			return nil, fmt.Errorf("invalid property %q for field type %s", property, fieldType)
+		}
+		strategy[fieldType] = property
+	}
+	return strategy, nil
+}
+
+// RenderCustomFields renders raw custom field values by their field schemas
+// Fields without a schema, and values that do not have the shape their schema promises,
+// are kept raw.
+func RenderCustomFields(values map[string]interface{}, schemas map[string]client.FieldSchema) map[string]interface{} {
+	return RenderCustomFieldsWithStrategy(values, schemas, nil)
+}
+
+// RenderCustomFieldsWithStrategy renders raw custom field values by their field schemas, with
+// the flattening of field types overridden by strategy (nil: built-in flattening)
+// Fields without a schema are flattened by the strategy's FlattenOther entry, if any.
+func RenderCustomFieldsWithStrategy(values map[string]interface{}, schemas map[string]client.FieldSchema, strategy FlattenStrategy) map[string]interface{} {
+	if len(values) == 0 {
+		return values
+	}
+	rendered := make(map[string]interface{}, len(values))
+	for id, value := range values {
+		fieldSchema, known := schemas[id]
+		if !known {
+			rendered[id] = strategy.flattenOther(value)
+			continue
+		}
+		rendered[id] = strategy.render(fieldSchema, value)
+	}
+	return rendered
+}
+
+// RenderCustomFieldValue renders one raw custom field value by its schema type:
+// options become their value, users their display name, named objects (versions,
+// components, priorities, sprints) their name, dates ISO dates, and arrays lists of
+// rendered items. Unknown types fall back to the raw value.
+func RenderCustomFieldValue(fieldSchema client.FieldSchema, value interface{}) interface{} {
+	return FlattenStrategy(nil).render(fieldSchema, value)
+}
+
+// render renders a custom field value like RenderCustomFieldValue, applying the strategy's
+// overrides; values left raw are flattened by its FlattenOther entry
+func (s FlattenStrategy) render(fieldSchema client.FieldSchema, value interface{}) interface{} {
+	if fieldSchema.Custom == sprintCustomType {
+		if property, ok := s[flattenSprint]; ok {
+			return renderList(value, func(v interface{}) interface{} { return flattenObject(v, property) })
+		}
+		return renderList(value, renderSprint)
+	}
+	if fieldSchema.Type == "array" {
+		item := client.FieldSchema{Type: fieldSchema.Items}
+		return renderList(value, func(v interface{}) interface{} { return s.render(item, v) })
+	}
+	if property, ok := s[fieldSchema.Type]; ok {
+		return flattenObject(value, property)
+	}
+
+	var rendered interface{}
+	switch fieldSchema.Type {
+	case "option":
+		rendered = objectString(value, "value")
+	case "option-with-child":
+		rendered = renderCascading(value)
+	case "user":
+		rendered = objectString(value, "displayName", "name", "emailAddress", "accountId")
+	case "group", "version", "component", "priority", "status", "resolution", "issuetype", "sd-customerrequesttype":
+		rendered = objectString(value, "name", "value")
+	case "project":
+		rendered = objectString(value, "key", "name")
+	case "date":
+		rendered = renderTime(value, jiraDateLayout, jiraDateLayout)
+	case "datetime":
+		rendered = renderTime(value, jiraDateTimeLayout, fileDateTimeLayout)
+	case "number":
+		rendered = renderNumber(value)
+	case "string":
+		if text, ok := value.(string); ok {
+			rendered = text
+		}
+	}
+	if rendered == nil {
+		return s.flattenOther(value)
+	}
+	return rendered
+}
+
+// flattenOther flattens an object (or the objects of a list) by the FlattenOther entry
+func (s FlattenStrategy) flattenOther(value interface{}) interface{} {
+	property, ok := s[FlattenOther]
+	if !ok {
+		return value
+	}
+	return renderList(flattenObject(value, property), func(v interface{}) interface{} { return flattenObject(v, property) })
+}
+
+// flattenObject reduces an object to one of its properties, keeping values without it raw
+func flattenObject(value interface{}, property string) interface{} {
+	var flattened interface{}
+	switch property {
+	case FlattenRaw:
+		return value
+	case FlattenAuto:
+		flattened = objectString(value, "value", "name", "displayName", "key")
+	default:
+		flattened = objectScalar(value, property)
+	}
+	if flattened == nil {
+		return value
+	}
+	return flattened
+}
+
+// objectScalar returns a scalar (string, number, or boolean) property of an object value, or nil
+func objectScalar(value interface{}, key string) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	switch property := object[key].(type) {
+	case string:
+		if property != "" {
+			return property
+		}
+	case float64:
+		return renderNumber(property)
+	case bool:
+		return property
+	}
+	return nil
+}
+
+// renderList renders each element of an array value, keeping non-arrays raw
+func renderList(value interface{}, render func(interface{}) interface{}) interface{} {
+	items, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	rendered := make([]interface{}, len(items))
+	for i, item := range items {
+		rendered[i] = render(item)
+	}
+	return rendered
+}
+
+// objectString returns the first non-empty string property of an object value, or nil
+func objectString(value interface{}, keys ...string) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for _, key := range keys {
+		if text, ok := object[key].(string); ok && text != "" {
+			return text
+		}
+	}
+	return nil
+}
+
+// renderCascading renders a cascading select as its parent and child option values
+func renderCascading(value interface{}) interface{} {
+	parent, ok := objectString(value, "value").(string)
+	if !ok {
+		return nil
+	}
+	cascading := CascadingValue{Value: parent}
+	if child, ok := value.(map[string]interface{})["child"]; ok {
+		cascading.Child, _ = objectString(child, "value").(string)
+	}
+	return cascading
+}
+
+// renderSprint renders a sprint as its name, from either the JIRA Cloud object or the
+// JIRA Server string form
+func renderSprint(value interface{}) interface{} {
+	if name := objectString(value, "name"); name != nil {
+		return name
+	}
+	if text, ok := value.(string); ok {
+		if match := legacySprintName.FindStringSubmatch(text); match != nil {
+			return match[1]
+		}
+	}
+	return value
+}
+
+// renderTime reformats a JIRA timestamp, or returns nil when it does not parse
+func renderTime(value interface{}, layout, format string) interface{} {
+	text, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	parsed, err := time.Parse(layout, text)
+	if err != nil {
+		return nil
+	}
+	return parsed.UTC().Format(format)
+}
+
+// renderNumber writes whole numbers without a fractional part (JSON decodes them as float64)
+func renderNumber(value interface{}) interface{} {
+	number, ok := value.(float64)
+	if !ok {
+		return nil
+	}
+	if number == math.Trunc(number) && math.Abs(number) < 1<<53 {
+		return int64(number)
+	}
+	return number
+}
@@ -0,0 +1,218 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestRenderCustomFieldValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema client.FieldSchema
+		value  interface{}
+		want   interface{}
+	}{
+		{
+			name:   "multi-select",
+			schema: client.FieldSchema{Type: "array", Items: "option"},
+			value: []interface{}{
+				map[string]interface{}{"self": "https://jira/option/1", "value": "Backend", "id": "1"},
+				map[string]interface{}{"self": "https://jira/option/2", "value": "Frontend", "id": "2"},
+			},
+			want: []interface{}{"Backend", "Frontend"},
+		},
+		{
+			name:   "single select",
+			schema: client.FieldSchema{Type: "option"},
+			value:  map[string]interface{}{"value": "Yes", "id": "10"},
+			want:   "Yes",
+		},
+		{
+			name:   "cascading select",
+			schema: client.FieldSchema{Type: "option-with-child"},
+			value: map[string]interface{}{
+				"value": "EMEA",
+				"child": map[string]interface{}{"value": "Germany"},
+			},
+			want: CascadingValue{Value: "EMEA", Child: "Germany"},
+		},
+		{
+			name:   "user picker",
+			schema: client.FieldSchema{Type: "user"},
+			value:  map[string]interface{}{"accountId": "5b10a2", "displayName": "Jane Doe", "emailAddress": "jane@example.com"},
+			want:   "Jane Doe",
+		},
+		{
+			name:   "multi user picker",
+			schema: client.FieldSchema{Type: "array", Items: "user"},
+			value:  []interface{}{map[string]interface{}{"name": "jdoe"}},
+			want:   []interface{}{"jdoe"},
+		},
+		{
+			name:   "versions",
+			schema: client.FieldSchema{Type: "array", Items: "version"},
+			value:  []interface{}{map[string]interface{}{"name": "1.2.0", "released": true}},
+			want:   []interface{}{"1.2.0"},
+		},
+		{
+			name:   "cloud sprint",
+			schema: client.FieldSchema{Type: "array", Items: "json", Custom: sprintCustomType},
+			value:  []interface{}{map[string]interface{}{"id": float64(12), "name": "Sprint 4", "state": "active"}},
+			want:   []interface{}{"Sprint 4"},
+		},
+		{
+			name:   "server sprint",
+			schema: client.FieldSchema{Type: "array", Items: "string", Custom: sprintCustomType},
+			value:  []interface{}{"com.atlassian.greenhopper.service.sprint.Sprint@1f[id=12,rapidViewId=3,state=ACTIVE,name=Sprint 4,startDate=2024-01-01T00:00:00.000Z]"},
+			want:   []interface{}{"Sprint 4"},
+		},
+		{
+			name:   "date",
+			schema: client.FieldSchema{Type: "date"},
+			value:  "2024-03-15",
+			want:   "2024-03-15",
+		},
+		{
+			name:   "datetime",
+			schema: client.FieldSchema{Type: "datetime"},
+			value:  "2024-03-15T09:30:00.000+0200",
+			want:   "2024-03-15T07:30:00.000Z",
+		},
+		{
+			name:   "whole number",
+			schema: client.FieldSchema{Type: "number"},
+			value:  float64(8),
+			want:   int64(8),
+		},
+		{
+			name:   "fractional number",
+			schema: client.FieldSchema{Type: "number"},
+			value:  2.5,
+			want:   2.5,
+		},
+		{
+			name:   "labels",
+			schema: client.FieldSchema{Type: "array", Items: "string"},
+			value:  []interface{}{"a", "b"},
+			want:   []interface{}{"a", "b"},
+		},
+		{
+			name:   "unknown type stays raw",
+			schema: client.FieldSchema{Type: "sd-servicelevelagreement"},
+			value:  map[string]interface{}{"id": "1"},
+			want:   map[string]interface{}{"id": "1"},
+		},
+		{
+			name:   "unexpected shape stays raw",
+			schema: client.FieldSchema{Type: "option"},
+			value:  "plain",
+			want:   "plain",
+		},
+		{
+			name:   "unparseable date stays raw",
+			schema: client.FieldSchema{Type: "date"},
+			value:  "15/03/2024",
+			want:   "15/03/2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderCustomFieldValue(tt.schema, tt.value)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RenderCustomFieldValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLFileWriter_RenderIssue_CustomFields(t *testing.T) {
+	issue := &client.Issue{
+		Key:     "PROJ-1",
+		Summary: "Typed custom fields",
+		CustomFields: map[string]interface{}{
+			"customfield_10030": []interface{}{map[string]interface{}{"value": "Backend", "id": "1"}},
+			"customfield_10099": map[string]interface{}{"id": "7"},
+		},
+	}
+	schemas := map[string]client.FieldSchema{
+		"customfield_10030": {ID: "customfield_10030", Name: "Teams", Type: "array", Items: "option"},
+	}
+
+	raw, err := (&YAMLFileWriter{}).RenderIssue(issue)
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	if !strings.Contains(string(raw), "value: Backend") {
+		t.Errorf("Expected raw values without field schemas, got:\n%s", raw)
+	}
+
+	typed, err := (&YAMLFileWriter{FieldSchemas: schemas}).RenderIssue(issue)
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	want := "custom_fields:\n    customfield_10030:\n        - Backend\n    customfield_10099:\n        id: \"7\"\n"
+	if !strings.Contains(string(typed), want) {
+		t.Errorf("Expected typed custom fields, got:\n%s", typed)
+	}
+	if _, ok := issue.CustomFields["customfield_10030"].([]interface{})[0].(map[string]interface{}); !ok {
+		t.Error("Rendering must not modify the issue")
+	}
+}
+
+func TestParseFlattenStrategy(t *testing.T) {
+	strategy, err := ParseFlattenStrategy(" user=accountId , option=id,other=auto")
+	if err != nil {
+		t.Fatalf("ParseFlattenStrategy() error = %v", err)
+	}
+	want := FlattenStrategy{"user": "accountId", "option": "id", FlattenOther: FlattenAuto}
+	if !reflect.DeepEqual(strategy, want) {
+		t.Errorf("ParseFlattenStrategy() = %v, want %v", strategy, want)
+	}
+
+	if strategy, err := ParseFlattenStrategy(""); err != nil || strategy != nil {
+		t.Errorf("Expected no strategy for an empty spec, got %v, %v", strategy, err)
+	}
+	for _, spec := range []string{"user", "widget=id", "user=", "user=account id"} {
+		if _, err := ParseFlattenStrategy(spec); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestRenderCustomFieldsWithStrategy(t *testing.T) {
+	values := map[string]interface{}{
+		"customfield_1": map[string]interface{}{"displayName": "Jane Doe", "accountId": "abc123"},
+		"customfield_2": []interface{}{map[string]interface{}{"value": "Backend", "id": "1"}},
+		"customfield_3": map[string]interface{}{"value": "Yes", "id": "10"},
+		"customfield_4": map[string]interface{}{"name": "Unknown", "id": "7"},
+	}
+	schemas := map[string]client.FieldSchema{
+		"customfield_1": {Type: "user"},
+		"customfield_2": {Type: "array", Items: "option"},
+		"customfield_3": {Type: "option"},
+	}
+	strategy := FlattenStrategy{"user": "accountId", "option": "id", FlattenOther: FlattenAuto}
+
+	got := RenderCustomFieldsWithStrategy(values, schemas, strategy)
+	want := map[string]interface{}{
+		"customfield_1": "abc123",
+		"customfield_2": []interface{}{"1"},
+		"customfield_3": "10",
+		"customfield_4": "Unknown",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RenderCustomFieldsWithStrategy() = %#v, want %#v", got, want)
+	}
+
+	raw := RenderCustomFieldsWithStrategy(values, schemas, FlattenStrategy{"user": FlattenRaw})
+	if !reflect.DeepEqual(raw["customfield_1"], values["customfield_1"]) {
+		t.Errorf("Expected the raw user object, got %#v", raw["customfield_1"])
+	}
+	if raw["customfield_3"] != "Yes" || !reflect.DeepEqual(raw["customfield_4"], values["customfield_4"]) {
+		t.Errorf("Expected built-in flattening for types without an override, got %#v", raw)
+	}
+}
@@ -37,6 +37,14 @@ func IsFileError(err error) bool {
 	return false
 }
 
+// IsTransformError checks if the error came from an issue transform or its output validation
+func IsTransformError(err error) bool {
+	if schemaErr, ok := err.(*SchemaError); ok {
+		return schemaErr.Type == "transform_error"
+	}
+	return false
+}
+
 // IsInvalidInputError checks if the error is related to invalid input
 func IsInvalidInputError(err error) bool {
 	if schemaErr, ok := err.(*SchemaError); ok {
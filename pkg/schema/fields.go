@@ -0,0 +1,207 @@
+package schema
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// regexFieldPrefix marks a field pattern as a regular expression instead of a glob
+const regexFieldPrefix = "re:"
+
+// alwaysKeptFields can never be filtered out; the issue key identifies the file and state entry
+var alwaysKeptFields = map[string]bool{"key": true}
+
+// FieldFilter selects which YAML fields are written for an issue
+// Fields are addressed by dotted path as they appear in the YAML output
+// (e.g. "description", "assignee.email", "relationships.issue_links").
+//
+// Patterns are globs unless prefixed with "re:", in which case they are regular
+// expressions matched against the full dotted path. A glob without a dot matches a
+// field name at any depth ("customfield_*"); a glob with dots matches the full path.
+// When include patterns are given only matching fields (and their children) are kept;
+// exclude patterns always win over include patterns.
+type FieldFilter struct {
+	include []fieldPattern
+	exclude []fieldPattern
+}
+
+// fieldPattern is a compiled include or exclude pattern
+type fieldPattern struct {
+	glob  string
+	regex *regexp.Regexp
+}
+
+// NewFieldFilter compiles include and exclude field patterns
+// Returns nil when both lists are empty, meaning every field is written.
+func NewFieldFilter(include, exclude []string) (*FieldFilter, error) {
+	filter := &FieldFilter{}
+	var err error
+	if filter.include, err = compileFieldPatterns(include); err != nil {
+		return nil, err
+	}
+	if filter.exclude, err = compileFieldPatterns(exclude); err != nil {
+		return nil, err
+	}
+	if len(filter.include) == 0 && len(filter.exclude) == 0 {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// ParseFieldPatterns splits comma-separated pattern lists, dropping empty entries
+// Values starting with "re:" are kept whole so regular expressions may contain commas.
+func ParseFieldPatterns(values ...string) []string {
+	var patterns []string
+	for _, value := range values {
+		if strings.HasPrefix(strings.TrimSpace(value), regexFieldPrefix) {
+			patterns = append(patterns, strings.TrimSpace(value))
+			continue
+		}
+		for _, pattern := range strings.Split(value, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+	return patterns
+}
+
+// compileFieldPatterns validates and compiles patterns
+func compileFieldPatterns(patterns []string) ([]fieldPattern, error) {
+	var compiled []fieldPattern
+	for _, raw := range patterns {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		var pattern fieldPattern
+		if expr, ok := strings.CutPrefix(raw, regexFieldPrefix); ok {
+			regex, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, &SchemaError{
+					Type:    "invalid_input",
+					Message: fmt.Sprintf("invalid field pattern %q", raw),
+					Err:     err,
+				}
+			}
+			pattern.regex = regex
+		} else {
+			if _, err := path.Match(raw, ""); err != nil {
+				return nil, &SchemaError{
+					Type:    "invalid_input",
+					Message: fmt.Sprintf("invalid field pattern %q", raw),
+					Err:     err,
+				}
+			}
+			pattern.glob = raw
+		}
+		compiled = append(compiled, pattern)
+	}
+	return compiled, nil
+}
+
+// matches reports whether the pattern selects the field at fieldPath
+func (p fieldPattern) matches(fieldPath, name string) bool {
+	if p.regex != nil {
+		return p.regex.MatchString(fieldPath)
+	}
+	target := fieldPath
+	if !strings.Contains(p.glob, ".") {
+		target = name
+	}
+	matched, _ := path.Match(p.glob, target)
+	return matched
+}
+
+// Allows reports whether a single field path would be written
+// Parent fields are not considered; use Apply to filter a whole document.
+func (f *FieldFilter) Allows(fieldPath string) bool {
+	if f == nil || alwaysKeptFields[fieldPath] {
+		return true
+	}
+	name := fieldPath[strings.LastIndex(fieldPath, ".")+1:]
+	if anyFieldPatternMatches(f.exclude, fieldPath, name) {
+		return false
+	}
+	return len(f.include) == 0 || anyFieldPatternMatches(f.include, fieldPath, name)
+}
+
+// Apply removes filtered fields from a YAML document or mapping node in place
+func (f *FieldFilter) Apply(node *yaml.Node) {
+	if f == nil || node == nil {
+		return
+	}
+	if node.Kind == yaml.DocumentNode {
+		for _, child := range node.Content {
+			f.Apply(child)
+		}
+		return
+	}
+	f.filterMapping(node, "", len(f.include) > 0)
+}
+
+// filterMapping prunes a mapping node; restrict is true while no ancestor matched an include pattern
+// Returns whether any field remains.
+func (f *FieldFilter) filterMapping(node *yaml.Node, prefix string, restrict bool) bool {
+	if node.Kind != yaml.MappingNode {
+		return true
+	}
+
+	kept := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		name := keyNode.Value
+		fieldPath := name
+		if prefix != "" {
+			fieldPath = prefix + "." + name
+		}
+
+		if f.keepField(valueNode, fieldPath, name, restrict) {
+			kept = append(kept, keyNode, valueNode)
+		}
+	}
+	node.Content = kept
+	return len(kept) > 0
+}
+
+// keepField decides whether one field stays, filtering its children as needed
+func (f *FieldFilter) keepField(value *yaml.Node, fieldPath, name string, restrict bool) bool {
+	if alwaysKeptFields[fieldPath] {
+		return true
+	}
+	if anyFieldPatternMatches(f.exclude, fieldPath, name) {
+		return false
+	}
+
+	if restrict && anyFieldPatternMatches(f.include, fieldPath, name) {
+		restrict = false
+	}
+
+	switch value.Kind {
+	case yaml.MappingNode:
+		// Keep a restricted parent only when an included child survives
+		return f.filterMapping(value, fieldPath, restrict) || !restrict
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			f.filterMapping(item, fieldPath, false)
+		}
+		return !restrict
+	default:
+		return !restrict
+	}
+}
+
+// anyFieldPatternMatches reports whether any pattern selects the field
+func anyFieldPatternMatches(patterns []fieldPattern, fieldPath, name string) bool {
+	for _, pattern := range patterns {
+		if pattern.matches(fieldPath, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package schema
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+func filteredYAML(t *testing.T, include, exclude []string, issue *client.Issue) string {
+	t.Helper()
+
+	filter, err := NewFieldFilter(include, exclude)
+	if err != nil {
+		t.Fatalf("NewFieldFilter() error = %v", err)
+	}
+	writer := &YAMLFileWriter{Fields: filter}
+	data, err := writer.marshal(issue)
+	if err != nil {
+		t.Fatalf("marshal() error = %v", err)
+	}
+	return string(data)
+}
+
+func fieldFilterTestIssue() *client.Issue {
+	return &client.Issue{
+		Key:         "PROJ-1",
+		Summary:     "Filter me",
+		Description: "Long description",
+		Status:      client.Status{Name: "Open", Category: "new"},
+		Assignee:    client.User{Name: "Jane", Email: "jane@example.com"},
+		Reporter:    client.User{Name: "John", Email: "john@example.com"},
+		Priority:    "High",
+		IssueType:   "Story",
+		Relationships: &client.Relationships{
+			EpicLink: "PROJ-100",
+			IssueLinks: []client.IssueLink{
+				{Type: "Blocks", Direction: "outward", IssueKey: "PROJ-2", Summary: "Other"},
+			},
+		},
+	}
+}
+
+func TestNewFieldFilter_Empty(t *testing.T) {
+	filter, err := NewFieldFilter(nil, []string{" ", ""})
+	if err != nil {
+		t.Fatalf("NewFieldFilter() error = %v", err)
+	}
+	if filter != nil {
+		t.Error("Expected nil filter when no patterns are given")
+	}
+	if !filter.Allows("description") {
+		t.Error("Nil filter should allow every field")
+	}
+}
+
+func TestNewFieldFilter_InvalidPatterns(t *testing.T) {
+	for _, pattern := range []string{"re:(unclosed", "[bad"} {
+		_, err := NewFieldFilter(nil, []string{pattern})
+		if err == nil {
+			t.Errorf("Expected error for pattern %q", pattern)
+			continue
+		}
+		if schemaErr, ok := err.(*SchemaError); !ok || schemaErr.Type != "invalid_input" {
+			t.Errorf("Expected invalid_input SchemaError for %q, got %v", pattern, err)
+		}
+	}
+}
+
+func TestParseFieldPatterns(t *testing.T) {
+	got := ParseFieldPatterns(" description, customfield_* ,,assignee.email", "re:^a{1,2}$")
+	want := []string{"description", "customfield_*", "assignee.email", "re:^a{1,2}$"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("ParseFieldPatterns() = %v, want %v", got, want)
+	}
+}
+
+func TestFieldFilter_Allows(t *testing.T) {
+	filter, err := NewFieldFilter(
+		[]string{"summary", "customfield_*", "relationships.*"},
+		[]string{"customfield_999", "re:^relationships\\.issue_"},
+	)
+	if err != nil {
+		t.Fatalf("NewFieldFilter() error = %v", err)
+	}
+
+	tests := map[string]bool{
+		"key":                       true, // always kept
+		"summary":                   true,
+		"description":               false, // not included
+		"customfield_100":           true,
+		"nested.customfield_100":    true,  // glob without dots matches at any depth
+		"customfield_999":           false, // exclude wins over include
+		"relationships.epic_link":   true,
+		"relationships.issue_links": false,
+	}
+	for fieldPath, want := range tests {
+		if got := filter.Allows(fieldPath); got != want {
+			t.Errorf("Allows(%q) = %v, want %v", fieldPath, got, want)
+		}
+	}
+}
+
+func TestFieldFilter_ExcludeFields(t *testing.T) {
+	output := filteredYAML(t, nil, []string{"description", "email", "relationships.issue_links"}, fieldFilterTestIssue())
+
+	for _, unwanted := range []string{"description:", "email:", "issue_links:"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected %q to be excluded, got:\n%s", unwanted, output)
+		}
+	}
+	for _, wanted := range []string{"key: PROJ-1", "summary: Filter me", "name: Jane", "epic_link: PROJ-100"} {
+		if !strings.Contains(output, wanted) {
+			t.Errorf("Expected %q to be kept, got:\n%s", wanted, output)
+		}
+	}
+}
+
+func TestFieldFilter_IncludeAndExclude(t *testing.T) {
+	output := filteredYAML(t,
+		[]string{"summary", "assignee", "relationships.issue_links"},
+		[]string{"assignee.email", "re:issue_links\\.summary$"},
+		fieldFilterTestIssue(),
+	)
+
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Filtered output is not valid YAML: %v", err)
+	}
+
+	for _, field := range []string{"key", "summary", "assignee", "relationships"} {
+		if _, ok := parsed[field]; !ok {
+			t.Errorf("Expected field %q in output:\n%s", field, output)
+		}
+	}
+	for _, field := range []string{"description", "status", "reporter", "priority"} {
+		if _, ok := parsed[field]; ok {
+			t.Errorf("Field %q should not be included:\n%s", field, output)
+		}
+	}
+	if strings.Contains(output, "jane@example.com") {
+		t.Errorf("Excluded child field was written:\n%s", output)
+	}
+	if strings.Contains(output, "epic_link") {
+		t.Errorf("Sibling of an included field was written:\n%s", output)
+	}
+	if !strings.Contains(output, "issue_key: PROJ-2") || strings.Contains(output, "summary: Other") {
+		t.Errorf("Expected issue links without summaries:\n%s", output)
+	}
+}
+
+func TestYAMLFileWriter_WriteIssueToYAML_ExcludeFieldsStable(t *testing.T) {
+	filter, err := NewFieldFilter(nil, []string{"updated"})
+	if err != nil {
+		t.Fatalf("NewFieldFilter() error = %v", err)
+	}
+	writer := NewFilteredYAMLFileWriter(filter)
+	basePath := t.TempDir()
+
+	issue := fieldFilterTestIssue()
+	issue.Updated = "2024-01-01T00:00:00Z"
+	first, err := writer.WriteIssueToYAML(issue, basePath)
+	if err != nil {
+		t.Fatalf("WriteIssueToYAML() error = %v", err)
+	}
+	firstData, _ := os.ReadFile(first)
+
+	// Changes confined to excluded fields produce identical files
+	issue.Updated = "2024-02-01T00:00:00Z"
+	second, err := writer.WriteIssueToYAML(issue, basePath)
+	if err != nil {
+		t.Fatalf("WriteIssueToYAML() error = %v", err)
+	}
+	secondData, _ := os.ReadFile(second)
+
+	if string(firstData) != string(secondData) {
+		t.Errorf("Expected identical output when only excluded fields change:\n%s\n---\n%s", firstData, secondData)
+	}
+	if strings.Contains(string(secondData), "updated:") {
+		t.Errorf("Excluded field written:\n%s", secondData)
+	}
+}
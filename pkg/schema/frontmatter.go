@@ -0,0 +1,183 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// FrontmatterFields are the issue fields Markdown frontmatter can carry
+// Users are written by display name (email when no name is known); labels, subtasks, and
+// linked issue keys are sorted so the block does not change with JIRA's ordering.
+var FrontmatterFields = []string{
+	"key", "title", "summary", "type", "status", "priority", "assignee", "reporter",
+	"labels", "created", "updated", "epic", "parent", "subtasks", "links", "url",
+}
+
+// DefaultFrontmatterFields are written when frontmatter is enabled without a field list
+var DefaultFrontmatterFields = []string{"key", "title", "status", "assignee", "labels", "created", "updated"}
+
+// ParseFrontmatterFields validates a comma-separated frontmatter field list
+// Duplicates are dropped and the order is kept, since it is the order the block is written in.
+// "default" stands for DefaultFrontmatterFields; an empty list disables frontmatter.
+func ParseFrontmatterFields(value string) ([]string, error) {
+	known := make(map[string]bool, len(FrontmatterFields))
+	for _, field := range FrontmatterFields {
+		known[field] = true
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		field := strings.ToLower(strings.TrimSpace(part))
+		if field == "" {
+			continue
+		}
+		expanded := []string{field}
+		if field == "default" {
+			expanded = DefaultFrontmatterFields
+		} else if !known[field] {
+			return nil, fmt.Errorf("unsupported frontmatter field %q (expected default or one of: %s)", part, strings.Join(FrontmatterFields, ", "))
+		}
+		for _, f := range expanded {
+			if !seen[f] {
+				seen[f] = true
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields, nil
+}
+
+// RenderFrontmatter renders the fields of an issue as a "---" delimited YAML block
+// Fields without a value are left out. Returns "" when no fields are given.
+// Unknown field names are ignored; validate lists with ParseFrontmatterFields.
+func RenderFrontmatter(issue *client.Issue, fields []string, baseURL string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	for _, field := range fields {
+		value := frontmatterValue(issue, field, baseURL)
+		if value == nil {
+			continue
+		}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field}, value)
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	if len(mapping.Content) > 0 {
+		data, err := yaml.Marshal(mapping)
+		if err == nil {
+			b.Write(data)
+		}
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+// frontmatterValue returns the YAML node of one field, or nil when the issue has no value for it
+func frontmatterValue(issue *client.Issue, field, baseURL string) *yaml.Node {
+	relationships := issue.Relationships
+	if relationships == nil {
+		relationships = &client.Relationships{}
+	}
+
+	switch field {
+	case "key":
+		return frontmatterString(issue.Key)
+	case "title":
+		title := issue.Key
+		if issue.Summary != "" {
+			title += ": " + issue.Summary
+		}
+		return frontmatterString(title)
+	case "summary":
+		return frontmatterString(issue.Summary)
+	case "type":
+		return frontmatterString(issue.IssueType)
+	case "status":
+		return frontmatterString(issue.Status.Name)
+	case "priority":
+		return frontmatterString(issue.Priority)
+	case "assignee":
+		return frontmatterString(frontmatterUser(issue.Assignee))
+	case "reporter":
+		return frontmatterString(frontmatterUser(issue.Reporter))
+	case "labels":
+		return frontmatterList(issue.Labels)
+	case "created":
+		return frontmatterString(issue.Created)
+	case "updated":
+		return frontmatterString(issue.Updated)
+	case "epic":
+		return frontmatterString(relationships.EpicLink)
+	case "parent":
+		return frontmatterString(relationships.ParentIssue)
+	case "subtasks":
+		return frontmatterList(relationships.Subtasks)
+	case "links":
+		keys := make([]string, 0, len(relationships.IssueLinks))
+		for _, link := range relationships.IssueLinks {
+			keys = append(keys, link.IssueKey)
+		}
+		return frontmatterList(keys)
+	case "url":
+		if baseURL == "" {
+			return nil
+		}
+		issueURL, err := client.BuildIssueURL(baseURL, issue.Key)
+		if err != nil {
+			return nil
+		}
+		return frontmatterString(issueURL)
+	}
+	return nil
+}
+
+// frontmatterUser returns the display name of a user, or the email when no name is known
+func frontmatterUser(user client.User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Email
+}
+
+// frontmatterString returns a string scalar node, quoted when YAML would read it as another type
+func frontmatterString(value string) *yaml.Node {
+	if value == "" {
+		return nil
+	}
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil
+	}
+	return node
+}
+
+// frontmatterList returns a sorted sequence node of the distinct non-empty values
+func frontmatterList(values []string) *yaml.Node {
+	seen := make(map[string]bool, len(values))
+	var sorted []string
+	for _, value := range values {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			sorted = append(sorted, value)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+	sort.Strings(sorted)
+
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, value := range sorted {
+		sequence.Content = append(sequence.Content, frontmatterString(value))
+	}
+	return sequence
+}
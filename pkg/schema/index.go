@@ -0,0 +1,246 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// Index formats
+const (
+	IndexFormatYAML     = "yaml"
+	IndexFormatMarkdown = "markdown"
+)
+
+// IndexEntry summarizes one synced issue in a project index
+// Updated and Priority are only listed when the index is sorted by them.
+type IndexEntry struct {
+	Key      string `yaml:"key"`
+	Summary  string `yaml:"summary"`
+	Status   string `yaml:"status,omitempty"`
+	Assignee string `yaml:"assignee,omitempty"`
+	Updated  string `yaml:"updated,omitempty"`
+	Priority string `yaml:"priority,omitempty"`
+
+	statusCategory string
+	updatedAt      time.Time
+}
+
+// ProjectIndex lists every issue file present for a project
+// It intentionally carries no generation time so regenerating an unchanged project is a no-op.
+type ProjectIndex struct {
+	Project    string       `yaml:"project"`
+	SortedBy   string       `yaml:"sorted_by,omitempty"` // empty when sorted by key
+	IssueCount int          `yaml:"issue_count"`
+	Issues     []IndexEntry `yaml:"issues"`
+}
+
+// ParseIndexFormat validates an index format name ("md" is accepted for Markdown)
+func ParseIndexFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", IndexFormatYAML, "yml":
+		return IndexFormatYAML, nil
+	case IndexFormatMarkdown, "md":
+		return IndexFormatMarkdown, nil
+	default:
+		return "", &SchemaError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("unsupported index format %q (use yaml or markdown)", format),
+		}
+	}
+}
+
+// GetIndexFilePath returns the index file path for a project
+// Pattern: /projects/{project-key}/index.yaml or /projects/{project-key}/index.md
+func GetIndexFilePath(basePath, projectKey, format string) string {
+	name := "index.yaml"
+	if format == IndexFormatMarkdown {
+		name = "index.md"
+	}
+	return filepath.Join(basePath, "projects", projectKey, name)
+}
+
+// BuildProjectIndex reads the issue files that exist for a project and lists them in sortBy order
+// The index is built from the files on disk rather than from sync results, so issues removed
+// from the repository drop out of the index on the next regeneration.
+func BuildProjectIndex(basePath, projectKey, sortBy string) (*ProjectIndex, error) {
+	sortBy, err := ParseIndexSort(sortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	issueFiles, err := filepath.Glob(filepath.Join(basePath, "projects", projectKey, "issues", "*.yaml"))
+	if err != nil {
+		return nil, &SchemaError{
+			Type:    "file_error",
+			Message: fmt.Sprintf("failed to list issue files for project %s", projectKey),
+			Err:     err,
+		}
+	}
+
+	index := &ProjectIndex{Project: projectKey, Issues: make([]IndexEntry, 0, len(issueFiles))}
+	for _, issueFile := range issueFiles {
+		data, err := os.ReadFile(issueFile)
+		if err != nil {
+			return nil, &SchemaError{
+				Type:    "file_error",
+				Message: fmt.Sprintf("failed to read issue file: %s", issueFile),
+				Err:     err,
+			}
+		}
+		issue, err := FromYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", issueFile, err)
+		}
+
+		key := issue.Key
+		if key == "" {
+			key = strings.TrimSuffix(filepath.Base(issueFile), ".yaml")
+		}
+		entry := IndexEntry{
+			Key:            key,
+			Summary:        issue.Summary,
+			Status:         issue.Status.Name,
+			Assignee:       formatIndexUser(issue.Assignee),
+			statusCategory: issue.Status.Category,
+		}
+		switch sortBy {
+		case IndexSortUpdated:
+			entry.Updated = issue.Updated
+			entry.updatedAt = parseIndexTimestamp(issue.Updated)
+		case IndexSortPriority:
+			entry.Priority = issue.Priority
+		}
+		index.Issues = append(index.Issues, entry)
+	}
+
+	sortIndexEntries(index.Issues, sortBy)
+	if sortBy != IndexSortKey {
+		index.SortedBy = sortBy
+	}
+	index.IssueCount = len(index.Issues)
+	return index, nil
+}
+
+// RenderIndex serializes an index in the given format
+func RenderIndex(index *ProjectIndex, format string) ([]byte, error) {
+	if format != IndexFormatMarkdown {
+		data, err := yaml.Marshal(index)
+		if err != nil {
+			return nil, &SchemaError{
+				Type:    "serialization_error",
+				Message: "failed to marshal project index to YAML",
+				Err:     err,
+			}
+		}
+		return data, nil
+	}
+
+	// The field an index is sorted by gets its own column when it is not already shown
+	var extraColumn string
+	switch index.SortedBy {
+	case IndexSortUpdated:
+		extraColumn = "Updated"
+	case IndexSortPriority:
+		extraColumn = "Priority"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s Issues\n\n", index.Project)
+	if index.SortedBy != "" {
+		fmt.Fprintf(&b, "%d issues, sorted by %s\n\n", index.IssueCount, index.SortedBy)
+	} else {
+		fmt.Fprintf(&b, "%d issues\n\n", index.IssueCount)
+	}
+	if extraColumn != "" {
+		fmt.Fprintf(&b, "| Key | Summary | Status | Assignee | %s |\n", extraColumn)
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+	} else {
+		b.WriteString("| Key | Summary | Status | Assignee |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+	}
+	for _, entry := range index.Issues {
+		fmt.Fprintf(&b, "| [%s](issues/%s.yaml) | %s | %s | %s |",
+			entry.Key, entry.Key,
+			escapeMarkdownCell(entry.Summary),
+			escapeMarkdownCell(entry.Status),
+			escapeMarkdownCell(entry.Assignee))
+		switch extraColumn {
+		case "Updated":
+			fmt.Fprintf(&b, " %s |", escapeMarkdownCell(entry.Updated))
+		case "Priority":
+			fmt.Fprintf(&b, " %s |", escapeMarkdownCell(entry.Priority))
+		}
+		b.WriteString("\n")
+	}
+	return b.Bytes(), nil
+}
+
+// WriteProjectIndex regenerates a project's index file
+// Returns the index path and whether its content changed; unchanged files are not rewritten.
+func WriteProjectIndex(basePath, projectKey, format, sortBy string) (string, bool, error) {
+	index, err := BuildProjectIndex(basePath, projectKey, sortBy)
+	if err != nil {
+		return "", false, err
+	}
+	data, err := RenderIndex(index, format)
+	if err != nil {
+		return "", false, err
+	}
+
+	indexPath := GetIndexFilePath(basePath, projectKey, format)
+	if existing, err := os.ReadFile(indexPath); err == nil && bytes.Equal(existing, data) {
+		return indexPath, false, nil
+	}
+
+	if err := os.WriteFile(indexPath, data, 0644); err != nil {
+		return "", false, &SchemaError{
+			Type:    "file_error",
+			Message: fmt.Sprintf("failed to write index file: %s", indexPath),
+			Err:     err,
+		}
+	}
+	return indexPath, true, nil
+}
+
+// ListProjects returns the project keys that have an issues directory, sorted
+func ListProjects(basePath string) ([]string, error) {
+	issueDirs, err := filepath.Glob(filepath.Join(basePath, "projects", "*", "issues"))
+	if err != nil {
+		return nil, &SchemaError{
+			Type:    "file_error",
+			Message: "failed to list project directories",
+			Err:     err,
+		}
+	}
+
+	var projects []string
+	for _, issueDir := range issueDirs {
+		if info, err := os.Stat(issueDir); err == nil && info.IsDir() {
+			projects = append(projects, filepath.Base(filepath.Dir(issueDir)))
+		}
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// formatIndexUser prefers the display name and falls back to the email
+func formatIndexUser(user client.User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Email
+}
+
+// escapeMarkdownCell keeps a value on one table row
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
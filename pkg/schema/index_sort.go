@@ -0,0 +1,129 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// Index sort orders
+const (
+	IndexSortKey      = "key"
+	IndexSortStatus   = "status"
+	IndexSortUpdated  = "updated"
+	IndexSortPriority = "priority"
+)
+
+// IndexSorts lists the supported index sort orders
+var IndexSorts = []string{IndexSortKey, IndexSortStatus, IndexSortUpdated, IndexSortPriority}
+
+// statusCategoryRanks orders status categories along the workflow; JIRA reports either the
+// category name or its key
+var statusCategoryRanks = map[string]int{
+	"to do": 0, "new": 0,
+	"in progress": 1, "indeterminate": 1,
+	"done": 2,
+}
+
+// priorityRanks orders the priorities of JIRA's default schemes, most urgent first
+var priorityRanks = map[string]int{
+	"blocker": 0, "highest": 0,
+	"critical": 1, "high": 1,
+	"major": 2, "medium": 2,
+	"minor": 3, "low": 3,
+	"trivial": 4, "lowest": 4,
+}
+
+// ParseIndexSort validates an index sort order; empty sorts by key
+func ParseIndexSort(value string) (string, error) {
+	sortBy := strings.ToLower(strings.TrimSpace(value))
+	if sortBy == "" {
+		return IndexSortKey, nil
+	}
+	for _, supported := range IndexSorts {
+		if sortBy == supported {
+			return sortBy, nil
+		}
+	}
+	return "", &SchemaError{
+		Type:    "invalid_input",
+		Message: fmt.Sprintf("unsupported index sort %q (use %s)", value, strings.Join(IndexSorts, ", ")),
+	}
+}
+
+// sortIndexEntries orders index entries by sortBy, breaking every tie by issue key so the
+// order only changes when the sorted values do
+//   - status: by status category (to do, in progress, done, others), then status name
+//   - updated: most recently updated first; entries without a timestamp last
+//   - priority: most urgent first for JIRA's default priorities, then other names alphabetically
+func sortIndexEntries(entries []IndexEntry, sortBy string) {
+	byKey := func(a, b IndexEntry) bool { return client.CompareIssueKeys(a.Key, b.Key) < 0 }
+
+	less := byKey
+	switch sortBy {
+	case IndexSortStatus:
+		less = func(a, b IndexEntry) bool {
+			if ra, rb := statusCategoryRank(a.statusCategory), statusCategoryRank(b.statusCategory); ra != rb {
+				return ra < rb
+			}
+			if a.Status != b.Status {
+				return a.Status < b.Status
+			}
+			return byKey(a, b)
+		}
+	case IndexSortUpdated:
+		less = func(a, b IndexEntry) bool {
+			if !a.updatedAt.Equal(b.updatedAt) {
+				if a.updatedAt.IsZero() || b.updatedAt.IsZero() {
+					return b.updatedAt.IsZero()
+				}
+				return a.updatedAt.After(b.updatedAt)
+			}
+			return byKey(a, b)
+		}
+	case IndexSortPriority:
+		less = func(a, b IndexEntry) bool {
+			if ra, rb := priorityRank(a.Priority), priorityRank(b.Priority); ra != rb {
+				return ra < rb
+			}
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority
+			}
+			return byKey(a, b)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return less(entries[i], entries[j]) })
+}
+
+// statusCategoryRank places unknown categories after done
+func statusCategoryRank(category string) int {
+	if rank, ok := statusCategoryRanks[strings.ToLower(category)]; ok {
+		return rank
+	}
+	return len(statusCategoryRanks)
+}
+
+// priorityRank places custom priorities after the default ones, and no priority last
+func priorityRank(priority string) int {
+	if priority == "" {
+		return len(priorityRanks) + 1
+	}
+	if rank, ok := priorityRanks[strings.ToLower(priority)]; ok {
+		return rank
+	}
+	return len(priorityRanks)
+}
+
+// parseIndexTimestamp parses an issue timestamp in any format JIRA returns, or returns zero
+func parseIndexTimestamp(value string) time.Time {
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
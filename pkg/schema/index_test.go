@@ -0,0 +1,189 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func writeIndexTestIssues(t *testing.T, basePath string, issues ...*client.Issue) {
+	t.Helper()
+	writer := NewYAMLFileWriter()
+	for _, issue := range issues {
+		if _, err := writer.WriteIssueToYAML(issue, basePath); err != nil {
+			t.Fatalf("WriteIssueToYAML() error = %v", err)
+		}
+	}
+}
+
+func TestParseIndexFormat(t *testing.T) {
+	tests := map[string]string{
+		"":         IndexFormatYAML,
+		"yaml":     IndexFormatYAML,
+		"YML":      IndexFormatYAML,
+		"markdown": IndexFormatMarkdown,
+		"md":       IndexFormatMarkdown,
+	}
+	for input, expected := range tests {
+		format, err := ParseIndexFormat(input)
+		if err != nil || format != expected {
+			t.Errorf("ParseIndexFormat(%q) = %q, %v; want %q", input, format, err, expected)
+		}
+	}
+
+	if _, err := ParseIndexFormat("html"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestWriteProjectIndex_SortedAndStable(t *testing.T) {
+	basePath := t.TempDir()
+	writeIndexTestIssues(t, basePath,
+		&client.Issue{Key: "PROJ-10", Summary: "Tenth", Status: client.Status{Name: "Done"}},
+		&client.Issue{Key: "PROJ-2", Summary: "Second", Status: client.Status{Name: "Open"},
+			Assignee: client.User{Name: "Jane", Email: "jane@example.com"}},
+	)
+
+	indexPath, changed, err := WriteProjectIndex(basePath, "PROJ", IndexFormatYAML, IndexSortKey)
+	if err != nil {
+		t.Fatalf("WriteProjectIndex() error = %v", err)
+	}
+	if !changed {
+		t.Error("Expected first write to report a change")
+	}
+	if indexPath != filepath.Join(basePath, "projects", "PROJ", "index.yaml") {
+		t.Errorf("Unexpected index path %s", indexPath)
+	}
+
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatalf("Failed to read index: %v", err)
+	}
+	content := string(data)
+	if strings.Index(content, "PROJ-2") > strings.Index(content, "PROJ-10") {
+		t.Errorf("Expected PROJ-2 before PROJ-10, got:\n%s", content)
+	}
+	if !strings.Contains(content, "issue_count: 2") || !strings.Contains(content, "assignee: Jane") {
+		t.Errorf("Index missing expected fields:\n%s", content)
+	}
+
+	// Regenerating without changes is a no-op
+	if _, changed, err := WriteProjectIndex(basePath, "PROJ", IndexFormatYAML, IndexSortKey); err != nil || changed {
+		t.Errorf("Expected unchanged index, got changed=%t err=%v", changed, err)
+	}
+}
+
+func TestWriteProjectIndex_FollowsRemovedFiles(t *testing.T) {
+	basePath := t.TempDir()
+	writeIndexTestIssues(t, basePath,
+		&client.Issue{Key: "PROJ-1", Summary: "Kept"},
+		&client.Issue{Key: "PROJ-3", Summary: "Pruned"},
+	)
+	if _, _, err := WriteProjectIndex(basePath, "PROJ", IndexFormatYAML, IndexSortKey); err != nil {
+		t.Fatalf("WriteProjectIndex() error = %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(basePath, "projects", "PROJ", "issues", "PROJ-3.yaml")); err != nil {
+		t.Fatalf("Failed to remove issue file: %v", err)
+	}
+
+	_, changed, err := WriteProjectIndex(basePath, "PROJ", IndexFormatYAML, IndexSortKey)
+	if err != nil || !changed {
+		t.Fatalf("Expected index to change after removal, got changed=%t err=%v", changed, err)
+	}
+	index, err := BuildProjectIndex(basePath, "PROJ", IndexSortKey)
+	if err != nil {
+		t.Fatalf("BuildProjectIndex() error = %v", err)
+	}
+	if index.IssueCount != 1 || index.Issues[0].Key != "PROJ-1" {
+		t.Errorf("Expected only PROJ-1 in index, got %+v", index.Issues)
+	}
+}
+
+func TestBuildProjectIndex_SortOrders(t *testing.T) {
+	basePath := t.TempDir()
+	writeIndexTestIssues(t, basePath,
+		&client.Issue{Key: "PROJ-1", Summary: "Shipped", Status: client.Status{Name: "Done", Category: "Done"},
+			Priority: "Low", Updated: "2024-03-15T09:30:00.000+0200"},
+		&client.Issue{Key: "PROJ-2", Summary: "Started", Status: client.Status{Name: "In Progress", Category: "In Progress"},
+			Priority: "Highest", Updated: "2024-03-15T08:00:00.000Z"},
+		&client.Issue{Key: "PROJ-3", Summary: "Queued", Status: client.Status{Name: "Backlog", Category: "To Do"},
+			Priority: "Cosmetic"},
+		&client.Issue{Key: "PROJ-10", Summary: "Also queued", Status: client.Status{Name: "Backlog", Category: "To Do"},
+			Priority: "Low", Updated: "2024-03-14T00:00:00.000Z"},
+	)
+
+	tests := map[string][]string{
+		IndexSortKey:      {"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-10"},
+		IndexSortStatus:   {"PROJ-3", "PROJ-10", "PROJ-2", "PROJ-1"},
+		IndexSortUpdated:  {"PROJ-2", "PROJ-1", "PROJ-10", "PROJ-3"},
+		IndexSortPriority: {"PROJ-2", "PROJ-1", "PROJ-10", "PROJ-3"},
+	}
+	for sortBy, want := range tests {
+		index, err := BuildProjectIndex(basePath, "PROJ", sortBy)
+		if err != nil {
+			t.Fatalf("BuildProjectIndex(%s) error = %v", sortBy, err)
+		}
+		var keys []string
+		for _, entry := range index.Issues {
+			keys = append(keys, entry.Key)
+		}
+		if strings.Join(keys, ",") != strings.Join(want, ",") {
+			t.Errorf("Sorted by %s = %v, want %v", sortBy, keys, want)
+		}
+	}
+
+	// The sort field is listed; the default key order leaves the index unchanged
+	index, _ := BuildProjectIndex(basePath, "PROJ", IndexSortUpdated)
+	data, _ := RenderIndex(index, IndexFormatMarkdown)
+	if !strings.Contains(string(data), "sorted by updated") || !strings.Contains(string(data), "| Started | In Progress |  | 2024-03-15T08:00:00.000Z |") {
+		t.Errorf("Expected an Updated column, got:\n%s", data)
+	}
+	index, _ = BuildProjectIndex(basePath, "PROJ", "")
+	data, _ = RenderIndex(index, IndexFormatYAML)
+	if strings.Contains(string(data), "sorted_by") || strings.Contains(string(data), "updated:") {
+		t.Errorf("Expected the key-ordered index to carry no sort fields:\n%s", data)
+	}
+
+	if _, err := BuildProjectIndex(basePath, "PROJ", "assignee"); err == nil {
+		t.Error("Expected an unsupported sort order to fail")
+	}
+}
+
+func TestRenderIndex_Markdown(t *testing.T) {
+	index := &ProjectIndex{
+		Project:    "PROJ",
+		IssueCount: 1,
+		Issues: []IndexEntry{
+			{Key: "PROJ-1", Summary: "Pipe | in\nsummary", Status: "Open", Assignee: "Jane"},
+		},
+	}
+
+	data, err := RenderIndex(index, IndexFormatMarkdown)
+	if err != nil {
+		t.Fatalf("RenderIndex() error = %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "| [PROJ-1](issues/PROJ-1.yaml) | Pipe \\| in summary | Open | Jane |") {
+		t.Errorf("Unexpected Markdown index:\n%s", content)
+	}
+}
+
+func TestListProjects(t *testing.T) {
+	basePath := t.TempDir()
+	writeIndexTestIssues(t, basePath,
+		&client.Issue{Key: "ZED-1", Summary: "Z"},
+		&client.Issue{Key: "ABC-1", Summary: "A"},
+	)
+
+	projects, err := ListProjects(basePath)
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if strings.Join(projects, ",") != "ABC,ZED" {
+		t.Errorf("ListProjects() = %v, want [ABC ZED]", projects)
+	}
+}
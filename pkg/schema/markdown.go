@@ -0,0 +1,289 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// Issue file formats
+const (
+	FileFormatYAML     = "yaml"
+	FileFormatMarkdown = "markdown"
+)
+
+// ParseFileFormat validates an issue file format name ("md" is accepted for Markdown)
+func ParseFileFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", FileFormatYAML, "yml":
+		return FileFormatYAML, nil
+	case FileFormatMarkdown, "md":
+		return FileFormatMarkdown, nil
+	default:
+		return "", &SchemaError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("unsupported issue file format %q (use yaml or markdown)", format),
+		}
+	}
+}
+
+// FileExtension returns the extension of issue files written in format
+func FileExtension(format string) string {
+	if format == FileFormatMarkdown {
+		return ".md"
+	}
+	return ".yaml"
+}
+
+// MarkdownFileWriter implements FileWriter by rendering issues as browsable Markdown
+// Files use the same layout as YAML output with a .md extension:
+// /projects/{project-key}/issues/{issue-key}.md
+type MarkdownFileWriter struct {
+	// BaseURL is the JIRA URL used to link each issue back to JIRA (no link when empty)
+	// Pass config.Config.IssueLinkBaseURL so links honor JIRA_PUBLIC_URL.
+	BaseURL string
+
+	// Frontmatter lists the fields written as a YAML frontmatter block, in order, before the
+	// Markdown body (none when empty); see FrontmatterFields
+	Frontmatter []string
+}
+
+// NewMarkdownFileWriter creates a new Markdown file writer that links issues under baseURL
+func NewMarkdownFileWriter(baseURL string) FileWriter {
+	return &MarkdownFileWriter{BaseURL: baseURL}
+}
+
+// NewMarkdownFileWriterWithFrontmatter creates a Markdown file writer that starts each file with
+// a YAML frontmatter block of the given fields, for static-site generators such as Hugo or Jekyll
+func NewMarkdownFileWriterWithFrontmatter(baseURL string, fields []string) FileWriter {
+	return &MarkdownFileWriter{BaseURL: baseURL, Frontmatter: fields}
+}
+
+// WriteIssueToYAML writes a JIRA issue as a Markdown file
+// The method name comes from the FileWriter interface; the output is Markdown.
+func (w *MarkdownFileWriter) WriteIssueToYAML(issue *client.Issue, basePath string) (string, error) {
+	if issue == nil {
+		return "", &SchemaError{
+			Type:    "invalid_input",
+			Message: "issue cannot be nil",
+		}
+	}
+
+	if issue.Key == "" {
+		return "", &SchemaError{
+			Type:    "invalid_input",
+			Message: "issue key cannot be empty",
+		}
+	}
+
+	projectKey := extractProjectKey(issue.Key)
+	if projectKey == "" {
+		return "", &SchemaError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("could not extract project key from issue key: %s", issue.Key),
+		}
+	}
+
+	if err := w.CreateDirectoryStructure(basePath, projectKey); err != nil {
+		return "", fmt.Errorf("failed to create directory structure: %w", err)
+	}
+
+	filePath := w.GetIssueFilePath(basePath, projectKey, issue.Key)
+
+	if err := os.WriteFile(filePath, []byte(w.Render(issue)), 0644); err != nil {
+		return "", &SchemaError{
+			Type:    "file_error",
+			Message: fmt.Sprintf("failed to write Markdown file: %s", filePath),
+			Err:     err,
+		}
+	}
+
+	return filePath, nil
+}
+
+// CreateDirectoryStructure creates the required directory structure
+// Pattern: /projects/{project-key}/issues/
+func (w *MarkdownFileWriter) CreateDirectoryStructure(basePath, projectKey string) error {
+	return (&YAMLFileWriter{}).CreateDirectoryStructure(basePath, projectKey)
+}
+
+// GetIssueFilePath returns the full file path for an issue Markdown file
+// Pattern: /projects/{project-key}/issues/{issue-key}.md
+func (w *MarkdownFileWriter) GetIssueFilePath(basePath, projectKey, issueKey string) string {
+	return filepath.Join(basePath, "projects", projectKey, "issues", issueKey+".md")
+}
+
+// RenderIssue returns the Markdown that WriteIssueToYAML would write for the issue
+func (w *MarkdownFileWriter) RenderIssue(issue *client.Issue) ([]byte, error) {
+	return []byte(w.Render(issue)), nil
+}
+
+// Render converts an issue to Markdown
+// The header line carries type, priority, and status indicators plus a link back to JIRA
+// so the mirror can be scanned at a glance; missing fields are left out.
+func (w *MarkdownFileWriter) Render(issue *client.Issue) string {
+	var b strings.Builder
+	b.WriteString(RenderFrontmatter(issue, w.Frontmatter, w.BaseURL))
+
+	title := issue.Key
+	if issue.Summary != "" {
+		title += ": " + issue.Summary
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+
+	if header := w.headerLine(issue); header != "" {
+		fmt.Fprintf(&b, "%s\n\n", header)
+	}
+
+	var details []string
+	if name := formatMarkdownUser(issue.Assignee); name != "" {
+		details = append(details, fmt.Sprintf("- **Assignee:** %s", name))
+	}
+	if name := formatMarkdownUser(issue.Reporter); name != "" {
+		details = append(details, fmt.Sprintf("- **Reporter:** %s", name))
+	}
+	if issue.Created != "" {
+		details = append(details, fmt.Sprintf("- **Created:** %s", issue.Created))
+	}
+	if issue.Updated != "" {
+		details = append(details, fmt.Sprintf("- **Updated:** %s", issue.Updated))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&b, "%s\n\n", strings.Join(details, "\n"))
+	}
+
+	if description := strings.TrimSpace(RenderADF(issue.Description, ADFRenderMarkdown)); description != "" {
+		fmt.Fprintf(&b, "## Description\n\n%s\n\n", description)
+	}
+
+	if relationships := renderMarkdownRelationships(issue.Relationships); relationships != "" {
+		fmt.Fprintf(&b, "## Relationships\n\n%s\n", relationships)
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// headerLine builds the "🐛 Bug · 🔴 High · 🔵 In Progress · View in JIRA" indicator line
+func (w *MarkdownFileWriter) headerLine(issue *client.Issue) string {
+	var parts []string
+	if issue.IssueType != "" {
+		parts = append(parts, IssueTypeIcon(issue.IssueType)+" "+issue.IssueType)
+	}
+	if issue.Priority != "" {
+		parts = append(parts, PriorityIcon(issue.Priority)+" "+issue.Priority)
+	}
+	if issue.Status.Name != "" {
+		parts = append(parts, StatusIcon(issue.Status)+" "+issue.Status.Name)
+	}
+	if w.BaseURL != "" {
+		if issueURL, err := client.BuildIssueURL(w.BaseURL, issue.Key); err == nil {
+			parts = append(parts, fmt.Sprintf("[View in JIRA](%s)", issueURL))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// IssueTypeIcon returns an emoji indicator for an issue type
+func IssueTypeIcon(issueType string) string {
+	switch strings.ToLower(issueType) {
+	case "bug", "defect":
+		return "🐛"
+	case "epic":
+		return "⚡"
+	case "story", "user story":
+		return "📖"
+	case "task":
+		return "✅"
+	case "sub-task", "subtask":
+		return "🔹"
+	case "improvement", "enhancement", "feature", "new feature":
+		return "✨"
+	case "spike":
+		return "🔬"
+	case "documentation":
+		return "📝"
+	default:
+		return "📄"
+	}
+}
+
+// PriorityIcon returns an emoji indicator for a priority
+func PriorityIcon(priority string) string {
+	switch strings.ToLower(priority) {
+	case "blocker", "highest", "critical":
+		return "🔴"
+	case "high", "major":
+		return "🟠"
+	case "medium", "normal":
+		return "🟡"
+	case "low", "minor":
+		return "🟢"
+	case "lowest", "trivial":
+		return "⚪"
+	default:
+		return "⚫"
+	}
+}
+
+// StatusIcon returns an emoji indicator for a status, based on its category when known
+func StatusIcon(status client.Status) string {
+	switch strings.ToLower(status.Category) {
+	case "to do", "new":
+		return "⬜"
+	case "in progress", "indeterminate":
+		return "🔵"
+	case "done", "complete":
+		return "✔️"
+	}
+
+	switch strings.ToLower(status.Name) {
+	case "closed", "done", "resolved":
+		return "✔️"
+	case "in progress", "in review", "code review":
+		return "🔵"
+	default:
+		return "⬜"
+	}
+}
+
+// formatMarkdownUser formats a user as "Name <email>", omitting missing parts
+func formatMarkdownUser(user client.User) string {
+	switch {
+	case user.Name != "" && user.Email != "":
+		return fmt.Sprintf("%s <%s>", user.Name, user.Email)
+	case user.Name != "":
+		return user.Name
+	default:
+		return user.Email
+	}
+}
+
+// renderMarkdownRelationships lists related issues as bullet points
+func renderMarkdownRelationships(relationships *client.Relationships) string {
+	if relationships == nil {
+		return ""
+	}
+
+	var lines []string
+	if relationships.EpicLink != "" {
+		lines = append(lines, fmt.Sprintf("- **Epic:** %s", relationships.EpicLink))
+	}
+	if relationships.ParentIssue != "" {
+		lines = append(lines, fmt.Sprintf("- **Parent:** %s", relationships.ParentIssue))
+	}
+	if len(relationships.Subtasks) > 0 {
+		lines = append(lines, fmt.Sprintf("- **Subtasks:** %s", strings.Join(relationships.Subtasks, ", ")))
+	}
+	for _, link := range relationships.IssueLinks {
+		line := fmt.Sprintf("- **%s** (%s): %s", link.Type, link.Direction, link.IssueKey)
+		if link.Summary != "" {
+			line += " - " + link.Summary
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestMarkdownFileWriter_WriteIssue(t *testing.T) {
+	tempDir := t.TempDir()
+	writer := NewMarkdownFileWriter("https://host.example.com/jira")
+
+	issue := &client.Issue{
+		Key:         "PROJ-123",
+		Summary:     "Login fails on Safari",
+		Description: "Steps to reproduce...",
+		IssueType:   "Bug",
+		Priority:    "High",
+		Status:      client.Status{Name: "In Review", Category: "In Progress"},
+		Assignee:    client.User{Name: "Jane Doe", Email: "jane@example.com"},
+		Relationships: &client.Relationships{
+			EpicLink:   "PROJ-1",
+			IssueLinks: []client.IssueLink{{Type: "blocks", Direction: "outward", IssueKey: "PROJ-200"}},
+		},
+	}
+
+	filePath, err := writer.WriteIssueToYAML(issue, tempDir)
+	if err != nil {
+		t.Fatalf("WriteIssueToYAML() error = %v", err)
+	}
+	if filePath != filepath.Join(tempDir, "projects", "PROJ", "issues", "PROJ-123.md") {
+		t.Errorf("Unexpected file path: %s", filePath)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read Markdown file: %v", err)
+	}
+	content := string(data)
+
+	expected := []string{
+		"# PROJ-123: Login fails on Safari\n",
+		"🐛 Bug · 🟠 High · 🔵 In Review · [View in JIRA](https://host.example.com/jira/browse/PROJ-123)\n",
+		"- **Assignee:** Jane Doe <jane@example.com>",
+		"## Description\n\nSteps to reproduce...",
+		"- **Epic:** PROJ-1",
+		"- **blocks** (outward): PROJ-200",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected Markdown to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestMarkdownFileWriter_MissingFields(t *testing.T) {
+	writer := &MarkdownFileWriter{}
+
+	content := writer.Render(&client.Issue{Key: "PROJ-1"})
+	if content != "# PROJ-1\n" {
+		t.Errorf("Expected only the title for a bare issue, got %q", content)
+	}
+
+	// Unknown values fall back to neutral indicators; no base URL means no link
+	content = writer.Render(&client.Issue{Key: "PROJ-2", IssueType: "Risk", Status: client.Status{Name: "Triage"}})
+	if !strings.Contains(content, "📄 Risk · ⬜ Triage\n") || strings.Contains(content, "View in JIRA") {
+		t.Errorf("Unexpected header for partial issue:\n%s", content)
+	}
+
+	if _, err := writer.WriteIssueToYAML(nil, t.TempDir()); err == nil {
+		t.Error("Expected error for nil issue")
+	}
+}
+
+func TestMarkdownFileWriter_Frontmatter(t *testing.T) {
+	issue := &client.Issue{
+		Key:       "PROJ-123",
+		Summary:   "Login fails: Safari",
+		Status:    client.Status{Name: "In Review"},
+		Assignee:  client.User{Name: "Jane Doe", Email: "jane@example.com"},
+		Reporter:  client.User{Email: "ops@example.com"},
+		Labels:    []string{"ui", "browser", "ui"},
+		Created:   "2024-01-15T10:30:00.000+0000",
+		IssueType: "Bug",
+		Relationships: &client.Relationships{
+			EpicLink:   "PROJ-1",
+			IssueLinks: []client.IssueLink{{Type: "blocks", Direction: "outward", IssueKey: "PROJ-200"}, {Type: "relates", Direction: "inward", IssueKey: "PROJ-150"}},
+		},
+	}
+
+	writer := NewMarkdownFileWriterWithFrontmatter("https://host.example.com/jira", []string{"key", "title", "status", "assignee", "reporter", "labels", "created", "updated", "epic", "links", "url"}).(*MarkdownFileWriter)
+	content := writer.Render(issue)
+
+	wantFrontmatter := `---
+key: PROJ-123
+title: 'PROJ-123: Login fails: Safari'
+status: In Review
+assignee: Jane Doe
+reporter: ops@example.com
+labels: [browser, ui]
+created: 2024-01-15T10:30:00.000+0000
+epic: PROJ-1
+links: [PROJ-150, PROJ-200]
+url: https://host.example.com/jira/browse/PROJ-123
+---
+
+# PROJ-123: Login fails: Safari
+`
+	if !strings.HasPrefix(content, wantFrontmatter) {
+		t.Errorf("Unexpected frontmatter:\n%s", content)
+	}
+	// The relationships stay in the body alongside the frontmatter keys
+	if !strings.Contains(content, "## Relationships\n\n- **Epic:** PROJ-1") {
+		t.Errorf("Expected the relationships section in the body:\n%s", content)
+	}
+	if again := writer.Render(issue); again != content {
+		t.Errorf("Expected deterministic output, got:\n%s\nthen:\n%s", content, again)
+	}
+}
+
+func TestParseFrontmatterFields(t *testing.T) {
+	fields, err := ParseFrontmatterFields(" Key, default ,url,key")
+	if err != nil {
+		t.Fatalf("ParseFrontmatterFields() error = %v", err)
+	}
+	want := []string{"key", "title", "status", "assignee", "labels", "created", "updated", "url"}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Errorf("ParseFrontmatterFields() = %v, want %v", fields, want)
+	}
+
+	if fields, err := ParseFrontmatterFields(""); err != nil || fields != nil {
+		t.Errorf("Expected an empty list to disable frontmatter, got %v, %v", fields, err)
+	}
+	if _, err := ParseFrontmatterFields("key,sprint"); err == nil || !strings.Contains(err.Error(), "sprint") {
+		t.Errorf("Expected an unsupported field error, got %v", err)
+	}
+}
+
+func TestParseFileFormat(t *testing.T) {
+	for input, want := range map[string]string{"": FileFormatYAML, "yml": FileFormatYAML, " Markdown ": FileFormatMarkdown, "md": FileFormatMarkdown} {
+		if got, err := ParseFileFormat(input); err != nil || got != want {
+			t.Errorf("ParseFileFormat(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFileFormat("ndjson"); err == nil {
+		t.Error("Expected ndjson to be rejected as an issue file format")
+	}
+	if FileExtension(FileFormatMarkdown) != ".md" || FileExtension(FileFormatYAML) != ".yaml" {
+		t.Errorf("Unexpected extensions %q and %q", FileExtension(FileFormatMarkdown), FileExtension(FileFormatYAML))
+	}
+}
@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// IssueMergeRenderer is implemented by writers whose output depends on the file already on disk,
+// so dry runs can plan the content a merge-update would actually write
+type IssueMergeRenderer interface {
+	RenderIssueUpdate(issue *client.Issue, existing []byte) ([]byte, error)
+}
+
+// RenderIssueUpdate returns the content WriteIssueToYAML would write over an existing file
+// Without Merge, or when the existing file cannot be merged, this is the full rendering.
+func (w *YAMLFileWriter) RenderIssueUpdate(issue *client.Issue, existing []byte) ([]byte, error) {
+	rendered, err := w.RenderIssue(issue)
+	if err != nil || !w.Merge || existing == nil {
+		return rendered, err
+	}
+
+	merged, mergeErr := MergeYAML(existing, rendered)
+	if mergeErr != nil {
+		return rendered, nil // malformed existing file: fall back to a full write
+	}
+	return merged, nil
+}
+
+// MergeYAML updates the existing YAML document with the values of the updated document
+// Only values that differ are replaced; keys keep their existing order, comments, and quoting.
+// Keys missing from the updated document are removed and new keys are appended at the end of
+// their mapping. Mappings are merged recursively; any other changed value is replaced whole.
+// Returns an error when either document is not a YAML mapping.
+func MergeYAML(existing, updated []byte) ([]byte, error) {
+	existingRoot, err := parseMappingDocument(existing)
+	if err != nil {
+		return nil, fmt.Errorf("existing document: %w", err)
+	}
+	updatedRoot, err := parseMappingDocument(updated)
+	if err != nil {
+		return nil, fmt.Errorf("updated document: %w", err)
+	}
+
+	if !mergeMapping(existingRoot.Content[0], updatedRoot.Content[0]) {
+		return existing, nil // nothing changed, keep the file byte for byte
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	if err := encoder.Encode(existingRoot); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// parseMappingDocument parses a YAML document whose root is a mapping
+func parseMappingDocument(data []byte) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("not a YAML mapping")
+	}
+	return &document, nil
+}
+
+// mergeMapping applies updated onto existing in place and reports whether anything changed
+func mergeMapping(existing, updated *yaml.Node) bool {
+	changed := false
+
+	updatedValues := make(map[string]*yaml.Node, len(updated.Content)/2)
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		updatedValues[updated.Content[i].Value] = updated.Content[i+1]
+	}
+
+	// Update or drop existing keys in their current order
+	kept := make([]*yaml.Node, 0, len(existing.Content))
+	seen := make(map[string]bool, len(existing.Content)/2)
+	for i := 0; i+1 < len(existing.Content); i += 2 {
+		keyNode, valueNode := existing.Content[i], existing.Content[i+1]
+		updatedValue, exists := updatedValues[keyNode.Value]
+		if !exists || seen[keyNode.Value] {
+			changed = true
+			continue
+		}
+		seen[keyNode.Value] = true
+
+		switch {
+		case valueNode.Kind == yaml.MappingNode && updatedValue.Kind == yaml.MappingNode:
+			if mergeMapping(valueNode, updatedValue) {
+				changed = true
+			}
+		case !nodesEqual(valueNode, updatedValue):
+			// Keep comments attached to the old value
+			updatedValue.HeadComment = valueNode.HeadComment
+			updatedValue.LineComment = valueNode.LineComment
+			updatedValue.FootComment = valueNode.FootComment
+			valueNode = updatedValue
+			changed = true
+		}
+		kept = append(kept, keyNode, valueNode)
+	}
+
+	// Append keys that are new to this mapping
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		if !seen[updated.Content[i].Value] {
+			kept = append(kept, updated.Content[i], updated.Content[i+1])
+			changed = true
+		}
+	}
+
+	existing.Content = kept
+	return changed
+}
+
+// nodesEqual compares two YAML nodes by content, ignoring style, comments, and positions
+func nodesEqual(a, b *yaml.Node) bool {
+	if a.Kind == yaml.AliasNode {
+		a = a.Alias
+	}
+	if b.Kind == yaml.AliasNode {
+		b = b.Alias
+	}
+	if a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+	if a.Kind == yaml.ScalarNode && (a.ShortTag() != b.ShortTag() || a.Value != b.Value) {
+		return false
+	}
+	for i := range a.Content {
+		if !nodesEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestMergeYAML(t *testing.T) {
+	existing := `# Curated issue file
+summary: "Old summary"
+key: PROJ-1
+status:
+    name: Open # triaged
+labels:
+    - a
+    - b
+`
+
+	tests := []struct {
+		name     string
+		updated  string
+		expected string
+	}{
+		{
+			name:     "unchanged values keep the file byte for byte",
+			updated:  "key: PROJ-1\nsummary: Old summary\nstatus:\n    name: Open\nlabels: [a, b]\n",
+			expected: existing,
+		},
+		{
+			name:    "changed scalar replaced in place",
+			updated: "key: PROJ-1\nsummary: New summary\nstatus:\n    name: Open\nlabels: [a, b]\n",
+			expected: `# Curated issue file
+summary: New summary
+key: PROJ-1
+status:
+    name: Open # triaged
+labels:
+    - a
+    - b
+`,
+		},
+		{
+			name:    "nested change keeps comments",
+			updated: "key: PROJ-1\nsummary: Old summary\nstatus:\n    name: Done\nlabels: [a, b]\n",
+			expected: `# Curated issue file
+summary: "Old summary"
+key: PROJ-1
+status:
+    name: Done # triaged
+labels:
+    - a
+    - b
+`,
+		},
+		{
+			name:    "removed and added keys",
+			updated: "key: PROJ-1\nsummary: Old summary\nstatus:\n    name: Open\npriority: High\n",
+			expected: `# Curated issue file
+summary: "Old summary"
+key: PROJ-1
+status:
+    name: Open # triaged
+priority: High
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := MergeYAML([]byte(existing), []byte(tt.updated))
+			if err != nil {
+				t.Fatalf("MergeYAML() error = %v", err)
+			}
+			if string(merged) != tt.expected {
+				t.Errorf("MergeYAML() =\n%s\nwant:\n%s", merged, tt.expected)
+			}
+		})
+	}
+
+	if _, err := MergeYAML([]byte("key: [unclosed"), []byte("key: PROJ-1\n")); err == nil {
+		t.Error("Expected error for malformed existing document")
+	}
+	if _, err := MergeYAML([]byte("- a\n- b\n"), []byte("key: PROJ-1\n")); err == nil {
+		t.Error("Expected error for a non-mapping document")
+	}
+}
+
+func TestYAMLFileWriter_MergeUpdate(t *testing.T) {
+	basePath := t.TempDir()
+	writer := &YAMLFileWriter{Merge: true}
+	filePath := writer.GetIssueFilePath(basePath, "PROJ", "PROJ-1")
+
+	// New files are written in full
+	issue := &client.Issue{Key: "PROJ-1", Summary: "First", Status: client.Status{Name: "Open"}, Priority: "High"}
+	if _, err := writer.WriteIssueToYAML(issue, basePath); err != nil {
+		t.Fatalf("WriteIssueToYAML() error = %v", err)
+	}
+	full, _ := ToYAML(issue)
+	if written, _ := os.ReadFile(filePath); string(written) != string(full) {
+		t.Errorf("Expected full rendering for a new file, got:\n%s", written)
+	}
+
+	// Manually move priority to the top; a status change keeps that order
+	reordered := "priority: High\n" + strings.Replace(string(full), "priority: High\n", "", 1)
+	if err := os.WriteFile(filePath, []byte(reordered), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	issue.Status.Name = "Done"
+	if _, err := writer.WriteIssueToYAML(issue, basePath); err != nil {
+		t.Fatalf("WriteIssueToYAML() error = %v", err)
+	}
+	expected := strings.Replace(reordered, "name: Open", "name: Done", 1)
+	if written, _ := os.ReadFile(filePath); string(written) != expected {
+		t.Errorf("Expected only the status to change:\n%s\nwant:\n%s", written, expected)
+	}
+
+	// Malformed files fall back to a full write
+	if err := os.WriteFile(filePath, []byte("key: [unclosed"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := writer.WriteIssueToYAML(issue, basePath); err != nil {
+		t.Fatalf("WriteIssueToYAML() error = %v", err)
+	}
+	full, _ = ToYAML(issue)
+	if written, _ := os.ReadFile(filepath.Clean(filePath)); string(written) != string(full) {
+		t.Errorf("Expected full rewrite of malformed file, got:\n%s", written)
+	}
+}
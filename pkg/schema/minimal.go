@@ -0,0 +1,20 @@
+package schema
+
+import "strings"
+
+// MinimalFields are the only top-level fields written in minimal mode: enough for a status
+// mirror, in the order they appear in issue files
+var MinimalFields = []string{"key", "summary", "status", "assignee", "updated"}
+
+// MinimalRelationshipsField is the field minimal mode can optionally keep as well
+const MinimalRelationshipsField = "relationships"
+
+// MinimalFieldPatterns returns the include patterns of the minimal preset
+// The patterns match the fields by full path, so same-named nested fields are not kept.
+func MinimalFieldPatterns(withRelationships bool) []string {
+	fields := append([]string(nil), MinimalFields...)
+	if withRelationships {
+		fields = append(fields, MinimalRelationshipsField)
+	}
+	return []string{regexFieldPrefix + "^(" + strings.Join(fields, "|") + ")$"}
+}
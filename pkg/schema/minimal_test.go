@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMinimalFieldPatterns(t *testing.T) {
+	issue := fieldFilterTestIssue()
+	issue.Updated = "2024-03-15T07:30:00.000Z"
+	issue.Labels = []string{"backend"}
+	issue.CustomFields = map[string]interface{}{"customfield_1": map[string]interface{}{"status": "nested"}}
+
+	tests := []struct {
+		withRelationships bool
+		want              []string
+	}{
+		{false, []string{"key", "summary", "status", "assignee", "updated"}},
+		{true, []string{"key", "summary", "status", "assignee", "updated", "relationships"}},
+	}
+	for _, tt := range tests {
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal([]byte(filteredYAML(t, MinimalFieldPatterns(tt.withRelationships), nil, issue)), &doc); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		var keys []string
+		for _, field := range DefaultFieldOrder {
+			if _, ok := doc[field]; ok {
+				keys = append(keys, field)
+			}
+		}
+		if len(keys) != len(doc) || !reflect.DeepEqual(keys, tt.want) {
+			t.Errorf("MinimalFieldPatterns(%t) wrote %v, want %v", tt.withRelationships, doc, tt.want)
+		}
+		if doc["status"].(map[string]interface{})["category"] != "new" || doc["assignee"].(map[string]interface{})["email"] != "jane@example.com" {
+			t.Errorf("Expected kept fields to be written whole, got %v", doc)
+		}
+	}
+
+	// Exclusions still apply on top of the preset
+	var doc map[string]interface{}
+	_ = yaml.Unmarshal([]byte(filteredYAML(t, MinimalFieldPatterns(false), []string{"assignee.email"}, issue)), &doc)
+	if _, ok := doc["assignee"].(map[string]interface{})["email"]; ok {
+		t.Errorf("Expected assignee.email to be excluded, got %v", doc["assignee"])
+	}
+}
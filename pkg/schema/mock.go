@@ -2,12 +2,16 @@ package schema
 
 import (
 	"path/filepath"
+	"sync"
 
 	"github.com/chambrid/jira-cdc-git/pkg/client"
 )
 
 // MockFileWriter implements FileWriter for testing
+// Its methods are safe for concurrent use, as sync workers write issues in parallel.
 type MockFileWriter struct {
+	mu sync.Mutex
+
 	// WrittenFiles tracks what files would be written
 	WrittenFiles map[string][]byte
 
@@ -37,6 +41,9 @@ func NewMockFileWriter() *MockFileWriter {
 
 // WriteIssueToYAML simulates writing an issue to YAML
 func (m *MockFileWriter) WriteIssueToYAML(issue *client.Issue, basePath string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.WriteIssueCallCount++
 	m.LastWrittenIssue = issue
 
@@ -77,6 +84,9 @@ func (m *MockFileWriter) WriteIssueToYAML(issue *client.Issue, basePath string)
 
 // CreateDirectoryStructure simulates creating directory structure
 func (m *MockFileWriter) CreateDirectoryStructure(basePath, projectKey string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	// Simulate directory error if configured
 	if m.DirectoryError != nil {
 		return m.DirectoryError
@@ -103,27 +113,42 @@ func (m *MockFileWriter) GetIssueFilePath(basePath, projectKey, issueKey string)
 
 // SetWriteError configures the mock to return a write error
 func (m *MockFileWriter) SetWriteError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.WriteError = err
 }
 
 // SetDirectoryError configures the mock to return a directory creation error
 func (m *MockFileWriter) SetDirectoryError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.DirectoryError = err
 }
 
 // GetWrittenFileContent returns the content that was "written" to a file
 func (m *MockFileWriter) GetWrittenFileContent(filePath string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	content, exists := m.WrittenFiles[filePath]
 	return content, exists
 }
 
 // GetCreatedDirectories returns all directories that would be created
 func (m *MockFileWriter) GetCreatedDirectories() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	return m.CreatedDirectories
 }
 
 // Reset clears all mock state for clean test setup
 func (m *MockFileWriter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.WrittenFiles = make(map[string][]byte)
 	m.CreatedDirectories = make([]string, 0)
 	m.WriteError = nil
@@ -134,6 +159,9 @@ func (m *MockFileWriter) Reset() {
 
 // VerifyIssueWritten checks if a specific issue was written to the expected path
 func (m *MockFileWriter) VerifyIssueWritten(basePath, issueKey string) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	projectKey := extractProjectKey(issueKey)
 	expectedPath := m.GetIssueFilePath(basePath, projectKey, issueKey)
 	_, exists := m.WrittenFiles[expectedPath]
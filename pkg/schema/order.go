@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldOrderRest stands for every field a field order does not list
+const FieldOrderRest = "*"
+
+// DefaultFieldOrder is the order issue files are written in when no order is configured:
+// identity and summary first, workflow and people next, then relationships, references, time
+// tracking, and custom fields last. It matches the order of client.Issue, so it costs no reordering.
+var DefaultFieldOrder = []string{
+	"key", "summary", "description", "status", "assignee", "reporter", "created", "updated",
+	"priority", "issuetype", "labels", "relationships", "externalRefs", "codeRefs", "time_tracking",
+	"worklogs", "custom_fields",
+}
+
+// ParseFieldOrder splits a comma-separated list of top-level field names
+// "*" marks where the fields that are not listed go, in their default order; without it they
+// follow the listed fields. An empty list keeps the default order and returns nil.
+func ParseFieldOrder(value string) ([]string, error) {
+	var order []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		field := strings.TrimSpace(part)
+		if field == "" {
+			continue
+		}
+		if strings.Contains(field, ".") {
+			return nil, fmt.Errorf("field order only applies to top-level fields, got %q", field)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("field %q is listed more than once", field)
+		}
+		seen[field] = true
+		order = append(order, field)
+	}
+	return order, nil
+}
+
+// OrderFields reorders the top-level keys of a YAML mapping (or document) per order
+// Listed keys that are absent are skipped; keys that are not listed keep their relative order
+// at the "*" position, or after the listed keys. Values and comments move with their keys.
+func OrderFields(node *yaml.Node, order []string) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode || len(order) == 0 {
+		return
+	}
+
+	values := make(map[string][]*yaml.Node, len(node.Content)/2)
+	listed := make(map[string]bool, len(order))
+	for _, field := range order {
+		listed[field] = true
+	}
+	var rest []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		pair := node.Content[i : i+2 : i+2]
+		if listed[key] && values[key] == nil {
+			values[key] = pair
+		} else {
+			rest = append(rest, pair...)
+		}
+	}
+
+	ordered := make([]*yaml.Node, 0, len(node.Content))
+	restPlaced := false
+	for _, field := range order {
+		if field == FieldOrderRest {
+			ordered = append(ordered, rest...)
+			restPlaced = true
+			continue
+		}
+		ordered = append(ordered, values[field]...)
+	}
+	if !restPlaced {
+		ordered = append(ordered, rest...)
+	}
+	node.Content = ordered
+}
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseFieldOrder(t *testing.T) {
+	order, err := ParseFieldOrder(" key, summary ,status,,*,custom_fields")
+	if err != nil {
+		t.Fatalf("ParseFieldOrder() error = %v", err)
+	}
+	if want := []string{"key", "summary", "status", "*", "custom_fields"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("ParseFieldOrder() = %v, want %v", order, want)
+	}
+
+	if order, err := ParseFieldOrder(""); err != nil || order != nil {
+		t.Errorf("Expected an empty order to keep the default, got %v, %v", order, err)
+	}
+	for _, invalid := range []string{"key,summary,key", "*,key,*", "status.name"} {
+		if _, err := ParseFieldOrder(invalid); err == nil {
+			t.Errorf("ParseFieldOrder(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestDefaultFieldOrder_MatchesIssue(t *testing.T) {
+	issue := &client.Issue{
+		Key: "PROJ-1", Labels: []string{"a"}, Relationships: &client.Relationships{EpicLink: "PROJ-2"},
+		ExternalRefs: []client.ExternalRef{{}}, CodeRefs: []client.CodeRef{{SHA: "abc"}},
+		TimeTracking: &client.TimeTracking{TimeSpentSeconds: 60}, Worklogs: []client.Worklog{{ID: "1"}},
+		CustomFields: map[string]interface{}{"customfield_1": "x"},
+	}
+	var node yaml.Node
+	if err := node.Encode(issue); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var keys []string
+	for i := 0; i < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	if !reflect.DeepEqual(keys, DefaultFieldOrder) {
+		t.Errorf("DefaultFieldOrder = %v, issues are written as %v", DefaultFieldOrder, keys)
+	}
+}
+
+func TestYAMLFileWriter_FieldOrder(t *testing.T) {
+	issue := &client.Issue{
+		Key:          "PROJ-1",
+		Summary:      "Ordered",
+		Description:  "Body",
+		Status:       client.Status{Name: "Open"},
+		Priority:     "High",
+		CustomFields: map[string]interface{}{"customfield_1": "x"},
+	}
+
+	topLevel := func(order []string) []string {
+		t.Helper()
+		data, err := (&YAMLFileWriter{FieldOrder: order}).RenderIssue(issue)
+		if err != nil {
+			t.Fatalf("RenderIssue() error = %v", err)
+		}
+		var keys []string
+		for _, line := range strings.Split(string(data), "\n") {
+			if line != "" && !strings.HasPrefix(line, " ") {
+				keys = append(keys, strings.SplitN(line, ":", 2)[0])
+			}
+		}
+		return keys
+	}
+
+	got := topLevel([]string{"key", "status", "priority", "*", "description"})
+	want := []string{"key", "status", "priority", "summary", "assignee", "reporter", "created", "updated", "issuetype", "custom_fields", "description"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Ordered fields = %v, want %v", got, want)
+	}
+
+	// Without "*" the unlisted fields follow; listed fields the issue lacks are skipped
+	got = topLevel([]string{"summary", "labels", "key"})
+	if !reflect.DeepEqual(got[:3], []string{"summary", "key", "description"}) {
+		t.Errorf("Ordered fields = %v, want summary, key, then the rest in default order", got)
+	}
+
+	// The default order renders the same bytes as an unordered writer
+	plain, _ := (&YAMLFileWriter{}).RenderIssue(issue)
+	ordered, _ := (&YAMLFileWriter{FieldOrder: DefaultFieldOrder}).RenderIssue(issue)
+	if string(plain) != string(ordered) {
+		t.Errorf("Expected DefaultFieldOrder to match the default output:\n%s\nvs\n%s", plain, ordered)
+	}
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// TimestampMode controls how issue timestamps are written
+type TimestampMode string
+
+const (
+	// TimestampPreserve writes timestamps as JIRA returned them (the default)
+	TimestampPreserve TimestampMode = "preserve"
+	// TimestampUTC writes timestamps in UTC with millisecond precision (2024-03-15T07:30:00.000Z)
+	TimestampUTC TimestampMode = "utc"
+)
+
+// timestampLayouts are the timestamp formats JIRA returns, by server version and field
+var timestampLayouts = []string{
+	jiraDateTimeLayout,
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339Nano,
+}
+
+// ParseTimestampMode validates a --normalize-timestamps value; empty preserves JIRA's format
+func ParseTimestampMode(value string) (TimestampMode, error) {
+	switch mode := TimestampMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return TimestampPreserve, nil
+	case TimestampPreserve, TimestampUTC:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported timestamp mode %q (expected preserve or utc)", value)
+	}
+}
+
+// NormalizeTimestamp formats a JIRA timestamp per mode
+// Values that are not timestamps, including plain dates, are returned unchanged.
+func NormalizeTimestamp(value string, mode TimestampMode) string {
+	if mode != TimestampUTC {
+		return value
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC().Format(fileDateTimeLayout)
+		}
+	}
+	return value
+}
+
+// NormalizeIssueTimestamps normalizes the created and updated times and the timestamp custom
+// field values of an issue per mode, returning a copy when anything changes so the issue is
+// never modified
+func NormalizeIssueTimestamps(issue *client.Issue, mode TimestampMode) *client.Issue {
+	if mode != TimestampUTC {
+		return issue
+	}
+
+	converted := *issue
+	converted.Created = NormalizeTimestamp(issue.Created, mode)
+	converted.Updated = NormalizeTimestamp(issue.Updated, mode)
+	if len(issue.CustomFields) > 0 {
+		converted.CustomFields = make(map[string]interface{}, len(issue.CustomFields))
+		for id, value := range issue.CustomFields {
+			converted.CustomFields[id] = normalizeTimestampValue(value, mode)
+		}
+	}
+	return &converted
+}
+
+// normalizeTimestampValue normalizes a custom field value that is a timestamp or a list of them
+func normalizeTimestampValue(value interface{}, mode TimestampMode) interface{} {
+	switch v := value.(type) {
+	case string:
+		return NormalizeTimestamp(v, mode)
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeTimestampValue(item, mode)
+		}
+		return normalized
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func TestNormalizeTimestamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "offset with milliseconds", value: "2024-03-15T09:30:00.000+0200", want: "2024-03-15T07:30:00.000Z"},
+		{name: "offset without milliseconds", value: "2024-03-15T02:30:00-0500", want: "2024-03-15T07:30:00.000Z"},
+		{name: "RFC 3339 with sub-millisecond precision", value: "2024-03-15T07:30:00.123456Z", want: "2024-03-15T07:30:00.123Z"},
+		{name: "already canonical", value: "2024-03-15T07:30:00.000Z", want: "2024-03-15T07:30:00.000Z"},
+		{name: "plain date", value: "2024-03-15", want: "2024-03-15"},
+		{name: "not a timestamp", value: "Backend", want: "Backend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTimestamp(tt.value, TimestampUTC); got != tt.want {
+				t.Errorf("NormalizeTimestamp(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+			if got := NormalizeTimestamp(tt.value, TimestampPreserve); got != tt.value {
+				t.Errorf("Expected preserve to keep %q, got %q", tt.value, got)
+			}
+		})
+	}
+}
+
+func TestParseTimestampMode(t *testing.T) {
+	for value, want := range map[string]TimestampMode{"": TimestampPreserve, "preserve": TimestampPreserve, " UTC ": TimestampUTC} {
+		if got, err := ParseTimestampMode(value); err != nil || got != want {
+			t.Errorf("ParseTimestampMode(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseTimestampMode("local"); err == nil {
+		t.Error("Expected an unsupported mode to be rejected")
+	}
+}
+
+func TestYAMLFileWriter_RenderIssue_NormalizeTimestamps(t *testing.T) {
+	issue := &client.Issue{
+		Key:     "PROJ-1",
+		Created: "2024-03-15T09:30:00.000+0200",
+		Updated: "2024-03-16T10:00:00.000-0700",
+		CustomFields: map[string]interface{}{
+			"customfield_10050": "2024-03-17T08:00:00.000+0100",
+		},
+	}
+
+	data, err := (&YAMLFileWriter{Timestamps: TimestampUTC}).RenderIssue(issue)
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	for _, want := range []string{"created: \"2024-03-15T07:30:00.000Z\"", "updated: \"2024-03-16T17:00:00.000Z\"", "customfield_10050: \"2024-03-17T07:00:00.000Z\""} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %s, got:\n%s", want, data)
+		}
+	}
+	if issue.Created != "2024-03-15T09:30:00.000+0200" {
+		t.Error("Normalizing must not modify the issue")
+	}
+
+	preserved, err := (&YAMLFileWriter{}).RenderIssue(issue)
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	if !strings.Contains(string(preserved), "2024-03-15T09:30:00.000+0200") {
+		t.Errorf("Expected JIRA's format by default, got:\n%s", preserved)
+	}
+}
@@ -0,0 +1,221 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTransformTimeout bounds how long an external transform may run for one issue
+const DefaultTransformTimeout = 30 * time.Second
+
+// templateExtensions select the Go template transform; any other path is run as a program
+var templateExtensions = map[string]bool{".tmpl": true, ".tpl": true, ".gotmpl": true}
+
+// IssueTransform rewrites an issue's JSON data before it is written
+// The input is the issue as a JSON object; the output must be a JSON object as well.
+type IssueTransform interface {
+	Transform(issueJSON []byte) ([]byte, error)
+}
+
+// NewIssueTransform loads a transform from a path
+// Files ending in .tmpl, .tpl or .gotmpl are Go templates executed with the issue data;
+// anything else is an executable that reads the issue JSON on stdin and writes JSON to stdout.
+func NewIssueTransform(path string) (IssueTransform, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, &SchemaError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("transform %s not found", path),
+			Err:     err,
+		}
+	}
+	if info.IsDir() {
+		return nil, &SchemaError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("transform %s is a directory", path),
+		}
+	}
+
+	if templateExtensions[strings.ToLower(filepath.Ext(path))] {
+		tmpl, err := template.New(filepath.Base(path)).Funcs(transformTemplateFuncs).Option("missingkey=zero").ParseFiles(path)
+		if err != nil {
+			return nil, &SchemaError{
+				Type:    "invalid_input",
+				Message: fmt.Sprintf("invalid transform template %s", path),
+				Err:     err,
+			}
+		}
+		return &TemplateTransform{template: tmpl}, nil
+	}
+
+	if info.Mode()&0111 == 0 {
+		return nil, &SchemaError{
+			Type:    "invalid_input",
+			Message: fmt.Sprintf("transform %s is not executable (use a .tmpl file for Go templates)", path),
+		}
+	}
+	return &CommandTransform{Path: path, Timeout: DefaultTransformTimeout}, nil
+}
+
+// CommandTransform pipes issue JSON through an external program
+type CommandTransform struct {
+	Path    string
+	Timeout time.Duration
+}
+
+// Transform runs the program with the issue JSON on stdin and returns its stdout
+func (t *CommandTransform) Transform(issueJSON []byte) ([]byte, error) {
+	timeout := t.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTransformTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, t.Path) // #nosec G204 -- the transform path is user-supplied by design
+	cmd.Stdin = bytes.NewReader(issueJSON)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if ctx.Err() == context.DeadlineExceeded {
+			message = fmt.Sprintf("timed out after %s", timeout)
+		}
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("transform %s failed: %s", t.Path, message)
+	}
+	return stdout.Bytes(), nil
+}
+
+// TemplateTransform renders issue data through a Go template that produces JSON
+type TemplateTransform struct {
+	template *template.Template
+}
+
+// transformTemplateFuncs are available to transform templates
+var transformTemplateFuncs = template.FuncMap{
+	// toJSON encodes a value as JSON, so templates can emit strings and objects safely
+	"toJSON": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"trim":     strings.TrimSpace,
+	"contains": strings.Contains,
+	"join": func(separator string, values []interface{}) string {
+		parts := make([]string, len(values))
+		for i, value := range values {
+			parts[i] = fmt.Sprint(value)
+		}
+		return strings.Join(parts, separator)
+	},
+}
+
+// Transform executes the template with the issue data (fields addressed by JSON name, e.g. .summary)
+func (t *TemplateTransform) Transform(issueJSON []byte) ([]byte, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(issueJSON, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode issue for template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := t.template.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("transform template failed: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// applyTransform runs the transform for an issue and validates its output
+// The output must be a JSON object that still decodes as an issue with the same key.
+// Known fields are written in the usual order; additional top-level fields (derived data)
+// follow in sorted order. Returns a YAML mapping node ready for filtering and marshaling.
+func applyTransform(transform IssueTransform, issue *client.Issue) (*yaml.Node, error) {
+	input, err := json.Marshal(issue)
+	if err != nil {
+		return nil, transformError(issue.Key, "failed to encode issue", err)
+	}
+
+	output, err := transform.Transform(input)
+	if err != nil {
+		return nil, transformError(issue.Key, "transform failed", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(output, &fields); err != nil {
+		return nil, transformError(issue.Key, "transform output is not a JSON object", err)
+	}
+
+	var transformed client.Issue
+	if err := json.Unmarshal(output, &transformed); err != nil {
+		return nil, transformError(issue.Key, "transform output does not match the issue schema", err)
+	}
+	if transformed.Key != issue.Key {
+		return nil, transformError(issue.Key,
+			fmt.Sprintf("transform changed the issue key to %q; keys cannot be rewritten", transformed.Key), nil)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(&transformed); err != nil {
+		return nil, transformError(issue.Key, "failed to encode transformed issue", err)
+	}
+
+	known := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		known[node.Content[i].Value] = true
+	}
+	// Fields omitted from the YAML output (e.g. empty relationships) are still known fields
+	known["relationships"] = true
+	known["externalRefs"] = true
+
+	extras := make([]string, 0)
+	for name := range fields {
+		if !known[name] {
+			extras = append(extras, name)
+		}
+	}
+	sort.Strings(extras)
+
+	for _, name := range extras {
+		var value interface{}
+		if err := json.Unmarshal(fields[name], &value); err != nil {
+			return nil, transformError(issue.Key, fmt.Sprintf("invalid value for derived field %q", name), err)
+		}
+		var valueNode yaml.Node
+		if err := valueNode.Encode(value); err != nil {
+			return nil, transformError(issue.Key, fmt.Sprintf("failed to encode derived field %q", name), err)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, &valueNode)
+	}
+
+	return &node, nil
+}
+
+// transformError builds the error reported when an issue cannot be transformed
+func transformError(issueKey, message string, err error) error {
+	return &SchemaError{
+		Type:    "transform_error",
+		Message: fmt.Sprintf("issue %s: %s", issueKey, message),
+		Err:     err,
+	}
+}
@@ -0,0 +1,170 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+func writeTransformFile(t *testing.T, name, content string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatalf("failed to write transform: %v", err)
+	}
+	return path
+}
+
+func TestNewIssueTransform_Kinds(t *testing.T) {
+	if transform, err := NewIssueTransform(""); err != nil || transform != nil {
+		t.Errorf("empty path should disable transforms, got %v, %v", transform, err)
+	}
+
+	template := writeTransformFile(t, "t.tmpl", `{"key": {{ toJSON .key }}}`, 0644)
+	if transform, err := NewIssueTransform(template); err != nil {
+		t.Errorf("template error = %v", err)
+	} else if _, ok := transform.(*TemplateTransform); !ok {
+		t.Errorf("expected TemplateTransform, got %T", transform)
+	}
+
+	script := writeTransformFile(t, "t.sh", "#!/bin/sh\ncat\n", 0755)
+	if transform, err := NewIssueTransform(script); err != nil {
+		t.Errorf("script error = %v", err)
+	} else if _, ok := transform.(*CommandTransform); !ok {
+		t.Errorf("expected CommandTransform, got %T", transform)
+	}
+
+	notExecutable := writeTransformFile(t, "t.py", "print('{}')\n", 0644)
+	if _, err := NewIssueTransform(notExecutable); !IsInvalidInputError(err) {
+		t.Errorf("non-executable script should be rejected, got %v", err)
+	}
+	if _, err := NewIssueTransform(filepath.Join(t.TempDir(), "missing")); !IsInvalidInputError(err) {
+		t.Errorf("missing transform should be rejected, got %v", err)
+	}
+	badTemplate := writeTransformFile(t, "bad.tmpl", `{{ .key `, 0644)
+	if _, err := NewIssueTransform(badTemplate); !IsInvalidInputError(err) {
+		t.Errorf("unparseable template should be rejected, got %v", err)
+	}
+}
+
+func TestYAMLFileWriter_TemplateTransform(t *testing.T) {
+	template := writeTransformFile(t, "enrich.tmpl", `{
+  "key": {{ toJSON .key }},
+  "summary": {{ toJSON (upper .summary) }},
+  "status": {{ toJSON .status }},
+  "priority": {{ toJSON .priority }},
+  "team": "platform"
+}`, 0644)
+	transform, err := NewIssueTransform(template)
+	if err != nil {
+		t.Fatalf("NewIssueTransform() error = %v", err)
+	}
+
+	writer := &YAMLFileWriter{Transform: transform}
+	data, err := writer.RenderIssue(fieldFilterTestIssue())
+	if err != nil {
+		t.Fatalf("RenderIssue() error = %v", err)
+	}
+	output := string(data)
+
+	for _, want := range []string{"summary: FILTER ME", "name: Open", "priority: High", "team: platform"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Long description") {
+		t.Errorf("fields dropped by the transform should not be written:\n%s", output)
+	}
+	if strings.Index(output, "key:") > strings.Index(output, "team:") {
+		t.Errorf("derived fields should follow the standard fields:\n%s", output)
+	}
+}
+
+func TestYAMLFileWriter_CommandTransformWithFilter(t *testing.T) {
+	script := writeTransformFile(t, "tag.sh", "#!/bin/sh\nsed 's/^{/{\"labels\":[\"synced\"],/'\n", 0755)
+	transform, err := NewIssueTransform(script)
+	if err != nil {
+		t.Fatalf("NewIssueTransform() error = %v", err)
+	}
+	filter, err := NewFieldFilter(nil, []string{"description"})
+	if err != nil {
+		t.Fatalf("NewFieldFilter() error = %v", err)
+	}
+
+	tempDir := t.TempDir()
+	writer := &YAMLFileWriter{Fields: filter, Transform: transform}
+	filePath, err := writer.WriteIssueToYAML(fieldFilterTestIssue(), tempDir)
+	if err != nil {
+		t.Fatalf("WriteIssueToYAML() error = %v", err)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+	output := string(data)
+
+	if !strings.Contains(output, "labels:\n    - synced") {
+		t.Errorf("expected derived labels in output:\n%s", output)
+	}
+	if strings.Contains(output, "description:") {
+		t.Errorf("field filter should still apply after the transform:\n%s", output)
+	}
+}
+
+func TestYAMLFileWriter_TransformValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		script string
+		want   string
+	}{
+		{"not json", "garbage.tmpl", `not json`, "not a JSON object"},
+		{"json array", "array.tmpl", `[1, 2]`, "not a JSON object"},
+		{"wrong type", "type.tmpl", `{"key": {{ toJSON .key }}, "summary": 42}`, "does not match the issue schema"},
+		{"key changed", "key.tmpl", `{"key": "OTHER-1"}`, "changed the issue key"},
+		{"script fails", "fail.sh", "#!/bin/sh\necho boom >&2\nexit 1\n", "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode := os.FileMode(0644)
+			if strings.HasSuffix(tt.file, ".sh") {
+				mode = 0755
+			}
+			transform, err := NewIssueTransform(writeTransformFile(t, tt.file, tt.script, mode))
+			if err != nil {
+				t.Fatalf("NewIssueTransform() error = %v", err)
+			}
+
+			tempDir := t.TempDir()
+			writer := &YAMLFileWriter{Transform: transform}
+			_, err = writer.WriteIssueToYAML(&client.Issue{Key: "PROJ-1", Summary: "Original"}, tempDir)
+			if !IsTransformError(err) {
+				t.Fatalf("expected transform error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) && !strings.Contains(errorChain(err), tt.want) {
+				t.Errorf("expected error mentioning %q, got %v", tt.want, errorChain(err))
+			}
+			if _, statErr := os.Stat(writer.GetIssueFilePath(tempDir, "PROJ", "PROJ-1")); !os.IsNotExist(statErr) {
+				t.Error("no file should be written when the transform fails")
+			}
+		})
+	}
+}
+
+// errorChain joins an error and its wrapped causes for matching
+func errorChain(err error) string {
+	var parts []string
+	for err != nil {
+		parts = append(parts, err.Error())
+		unwrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		err = unwrapper.Unwrap()
+	}
+	return strings.Join(parts, ": ")
+}
@@ -18,14 +18,48 @@ type FileWriter interface {
 	GetIssueFilePath(basePath, projectKey, issueKey string) string
 }
 
+// IssueRenderer is implemented by writers that can render an issue's file content without
+// writing it, which lets dry runs compare planned content against the files on disk
+type IssueRenderer interface {
+	RenderIssue(issue *client.Issue) ([]byte, error)
+}
+
 // YAMLFileWriter implements FileWriter for YAML file operations
-type YAMLFileWriter struct{}
+type YAMLFileWriter struct {
+	// Fields restricts which fields are written (nil writes every field)
+	Fields *FieldFilter
+	// Transform post-processes issue data before it is filtered and written (nil writes it unchanged)
+	Transform IssueTransform
+	// Merge updates existing files in place, replacing only changed values (see MergeYAML)
+	// Files that cannot be parsed are rewritten in full.
+	Merge bool
+	// ADFRender controls how ADF (JIRA Cloud rich text) descriptions are written (empty: Markdown)
+	ADFRender ADFRenderMode
+	// FieldSchemas renders custom field values by their JIRA field type (nil writes them raw)
+	FieldSchemas map[string]client.FieldSchema
+	// Flatten overrides how custom field objects are flattened, by field type (nil: built-in)
+	Flatten FlattenStrategy
+	// Timestamps controls how created, updated, and timestamp custom fields are written
+	// (empty: as JIRA returned them)
+	Timestamps TimestampMode
+	// CodeRefs lists the commits referencing each issue, by issue key (nil writes no codeRefs)
+	CodeRefs map[string][]client.CodeRef
+	// FieldOrder reorders the top-level fields of new files (nil: DefaultFieldOrder); see OrderFields
+	// Merge updates keep the order of the existing file.
+	FieldOrder []string
+}
 
 // NewYAMLFileWriter creates a new YAML file writer
 func NewYAMLFileWriter() FileWriter {
 	return &YAMLFileWriter{}
 }
 
+// NewFilteredYAMLFileWriter creates a YAML file writer that omits fields rejected by the filter
+// Filtering happens before the file is written, so state checksums only cover kept fields.
+func NewFilteredYAMLFileWriter(fields *FieldFilter) FileWriter {
+	return &YAMLFileWriter{Fields: fields}
+}
+
 // WriteIssueToYAML writes a JIRA issue to a YAML file in the correct directory structure
 // Directory structure: /projects/{project-key}/issues/{issue-key}.yaml
 // Based on SPIKE-001 recommendations and JCG-004 requirements
@@ -61,14 +95,14 @@ func (w *YAMLFileWriter) WriteIssueToYAML(issue *client.Issue, basePath string)
 	// Get file path
 	filePath := w.GetIssueFilePath(basePath, projectKey, issue.Key)
 
-	// Convert issue to YAML
-	yamlData, err := yaml.Marshal(issue)
+	// Convert issue to YAML, applying the transform and dropping filtered fields
+	var existing []byte
+	if w.Merge {
+		existing, _ = os.ReadFile(filePath) // nil for new files
+	}
+	yamlData, err := w.RenderIssueUpdate(issue, existing)
 	if err != nil {
-		return "", &SchemaError{
-			Type:    "serialization_error",
-			Message: "failed to marshal issue to YAML",
-			Err:     err,
-		}
+		return "", err
 	}
 
 	// Write YAML to file
@@ -83,6 +117,71 @@ func (w *YAMLFileWriter) WriteIssueToYAML(issue *client.Issue, basePath string)
 	return filePath, nil
 }
 
+// RenderIssue returns the YAML that WriteIssueToYAML would write for the issue
+func (w *YAMLFileWriter) RenderIssue(issue *client.Issue) ([]byte, error) {
+	data, err := w.marshal(issue)
+	if err != nil {
+		if IsTransformError(err) {
+			return nil, err
+		}
+		return nil, &SchemaError{
+			Type:    "serialization_error",
+			Message: fmt.Sprintf("failed to marshal issue %s to YAML", issue.Key),
+			Err:     err,
+		}
+	}
+	return data, nil
+}
+
+// marshal converts an issue to YAML, applying the transform and field filter when configured
+func (w *YAMLFileWriter) marshal(issue *client.Issue) ([]byte, error) {
+	issue = RenderIssueValues(issue, w.ADFRender, w.FieldSchemas, w.Flatten)
+	issue = NormalizeIssueTimestamps(issue, w.Timestamps)
+	issue = AttachCodeRefs(issue, w.CodeRefs)
+
+	if w.Fields == nil && w.Transform == nil && len(w.FieldOrder) == 0 {
+		return yaml.Marshal(issue)
+	}
+
+	node := &yaml.Node{}
+	if w.Transform != nil {
+		transformed, err := applyTransform(w.Transform, issue)
+		if err != nil {
+			return nil, err
+		}
+		node = transformed
+	} else if err := node.Encode(issue); err != nil {
+		return nil, err
+	}
+
+	if w.Fields != nil {
+		w.Fields.Apply(node)
+	}
+	OrderFields(node, w.FieldOrder)
+	return yaml.Marshal(node)
+}
+
+// RenderIssueValues renders an ADF description per mode (empty: Markdown) and custom fields by
+// their schemas (nil: raw) and flatten strategy, returning a copy when anything changes so the
+// issue is never modified. Rendering happens before the file is written, so checksums and diffs
+// only see flattened values.
+func RenderIssueValues(issue *client.Issue, adfRender ADFRenderMode, fieldSchemas map[string]client.FieldSchema, flatten FlattenStrategy) *client.Issue {
+	if adfRender == "" {
+		adfRender = ADFRenderMarkdown
+	}
+	if rendered := RenderADF(issue.Description, adfRender); rendered != issue.Description {
+		converted := *issue
+		converted.Description = rendered
+		issue = &converted
+	}
+	if (fieldSchemas != nil || flatten != nil) && len(issue.CustomFields) > 0 {
+		converted := *issue
+		converted.CustomFields = RenderCustomFieldsWithStrategy(issue.CustomFields, fieldSchemas, flatten)
+		issue = &converted
+	}
+	return issue
+}
+
 // CreateDirectoryStructure creates the required directory structure
 // Pattern: /projects/{project-key}/issues/
 func (w *YAMLFileWriter) CreateDirectoryStructure(basePath, projectKey string) error {
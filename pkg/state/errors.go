@@ -0,0 +1,50 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+)
+
+// State error types
+const (
+	ErrorTypeConflict = "state_conflict"
+	ErrorTypeLocked   = "state_locked"
+	ErrorTypeVersion  = "state_version"
+)
+
+// StateError represents errors that occur while reading or writing sync state
+type StateError struct {
+	Type    string // Type of error (state_conflict, state_locked, state_version)
+	Message string // Human-readable error message
+	Err     error  // Underlying error
+	Context string // Additional context (state file path)
+}
+
+func (e *StateError) Error() string {
+	if e.Context != "" {
+		return fmt.Sprintf("state error (%s) for %s: %s", e.Type, e.Context, e.Message)
+	}
+	return fmt.Sprintf("state error (%s): %s", e.Type, e.Message)
+}
+
+func (e *StateError) Unwrap() error {
+	return e.Err
+}
+
+// IsConflictError checks if the error is caused by another writer saving the state first
+func IsConflictError(err error) bool {
+	var stateErr *StateError
+	return errors.As(err, &stateErr) && stateErr.Type == ErrorTypeConflict
+}
+
+// IsLockedError checks if the error is caused by another writer holding the state lock
+func IsLockedError(err error) bool {
+	var stateErr *StateError
+	return errors.As(err, &stateErr) && stateErr.Type == ErrorTypeLocked
+}
+
+// IsRetryableError checks if a state write failed only because of a concurrent writer
+// Reloading the state and re-running the sync resolves these errors.
+func IsRetryableError(err error) bool {
+	return IsConflictError(err) || IsLockedError(err)
+}
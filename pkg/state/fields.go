@@ -0,0 +1,161 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+)
+
+// TrackableFields lists the issue fields whose last-synced values can be kept in state
+var TrackableFields = []string{
+	"summary", "description", "status", "priority", "issuetype",
+	"assignee", "reporter", "epic_link", "parent",
+}
+
+// FieldState is the last-synced value of a tracked field
+// Only a short hash of the value is stored, so tracking long fields keeps the state file small.
+type FieldState struct {
+	Hash        string    `json:"hash" yaml:"hash"`
+	LastChanged time.Time `json:"last_changed" yaml:"last_changed"` // first sync that saw this value
+}
+
+// ParseTrackedFields validates field names for tracking, returning them lowercased and sorted
+func ParseTrackedFields(fields []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var parsed []string
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			continue
+		}
+		if !isTrackableField(field) {
+			return nil, fmt.Errorf("field %q cannot be tracked (supported: %s)", field, strings.Join(TrackableFields, ", "))
+		}
+		seen[field] = true
+		parsed = append(parsed, field)
+	}
+	sort.Strings(parsed)
+	return parsed, nil
+}
+
+// SetTrackedFields enables per-field tracking: each synced issue records the given fields
+// Tracking is off by default. Fields that are no longer tracked are dropped from an issue's
+// record the next time it is synced.
+func (m *FileStateManager) SetTrackedFields(fields []string) error {
+	parsed, err := ParseTrackedFields(fields)
+	if err != nil {
+		return err
+	}
+	m.trackedFields = parsed
+	return nil
+}
+
+// TrackedFields returns the fields recorded for each synced issue
+func (m *FileStateManager) TrackedFields() []string {
+	return m.trackedFields
+}
+
+// IssueFieldValue returns the value of a trackable field of an issue
+func IssueFieldValue(issue *client.Issue, field string) (string, bool) {
+	switch field {
+	case "summary":
+		return issue.Summary, true
+	case "description":
+		return issue.Description, true
+	case "status":
+		return issue.Status.Name, true
+	case "priority":
+		return issue.Priority, true
+	case "issuetype":
+		return issue.IssueType, true
+	case "assignee":
+		return userIdentity(issue.Assignee), true
+	case "reporter":
+		return userIdentity(issue.Reporter), true
+	case "epic_link":
+		if issue.Relationships == nil {
+			return "", true
+		}
+		return issue.Relationships.EpicLink, true
+	case "parent":
+		if issue.Relationships == nil {
+			return "", true
+		}
+		return issue.Relationships.ParentIssue, true
+	default:
+		return "", false
+	}
+}
+
+// ChangedFields returns the tracked fields whose value differs from the issue's last sync
+// A field without a recorded value (a new issue, or one last synced before the field was
+// tracked) counts as changed.
+func ChangedFields(issueState *IssueState, issue *client.Issue, fields []string) []string {
+	var changed []string
+	for _, field := range fields {
+		value, ok := IssueFieldValue(issue, field)
+		if !ok {
+			continue
+		}
+		if issueState == nil {
+			changed = append(changed, field)
+			continue
+		}
+		recorded, exists := issueState.Fields[field]
+		if !exists || recorded.Hash != hashFieldValue(value) {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
+
+// recordTrackedFields returns the field records of an issue after a sync at now
+// Unchanged values keep their LastChanged time.
+func recordTrackedFields(previous map[string]FieldState, issue *client.Issue, fields []string, now time.Time) map[string]FieldState {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	records := make(map[string]FieldState, len(fields))
+	for _, field := range fields {
+		value, ok := IssueFieldValue(issue, field)
+		if !ok {
+			continue
+		}
+		hash := hashFieldValue(value)
+		if recorded, exists := previous[field]; exists && recorded.Hash == hash {
+			records[field] = recorded
+			continue
+		}
+		records[field] = FieldState{Hash: hash, LastChanged: now}
+	}
+	return records
+}
+
+// hashFieldValue hashes a field value; 64 bits are plenty to detect a change of one field
+func hashFieldValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// userIdentity identifies a user by account ID when resolved, else by name
+func userIdentity(user client.User) string {
+	if user.AccountID != "" {
+		return user.AccountID
+	}
+	return user.Name
+}
+
+func isTrackableField(field string) bool {
+	for _, trackable := range TrackableFields {
+		if field == trackable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrackedFields(t *testing.T) {
+	fields, err := ParseTrackedFields([]string{" Status", "assignee", "status", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"assignee", "status"}, fields)
+
+	_, err = ParseTrackedFields([]string{"story_points"})
+	assert.Error(t, err)
+}
+
+func TestFileStateManager_TrackedFields(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewFileStateManager(FormatYAML)
+	require.NoError(t, manager.SetTrackedFields([]string{"status", "assignee"}))
+
+	state, err := manager.InitializeState(tempDir, RepositoryInfo{Path: tempDir})
+	require.NoError(t, err)
+
+	filePath := filepath.Join(tempDir, "TEST-1.yaml")
+	require.NoError(t, os.WriteFile(filePath, []byte("key: TEST-1"), 0644))
+
+	issue := &client.Issue{
+		Key:         "TEST-1",
+		Summary:     "Not tracked",
+		Status:      client.Status{Name: "To Do"},
+		Assignee:    client.User{Name: "Jane"},
+		Description: "A long description that is not stored",
+	}
+	assert.Equal(t, []string{"status", "assignee"}, ChangedFields(nil, issue, []string{"status", "assignee"}))
+
+	require.NoError(t, manager.UpdateIssueState(state, issue, filePath))
+	issueState, _ := manager.GetIssueState(state, "TEST-1")
+	require.Len(t, issueState.Fields, 2, "only tracked fields are recorded")
+	firstSeen := issueState.Fields["assignee"].LastChanged
+
+	// Only the status changes
+	updated := *issue
+	updated.Status = client.Status{Name: "Done"}
+	updated.Summary = "Edited"
+	assert.Equal(t, []string{"status"}, ChangedFields(issueState, &updated, manager.TrackedFields()))
+
+	require.NoError(t, manager.UpdateIssueState(state, &updated, filePath))
+	issueState, _ = manager.GetIssueState(state, "TEST-1")
+	assert.Empty(t, ChangedFields(issueState, &updated, manager.TrackedFields()))
+	assert.Equal(t, firstSeen, issueState.Fields["assignee"].LastChanged, "unchanged fields keep their change time")
+	assert.False(t, issueState.Fields["status"].LastChanged.Before(firstSeen))
+
+	// Records survive a save and reload
+	require.NoError(t, manager.SaveState(tempDir, state))
+	reloaded, err := manager.LoadState(tempDir)
+	require.NoError(t, err)
+	for field, recorded := range issueState.Fields {
+		reloadedField := reloaded.Issues["TEST-1"].Fields[field]
+		assert.Equal(t, recorded.Hash, reloadedField.Hash)
+		assert.True(t, recorded.LastChanged.Equal(reloadedField.LastChanged))
+	}
+
+	// Without tracking, no field records are written
+	untracked := NewFileStateManager(FormatYAML)
+	require.NoError(t, untracked.UpdateIssueState(state, &updated, filePath))
+	assert.Nil(t, state.Issues["TEST-1"].Fields)
+}
+
+func TestFileStateManager_MigrateLegacyState(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewFileStateManager(FormatJSON)
+
+	legacy := `{"version": "v0.3.0", "issues": {"TEST-1": {"key": "TEST-1", "checksum": "abc"}}, "history": []}`
+	require.NoError(t, os.WriteFile(manager.getStateFilePath(tempDir), []byte(legacy), 0644))
+
+	state, err := manager.LoadState(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, StateFileVersion, state.Version)
+	assert.Equal(t, "abc", state.Issues["TEST-1"].Checksum)
+	assert.Nil(t, state.Issues["TEST-1"].Fields)
+
+	// Tracked fields of migrated issues count as changed until they are synced again
+	issue := &client.Issue{Key: "TEST-1", Status: client.Status{Name: "Done"}}
+	issueState := state.Issues["TEST-1"]
+	assert.Equal(t, []string{"status"}, ChangedFields(&issueState, issue, []string{"status"}))
+
+	// State files from a newer release are refused
+	newer := `{"version": "v9.0.0", "issues": {}, "history": []}`
+	require.NoError(t, os.WriteFile(manager.getStateFilePath(tempDir), []byte(newer), 0644))
+	_, err = manager.LoadState(tempDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "newer than the supported version")
+}
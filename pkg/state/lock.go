@@ -0,0 +1,62 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// DefaultLockTimeout is how long a writer waits for another writer to release the state lock
+	DefaultLockTimeout = 10 * time.Second
+	// DefaultStaleLockAge is the age after which a lock left by a crashed writer is broken
+	DefaultStaleLockAge = 10 * time.Minute
+
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+// stateLock is an exclusive lock on a state file, held as a sibling .lock file
+// The lock file is created with O_EXCL, which is atomic on local filesystems on every platform.
+type stateLock struct {
+	path string
+}
+
+// acquireStateLock waits up to timeout for the lock of a state file
+// Locks older than staleAge are assumed to belong to a crashed writer and are removed.
+func acquireStateLock(stateFilePath string, timeout, staleAge time.Duration) (*stateLock, error) {
+	lockPath := stateFilePath + ".lock"
+	deadline := time.Now().Add(timeout)
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			hostname, _ := os.Hostname()
+			_, _ = fmt.Fprintf(file, "pid=%d host=%s acquired=%s\n", os.Getpid(), hostname, time.Now().UTC().Format(time.RFC3339))
+			_ = file.Close()
+			return &stateLock{path: lockPath}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create state lock: %w", err)
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleAge {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, &StateError{
+				Type:    ErrorTypeLocked,
+				Message: fmt.Sprintf("another sync has held the state lock for over %s; retry once it finishes (remove %s if no sync is running)", timeout, lockPath),
+				Context: stateFilePath,
+			}
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// release removes the lock file
+func (l *stateLock) release() {
+	_ = os.Remove(l.path)
+}
@@ -0,0 +1,193 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStateManager_SaveStateConflict(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewFileStateManager(FormatYAML)
+
+	_, err := manager.InitializeState(tempDir, RepositoryInfo{Path: tempDir, Branch: "main"})
+	require.NoError(t, err)
+
+	first, err := manager.LoadState(tempDir)
+	require.NoError(t, err)
+	second, err := manager.LoadState(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Revision)
+
+	require.NoError(t, manager.SaveState(tempDir, first))
+	assert.Equal(t, int64(2), first.Revision)
+
+	// The second writer loaded revision 1 and must not overwrite revision 2
+	err = manager.SaveState(tempDir, second)
+	require.Error(t, err)
+	assert.True(t, IsConflictError(err))
+	assert.True(t, IsRetryableError(fmt.Errorf("sync completed but failed to save state: %w", err)))
+	assert.Equal(t, int64(1), second.Revision)
+
+	// Reloading resolves the conflict
+	reloaded, err := manager.LoadState(tempDir)
+	require.NoError(t, err)
+	require.NoError(t, manager.SaveState(tempDir, reloaded))
+	assert.Equal(t, int64(3), reloaded.Revision)
+}
+
+func TestFileStateManager_SaveStateLegacyFile(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewFileStateManager(FormatJSON)
+
+	// State files written before revisions existed have no revision field
+	legacy := `{"version": "v0.3.0", "issues": {}, "history": []}`
+	require.NoError(t, os.WriteFile(manager.getStateFilePath(tempDir), []byte(legacy), 0644))
+
+	loaded, err := manager.LoadState(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), loaded.Revision)
+	require.NoError(t, manager.SaveState(tempDir, loaded))
+	assert.Equal(t, int64(1), loaded.Revision)
+}
+
+func TestFileStateManager_StateLock(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewFileStateManager(FormatYAML)
+	manager.lockTimeout = 100 * time.Millisecond
+
+	state, err := manager.InitializeState(tempDir, RepositoryInfo{Path: tempDir})
+	require.NoError(t, err)
+
+	// A lock held by another writer times out with a retryable error
+	lockPath := manager.getStateFilePath(tempDir) + ".lock"
+	require.NoError(t, os.WriteFile(lockPath, []byte("pid=1"), 0644))
+	err = manager.SaveState(tempDir, state)
+	require.Error(t, err)
+	assert.True(t, IsLockedError(err))
+	assert.True(t, IsRetryableError(err))
+
+	// A stale lock left by a crashed writer is broken
+	old := time.Now().Add(-2 * DefaultStaleLockAge)
+	require.NoError(t, os.Chtimes(lockPath, old, old))
+	require.NoError(t, manager.SaveState(tempDir, state))
+	_, err = os.Stat(lockPath)
+	assert.True(t, os.IsNotExist(err), "lock is released after saving")
+}
+
+func TestFileStateManager_ConcurrentWriters(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewFileStateManager(FormatYAML)
+	_, err := manager.InitializeState(tempDir, RepositoryInfo{Path: tempDir})
+	require.NoError(t, err)
+
+	// Each writer records one issue, reloading and retrying on conflicts the way a re-run would
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("PROJ-%d", i+1)
+			for attempt := 0; attempt < 100; attempt++ {
+				state, err := manager.LoadState(tempDir)
+				if err != nil {
+					errs <- err
+					return
+				}
+				state.Issues[key] = IssueState{Key: key, FilePath: "projects/PROJ/issues/" + key + ".yaml"}
+				err = manager.SaveState(tempDir, state)
+				if err == nil {
+					return
+				}
+				if !IsRetryableError(err) {
+					errs <- err
+					return
+				}
+			}
+			errs <- fmt.Errorf("%s: gave up after repeated conflicts", key)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// No update was lost and the file is intact
+	final, err := manager.LoadState(tempDir)
+	require.NoError(t, err)
+	assert.Len(t, final.Issues, writers)
+	assert.Equal(t, int64(writers+1), final.Revision)
+}
+
+func TestFileStateManager_ConcurrentRepositories(t *testing.T) {
+	// One manager serves syncs of several repositories at once, as --projects and multi-destination
+	// profiles do; each repository's state lives in and is locked within that repository
+	manager := NewFileStateManager(FormatYAML)
+	repos := map[string]string{"ALPHA": t.TempDir(), "BETA": t.TempDir()}
+	const issuesPerRepo = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(repos))
+	for project, repoPath := range repos {
+		wg.Add(1)
+		go func(project, repoPath string) {
+			defer wg.Done()
+			if _, err := manager.InitializeState(repoPath, RepositoryInfo{Path: repoPath}); err != nil {
+				errs <- err
+				return
+			}
+			issueDir := filepath.Join(repoPath, "projects", project, "issues")
+			if err := os.MkdirAll(issueDir, 0755); err != nil {
+				errs <- err
+				return
+			}
+			for i := 1; i <= issuesPerRepo; i++ {
+				state, err := manager.LoadState(repoPath)
+				if err != nil {
+					errs <- err
+					return
+				}
+				key := fmt.Sprintf("%s-%d", project, i)
+				filePath := filepath.Join(issueDir, key+".yaml")
+				if err := os.WriteFile(filePath, []byte("key: "+key+"\n"), 0644); err != nil {
+					errs <- err
+					return
+				}
+				if err := manager.UpdateIssueState(state, &client.Issue{Key: key, Updated: "2024-03-15T10:00:00.000+0000"}, filePath); err != nil {
+					errs <- err
+					return
+				}
+				if err := manager.SaveState(repoPath, state); err != nil {
+					errs <- fmt.Errorf("%s: %w", project, err)
+					return
+				}
+			}
+		}(project, repoPath)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	for project, repoPath := range repos {
+		state, err := manager.LoadState(repoPath)
+		require.NoError(t, err)
+		assert.Equal(t, repoPath, state.Repository.Path)
+		assert.Len(t, state.Issues, issuesPerRepo, "%s state", project)
+		assert.Equal(t, int64(issuesPerRepo+1), state.Revision, "%s revision", project)
+		for key, issue := range state.Issues {
+			assert.Equal(t, project, issue.ProjectKey, "%s state holds %s", project, key)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -16,7 +17,7 @@ import (
 )
 
 const (
-	StateFileVersion  = "v0.3.0"
+	StateFileVersion  = "v0.4.0"
 	StateFileName     = ".jira-sync-state.yaml"
 	StateFileBackup   = ".jira-sync-state.backup.yaml"
 	MaxHistoryEntries = 50
@@ -57,8 +58,17 @@ type StateManager interface {
 }
 
 // FileStateManager implements StateManager using file-based storage
+// Saves are serialized with a lock file and use optimistic concurrency on SyncState.Revision,
+// so overlapping syncs against the same repository never interleave writes and a sync working
+// from outdated state fails with a retryable conflict instead of overwriting newer state.
+// State and its lock live inside each repository, so one manager can serve concurrent syncs to
+// different repositories without their states colliding.
 type FileStateManager struct {
-	format StateFileFormat
+	format       StateFileFormat
+	lockTimeout  time.Duration
+	staleLockAge time.Duration
+
+	trackedFields []string // issue fields recorded per issue, see SetTrackedFields
 }
 
 // StateFileFormat represents the file format for state storage
@@ -75,7 +85,9 @@ func NewFileStateManager(format StateFileFormat) *FileStateManager {
 		format = FormatYAML // Default to YAML
 	}
 	return &FileStateManager{
-		format: format,
+		format:       format,
+		lockTimeout:  DefaultLockTimeout,
+		staleLockAge: DefaultStaleLockAge,
 	}
 }
 
@@ -122,9 +134,9 @@ func (m *FileStateManager) LoadState(repoPath string) (*SyncState, error) {
 		}
 	}
 
-	// Validate state version
-	if state.Version == "" {
-		state.Version = StateFileVersion
+	// Upgrade states written by older versions
+	if err := migrateState(&state); err != nil {
+		return nil, err
 	}
 
 	// Initialize maps if nil
@@ -141,9 +153,26 @@ func (m *FileStateManager) SaveState(repoPath string, state *SyncState) error {
 		return fmt.Errorf("state cannot be nil")
 	}
 
+	stateFilePath := m.getStateFilePath(repoPath)
+	lock, err := acquireStateLock(stateFilePath, m.lockTimeout, m.staleLockAge)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	// Reject the save if another writer saved since this state was loaded
+	if current, exists := m.readRevision(stateFilePath); exists && current != state.Revision {
+		return &StateError{
+			Type:    ErrorTypeConflict,
+			Message: fmt.Sprintf("state was saved by another sync (revision %d, expected %d); reload the state and retry", current, state.Revision),
+			Context: stateFilePath,
+		}
+	}
+
 	// Update metadata
 	state.Version = StateFileVersion
 	state.UpdatedAt = time.Now()
+	state.Revision++
 
 	// Limit history size
 	if len(state.History) > MaxHistoryEntries {
@@ -153,21 +182,21 @@ func (m *FileStateManager) SaveState(repoPath string, state *SyncState) error {
 
 	// Marshal state to bytes
 	var data []byte
-	var err error
 	if m.format == FormatJSON {
 		data, err = json.MarshalIndent(state, "", "  ")
 	} else {
 		data, err = yaml.Marshal(state)
 	}
 	if err != nil {
+		state.Revision--
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
 
 	// Write to temp file first for atomic operation
-	stateFilePath := m.getStateFilePath(repoPath)
 	tempFilePath := stateFilePath + ".tmp"
 
 	if err := os.WriteFile(tempFilePath, data, 0644); err != nil {
+		state.Revision--
 		return fmt.Errorf("failed to write temp state file: %w", err)
 	}
 
@@ -175,12 +204,32 @@ func (m *FileStateManager) SaveState(repoPath string, state *SyncState) error {
 	if err := os.Rename(tempFilePath, stateFilePath); err != nil {
 		// Clean up temp file on failure
 		_ = os.Remove(tempFilePath)
+		state.Revision--
 		return fmt.Errorf("failed to rename temp state file: %w", err)
 	}
 
 	return nil
 }
 
+// readRevision returns the revision of the state file on disk and whether the file exists
+// Files written before revisions were introduced, and unreadable files, report revision 0.
+func (m *FileStateManager) readRevision(stateFilePath string) (int64, bool) {
+	data, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return 0, !os.IsNotExist(err)
+	}
+
+	var header struct {
+		Revision int64 `json:"revision" yaml:"revision"`
+	}
+	if m.format == FormatJSON {
+		_ = json.Unmarshal(data, &header)
+	} else {
+		_ = yaml.Unmarshal(data, &header)
+	}
+	return header.Revision, true
+}
+
 // InitializeState creates a new state for a repository
 func (m *FileStateManager) InitializeState(repoPath string, repoInfo RepositoryInfo) (*SyncState, error) {
 	now := time.Now()
@@ -246,6 +295,12 @@ func (m *FileStateManager) RestoreState(repoPath string) error {
 		return fmt.Errorf("backup file does not exist")
 	}
 
+	lock, err := acquireStateLock(stateFilePath, m.lockTimeout, m.staleLockAge)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
 	// Copy backup to state file
 	backupFile, err := os.Open(backupFilePath)
 	if err != nil {
@@ -266,10 +321,20 @@ func (m *FileStateManager) RestoreState(repoPath string) error {
 	return nil
 }
 
+// NewOperationID returns a unique ID for one sync run, e.g. sync-20240102T150405Z-1a2b3c
+// The timestamp keeps IDs sortable; the random suffix keeps runs started in the same second apart.
+func NewOperationID() string {
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Sprintf("sync-%s-%06x", time.Now().UTC().Format("20060102T150405Z"), time.Now().Nanosecond()&0xffffff)
+	}
+	return fmt.Sprintf("sync-%s-%s", time.Now().UTC().Format("20060102T150405Z"), hex.EncodeToString(suffix))
+}
+
 // StartSyncOperation creates and starts a new sync operation
 func (m *FileStateManager) StartSyncOperation(state *SyncState, syncType SyncType, config SyncConfig) *SyncOperation {
 	now := time.Now()
-	operationID := fmt.Sprintf("sync-%d", now.Unix())
+	operationID := NewOperationID()
 
 	operation := &SyncOperation{
 		ID:        operationID,
@@ -344,8 +409,10 @@ func (m *FileStateManager) UpdateIssueState(state *SyncState, issue *client.Issu
 	// Update or create issue state
 	existingState, exists := state.Issues[issue.Key]
 	syncCount := 1
+	var previousFields map[string]FieldState
 	if exists {
 		syncCount = existingState.SyncCount + 1
+		previousFields = existingState.Fields
 	}
 
 	issueState := IssueState{
@@ -360,6 +427,7 @@ func (m *FileStateManager) UpdateIssueState(state *SyncState, issue *client.Issu
 		Checksum:     checksum,
 		SyncStatus:   "success",
 		SyncCount:    syncCount,
+		Fields:       recordTrackedFields(previousFields, issue, m.trackedFields, now),
 	}
 
 	state.Issues[issue.Key] = issueState
@@ -647,6 +715,11 @@ func (m *FileStateManager) GetHistoryReport(state *SyncState, limit int) []SyncO
 
 // calculateFileChecksum calculates SHA256 checksum of a file
 func (m *FileStateManager) calculateFileChecksum(filePath string) (string, error) {
+	return CalculateFileChecksum(filePath)
+}
+
+// CalculateFileChecksum calculates the SHA256 checksum recorded in IssueState.Checksum
+func CalculateFileChecksum(filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", err
@@ -0,0 +1,75 @@
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// stateMigration upgrades a state loaded from an older state file version
+type stateMigration struct {
+	from    string
+	to      string
+	migrate func(state *SyncState)
+}
+
+// stateMigrations run in order on states older than StateFileVersion
+var stateMigrations = []stateMigration{
+	{
+		// v0.4.0 adds optional per-field records to issues. Older issues have none: tracked
+		// fields count as changed until the issue is next synced and its values are recorded.
+		from: "v0.3.0",
+		to:   "v0.4.0",
+		migrate: func(state *SyncState) {
+			for key, issue := range state.Issues {
+				issue.Fields = nil
+				state.Issues[key] = issue
+			}
+		},
+	},
+}
+
+// migrateState upgrades a loaded state to StateFileVersion
+// States without a version predate versioning and are treated as the oldest known version.
+// A state written by a newer release is rejected rather than silently losing its new fields.
+func migrateState(state *SyncState) error {
+	if state.Version == "" {
+		state.Version = stateMigrations[0].from
+	}
+	if compareStateVersions(state.Version, StateFileVersion) > 0 {
+		return &StateError{
+			Type:    ErrorTypeVersion,
+			Message: fmt.Sprintf("state file version %s is newer than the supported version %s; upgrade jira-sync", state.Version, StateFileVersion),
+		}
+	}
+
+	for _, migration := range stateMigrations {
+		if compareStateVersions(state.Version, migration.to) < 0 {
+			migration.migrate(state)
+			state.Version = migration.to
+		}
+	}
+	return nil
+}
+
+// compareStateVersions compares two vMAJOR.MINOR.PATCH versions; unparseable parts count as 0
+func compareStateVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < 3; i++ {
+		var x, y int
+		if i < len(partsA) {
+			x, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			y, _ = strconv.Atoi(partsB[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
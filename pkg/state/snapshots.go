@@ -0,0 +1,224 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RunSnapshotDir holds one issue snapshot per recorded sync run, next to the state file
+const RunSnapshotDir = ".jira-sync-runs"
+
+// SnapshotIssue is the part of an issue file a run snapshot records
+type SnapshotIssue struct {
+	Summary string `json:"summary" yaml:"summary"`
+	Status  string `json:"status" yaml:"status"`
+	Updated string `json:"updated,omitempty" yaml:"updated,omitempty"`
+}
+
+// RunSnapshot records the issues mirrored in a repository at the end of a sync run
+type RunSnapshot struct {
+	OperationID string                   `json:"operation_id" yaml:"operation_id"`
+	RecordedAt  time.Time                `json:"recorded_at" yaml:"recorded_at"`
+	IssueCount  int                      `json:"issue_count" yaml:"issue_count"`
+	Issues      map[string]SnapshotIssue `json:"issues" yaml:"issues"`
+}
+
+// snapshotIssueFile is the subset of an issue file read into a snapshot
+type snapshotIssueFile struct {
+	Key     string `yaml:"key"`
+	Summary string `yaml:"summary"`
+	Status  struct {
+		Name string `yaml:"name"`
+	} `yaml:"status"`
+	Updated string `yaml:"updated"`
+}
+
+// BuildRunSnapshot reads the issue files of every project into a snapshot for a sync run
+// The snapshot is built from the files on disk, so it reflects pruned and archived issues too.
+func BuildRunSnapshot(repoPath, operationID string) (*RunSnapshot, error) {
+	issueFiles, err := filepath.Glob(filepath.Join(repoPath, "projects", "*", "issues", "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue files: %w", err)
+	}
+
+	snapshot := &RunSnapshot{
+		OperationID: operationID,
+		RecordedAt:  time.Now(),
+		Issues:      make(map[string]SnapshotIssue, len(issueFiles)),
+	}
+	for _, issueFile := range issueFiles {
+		data, err := os.ReadFile(issueFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", issueFile, err)
+		}
+		var issue snapshotIssueFile
+		if err := yaml.Unmarshal(data, &issue); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", issueFile, err)
+		}
+		if issue.Key == "" {
+			issue.Key = strings.TrimSuffix(filepath.Base(issueFile), ".yaml")
+		}
+		snapshot.Issues[issue.Key] = SnapshotIssue{Summary: issue.Summary, Status: issue.Status.Name, Updated: issue.Updated}
+	}
+	snapshot.IssueCount = len(snapshot.Issues)
+	return snapshot, nil
+}
+
+// runSnapshotPath returns the file of a run's snapshot
+// Operation IDs can be supplied by callers, so IDs that would escape RunSnapshotDir are rejected.
+func runSnapshotPath(repoPath, operationID string) (string, error) {
+	if operationID == "" || operationID != filepath.Base(operationID) || strings.HasPrefix(operationID, ".") {
+		return "", fmt.Errorf("invalid run ID %q", operationID)
+	}
+	return filepath.Join(repoPath, RunSnapshotDir, operationID+".yaml"), nil
+}
+
+// SaveRunSnapshot writes a run snapshot below RunSnapshotDir
+func SaveRunSnapshot(repoPath string, snapshot *RunSnapshot) error {
+	if snapshot == nil {
+		return fmt.Errorf("run snapshot cannot be nil")
+	}
+	snapshotPath, err := runSnapshotPath(repoPath, snapshot.OperationID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(repoPath, RunSnapshotDir), 0755); err != nil {
+		return fmt.Errorf("failed to create run snapshot directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal run snapshot: %w", err)
+	}
+	tempPath := snapshotPath + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write run snapshot: %w", err)
+	}
+	if err := os.Rename(tempPath, snapshotPath); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename run snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadRunSnapshot reads the snapshot recorded for a sync run
+func LoadRunSnapshot(repoPath, operationID string) (*RunSnapshot, error) {
+	snapshotPath, err := runSnapshotPath(repoPath, operationID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(snapshotPath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no snapshot recorded for run %s", operationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot of run %s: %w", operationID, err)
+	}
+
+	var snapshot RunSnapshot
+	if err := yaml.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot of run %s: %w", operationID, err)
+	}
+	if snapshot.Issues == nil {
+		snapshot.Issues = make(map[string]SnapshotIssue)
+	}
+	return &snapshot, nil
+}
+
+// PruneRunSnapshots removes the snapshots of runs no longer in the state's history
+// History keeps the most recent MaxHistoryEntries runs, so snapshots are kept for as long as
+// their run can be looked up.
+func PruneRunSnapshots(repoPath string, history []SyncOperation) error {
+	if len(history) > MaxHistoryEntries {
+		history = history[len(history)-MaxHistoryEntries:]
+	}
+	recorded := make(map[string]bool, len(history))
+	for _, operation := range history {
+		recorded[operation.ID] = true
+	}
+
+	snapshotFiles, err := filepath.Glob(filepath.Join(repoPath, RunSnapshotDir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to list run snapshots: %w", err)
+	}
+	for _, snapshotFile := range snapshotFiles {
+		if recorded[strings.TrimSuffix(filepath.Base(snapshotFile), ".yaml")] {
+			continue
+		}
+		if err := os.Remove(snapshotFile); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove run snapshot %s: %w", snapshotFile, err)
+		}
+	}
+	return nil
+}
+
+// IssueStatusChange is an issue whose status differs between two runs
+type IssueStatusChange struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// SnapshotIssueEntry is an issue present in only one of two runs
+type SnapshotIssueEntry struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+}
+
+// RunDelta is the difference between the mirrored issues of two sync runs
+// Issues whose status changed are not also listed as updated.
+type RunDelta struct {
+	Appeared      []SnapshotIssueEntry `json:"appeared"`
+	Disappeared   []SnapshotIssueEntry `json:"disappeared"`
+	StatusChanged []IssueStatusChange  `json:"status_changed"`
+	Updated       []string             `json:"updated"`
+}
+
+// CompareRunSnapshots reports the issues that appeared, disappeared, changed status, or were
+// otherwise updated between two runs, each sorted by issue key
+func CompareRunSnapshots(from, to *RunSnapshot) *RunDelta {
+	delta := &RunDelta{
+		Appeared:      []SnapshotIssueEntry{},
+		Disappeared:   []SnapshotIssueEntry{},
+		StatusChanged: []IssueStatusChange{},
+		Updated:       []string{},
+	}
+
+	for _, key := range sortedSnapshotKeys(to.Issues) {
+		after := to.Issues[key]
+		before, existed := from.Issues[key]
+		switch {
+		case !existed:
+			delta.Appeared = append(delta.Appeared, SnapshotIssueEntry{Key: key, Summary: after.Summary, Status: after.Status})
+		case before.Status != after.Status:
+			delta.StatusChanged = append(delta.StatusChanged, IssueStatusChange{Key: key, Summary: after.Summary, From: before.Status, To: after.Status})
+		case before != after:
+			delta.Updated = append(delta.Updated, key)
+		}
+	}
+	for _, key := range sortedSnapshotKeys(from.Issues) {
+		if _, exists := to.Issues[key]; !exists {
+			before := from.Issues[key]
+			delta.Disappeared = append(delta.Disappeared, SnapshotIssueEntry{Key: key, Summary: before.Summary, Status: before.Status})
+		}
+	}
+	return delta
+}
+
+// sortedSnapshotKeys returns the issue keys of a snapshot in order
+func sortedSnapshotKeys(issues map[string]SnapshotIssue) []string {
+	keys := make([]string, 0, len(issues))
+	for key := range issues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
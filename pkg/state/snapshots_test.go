@@ -0,0 +1,81 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeSnapshotIssue(t *testing.T, repoPath, key, content string) {
+	t.Helper()
+	dir := filepath.Join(repoPath, "projects", extractProjectKey(key), "issues")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, key+".yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunSnapshots_RoundTripAndPrune(t *testing.T) {
+	repoPath := t.TempDir()
+	writeSnapshotIssue(t, repoPath, "PROJ-1", "key: PROJ-1\nsummary: Login fails\nstatus:\n  name: To Do\nupdated: \"2024-01-10T09:00:00Z\"\n")
+	writeSnapshotIssue(t, repoPath, "PROJ-2", "key: PROJ-2\nsummary: Add export\nstatus:\n  name: Done\n")
+
+	snapshot, err := BuildRunSnapshot(repoPath, "sync-1")
+	if err != nil {
+		t.Fatalf("BuildRunSnapshot() error = %v", err)
+	}
+	if snapshot.IssueCount != 2 || snapshot.Issues["PROJ-1"].Status != "To Do" {
+		t.Fatalf("Unexpected snapshot: %+v", snapshot)
+	}
+	if err := SaveRunSnapshot(repoPath, snapshot); err != nil {
+		t.Fatalf("SaveRunSnapshot() error = %v", err)
+	}
+	loaded, err := LoadRunSnapshot(repoPath, "sync-1")
+	if err != nil {
+		t.Fatalf("LoadRunSnapshot() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Issues, snapshot.Issues) {
+		t.Errorf("Loaded issues = %+v, want %+v", loaded.Issues, snapshot.Issues)
+	}
+
+	if _, err := LoadRunSnapshot(repoPath, "../.jira-sync-state"); err == nil {
+		t.Error("Expected run IDs outside the snapshot directory to be rejected")
+	}
+
+	// Snapshots of runs that are no longer in the history are removed
+	if err := PruneRunSnapshots(repoPath, []SyncOperation{{ID: "sync-2"}}); err != nil {
+		t.Fatalf("PruneRunSnapshots() error = %v", err)
+	}
+	if _, err := LoadRunSnapshot(repoPath, "sync-1"); err == nil {
+		t.Error("Expected the snapshot of a pruned run to be removed")
+	}
+}
+
+func TestCompareRunSnapshots(t *testing.T) {
+	from := &RunSnapshot{Issues: map[string]SnapshotIssue{
+		"PROJ-1": {Summary: "Login fails", Status: "To Do"},
+		"PROJ-2": {Summary: "Add export", Status: "Done"},
+		"PROJ-3": {Summary: "Old task", Status: "Done", Updated: "2024-01-01"},
+		"PROJ-5": {Summary: "Same", Status: "To Do"},
+	}}
+	to := &RunSnapshot{Issues: map[string]SnapshotIssue{
+		"PROJ-1": {Summary: "Login fails on Safari", Status: "In Progress"},
+		"PROJ-3": {Summary: "Old task", Status: "Done", Updated: "2024-02-01"},
+		"PROJ-4": {Summary: "New bug", Status: "To Do"},
+		"PROJ-5": {Summary: "Same", Status: "To Do"},
+	}}
+
+	delta := CompareRunSnapshots(from, to)
+	want := &RunDelta{
+		Appeared:      []SnapshotIssueEntry{{Key: "PROJ-4", Summary: "New bug", Status: "To Do"}},
+		Disappeared:   []SnapshotIssueEntry{{Key: "PROJ-2", Summary: "Add export", Status: "Done"}},
+		StatusChanged: []IssueStatusChange{{Key: "PROJ-1", Summary: "Login fails on Safari", From: "To Do", To: "In Progress"}},
+		Updated:       []string{"PROJ-3"},
+	}
+	if !reflect.DeepEqual(delta, want) {
+		t.Errorf("CompareRunSnapshots() = %+v, want %+v", delta, want)
+	}
+}
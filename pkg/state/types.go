@@ -14,6 +14,9 @@ type SyncState struct {
 	Stats      SyncStatistics        `json:"stats" yaml:"stats"`
 	CreatedAt  time.Time             `json:"created_at" yaml:"created_at"`
 	UpdatedAt  time.Time             `json:"updated_at" yaml:"updated_at"`
+	// Revision increases with every save; a save is rejected when the file's revision moved
+	// on since this state was loaded (another sync saved first)
+	Revision int64 `json:"revision,omitempty" yaml:"revision,omitempty"`
 }
 
 // RepositoryInfo contains metadata about the target repository
@@ -97,6 +100,9 @@ type IssueState struct {
 	SyncStatus   string    `json:"sync_status" yaml:"sync_status"`
 	ErrorMessage string    `json:"error_message,omitempty" yaml:"error_message,omitempty"`
 	SyncCount    int       `json:"sync_count" yaml:"sync_count"`
+
+	// Fields holds the last-synced values of tracked fields; empty unless tracking is enabled
+	Fields map[string]FieldState `json:"fields,omitempty" yaml:"fields,omitempty"`
 }
 
 // SyncStatistics contains aggregate statistics for sync operations
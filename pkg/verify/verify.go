@@ -0,0 +1,576 @@
+package verify
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chambrid/jira-cdc-git/pkg/checksum"
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+	"gopkg.in/yaml.v3"
+)
+
+// Verifier defines the interface for repository integrity checks
+// This enables dependency injection and testing with mock implementations
+type Verifier interface {
+	Verify(repoPath string, options Options) (*Result, error)
+}
+
+// Options controls how verification is performed
+type Options struct {
+	// Fix repairs problems that can be fixed safely (broken links, schema drift, stale state)
+	Fix bool
+
+	// PublicKey verifies the detached signature of each checksum manifest (nil skips signatures)
+	PublicKey ed25519.PublicKey
+
+	// SigningKey re-signs the checksum manifests that Fix regenerates for re-stamped files
+	// (nil leaves them unsigned and removes their stale signatures)
+	SigningKey ed25519.PrivateKey
+
+	// Writer renders issue files the way the sync wrote them, so the sync's output options are
+	// not mistaken for schema drift and --fix re-stamps files with them (nil: the default writer)
+	Writer *schema.YAMLFileWriter
+
+	// Transformed marks issue files written through a sync transform, which only the transform
+	// can reproduce: they are checked to be valid YAML, never against the schema or re-stamped
+	Transformed bool
+}
+
+// issueWriter returns the writer that renders the expected content of issue files
+func (o Options) issueWriter() *schema.YAMLFileWriter {
+	if o.Writer == nil {
+		return &schema.YAMLFileWriter{}
+	}
+	return o.Writer
+}
+
+// ProblemType categorizes an integrity problem
+type ProblemType string
+
+const (
+	ProblemBrokenLink       ProblemType = "broken_link"
+	ProblemInvalidYAML      ProblemType = "invalid_yaml"
+	ProblemSchemaMismatch   ProblemType = "schema_mismatch"
+	ProblemKeyMismatch      ProblemType = "key_mismatch"
+	ProblemStateUnreadable  ProblemType = "state_unreadable"
+	ProblemStateMissingFile ProblemType = "state_missing_file"
+	ProblemChecksumMismatch ProblemType = "checksum_mismatch"
+	ProblemManifestMismatch ProblemType = "manifest_mismatch"
+	ProblemBadSignature     ProblemType = "bad_signature"
+)
+
+// Problem describes a single integrity problem found in the repository
+type Problem struct {
+	Type    ProblemType `json:"type" yaml:"type"`
+	Path    string      `json:"path" yaml:"path"`
+	Message string      `json:"message" yaml:"message"`
+	Fixable bool        `json:"fixable" yaml:"fixable"`
+	Fixed   bool        `json:"fixed" yaml:"fixed"`
+}
+
+// Result contains the outcome of a verification run
+type Result struct {
+	RepoPath            string    `json:"repo_path" yaml:"repo_path"`
+	IssueFilesChecked   int       `json:"issue_files_checked" yaml:"issue_files_checked"`
+	LinksChecked        int       `json:"links_checked" yaml:"links_checked"`
+	StateEntriesChecked int       `json:"state_entries_checked" yaml:"state_entries_checked"`
+	StatePresent        bool      `json:"state_present" yaml:"state_present"`
+	ManifestsChecked    int       `json:"manifests_checked" yaml:"manifests_checked"`
+	SignaturesChecked   int       `json:"signatures_checked" yaml:"signatures_checked"`
+	ManifestsRefreshed  []string  `json:"manifests_refreshed,omitempty" yaml:"manifests_refreshed,omitempty"`
+	Problems            []Problem `json:"problems" yaml:"problems"`
+}
+
+// Unrepaired returns the problems that remain after any fixes were applied
+func (r *Result) Unrepaired() []Problem {
+	var remaining []Problem
+	for _, problem := range r.Problems {
+		if !problem.Fixed {
+			remaining = append(remaining, problem)
+		}
+	}
+	return remaining
+}
+
+// FixedCount returns the number of problems that were repaired
+func (r *Result) FixedCount() int {
+	count := 0
+	for _, problem := range r.Problems {
+		if problem.Fixed {
+			count++
+		}
+	}
+	return count
+}
+
+// RepositoryVerifier checks a synced repository for internal consistency
+type RepositoryVerifier struct {
+	linkManager  links.LinkManager
+	stateManager state.StateManager
+}
+
+// NewRepositoryVerifier creates a new repository verifier
+func NewRepositoryVerifier(linkManager links.LinkManager, stateManager state.StateManager) Verifier {
+	return &RepositoryVerifier{
+		linkManager:  linkManager,
+		stateManager: stateManager,
+	}
+}
+
+// Verify runs all integrity checks against the repository
+// Checks run in dependency order: issue files are re-stamped first so that state
+// checksums can be refreshed for files the verifier itself rewrote.
+func (v *RepositoryVerifier) Verify(repoPath string, options Options) (*Result, error) {
+	if repoPath == "" {
+		return nil, fmt.Errorf("repository path cannot be empty")
+	}
+
+	info, err := os.Stat(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access repository %s: %w", repoPath, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("repository path is not a directory: %s", repoPath)
+	}
+
+	result := &Result{
+		RepoPath: repoPath,
+		Problems: make([]Problem, 0),
+	}
+
+	// Manifests are checked first, against the files as synced, before --fix re-stamps any
+	if err := v.verifyManifests(repoPath, options, result); err != nil {
+		return nil, err
+	}
+
+	restamped, err := v.verifyIssueFiles(repoPath, options, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.refreshManifests(repoPath, options, restamped, result); err != nil {
+		return nil, err
+	}
+
+	if err := v.verifyLinks(repoPath, options, result); err != nil {
+		return nil, err
+	}
+
+	if err := v.verifyState(repoPath, options, restamped, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// verifyIssueFiles checks every issue file parses as YAML and matches the current schema
+// Returns the set of files that were rewritten so state checksums can follow them.
+func (v *RepositoryVerifier) verifyIssueFiles(repoPath string, options Options, result *Result) (map[string]bool, error) {
+	restamped := make(map[string]bool)
+
+	issueFiles, err := filepath.Glob(filepath.Join(repoPath, "projects", "*", "issues", "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue files: %w", err)
+	}
+	sort.Strings(issueFiles)
+
+	for _, filePath := range issueFiles {
+		result.IssueFilesChecked++
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			result.Problems = append(result.Problems, Problem{
+				Type:    ProblemInvalidYAML,
+				Path:    filePath,
+				Message: fmt.Sprintf("cannot read file: %v", err),
+			})
+			continue
+		}
+
+		if options.Transformed {
+			var document yaml.Node
+			if err := yaml.Unmarshal(data, &document); err != nil {
+				result.Problems = append(result.Problems, Problem{
+					Type:    ProblemInvalidYAML,
+					Path:    filePath,
+					Message: fmt.Sprintf("file is not valid YAML: %v", err),
+				})
+			}
+			continue
+		}
+
+		issue, err := schema.FromYAML(data)
+		if err != nil {
+			result.Problems = append(result.Problems, Problem{
+				Type:    ProblemInvalidYAML,
+				Path:    filePath,
+				Message: fmt.Sprintf("file is not valid YAML: %v", err),
+			})
+			continue
+		}
+
+		expectedKey := strings.TrimSuffix(filepath.Base(filePath), ".yaml")
+		if issue.Key != expectedKey {
+			result.Problems = append(result.Problems, Problem{
+				Type:    ProblemKeyMismatch,
+				Path:    filePath,
+				Message: fmt.Sprintf("issue key '%s' does not match file name '%s'", issue.Key, expectedKey),
+			})
+			continue
+		}
+
+		// A file matches the schema when it decodes strictly and re-renders byte-for-byte; with
+		// merge updates the rendering is merged into the file, keeping its field order
+		canonical, err := options.issueWriter().RenderIssueUpdate(issue, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize issue %s: %w", issue.Key, err)
+		}
+
+		strictErr := decodeStrict(data)
+		if strictErr == nil && bytes.Equal(canonical, data) {
+			continue
+		}
+
+		message := "file does not match the current issue schema"
+		if strictErr != nil {
+			message = fmt.Sprintf("file contains fields outside the current issue schema: %v", strictErr)
+		}
+
+		problem := Problem{
+			Type:    ProblemSchemaMismatch,
+			Path:    filePath,
+			Message: message,
+			Fixable: true,
+		}
+
+		if options.Fix {
+			if err := os.WriteFile(filePath, canonical, 0644); err != nil {
+				problem.Message = fmt.Sprintf("%s (re-stamp failed: %v)", message, err)
+			} else {
+				problem.Fixed = true
+				restamped[filePath] = true
+			}
+		}
+
+		result.Problems = append(result.Problems, problem)
+	}
+
+	return restamped, nil
+}
+
+// verifyManifests checks each project's checksum manifest (if present) against its issue files
+// Mismatches are never fixable: a manifest exists to show tampering, which needs a human.
+func (v *RepositoryVerifier) verifyManifests(repoPath string, options Options, result *Result) error {
+	manifestPaths, err := filepath.Glob(filepath.Join(repoPath, "projects", "*", checksum.ManifestFileName))
+	if err != nil {
+		return fmt.Errorf("failed to list checksum manifests: %w", err)
+	}
+	sort.Strings(manifestPaths)
+
+	for _, manifestPath := range manifestPaths {
+		result.ManifestsChecked++
+		projectDir := filepath.Dir(manifestPath)
+
+		data, err := os.ReadFile(manifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to read checksum manifest %s: %w", manifestPath, err)
+		}
+
+		if options.PublicKey != nil {
+			result.SignaturesChecked++
+			signaturePath := filepath.Join(projectDir, checksum.SignatureFileName)
+			signature, readErr := os.ReadFile(signaturePath)
+			switch {
+			case readErr != nil:
+				result.Problems = append(result.Problems, Problem{
+					Type:    ProblemBadSignature,
+					Path:    signaturePath,
+					Message: "checksum manifest is not signed",
+				})
+			default:
+				if verifyErr := checksum.VerifySignature(options.PublicKey, data, signature); verifyErr != nil {
+					result.Problems = append(result.Problems, Problem{
+						Type:    ProblemBadSignature,
+						Path:    signaturePath,
+						Message: verifyErr.Error(),
+					})
+				}
+			}
+		}
+
+		entries, err := checksum.ParseManifest(data)
+		if err != nil {
+			result.Problems = append(result.Problems, Problem{
+				Type:    ProblemManifestMismatch,
+				Path:    manifestPath,
+				Message: fmt.Sprintf("manifest is malformed: %v", err),
+			})
+			continue
+		}
+
+		listed := make(map[string]bool, len(entries))
+		for _, entry := range entries {
+			filePath := filepath.Join(projectDir, filepath.FromSlash(entry.Path))
+			listed[filePath] = true
+
+			sum, err := state.CalculateFileChecksum(filePath)
+			if err != nil {
+				result.Problems = append(result.Problems, Problem{
+					Type:    ProblemManifestMismatch,
+					Path:    filePath,
+					Message: "file listed in the checksum manifest is missing",
+				})
+				continue
+			}
+			if sum != entry.Checksum {
+				result.Problems = append(result.Problems, Problem{
+					Type:    ProblemManifestMismatch,
+					Path:    filePath,
+					Message: "file does not match its checksum in the manifest",
+				})
+			}
+		}
+
+		issueFiles, err := filepath.Glob(filepath.Join(projectDir, "issues", "*.yaml"))
+		if err != nil {
+			return fmt.Errorf("failed to list issue files: %w", err)
+		}
+		sort.Strings(issueFiles)
+		for _, filePath := range issueFiles {
+			if !listed[filePath] {
+				result.Problems = append(result.Problems, Problem{
+					Type:    ProblemManifestMismatch,
+					Path:    filePath,
+					Message: "file is not listed in the checksum manifest",
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// refreshManifests regenerates the checksum manifests of projects whose files were re-stamped, so
+// the repairs of --fix do not read as tampering. Manifests that already showed problems before
+// the fix are left alone: regenerating them would hide those problems.
+func (v *RepositoryVerifier) refreshManifests(repoPath string, options Options, restamped map[string]bool, result *Result) error {
+	projectDirs := make(map[string]bool)
+	for filePath := range restamped {
+		projectDirs[filepath.Dir(filepath.Dir(filePath))] = true
+	}
+	for _, problem := range result.Problems {
+		if problem.Type == ProblemManifestMismatch || problem.Type == ProblemBadSignature {
+			for projectDir := range projectDirs {
+				if strings.HasPrefix(problem.Path, projectDir+string(filepath.Separator)) {
+					delete(projectDirs, projectDir)
+				}
+			}
+		}
+	}
+
+	projects := make([]string, 0, len(projectDirs))
+	for projectDir := range projectDirs {
+		if _, err := os.Stat(filepath.Join(projectDir, checksum.ManifestFileName)); err == nil {
+			projects = append(projects, filepath.Base(projectDir))
+		}
+	}
+	sort.Strings(projects)
+
+	for _, projectKey := range projects {
+		changed, err := checksum.WriteProjectManifest(repoPath, projectKey, options.SigningKey)
+		if err != nil {
+			return fmt.Errorf("failed to refresh checksum manifest for project %s: %w", projectKey, err)
+		}
+		result.ManifestsRefreshed = append(result.ManifestsRefreshed, changed...)
+	}
+	return nil
+}
+
+// verifyLinks checks every relationship symlink resolves to an existing issue file
+func (v *RepositoryVerifier) verifyLinks(repoPath string, options Options, result *Result) error {
+	projectDirs, err := filepath.Glob(filepath.Join(repoPath, "projects", "*"))
+	if err != nil {
+		return fmt.Errorf("failed to list project directories: %w", err)
+	}
+	sort.Strings(projectDirs)
+
+	for _, projectDir := range projectDirs {
+		relationshipsDir := filepath.Join(projectDir, "relationships")
+		if _, err := os.Stat(relationshipsDir); os.IsNotExist(err) {
+			continue
+		}
+
+		walkErr := filepath.Walk(relationshipsDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode()&os.ModeSymlink == 0 {
+				return nil
+			}
+
+			result.LinksChecked++
+
+			validateErr := v.linkManager.ValidateLink(path)
+			if validateErr == nil {
+				return nil
+			}
+
+			problem := Problem{
+				Type:    ProblemBrokenLink,
+				Path:    path,
+				Message: validateErr.Error(),
+			}
+
+			if linkErr, ok := validateErr.(*links.LinkError); ok && linkErr.Type == links.ErrorTypeBrokenLink {
+				problem.Fixable = true
+				if target, readErr := os.Readlink(path); readErr == nil {
+					problem.Message = fmt.Sprintf("relationship link target does not exist: %s", target)
+				}
+
+				if options.Fix {
+					if removeErr := os.Remove(path); removeErr != nil {
+						problem.Message = fmt.Sprintf("%s (prune failed: %v)", problem.Message, removeErr)
+					} else {
+						problem.Fixed = true
+					}
+				}
+			}
+
+			result.Problems = append(result.Problems, problem)
+			return nil
+		})
+		if walkErr != nil {
+			return fmt.Errorf("failed to scan relationships in %s: %w", projectDir, walkErr)
+		}
+	}
+
+	return nil
+}
+
+// verifyState checks the state file (if present) against on-disk content hashes
+func (v *RepositoryVerifier) verifyState(repoPath string, options Options, restamped map[string]bool, result *Result) error {
+	statePath := filepath.Join(repoPath, state.StateFileName)
+	if _, err := os.Stat(statePath); os.IsNotExist(err) {
+		return nil
+	}
+	result.StatePresent = true
+
+	syncState, err := v.stateManager.LoadState(repoPath)
+	if err != nil {
+		result.Problems = append(result.Problems, Problem{
+			Type:    ProblemStateUnreadable,
+			Path:    statePath,
+			Message: err.Error(),
+		})
+		return nil
+	}
+
+	issueKeys := make([]string, 0, len(syncState.Issues))
+	for issueKey := range syncState.Issues {
+		issueKeys = append(issueKeys, issueKey)
+	}
+	sort.Strings(issueKeys)
+
+	stateChanged := false
+	for _, issueKey := range issueKeys {
+		issueState := syncState.Issues[issueKey]
+		result.StateEntriesChecked++
+
+		filePath := resolveStatePath(repoPath, issueState)
+		fileInfo, statErr := os.Stat(filePath)
+		if statErr != nil {
+			problem := Problem{
+				Type:    ProblemStateMissingFile,
+				Path:    filePath,
+				Message: fmt.Sprintf("state tracks %s but its file is missing", issueKey),
+				Fixable: true,
+			}
+			if options.Fix {
+				delete(syncState.Issues, issueKey)
+				problem.Fixed = true
+				stateChanged = true
+			}
+			result.Problems = append(result.Problems, problem)
+			continue
+		}
+
+		checksum, err := state.CalculateFileChecksum(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to calculate checksum for %s: %w", filePath, err)
+		}
+		if checksum == issueState.Checksum {
+			continue
+		}
+
+		// Only checksums invalidated by our own re-stamp are safe to refresh;
+		// anything else means the file changed outside of sync and needs a human.
+		problem := Problem{
+			Type:    ProblemChecksumMismatch,
+			Path:    filePath,
+			Message: fmt.Sprintf("content hash for %s does not match state (file modified outside of sync)", issueKey),
+			Fixable: restamped[filePath],
+		}
+		if options.Fix && restamped[filePath] {
+			issueState.Checksum = checksum
+			issueState.FileSize = fileInfo.Size()
+			syncState.Issues[issueKey] = issueState
+			problem.Message = fmt.Sprintf("content hash for %s refreshed after schema re-stamp", issueKey)
+			problem.Fixed = true
+			stateChanged = true
+		}
+		result.Problems = append(result.Problems, problem)
+	}
+
+	if stateChanged {
+		if err := v.stateManager.SaveState(repoPath, syncState); err != nil {
+			return fmt.Errorf("failed to save repaired state: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// resolveStatePath maps a recorded file path onto the repository being verified
+// State stores the path as written during sync, which may be relative to the
+// directory the sync ran from rather than to the repository itself.
+func resolveStatePath(repoPath string, issueState state.IssueState) string {
+	if filepath.IsAbs(issueState.FilePath) {
+		return issueState.FilePath
+	}
+	if _, err := os.Stat(issueState.FilePath); err == nil {
+		return issueState.FilePath
+	}
+
+	projectKey := issueState.ProjectKey
+	if projectKey == "" {
+		projectKey = projectKeyFromIssueKey(issueState.Key)
+	}
+	return filepath.Join(repoPath, "projects", projectKey, "issues", issueState.Key+".yaml")
+}
+
+// decodeStrict decodes issue YAML rejecting fields the current schema does not know
+func decodeStrict(data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var issue client.Issue
+	return decoder.Decode(&issue)
+}
+
+// projectKeyFromIssueKey extracts the project key from a full issue key
+// Example: "MY-PROJECT-456" -> "MY-PROJECT"
+func projectKeyFromIssueKey(issueKey string) string {
+	parts := strings.Split(issueKey, "-")
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-1], "-")
+}
@@ -0,0 +1,455 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chambrid/jira-cdc-git/pkg/checksum"
+	"github.com/chambrid/jira-cdc-git/pkg/client"
+	"github.com/chambrid/jira-cdc-git/pkg/links"
+	"github.com/chambrid/jira-cdc-git/pkg/schema"
+	"github.com/chambrid/jira-cdc-git/pkg/state"
+)
+
+// setupSyncedRepo writes two linked issues and a matching state file
+func setupSyncedRepo(t *testing.T) (string, state.StateManager) {
+	t.Helper()
+
+	repoPath := t.TempDir()
+	writer := schema.NewYAMLFileWriter()
+	linkManager := links.NewSymbolicLinkManager()
+	stateManager := state.NewFileStateManager(state.FormatYAML)
+
+	syncState, err := stateManager.InitializeState(repoPath, state.RepositoryInfo{Path: repoPath, Branch: "main"})
+	if err != nil {
+		t.Fatalf("Failed to initialize state: %v", err)
+	}
+
+	issues := []*client.Issue{
+		{Key: "PROJ-1", Summary: "Epic", IssueType: "Epic", Updated: "2024-01-01T10:00:00.000+0000"},
+		{
+			Key:           "PROJ-2",
+			Summary:       "Story",
+			IssueType:     "Story",
+			Updated:       "2024-01-01T10:00:00.000+0000",
+			Relationships: &client.Relationships{EpicLink: "PROJ-1"},
+		},
+	}
+
+	for _, issue := range issues {
+		filePath, err := writer.WriteIssueToYAML(issue, repoPath)
+		if err != nil {
+			t.Fatalf("Failed to write issue %s: %v", issue.Key, err)
+		}
+		if err := linkManager.CreateRelationshipLinks(issue, repoPath); err != nil {
+			t.Fatalf("Failed to create links for %s: %v", issue.Key, err)
+		}
+		if err := stateManager.UpdateIssueState(syncState, issue, filePath); err != nil {
+			t.Fatalf("Failed to update state for %s: %v", issue.Key, err)
+		}
+	}
+
+	if err := stateManager.SaveState(repoPath, syncState); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+
+	return repoPath, stateManager
+}
+
+func countProblems(result *Result, problemType ProblemType) int {
+	count := 0
+	for _, problem := range result.Problems {
+		if problem.Type == problemType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestVerify_ConsistentRepository(t *testing.T) {
+	repoPath, stateManager := setupSyncedRepo(t)
+	verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), stateManager)
+
+	result, err := verifier.Verify(repoPath, Options{})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+
+	if len(result.Problems) != 0 {
+		t.Errorf("Expected no problems, got %+v", result.Problems)
+	}
+	if result.IssueFilesChecked != 2 {
+		t.Errorf("Expected 2 issue files checked, got %d", result.IssueFilesChecked)
+	}
+	if result.LinksChecked != 1 {
+		t.Errorf("Expected 1 link checked, got %d", result.LinksChecked)
+	}
+	if !result.StatePresent || result.StateEntriesChecked != 2 {
+		t.Errorf("Expected state with 2 entries, got present=%t entries=%d", result.StatePresent, result.StateEntriesChecked)
+	}
+}
+
+func TestVerify_BrokenLink(t *testing.T) {
+	repoPath, stateManager := setupSyncedRepo(t)
+	verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), stateManager)
+
+	brokenLink := filepath.Join(repoPath, "projects", "PROJ", "relationships", "epic", "PROJ-9")
+	if err := os.Symlink("../../issues/PROJ-404.yaml", brokenLink); err != nil {
+		t.Fatalf("Failed to create broken link: %v", err)
+	}
+
+	result, err := verifier.Verify(repoPath, Options{})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if countProblems(result, ProblemBrokenLink) != 1 {
+		t.Fatalf("Expected 1 broken link problem, got %+v", result.Problems)
+	}
+	if len(result.Unrepaired()) != 1 {
+		t.Errorf("Expected 1 unrepaired problem without --fix, got %d", len(result.Unrepaired()))
+	}
+
+	result, err = verifier.Verify(repoPath, Options{Fix: true})
+	if err != nil {
+		t.Fatalf("Verify with fix failed: %v", err)
+	}
+	if result.FixedCount() != 1 || len(result.Unrepaired()) != 0 {
+		t.Errorf("Expected broken link to be pruned, got %+v", result.Problems)
+	}
+	if _, err := os.Lstat(brokenLink); !os.IsNotExist(err) {
+		t.Error("Expected broken link to be removed")
+	}
+}
+
+func TestVerify_SchemaRestampRefreshesState(t *testing.T) {
+	repoPath, stateManager := setupSyncedRepo(t)
+	verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), stateManager)
+
+	// Simulate a file written by an older schema with an extra field
+	filePath := filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-1.yaml")
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read issue file: %v", err)
+	}
+	if err := os.WriteFile(filePath, append(data, []byte("legacy_field: true\n")...), 0644); err != nil {
+		t.Fatalf("Failed to write issue file: %v", err)
+	}
+
+	result, err := verifier.Verify(repoPath, Options{})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if countProblems(result, ProblemSchemaMismatch) != 1 {
+		t.Errorf("Expected 1 schema mismatch, got %+v", result.Problems)
+	}
+	if countProblems(result, ProblemChecksumMismatch) != 1 {
+		t.Errorf("Expected 1 checksum mismatch, got %+v", result.Problems)
+	}
+
+	result, err = verifier.Verify(repoPath, Options{Fix: true})
+	if err != nil {
+		t.Fatalf("Verify with fix failed: %v", err)
+	}
+	if len(result.Unrepaired()) != 0 {
+		t.Fatalf("Expected all problems repaired, got %+v", result.Unrepaired())
+	}
+
+	// A follow-up audit must come back clean
+	result, err = verifier.Verify(repoPath, Options{})
+	if err != nil {
+		t.Fatalf("Verify after fix failed: %v", err)
+	}
+	if len(result.Problems) != 0 {
+		t.Errorf("Expected clean repository after fix, got %+v", result.Problems)
+	}
+}
+
+func TestVerify_UnrepairableProblems(t *testing.T) {
+	repoPath, stateManager := setupSyncedRepo(t)
+	verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), stateManager)
+
+	// Content edited outside of sync: checksum cannot be safely re-stamped
+	filePath := filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-2.yaml")
+	issue := &client.Issue{
+		Key:           "PROJ-2",
+		Summary:       "Edited by hand",
+		IssueType:     "Story",
+		Updated:       time.Now().Format(time.RFC3339),
+		Relationships: &client.Relationships{EpicLink: "PROJ-1"},
+	}
+	data, err := schema.ToYAML(issue)
+	if err != nil {
+		t.Fatalf("Failed to serialize issue: %v", err)
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		t.Fatalf("Failed to write issue file: %v", err)
+	}
+
+	// Invalid YAML cannot be repaired either
+	invalidPath := filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-3.yaml")
+	if err := os.WriteFile(invalidPath, []byte("key: [unterminated"), 0644); err != nil {
+		t.Fatalf("Failed to write invalid file: %v", err)
+	}
+
+	result, err := verifier.Verify(repoPath, Options{Fix: true})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+
+	unrepaired := result.Unrepaired()
+	if len(unrepaired) != 2 {
+		t.Fatalf("Expected 2 unrepaired problems, got %+v", unrepaired)
+	}
+	if countProblems(result, ProblemChecksumMismatch) != 1 || countProblems(result, ProblemInvalidYAML) != 1 {
+		t.Errorf("Expected checksum and invalid YAML problems, got %+v", result.Problems)
+	}
+}
+
+func TestVerify_MissingStateFile(t *testing.T) {
+	repoPath, stateManager := setupSyncedRepo(t)
+	verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), stateManager)
+
+	if err := os.Remove(filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-2.yaml")); err != nil {
+		t.Fatalf("Failed to remove issue file: %v", err)
+	}
+
+	result, err := verifier.Verify(repoPath, Options{Fix: true})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if countProblems(result, ProblemStateMissingFile) != 1 {
+		t.Errorf("Expected missing file problem, got %+v", result.Problems)
+	}
+	if len(result.Unrepaired()) != 0 {
+		t.Errorf("Expected stale state entry to be pruned, got %+v", result.Unrepaired())
+	}
+
+	syncState, err := stateManager.LoadState(repoPath)
+	if err != nil {
+		t.Fatalf("Failed to reload state: %v", err)
+	}
+	if _, exists := syncState.Issues["PROJ-2"]; exists {
+		t.Error("Expected PROJ-2 to be removed from state")
+	}
+}
+
+func TestVerify_InvalidRepoPath(t *testing.T) {
+	verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), state.NewFileStateManager(state.FormatYAML))
+
+	if _, err := verifier.Verify("", Options{}); err == nil {
+		t.Error("Expected error for empty repository path")
+	}
+	if _, err := verifier.Verify(filepath.Join(t.TempDir(), "missing"), Options{}); err == nil {
+		t.Error("Expected error for missing repository path")
+	}
+}
+
+func TestVerify_ChecksumManifest(t *testing.T) {
+	repoPath, stateManager := setupSyncedRepo(t)
+	verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), stateManager)
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	if _, err := checksum.WriteProjectManifest(repoPath, "PROJ", privateKey); err != nil {
+		t.Fatalf("WriteProjectManifest() error = %v", err)
+	}
+
+	result, err := verifier.Verify(repoPath, Options{PublicKey: publicKey})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if len(result.Problems) != 0 || result.ManifestsChecked != 1 || result.SignaturesChecked != 1 {
+		t.Fatalf("Expected a clean signed manifest, got %+v", result)
+	}
+
+	// Tampering with an issue file is reported and never fixed
+	issuePath := filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-2.yaml")
+	if err := os.WriteFile(issuePath, []byte("key: PROJ-2\nsummary: Edited\n"), 0644); err != nil {
+		t.Fatalf("Failed to tamper with issue file: %v", err)
+	}
+	otherKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	result, err = verifier.Verify(repoPath, Options{Fix: true, PublicKey: otherKey})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if countProblems(result, ProblemManifestMismatch) != 1 || countProblems(result, ProblemBadSignature) != 1 {
+		t.Errorf("Expected a manifest mismatch and a bad signature, got %+v", result.Problems)
+	}
+	for _, problem := range result.Problems {
+		if (problem.Type == ProblemManifestMismatch || problem.Type == ProblemBadSignature) && problem.Fixed {
+			t.Errorf("Expected %s to stay unrepaired", problem.Type)
+		}
+	}
+}
+
+func TestVerify_WriterOptions(t *testing.T) {
+	issue := &client.Issue{
+		Key:       "PROJ-1",
+		Summary:   "Story",
+		IssueType: "Story",
+		Status:    client.Status{Name: "Open"},
+		Created:   "2024-01-01T12:00:00.000+0200",
+		Updated:   "2024-01-02T12:00:00.000+0200",
+		Description: `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[` +
+			`{"type":"text","text":"Rich text"}]}]}`,
+	}
+	tests := []struct {
+		name   string
+		writer *schema.YAMLFileWriter
+		// previous writes the file before writer updates it (nil: writer creates it)
+		previous *schema.YAMLFileWriter
+		// timestamps already in UTC are valid output of the default writer as well
+		defaultMatches bool
+	}{
+		{
+			name:   "field order",
+			writer: &schema.YAMLFileWriter{FieldOrder: []string{"summary", "status", "*"}},
+		},
+		{
+			name:   "raw ADF descriptions",
+			writer: &schema.YAMLFileWriter{ADFRender: schema.ADFRenderRaw},
+		},
+		{
+			name:   "excluded fields",
+			writer: &schema.YAMLFileWriter{Fields: mustFieldFilter(t, nil, []string{"created", "description"})},
+		},
+		{
+			name:   "minimal",
+			writer: &schema.YAMLFileWriter{Fields: mustFieldFilter(t, schema.MinimalFieldPatterns(false), nil)},
+		},
+		{
+			name:           "UTC timestamps",
+			writer:         &schema.YAMLFileWriter{Timestamps: schema.TimestampUTC},
+			defaultMatches: true,
+		},
+		{
+			name:     "merge update",
+			writer:   &schema.YAMLFileWriter{Merge: true},
+			previous: &schema.YAMLFileWriter{FieldOrder: []string{"updated", "*"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoPath := t.TempDir()
+			if tt.previous != nil {
+				if _, err := tt.previous.WriteIssueToYAML(issue, repoPath); err != nil {
+					t.Fatalf("Failed to write issue: %v", err)
+				}
+			}
+			filePath, err := tt.writer.WriteIssueToYAML(issue, repoPath)
+			if err != nil {
+				t.Fatalf("Failed to write issue: %v", err)
+			}
+			written, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read issue file: %v", err)
+			}
+
+			verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), state.NewFileStateManager(state.FormatYAML))
+			result, err := verifier.Verify(repoPath, Options{Fix: true, Writer: tt.writer})
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if len(result.Problems) != 0 {
+				t.Errorf("Expected a file written with the sync's options to verify, got %+v", result.Problems)
+			}
+			if data, _ := os.ReadFile(filePath); string(data) != string(written) {
+				t.Errorf("Expected --fix to leave the file alone, got:\n%s", data)
+			}
+
+			// The default writer does not expect these options
+			result, err = verifier.Verify(repoPath, Options{})
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if !tt.defaultMatches && countProblems(result, ProblemSchemaMismatch) != 1 {
+				t.Errorf("Expected the default writer to report a schema mismatch, got %+v", result.Problems)
+			}
+		})
+	}
+}
+
+func mustFieldFilter(t *testing.T, include, exclude []string) *schema.FieldFilter {
+	t.Helper()
+	filter, err := schema.NewFieldFilter(include, exclude)
+	if err != nil {
+		t.Fatalf("NewFieldFilter() error = %v", err)
+	}
+	return filter
+}
+
+func TestVerify_TransformedFiles(t *testing.T) {
+	repoPath := t.TempDir()
+	issuesDir := filepath.Join(repoPath, "projects", "PROJ", "issues")
+	if err := os.MkdirAll(issuesDir, 0755); err != nil {
+		t.Fatalf("Failed to create issues directory: %v", err)
+	}
+	transformed := []byte("id: PROJ-1\ntitle: Story\nlabels: [team-a]\n")
+	if err := os.WriteFile(filepath.Join(issuesDir, "PROJ-1.yaml"), transformed, 0644); err != nil {
+		t.Fatalf("Failed to write issue file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(issuesDir, "PROJ-2.yaml"), []byte("title: [unclosed\n"), 0644); err != nil {
+		t.Fatalf("Failed to write issue file: %v", err)
+	}
+
+	verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), state.NewFileStateManager(state.FormatYAML))
+	result, err := verifier.Verify(repoPath, Options{Fix: true, Transformed: true})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if len(result.Problems) != 1 || countProblems(result, ProblemInvalidYAML) != 1 {
+		t.Errorf("Expected only the invalid YAML file to be reported, got %+v", result.Problems)
+	}
+	if data, _ := os.ReadFile(filepath.Join(issuesDir, "PROJ-1.yaml")); string(data) != string(transformed) {
+		t.Errorf("Expected --fix to leave transformed files alone, got:\n%s", data)
+	}
+}
+
+func TestVerify_FixRefreshesChecksumManifest(t *testing.T) {
+	for _, resign := range []bool{true, false} {
+		repoPath, stateManager := setupSyncedRepo(t)
+		verifier := NewRepositoryVerifier(links.NewSymbolicLinkManager(), stateManager)
+		publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+
+		// A file synced by an older schema, listed in a signed manifest
+		filePath := filepath.Join(repoPath, "projects", "PROJ", "issues", "PROJ-1.yaml")
+		data, _ := os.ReadFile(filePath)
+		if err := os.WriteFile(filePath, append(data, []byte("legacy_field: true\n")...), 0644); err != nil {
+			t.Fatalf("Failed to write issue file: %v", err)
+		}
+		if _, err := checksum.WriteProjectManifest(repoPath, "PROJ", privateKey); err != nil {
+			t.Fatalf("WriteProjectManifest() error = %v", err)
+		}
+
+		options := Options{Fix: true}
+		if resign {
+			options.SigningKey = privateKey
+		}
+		result, err := verifier.Verify(repoPath, options)
+		if err != nil {
+			t.Fatalf("Verify failed: %v", err)
+		}
+		if result.FixedCount() == 0 || len(result.ManifestsRefreshed) != 2 {
+			t.Fatalf("Expected the re-stamp to refresh the manifest and its signature, got %+v", result)
+		}
+
+		signaturePath := checksum.GetSignaturePath(repoPath, "PROJ")
+		if resign {
+			result, err = verifier.Verify(repoPath, Options{PublicKey: publicKey})
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if len(result.Problems) != 0 {
+				t.Errorf("Expected a clean re-signed manifest after the fix, got %+v", result.Problems)
+			}
+		} else if _, err := os.Stat(signaturePath); !os.IsNotExist(err) {
+			t.Errorf("Expected the stale signature to be removed, got %v", err)
+		}
+	}
+}